@@ -7,18 +7,11 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
-	"github.com/contest-maker-150/backend/internal/data"
-	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/app"
 	"github.com/contest-maker-150/backend/internal/infrastructure"
-	"github.com/contest-maker-150/backend/internal/middleware"
-	"github.com/contest-maker-150/backend/internal/repository"
-	"github.com/contest-maker-150/backend/internal/service"
 )
 
 func main() {
@@ -26,7 +19,7 @@ func main() {
 	config := infrastructure.LoadConfig()
 
 	// Initialize logger
-	logger, err := infrastructure.NewLogger(config.Server.Environment)
+	logger, logLevel, err := infrastructure.NewLogger(config.Server.Environment, &config.Logging)
 	if err != nil {
 		fmt.Printf("Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -43,13 +36,14 @@ func main() {
 	defer cancel()
 
 	// Initialize telemetry
-	telemetry, err := infrastructure.NewTelemetry(ctx, &config.Telemetry, logger)
+	telemetry, err := infrastructure.NewTelemetry(ctx, &config.Telemetry, &config.Resilience, logger)
 	if err != nil {
 		logger.Error("Failed to initialize telemetry", zap.Error(err))
 		os.Exit(1)
 	}
 	defer func() {
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		// Runs last, so spans and metrics recorded while draining are flushed too
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.Shutdown.TelemetryTimeout)
 		defer shutdownCancel()
 		telemetry.Shutdown(shutdownCtx)
 	}()
@@ -69,116 +63,56 @@ func main() {
 	}
 	defer database.Close()
 
-	// Run migrations
-	if err := database.AutoMigrate(); err != nil {
-		logger.Error("Failed to run migrations", zap.Error(err))
+	// Trace queries and export their durations and connection pool statistics
+	if err := infrastructure.InstrumentQueries(database.DB, telemetry.Tracer, metrics.DBQueryDuration); err != nil {
+		logger.Error("Failed to instrument database queries", zap.Error(err))
 		os.Exit(1)
 	}
-
-	// Seed problems
-	seeder := data.NewSeeder(database.DB, logger)
-	if err := seeder.SeedProblems(); err != nil {
-		logger.Error("Failed to seed problems", zap.Error(err))
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		logger.Error("Failed to access database pool", zap.Error(err))
 		os.Exit(1)
 	}
-
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(database.DB)
-	problemRepo := repository.NewProblemRepository(database.DB)
-	contestRepo := repository.NewContestRepository(database.DB)
-	submissionRepo := repository.NewSubmissionRepository(database.DB)
-
-	// Initialize services
-	userService := service.NewUserService(userRepo, submissionRepo, &config.JWT, telemetry.Tracer, logger)
-	problemService := service.NewProblemService(problemRepo, userRepo, telemetry.Tracer, logger)
-	contestService := service.NewContestService(contestRepo, problemService, submissionRepo, telemetry.Tracer, logger)
-
-	// Initialize handlers
-	authHandler := handler.NewAuthHandler(userService)
-	userHandler := handler.NewUserHandler(userService)
-	problemHandler := handler.NewProblemHandler(problemService)
-	contestHandler := handler.NewContestHandler(contestService)
-
-	// Setup Gin router
-	if config.Server.Environment == "production" {
-		gin.SetMode(gin.ReleaseMode)
+	dbStats, err := infrastructure.NewDBStatsExporter(telemetry.Meter, sqlDB, config.Telemetry.DBStatsInterval, logger)
+	if err != nil {
+		logger.Error("Failed to create database pool metrics", zap.Error(err))
+		os.Exit(1)
 	}
-
-	router := gin.New()
-
-	// Add global middleware
-	router.Use(middleware.RecoveryMiddleware(logger))
-	router.Use(middleware.LoggingMiddleware(logger))
-	router.Use(middleware.CORSMiddleware(middleware.DefaultCORSConfig()))
-	router.Use(middleware.TracingMiddleware(telemetry.Tracer))
-	router.Use(middleware.MetricsMiddleware(metrics))
-
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		if err := database.HealthCheck(c.Request.Context()); err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status": "unhealthy",
-				"error":  "database connection failed",
-			})
-			return
+	dbStats.Start(ctx)
+	defer dbStats.Stop()
+
+	// Route lag-tolerant reads to the read replicas that keep up with the primary
+	if database.Replicas != nil {
+		if err := infrastructure.InstrumentQueries(database.Reader, telemetry.Tracer, metrics.DBQueryDuration); err != nil {
+			logger.Error("Failed to instrument read replica queries", zap.Error(err))
+			os.Exit(1)
 		}
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "healthy",
-			"version": config.Telemetry.ServiceVersion,
-		})
-	})
-
-	// Metrics endpoint for Prometheus
-	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
-
-	// API routes
-	api := router.Group("/api")
-	{
-		// Auth routes (public)
-		auth := api.Group("/auth")
-		{
-			auth.POST("/signup", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/refresh", authHandler.Refresh)
+		if err := database.Replicas.RegisterMetrics(telemetry.Meter); err != nil {
+			logger.Error("Failed to create read replica metrics", zap.Error(err))
+			os.Exit(1)
 		}
+		database.Replicas.Start(ctx)
+		defer database.Replicas.Stop(context.Background())
+	}
 
-		// Problem routes (public for listing, protected for some features)
-		problems := api.Group("/problems")
-		{
-			problems.GET("", problemHandler.GetProblems)
-			problems.GET("/stats", problemHandler.GetProblemStats)
-			problems.GET("/:id", problemHandler.GetProblem)
-		}
+	// Run migrations and seed the problem catalog
+	if err := app.PrepareDatabase(database, logger); err != nil {
+		logger.Error("Failed to prepare database", zap.Error(err))
+		os.Exit(1)
+	}
 
-		// Protected routes
-		protected := api.Group("")
-		protected.Use(middleware.AuthMiddleware(userService))
-		{
-			// User routes
-			users := protected.Group("/users")
-			{
-				users.GET("/me", userHandler.GetCurrentUser)
-				users.GET("/me/progress", userHandler.GetUserProgress)
-			}
-
-			// Contest routes
-			contests := protected.Group("/contests")
-			{
-				contests.POST("", contestHandler.CreateContest)
-				contests.GET("", contestHandler.GetContests)
-				contests.GET("/active", contestHandler.GetActiveContest)
-				contests.GET("/:id", contestHandler.GetContest)
-				contests.PATCH("/:id/problems/:problemId", contestHandler.MarkProblemComplete)
-				contests.POST("/:id/complete", contestHandler.CompleteContest)
-				contests.POST("/:id/abandon", contestHandler.AbandonContest)
-			}
-		}
+	// Assemble the API and start its background workers
+	api, err := app.New(config, database, telemetry, metrics, logger, logLevel)
+	if err != nil {
+		logger.Error("Failed to initialize API", zap.Error(err))
+		os.Exit(1)
 	}
+	api.Start(ctx)
 
 	// Create HTTP server
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port),
-		Handler:      router,
+		Handler:      api.Router,
 		ReadTimeout:  config.Server.ReadTimeout,
 		WriteTimeout: config.Server.WriteTimeout,
 	}
@@ -200,12 +134,18 @@ func main() {
 
 	logger.Info("Shutting down server...")
 
-	// Graceful shutdown with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
+	// Stop accepting connections and wait for in-flight requests
+	httpCtx, httpCancel := context.WithTimeout(context.Background(), config.Shutdown.HTTPTimeout)
+	defer httpCancel()
+	if err := server.Shutdown(httpCtx); err != nil {
+		logger.Error("In-flight requests did not finish, closing connections", zap.Error(err))
+		server.Close()
+	}
 
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.Error("Server forced to shutdown", zap.Error(err))
+	// Stop background workers and drain events before the database closes;
+	// each component has its own timeout
+	if err := api.Stop(context.Background()); err != nil {
+		logger.Error("Background components did not shut down cleanly", zap.Error(err))
 	}
 
 	logger.Info("Server exited")
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/contest-maker-150/backend/internal/openapi"
+)
+
+const goHeader = "// Code generated by backend/cmd/clientgen from backend/api/openapi.json. DO NOT EDIT.\n\npackage contestmaker\n\n"
+
+// goTypes renders types_gen.go
+func goTypes(m *model) ([]byte, error) {
+	var b bytes.Buffer
+	for _, t := range m.Types {
+		fmt.Fprintf(&b, "\n// %s %s\ntype %s struct {\n", t.Name, t.Doc, t.Name)
+		for _, f := range t.Fields {
+			tag := f.JSON
+			if t.Input && !f.Required {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", exportedName(f.JSON), goType(f.Schema), tag)
+		}
+		b.WriteString("}\n")
+	}
+	return goFile(b.Bytes())
+}
+
+// goOperations renders operations_gen.go
+func goOperations(m *model) ([]byte, error) {
+	var b bytes.Buffer
+	for _, o := range m.Operations {
+		if len(o.Query) > 0 {
+			writeGoParams(&b, o)
+		}
+
+		args := []string{"ctx context.Context"}
+		for _, p := range o.PathParams {
+			args = append(args, localName(p)+" string")
+		}
+		if o.Body != nil {
+			args = append(args, "body *"+goType(o.Body))
+		}
+		if len(o.Query) > 0 {
+			args = append(args, "params *"+o.Name+"Params")
+		}
+		result := goType(o.Response)
+		pointer := o.Response.Ref != ""
+
+		fmt.Fprintf(&b, "\n// %s calls %s %s: %s\n", o.Name, o.Method, o.Path, o.Summary)
+		if o.Deprecated {
+			if o.Sunset != "" {
+				fmt.Fprintf(&b, "//\n// Deprecated: the operation will be removed on %s.\n", o.Sunset)
+			} else {
+				b.WriteString("//\n// Deprecated: the operation will be removed.\n")
+			}
+		}
+		if pointer {
+			fmt.Fprintf(&b, "func (c *Client) %s(%s) (*%s, error) {\n", o.Name, strings.Join(args, ", "), result)
+		} else {
+			fmt.Fprintf(&b, "func (c *Client) %s(%s) (%s, error) {\n", o.Name, strings.Join(args, ", "), result)
+		}
+
+		fmt.Fprintf(&b, "\treq := request{method: http.Method%s, path: %s, auth: %t}\n", methodConst(o.Method), goPath(o), o.Auth)
+		if o.Body != nil {
+			b.WriteString("\treq.body = body\n")
+		}
+		if len(o.Query) > 0 {
+			b.WriteString("\tif params != nil {\n\t\treq.query = params.values()\n\t}\n")
+		}
+		fmt.Fprintf(&b, "\tvar out %s\n", result)
+		b.WriteString("\tif err := c.do(ctx, req, &out); err != nil {\n")
+		if pointer {
+			b.WriteString("\t\treturn nil, err\n\t}\n\treturn &out, nil\n}\n")
+		} else {
+			b.WriteString("\t\treturn out, err\n\t}\n\treturn out, nil\n}\n")
+		}
+	}
+	return goFile(b.Bytes())
+}
+
+// goFile adds the header and the standard library imports the body uses
+func goFile(body []byte) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(goHeader)
+	b.WriteString("import (\n")
+	for _, pkg := range []string{"context", "net/http", "net/url", "strconv", "time"} {
+		name := pkg[strings.LastIndex(pkg, "/")+1:]
+		if bytes.Contains(body, []byte(name+".")) {
+			fmt.Fprintf(&b, "\t%q\n", pkg)
+		}
+	}
+	b.WriteString(")\n")
+	b.Write(body)
+	return format.Source(b.Bytes())
+}
+
+func writeGoParams(b *bytes.Buffer, o *operation) {
+	fmt.Fprintf(b, "\n// %sParams holds the optional query parameters of %s; zero values are omitted\ntype %sParams struct {\n", o.Name, o.Name, o.Name)
+	for _, p := range o.Query {
+		if p.Description != "" {
+			fmt.Fprintf(b, "\t// %s\n", p.Description)
+		}
+		fmt.Fprintf(b, "\t%s %s\n", exportedName(p.Name), goType(p.Schema))
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "func (p *%sParams) values() url.Values {\n\tq := url.Values{}\n", o.Name)
+	for _, p := range o.Query {
+		name := exportedName(p.Name)
+		switch goType(p.Schema) {
+		case "int", "int64":
+			fmt.Fprintf(b, "\tif p.%s != 0 {\n\t\tq.Set(%q, strconv.FormatInt(int64(p.%s), 10))\n\t}\n", name, p.Name, name)
+		case "bool":
+			fmt.Fprintf(b, "\tif p.%s {\n\t\tq.Set(%q, \"true\")\n\t}\n", name, p.Name)
+		case "[]string":
+			fmt.Fprintf(b, "\tfor _, v := range p.%s {\n\t\tq.Add(%q, v)\n\t}\n", name, p.Name)
+		default:
+			fmt.Fprintf(b, "\tif p.%s != \"\" {\n\t\tq.Set(%q, p.%s)\n\t}\n", name, p.Name, name)
+		}
+	}
+	b.WriteString("\treturn q\n}\n")
+}
+
+// goPath renders the request path, escaping each path parameter
+func goPath(o *operation) string {
+	parts := strings.Split(o.Path, "/")
+	var exprs []string
+	literal := ""
+	for i, part := range parts {
+		if i > 0 {
+			literal += "/"
+		}
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			exprs = append(exprs, fmt.Sprintf("%q", literal), "url.PathEscape("+localName(strings.Trim(part, "{}"))+")")
+			literal = ""
+			continue
+		}
+		literal += part
+	}
+	if literal != "" {
+		exprs = append(exprs, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(exprs, " + ")
+}
+
+func methodConst(method string) string {
+	return method[:1] + strings.ToLower(method[1:])
+}
+
+// goType maps a schema to a Go type expression
+func goType(s *openapi.Schema) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		return refName(s)
+	}
+
+	var t string
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			t = "time.Time"
+		case "byte":
+			return "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		t = "int"
+		if s.Format == "int64" {
+			t = "int64"
+		}
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + goType(s.AdditionalProperties)
+		}
+		return "map[string]any"
+	default:
+		return "any"
+	}
+	if s.Nullable {
+		return "*" + t
+	}
+	return t
+}
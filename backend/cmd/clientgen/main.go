@@ -0,0 +1,66 @@
+// Command clientgen generates the Go and TypeScript API clients under /clients
+// from the OpenAPI specification. Run via `go generate ./...` after the spec is
+// regenerated; pass -check in CI to fail when a client is out of date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "api/openapi.json", "path of the OpenAPI specification")
+	goDir := flag.String("go", "../clients/go", "directory of the Go client package")
+	tsDir := flag.String("ts", "../clients/typescript/src", "source directory of the TypeScript client")
+	check := flag.Bool("check", false, "verify the generated files are up to date instead of writing them")
+	flag.Parse()
+
+	m, err := loadModel(*specPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", *specPath, err)
+		os.Exit(1)
+	}
+	for _, op := range m.Skipped {
+		fmt.Fprintf(os.Stderr, "Skipping %s: no JSON response\n", op)
+	}
+
+	types, err := goTypes(m)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render Go types: %v\n", err)
+		os.Exit(1)
+	}
+	operations, err := goOperations(m)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render Go operations: %v\n", err)
+		os.Exit(1)
+	}
+
+	files := map[string][]byte{
+		filepath.Join(*goDir, "types_gen.go"):      types,
+		filepath.Join(*goDir, "operations_gen.go"): operations,
+		filepath.Join(*tsDir, "types.ts"):          tsTypes(m),
+		filepath.Join(*tsDir, "client.ts"):         tsClient(m),
+	}
+
+	failed := false
+	for path, content := range files {
+		if *check {
+			existing, err := os.ReadFile(path)
+			if err != nil || !bytes.Equal(existing, content) {
+				fmt.Fprintf(os.Stderr, "%s is out of date; run go generate ./...\n", path)
+				failed = true
+			}
+			continue
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", path, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/contest-maker-150/backend/internal/openapi"
+)
+
+const refPrefix = "#/components/schemas/"
+
+// spec is the subset of the OpenAPI document the generator reads
+type spec struct {
+	Paths      map[string]map[string]specOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]*openapi.Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type specOperation struct {
+	Summary     string `json:"summary"`
+	OperationID string `json:"operationId"`
+	Parameters  []struct {
+		Name        string          `json:"name"`
+		In          string          `json:"in"`
+		Description string          `json:"description"`
+		Required    bool            `json:"required"`
+		Schema      *openapi.Schema `json:"schema"`
+	} `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *openapi.Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *openapi.Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+	Security   []map[string][]string `json:"security"`
+	Deprecated bool                  `json:"deprecated"`
+	Sunset     string                `json:"x-sunset"`
+}
+
+// typeDef is a named object type shared by both clients
+type typeDef struct {
+	Name   string
+	Doc    string // Completes "<Name> ..." in the Go doc comment
+	Fields []field
+	Input  bool // Sent in a request body; fields not marked required may be omitted
+}
+
+type field struct {
+	JSON     string
+	Schema   *openapi.Schema
+	Required bool
+}
+
+type param struct {
+	Name        string
+	Description string
+	Schema      *openapi.Schema
+}
+
+// operation is one API call. Name is the operation ID without the "Api" prefix
+// segment, e.g. GetContestsID for GET /api/contests/{id}.
+type operation struct {
+	Name       string
+	Method     string
+	Path       string // OpenAPI path template, e.g. /api/contests/{id}
+	Summary    string
+	Auth       bool
+	PathParams []string
+	Query      []param
+	Body       *openapi.Schema
+	Response   *openapi.Schema
+	Deprecated bool
+	Sunset     string
+}
+
+// model is the language-neutral view of the spec both emitters render
+type model struct {
+	Types      []*typeDef
+	Operations []*operation
+	Skipped    []string // Operations without a JSON response, e.g. the HTML docs page
+}
+
+func loadModel(path string) (*model, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc spec
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	m := &model{}
+	types := make(map[string]*typeDef)
+	for name, schema := range doc.Components.Schemas {
+		types[name] = newTypeDef(name, schema)
+	}
+	hoist := func(name string, schema *openapi.Schema) *openapi.Schema {
+		return hoistInline(types, name, schema)
+	}
+	for _, t := range sortedTypes(types) {
+		for i := range t.Fields {
+			t.Fields[i].Schema = hoist(t.Name+exportedName(t.Fields[i].JSON), t.Fields[i].Schema)
+		}
+	}
+
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			method = strings.ToUpper(method)
+			schema, ok := jsonResponse(op)
+			if !ok {
+				m.Skipped = append(m.Skipped, method+" "+path)
+				continue
+			}
+
+			o := &operation{
+				Name:       operationName(op.OperationID),
+				Method:     method,
+				Path:       path,
+				Summary:    op.Summary,
+				Auth:       len(op.Security) > 0,
+				Deprecated: op.Deprecated,
+				Sunset:     op.Sunset,
+			}
+			for _, p := range op.Parameters {
+				switch p.In {
+				case "path":
+					o.PathParams = append(o.PathParams, p.Name)
+				case "query":
+					o.Query = append(o.Query, param{Name: p.Name, Description: p.Description, Schema: p.Schema})
+				}
+			}
+			if op.RequestBody != nil {
+				o.Body = hoist(o.Name+"Request", op.RequestBody.Content["application/json"].Schema)
+			}
+			o.Response = hoist(o.Name+"Response", schema)
+			m.Operations = append(m.Operations, o)
+		}
+	}
+
+	sort.Slice(m.Operations, func(i, j int) bool {
+		if m.Operations[i].Path != m.Operations[j].Path {
+			return m.Operations[i].Path < m.Operations[j].Path
+		}
+		return m.Operations[i].Method < m.Operations[j].Method
+	})
+	sort.Strings(m.Skipped)
+
+	for _, o := range m.Operations {
+		if o.Body != nil {
+			markInput(types, o.Body)
+		}
+		if t := types[o.Name+"Response"]; t != nil {
+			t.Doc = "is the response body of " + o.Name
+		}
+		if t := types[o.Name+"Request"]; t != nil {
+			t.Doc = "is the request body of " + o.Name
+		}
+	}
+	if t := types["MessageResponse"]; t != nil {
+		t.Doc = "confirms an operation that has no other result"
+	}
+	m.Types = sortedTypes(types)
+	return m, nil
+}
+
+func newTypeDef(name string, schema *openapi.Schema) *typeDef {
+	required := make(map[string]bool, len(schema.Required))
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+	t := &typeDef{Name: name, Doc: "is the " + name + " schema of the API"}
+	for prop, s := range schema.Properties {
+		t.Fields = append(t.Fields, field{JSON: prop, Schema: s, Required: required[prop]})
+	}
+	sort.Slice(t.Fields, func(i, j int) bool { return t.Fields[i].JSON < t.Fields[j].JSON })
+	return t
+}
+
+// hoistInline turns inline object schemas into named types so both clients can
+// refer to them. Every {"message": string} acknowledgement shares MessageResponse.
+func hoistInline(types map[string]*typeDef, name string, schema *openapi.Schema) *openapi.Schema {
+	if schema == nil {
+		return nil
+	}
+	switch {
+	case schema.Type == "array" && schema.Items != nil:
+		items := hoistInline(types, name+"Item", schema.Items)
+		return &openapi.Schema{Type: "array", Items: items, Nullable: schema.Nullable}
+	case schema.Type == "object" && len(schema.Properties) > 0:
+		if isMessageOnly(schema) {
+			name = "MessageResponse"
+		}
+		if _, ok := types[name]; !ok {
+			t := newTypeDef(name, schema)
+			types[name] = t
+			for i := range t.Fields {
+				t.Fields[i].Schema = hoistInline(types, name+exportedName(t.Fields[i].JSON), t.Fields[i].Schema)
+			}
+		}
+		return &openapi.Schema{Ref: refPrefix + name}
+	}
+	return schema
+}
+
+func isMessageOnly(schema *openapi.Schema) bool {
+	message, ok := schema.Properties["message"]
+	return ok && len(schema.Properties) == 1 && message.Type == "string"
+}
+
+// markInput flags types reachable from a request body
+func markInput(types map[string]*typeDef, schema *openapi.Schema) {
+	switch {
+	case schema.Ref != "":
+		t := types[strings.TrimPrefix(schema.Ref, refPrefix)]
+		if t == nil || t.Input {
+			return
+		}
+		t.Input = true
+		for _, f := range t.Fields {
+			markInput(types, f.Schema)
+		}
+	case schema.Items != nil:
+		markInput(types, schema.Items)
+	case schema.AdditionalProperties != nil:
+		markInput(types, schema.AdditionalProperties)
+	}
+}
+
+func sortedTypes(types map[string]*typeDef) []*typeDef {
+	list := make([]*typeDef, 0, len(types))
+	for _, t := range types {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// jsonResponse returns the schema of the lowest 2xx JSON response
+func jsonResponse(op specOperation) (*openapi.Schema, bool) {
+	best := 0
+	var schema *openapi.Schema
+	for status, resp := range op.Responses {
+		code, err := strconv.Atoi(status)
+		if err != nil || code < http.StatusOK || code >= http.StatusMultipleChoices {
+			continue
+		}
+		content, ok := resp.Content["application/json"]
+		if !ok {
+			continue
+		}
+		if best == 0 || code < best {
+			best, schema = code, content.Schema
+		}
+	}
+	return schema, schema != nil
+}
+
+// operationName drops the "Api" segment every operation ID shares
+func operationName(id string) string {
+	words := splitWords(id)
+	if len(words) > 1 && strings.EqualFold(words[1], "api") {
+		words = append(words[:1], words[2:]...)
+	}
+	return joinExported(words)
+}
+
+// initialisms are written in upper case in Go identifiers
+var initialisms = map[string]bool{"api": true, "id": true, "ip": true, "json": true, "url": true, "uuid": true, "http": true}
+
+// exportedName converts a JSON or path name to a Go identifier, e.g. problem_id → ProblemID
+func exportedName(s string) string {
+	return joinExported(splitWords(s))
+}
+
+// localName converts a name to an unexported Go identifier, e.g. problemId → problemID
+func localName(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return s
+	}
+	return strings.ToLower(words[0]) + joinExported(words[1:])
+}
+
+// camelName converts a name to a TypeScript identifier, e.g. GetContestsID → getContestsId
+func camelName(s string) string {
+	words := splitWords(s)
+	var b strings.Builder
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+
+func joinExported(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		if initialisms[lower] {
+			b.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		b.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+	}
+	return b.String()
+}
+
+// splitWords splits on separators and lower-to-upper case changes
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// refName returns the type name a $ref points to
+func refName(schema *openapi.Schema) string {
+	return strings.TrimPrefix(schema.Ref, refPrefix)
+}
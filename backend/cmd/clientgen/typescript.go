@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/contest-maker-150/backend/internal/openapi"
+)
+
+const tsHeader = "// Code generated by backend/cmd/clientgen from backend/api/openapi.json. DO NOT EDIT.\n\n"
+
+// tsTypes renders types.ts
+func tsTypes(m *model) []byte {
+	var b bytes.Buffer
+	b.WriteString(tsHeader)
+	for i, t := range m.Types {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", t.Name)
+		for _, f := range t.Fields {
+			optional := ""
+			if t.Input && !f.Required {
+				optional = "?"
+			}
+			fmt.Fprintf(&b, "    %s%s: %s;\n", f.JSON, optional, tsType(f.Schema))
+		}
+		b.WriteString("}\n")
+	}
+	return b.Bytes()
+}
+
+// tsClient renders client.ts
+func tsClient(m *model) []byte {
+	var b bytes.Buffer
+	b.WriteString(tsHeader)
+
+	used := make(map[string]bool)
+	for _, o := range m.Operations {
+		for _, s := range []*openapi.Schema{o.Body, o.Response} {
+			if s != nil && s.Ref != "" {
+				used[refName(s)] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b.WriteString("import { BaseClient, type RequestOptions } from './runtime.js';\nimport type {\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "    %s,\n", name)
+	}
+	b.WriteString("} from './types.js';\n")
+
+	for _, o := range m.Operations {
+		if len(o.Query) > 0 {
+			fmt.Fprintf(&b, "\nexport interface %sParams {\n", o.Name)
+			for _, p := range o.Query {
+				if p.Description != "" {
+					fmt.Fprintf(&b, "    /** %s */\n", p.Description)
+				}
+				fmt.Fprintf(&b, "    %s?: %s;\n", p.Name, tsType(p.Schema))
+			}
+			b.WriteString("}\n")
+		}
+	}
+
+	b.WriteString("\n/** Typed client for the Contest Maker 150 API */\nexport class ContestMakerClient extends BaseClient {\n")
+	for i, o := range m.Operations {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		args := make([]string, 0, len(o.PathParams)+3)
+		for _, p := range o.PathParams {
+			args = append(args, p+": string")
+		}
+		if o.Body != nil {
+			args = append(args, "body: "+tsType(o.Body))
+		}
+		if len(o.Query) > 0 {
+			args = append(args, "params: "+o.Name+"Params = {}")
+		}
+		args = append(args, "options: RequestOptions = {}")
+
+		fmt.Fprintf(&b, "    /** %s %s: %s", o.Method, o.Path, o.Summary)
+		if o.Deprecated {
+			b.WriteString("\n     * @deprecated")
+			if o.Sunset != "" {
+				fmt.Fprintf(&b, " Removed on %s.", o.Sunset)
+			}
+			b.WriteString("\n    ")
+		}
+		b.WriteString(" */\n")
+		fmt.Fprintf(&b, "    %s(%s): Promise<%s> {\n", camelName(o.Name), strings.Join(args, ", "), tsType(o.Response))
+
+		fields := []string{fmt.Sprintf("auth: %t", o.Auth)}
+		if o.Body != nil {
+			fields = append(fields, "body")
+		}
+		if len(o.Query) > 0 {
+			fields = append(fields, "query: { ...params }")
+		}
+		fmt.Fprintf(&b, "        return this.request('%s', %s, { %s, ...options });\n    }\n", o.Method, tsPath(o.Path), strings.Join(fields, ", "))
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// tsPath renders the request path as a template literal escaping each parameter
+func tsPath(path string) string {
+	if !strings.Contains(path, "{") {
+		return "'" + path + "'"
+	}
+	var b strings.Builder
+	b.WriteString("`")
+	for i, part := range strings.Split(path, "/") {
+		if i > 0 {
+			b.WriteString("/")
+		}
+		if strings.HasPrefix(part, "{") {
+			fmt.Fprintf(&b, "${encodeURIComponent(%s)}", strings.Trim(part, "{}"))
+			continue
+		}
+		b.WriteString(part)
+	}
+	b.WriteString("`")
+	return b.String()
+}
+
+// tsType maps a schema to a TypeScript type expression
+func tsType(s *openapi.Schema) string {
+	if s == nil {
+		return "unknown"
+	}
+	if s.Ref != "" {
+		return refName(s)
+	}
+
+	var t string
+	switch s.Type {
+	case "string":
+		t = "string" // Dates are ISO 8601 strings, as in the frontend
+	case "integer", "number":
+		t = "number"
+	case "boolean":
+		t = "boolean"
+	case "array":
+		item := tsType(s.Items)
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		t = item + "[]"
+	case "object":
+		if s.AdditionalProperties != nil {
+			t = "Record<string, " + tsType(s.AdditionalProperties) + ">"
+		} else {
+			t = "Record<string, unknown>"
+		}
+	default:
+		return "unknown"
+	}
+	if s.Nullable {
+		return t + " | null"
+	}
+	return t
+}
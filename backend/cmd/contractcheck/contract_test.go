@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestContract runs the contract check under go test, so `go test ./...`
+// catches drift between the router and the OpenAPI document
+func TestContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("drives the whole API scenario")
+	}
+
+	var out bytes.Buffer
+	failures, err := check(&out, false)
+	if err != nil {
+		t.Fatalf("Failed to %v", err)
+	}
+	if failures > 0 {
+		t.Fatalf("%d contract violations:\n%s", failures, out.String())
+	}
+	t.Log(strings.TrimSpace(out.String()))
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// leakRecorder captures what the API logs and traces during the scenario, after
+// the same redaction production applies, so credentials sent by the scenario
+// can be looked for in the output
+type leakRecorder struct {
+	core           zapcore.Core
+	logs           *observer.ObservedLogs
+	spans          *tracetest.InMemoryExporter
+	tracerProvider *sdktrace.TracerProvider
+}
+
+func newLeakRecorder() *leakRecorder {
+	core, logs := observer.New(zapcore.DebugLevel)
+	spans := tracetest.NewInMemoryExporter()
+	return &leakRecorder{
+		core:  core,
+		logs:  logs,
+		spans: spans,
+		tracerProvider: sdktrace.NewTracerProvider(
+			sdktrace.WithSyncer(infrastructure.NewRedactingSpanExporter(spans)),
+		),
+	}
+}
+
+// checkLeaks fails for every log entry or span that contains one of the secrets
+func (c *checker) checkLeaks(r *leakRecorder, secrets []string) {
+	find := func(text string) string {
+		for _, secret := range secrets {
+			if secret != "" && strings.Contains(text, secret) {
+				return secret
+			}
+		}
+		return ""
+	}
+	report := func(where, secret string) {
+		c.failf("redaction: %s contains %q", where, secret)
+	}
+
+	for _, entry := range r.logs.All() {
+		text := entry.Message
+		for key, value := range entry.ContextMap() {
+			text += fmt.Sprintf(" %s=%v", key, value)
+		}
+		if secret := find(text); secret != "" {
+			report(fmt.Sprintf("log %q", entry.Message), secret)
+		}
+	}
+
+	for _, span := range r.spans.GetSpans() {
+		text := span.Name + " " + span.Status.Description
+		for _, kv := range span.Attributes {
+			text += " " + string(kv.Key) + "=" + kv.Value.Emit()
+		}
+		for _, event := range span.Events {
+			text += " " + event.Name
+			for _, kv := range event.Attributes {
+				text += " " + string(kv.Key) + "=" + kv.Value.Emit()
+			}
+		}
+		if secret := find(text); secret != "" {
+			report(fmt.Sprintf("span %q", span.Name), secret)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Command contractcheck drives the assembled router in-process through a scripted
+// scenario and checks every response against the generated OpenAPI document:
+// documented status codes, response shapes and the error envelope. It runs on
+// an in-memory SQLite database, needs no running server or network, and exits
+// non-zero on any drift, so CI can run it next to `openapi -check`. TestContract
+// runs the same check under go test.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/contest-maker-150/backend/internal/app"
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/openapi"
+)
+
+// step is one request of the scenario
+type step struct {
+	op     string            // Documented operation, e.g. "POST /api/contests/:id/start"
+	url    string            // Request path; {name} placeholders are replaced by saved values
+	token  string            // Name of the saved value sent as bearer token, if any
+	tenant string            // Tenant slug sent in the tenant header, if any
+	body   interface{}       // JSON request body; string values may contain placeholders
+	status int               // Expected status code
+	code   string            // Expected error code for error statuses
+	save   map[string]string // Saved value name → dotted path into the JSON response
+	signed bool              // Sign the body as a Stripe webhook
+}
+
+const (
+	password    = "Xq9!vLm2#pRt"
+	newPassword = "Zk8@wQn3$sUv"
+
+	stripeSecretKey     = "sk_test_contractcheck"
+	stripeWebhookSecret = "whsec_contractcheck"
+)
+
+var (
+	placeholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+	pathParam   = regexp.MustCompile(`:[A-Za-z]+`)
+)
+
+func main() {
+	verbose := flag.Bool("v", false, "print every request and log server output")
+	flag.Parse()
+
+	failures, err := check(os.Stdout, *verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to %v\n", err)
+		os.Exit(1)
+	}
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// check runs the scenario against a freshly assembled API, writing every
+// contract violation and a summary to out, and returns the number of violations.
+// An error means the API could not be set up or torn down.
+func check(out io.Writer, verbose bool) (int, error) {
+	config := infrastructure.LoadConfig()
+	config.Database.Driver = infrastructure.DriverSQLite
+	config.Database.SQLitePath = ":memory:"
+	config.Telemetry.Enabled = false
+	config.Password.BreachCheckEnabled = false
+	config.LoadShed.Enabled = false
+	config.Tenancy.Enabled = true
+
+	// Checkout sessions are created on a stand-in for the Stripe API
+	stripe := fakeStripe()
+	defer stripe.Close()
+	config.Billing.StripeAPIURL = stripe.URL
+	config.Billing.StripeSecretKey = stripeSecretKey
+	config.Billing.StripeWebhookSecret = stripeWebhookSecret
+	config.Billing.StripePriceID = "price_contractcheck"
+
+	// Backups are written to a directory removed afterwards
+	backupDir, err := os.MkdirTemp("", "contractcheck-backups-")
+	if err != nil {
+		return 0, fmt.Errorf("create backup dir: %w", err)
+	}
+	defer os.RemoveAll(backupDir)
+	config.Backup.Storage = infrastructure.BackupStorageFile
+	config.Backup.Dir = backupDir
+	config.Backup.Interval = 0
+
+	gin.SetMode(gin.ReleaseMode)
+	// Everything logged and traced is recorded, after redaction, to check for leaked credentials
+	leaks := newLeakRecorder()
+	core := leaks.core
+	if verbose {
+		development, _ := zap.NewDevelopment()
+		core = zapcore.NewTee(core, development.Core())
+	}
+	logger := zap.New(infrastructure.NewRedactingCore(core))
+
+	database, err := infrastructure.NewDatabase(&config.Database, logger)
+	if err != nil {
+		return 0, fmt.Errorf("connect: %w", err)
+	}
+	defer database.Close()
+
+	telemetry, err := infrastructure.NewTelemetry(context.Background(), &config.Telemetry, &config.Resilience, logger)
+	if err != nil {
+		return 0, fmt.Errorf("set up telemetry: %w", err)
+	}
+	telemetry.Tracer = leaks.tracerProvider.Tracer(config.Telemetry.ServiceName)
+	metrics, err := telemetry.CreateMetrics()
+	if err != nil {
+		return 0, fmt.Errorf("create metrics: %w", err)
+	}
+
+	if err := app.PrepareDatabase(database, logger); err != nil {
+		return 0, fmt.Errorf("prepare database: %w", err)
+	}
+	api, err := app.New(config, database, telemetry, metrics, logger, zap.NewAtomicLevel())
+	if err != nil {
+		return 0, fmt.Errorf("assemble API: %w", err)
+	}
+
+	c := &checker{
+		router:  api.Router,
+		doc:     handler.BuildOpenAPI(config.Telemetry.ServiceVersion),
+		out:     out,
+		vars:    make(map[string]string),
+		covered: make(map[string]bool),
+		verbose: verbose,
+		secrets: []string{password, newPassword, "alice@example.com", "bob@example.com", "carol@example.com", stripeSecretKey, stripeWebhookSecret},
+	}
+
+	c.run(scenarioBeforeAdmin())
+	// Admin routes need a role that only the database can grant
+	if err := database.DB.Model(&domain.User{}).Where("email = ?", "alice@example.com").
+		Update("role", domain.RoleAdmin).Error; err != nil {
+		return 0, fmt.Errorf("promote admin: %w", err)
+	}
+	c.run(scenarioAfterAdmin())
+	c.checkUnauthorized()
+	if err := api.Stop(context.Background()); err != nil {
+		return 0, fmt.Errorf("stop API: %w", err)
+	}
+	c.checkLeaks(leaks, c.secrets)
+	c.report()
+
+	return c.failures, nil
+}
+
+// checker sends requests and records contract violations
+type checker struct {
+	router   http.Handler
+	doc      *openapi.Document
+	out      io.Writer // Receives the violations and the summary
+	vars     map[string]string
+	covered  map[string]bool // Operations answered with a documented success status
+	verbose  bool
+	secrets  []string // Credentials sent or issued, which must never be logged or traced
+	requests int
+	failures int
+}
+
+func (c *checker) run(steps []step) {
+	for _, s := range steps {
+		c.do(s)
+	}
+}
+
+// do sends one step and validates the response against the document
+func (c *checker) do(s step) {
+	method, pattern, _ := strings.Cut(s.op, " ")
+	url := c.expand(s.url)
+
+	var body io.Reader
+	var payload string
+	if s.body != nil {
+		raw, err := json.Marshal(s.body)
+		if err != nil {
+			c.failf("%s: encode body: %v", s.op, err)
+			return
+		}
+		payload = c.expand(string(raw))
+		body = strings.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, url, body)
+	if s.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.signed {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("Stripe-Signature", "t="+timestamp+",v1="+infrastructure.StripeSignature(stripeWebhookSecret, timestamp, []byte(payload)))
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.vars[s.token])
+	}
+	if s.tenant != "" {
+		req.Header.Set("X-Tenant", s.tenant)
+	}
+	rec := httptest.NewRecorder()
+	c.router.ServeHTTP(rec, req)
+	c.requests++
+
+	if c.verbose {
+		fmt.Fprintf(c.out, "%-7s %-60s %d\n", method, url, rec.Code)
+	}
+
+	var problems []string
+	if rec.Code != s.status {
+		problems = append(problems, fmt.Sprintf("expected status %d, got %d: %s", s.status, rec.Code, truncate(rec.Body.Bytes())))
+	}
+	if err := c.doc.ValidateResponse(method, pattern, rec.Code, rec.Body.Bytes()); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	var decoded interface{}
+	if json.Valid(rec.Body.Bytes()) {
+		_ = json.Unmarshal(rec.Body.Bytes(), &decoded)
+	}
+	if rec.Code >= 400 {
+		if code := lookup(decoded, "error.code"); code == "" {
+			problems = append(problems, "error response without error.code")
+		} else if s.code != "" && code != s.code {
+			problems = append(problems, fmt.Sprintf("expected error code %s, got %s", s.code, code))
+		}
+	} else if len(problems) == 0 {
+		c.covered[s.op] = true
+	}
+
+	for name, path := range s.save {
+		value := lookup(decoded, path)
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("response has no %s to save as %s", path, name))
+			continue
+		}
+		c.vars[name] = value
+		if strings.HasPrefix(path, "tokens.") {
+			c.secrets = append(c.secrets, value)
+		}
+	}
+
+	for _, p := range problems {
+		c.failf("%s (%s %s): %s", s.op, method, url, p)
+	}
+}
+
+// fakeStripe serves the one Stripe API call billing makes, creating a checkout session
+func fakeStripe() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/checkout/sessions" || r.Header.Get("Authorization") != "Bearer "+stripeSecretKey {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "cs_test_contract", "url": "https://checkout.stripe.com/c/pay/cs_test_contract"}`))
+	}))
+}
+
+// checkUnauthorized calls every operation that requires auth without a token
+// and expects the 401 error envelope
+func (c *checker) checkUnauthorized() {
+	for _, op := range handler.APIOperations() {
+		if !op.Auth {
+			continue
+		}
+		url := pathParam.ReplaceAllString(op.Path, uuid.Nil.String())
+		c.do(step{op: op.Method + " " + op.Path, url: url, status: http.StatusUnauthorized, code: domain.CodeUnauthorized})
+	}
+}
+
+// report prints the documented operations the scenario never exercised successfully
+func (c *checker) report() {
+	var uncovered []string
+	for _, op := range handler.APIOperations() {
+		key := op.Method + " " + op.Path
+		if !c.covered[key] {
+			uncovered = append(uncovered, key)
+		}
+	}
+	sort.Strings(uncovered)
+	for _, key := range uncovered {
+		c.failf("%s: never exercised with a success status", key)
+	}
+
+	fmt.Fprintf(c.out, "%d requests, %d of %d operations covered, %d failures\n",
+		c.requests, len(c.covered), len(handler.APIOperations()), c.failures)
+}
+
+// expand replaces {name} placeholders with saved values
+func (c *checker) expand(s string) string {
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		if v, ok := c.vars[m[1:len(m)-1]]; ok {
+			return v
+		}
+		return m
+	})
+}
+
+// lookup follows a dotted path (numeric segments index arrays) and returns the
+// value as a string, or "" when the path does not exist
+func lookup(v interface{}, path string) string {
+	for _, part := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			v = node[part]
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i >= len(node) {
+				return ""
+			}
+			v = node[i]
+		default:
+			return ""
+		}
+	}
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return ""
+	}
+}
+
+func truncate(b []byte) string {
+	b = bytes.TrimSpace(b)
+	if len(b) > 200 {
+		return string(b[:200]) + "..."
+	}
+	return string(b)
+}
+
+// failf records a contract violation
+func (c *checker) failf(format string, args ...interface{}) {
+	c.failures++
+	fmt.Fprintf(c.out, "FAIL "+format+"\n", args...)
+}
@@ -0,0 +1,789 @@
+package main
+
+import "net/http"
+
+// obj is shorthand for JSON request bodies
+type obj = map[string]interface{}
+
+// scenarioBeforeAdmin covers the public and user endpoints with two users,
+// alice and bob, including a challenge between them
+func scenarioBeforeAdmin() []step {
+	login := func(name, email, pass string) step {
+		return step{op: "POST /api/auth/login", url: "/api/auth/login",
+			body: obj{"email": email, "password": pass}, status: http.StatusOK,
+			save: map[string]string{name: "tokens.access_token", name + "_refresh": "tokens.refresh_token"}}
+	}
+
+	// template rebuilds the contest template alice exported from the saved
+	// fields; any other duration than the exported 60 minutes breaks its signature
+	problemFields := [][2]string{{"0", "a"}, {"1", "b"}, {"2", "c"}}
+	templateFields := map[string]string{"tpl_signature": "signature", "tpl_ordering": "template.ordering", "tpl_exported_at": "template.exported_at"}
+	for _, f := range problemFields {
+		templateFields["tpl_slug_"+f[1]] = "template.problems." + f[0] + ".slug"
+		templateFields["tpl_title_"+f[1]] = "template.problems." + f[0] + ".title"
+		templateFields["tpl_difficulty_"+f[1]] = "template.problems." + f[0] + ".difficulty"
+	}
+	template := func(duration int, dryRun bool) obj {
+		problems := make([]obj, len(problemFields))
+		for i, f := range problemFields {
+			problems[i] = obj{"slug": "{tpl_slug_" + f[1] + "}", "title": "{tpl_title_" + f[1] + "}", "difficulty": "{tpl_difficulty_" + f[1] + "}"}
+		}
+		return obj{
+			"template": obj{
+				"version": 1, "duration_minutes": duration, "ordering": "{tpl_ordering}",
+				"problems": problems, "exported_at": "{tpl_exported_at}",
+			},
+			"signature": "{tpl_signature}",
+			"dry_run":   dryRun,
+		}
+	}
+
+	return []step{
+		// Auth
+		{op: "POST /api/auth/signup", url: "/api/auth/signup",
+			body: obj{"email": "alice@example.com", "username": "alice", "password": password}, status: http.StatusCreated,
+			save: map[string]string{"alice_id": "user.id"}},
+		{op: "POST /api/auth/signup", url: "/api/auth/signup",
+			body: obj{"email": "bob@example.com", "username": "bob", "password": password}, status: http.StatusCreated,
+			save: map[string]string{"bob_id": "user.id"}},
+		{op: "POST /api/auth/signup", url: "/api/auth/signup",
+			body: obj{"email": "alice@example.com", "username": "alice2", "password": password}, status: http.StatusConflict, code: "USER_ALREADY_EXISTS"},
+		{op: "POST /api/auth/signup", url: "/api/auth/signup",
+			body: obj{"email": "not-an-email"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/auth/login", url: "/api/auth/login",
+			body: obj{"email": "alice@example.com", "password": "wrong"}, status: http.StatusUnauthorized, code: "INVALID_CREDENTIALS"},
+		login("alice", "alice@example.com", password),
+		login("bob", "bob@example.com", password),
+		{op: "POST /api/auth/refresh", url: "/api/auth/refresh",
+			body: obj{"refresh_token": "{alice_refresh}"}, status: http.StatusOK},
+		{op: "POST /api/auth/refresh", url: "/api/auth/refresh",
+			body: obj{"refresh_token": "garbage"}, status: http.StatusUnauthorized},
+
+		// Users
+		{op: "GET /api/users/me", url: "/api/users/me", token: "alice", status: http.StatusOK},
+		{op: "GET /api/users/me/progress", url: "/api/users/me/progress", token: "alice", status: http.StatusOK},
+
+		// Problems
+		{op: "GET /api/problems", url: "/api/problems", status: http.StatusOK,
+			save: map[string]string{"problem_id": "problems.0.id"}},
+		{op: "GET /api/problems", url: "/api/problems?difficulty=Easy&include=popularity", token: "alice", status: http.StatusOK},
+		{op: "GET /api/problems", url: "/api/problems?solved=solved", status: http.StatusUnauthorized},
+		{op: "GET /api/problems/stats", url: "/api/problems/stats", status: http.StatusOK},
+		{op: "GET /api/problems/:id", url: "/api/problems/{problem_id}?include=popularity", status: http.StatusOK},
+		{op: "GET /api/problems/:id", url: "/api/problems/not-a-uuid", status: http.StatusBadRequest},
+		{op: "GET /api/problems/:id", url: "/api/problems/00000000-0000-0000-0000-000000000000", status: http.StatusNotFound, code: "PROBLEM_NOT_FOUND"},
+		{op: "POST /api/problems/batch", url: "/api/problems/batch?include=popularity", status: http.StatusOK,
+			body: obj{"keys": []string{"{problem_id}", "two-sum", "no-such-problem"}}},
+		{op: "POST /api/problems/batch", url: "/api/problems/batch", status: http.StatusBadRequest,
+			body: obj{"keys": []string{}}, code: "VALIDATION_FAILED"},
+		{op: "GET /api/problems/search", url: "/api/problems/search?q=palindrom&limit=5", status: http.StatusOK},
+		{op: "GET /api/problems/search", url: "/api/problems/search", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/problems/slug/:slug", url: "/api/problems/slug/two-sum", status: http.StatusOK},
+		{op: "GET /api/problems/slug/:slug", url: "/api/problems/slug/no-such-problem", status: http.StatusNotFound, code: "PROBLEM_NOT_FOUND"},
+		{op: "GET /api/sitemap.xml", url: "/api/sitemap.xml", status: http.StatusOK},
+		{op: "GET /api/public/stats", url: "/api/public/stats", status: http.StatusOK},
+		{op: "GET /api/public/stats/problems", url: "/api/public/stats/problems?limit=5", status: http.StatusOK},
+		{op: "GET /api/public/stats/problems", url: "/api/public/stats/problems?limit=500", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/problems/:id/prerequisites", url: "/api/problems/{problem_id}/prerequisites", status: http.StatusOK},
+		{op: "GET /api/companies", url: "/api/companies", status: http.StatusOK},
+		{op: "GET /api/roadmap", url: "/api/roadmap", status: http.StatusOK},
+		{op: "GET /api/roadmap", url: "/api/roadmap", token: "alice", status: http.StatusOK,
+			save: map[string]string{"category_id": "categories.0.id"}},
+
+		// Saved filters
+		{op: "POST /api/users/me/filters", url: "/api/users/me/filters", token: "alice",
+			body: obj{"name": "Easy arrays", "difficulties": []string{"Easy"}, "solved_state": "unsolved"}, status: http.StatusCreated,
+			save: map[string]string{"filter_id": "id"}},
+		{op: "POST /api/users/me/filters", url: "/api/users/me/filters", token: "alice",
+			body: obj{"name": "Easy arrays"}, status: http.StatusConflict, code: "FILTER_NAME_TAKEN"},
+		{op: "GET /api/users/me/filters", url: "/api/users/me/filters", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/users/me/filters/:filterId", url: "/api/users/me/filters/{filter_id}", token: "alice",
+			body: obj{"name": "Mediums", "difficulties": []string{"Medium"}}, status: http.StatusOK},
+		{op: "GET /api/problems", url: "/api/problems?filter_id={filter_id}", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/users/me/filters/:filterId", url: "/api/users/me/filters/{filter_id}", token: "bob",
+			body: obj{"name": "Stolen"}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "DELETE /api/users/me/filters/:filterId", url: "/api/users/me/filters/{filter_id}", token: "alice", status: http.StatusOK},
+
+		// Custom problems
+		{op: "POST /api/users/me/problems", url: "/api/users/me/problems", token: "alice",
+			body:   obj{"title": "Interview Question", "url": "https://example.com/q", "difficulty": "Medium", "topics": []string{"Graphs"}},
+			status: http.StatusCreated, save: map[string]string{"custom_id": "id"}},
+		{op: "GET /api/users/me/problems", url: "/api/users/me/problems", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/users/me/problems/:problemId", url: "/api/users/me/problems/{custom_id}", token: "alice",
+			body: obj{"title": "Interview Question II", "url": "https://example.com/q2", "difficulty": "Hard"}, status: http.StatusOK},
+		{op: "DELETE /api/users/me/problems/:problemId", url: "/api/users/me/problems/{custom_id}", token: "bob", status: http.StatusNotFound},
+		{op: "DELETE /api/users/me/problems/:problemId", url: "/api/users/me/problems/{custom_id}", token: "alice", status: http.StatusOK},
+
+		// Contest lifecycle with a warmup
+		{op: "POST /api/contests", url: "/api/contests", token: "alice",
+			body: obj{"problem_count": 21, "duration_minutes": 10}, status: http.StatusBadRequest},
+		{op: "POST /api/contests", url: "/api/contests", token: "alice",
+			body: obj{"problem_count": 3, "duration_minutes": 60, "warmup_minutes": 5, "tags": []string{"mock"}}, status: http.StatusCreated,
+			save: map[string]string{"contest_id": "id", "contest_problem": "problems.0.problem.id"}},
+		{op: "POST /api/contests", url: "/api/contests", token: "alice",
+			body: obj{"problem_count": 3, "duration_minutes": 60}, status: http.StatusConflict, code: "ACTIVE_CONTEST_EXISTS",
+			save: map[string]string{"conflicting_contest": "error.details.contest_id"}},
+		{op: "PATCH /api/contests/:id/problems/:problemId", url: "/api/contests/{contest_id}/problems/{contest_problem}", token: "alice",
+			body: obj{"is_completed": true}, status: http.StatusBadRequest, code: "CONTEST_NOT_STARTED"},
+		{op: "PATCH /api/contests/:id/warmup", url: "/api/contests/{contest_id}/warmup", token: "alice",
+			body: obj{"is_completed": true}, status: http.StatusOK},
+		{op: "POST /api/contests/:id/start", url: "/api/contests/{contest_id}/start", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/contests/:id/problems/:problemId/complexity", url: "/api/contests/{contest_id}/problems/{contest_problem}/complexity", token: "alice",
+			body: obj{"time_complexity": "O(n)"}, status: http.StatusBadRequest, code: "PROBLEM_NOT_COMPLETED"},
+		{op: "POST /api/contests/:id/problems/:problemId/start", url: "/api/contests/{contest_id}/problems/{contest_problem}/start", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/contests/:id/problems/:problemId/start", url: "/api/contests/{contest_id}/problems/{contest_problem}/start", token: "alice",
+			status: http.StatusOK},
+		{op: "POST /api/contests/:id/problems/:problemId/attempts", url: "/api/contests/{contest_id}/problems/{contest_problem}/attempts", token: "alice",
+			body: obj{"outcome": "gave_up"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/contests/:id/problems/:problemId/attempts", url: "/api/contests/{contest_id}/problems/{contest_problem}/attempts", token: "alice",
+			body: obj{"outcome": "failed"}, status: http.StatusCreated},
+		{op: "PATCH /api/contests/:id/problems/:problemId", url: "/api/contests/{contest_id}/problems/{contest_problem}", token: "alice",
+			body: obj{"is_completed": true, "confidence": 9}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PATCH /api/contests/:id/problems/:problemId", url: "/api/contests/{contest_id}/problems/{contest_problem}", token: "alice",
+			body: obj{"is_completed": true, "confidence": 2}, status: http.StatusOK},
+		{op: "POST /api/contests/:id/problems/:problemId/attempts", url: "/api/contests/{contest_id}/problems/{contest_problem}/attempts", token: "alice",
+			body: obj{"outcome": "failed"}, status: http.StatusConflict, code: "PROBLEM_ALREADY_COMPLETED"},
+		{op: "POST /api/contests/:id/problems/:problemId/start", url: "/api/contests/{contest_id}/problems/{contest_problem}/start", token: "alice",
+			status: http.StatusConflict, code: "PROBLEM_ALREADY_COMPLETED"},
+		{op: "GET /api/users/me/attempts/:problemId", url: "/api/users/me/attempts/not-a-uuid", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/users/me/attempts/:problemId", url: "/api/users/me/attempts/{contest_problem}", token: "alice", status: http.StatusOK,
+			save: map[string]string{"attempt_outcome": "attempts.1.outcome"}},
+		{op: "GET /api/users/me/reviews", url: "/api/users/me/reviews?limit=300", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/users/me/reviews", url: "/api/users/me/reviews", token: "alice", status: http.StatusOK,
+			save: map[string]string{"review_confidence": "reviews.0.confidence"}},
+		{op: "PUT /api/contests/:id/problems/:problemId/complexity", url: "/api/contests/{contest_id}/problems/{contest_problem}/complexity", token: "bob",
+			body: obj{"time_complexity": "O(n)"}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "PUT /api/contests/:id/problems/:problemId/complexity", url: "/api/contests/{contest_id}/problems/{contest_problem}/complexity", token: "alice",
+			body: obj{"time_complexity": "O(n log n)", "space_complexity": "O(1)"}, status: http.StatusOK},
+		{op: "PUT /api/contests/:id/tags", url: "/api/contests/{contest_id}/tags", token: "alice",
+			body: obj{"tags": []string{"mock", "arrays"}}, status: http.StatusOK},
+		// Session state synced between alice's devices: a save from a device
+		// that read an older version loses and gets the current state back
+		{op: "GET /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "alice", status: http.StatusOK,
+			save: map[string]string{"state_version": "version"}},
+		{op: "PUT /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "alice",
+			body: obj{"notes": obj{"two-sum": "hash map"}, "version": 0}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PUT /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "alice",
+			body: obj{"current_problem_id": "{contest_problem}", "notes": obj{"{contest_problem}": "Sort first, then two pointers"}, "device": "laptop", "version": 0}, status: http.StatusOK,
+			save: map[string]string{"state_version": "version"}},
+		{op: "PUT /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "alice",
+			body: obj{"current_problem_id": "{contest_problem}", "device": "phone", "version": 0}, status: http.StatusConflict, code: "SESSION_CONFLICT",
+			save: map[string]string{"state_device": "error.details.device"}},
+		{op: "PUT /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "alice",
+			body: obj{"current_problem_id": "{contest_problem}", "notes": obj{"{contest_problem}": "Sort first, then two pointers"}, "device": "phone", "version": 1}, status: http.StatusOK},
+		{op: "GET /api/contests/:id", url: "/api/contests/{conflicting_contest}", token: "alice", status: http.StatusOK},
+		{op: "GET /api/contests/:id", url: "/api/contests/{contest_id}", token: "bob", status: http.StatusForbidden},
+		{op: "GET /api/contests/active", url: "/api/contests/active", token: "alice", status: http.StatusOK},
+		{op: "GET /api/contests/active", url: "/api/contests/active", token: "bob", status: http.StatusOK},
+		{op: "GET /api/contests/tags", url: "/api/contests/tags?prefix=m&limit=5", token: "alice", status: http.StatusOK},
+		{op: "PATCH /api/contests/:id/retro", url: "/api/contests/{contest_id}/retro", token: "alice",
+			body: obj{"retro": "Too early"}, status: http.StatusBadRequest, code: "CONTEST_IN_PROGRESS"},
+		{op: "PUT /api/contests/:id/rating", url: "/api/contests/{contest_id}/rating", token: "alice",
+			body: obj{"rating": 2}, status: http.StatusBadRequest, code: "CONTEST_IN_PROGRESS"},
+
+		// Challenge between alice and bob
+		{op: "POST /api/contests/:id/challenge", url: "/api/contests/{contest_id}/challenge", token: "alice",
+			body: obj{"silent_mode": true}, status: http.StatusCreated,
+			save: map[string]string{"challenge": "code"}},
+		{op: "GET /api/challenges/:code", url: "/api/challenges/{challenge}", token: "bob", status: http.StatusOK},
+		{op: "POST /api/challenges/:code/accept", url: "/api/challenges/{challenge}/accept", token: "alice", status: http.StatusBadRequest},
+		{op: "GET /api/challenges/:code/comparison", url: "/api/challenges/{challenge}/comparison", token: "alice",
+			status: http.StatusConflict, code: "CHALLENGE_IN_PROGRESS"},
+		{op: "POST /api/challenges/:code/accept", url: "/api/challenges/{challenge}/accept", token: "bob", status: http.StatusCreated,
+			save: map[string]string{"bob_contest": "id"}},
+		{op: "POST /api/challenges/:code/accept", url: "/api/challenges/{challenge}/accept", token: "bob",
+			status: http.StatusConflict, code: "CHALLENGE_ACCEPTED"},
+		{op: "POST /api/users/me/heartbeat", url: "/api/users/me/heartbeat", token: "bob",
+			body: obj{"contest_id": "{contest_id}"}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/users/me/heartbeat", url: "/api/users/me/heartbeat", token: "alice",
+			body: obj{"contest_id": "{contest_id}"}, status: http.StatusOK,
+			save: map[string]string{"alice_presence": "status"}},
+		{op: "GET /api/challenges/:code/standings", url: "/api/challenges/{challenge}/standings", token: "bob", status: http.StatusOK,
+			save: map[string]string{"challenger_presence": "participants.0.presence.status"}},
+		{op: "POST /api/challenges/:code/spectators", url: "/api/challenges/{challenge}/spectators", token: "alice",
+			body: obj{"anonymize": true}, status: http.StatusCreated,
+			save: map[string]string{"spectator_code": "spectator_code"}},
+		{op: "GET /api/spectate/:spectatorCode", url: "/api/spectate/{spectator_code}", token: "bob", status: http.StatusOK,
+			save: map[string]string{"spectated_name": "participants.0.name"}},
+		{op: "DELETE /api/challenges/:code/spectators", url: "/api/challenges/{challenge}/spectators", token: "bob", status: http.StatusOK},
+		{op: "GET /api/spectate/:spectatorCode", url: "/api/spectate/{spectator_code}", token: "bob",
+			status: http.StatusNotFound, code: "CHALLENGE_NOT_FOUND"},
+		{op: "POST /api/challenges/:code/chat", url: "/api/challenges/{challenge}/chat", token: "alice",
+			body: obj{"body": "Good luck"}, status: http.StatusConflict, code: "CHAT_SILENCED"},
+		{op: "GET /api/challenges/:code/chat", url: "/api/challenges/{challenge}/chat", token: "bob", status: http.StatusOK,
+			save: map[string]string{"chat_silenced": "silenced"}},
+		{op: "POST /api/contests/:id/abandon", url: "/api/contests/{bob_contest}/abandon", token: "bob", status: http.StatusOK},
+		{op: "POST /api/contests/:id/complete", url: "/api/contests/{contest_id}/complete", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/contests/:id/state", url: "/api/contests/{contest_id}/state", token: "alice",
+			body: obj{"version": 2}, status: http.StatusBadRequest, code: "CONTEST_NOT_ACTIVE"},
+		{op: "GET /api/challenges/:code/comparison", url: "/api/challenges/{challenge}/comparison", token: "bob", status: http.StatusOK},
+		{op: "POST /api/challenges/:code/chat", url: "/api/challenges/{challenge}/chat", token: "alice",
+			body: obj{"body": ""}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/challenges/:code/chat", url: "/api/challenges/{challenge}/chat", token: "alice",
+			body: obj{"body": "Good game"}, status: http.StatusCreated},
+		{op: "GET /api/challenges/:code/chat", url: "/api/challenges/{challenge}/chat?since=yesterday", token: "bob",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/challenges/:code/chat", url: "/api/challenges/{challenge}/chat?limit=10", token: "bob", status: http.StatusOK,
+			save: map[string]string{"chat_message": "messages.0.body"}},
+		{op: "GET /api/challenges/:code", url: "/api/challenges/unknown", token: "bob", status: http.StatusNotFound, code: "CHALLENGE_NOT_FOUND"},
+
+		// Finished contests
+		{op: "PATCH /api/contests/:id/retro", url: "/api/contests/{contest_id}/retro", token: "alice",
+			body: obj{"retro": "Review sliding window"}, status: http.StatusOK},
+		{op: "PUT /api/contests/:id/rating", url: "/api/contests/{contest_id}/rating", token: "alice",
+			body: obj{"rating": 6}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PUT /api/contests/:id/rating", url: "/api/contests/{contest_id}/rating", token: "bob",
+			body: obj{"rating": 1}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "PUT /api/contests/:id/rating", url: "/api/contests/{contest_id}/rating", token: "alice",
+			body: obj{"rating": 1}, status: http.StatusOK},
+		{op: "GET /api/contests", url: "/api/contests?q=sliding&tag=mock", token: "alice", status: http.StatusOK},
+		{op: "GET /api/contests", url: "/api/contests?status=completed&from=2020-01-01&min_duration=60&max_duration=60&min_score=1&problem={contest_problem}",
+			token: "alice", status: http.StatusOK, save: map[string]string{"filtered_contest": "contests.0.id"}},
+		{op: "GET /api/users/me/contests/by-topic", url: "/api/users/me/contests/by-topic", token: "alice", status: http.StatusOK,
+			save: map[string]string{"topic_history": "topics.0.topic"}},
+		{op: "GET /api/contests", url: "/api/contests?min_score=3&max_score=1", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/contests", url: "/api/contests?from=last-week", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/contests/:id/template", url: "/api/contests/{contest_id}/template", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/contests/:id/template", url: "/api/contests/{contest_id}/template", token: "alice", status: http.StatusOK,
+			save: templateFields},
+		{op: "POST /api/contests/import", url: "/api/contests/import", token: "bob",
+			body: template(90, false), status: http.StatusUnprocessableEntity, code: "INVALID_TEMPLATE"},
+		{op: "POST /api/contests/import", url: "/api/contests/import", token: "bob",
+			body: obj{"signature": "{tpl_signature}"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/contests/import", url: "/api/contests/import", token: "bob",
+			body: template(60, true), status: http.StatusOK,
+			save: map[string]string{"tpl_dry_run_problems": "problems"}},
+		{op: "POST /api/contests/import", url: "/api/contests/import", token: "bob",
+			body: template(60, false), status: http.StatusCreated,
+			save: map[string]string{"bob_template_contest": "contest.id"}},
+		{op: "POST /api/contests/:id/abandon", url: "/api/contests/{bob_template_contest}/abandon", token: "bob", status: http.StatusOK},
+		{op: "GET /api/users/me/search", url: "/api/users/me/search?q=Sliding+window", token: "alice", status: http.StatusOK,
+			save: map[string]string{"note_kind": "results.0.kind"}},
+		{op: "GET /api/users/me/search", url: "/api/users/me/search", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/contests/active", url: "/api/contests/active", token: "alice", status: http.StatusOK},
+		{op: "POST /api/contests/:id/abandon", url: "/api/contests/{contest_id}/abandon", token: "alice",
+			status: http.StatusBadRequest, code: "CONTEST_NOT_ACTIVE"},
+
+		// Solved warm-up, only for users with a solved easy problem
+		{op: "POST /api/contests", url: "/api/contests", token: "bob",
+			body: obj{"problem_count": 2, "duration_minutes": 30, "solved_warmup": true}, status: http.StatusBadRequest, code: "NOT_ENOUGH_PROBLEMS"},
+		{op: "POST /api/contests", url: "/api/contests", token: "alice",
+			body: obj{"problem_count": 2, "duration_minutes": 30, "solved_warmup": true}, status: http.StatusCreated,
+			save: map[string]string{"warmup_contest": "id", "warmup_problem": "problems.0.problem.id"}},
+		{op: "PATCH /api/contests/:id/problems/:problemId", url: "/api/contests/{warmup_contest}/problems/{warmup_problem}", token: "alice",
+			body: obj{"is_completed": true}, status: http.StatusOK},
+		{op: "POST /api/contests/:id/abandon", url: "/api/contests/{warmup_contest}/abandon", token: "alice", status: http.StatusOK},
+
+		// Availability of unsolved problems, also reported when a contest cannot be filled
+		{op: "GET /api/contests/availability", url: "/api/contests/availability?respect_prerequisites=true", token: "alice", status: http.StatusOK},
+		{op: "GET /api/contests/availability", url: "/api/contests/availability?company=", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/contests", url: "/api/contests", token: "alice",
+			body: obj{"problem_count": 2, "duration_minutes": 30, "companies": []string{"No Such Company"}}, status: http.StatusBadRequest, code: "NOT_ENOUGH_PROBLEMS"},
+
+		// Quick commands
+		{op: "POST /api/quick", url: "/api/quick", token: "bob",
+			body: obj{"command": "start 5 problems"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/quick", url: "/api/quick", token: "bob",
+			body: obj{"command": "skip"}, status: http.StatusNotFound, code: "NO_ACTIVE_CONTEST"},
+		{op: "POST /api/quick", url: "/api/quick", token: "alice",
+			body: obj{"command": "start 2x30"}, status: http.StatusOK,
+			save: map[string]string{"quick_contest": "contest.id"}},
+		{op: "POST /api/quick", url: "/api/quick", token: "alice",
+			body: obj{"command": "done 9"}, status: http.StatusNotFound, code: "PROBLEM_NOT_IN_CONTEST"},
+		{op: "POST /api/quick", url: "/api/quick", token: "alice",
+			body: obj{"command": "done 1"}, status: http.StatusOK},
+		{op: "POST /api/quick", url: "/api/quick", token: "alice",
+			body: obj{"command": "status"}, status: http.StatusOK,
+			save: map[string]string{"quick_status": "message"}},
+		{op: "POST /api/quick", url: "/api/quick", token: "alice",
+			body: obj{"command": "abandon"}, status: http.StatusOK},
+		{op: "GET /api/quick/history", url: "/api/quick/history?limit=500", token: "alice",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/quick/history", url: "/api/quick/history?limit=10", token: "alice", status: http.StatusOK,
+			save: map[string]string{"quick_last_verb": "commands.0.command.verb"}},
+
+		// Organization with alice as instructor and bob as student
+		{op: "POST /api/orgs", url: "/api/orgs", token: "alice",
+			body: obj{"name": ""}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/orgs", url: "/api/orgs", token: "alice",
+			body: obj{"name": "Algorithms 101"}, status: http.StatusCreated,
+			save: map[string]string{"org_id": "id"}},
+		{op: "POST /api/orgs/:id/invites", url: "/api/orgs/{org_id}/invites", token: "alice", status: http.StatusCreated,
+			save: map[string]string{"org_invite": "code"}},
+		{op: "POST /api/orgs/:id/invites", url: "/api/orgs/{org_id}/invites", token: "alice",
+			body: obj{"role": "student"}, status: http.StatusCreated,
+			save: map[string]string{"org_invite_revoked": "code"}},
+		{op: "DELETE /api/orgs/:id/invites/:code", url: "/api/orgs/{org_id}/invites/{org_invite_revoked}", token: "alice", status: http.StatusOK},
+		{op: "DELETE /api/orgs/:id/invites/:code", url: "/api/orgs/{org_id}/invites/{org_invite_revoked}", token: "alice",
+			status: http.StatusNotFound, code: "ORG_INVITE_NOT_FOUND"},
+		{op: "GET /api/orgs/:id", url: "/api/orgs/{org_id}", token: "bob", status: http.StatusNotFound, code: "ORG_NOT_FOUND"},
+		{op: "POST /api/orgs/join", url: "/api/orgs/join", token: "bob",
+			body: obj{"code": "{org_invite_revoked}"}, status: http.StatusNotFound, code: "ORG_INVITE_NOT_FOUND"},
+		{op: "POST /api/orgs/join", url: "/api/orgs/join", token: "bob",
+			body: obj{"code": "{org_invite}"}, status: http.StatusOK},
+		{op: "POST /api/orgs/join", url: "/api/orgs/join", token: "bob",
+			body: obj{"code": "{org_invite}"}, status: http.StatusConflict, code: "ALREADY_ORG_MEMBER"},
+		{op: "GET /api/orgs", url: "/api/orgs", token: "bob", status: http.StatusOK,
+			save: map[string]string{"org_role": "orgs.0.role"}},
+		{op: "GET /api/orgs/:id", url: "/api/orgs/{org_id}", token: "bob", status: http.StatusOK},
+		{op: "GET /api/orgs/:id", url: "/api/orgs/not-a-uuid", token: "bob", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/orgs/:id/invites", url: "/api/orgs/{org_id}/invites", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice",
+			body: obj{"kind": "contest", "title": "Week 1"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "bob",
+			body: obj{"kind": "study_plan", "title": "Arrays", "category_id": "{category_id}"}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice",
+			body: obj{"kind": "study_plan", "title": "Arrays", "category_id": "{category_id}", "due_at": "2030-01-01T00:00:00Z"}, status: http.StatusCreated,
+			save: map[string]string{"plan_assignment": "id"}},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice",
+			body: obj{"kind": "contest", "title": "Week 1", "contest": obj{"problem_count": 2, "duration_minutes": 30}}, status: http.StatusCreated,
+			save: map[string]string{"contest_assignment": "id"}},
+		{op: "POST /api/orgs/:id/assignments/:assignmentId/start", url: "/api/orgs/{org_id}/assignments/{plan_assignment}/start", token: "bob",
+			status: http.StatusBadRequest, code: "NOT_CONTEST_ASSIGNMENT"},
+		{op: "POST /api/orgs/:id/assignments/:assignmentId/start", url: "/api/orgs/{org_id}/assignments/{contest_assignment}/start", token: "bob",
+			status: http.StatusCreated, save: map[string]string{"assignment_contest": "id"}},
+		{op: "POST /api/orgs/:id/assignments/:assignmentId/start", url: "/api/orgs/{org_id}/assignments/{contest_assignment}/start", token: "bob",
+			status: http.StatusConflict, code: "ASSIGNMENT_STARTED"},
+		{op: "POST /api/contests/:id/events", url: "/api/contests/{assignment_contest}/events", token: "bob",
+			body: obj{"events": []obj{{"type": "blur", "occurred_at": "2030-01-01T00:00:00Z"}}}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/contests/:id/events", url: "/api/contests/{assignment_contest}/events", token: "alice",
+			body: obj{"events": []obj{{"type": "focus_lost", "occurred_at": "2030-01-01T00:00:00Z"}}}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/contests/:id/events", url: "/api/contests/{assignment_contest}/events", token: "bob",
+			body: obj{"events": []obj{
+				{"type": "tab_hidden", "occurred_at": "2020-01-01T00:00:00Z"},
+				{"type": "tab_visible", "occurred_at": "2030-01-01T00:00:00Z"},
+			}}, status: http.StatusOK, save: map[string]string{"events_recorded": "recorded"}},
+		{op: "POST /api/contests/:id/complete", url: "/api/contests/{assignment_contest}/complete", token: "bob", status: http.StatusOK},
+		{op: "POST /api/contests/:id/events", url: "/api/contests/{assignment_contest}/events", token: "bob",
+			body: obj{"events": []obj{{"type": "focus_lost", "occurred_at": "2030-01-01T00:00:00Z"}}}, status: http.StatusBadRequest, code: "CONTEST_NOT_ACTIVE"},
+		{op: "GET /api/orgs/:id/contests/:contestId/proctoring", url: "/api/orgs/{org_id}/contests/{assignment_contest}/proctoring", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/orgs/:id/contests/:contestId/proctoring", url: "/api/orgs/{org_id}/contests/{assignment_contest}/proctoring", token: "alice", status: http.StatusOK,
+			save: map[string]string{"tab_switches": "tab_switches"}},
+		{op: "GET /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "bob", status: http.StatusOK,
+			save: map[string]string{"assignment_status": "assignments.0.status"}},
+		{op: "GET /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice", status: http.StatusOK,
+			save: map[string]string{"assignment_completed": "assignments.0.completed_count"}},
+		{op: "GET /api/orgs/:id/roster", url: "/api/orgs/{org_id}/roster", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/orgs/:id/roster", url: "/api/orgs/{org_id}/roster?limit=500", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/orgs/:id/roster", url: "/api/orgs/{org_id}/roster?limit=10", token: "alice", status: http.StatusOK,
+			save: map[string]string{"roster_completed": "members.0.assignments_completed"}},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice",
+			body:   obj{"kind": "problem_set", "title": "Warmup set", "problems": []string{"{problem_id}"}, "duration_minutes": 30, "due_at": "2020-01-01T00:00:00Z"},
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice",
+			body:   obj{"kind": "problem_set", "title": "Warmup set", "problems": []string{"{problem_id}"}, "duration_minutes": 30, "due_at": "2030-01-01T00:00:00Z"},
+			status: http.StatusCreated, save: map[string]string{"set_assignment": "id"}},
+		{op: "GET /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "bob", status: http.StatusOK,
+			save: map[string]string{"set_contest": "assignments.0.contest_id"}},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/report", url: "/api/orgs/{org_id}/assignments/{set_assignment}/report", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/report", url: "/api/orgs/{org_id}/assignments/{set_assignment}/report?format=xml", token: "alice",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/report", url: "/api/orgs/{org_id}/assignments/{set_assignment}/report", token: "alice", status: http.StatusOK,
+			save: map[string]string{"set_not_started": "not_started"}},
+		{op: "POST /api/contests/:id/start", url: "/api/contests/{set_contest}/start", token: "bob", status: http.StatusOK},
+		{op: "POST /api/orgs/:id/assignments/:assignmentId/start", url: "/api/orgs/{org_id}/assignments/{set_assignment}/start", token: "bob",
+			status: http.StatusConflict, code: "ASSIGNMENT_STARTED"},
+		{op: "POST /api/contests/:id/complete", url: "/api/contests/{set_contest}/complete", token: "bob", status: http.StatusOK},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/report", url: "/api/orgs/{org_id}/assignments/{set_assignment}/report", token: "alice", status: http.StatusOK,
+			save: map[string]string{"set_completed": "completed"}},
+		{op: "DELETE /api/orgs/:id/assignments/:assignmentId", url: "/api/orgs/{org_id}/assignments/{plan_assignment}", token: "alice", status: http.StatusOK},
+		{op: "DELETE /api/orgs/:id/assignments/:assignmentId", url: "/api/orgs/{org_id}/assignments/{plan_assignment}", token: "alice",
+			status: http.StatusNotFound, code: "ASSIGNMENT_NOT_FOUND"},
+		{op: "DELETE /api/orgs/:id/members/:userId", url: "/api/orgs/{org_id}/members/{alice_id}", token: "alice", status: http.StatusConflict, code: "ORG_OWNER"},
+		{op: "DELETE /api/orgs/:id/members/:userId", url: "/api/orgs/{org_id}/members/{bob_id}", token: "bob", status: http.StatusOK},
+		{op: "GET /api/orgs", url: "/api/orgs", token: "bob", status: http.StatusOK},
+
+		// Mentorship with alice as mentor and bob as mentee
+		{op: "POST /api/mentorships", url: "/api/mentorships", token: "alice",
+			body: obj{"mentee_email": "alice@example.com"}, status: http.StatusBadRequest, code: "SELF_MENTORSHIP"},
+		{op: "POST /api/mentorships", url: "/api/mentorships", token: "alice",
+			body: obj{"mentee_email": "bob@example.com"}, status: http.StatusCreated,
+			save: map[string]string{"mentorship_id": "id"}},
+		{op: "POST /api/mentorships", url: "/api/mentorships", token: "alice",
+			body: obj{"mentee_email": "bob@example.com"}, status: http.StatusConflict, code: "MENTORSHIP_EXISTS"},
+		{op: "GET /api/mentorships/:id/contests", url: "/api/mentorships/{mentorship_id}/contests", token: "alice",
+			status: http.StatusForbidden, code: "MENTORSHIP_NOT_ACTIVE"},
+		{op: "POST /api/mentorships/:id/accept", url: "/api/mentorships/{mentorship_id}/accept", token: "alice",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/mentorships/:id/accept", url: "/api/mentorships/{mentorship_id}/accept", token: "bob", status: http.StatusOK},
+		{op: "POST /api/mentorships/:id/decline", url: "/api/mentorships/{mentorship_id}/decline", token: "bob",
+			status: http.StatusConflict, code: "MENTORSHIP_NOT_PENDING"},
+		{op: "GET /api/mentorships", url: "/api/mentorships", token: "bob", status: http.StatusOK,
+			save: map[string]string{"mentor_username": "mentors.0.mentor_username"}},
+		{op: "GET /api/mentorships/:id/contests", url: "/api/mentorships/{mentorship_id}/contests", token: "alice", status: http.StatusOK},
+		{op: "GET /api/mentorships/:id/contests", url: "/api/mentorships/{mentorship_id}/contests", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/mentorships/:id/contests/:contestId", url: "/api/mentorships/{mentorship_id}/contests/{assignment_contest}", token: "alice", status: http.StatusOK},
+		{op: "GET /api/mentorships/:id/contests/:contestId", url: "/api/mentorships/{mentorship_id}/contests/not-a-uuid", token: "alice",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/mentorships/:id/timing", url: "/api/mentorships/{mentorship_id}/timing", token: "alice", status: http.StatusOK},
+		{op: "GET /api/mentorships/:id/notes", url: "/api/mentorships/{mentorship_id}/notes", token: "alice", status: http.StatusOK},
+		{op: "GET /api/mentorships/:id/audit", url: "/api/mentorships/{mentorship_id}/audit?limit=500", token: "bob",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/mentorships/:id/audit", url: "/api/mentorships/{mentorship_id}/audit", token: "bob", status: http.StatusOK,
+			save: map[string]string{"mentor_last_read": "entries.0.action"}},
+		{op: "POST /api/mentorships/:id/assignments", url: "/api/mentorships/{mentorship_id}/assignments", token: "bob",
+			body:   obj{"kind": "contest", "title": "Mock interview", "contest": obj{"problem_count": 2, "duration_minutes": 30}, "due_at": "2030-01-01T00:00:00Z"},
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/mentorships/:id/assignments", url: "/api/mentorships/{mentorship_id}/assignments", token: "alice",
+			body:   obj{"kind": "contest", "title": "Mock interview", "contest": obj{"problem_count": 2, "duration_minutes": 30}},
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/mentorships/:id/assignments", url: "/api/mentorships/{mentorship_id}/assignments", token: "alice",
+			body:   obj{"kind": "contest", "title": "Mock interview", "contest": obj{"problem_count": 2, "duration_minutes": 30}, "due_at": "2030-01-01T00:00:00Z"},
+			status: http.StatusCreated, save: map[string]string{"mentor_contest_assignment": "id"}},
+		{op: "POST /api/mentorships/:id/assignments", url: "/api/mentorships/{mentorship_id}/assignments", token: "alice",
+			body:   obj{"kind": "problem_set", "title": "Review set", "problems": []string{"{problem_id}"}, "duration_minutes": 30, "due_at": "2030-01-01T00:00:00Z"},
+			status: http.StatusCreated, save: map[string]string{"mentor_set_assignment": "id", "mentor_set_contest": "contest_id"}},
+		{op: "GET /api/assignments", url: "/api/assignments", token: "bob", status: http.StatusOK,
+			save: map[string]string{"pending_source": "assignments.0.source"}},
+		{op: "POST /api/mentorships/:id/assignments/:assignmentId/start", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_contest_assignment}/start", token: "alice",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/mentorships/:id/assignments/:assignmentId/start", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_contest_assignment}/start", token: "bob",
+			status: http.StatusCreated, save: map[string]string{"mentor_contest": "id"}},
+		{op: "POST /api/mentorships/:id/assignments/:assignmentId/start", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_contest_assignment}/start", token: "bob",
+			status: http.StatusConflict, code: "ASSIGNMENT_STARTED"},
+		{op: "POST /api/contests/:id/complete", url: "/api/contests/{mentor_contest}/complete", token: "bob", status: http.StatusOK},
+		{op: "POST /api/mentorships/:id/assignments/:assignmentId/start", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_set_assignment}/start", token: "bob",
+			status: http.StatusCreated},
+		{op: "POST /api/contests/:id/complete", url: "/api/contests/{mentor_set_contest}/complete", token: "bob", status: http.StatusOK},
+		{op: "GET /api/mentorships/:id/assignments", url: "/api/mentorships/{mentorship_id}/assignments", token: "alice", status: http.StatusOK,
+			save: map[string]string{"mentor_assignment_status": "assignments.0.status"}},
+		{op: "DELETE /api/mentorships/:id/assignments/:assignmentId", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_set_assignment}", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "DELETE /api/mentorships/:id/assignments/:assignmentId", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_set_assignment}", token: "alice", status: http.StatusOK},
+		{op: "DELETE /api/mentorships/:id/assignments/:assignmentId", url: "/api/mentorships/{mentorship_id}/assignments/{mentor_set_assignment}", token: "alice",
+			status: http.StatusNotFound, code: "ASSIGNMENT_NOT_FOUND"},
+		{op: "DELETE /api/mentorships/:id", url: "/api/mentorships/{mentorship_id}", token: "bob", status: http.StatusOK},
+		{op: "DELETE /api/mentorships/:id", url: "/api/mentorships/{mentorship_id}", token: "alice",
+			status: http.StatusForbidden, code: "MENTORSHIP_NOT_ACTIVE"},
+		{op: "GET /api/mentorships/:id/timing", url: "/api/mentorships/{mentorship_id}/timing", token: "alice",
+			status: http.StatusForbidden, code: "MENTORSHIP_NOT_ACTIVE"},
+		{op: "POST /api/mentorships", url: "/api/mentorships", token: "alice",
+			body: obj{"mentee_email": "bob@example.com"}, status: http.StatusCreated},
+		{op: "POST /api/mentorships/:id/decline", url: "/api/mentorships/{mentorship_id}/decline", token: "bob", status: http.StatusOK},
+
+		// Premium through Stripe checkout and subscription webhooks
+		{op: "POST /api/billing/checkout", url: "/api/billing/checkout", token: "bob", status: http.StatusCreated},
+		{op: "POST /api/billing/webhook", url: "/api/billing/webhook",
+			body: checkoutCompleted, status: http.StatusBadRequest, code: "INVALID_SIGNATURE"},
+		{op: "POST /api/billing/webhook", url: "/api/billing/webhook", signed: true,
+			body: checkoutCompleted, status: http.StatusOK},
+		{op: "POST /api/billing/checkout", url: "/api/billing/checkout", token: "bob", status: http.StatusConflict, code: "ALREADY_SUBSCRIBED"},
+		{op: "POST /api/billing/webhook", url: "/api/billing/webhook", signed: true,
+			body: subscriptionEvent("evt_sub_updated", "customer.subscription.updated", 1700000100, "past_due"), status: http.StatusOK},
+		{op: "POST /api/billing/webhook", url: "/api/billing/webhook", signed: true,
+			body: subscriptionEvent("evt_sub_updated", "customer.subscription.updated", 1700000100, "past_due"), status: http.StatusOK},
+		{op: "GET /api/users/me/quotas", url: "/api/users/me/quotas", token: "bob", status: http.StatusOK},
+		{op: "POST /api/billing/webhook", url: "/api/billing/webhook", signed: true,
+			body: subscriptionEvent("evt_sub_deleted", "customer.subscription.deleted", 1700000200, "canceled"), status: http.StatusOK},
+		{op: "POST /api/billing/checkout", url: "/api/billing/checkout", token: "bob", status: http.StatusCreated},
+
+		// Password change invalidates nothing but the old password
+		{op: "PUT /api/users/me/password", url: "/api/users/me/password", token: "alice",
+			body: obj{"current_password": "wrong", "new_password": newPassword}, status: http.StatusUnauthorized},
+		{op: "PUT /api/users/me/password", url: "/api/users/me/password", token: "alice",
+			body: obj{"current_password": password, "new_password": newPassword}, status: http.StatusOK},
+		login("alice", "alice@example.com", newPassword),
+	}
+}
+
+// checkoutCompleted is the webhook event of bob paying for premium
+var checkoutCompleted = obj{"id": "evt_checkout", "type": "checkout.session.completed", "created": 1700000000,
+	"data": obj{"object": obj{"id": "cs_test_contract", "mode": "subscription", "payment_status": "paid",
+		"client_reference_id": "{bob_id}", "customer": "cus_bob", "subscription": "sub_bob"}}}
+
+// subscriptionEvent builds a webhook event about bob's subscription
+func subscriptionEvent(id, eventType string, created int64, status string) obj {
+	return obj{"id": id, "type": eventType, "created": created,
+		"data": obj{"object": obj{"id": "sub_bob", "customer": "cus_bob", "status": status,
+			"current_period_end": 4102444800, "metadata": obj{"user_id": "{bob_id}"}}}}
+}
+
+// similarSolutionA and similarSolutionB are the same two-sum solution with
+// renamed variables and different comments, which the similarity check flags
+const (
+	similarSolutionA = `def two_sum(nums, target):
+    # remember where each value was seen
+    seen = dict()
+    for i, num in enumerate(nums):
+        need = target - num
+        if need in seen:
+            return [seen[need], i]
+        seen[num] = i
+    return []
+`
+	similarSolutionB = `def solve(arr, goal):
+    index = dict()  # value -> position
+    for j, value in enumerate(arr):
+        other = goal - value
+        if other in index:
+            return [index[other], j]
+        index[value] = j
+    return []
+`
+)
+
+// scenarioAfterAdmin covers the admin endpoints and token revocation; alice has
+// been promoted to admin and signs in again to receive the role in her token
+func scenarioAfterAdmin() []step {
+	return []step{
+		{op: "POST /api/auth/login", url: "/api/auth/login",
+			body: obj{"email": "alice@example.com", "password": newPassword}, status: http.StatusOK,
+			save: map[string]string{"alice": "tokens.access_token", "alice_refresh": "tokens.refresh_token"}},
+
+		// Admin
+		{op: "GET /api/admin/problems/calibration", url: "/api/admin/problems/calibration", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/problems/calibration", url: "/api/admin/problems/calibration", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/admin/problems/:id/companies", url: "/api/admin/problems/{problem_id}/companies", token: "alice",
+			body: obj{"companies": []string{"Amazon", "Google"}}, status: http.StatusOK},
+		{op: "PATCH /api/admin/problems/:id/importance", url: "/api/admin/problems/{problem_id}/importance", token: "alice",
+			body: obj{"importance": 500}, status: http.StatusBadRequest},
+		{op: "PATCH /api/admin/problems/:id/importance", url: "/api/admin/problems/{problem_id}/importance", token: "alice",
+			body: obj{"importance": 80}, status: http.StatusOK},
+		{op: "PUT /api/admin/problems/:id/complexity", url: "/api/admin/problems/{contest_problem}/complexity", token: "bob",
+			body: obj{"time_complexity": "O(n log n)"}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "PUT /api/admin/problems/:id/complexity", url: "/api/admin/problems/{contest_problem}/complexity", token: "alice",
+			body: obj{"time_complexity": "O(nlogn)", "space_complexity": "O(n)"}, status: http.StatusOK},
+		{op: "GET /api/contests/:id", url: "/api/contests/{contest_id}", token: "alice", status: http.StatusOK,
+			save: map[string]string{"complexity_correct": "complexity_score.correct"}},
+		{op: "GET /api/admin/feature-flags", url: "/api/admin/feature-flags", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/feature-flags", url: "/api/admin/feature-flags", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/admin/feature-flags/:key", url: "/api/admin/feature-flags/no-such-flag", token: "alice",
+			body: obj{"enabled": true}, status: http.StatusNotFound, code: "FEATURE_FLAG_NOT_FOUND"},
+		{op: "PUT /api/admin/feature-flags/:key", url: "/api/admin/feature-flags/duels", token: "alice",
+			body: obj{"enabled": true, "rollout_percent": 150}, status: http.StatusBadRequest},
+		{op: "PUT /api/admin/feature-flags/:key", url: "/api/admin/feature-flags/duels", token: "alice",
+			body: obj{"enabled": true, "rollout_percent": 100}, status: http.StatusOK},
+		{op: "GET /api/users/me/features", url: "/api/users/me/features", token: "bob", status: http.StatusOK},
+		{op: "GET /api/admin/experiments", url: "/api/admin/experiments", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/experiments", url: "/api/admin/experiments", token: "alice", status: http.StatusOK},
+		{op: "GET /api/admin/analytics/cohorts", url: "/api/admin/analytics/cohorts", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/analytics/cohorts", url: "/api/admin/analytics/cohorts", token: "alice", status: http.StatusOK},
+		{op: "GET /api/admin/log-level", url: "/api/admin/log-level", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/log-level", url: "/api/admin/log-level", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/admin/log-level", url: "/api/admin/log-level", token: "alice",
+			body: obj{"level": "verbose"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PUT /api/admin/log-level", url: "/api/admin/log-level", token: "alice",
+			body: obj{"level": "debug", "duration_minutes": 15}, status: http.StatusOK},
+		{op: "PUT /api/admin/log-level", url: "/api/admin/log-level", token: "alice",
+			body: obj{"level": "default"}, status: http.StatusOK},
+		{op: "POST /api/admin/integrity", url: "/api/admin/integrity", token: "bob",
+			body: obj{"dry_run": true}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/admin/integrity", url: "/api/admin/integrity", token: "alice",
+			body: obj{"dry_run": "yes"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/admin/integrity", url: "/api/admin/integrity", token: "alice",
+			body: obj{"dry_run": true}, status: http.StatusOK},
+		{op: "POST /api/admin/integrity", url: "/api/admin/integrity", token: "alice", status: http.StatusOK,
+			save: map[string]string{"integrity_issue": "findings.0.issue"}},
+		{op: "POST /api/admin/retention", url: "/api/admin/retention", token: "bob",
+			body: obj{"dry_run": true}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/admin/retention", url: "/api/admin/retention", token: "alice",
+			body: obj{"dry_run": 1}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/admin/retention", url: "/api/admin/retention", token: "alice",
+			body: obj{"dry_run": true}, status: http.StatusOK},
+		{op: "POST /api/admin/retention", url: "/api/admin/retention", token: "alice", status: http.StatusOK},
+		{op: "GET /api/admin/backups", url: "/api/admin/backups", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/admin/backups", url: "/api/admin/backups", token: "alice", status: http.StatusCreated,
+			save: map[string]string{"backup_id": "id"}},
+		{op: "GET /api/admin/backups", url: "/api/admin/backups", token: "alice", status: http.StatusOK},
+		{op: "POST /api/admin/backups/:id/verify", url: "/api/admin/backups/{backup_id}/verify", token: "alice", status: http.StatusOK},
+		{op: "POST /api/admin/backups/:id/verify", url: "/api/admin/backups/20000101T000000Z/verify", token: "alice",
+			status: http.StatusNotFound, code: "BACKUP_NOT_FOUND"},
+
+		// Community problem lists: alice publishes one, bob subscribes, builds a
+		// contest from it and reports it, and alice moderates it as admin
+		{op: "POST /api/lists", url: "/api/lists", token: "alice",
+			body: obj{"title": "Warm-ups", "problems": []string{}}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/lists", url: "/api/lists", token: "alice",
+			body: obj{"title": "Warm-ups", "description": "Short ones first", "problems": []string{"{problem_id}", "two-sum"}}, status: http.StatusCreated,
+			save: map[string]string{"list_id": "id"}},
+		{op: "GET /api/lists", url: "/api/lists?q=warm&sort=newest", token: "bob", status: http.StatusOK},
+		{op: "GET /api/lists", url: "/api/lists?sort=oldest", token: "bob", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/lists/:id", url: "/api/lists/{list_id}", token: "bob", status: http.StatusOK},
+		{op: "GET /api/lists/:id", url: "/api/lists/not-a-uuid", token: "bob", status: http.StatusBadRequest},
+		{op: "PUT /api/lists/:id", url: "/api/lists/{list_id}", token: "bob",
+			body: obj{"title": "Mine now", "problems": []string{"two-sum"}}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "PUT /api/lists/:id", url: "/api/lists/{list_id}", token: "alice",
+			body: obj{"title": "Warm-ups", "description": "Short ones first", "problems": []string{"two-sum", "{problem_id}"}}, status: http.StatusOK},
+		{op: "POST /api/lists/:id/contests", url: "/api/lists/{list_id}/contests", token: "bob",
+			body: obj{"problem_count": 2, "duration_minutes": 30}, status: http.StatusForbidden, code: "LIST_NOT_SUBSCRIBED"},
+		{op: "PUT /api/lists/:id/subscription", url: "/api/lists/{list_id}/subscription", token: "alice",
+			status: http.StatusBadRequest, code: "OWN_LIST"},
+		{op: "PUT /api/lists/:id/subscription", url: "/api/lists/{list_id}/subscription", token: "bob", status: http.StatusOK},
+		{op: "POST /api/lists/:id/contests", url: "/api/lists/{list_id}/contests", token: "bob",
+			body: obj{"problem_count": 2, "duration_minutes": 30, "include_solved": true}, status: http.StatusCreated,
+			save: map[string]string{"bob_list_contest": "id"}},
+		{op: "POST /api/contests/:id/abandon", url: "/api/contests/{bob_list_contest}/abandon", token: "bob", status: http.StatusOK},
+		{op: "POST /api/lists/:id/reports", url: "/api/lists/{list_id}/reports", token: "bob",
+			body: obj{"reason": "Duplicate of another list"}, status: http.StatusOK},
+		{op: "POST /api/lists/:id/reports", url: "/api/lists/{list_id}/reports", token: "bob",
+			body: obj{"reason": "Still a duplicate"}, status: http.StatusConflict, code: "LIST_ALREADY_REPORTED"},
+		{op: "GET /api/admin/lists", url: "/api/admin/lists", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/lists", url: "/api/admin/lists", token: "alice", status: http.StatusOK},
+		{op: "PATCH /api/admin/lists/:id", url: "/api/admin/lists/{list_id}", token: "alice",
+			body: obj{"status": "hidden", "reason": "Duplicate"}, status: http.StatusOK},
+		{op: "GET /api/lists/:id", url: "/api/lists/{list_id}", token: "bob", status: http.StatusNotFound, code: "LIST_NOT_FOUND"},
+		{op: "PATCH /api/admin/lists/:id", url: "/api/admin/lists/{list_id}", token: "alice",
+			body: obj{"status": "published"}, status: http.StatusOK},
+		{op: "DELETE /api/lists/:id/subscription", url: "/api/lists/{list_id}/subscription", token: "bob", status: http.StatusOK},
+		{op: "DELETE /api/lists/:id", url: "/api/lists/{list_id}", token: "bob", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "DELETE /api/lists/:id", url: "/api/lists/{list_id}", token: "alice", status: http.StatusOK},
+
+		// Recommendation digests
+		{op: "GET /api/users/me/digest", url: "/api/users/me/digest", token: "bob", status: http.StatusOK},
+		{op: "PUT /api/users/me/digest", url: "/api/users/me/digest", token: "bob",
+			body: obj{"enabled": "yes"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PUT /api/users/me/digest", url: "/api/users/me/digest", token: "bob",
+			body: obj{"enabled": true}, status: http.StatusOK},
+		{op: "POST /api/admin/digests/send", url: "/api/admin/digests/send", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/admin/digests/send", url: "/api/admin/digests/send", token: "alice", status: http.StatusOK},
+		{op: "GET /api/users/me/digest", url: "/api/users/me/digest", token: "bob", status: http.StatusOK,
+			save: map[string]string{"digest_click": "latest.items.0.click_url"}},
+		{op: "GET /api/digests/click/:token", url: "{digest_click}", status: http.StatusFound},
+		{op: "GET /api/digests/click/:token", url: "/api/digests/click/unknown", status: http.StatusFound},
+		{op: "GET /api/admin/digests/stats", url: "/api/admin/digests/stats?days=400", token: "alice",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/admin/digests/stats", url: "/api/admin/digests/stats?days=7", token: "alice", status: http.StatusOK,
+			save: map[string]string{"digest_clicks": "clicks"}},
+		{op: "PUT /api/users/me/digest", url: "/api/users/me/digest", token: "bob",
+			body: obj{"enabled": false}, status: http.StatusOK},
+		// Duplicate solution detection: bob rejoins the class, and he and alice
+		// attach the same solution with renamed variables to a problem set
+		{op: "POST /api/orgs/join", url: "/api/orgs/join", token: "bob",
+			body: obj{"code": "{org_invite}"}, status: http.StatusOK},
+		{op: "POST /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "alice",
+			body:   obj{"kind": "problem_set", "title": "Pairs", "problems": []string{"{problem_id}"}, "duration_minutes": 30, "due_at": "2030-01-01T00:00:00Z"},
+			status: http.StatusCreated, save: map[string]string{"similar_assignment": "id"}},
+		{op: "GET /api/orgs/:id/assignments", url: "/api/orgs/{org_id}/assignments", token: "bob", status: http.StatusOK,
+			save: map[string]string{"similar_contest": "assignments.0.contest_id"}},
+		{op: "POST /api/orgs/:id/assignments/:assignmentId/start", url: "/api/orgs/{org_id}/assignments/{similar_assignment}/start", token: "alice",
+			status: http.StatusCreated, save: map[string]string{"alice_similar_contest": "id"}},
+		{op: "GET /api/contests/:id/problems/:problemId/solution", url: "/api/contests/{similar_contest}/problems/{problem_id}/solution", token: "bob",
+			status: http.StatusNotFound, code: "SOLUTION_NOT_FOUND"},
+		{op: "PUT /api/contests/:id/problems/:problemId/solution", url: "/api/contests/{similar_contest}/problems/{problem_id}/solution", token: "bob",
+			body: obj{"language": "python"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PUT /api/contests/:id/problems/:problemId/solution", url: "/api/contests/{similar_contest}/problems/{problem_id}/solution", token: "alice",
+			body: obj{"language": "python", "code": similarSolutionA}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "PUT /api/contests/:id/problems/:problemId/solution", url: "/api/contests/{similar_contest}/problems/{problem_id}/solution", token: "bob",
+			body: obj{"language": "python", "code": similarSolutionA}, status: http.StatusOK},
+		{op: "GET /api/contests/:id/problems/:problemId/solution", url: "/api/contests/{similar_contest}/problems/{problem_id}/solution", token: "bob",
+			status: http.StatusOK, save: map[string]string{"solution_language": "language"}},
+		{op: "GET /api/users/me/search", url: "/api/users/me/search?q=seen&limit=5", token: "bob", status: http.StatusOK,
+			save: map[string]string{"note_problem": "results.0.problem_title"}},
+		{op: "PUT /api/contests/:id/problems/:problemId/solution", url: "/api/contests/{alice_similar_contest}/problems/{problem_id}/solution", token: "alice",
+			body: obj{"language": "python", "code": similarSolutionB}, status: http.StatusOK},
+		{op: "POST /api/admin/similarity/check", url: "/api/admin/similarity/check", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/admin/similarity/check", url: "/api/admin/similarity/check", token: "alice", status: http.StatusOK,
+			save: map[string]string{"similarity_flagged": "flagged"}},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/similarity", url: "/api/orgs/{org_id}/assignments/{similar_assignment}/similarity", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/similarity", url: "/api/orgs/{org_id}/assignments/{similar_assignment}/similarity?status=copied", token: "alice",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "GET /api/orgs/:id/assignments/:assignmentId/similarity", url: "/api/orgs/{org_id}/assignments/{similar_assignment}/similarity?status=pending", token: "alice",
+			status: http.StatusOK, save: map[string]string{"similarity_flag": "flags.0.id"}},
+		{op: "GET /api/orgs/:id/similarity/:flagId", url: "/api/orgs/{org_id}/similarity/{similarity_flag}", token: "alice", status: http.StatusOK,
+			save: map[string]string{"similar_snippet": "snippet_a.code"}},
+		{op: "GET /api/orgs/:id/similarity/:flagId", url: "/api/orgs/{org_id}/similarity/{org_id}", token: "alice",
+			status: http.StatusNotFound, code: "SIMILARITY_FLAG_NOT_FOUND"},
+		{op: "PATCH /api/orgs/:id/similarity/:flagId", url: "/api/orgs/{org_id}/similarity/{similarity_flag}", token: "alice",
+			body: obj{"status": "copied"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PATCH /api/orgs/:id/similarity/:flagId", url: "/api/orgs/{org_id}/similarity/{similarity_flag}", token: "alice",
+			body: obj{"status": "dismissed", "note": "Pair programming was allowed"}, status: http.StatusOK},
+
+		// Tenants: a private instance with its own users, problems and leaderboard
+		{op: "GET /api/tenant", url: "/api/tenant", status: http.StatusNotFound, code: "TENANT_NOT_FOUND"},
+		{op: "POST /api/admin/tenants", url: "/api/admin/tenants", token: "alice",
+			body: obj{"slug": "Acme Corp", "name": "Acme", "admin_email": "carol@example.com"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "POST /api/admin/tenants", url: "/api/admin/tenants", token: "alice",
+			body: obj{"slug": "acme", "name": "Acme", "admin_email": "carol@example.com"}, status: http.StatusCreated},
+		{op: "POST /api/admin/tenants", url: "/api/admin/tenants", token: "alice",
+			body: obj{"slug": "acme", "name": "Acme again", "admin_email": "carol@example.com"}, status: http.StatusConflict, code: "TENANT_SLUG_TAKEN"},
+		{op: "GET /api/admin/tenants", url: "/api/admin/tenants", token: "alice", status: http.StatusOK},
+		{op: "GET /api/tenant", url: "/api/tenant", tenant: "nowhere", status: http.StatusNotFound, code: "TENANT_NOT_FOUND"},
+		{op: "GET /api/tenant", url: "/api/tenant", tenant: "acme", status: http.StatusOK},
+		{op: "POST /api/auth/signup", url: "/api/auth/signup", tenant: "acme",
+			body: obj{"email": "carol@example.com", "username": "carol", "password": password}, status: http.StatusCreated,
+			save: map[string]string{"carol": "tokens.access_token"}},
+		// Emails are unique per tenant, so bob can sign up in the tenant too
+		{op: "POST /api/auth/signup", url: "/api/auth/signup", tenant: "acme",
+			body: obj{"email": "bob@example.com", "username": "bob", "password": password}, status: http.StatusCreated,
+			save: map[string]string{"acme_bob": "tokens.access_token"}},
+		{op: "GET /api/users/me", url: "/api/users/me", token: "alice", tenant: "acme", status: http.StatusUnauthorized, code: "INVALID_TOKEN"},
+		{op: "GET /api/admin/tenants", url: "/api/admin/tenants", token: "carol", tenant: "acme", status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/tenant/problems", url: "/api/tenant/problems", token: "acme_bob", tenant: "acme",
+			body: obj{"title": "Acme Routing", "url": "https://example.com/acme-routing", "difficulty": "Medium"}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "POST /api/tenant/problems", url: "/api/tenant/problems", token: "carol", tenant: "acme",
+			body:   obj{"title": "Acme Routing", "url": "https://example.com/acme-routing", "difficulty": "Medium", "topics": []string{"Graphs"}},
+			status: http.StatusCreated, save: map[string]string{"acme_problem": "id"}},
+		{op: "GET /api/problems/:id", url: "/api/problems/{acme_problem}", tenant: "acme", status: http.StatusOK},
+		{op: "GET /api/problems/:id", url: "/api/problems/{acme_problem}", status: http.StatusNotFound, code: "PROBLEM_NOT_FOUND"},
+		{op: "GET /api/tenant/leaderboard", url: "/api/tenant/leaderboard?limit=10", token: "carol", tenant: "acme", status: http.StatusOK},
+		{op: "GET /api/tenant/leaderboard", url: "/api/tenant/leaderboard?limit=0", token: "alice", status: http.StatusOK},
+		{op: "GET /api/tenant/leaderboard", url: "/api/tenant/leaderboard?limit=500", token: "alice", status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+
+		// Activity feed: bob accepted alice's challenge, so her activity reaches his feed
+		{op: "GET /api/users/me/feed", url: "/api/users/me/feed?limit=1", token: "bob", status: http.StatusOK,
+			save: map[string]string{"feed_cursor": "next_cursor"}},
+		{op: "GET /api/users/me/feed", url: "/api/users/me/feed?cursor={feed_cursor}", token: "bob", status: http.StatusOK},
+		{op: "GET /api/users/me/feed", url: "/api/users/me/feed?cursor=latest", token: "bob",
+			status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+
+		{op: "GET /api/maintenance", url: "/api/maintenance", status: http.StatusOK},
+		{op: "PUT /api/admin/maintenance", url: "/api/admin/maintenance", token: "bob",
+			body: obj{"enabled": true}, status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "PUT /api/admin/maintenance", url: "/api/admin/maintenance", token: "alice",
+			body: obj{"enabled": true, "starts_at": "2030-01-02T00:00:00Z", "ends_at": "2030-01-01T00:00:00Z"}, status: http.StatusBadRequest, code: "INVALID_MAINTENANCE_WINDOW"},
+		{op: "PUT /api/admin/maintenance", url: "/api/admin/maintenance", token: "alice",
+			body: obj{"enabled": true, "message": "Database upgrade"}, status: http.StatusOK},
+		{op: "PATCH /api/admin/problems/:id/importance", url: "/api/admin/problems/{problem_id}/importance", token: "alice",
+			body: obj{"importance": 80}, status: http.StatusServiceUnavailable, code: "MAINTENANCE"},
+		{op: "PUT /api/admin/maintenance", url: "/api/admin/maintenance", token: "alice",
+			body: obj{"enabled": false}, status: http.StatusOK},
+		{op: "GET /api/users/me/quotas", url: "/api/users/me/quotas", token: "bob", status: http.StatusOK},
+		{op: "GET /api/admin/users/:id/quotas", url: "/api/admin/users/{bob_id}/quotas", token: "bob",
+			status: http.StatusForbidden, code: "FORBIDDEN"},
+		{op: "GET /api/admin/users/:id/quotas", url: "/api/admin/users/{bob_id}/quotas", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/admin/users/:id/quotas", url: "/api/admin/users/{bob_id}/quotas", token: "alice",
+			body: obj{"plan": "gold"}, status: http.StatusBadRequest, code: "VALIDATION_FAILED"},
+		{op: "PUT /api/admin/users/:id/quotas", url: "/api/admin/users/{bob_id}/quotas", token: "alice",
+			body: obj{"plan": "free", "contests_per_day": 1, "reason": "Contract check"}, status: http.StatusOK},
+		{op: "POST /api/contests", url: "/api/contests", token: "bob",
+			body: obj{"problem_count": 2, "duration_minutes": 30}, status: http.StatusForbidden, code: "QUOTA_EXCEEDED"},
+		{op: "DELETE /api/admin/users/:id/quotas", url: "/api/admin/users/{bob_id}/quotas", token: "alice", status: http.StatusOK},
+		{op: "PUT /api/admin/users/:id/quotas", url: "/api/admin/users/00000000-0000-0000-0000-000000000000/quotas", token: "alice",
+			body: obj{"plan": "premium"}, status: http.StatusNotFound, code: "USER_NOT_FOUND"},
+		{op: "PUT /api/admin/users/:id/quotas", url: "/api/admin/users/{bob_id}/quotas", token: "alice",
+			body: obj{"plan": "premium"}, status: http.StatusOK},
+		{op: "POST /api/admin/users/:id/revoke-tokens", url: "/api/admin/users/00000000-0000-0000-0000-000000000000/revoke-tokens", token: "alice",
+			status: http.StatusNotFound, code: "USER_NOT_FOUND"},
+		{op: "POST /api/admin/users/:id/revoke-tokens", url: "/api/admin/users/{bob_id}/revoke-tokens", token: "alice", status: http.StatusOK},
+		{op: "GET /api/users/me", url: "/api/users/me", token: "bob", status: http.StatusUnauthorized, code: "TOKEN_REVOKED"},
+
+		// Logout
+		{op: "POST /api/auth/logout", url: "/api/auth/logout", token: "alice",
+			body: obj{"refresh_token": "{alice_refresh}"}, status: http.StatusOK},
+		{op: "GET /api/users/me", url: "/api/users/me", token: "alice", status: http.StatusUnauthorized, code: "TOKEN_REVOKED"},
+		{op: "POST /api/auth/refresh", url: "/api/auth/refresh",
+			body: obj{"refresh_token": "{alice_refresh}"}, status: http.StatusUnauthorized, code: "TOKEN_REVOKED"},
+		{op: "POST /api/auth/login", url: "/api/auth/login",
+			body: obj{"email": "alice@example.com", "password": newPassword}, status: http.StatusOK,
+			save: map[string]string{"alice": "tokens.access_token"}},
+		{op: "POST /api/auth/logout-all", url: "/api/auth/logout-all", token: "alice", status: http.StatusOK},
+		{op: "GET /api/users/me", url: "/api/users/me", token: "alice", status: http.StatusUnauthorized, code: "TOKEN_REVOKED"},
+
+		// Documentation
+		{op: "GET /api/openapi.json", url: "/api/openapi.json", status: http.StatusOK},
+		{op: "GET /api/docs", url: "/api/docs", status: http.StatusOK},
+	}
+}
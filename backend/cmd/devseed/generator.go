@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+var (
+	contestSizes     = []int{3, 3, 5, 5, 5, 8, 10}
+	contestDurations = []int{30, 45, 60, 60, 90, 120}
+	contestTags      = []string{"mock-interview", "warmup", "weekend", "arrays", "graphs", "dp", "timed", "review"}
+	retros           = []string{
+		"Ran out of time on the hard one; practice sliding window.",
+		"Good pace. Need to review binary search edge cases.",
+		"Got stuck on the graph problem, revisit BFS vs DFS.",
+		"Solid session, two-pointer patterns feel natural now.",
+		"Spent too long on the first problem. Read all problems first next time.",
+	}
+)
+
+// generator creates the history of fake users
+type generator struct {
+	db       *gorm.DB
+	rng      *rand.Rand
+	now      time.Time
+	since    time.Time
+	problems []domain.Problem
+	hash     string
+	stats    stats
+}
+
+type stats struct {
+	active, completed, abandoned, submissions int
+}
+
+func (s stats) contests() int { return s.active + s.completed + s.abandoned }
+
+// user creates one user with contests, submissions and tags in a transaction
+func (g *generator) user(n int) error {
+	// Skill drives solve rates; activity drives how many contests the user ran
+	skill := 0.2 + g.rng.Float64()*0.75
+	activity := g.rng.Float64()
+
+	joined := g.between(g.since, g.now.Add(-24*time.Hour))
+	user := domain.User{
+		Email:        fmt.Sprintf("user%03d@%s", n, emailDomain),
+		Username:     fmt.Sprintf("dev_user_%03d", n),
+		PasswordHash: g.hash,
+		Role:         domain.RoleUser,
+		CreatedAt:    joined,
+		UpdatedAt:    joined,
+	}
+	if n == 1 {
+		user.Role = domain.RoleAdmin
+	}
+
+	return g.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		solved := make(map[uuid.UUID]bool)
+		contests := 1 + int(activity*activity*40)
+		// Sorted start times spread between joining and now
+		starts := make([]time.Time, contests)
+		for i := range starts {
+			starts[i] = g.between(joined, g.now.Add(-2*time.Hour))
+		}
+		sortTimes(starts)
+
+		for i, startedAt := range starts {
+			last := i == len(starts)-1
+			if err := g.contest(tx, user.ID, startedAt, last, skill, solved); err != nil {
+				return err
+			}
+		}
+		return g.practice(tx, user.ID, joined, skill, solved)
+	})
+}
+
+// contest creates one contest; the user's latest contest may still be running
+func (g *generator) contest(tx *gorm.DB, userID uuid.UUID, startedAt time.Time, last bool, skill float64, solved map[uuid.UUID]bool) error {
+	duration := pick(g.rng, contestDurations)
+	status := domain.ContestStatusCompleted
+	switch {
+	case last && g.rng.Float64() < 0.25:
+		// Still running: started recently enough that the timer has not run out
+		status = domain.ContestStatusActive
+		startedAt = g.now.Add(-time.Duration(g.rng.Intn(duration)) * time.Minute)
+	case g.rng.Float64() < 0.15:
+		status = domain.ContestStatusAbandoned
+	}
+
+	contest := domain.Contest{
+		UserID:          userID,
+		DurationMinutes: duration,
+		StartedAt:       startedAt,
+		Status:          status,
+		Ordering:        domain.OrderingAscending,
+		CreatedAt:       startedAt,
+		UpdatedAt:       startedAt,
+	}
+
+	// Solves happen at increasing offsets within the contest window
+	var submissions []domain.Submission
+	var lastSolve time.Duration
+	for i, p := range g.choose(pick(g.rng, contestSizes), solved) {
+		cp := domain.ContestProblem{ProblemID: p.ID, Order: i + 1}
+		window := time.Duration(duration) * time.Minute
+		if status == domain.ContestStatusAbandoned {
+			window /= 3
+		}
+		if status == domain.ContestStatusActive {
+			window = g.now.Sub(startedAt)
+		}
+		if g.rng.Float64() < solveChance(skill, p.Difficulty) && window > lastSolve {
+			lastSolve += time.Duration(g.rng.Int63n(int64(window-lastSolve))) + 1
+			if lastSolve <= window {
+				cp.IsCompleted = true
+				solved[p.ID] = true
+				submissions = append(submissions, domain.Submission{
+					UserID: userID, ProblemID: p.ID, ContestID: &contest.ID, SolvedAt: startedAt.Add(lastSolve),
+				})
+			}
+		}
+		contest.ContestProblems = append(contest.ContestProblems, cp)
+	}
+
+	switch status {
+	case domain.ContestStatusCompleted:
+		ended := startedAt.Add(time.Duration(duration) * time.Minute)
+		if lastSolve > 0 && g.rng.Float64() < 0.5 {
+			ended = startedAt.Add(lastSolve + time.Duration(g.rng.Intn(5))*time.Minute)
+		}
+		contest.EndedAt = &ended
+		if g.rng.Float64() < 0.3 {
+			contest.Retro = pick(g.rng, retros)
+			contest.RetroUpdatedAt = &ended
+		}
+		g.stats.completed++
+	case domain.ContestStatusAbandoned:
+		ended := startedAt.Add(lastSolve + time.Duration(1+g.rng.Intn(10))*time.Minute)
+		contest.EndedAt = &ended
+		g.stats.abandoned++
+	default:
+		g.stats.active++
+	}
+
+	// The ID is needed for the submissions, so create the contest first
+	if err := tx.Create(&contest).Error; err != nil {
+		return err
+	}
+	for i := range submissions {
+		submissions[i].ContestID = &contest.ID
+	}
+	if len(submissions) > 0 {
+		if err := tx.Create(&submissions).Error; err != nil {
+			return err
+		}
+	}
+	g.stats.submissions += len(submissions)
+
+	if g.rng.Float64() < 0.4 {
+		var tags []domain.ContestTag
+		for _, tag := range distinct(g.rng, contestTags, 1+g.rng.Intn(2)) {
+			tags = append(tags, domain.ContestTag{ContestID: contest.ID, Tag: tag, CreatedAt: startedAt})
+		}
+		if err := tx.Create(&tags).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// practice adds a few problems solved outside any contest
+func (g *generator) practice(tx *gorm.DB, userID uuid.UUID, joined time.Time, skill float64, solved map[uuid.UUID]bool) error {
+	var submissions []domain.Submission
+	for _, p := range g.choose(g.rng.Intn(6), solved) {
+		if g.rng.Float64() < solveChance(skill, p.Difficulty) {
+			solved[p.ID] = true
+			submissions = append(submissions, domain.Submission{UserID: userID, ProblemID: p.ID, SolvedAt: g.between(joined, g.now)})
+		}
+	}
+	if len(submissions) == 0 {
+		return nil
+	}
+	g.stats.submissions += len(submissions)
+	return tx.Create(&submissions).Error
+}
+
+// choose picks count distinct catalog problems, preferring ones the user has
+// not solved yet like the contest selection does, sorted easy to hard
+func (g *generator) choose(count int, solved map[uuid.UUID]bool) []domain.Problem {
+	var fresh, seen []domain.Problem
+	for _, i := range g.rng.Perm(len(g.problems)) {
+		if solved[g.problems[i].ID] {
+			seen = append(seen, g.problems[i])
+		} else {
+			fresh = append(fresh, g.problems[i])
+		}
+	}
+	chosen := append(fresh, seen...)
+	if count < len(chosen) {
+		chosen = chosen[:count]
+	}
+	for i := 1; i < len(chosen); i++ {
+		for j := i; j > 0 && chosen[j].Difficulty.Weight() < chosen[j-1].Difficulty.Weight(); j-- {
+			chosen[j], chosen[j-1] = chosen[j-1], chosen[j]
+		}
+	}
+	return chosen
+}
+
+// between returns a random time in [from, to)
+func (g *generator) between(from, to time.Time) time.Time {
+	if !to.After(from) {
+		return from
+	}
+	return from.Add(time.Duration(g.rng.Int63n(int64(to.Sub(from)))))
+}
+
+// solveChance is the probability a user of the given skill solves a problem
+func solveChance(skill float64, difficulty domain.Difficulty) float64 {
+	switch difficulty {
+	case domain.DifficultyEasy:
+		return skill + 0.2
+	case domain.DifficultyHard:
+		return skill - 0.3
+	default:
+		return skill
+	}
+}
+
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.Intn(len(values))]
+}
+
+// distinct returns n different values
+func distinct(rng *rand.Rand, values []string, n int) []string {
+	out := make([]string, 0, n)
+	for _, i := range rng.Perm(len(values))[:n] {
+		out = append(out, values[i])
+	}
+	return out
+}
+
+func sortTimes(times []time.Time) {
+	for i := 1; i < len(times); i++ {
+		for j := i; j > 0 && times[j].Before(times[j-1]); j-- {
+			times[j], times[j-1] = times[j-1], times[j]
+		}
+	}
+}
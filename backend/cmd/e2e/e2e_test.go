@@ -0,0 +1,87 @@
+//go:build e2e
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/contest-maker-150/backend/internal/testutil"
+)
+
+// TestReleaseGate runs the flows against the real binary, built and started
+// with the current environment and stopped with SIGTERM, or against the
+// server at E2E_BASE_URL when set
+func TestReleaseGate(t *testing.T) {
+	url := os.Getenv("E2E_BASE_URL")
+	if url == "" {
+		url = startBinary(t)
+	}
+	if err := waitHealthy(url, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	testFlows(t, url)
+}
+
+// TestFlowsInProcess runs the flows against the API served from the test
+// process, on in-memory SQLite or, with TEST_POSTGRES set, a Postgres container
+func TestFlowsInProcess(t *testing.T) {
+	opts := testutil.ServerOptions{Postgres: os.Getenv("TEST_POSTGRES") != ""}
+	srv, err := testutil.NewServer(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := srv.Close(); err != nil {
+			t.Errorf("close server: %v", err)
+		}
+	})
+	testFlows(t, srv.URL)
+}
+
+// testFlows runs every flow as a subtest, in order, sharing one run
+func testFlows(t *testing.T, url string) {
+	r := newRun(url)
+	for _, f := range flows {
+		t.Run(f.name, func(t *testing.T) {
+			if err := f.run(r); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// startBinary builds ./cmd/api, starts it on a free port and returns its URL;
+// the server must exit cleanly on SIGTERM when the test ends
+func startBinary(t *testing.T) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), "api")
+	build := exec.Command("go", "build", "-o", bin, "../api")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("build API: %v\n%s", err, out)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	server, err := startServer(bin, port, testing.Verbose())
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := stopServer(server); err != nil {
+			t.Errorf("shutdown: %v", err)
+		}
+	})
+	return fmt.Sprintf("http://localhost:%d", port)
+}
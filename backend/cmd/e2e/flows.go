@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+	"github.com/contest-maker-150/backend/internal/testutil"
+)
+
+// run holds the state shared by the flows of one run
+type run struct {
+	baseURL string
+	id      string // Makes emails unique so a database can be reused across runs
+}
+
+func newRun(baseURL string) *run {
+	return &run{baseURL: baseURL, id: uuid.NewString()[:8]}
+}
+
+// client is one API user with the flows' contest helpers
+type client struct {
+	*testutil.Client
+}
+
+// flow is one end-to-end scenario
+type flow struct {
+	name string
+	run  func(r *run) error
+}
+
+var flows = []flow{
+	{"solo contest updates progress", soloContest},
+	{"abandoned contest is counted separately", abandonedContest},
+	{"challenge a friend and compare results", challengeFriend},
+	{"logout ends the session", logoutSession},
+}
+
+// runFlows runs every flow in order and returns the number of failures
+func runFlows(r *run) int {
+	failed := 0
+	for _, f := range flows {
+		start := time.Now()
+		if err := f.run(r); err != nil {
+			fmt.Printf("FAIL %s: %v\n", f.name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("PASS %s (%s)\n", f.name, time.Since(start).Round(time.Millisecond))
+	}
+	fmt.Printf("%d of %d flows passed\n", len(flows)-failed, len(flows))
+	return failed
+}
+
+// signup registers a fresh user and returns a client signed in as them
+func (r *run) signup(name string) (*client, error) {
+	c := testutil.NewClient(r.baseURL)
+	if err := c.SignUp(r.email(name), fmt.Sprintf("%s_%s", name, r.id)); err != nil {
+		return nil, err
+	}
+	return &client{c}, nil
+}
+
+// login signs in again as an existing user
+func (r *run) login(name string) (*client, error) {
+	c := testutil.NewClient(r.baseURL)
+	if err := c.Login(r.email(name)); err != nil {
+		return nil, err
+	}
+	return &client{c}, nil
+}
+
+// email is the unique email of the named user in this run
+func (r *run) email(name string) string {
+	return fmt.Sprintf("%s+%s@example.com", name, r.id)
+}
+
+// createContest starts a contest without warmup
+func (c *client) createContest(problems int) (*domain.ContestResponse, error) {
+	var contest domain.ContestResponse
+	req := domain.CreateContestRequest{ProblemCount: problems, DurationMinutes: 30}
+	if err := c.Do(http.MethodPost, "/api/contests", req, http.StatusCreated, &contest); err != nil {
+		return nil, fmt.Errorf("create contest: %w", err)
+	}
+	if len(contest.Problems) != problems || contest.Status != domain.ContestStatusActive {
+		return nil, fmt.Errorf("create contest: got %d problems in status %s", len(contest.Problems), contest.Status)
+	}
+	return &contest, nil
+}
+
+// solve marks the contest problem at index as completed
+func (c *client) solve(contest *domain.ContestResponse, index int) error {
+	path := fmt.Sprintf("/api/contests/%s/problems/%s", contest.ID, contest.Problems[index].Problem.ID)
+	return c.Do(http.MethodPatch, path, domain.MarkProblemCompleteRequest{IsCompleted: true}, http.StatusOK, nil)
+}
+
+// finish completes or abandons a contest
+func (c *client) finish(contestID uuid.UUID, action string) error {
+	return c.Do(http.MethodPost, fmt.Sprintf("/api/contests/%s/%s", contestID, action), nil, http.StatusOK, nil)
+}
+
+// expectProgress checks that the user's progress reports the given counters
+func (c *client) expectProgress(solved, total, completed, abandoned int) error {
+	var p domain.UserProgress
+	if err := c.Do(http.MethodGet, "/api/users/me/progress", nil, http.StatusOK, &p); err != nil {
+		return err
+	}
+	want := domain.ContestStatistics{TotalContests: total, CompletedContests: completed, AbandonedContests: abandoned}
+	if p.TotalSolved != solved || p.ContestStats != want {
+		return fmt.Errorf("progress: expected %d solved and %+v, got %d solved and %+v", solved, want, p.TotalSolved, p.ContestStats)
+	}
+	return nil
+}
+
+// soloContest: sign up, create a contest, solve every problem, complete it and
+// see the solved problems and the contest in the progress summary
+func soloContest(r *run) error {
+	alice, err := r.signup("alice")
+	if err != nil {
+		return err
+	}
+
+	var me domain.UserResponse
+	if err := alice.Do(http.MethodGet, "/api/users/me", nil, http.StatusOK, &me); err != nil {
+		return err
+	}
+	if me.ID != alice.Auth.User.ID {
+		return fmt.Errorf("users/me returned %s, signed up as %s", me.ID, alice.Auth.User.ID)
+	}
+	if err := alice.expectProgress(0, 0, 0, 0); err != nil {
+		return err
+	}
+
+	contest, err := alice.createContest(3)
+	if err != nil {
+		return err
+	}
+	if err := alice.ExpectError(http.MethodPost, "/api/contests",
+		domain.CreateContestRequest{ProblemCount: 3, DurationMinutes: 30}, http.StatusConflict, domain.CodeActiveContest); err != nil {
+		return err
+	}
+
+	for i := range contest.Problems {
+		if err := alice.solve(contest, i); err != nil {
+			return err
+		}
+	}
+	if err := alice.finish(contest.ID, "complete"); err != nil {
+		return err
+	}
+
+	var finished domain.ContestResponse
+	if err := alice.Do(http.MethodGet, "/api/contests/"+contest.ID.String(), nil, http.StatusOK, &finished); err != nil {
+		return err
+	}
+	if finished.Status != domain.ContestStatusCompleted || finished.EndedAt == nil {
+		return fmt.Errorf("completed contest has status %s", finished.Status)
+	}
+	for _, p := range finished.Problems {
+		if !p.IsCompleted {
+			return fmt.Errorf("problem %s is not marked completed", p.Problem.Title)
+		}
+	}
+
+	return alice.expectProgress(3, 1, 1, 0)
+}
+
+// abandonedContest: a second contest with one solved problem is abandoned; the
+// solved problem still counts, the contest as abandoned
+func abandonedContest(r *run) error {
+	alice, err := r.login("alice")
+	if err != nil {
+		return err
+	}
+
+	contest, err := alice.createContest(2)
+	if err != nil {
+		return err
+	}
+	if err := alice.solve(contest, 0); err != nil {
+		return err
+	}
+	if err := alice.finish(contest.ID, "abandon"); err != nil {
+		return err
+	}
+	if err := alice.ExpectError(http.MethodPost, fmt.Sprintf("/api/contests/%s/complete", contest.ID),
+		nil, http.StatusBadRequest, domain.CodeContestNotActive); err != nil {
+		return err
+	}
+
+	var active struct {
+		Contest *domain.ContestResponse `json:"contest"`
+	}
+	if err := alice.Do(http.MethodGet, "/api/contests/active", nil, http.StatusOK, &active); err != nil {
+		return err
+	}
+	if active.Contest != nil {
+		return fmt.Errorf("abandoned contest %s is still active", active.Contest.ID)
+	}
+
+	return alice.expectProgress(4, 2, 1, 1)
+}
+
+// challengeFriend: alice challenges bob to her contest, bob accepts and both
+// finish; the comparison shows who solved what
+func challengeFriend(r *run) error {
+	alice, err := r.login("alice")
+	if err != nil {
+		return err
+	}
+	bob, err := r.signup("bob")
+	if err != nil {
+		return err
+	}
+
+	contest, err := alice.createContest(2)
+	if err != nil {
+		return err
+	}
+	var challenge domain.ChallengeResponse
+	if err := alice.Do(http.MethodPost, fmt.Sprintf("/api/contests/%s/challenge", contest.ID), nil, http.StatusCreated, &challenge); err != nil {
+		return err
+	}
+
+	var bobContest domain.ContestResponse
+	if err := bob.Do(http.MethodPost, "/api/challenges/"+challenge.Code+"/accept", nil, http.StatusCreated, &bobContest); err != nil {
+		return err
+	}
+	for i, p := range bobContest.Problems {
+		if p.Problem.ID != contest.Problems[i].Problem.ID {
+			return fmt.Errorf("accepted contest differs from the challenge at problem %d", i+1)
+		}
+	}
+
+	if err := alice.solve(contest, 0); err != nil {
+		return err
+	}
+	if err := alice.solve(contest, 1); err != nil {
+		return err
+	}
+	if err := bob.solve(&bobContest, 0); err != nil {
+		return err
+	}
+	if err := alice.finish(contest.ID, "complete"); err != nil {
+		return err
+	}
+	if err := bob.finish(bobContest.ID, "complete"); err != nil {
+		return err
+	}
+
+	var comparison domain.ChallengeComparison
+	if err := bob.Do(http.MethodGet, "/api/challenges/"+challenge.Code+"/comparison", nil, http.StatusOK, &comparison); err != nil {
+		return err
+	}
+	if comparison.Challenger.Solved != 2 || comparison.Opponent.Solved != 1 {
+		return fmt.Errorf("comparison: challenger solved %d, opponent %d", comparison.Challenger.Solved, comparison.Opponent.Solved)
+	}
+	if comparison.WinnerID == nil || *comparison.WinnerID != alice.Auth.User.ID {
+		return fmt.Errorf("comparison: expected alice to win")
+	}
+
+	return bob.expectProgress(1, 1, 1, 0)
+}
+
+// logoutSession: logging out revokes the access token and its refresh token
+func logoutSession(r *run) error {
+	alice, err := r.login("alice")
+	if err != nil {
+		return err
+	}
+	tokens := *alice.Auth.Tokens
+
+	if err := alice.Do(http.MethodPost, "/api/auth/logout",
+		domain.LogoutRequest{RefreshToken: tokens.RefreshToken}, http.StatusOK, nil); err != nil {
+		return err
+	}
+	if err := alice.ExpectError(http.MethodGet, "/api/users/me", nil, http.StatusUnauthorized, domain.CodeTokenRevoked); err != nil {
+		return err
+	}
+
+	anonymous := testutil.NewClient(r.baseURL)
+	var refreshed struct {
+		Tokens service.TokenPair `json:"tokens"`
+	}
+	err = anonymous.Do(http.MethodPost, "/api/auth/refresh",
+		map[string]string{"refresh_token": tokens.RefreshToken}, http.StatusOK, &refreshed)
+	if apiErr, ok := err.(*testutil.APIError); !ok || apiErr.Body.Error.Code != domain.CodeTokenRevoked {
+		return fmt.Errorf("refresh after logout: expected %s, got %v", domain.CodeTokenRevoked, err)
+	}
+
+	_, err = r.login("alice")
+	return err
+}
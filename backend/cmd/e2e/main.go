@@ -0,0 +1,185 @@
+// Command e2e is the release gate: it builds and starts the real API binary,
+// walks realistic user flows over HTTP and stops the server with SIGTERM,
+// failing if any flow or the shutdown goes wrong. The server inherits the
+// environment, so DB_DRIVER and DATABASE_* select the database; point them at
+// a scratch Postgres database. Every run signs up fresh users, so the same
+// database can be reused. Pass -base-url to run the flows against a server
+// that is already running instead, or -in-process to serve the API from this
+// process on in-memory SQLite or a throwaway Postgres container (which needs
+// Docker); no database has to be set up for either. With the e2e build tag,
+// go test runs the same flows as subtests.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/testutil"
+)
+
+func main() {
+	baseURL := flag.String("base-url", "", "run against this server instead of starting one")
+	bin := flag.String("bin", "", "API binary to start (built from ./cmd/api when empty)")
+	port := flag.Int("port", 18080, "port of the started server")
+	inProcess := flag.String("in-process", "", `serve the API in-process on "sqlite" or "postgres" instead of starting the binary`)
+	verbose := flag.Bool("v", false, "stream server logs to stderr")
+	flag.Parse()
+
+	if *inProcess != "" {
+		os.Exit(runInProcess(*inProcess, *verbose))
+	}
+
+	url := *baseURL
+	var server *exec.Cmd
+	if url == "" {
+		var err error
+		server, err = startServer(*bin, *port, *verbose)
+		if err != nil {
+			fail("start server", err)
+		}
+		url = fmt.Sprintf("http://localhost:%d", *port)
+	}
+
+	if err := waitHealthy(url, 30*time.Second); err != nil {
+		stopServer(server)
+		fail("wait for server", err)
+	}
+
+	failed := runFlows(newRun(url))
+
+	if server != nil {
+		if err := stopServer(server); err != nil {
+			fmt.Printf("FAIL shutdown: %v\n", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runInProcess runs the flows against a testutil server on the given database
+// and returns the exit code
+func runInProcess(database string, verbose bool) int {
+	if database != "sqlite" && database != "postgres" {
+		fail("start server", fmt.Errorf("unknown database %q", database))
+	}
+	opts := testutil.ServerOptions{Postgres: database == "postgres"}
+	if verbose {
+		opts.Logger, _ = zap.NewDevelopment()
+	}
+
+	srv, err := testutil.NewServer(context.Background(), opts)
+	if err != nil {
+		fail("start server", err)
+	}
+
+	code := 0
+	if runFlows(newRun(srv.URL)) > 0 {
+		code = 1
+	}
+	if err := srv.Close(); err != nil {
+		fmt.Printf("FAIL shutdown: %v\n", err)
+		code = 1
+	}
+	return code
+}
+
+// startServer builds the API binary unless one is given and starts it with the
+// current environment, overriding only the port and disabling telemetry
+func startServer(bin string, port int, verbose bool) (*exec.Cmd, error) {
+	if bin == "" {
+		dir, err := os.MkdirTemp("", "contest-maker-e2e")
+		if err != nil {
+			return nil, err
+		}
+		bin = filepath.Join(dir, "api")
+		build := exec.Command("go", "build", "-o", bin, "./cmd/api")
+		build.Stdout, build.Stderr = os.Stdout, os.Stderr
+		if err := build.Run(); err != nil {
+			return nil, fmt.Errorf("build API: %w", err)
+		}
+	}
+
+	cmd := exec.Command(bin)
+	cmd.Env = append(os.Environ(),
+		"SERVER_PORT="+strconv.Itoa(port),
+		"TELEMETRY_ENABLED="+getEnv("TELEMETRY_ENABLED", "false"),
+	)
+	cmd.Stdout, cmd.Stderr = io.Discard, io.Discard
+	if verbose {
+		cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	}
+	return cmd, cmd.Start()
+}
+
+// stopServer sends SIGTERM and expects a clean exit within the shutdown timeout
+func stopServer(cmd *exec.Cmd) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("server exited uncleanly: %w", err)
+		}
+		return nil
+	case <-time.After(90 * time.Second):
+		cmd.Process.Kill()
+		return errors.New("server did not exit within 90s of SIGTERM")
+	}
+}
+
+// waitHealthy polls /health until the server reports healthy
+func waitHealthy(baseURL string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/health", nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s/health not healthy after %s", baseURL, timeout)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// getEnv gets an environment variable with a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func fail(action string, err error) {
+	fmt.Fprintf(os.Stderr, "Failed to %s: %v\n", action, err)
+	os.Exit(1)
+}
@@ -0,0 +1,59 @@
+// Command integrity checks the database for orphaned and duplicate rows and
+// recomputes the progress summaries and problem usage counters derived from
+// submissions and contests, repairing what it finds. It is the command-line form
+// of POST /api/admin/integrity and uses the DATABASE_* settings (or -driver
+// sqlite for a local file). It does not migrate, so run it against a database
+// the API has already migrated. Pass -dry-run to only report.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/repository"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+func main() {
+	driver := flag.String("driver", "", "database driver: sqlite or postgres (defaults to DB_DRIVER)")
+	dryRun := flag.Bool("dry-run", false, "only report issues, leave the data untouched")
+	flag.Parse()
+
+	config := infrastructure.LoadConfig()
+	if *driver != "" {
+		config.Database.Driver = *driver
+	}
+
+	logger := zap.NewNop()
+	database, err := infrastructure.NewDatabase(&config.Database, logger)
+	if err != nil {
+		fail("connect", err)
+	}
+	defer database.Close()
+
+	integrityService := service.NewIntegrityService(repository.NewIntegrityRepository(database.DB), otel.Tracer("integrity"), logger)
+	report, err := integrityService.Run(context.Background(), *dryRun)
+	if err != nil {
+		fail("run", err)
+	}
+
+	for _, finding := range report.Findings {
+		fmt.Printf("%-28s found %6d  repaired %6d\n", finding.Issue, finding.Found, finding.Repaired)
+	}
+	if report.DryRun {
+		fmt.Println("Dry run: nothing was repaired")
+	}
+	fmt.Printf("Finished in %s\n", report.FinishedAt.Sub(report.StartedAt).Round(time.Millisecond))
+}
+
+func fail(step string, err error) {
+	fmt.Fprintf(os.Stderr, "integrity: %s: %v\n", step, err)
+	os.Exit(1)
+}
@@ -0,0 +1,66 @@
+// Command mockgen generates function-field mocks of interfaces declared in a
+// package, for tests that exercise a layer without the ones beneath it. Run
+// via `go generate ./...` from the package declaring the interfaces; pass
+// -check in CI to fail when the mocks are out of date.
+//
+//	go run ../../cmd/mockgen -out mocks/services_gen.go ContestServicer UserServicer
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package declaring the interfaces")
+	out := flag.String("out", "mocks/mocks_gen.go", "path of the generated file; its directory names the mocks package")
+	check := flag.Bool("check", false, "verify the generated file is up to date instead of writing it")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: mockgen [-dir dir] [-out file] [-check] Interface...")
+		os.Exit(2)
+	}
+
+	pkg, err := loadPackage(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", *dir, err)
+		os.Exit(1)
+	}
+
+	mocks := make([]*mock, 0, flag.NArg())
+	for _, name := range flag.Args() {
+		m, err := pkg.mock(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to mock %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		mocks = append(mocks, m)
+	}
+
+	content, err := render(pkg, filepath.Base(filepath.Dir(*out)), mocks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+
+	if *check {
+		existing, err := os.ReadFile(*out)
+		if err != nil || !bytes.Equal(existing, content) {
+			fmt.Fprintf(os.Stderr, "%s is out of date; run go generate ./...\n", *out)
+			os.Exit(1)
+		}
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", filepath.Dir(*out), err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, content, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sourcePackage is the parsed package declaring the interfaces to mock
+type sourcePackage struct {
+	name       string
+	importPath string
+	module     string
+	interfaces map[string]*ast.InterfaceType
+	imports    map[*ast.InterfaceType]map[string]string // Imports of the declaring file by name
+	used       map[string]string                        // Imports the rendered signatures refer to, by name
+}
+
+// mock is one interface to render a mock of
+type mock struct {
+	name    string
+	methods []method
+}
+
+// method is an interface method with its signature rendered for the mocks package
+type method struct {
+	name     string
+	params   []param
+	variadic bool // The last parameter is variadic
+	results  []string
+}
+
+type param struct {
+	name, typ string
+}
+
+// loadPackage parses the non-test Go files of dir
+func loadPackage(dir string) (*sourcePackage, error) {
+	importPath, module, err := modulePath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &sourcePackage{
+		importPath: importPath,
+		module:     module,
+		interfaces: make(map[string]*ast.InterfaceType),
+		imports:    make(map[*ast.InterfaceType]map[string]string),
+		used:       make(map[string]string),
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		pkg.name = f.Name.Name
+
+		imports := make(map[string]string, len(f.Imports))
+		for _, imp := range f.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			name := path.Base(p)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = p
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if it, ok := ts.Type.(*ast.InterfaceType); ok {
+					pkg.interfaces[ts.Name.Name] = it
+					pkg.imports[it] = imports
+				}
+			}
+		}
+	}
+	if pkg.name == "" {
+		return nil, fmt.Errorf("no Go files")
+	}
+	return pkg, nil
+}
+
+// modulePath derives the import path of dir and its module from the enclosing go.mod
+func modulePath(dir string) (string, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		f, err := os.Open(filepath.Join(root, "go.mod"))
+		if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return "", "", err
+					}
+					module = strings.TrimSpace(module)
+					return path.Join(module, filepath.ToSlash(rel)), module, nil
+				}
+			}
+			return "", "", fmt.Errorf("%s/go.mod has no module line", root)
+		}
+		if filepath.Dir(root) == root {
+			return "", "", fmt.Errorf("no go.mod above %s", abs)
+		}
+	}
+}
+
+// mock reads the methods of the named interface
+func (p *sourcePackage) mock(name string) (*mock, error) {
+	it, ok := p.interfaces[name]
+	if !ok {
+		return nil, fmt.Errorf("no interface %s in package %s", name, p.name)
+	}
+
+	m := &mock{name: name}
+	for _, field := range it.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, fmt.Errorf("embedded interfaces are not supported")
+		}
+		meth := method{name: field.Names[0].Name}
+
+		for _, f := range fn.Params.List {
+			typ := f.Type
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				meth.variadic = true
+				typ = ellipsis.Elt
+			}
+			rendered, err := p.typeString(it, typ)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", meth.name, err)
+			}
+			names := f.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{{Name: "_"}}
+			}
+			for _, n := range names {
+				pname := n.Name
+				if pname == "_" {
+					pname = fmt.Sprintf("p%d", len(meth.params))
+				}
+				meth.params = append(meth.params, param{name: pname, typ: rendered})
+			}
+		}
+
+		if fn.Results != nil {
+			for _, f := range fn.Results.List {
+				rendered, err := p.typeString(it, f.Type)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", meth.name, err)
+				}
+				for range max(len(f.Names), 1) {
+					meth.results = append(meth.results, rendered)
+				}
+			}
+		}
+		m.methods = append(m.methods, meth)
+	}
+	return m, nil
+}
+
+// typeString renders a type expression as the mocks package spells it: types
+// of the source package are qualified with its name, and each package
+// referred to is recorded for the imports
+func (p *sourcePackage) typeString(it *ast.InterfaceType, expr ast.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(e.Name) != nil {
+			return e.Name, nil
+		}
+		p.used[p.name] = p.importPath
+		return p.name + "." + e.Name, nil
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported type selector")
+		}
+		importPath, ok := p.imports[it][pkg.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown package %s", pkg.Name)
+		}
+		p.used[pkg.Name] = importPath
+		return pkg.Name + "." + e.Sel.Name, nil
+	case *ast.StarExpr:
+		elem, err := p.typeString(it, e.X)
+		return "*" + elem, err
+	case *ast.ArrayType:
+		elem, err := p.typeString(it, e.Elt)
+		if e.Len != nil {
+			lit, ok := e.Len.(*ast.BasicLit)
+			if !ok {
+				return "", fmt.Errorf("unsupported array length")
+			}
+			return "[" + lit.Value + "]" + elem, err
+		}
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := p.typeString(it, e.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := p.typeString(it, e.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.InterfaceType:
+		if len(e.Methods.List) > 0 {
+			return "", fmt.Errorf("unsupported inline interface")
+		}
+		return "interface{}", nil
+	case *ast.ChanType:
+		elem, err := p.typeString(it, e.Value)
+		switch e.Dir {
+		case ast.SEND:
+			return "chan<- " + elem, err
+		case ast.RECV:
+			return "<-chan " + elem, err
+		}
+		return "chan " + elem, err
+	case *ast.FuncType:
+		var params, results []string
+		for _, list := range []*ast.FieldList{e.Params, e.Results} {
+			if list == nil {
+				continue
+			}
+			for _, f := range list.List {
+				rendered, err := p.typeString(it, f.Type)
+				if err != nil {
+					return "", err
+				}
+				for range max(len(f.Names), 1) {
+					if list == e.Params {
+						params = append(params, rendered)
+					} else {
+						results = append(results, rendered)
+					}
+				}
+			}
+		}
+		return "func(" + strings.Join(params, ", ") + ")" + resultList(results), nil
+	case *ast.Ellipsis:
+		elem, err := p.typeString(it, e.Elt)
+		return "..." + elem, err
+	}
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+// resultList renders a result list after a signature's parameters
+func resultList(results []string) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return " " + results[0]
+	}
+	return " (" + strings.Join(results, ", ") + ")"
+}
+
+// importGroups lists the names of the recorded imports in path order, grouped
+// like the repository's files: the standard library, other modules, then this one
+func (p *sourcePackage) importGroups() [][]string {
+	groups := make([][]string, 3)
+	for name, importPath := range p.used {
+		group := 1
+		switch {
+		case !strings.Contains(strings.Split(importPath, "/")[0], "."):
+			group = 0
+		case importPath == p.module || strings.HasPrefix(importPath, p.module+"/"):
+			group = 2
+		}
+		groups[group] = append(groups[group], name)
+	}
+	for _, names := range groups {
+		sort.Slice(names, func(i, j int) bool { return p.used[names[i]] < p.used[names[j]] })
+	}
+	return groups
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"strings"
+)
+
+// render renders the mocks file. Each mock has a func field per method named
+// after it; a method calls its field and panics when a test left it unset, so
+// an unexpected call fails loudly instead of returning zero values.
+func render(pkg *sourcePackage, mocksPkg string, mocks []*mock) ([]byte, error) {
+	var body bytes.Buffer
+	for _, m := range mocks {
+		fmt.Fprintf(&body, "\n// %s mocks %s.%s\ntype %s struct {\n", m.name, pkg.name, m.name, m.name)
+		for _, meth := range m.methods {
+			fmt.Fprintf(&body, "\t%sFunc func%s\n", meth.name, meth.signature())
+		}
+		body.WriteString("}\n")
+
+		fmt.Fprintf(&body, "\nvar _ %s.%s = (*%s)(nil)\n", pkg.name, m.name, m.name)
+		pkg.used[pkg.name] = pkg.importPath
+
+		for _, meth := range m.methods {
+			fmt.Fprintf(&body, "\n// %s calls %sFunc\n", meth.name, meth.name)
+			fmt.Fprintf(&body, "func (m *%s) %s%s {\n", m.name, meth.name, meth.signature())
+			fmt.Fprintf(&body, "\tif m.%sFunc == nil {\n", meth.name)
+			fmt.Fprintf(&body, "\t\tpanic(\"%s: unexpected call to %s.%s\")\n", mocksPkg, m.name, meth.name)
+			body.WriteString("\t}\n\t")
+			if len(meth.results) > 0 {
+				body.WriteString("return ")
+			}
+			fmt.Fprintf(&body, "m.%sFunc(%s)\n}\n", meth.name, meth.arguments())
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by backend/cmd/mockgen from %s. DO NOT EDIT.\n\n", pkg.importPath)
+	fmt.Fprintf(&b, "// Package %s provides mocks of the %s interfaces: set the Func field of\n", mocksPkg, pkg.name)
+	b.WriteString("// each method a test expects to be called.\n")
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", mocksPkg)
+	for _, names := range pkg.importGroups() {
+		if len(names) == 0 {
+			continue
+		}
+		b.WriteString("\n")
+		for _, name := range names {
+			if importPath := pkg.used[name]; path.Base(importPath) != name {
+				fmt.Fprintf(&b, "\t%s %q\n", name, importPath)
+			} else {
+				fmt.Fprintf(&b, "\t%q\n", importPath)
+			}
+		}
+	}
+	b.WriteString(")\n")
+	b.Write(body.Bytes())
+
+	return format.Source(b.Bytes())
+}
+
+// signature renders the parameters and results of the method
+func (m method) signature() string {
+	params := make([]string, len(m.params))
+	for i, p := range m.params {
+		typ := p.typ
+		if m.variadic && i == len(m.params)-1 {
+			typ = "..." + typ
+		}
+		params[i] = p.name + " " + typ
+	}
+	return "(" + strings.Join(params, ", ") + ")" + resultList(m.results)
+}
+
+// arguments renders the call passing the method's parameters on
+func (m method) arguments() string {
+	args := make([]string, len(m.params))
+	for i, p := range m.params {
+		args[i] = p.name
+		if m.variadic && i == len(m.params)-1 {
+			args[i] += "..."
+		}
+	}
+	return strings.Join(args, ", ")
+}
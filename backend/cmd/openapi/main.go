@@ -0,0 +1,44 @@
+// Command openapi writes the OpenAPI specification derived from the handler
+// operation table. Run via `go generate ./...`; pass -check in CI to fail when
+// the committed specification is out of date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/contest-maker-150/backend/internal/handler"
+)
+
+func main() {
+	out := flag.String("out", "api/openapi.json", "path of the generated specification")
+	version := flag.String("version", "1.0.0", "API version recorded in the specification")
+	check := flag.Bool("check", false, "verify the existing file is up to date instead of writing it")
+	flag.Parse()
+
+	spec, err := handler.BuildOpenAPI(*version).JSON()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to build OpenAPI spec: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *check {
+		existing, err := os.ReadFile(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", *out, err)
+			os.Exit(1)
+		}
+		if !bytes.Equal(existing, spec) {
+			fmt.Fprintf(os.Stderr, "%s is out of date; run go generate ./...\n", *out)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,744 @@
+// Package app assembles the API from its parts: repositories, services, event
+// subscriptions, background workers and the HTTP router. The API server and the
+// tools that exercise the API in-process share this wiring.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/data"
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/openapi"
+	"github.com/contest-maker-150/backend/internal/repository"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// App is the assembled API
+type App struct {
+	Router *gin.Engine
+
+	expiryWorker     *service.ContestExpiryWorker
+	progressWorker   *service.ProgressBackfillWorker
+	cohortWorker     *service.CohortSnapshotWorker
+	backupWorker     *service.BackupWorker
+	retentionWorker  *service.RetentionWorker
+	presenceWorker   *service.PresenceSweepWorker
+	digestWorker     *service.DigestWorker
+	similarityWorker *service.SimilarityWorker
+	alerts           *infrastructure.AlertEvaluator
+	dependencies     *infrastructure.DependencyMonitor
+	logLevel         *infrastructure.LogLevel
+	crashReporter    *infrastructure.CrashReporter
+	shutdown         []shutdownStep
+	logger           *zap.Logger
+}
+
+// shutdownStep is one component Stop shuts down, within its own timeout
+type shutdownStep struct {
+	name    string
+	timeout time.Duration
+	stop    func(ctx context.Context) error
+}
+
+// PrepareDatabase runs migrations and seeds the problem catalog
+func PrepareDatabase(database *infrastructure.Database, logger *zap.Logger) error {
+	// Duplicates left by racing solves would fail the unique submissions index
+	if database.DB.Migrator().HasTable(&domain.Submission{}) {
+		removed, err := repository.NewIntegrityRepository(database.DB).Repair(domain.IssueDuplicateSubmissions)
+		if err != nil {
+			return fmt.Errorf("failed to deduplicate submissions: %w", err)
+		}
+		if removed > 0 {
+			logger.Warn("Removed duplicate submissions", zap.Int64("count", removed))
+		}
+	}
+
+	if err := database.AutoMigrate(); err != nil {
+		return err
+	}
+
+	seeder := data.NewSeeder(database.DB, logger)
+	if err := seeder.SeedProblems(); err != nil {
+		return fmt.Errorf("failed to seed problems: %w", err)
+	}
+	if err := seeder.SeedImportance(); err != nil {
+		return fmt.Errorf("failed to seed importance scores: %w", err)
+	}
+	if err := seeder.SeedCompanies(); err != nil {
+		return fmt.Errorf("failed to seed company tags: %w", err)
+	}
+	if err := seeder.SeedPrerequisites(); err != nil {
+		return fmt.Errorf("failed to seed prerequisites: %w", err)
+	}
+	if err := seeder.SeedRoadmap(); err != nil {
+		return fmt.Errorf("failed to seed roadmap: %w", err)
+	}
+
+	backfilled, err := repository.NewAttemptRepository(database.DB).BackfillFromSubmissions()
+	if err != nil {
+		return fmt.Errorf("failed to backfill attempts: %w", err)
+	}
+	if backfilled > 0 {
+		logger.Info("Backfilled attempts from submissions", zap.Int64("count", backfilled))
+	}
+	return nil
+}
+
+// New wires the API on top of a prepared database. logLevel is the level logger
+// was built with, which admins can change at runtime. Background workers are not
+// running until Start is called.
+func New(
+	config *infrastructure.Config,
+	database *infrastructure.Database,
+	telemetry *infrastructure.Telemetry,
+	metrics *infrastructure.TelemetryMetrics,
+	logger *zap.Logger,
+	logLevel zap.AtomicLevel,
+) (*App, error) {
+	// Initialize repositories
+	userRepo := repository.NewUserRepository(database.DB)
+	problemRepo := repository.NewProblemRepository(database.DB)
+	contestRepo := repository.NewContestRepository(database.DB)
+	submissionRepo := repository.NewSubmissionRepository(database.DB)
+	attemptRepo := repository.NewAttemptRepository(database.DB)
+	presenceRepo := repository.NewPresenceRepository(database.DB)
+	chatRepo := repository.NewChatRepository(database.DB)
+	filterRepo := repository.NewSavedFilterRepository(database.DB)
+	roadmapRepo := repository.NewRoadmapRepository(database.DB)
+	challengeRepo := repository.NewChallengeRepository(database.DB)
+	listRepo := repository.NewProblemListRepository(database.DB)
+	orgRepo := repository.NewOrgRepository(database.DB)
+	mentorshipRepo := repository.NewMentorshipRepository(database.DB)
+	contestEventRepo := repository.NewContestEventRepository(database.DB)
+	digestRepo := repository.NewDigestRepository(database.DB)
+	similarityRepo := repository.NewSimilarityRepository(database.DB)
+	noteSearchRepo := repository.NewNoteSearchRepository(database.DB)
+	feedRepo := repository.NewFeedRepository(database.DB)
+	progressRepo := repository.NewProgressRepository(database.DB)
+	revocationRepo := repository.NewTokenRevocationRepository(database.DB)
+	featureFlagRepo := repository.NewFeatureFlagRepository(database.DB)
+	maintenanceRepo := repository.NewMaintenanceRepository(database.DB)
+	analyticsRepo := repository.NewAnalyticsRepository(database.DB, database.Reader)
+	logLevelRepo := repository.NewLogLevelRepository(database.DB)
+	rateLimitRepo := repository.NewRateLimitRepository(database.DB)
+	quotaRepo := repository.NewQuotaRepository(database.DB)
+	billingRepo := repository.NewBillingRepository(database.DB)
+	integrityRepo := repository.NewIntegrityRepository(database.DB)
+	sessionRepo := repository.NewContestSessionRepository(database.DB)
+	quickRepo := repository.NewQuickCommandRepository(database.DB)
+	publicStatsRepo := repository.NewPublicStatsRepository(database.Reader)
+	backupRepo := repository.NewBackupRepository(database.DB)
+	retentionRepo := repository.NewRetentionRepository(database.DB)
+	tenantRepo := repository.NewTenantRepository(database.DB)
+
+	// Initialize event bus
+	eventBus := infrastructure.NewEventBus(1024, logger)
+
+	// Initialize feature flags
+	featureFlags := infrastructure.NewFeatureFlags(featureFlagRepo, &config.Features, logger)
+	maintenance := infrastructure.NewMaintenance(maintenanceRepo, &config.Maintenance, logger)
+	runtimeLogLevel := infrastructure.NewLogLevel(logLevel, logLevelRepo, &config.Logging, logger)
+
+	// Initialize alerting; each instance reports its own traffic under its host name
+	var alertNotifier infrastructure.AlertNotifier
+	if config.Alerts.WebhookURL != "" {
+		alertNotifier = infrastructure.NewWebhookAlertNotifier(config.Alerts.WebhookURL,
+			telemetry.Breakers.New("alert_webhook", config.Alerts.WebhookTimeout))
+	}
+	hostname, _ := os.Hostname()
+	instance := config.Telemetry.ServiceName
+	if hostname != "" {
+		instance += "@" + hostname
+	}
+	alerts := infrastructure.NewAlertEvaluator(&config.Alerts, alertNotifier, instance, logger)
+	crashReporter, err := infrastructure.NewCrashReporter(&config.CrashReport, config.Telemetry.ServiceVersion, config.Server.Environment, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crash report configuration: %w", err)
+	}
+
+	mailer, err := infrastructure.NewMailer(&config.Mail, telemetry.Breakers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mail configuration: %w", err)
+	}
+
+	backupStore, err := infrastructure.NewObjectStore(&config.Backup)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup configuration: %w", err)
+	}
+
+	// Probe what the API depends on for /readyz; the mail server and backup
+	// storage are optional, the features using them report their own failures
+	dependencies := infrastructure.NewDependencyMonitor(&config.Readiness, logger)
+	dependencies.Add("database", true, infrastructure.PingFunc(database.HealthCheck))
+	if pinger, ok := mailer.(infrastructure.Pinger); ok {
+		dependencies.Add("mail", false, pinger)
+	}
+	if pinger, ok := backupStore.(infrastructure.Pinger); ok {
+		dependencies.Add("backup_storage", false, pinger)
+	}
+	if err := dependencies.RegisterMetrics(telemetry.Meter); err != nil {
+		logger.Warn("Failed to register dependency health metrics", zap.Error(err))
+	}
+
+	// Initialize services
+	breachChecker := infrastructure.NewPwnedPasswordsClient(config.Password.BreachCheckURL, config.Password.BreachCheckTimeout)
+	passwordPolicy := service.NewPasswordPolicy(&config.Password, breachChecker, logger)
+	passwordHasher, err := service.NewPasswordHasher(&config.Password)
+	if err != nil {
+		return nil, fmt.Errorf("invalid password hashing configuration: %w", err)
+	}
+	userService := service.NewUserService(userRepo, submissionRepo, attemptRepo, contestRepo, progressRepo, revocationRepo, tenantRepo, &config.JWT, passwordPolicy, passwordHasher, telemetry.Tracer, logger)
+	problemService := service.NewProblemService(problemRepo, userRepo, &config.Contest, &config.Problems, rand.NewSource, telemetry.Tracer, logger)
+	filterService := service.NewSavedFilterService(filterRepo, telemetry.Tracer, logger)
+	quotaService := service.NewQuotaService(quotaRepo, userRepo, contestRepo, problemRepo, &config.Quotas, telemetry.Tracer, logger)
+	billingService := service.NewBillingService(billingRepo, userRepo, infrastructure.NewStripeClient(&config.Billing), &config.Quotas, telemetry.Tracer, logger)
+	customProblemService := service.NewCustomProblemService(problemRepo, quotaService, telemetry.Tracer, logger)
+	roadmapService := service.NewRoadmapService(roadmapRepo, telemetry.Tracer, logger)
+	contestService := service.NewContestService(contestRepo, problemService, roadmapService, quotaService, submissionRepo, attemptRepo, eventBus, telemetry.Tracer, logger)
+	presenceService := service.NewPresenceService(presenceRepo, contestRepo, &config.Presence, telemetry.Tracer, logger)
+	chatService := service.NewChatService(chatRepo, challengeRepo, userRepo, contestService, service.NewWordListFilter(config.Chat.BannedWords), telemetry.Tracer, logger)
+	templateService := service.NewContestTemplateService(contestService, problemRepo, &config.Contest, telemetry.Tracer, logger)
+	listService := service.NewProblemListService(listRepo, userRepo, problemService, contestService, service.NewWordListFilter(config.Lists.BannedWords), &config.Lists, telemetry.Tracer, logger)
+	challengeService := service.NewChallengeService(challengeRepo, contestService, userRepo, presenceService, eventBus, &config.Contest, telemetry.Tracer, logger)
+	orgService := service.NewOrgService(orgRepo, progressRepo, contestEventRepo, contestService, problemService, &config.Orgs, telemetry.Tracer, logger)
+	mentorshipService := service.NewMentorshipService(mentorshipRepo, userRepo, progressRepo, contestRepo, contestService, problemService, telemetry.Tracer, logger)
+	proctoringService := service.NewProctoringService(contestEventRepo, orgRepo, contestRepo, telemetry.Tracer, logger)
+	assignmentService := service.NewAssignmentService(orgService, mentorshipService, telemetry.Tracer, logger)
+	digestService := service.NewDigestService(digestRepo, userRepo, roadmapService, mailer, &config.Digest, &config.Problems, telemetry.Tracer, logger)
+	similarityService := service.NewSimilarityService(similarityRepo, contestRepo, orgRepo, &config.Similarity, telemetry.Tracer, logger)
+	sessionService := service.NewContestSessionService(sessionRepo, contestRepo, telemetry.Tracer, logger)
+	noteSearchService := service.NewNoteSearchService(noteSearchRepo, telemetry.Tracer, logger)
+	feedService := service.NewFeedService(feedRepo, challengeRepo, problemRepo, progressRepo, telemetry.Tracer, logger)
+	featureFlagService := service.NewFeatureFlagService(featureFlags, telemetry.Tracer, logger)
+	maintenanceService := service.NewMaintenanceService(maintenance, telemetry.Tracer, logger)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, &config.Analytics, telemetry.Tracer, logger)
+	logLevelService := service.NewLogLevelService(runtimeLogLevel, telemetry.Tracer, logger)
+	integrityService := service.NewIntegrityService(integrityRepo, telemetry.Tracer, logger)
+	quickService := service.NewQuickService(quickRepo, contestService, telemetry.Tracer, logger)
+	publicStatsService := service.NewPublicStatsService(publicStatsRepo, &config.PublicStats, telemetry.Tracer, logger)
+	backupService := service.NewBackupService(backupRepo, backupStore, &config.Backup, telemetry.Tracer, logger)
+	retentionService := service.NewRetentionService(retentionRepo, &config.Retention, telemetry.Tracer, logger)
+	tenantService := service.NewTenantService(tenantRepo, problemRepo, &config.Tenancy, telemetry.Tracer, logger)
+
+	// Subscribe event handlers
+	eventBus.Subscribe(domain.EventContestCreated, problemService.HandleContestCreated)
+	eventBus.Subscribe(domain.EventProblemCompletionChanged, problemService.HandleProblemCompletionChanged)
+	eventBus.Subscribe(domain.EventProblemSolved, feedService.HandleProblemSolved)
+	eventBus.Subscribe(domain.EventContestFinished, feedService.HandleContestFinished)
+	eventBus.Subscribe(domain.EventChallengeAccepted, feedService.HandleChallengeAccepted)
+
+	// Initialize handlers
+	authHandler := handler.NewAuthHandler(userService)
+	userHandler := handler.NewUserHandler(userService)
+	problemHandler := handler.NewProblemHandler(problemService, filterService)
+	filterHandler := handler.NewSavedFilterHandler(filterService)
+	customProblemHandler := handler.NewCustomProblemHandler(customProblemService)
+	roadmapHandler := handler.NewRoadmapHandler(roadmapService)
+	contestHandler := handler.NewContestHandler(contestService)
+	challengeHandler := handler.NewChallengeHandler(challengeService)
+	templateHandler := handler.NewContestTemplateHandler(templateService)
+	listHandler := handler.NewProblemListHandler(listService)
+	orgHandler := handler.NewOrgHandler(orgService)
+	mentorshipHandler := handler.NewMentorshipHandler(mentorshipService)
+	assignmentHandler := handler.NewAssignmentHandler(assignmentService)
+	proctoringHandler := handler.NewProctoringHandler(proctoringService)
+	digestHandler := handler.NewDigestHandler(digestService)
+	similarityHandler := handler.NewSimilarityHandler(similarityService)
+	sessionHandler := handler.NewContestSessionHandler(sessionService)
+	noteSearchHandler := handler.NewNoteSearchHandler(noteSearchService)
+	feedHandler := handler.NewFeedHandler(feedService)
+	featureFlagHandler := handler.NewFeatureFlagHandler(featureFlagService)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	logLevelHandler := handler.NewLogLevelHandler(logLevelService)
+	integrityHandler := handler.NewIntegrityHandler(integrityService)
+	backupHandler := handler.NewBackupHandler(backupService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	quotaHandler := handler.NewQuotaHandler(quotaService)
+	presenceHandler := handler.NewPresenceHandler(presenceService)
+	chatHandler := handler.NewChatHandler(chatService)
+	billingHandler := handler.NewBillingHandler(billingService)
+	publicStatsHandler := handler.NewPublicStatsHandler(publicStatsService)
+	tenantHandler := handler.NewTenantHandler(tenantService)
+	docsHandler, err := handler.NewDocsHandler(config.Telemetry.ServiceVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI spec: %w", err)
+	}
+
+	// Setup Gin router
+	if config.Server.Environment == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+
+	// Add global middleware
+	router.Use(middleware.RecoveryMiddleware(logger, crashReporter))
+	router.Use(middleware.ServedByMiddleware(config.Server.Region, hostname))
+	router.Use(middleware.LoggingMiddleware(logger, middleware.NewLogSampler(&config.Logging, logger)))
+	router.Use(middleware.CORSMiddleware(middleware.DefaultCORSConfig()))
+	router.Use(middleware.TracingMiddleware(telemetry.Tracer))
+	router.Use(middleware.MetricsMiddleware(metrics))
+	router.Use(middleware.ErrorHandlerMiddleware())
+	router.Use(middleware.FeatureFlagsMiddleware(featureFlags))
+
+	// Health check endpoint
+	router.GET("/health", func(c *gin.Context) {
+		if err := database.HealthCheck(c.Request.Context()); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "unhealthy",
+				"error":  "database connection failed",
+			})
+			return
+		}
+		health := gin.H{
+			"status":  "healthy",
+			"version": config.Telemetry.ServiceVersion,
+			"region":  config.Server.Region,
+		}
+		if database.Replicas != nil {
+			health["replicas"] = database.Replicas.Status()
+		}
+		c.JSON(http.StatusOK, health)
+	})
+
+	// Readiness endpoint: ready while every required dependency passed its last probe
+	router.GET("/readyz", func(c *gin.Context) {
+		// Open breakers are reported, but the dependency probes decide readiness
+		ready, statuses := dependencies.Status()
+		breakers := telemetry.Breakers.Status()
+		if !ready {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready", "dependencies": statuses, "breakers": breakers})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "dependencies": statuses, "breakers": breakers})
+	})
+
+	// Metrics endpoint for Prometheus; exemplars are only exposed in the OpenMetrics
+	// format, which Prometheus negotiates when exemplar storage is enabled
+	router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	})))
+
+	// Per-user limits on expensive endpoints, applied per route after authentication
+	rateLimits := config.RateLimits
+	if !rateLimits.Enabled {
+		rateLimits = infrastructure.RateLimitConfig{}
+	}
+	contestRate := middleware.RateLimit{Name: "contests", Limit: rateLimits.ContestsPerHour, Window: time.Hour}
+	contestLimit := middleware.RateLimitMiddleware(rateLimitRepo, contestRate, logger)
+	searchLimit := middleware.RateLimitMiddleware(rateLimitRepo,
+		middleware.RateLimit{Name: "searches", Limit: rateLimits.SearchesPerMinute, Window: time.Minute}, logger)
+	reportLimit := middleware.RateLimitMiddleware(rateLimitRepo,
+		middleware.RateLimit{Name: "reports", Limit: rateLimits.ReportsPerMinute, Window: time.Minute}, logger)
+	chatLimit := middleware.RateLimitMiddleware(rateLimitRepo,
+		middleware.RateLimit{Name: "chat", Limit: rateLimits.ChatPerMinute, Window: time.Minute}, logger)
+	publicLimit := middleware.RateLimitMiddleware(rateLimitRepo,
+		middleware.RateLimit{Name: "public", Limit: rateLimits.PublicPerMinute, Window: time.Minute}, logger)
+
+	// Quick start commands create contests, so they count against the contest limit
+	quickHandler := handler.NewQuickHandler(quickService, middleware.RateLimitCheck(rateLimitRepo, contestRate, logger))
+
+	// API routes
+	api := router.Group("/api")
+	api.Use(middleware.MaintenanceMiddleware(maintenance))
+	api.Use(middleware.AlertMiddleware(alerts))
+	api.Use(middleware.DeprecationMiddleware(handler.APIOperations(), metrics, logger))
+	if config.LoadShed.Enabled {
+		limiter := middleware.NewAdaptiveLimiter(&config.LoadShed)
+		if err := limiter.RegisterMetrics(telemetry.Meter); err != nil {
+			logger.Warn("Failed to register concurrency limit metrics", zap.Error(err))
+		}
+		api.Use(middleware.LoadSheddingMiddleware(limiter, metrics))
+	}
+	api.Use(middleware.TimeoutMiddleware(middleware.TimeoutConfig{
+		Default: config.Server.HandlerTimeout,
+		Routes: map[string]time.Duration{
+			"POST /api/contests":                                        config.Server.SlowHandlerTimeout,
+			"POST /api/challenges/:code/accept":                         config.Server.SlowHandlerTimeout,
+			"POST /api/orgs/:id/assignments":                            config.Server.SlowHandlerTimeout,
+			"POST /api/orgs/:id/assignments/:assignmentId/start":        config.Server.SlowHandlerTimeout,
+			"POST /api/mentorships/:id/assignments":                     config.Server.SlowHandlerTimeout,
+			"POST /api/mentorships/:id/assignments/:assignmentId/start": config.Server.SlowHandlerTimeout,
+			"GET /api/admin/problems/calibration":                       config.Server.SlowHandlerTimeout,
+			"POST /api/admin/integrity":                                 config.Server.SlowHandlerTimeout,
+			"POST /api/admin/backups":                                   config.Server.SlowHandlerTimeout,
+			"POST /api/admin/backups/:id/verify":                        config.Server.SlowHandlerTimeout,
+			"POST /api/admin/retention":                                 config.Server.SlowHandlerTimeout,
+			"POST /api/admin/digests/send":                              config.Server.SlowHandlerTimeout,
+			"POST /api/admin/similarity/check":                          config.Server.SlowHandlerTimeout,
+			"POST /api/quick":                                           config.Server.SlowHandlerTimeout,
+		},
+	}))
+	if config.Tenancy.Enabled {
+		// Resolved before the request transaction so its queries are scoped too
+		api.Use(middleware.TenancyMiddleware(tenantService, &config.Tenancy))
+	}
+	if config.Database.RequestTransactions {
+		// Jobs that commit in batches or need their own isolation level stay out of the
+		// request transaction, and so do lookups that only read despite being POSTs
+		api.Use(middleware.TransactionMiddleware(middleware.TransactionConfig{
+			DB: database.DB,
+			Skip: map[string]bool{
+				"POST /api/problems/batch":           true,
+				"POST /api/admin/integrity":          true,
+				"POST /api/admin/retention":          true,
+				"POST /api/admin/digests/send":       true,
+				"POST /api/admin/similarity/check":   true,
+				"POST /api/admin/backups":            true,
+				"POST /api/admin/backups/:id/verify": true,
+			},
+		}, logger))
+	}
+	{
+		// API documentation
+		api.GET("/openapi.json", docsHandler.GetSpec)
+		api.GET("/docs", docsHandler.GetDocs)
+
+		// Auth routes (public)
+		auth := api.Group("/auth")
+		{
+			auth.POST("/signup", authHandler.Register)
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", middleware.AuthMiddleware(userService), authHandler.Logout)
+			auth.POST("/logout-all", middleware.AuthMiddleware(userService), authHandler.LogoutAll)
+		}
+
+		// Problem routes (public for listing, protected for some features)
+		problems := api.Group("/problems")
+		problems.Use(middleware.OptionalAuthMiddleware(userService))
+		{
+			problems.GET("", searchLimit, problemHandler.GetProblems)
+			problems.GET("/stats", problemHandler.GetProblemStats)
+			problems.GET("/search", searchLimit, problemHandler.SearchProblems)
+			problems.POST("/batch", problemHandler.GetProblemsBatch)
+			problems.GET("/slug/:slug", problemHandler.GetProblemPage)
+			problems.GET("/:id", problemHandler.GetProblem)
+			problems.GET("/:id/prerequisites", problemHandler.GetPrerequisites)
+		}
+
+		// Maintenance announcements (public)
+		api.GET("/maintenance", maintenanceHandler.GetStatus)
+
+		// Sitemap of the public problem pages
+		api.GET("/sitemap.xml", problemHandler.GetSitemap)
+
+		// Companies (public)
+		api.GET("/companies", problemHandler.GetCompanies)
+
+		// Platform statistics (public, limited per client IP)
+		public := api.Group("/public")
+		public.Use(publicLimit)
+		{
+			public.GET("/stats", publicStatsHandler.GetStats)
+			public.GET("/stats/problems", publicStatsHandler.GetProblemStats)
+		}
+
+		// Click-through links of recommendation digests (public, identified by their token)
+		api.GET("/digests/click/:token", publicLimit, digestHandler.Click)
+
+		// Tenant of the request (public)
+		api.GET("/tenant", tenantHandler.GetCurrent)
+
+		// Roadmap (public, with completion for authenticated users)
+		api.GET("/roadmap", middleware.OptionalAuthMiddleware(userService), roadmapHandler.GetRoadmap)
+
+		// Stripe webhooks (public, authenticated by their signature)
+		api.POST("/billing/webhook", billingHandler.HandleWebhook)
+
+		// Protected routes
+		protected := api.Group("")
+		protected.Use(middleware.AuthMiddleware(userService))
+		{
+			// User routes
+			users := protected.Group("/users")
+			{
+				users.GET("/me", userHandler.GetCurrentUser)
+				users.GET("/me/progress", reportLimit, userHandler.GetUserProgress)
+				users.GET("/me/reviews", userHandler.GetReviewQueue)
+				users.GET("/me/attempts/:problemId", userHandler.GetAttemptHistory)
+				users.GET("/me/contests/by-topic", reportLimit, contestHandler.GetTopicHistory)
+				users.PUT("/me/password", userHandler.ChangePassword)
+				users.GET("/me/filters", filterHandler.GetFilters)
+				users.POST("/me/filters", filterHandler.CreateFilter)
+				users.PUT("/me/filters/:filterId", filterHandler.UpdateFilter)
+				users.DELETE("/me/filters/:filterId", filterHandler.DeleteFilter)
+				users.GET("/me/problems", customProblemHandler.GetCustomProblems)
+				users.POST("/me/problems", customProblemHandler.CreateCustomProblem)
+				users.PUT("/me/problems/:problemId", customProblemHandler.UpdateCustomProblem)
+				users.DELETE("/me/problems/:problemId", customProblemHandler.DeleteCustomProblem)
+				users.GET("/me/features", featureFlagHandler.GetFeatures)
+				users.GET("/me/quotas", quotaHandler.GetMyQuotas)
+				users.POST("/me/heartbeat", presenceHandler.Heartbeat)
+				users.GET("/me/digest", digestHandler.GetDigest)
+				users.PUT("/me/digest", digestHandler.UpdateDigest)
+				users.GET("/me/search", searchLimit, noteSearchHandler.Search)
+				users.GET("/me/feed", feedHandler.GetFeed)
+			}
+
+			// Contest routes
+			contests := protected.Group("/contests")
+			{
+				contests.POST("", contestLimit, contestHandler.CreateContest)
+				contests.GET("", contestHandler.GetContests)
+				contests.GET("/active", contestHandler.GetActiveContest)
+				contests.GET("/tags", searchLimit, contestHandler.GetTagSuggestions)
+				contests.GET("/availability", searchLimit, contestHandler.GetAvailability)
+				contests.POST("/import", contestLimit, templateHandler.ImportTemplate)
+				contests.GET("/:id", contestHandler.GetContest)
+				contests.PATCH("/:id/problems/:problemId", contestHandler.MarkProblemComplete)
+				contests.PUT("/:id/problems/:problemId/complexity", contestHandler.StateComplexity)
+				contests.POST("/:id/problems/:problemId/attempts", contestHandler.RecordAttempt)
+				contests.POST("/:id/problems/:problemId/start", contestHandler.StartProblem)
+				contests.GET("/:id/problems/:problemId/solution", similarityHandler.GetSolution)
+				contests.PUT("/:id/problems/:problemId/solution", similarityHandler.AttachSolution)
+				contests.GET("/:id/state", sessionHandler.GetState)
+				contests.PUT("/:id/state", sessionHandler.SaveState)
+				contests.PATCH("/:id/warmup", contestHandler.MarkWarmupComplete)
+				contests.POST("/:id/start", contestHandler.StartContest)
+				contests.PATCH("/:id/retro", contestHandler.UpdateRetro)
+				contests.PUT("/:id/rating", contestHandler.RateContest)
+				contests.PUT("/:id/tags", contestHandler.SetContestTags)
+				contests.POST("/:id/complete", contestHandler.CompleteContest)
+				contests.POST("/:id/abandon", contestHandler.AbandonContest)
+				contests.POST("/:id/events", proctoringHandler.RecordEvents)
+				contests.POST("/:id/challenge", challengeHandler.CreateChallenge)
+				contests.GET("/:id/template", templateHandler.ExportTemplate)
+			}
+
+			// Quick commands for keyboard-driven clients
+			protected.POST("/quick", quickHandler.Execute)
+			protected.GET("/quick/history", quickHandler.GetHistory)
+
+			// Billing routes
+			protected.POST("/billing/checkout", billingHandler.CreateCheckoutSession)
+
+			// Challenge routes
+			challenges := protected.Group("/challenges")
+			{
+				challenges.GET("/:code", challengeHandler.GetChallenge)
+				challenges.POST("/:code/accept", contestLimit, challengeHandler.AcceptChallenge)
+				challenges.GET("/:code/comparison", reportLimit, challengeHandler.GetComparison)
+				challenges.GET("/:code/standings", challengeHandler.GetStandings)
+				challenges.POST("/:code/spectators", challengeHandler.OpenSpectatorInvite)
+				challenges.DELETE("/:code/spectators", challengeHandler.CloseSpectatorInvite)
+				challenges.GET("/:code/chat", chatHandler.GetMessages)
+				challenges.POST("/:code/chat", chatLimit, chatHandler.PostMessage)
+			}
+
+			// Organization (classroom) routes
+			orgs := protected.Group("/orgs")
+			{
+				orgs.POST("", orgHandler.CreateOrg)
+				orgs.GET("", orgHandler.GetOrgs)
+				orgs.POST("/join", orgHandler.JoinOrg)
+				orgs.GET("/:id", orgHandler.GetOrg)
+				orgs.POST("/:id/invites", orgHandler.CreateInvite)
+				orgs.DELETE("/:id/invites/:code", orgHandler.RevokeInvite)
+				orgs.DELETE("/:id/members/:userId", orgHandler.RemoveMember)
+				orgs.GET("/:id/roster", reportLimit, orgHandler.GetRoster)
+				orgs.GET("/:id/assignments", orgHandler.GetAssignments)
+				orgs.POST("/:id/assignments", orgHandler.CreateAssignment)
+				orgs.DELETE("/:id/assignments/:assignmentId", orgHandler.DeleteAssignment)
+				orgs.GET("/:id/assignments/:assignmentId/report", reportLimit, orgHandler.GetAssignmentReport)
+				orgs.POST("/:id/assignments/:assignmentId/start", contestLimit, orgHandler.StartAssignment)
+				orgs.GET("/:id/contests/:contestId/proctoring", proctoringHandler.GetSummary)
+				orgs.GET("/:id/assignments/:assignmentId/similarity", similarityHandler.ListFlags)
+				orgs.GET("/:id/similarity/:flagId", similarityHandler.GetFlag)
+				orgs.PATCH("/:id/similarity/:flagId", similarityHandler.ReviewFlag)
+			}
+
+			// Mentorship routes; mentors read a consenting mentee's data
+			mentorships := protected.Group("/mentorships")
+			{
+				mentorships.POST("", mentorshipHandler.Invite)
+				mentorships.GET("", mentorshipHandler.GetMentorships)
+				mentorships.POST("/:id/accept", mentorshipHandler.Accept)
+				mentorships.POST("/:id/decline", mentorshipHandler.Decline)
+				mentorships.DELETE("/:id", mentorshipHandler.Revoke)
+				mentorships.GET("/:id/contests", mentorshipHandler.GetMenteeContests)
+				mentorships.GET("/:id/contests/:contestId", mentorshipHandler.GetMenteeContest)
+				mentorships.GET("/:id/timing", mentorshipHandler.GetMenteeTiming)
+				mentorships.GET("/:id/notes", mentorshipHandler.GetMenteeNotes)
+				mentorships.GET("/:id/audit", mentorshipHandler.GetAudit)
+				mentorships.GET("/:id/assignments", mentorshipHandler.GetAssignments)
+				mentorships.POST("/:id/assignments", mentorshipHandler.CreateAssignment)
+				mentorships.DELETE("/:id/assignments/:assignmentId", mentorshipHandler.DeleteAssignment)
+				mentorships.POST("/:id/assignments/:assignmentId/start", contestLimit, mentorshipHandler.StartAssignment)
+			}
+
+			// Community problem lists
+			lists := protected.Group("/lists")
+			{
+				lists.GET("", searchLimit, listHandler.BrowseLists)
+				lists.POST("", listHandler.PublishList)
+				lists.GET("/:id", listHandler.GetList)
+				lists.PUT("/:id", listHandler.UpdateList)
+				lists.DELETE("/:id", listHandler.DeleteList)
+				lists.PUT("/:id/subscription", listHandler.Subscribe)
+				lists.DELETE("/:id/subscription", listHandler.Unsubscribe)
+				lists.POST("/:id/contests", contestLimit, listHandler.CreateContest)
+				lists.POST("/:id/reports", listHandler.ReportList)
+			}
+
+			// Pending work from the caller's organizations and mentors
+			protected.GET("/assignments", assignmentHandler.GetPendingAssignments)
+
+			// Per-tenant routes
+			tenant := protected.Group("/tenant")
+			{
+				tenant.GET("/leaderboard", tenantHandler.GetLeaderboard)
+				tenant.POST("/problems", middleware.RequireRole(domain.RoleAdmin), tenantHandler.CreateProblem)
+			}
+
+			// Read-only challenge standings for invited spectators
+			protected.GET("/spectate/:spectatorCode", challengeHandler.Spectate)
+
+			// Admin routes
+			admin := protected.Group("/admin")
+			admin.Use(middleware.RequireRole(domain.RoleAdmin), middleware.RequireDefaultTenant())
+			{
+				admin.GET("/problems/calibration", reportLimit, problemHandler.GetCalibration)
+				admin.PUT("/problems/:id/companies", problemHandler.SetProblemCompanies)
+				admin.PATCH("/problems/:id/importance", problemHandler.SetProblemImportance)
+				admin.PUT("/problems/:id/complexity", problemHandler.SetProblemComplexity)
+				admin.POST("/users/:id/revoke-tokens", userHandler.RevokeUserTokens)
+				admin.GET("/users/:id/quotas", quotaHandler.GetUserQuotas)
+				admin.PUT("/users/:id/quotas", quotaHandler.SetUserQuotas)
+				admin.DELETE("/users/:id/quotas", quotaHandler.ClearUserQuotas)
+				admin.GET("/feature-flags", featureFlagHandler.GetFlags)
+				admin.PUT("/feature-flags/:key", featureFlagHandler.UpdateFlag)
+				admin.GET("/experiments", reportLimit, contestHandler.GetExperiments)
+				admin.PUT("/maintenance", maintenanceHandler.SetMaintenance)
+				admin.GET("/analytics/cohorts", reportLimit, analyticsHandler.GetCohorts)
+				admin.GET("/log-level", logLevelHandler.GetLogLevel)
+				admin.PUT("/log-level", logLevelHandler.SetLogLevel)
+				admin.POST("/integrity", reportLimit, integrityHandler.RunIntegrity)
+				admin.POST("/retention", reportLimit, retentionHandler.RunRetention)
+				admin.POST("/digests/send", reportLimit, digestHandler.SendDigests)
+				admin.GET("/digests/stats", reportLimit, digestHandler.GetStats)
+				admin.POST("/similarity/check", reportLimit, similarityHandler.CheckNow)
+				admin.GET("/tenants", tenantHandler.ListTenants)
+				admin.POST("/tenants", tenantHandler.CreateTenant)
+				admin.GET("/backups", backupHandler.ListBackups)
+				admin.POST("/backups", reportLimit, backupHandler.CreateBackup)
+				admin.POST("/backups/:id/verify", reportLimit, backupHandler.VerifyBackup)
+				admin.GET("/lists", listHandler.GetModerationQueue)
+				admin.PATCH("/lists/:id", listHandler.ModerateList)
+			}
+		}
+	}
+
+	// Report routes that have drifted from the OpenAPI operation table
+	var routes []openapi.RouteKey
+	for _, r := range router.Routes() {
+		if strings.HasPrefix(r.Path, "/api/") {
+			routes = append(routes, openapi.RouteKey{Method: r.Method, Path: r.Path})
+		}
+	}
+	undocumented, unregistered := openapi.Diff(handler.APIOperations(), routes)
+	for _, r := range undocumented {
+		logger.Warn("Route missing from OpenAPI spec", zap.String("method", r.Method), zap.String("path", r.Path))
+	}
+	for _, r := range unregistered {
+		logger.Warn("OpenAPI operation has no route", zap.String("method", r.Method), zap.String("path", r.Path))
+	}
+
+	// Background jobs take a lock, so scaled-out instances do not run them twice
+	jobLocks := infrastructure.NewJobLocks(database.DB, logger)
+
+	a := &App{
+		Router:           router,
+		expiryWorker:     service.NewContestExpiryWorker(contestRepo, eventBus, &config.Contest, jobLocks, logger),
+		progressWorker:   service.NewProgressBackfillWorker(progressRepo, &config.Progress, jobLocks, logger),
+		cohortWorker:     service.NewCohortSnapshotWorker(analyticsService, &config.Analytics, jobLocks, logger),
+		backupWorker:     service.NewBackupWorker(backupService, &config.Backup, jobLocks, logger),
+		retentionWorker:  service.NewRetentionWorker(retentionService, &config.Retention, jobLocks, logger),
+		presenceWorker:   service.NewPresenceSweepWorker(presenceRepo, &config.Presence, jobLocks, logger),
+		digestWorker:     service.NewDigestWorker(digestService, &config.Digest, jobLocks, logger),
+		similarityWorker: service.NewSimilarityWorker(similarityService, &config.Similarity, jobLocks, logger),
+		alerts:           alerts,
+		dependencies:     dependencies,
+		logLevel:         runtimeLogLevel,
+		crashReporter:    crashReporter,
+		logger:           logger,
+	}
+
+	// Workers stop before the event bus so their last events are still delivered
+	a.shutdown = []shutdownStep{
+		{name: "contest expiry worker", timeout: config.Shutdown.WorkerTimeout, stop: a.expiryWorker.Stop},
+		{name: "progress backfill worker", timeout: config.Shutdown.WorkerTimeout, stop: a.progressWorker.Stop},
+		{name: "cohort snapshot worker", timeout: config.Shutdown.WorkerTimeout, stop: a.cohortWorker.Stop},
+		{name: "backup worker", timeout: config.Shutdown.WorkerTimeout, stop: a.backupWorker.Stop},
+		{name: "retention worker", timeout: config.Shutdown.WorkerTimeout, stop: a.retentionWorker.Stop},
+		{name: "presence sweep worker", timeout: config.Shutdown.WorkerTimeout, stop: a.presenceWorker.Stop},
+		{name: "digest worker", timeout: config.Shutdown.WorkerTimeout, stop: a.digestWorker.Stop},
+		{name: "similarity worker", timeout: config.Shutdown.WorkerTimeout, stop: a.similarityWorker.Stop},
+		{name: "alert evaluator", timeout: config.Shutdown.WorkerTimeout, stop: a.alerts.Stop},
+		{name: "dependency probes", timeout: config.Shutdown.WorkerTimeout, stop: a.dependencies.Stop},
+		{name: "log level refresh", timeout: config.Shutdown.WorkerTimeout, stop: a.logLevel.Stop},
+		{name: "event bus", timeout: config.Shutdown.EventTimeout, stop: eventBus.Close},
+		{name: "crash reporter", timeout: config.Shutdown.WorkerTimeout, stop: a.crashReporter.Stop},
+	}
+	return a, nil
+}
+
+// Start runs the background workers until ctx is cancelled or Stop is called
+func (a *App) Start(ctx context.Context) {
+	a.expiryWorker.Start(ctx)
+	a.progressWorker.Start(ctx)
+	a.cohortWorker.Start(ctx)
+	a.backupWorker.Start(ctx)
+	a.retentionWorker.Start(ctx)
+	a.presenceWorker.Start(ctx)
+	a.digestWorker.Start(ctx)
+	a.similarityWorker.Start(ctx)
+	a.alerts.Start(ctx)
+	a.dependencies.Start(ctx)
+	a.logLevel.Start(ctx)
+}
+
+// Stop stops the background workers and drains queued events, giving each
+// component its own timeout; call it after the HTTP server has stopped and
+// before the database closes. A component that times out is reported and the
+// remaining ones are still stopped.
+func (a *App) Stop(ctx context.Context) error {
+	var errs []error
+	for _, step := range a.shutdown {
+		start := time.Now()
+		stepCtx, cancel := context.WithTimeout(ctx, step.timeout)
+		err := step.stop(stepCtx)
+		cancel()
+
+		if err != nil {
+			a.logger.Error("Component did not shut down cleanly",
+				zap.String("component", step.name),
+				zap.Duration("timeout", step.timeout),
+				zap.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+			continue
+		}
+		a.logger.Info("Component shut down",
+			zap.String("component", step.name),
+			zap.Duration("duration", time.Since(start)),
+		)
+	}
+	return errors.Join(errs...)
+}
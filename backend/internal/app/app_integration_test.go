@@ -0,0 +1,163 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/testutil"
+)
+
+// newServer serves the API on a fresh database: in-memory SQLite, or a
+// Postgres container when TEST_POSTGRES is set
+func newServer(t *testing.T) *testutil.Server {
+	t.Helper()
+	return newServerWith(t, nil)
+}
+
+// newServerWith is newServer with the configuration adjusted by configure
+func newServerWith(t *testing.T, configure func(*infrastructure.Config)) *testutil.Server {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("integration test")
+	}
+
+	opts := testutil.ServerOptions{Postgres: os.Getenv("TEST_POSTGRES") != "", Configure: configure}
+	srv, err := testutil.NewServer(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := srv.Close(); err != nil {
+			t.Errorf("close server: %v", err)
+		}
+	})
+	return srv
+}
+
+func signUp(t *testing.T, srv *testutil.Server, name string) *testutil.Client {
+	t.Helper()
+	c, err := srv.SignUp(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func createContest(t *testing.T, c *testutil.Client, problems int) domain.ContestResponse {
+	t.Helper()
+	var contest domain.ContestResponse
+	req := domain.CreateContestRequest{ProblemCount: problems, DurationMinutes: 30}
+	if err := c.Do(http.MethodPost, "/api/contests", req, http.StatusCreated, &contest); err != nil {
+		t.Fatal(err)
+	}
+	if len(contest.Problems) != problems || contest.Status != domain.ContestStatusActive {
+		t.Fatalf("created contest has %d problems in status %s, want %d active", len(contest.Problems), contest.Status, problems)
+	}
+	return contest
+}
+
+func markSolved(t *testing.T, c *testutil.Client, contest domain.ContestResponse, index int) {
+	t.Helper()
+	path := fmt.Sprintf("/api/contests/%s/problems/%s", contest.ID, contest.Problems[index].Problem.ID)
+	if err := c.Do(http.MethodPatch, path, domain.MarkProblemCompleteRequest{IsCompleted: true}, http.StatusOK, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectProgress(t *testing.T, c *testutil.Client, solved int, want domain.ContestStatistics) {
+	t.Helper()
+	var p domain.UserProgress
+	if err := c.Do(http.MethodGet, "/api/users/me/progress", nil, http.StatusOK, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.TotalSolved != solved || p.ContestStats != want {
+		t.Fatalf("progress: got %d solved and %+v, want %d and %+v", p.TotalSolved, p.ContestStats, solved, want)
+	}
+}
+
+func TestSignupToCompletedContest(t *testing.T) {
+	srv := newServer(t)
+	alice := signUp(t, srv, "alice")
+
+	var me domain.UserResponse
+	if err := alice.Do(http.MethodGet, "/api/users/me", nil, http.StatusOK, &me); err != nil {
+		t.Fatal(err)
+	}
+	if me.ID != alice.Auth.User.ID {
+		t.Fatalf("users/me returned %s, signed up as %s", me.ID, alice.Auth.User.ID)
+	}
+	expectProgress(t, alice, 0, domain.ContestStatistics{})
+
+	contest := createContest(t, alice, 3)
+	if err := alice.ExpectError(http.MethodPost, "/api/contests", domain.CreateContestRequest{ProblemCount: 3, DurationMinutes: 30},
+		http.StatusConflict, domain.CodeActiveContest); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range contest.Problems {
+		markSolved(t, alice, contest, i)
+	}
+	if err := alice.Do(http.MethodPost, fmt.Sprintf("/api/contests/%s/complete", contest.ID), nil, http.StatusOK, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var finished domain.ContestResponse
+	if err := alice.Do(http.MethodGet, "/api/contests/"+contest.ID.String(), nil, http.StatusOK, &finished); err != nil {
+		t.Fatal(err)
+	}
+	if finished.Status != domain.ContestStatusCompleted || finished.EndedAt == nil {
+		t.Fatalf("completed contest has status %s", finished.Status)
+	}
+	for _, p := range finished.Problems {
+		if !p.IsCompleted {
+			t.Errorf("problem %s is not marked completed", p.Problem.Title)
+		}
+	}
+
+	expectProgress(t, alice, 3, domain.ContestStatistics{TotalContests: 1, CompletedContests: 1})
+}
+
+func TestAbandonedContestCountsSolvedProblems(t *testing.T) {
+	srv := newServer(t)
+	bob := signUp(t, srv, "bob")
+
+	contest := createContest(t, bob, 2)
+	markSolved(t, bob, contest, 0)
+	if err := bob.Do(http.MethodPost, fmt.Sprintf("/api/contests/%s/abandon", contest.ID), nil, http.StatusOK, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := bob.ExpectError(http.MethodPost, fmt.Sprintf("/api/contests/%s/complete", contest.ID), nil,
+		http.StatusBadRequest, domain.CodeContestNotActive); err != nil {
+		t.Fatal(err)
+	}
+
+	expectProgress(t, bob, 1, domain.ContestStatistics{TotalContests: 1, AbandonedContests: 1})
+}
+
+func TestSignedOutClientIsRejected(t *testing.T) {
+	srv := newServer(t)
+	carol := signUp(t, srv, "carol")
+
+	// A new sign-in works with the same credentials
+	again := srv.Client()
+	if err := again.Login("carol@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	revoked := *carol.Auth.Tokens
+	if err := carol.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	carol.Auth.Tokens = &revoked
+	if err := carol.ExpectError(http.MethodGet, "/api/users/me", nil, http.StatusUnauthorized, domain.CodeTokenRevoked); err != nil {
+		t.Fatal(err)
+	}
+	if err := again.Do(http.MethodGet, "/api/users/me", nil, http.StatusOK, nil); err != nil {
+		t.Fatalf("other session after logout: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package app_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/repository"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+func TestBackupRestoresTenants(t *testing.T) {
+	dir := t.TempDir()
+	configure := func(config *infrastructure.Config) {
+		config.Tenancy.Enabled = true
+		config.Backup.Storage = infrastructure.BackupStorageFile
+		config.Backup.Dir = dir
+		config.Backup.Interval = 0
+	}
+	source := newServerWith(t, configure)
+
+	alice := signUp(t, source, "alice")
+	if err := source.Promote("alice@example.com", domain.RoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	if err := alice.Login("alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	tenant := domain.CreateTenantRequest{Slug: "acme", Name: "Acme", AdminEmail: "carol@example.com"}
+	if err := alice.Do(http.MethodPost, "/api/admin/tenants", tenant, http.StatusCreated, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// carol is the tenant's admin and adds a problem to its catalog
+	carol := source.Client()
+	carol.Header.Set("X-Tenant", "acme")
+	if err := carol.SignUp("carol@example.com", "carol"); err != nil {
+		t.Fatal(err)
+	}
+	var problem domain.Problem
+	req := domain.CustomProblemRequest{Title: "Acme Routing", URL: "https://example.com/acme-routing", Difficulty: domain.DifficultyMedium}
+	if err := carol.Do(http.MethodPost, "/api/tenant/problems", req, http.StatusCreated, &problem); err != nil {
+		t.Fatal(err)
+	}
+
+	// Selection is random, so point carol's contest at the tenant problem
+	contest := createContest(t, carol, 1)
+	if err := source.Database.DB.Model(&domain.ContestProblem{}).Where("contest_id = ?", contest.ID).
+		Update("problem_id", problem.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var manifest domain.BackupManifest
+	if err := alice.Do(http.MethodPost, "/api/admin/backups", nil, http.StatusCreated, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if file := manifest.File(domain.BackupTenants); file == nil || file.Rows != 1 {
+		t.Fatalf("backup tenants file: %+v", file)
+	}
+
+	// Restore into a fresh database, as the backup command does
+	target := newServerWith(t, configure)
+	config := infrastructure.LoadConfig().Backup
+	config.Storage, config.Dir = infrastructure.BackupStorageFile, dir
+	store, err := infrastructure.NewObjectStore(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backups := service.NewBackupService(repository.NewBackupRepository(target.Database.DB), store, &config, noop.NewTracerProvider().Tracer(""), zap.NewNop())
+	report, err := backups.Restore(context.Background(), manifest.ID)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if report.Rows[domain.BackupTenants] != 1 || report.Rows[domain.BackupProblems] != 1 {
+		t.Fatalf("restored rows: %v", report.Rows)
+	}
+
+	restored := target.Client()
+	restored.Header.Set("X-Tenant", "acme")
+	if err := restored.Login("carol@example.com"); err != nil {
+		t.Fatalf("tenant user after restore: %v", err)
+	}
+	var got domain.ContestResponse
+	if err := restored.Do(http.MethodGet, "/api/contests/"+contest.ID.String(), nil, http.StatusOK, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Problems) != 1 || got.Problems[0].Problem.ID != problem.ID {
+		t.Fatalf("restored contest does not reference the tenant problem %s", problem.ID)
+	}
+}
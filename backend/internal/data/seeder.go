@@ -1,8 +1,13 @@
 package data
 
 import (
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -14,6 +19,24 @@ import (
 //go:embed neetcode150.json
 var neetcode150Data []byte
 
+//go:embed companies.json
+var companiesData []byte
+
+//go:embed frequency.json
+var frequencyData []byte
+
+//go:embed prerequisites.json
+var prerequisitesData []byte
+
+// problemsSeed names the NeetCode 150 data set in the seed versions
+const problemsSeed = "problems"
+
+// prerequisiteJSON represents the JSON structure of a curated prerequisite edge list
+type prerequisiteJSON struct {
+	Problem  string   `json:"problem"`
+	Requires []string `json:"requires"`
+}
+
 // problemJSON represents the JSON structure for problems
 type problemJSON struct {
 	Title       string   `json:"title"`
@@ -25,6 +48,34 @@ type problemJSON struct {
 	OrderIndex  int      `json:"order_index"`
 }
 
+// toProblem converts the entry into a catalog problem with a new ID
+func (p problemJSON) toProblem() domain.Problem {
+	return domain.Problem{
+		ID:          uuid.New(),
+		Title:       p.Title,
+		Slug:        p.Slug,
+		Difficulty:  domain.Difficulty(p.Difficulty),
+		Topics:      p.Topics,
+		LeetCodeURL: p.LeetCodeURL,
+		NeetCodeURL: p.NeetCodeURL,
+		OrderIndex:  p.OrderIndex,
+	}
+}
+
+// seededFields are the columns of a catalog problem that come from the seed
+// data; the rest (importance, counters, complexity) are kept on reconciliation
+var seededFields = []string{"title", "difficulty", "topics", "leetcode_url", "neetcode_url", "order_index"}
+
+// matchesSeed reports whether the stored problem has the entry's seeded fields
+func (p problemJSON) matchesSeed(problem *domain.Problem) bool {
+	return problem.Title == p.Title &&
+		problem.Difficulty == domain.Difficulty(p.Difficulty) &&
+		slices.Equal([]string(problem.Topics), p.Topics) &&
+		problem.LeetCodeURL == p.LeetCodeURL &&
+		problem.NeetCodeURL == p.NeetCodeURL &&
+		problem.OrderIndex == p.OrderIndex
+}
+
 // Seeder handles database seeding operations
 type Seeder struct {
 	db     *gorm.DB
@@ -39,57 +90,360 @@ func NewSeeder(db *gorm.DB, logger *zap.Logger) *Seeder {
 	}
 }
 
-// SeedProblems seeds the problems table with NeetCode 150 data
-// It uses upsert semantics to avoid duplicates
+// SeedProblems reconciles the catalog with the embedded NeetCode 150 data. The
+// checksum of the data is recorded as the seed version, so startup skips the
+// work until the file changes. Problems are matched by slug: new ones are
+// added and edits to the seeded fields are applied, keeping IDs and everything
+// admins or usage maintain. Problems dropped from the data stay in the
+// catalog, since contests and submissions refer to them.
 func (s *Seeder) SeedProblems() error {
-	s.logger.Info("Starting to seed problems...")
+	checksum := sha256.Sum256(neetcode150Data)
+	version := hex.EncodeToString(checksum[:])
+
+	var applied []domain.SeedVersion
+	if err := s.db.Where("name = ?", problemsSeed).Find(&applied).Error; err != nil {
+		return err
+	}
+	if len(applied) > 0 && applied[0].Checksum == version {
+		s.logger.Info("Problems seed is up to date, skipping",
+			zap.String("version", version[:12]),
+		)
+		return nil
+	}
+
+	s.logger.Info("Reconciling problems with the seed data...",
+		zap.String("version", version[:12]),
+	)
+
+	var problemsJSON []problemJSON
+	if err := json.Unmarshal(neetcode150Data, &problemsJSON); err != nil {
+		return err
+	}
+
+	var created []domain.Problem
+	updated := 0
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var catalog []domain.Problem
+		if err := tx.Where("owner_id IS NULL AND tenant_id = ?", domain.DefaultTenantID).Find(&catalog).Error; err != nil {
+			return err
+		}
+		bySlug := make(map[string]*domain.Problem, len(catalog))
+		for i := range catalog {
+			bySlug[catalog[i].Slug] = &catalog[i]
+		}
+
+		for _, p := range problemsJSON {
+			existing, ok := bySlug[p.Slug]
+			if !ok {
+				created = append(created, p.toProblem())
+				continue
+			}
+			delete(bySlug, p.Slug)
+			if p.matchesSeed(existing) {
+				continue
+			}
+			want := p.toProblem()
+			want.ID = existing.ID
+			if err := tx.Model(&want).Select(seededFields).Updates(&want).Error; err != nil {
+				return err
+			}
+			updated++
+		}
+
+		for slug := range bySlug {
+			s.logger.Warn("Catalog problem is no longer in the seed data, keeping it", zap.String("slug", slug))
+		}
+
+		if len(created) > 0 {
+			if err := tx.CreateInBatches(created, 50).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(&domain.SeedVersion{Name: problemsSeed, Checksum: version, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Successfully reconciled problems",
+		zap.Int("created", len(created)),
+		zap.Int("updated", updated),
+	)
 
-	// Check if problems already exist
+	return nil
+}
+
+// SeedCompanies seeds the curated company tags of problems, keyed by problem slug.
+// It must run after SeedProblems and is skipped once any company tag exists, so
+// tags edited by admins are never overwritten.
+func (s *Seeder) SeedCompanies() error {
 	var count int64
-	if err := s.db.Model(&domain.Problem{}).Count(&count).Error; err != nil {
+	if err := s.db.Model(&domain.ProblemCompany{}).Count(&count).Error; err != nil {
 		return err
 	}
 
 	if count > 0 {
-		s.logger.Info("Problems already seeded, skipping",
+		s.logger.Info("Company tags already seeded, skipping",
 			zap.Int64("count", count),
 		)
 		return nil
 	}
 
-	// Parse embedded JSON
-	var problemsJSON []problemJSON
-	if err := json.Unmarshal(neetcode150Data, &problemsJSON); err != nil {
+	var companiesBySlug map[string][]string
+	if err := json.Unmarshal(companiesData, &companiesBySlug); err != nil {
 		return err
 	}
 
-	// Convert to domain models
-	problems := make([]domain.Problem, len(problemsJSON))
-	for i, p := range problemsJSON {
-		problems[i] = domain.Problem{
-			ID:          uuid.New(),
-			Title:       p.Title,
-			Slug:        p.Slug,
-			Difficulty:  domain.Difficulty(p.Difficulty),
-			Topics:      p.Topics,
-			LeetCodeURL: p.LeetCodeURL,
-			NeetCodeURL: p.NeetCodeURL,
-			OrderIndex:  p.OrderIndex,
+	var problems []domain.Problem
+	if err := s.db.Select("id", "slug").Order("order_index ASC").Find(&problems).Error; err != nil {
+		return err
+	}
+
+	var tags []domain.ProblemCompany
+	for _, p := range problems {
+		for _, company := range domain.NormalizeCompanies(companiesBySlug[p.Slug]) {
+			tags = append(tags, domain.ProblemCompany{ProblemID: p.ID, Company: company})
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+	if err := s.db.CreateInBatches(tags, 100).Error; err != nil {
+		return err
+	}
+
+	s.logger.Info("Successfully seeded company tags",
+		zap.Int("count", len(tags)),
+	)
+
+	return nil
+}
+
+// SeedImportance fills in the importance score of problems from interview
+// frequency data, keyed by problem slug. Only problems without a score (0) are
+// updated, so scores tuned by admins are kept.
+func (s *Seeder) SeedImportance() error {
+	var scores map[string]int
+	if err := json.Unmarshal(frequencyData, &scores); err != nil {
+		return err
+	}
+
+	var problems []domain.Problem
+	if err := s.db.Select("id", "slug").Where("importance = 0").Find(&problems).Error; err != nil {
+		return err
+	}
+
+	updated := 0
+	for _, p := range problems {
+		score, ok := scores[p.Slug]
+		if !ok || score < 1 || score > 100 {
+			continue
+		}
+		if err := s.db.Model(&domain.Problem{}).Where("id = ?", p.ID).UpdateColumn("importance", score).Error; err != nil {
+			return err
+		}
+		updated++
+	}
+
+	if updated > 0 {
+		s.logger.Info("Successfully seeded importance scores",
+			zap.Int("count", updated),
+		)
+	}
+
+	return nil
+}
+
+// SeedPrerequisites seeds the curated prerequisite graph between problems.
+// It must run after SeedProblems and is skipped once any prerequisite exists.
+func (s *Seeder) SeedPrerequisites() error {
+	var count int64
+	if err := s.db.Model(&domain.ProblemPrerequisite{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		s.logger.Info("Prerequisites already seeded, skipping",
+			zap.Int64("count", count),
+		)
+		return nil
+	}
+
+	var edgesJSON []prerequisiteJSON
+	if err := json.Unmarshal(prerequisitesData, &edgesJSON); err != nil {
+		return err
+	}
+	if err := checkAcyclic(edgesJSON); err != nil {
+		return err
+	}
+
+	// Resolve slugs to the IDs assigned when the problems were seeded
+	var problems []domain.Problem
+	if err := s.db.Select("id", "slug").Find(&problems).Error; err != nil {
+		return err
+	}
+	idBySlug := make(map[string]uuid.UUID, len(problems))
+	for _, p := range problems {
+		idBySlug[p.Slug] = p.ID
+	}
+
+	var prerequisites []domain.ProblemPrerequisite
+	for _, edge := range edgesJSON {
+		problemID, ok := idBySlug[edge.Problem]
+		if !ok {
+			s.logger.Warn("Skipping prerequisites of unknown problem", zap.String("slug", edge.Problem))
+			continue
+		}
+		for _, slug := range edge.Requires {
+			prerequisiteID, ok := idBySlug[slug]
+			if !ok {
+				s.logger.Warn("Skipping unknown prerequisite",
+					zap.String("problem", edge.Problem),
+					zap.String("slug", slug),
+				)
+				continue
+			}
+			prerequisites = append(prerequisites, domain.ProblemPrerequisite{
+				ProblemID:      problemID,
+				PrerequisiteID: prerequisiteID,
+			})
+		}
+	}
+
+	if len(prerequisites) == 0 {
+		return nil
+	}
+	if err := s.db.CreateInBatches(prerequisites, 100).Error; err != nil {
+		return err
+	}
+
+	s.logger.Info("Successfully seeded prerequisites",
+		zap.Int("count", len(prerequisites)),
+	)
+
+	return nil
+}
+
+// SeedRoadmap seeds the roadmap from the NeetCode 150 ordering: one category per
+// topic in order of first appearance, with problems in list order. Catalog
+// problems not on the roadmap yet, such as those a newer seed added, are placed
+// under the category of their primary topic, after the existing categories
+// when the topic is new. It must run after SeedProblems.
+func (s *Seeder) SeedRoadmap() error {
+	var existing []domain.RoadmapCategory
+	if err := s.db.Order("position ASC").Find(&existing).Error; err != nil {
+		return err
+	}
+
+	var problems []domain.Problem
+	err := s.db.Where("owner_id IS NULL AND tenant_id = ?", domain.DefaultTenantID).
+		Where("NOT EXISTS (SELECT 1 FROM roadmap_problems WHERE roadmap_problems.problem_id = problems.id)").
+		Order("order_index ASC").
+		Find(&problems).Error
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		s.logger.Info("Roadmap already seeded, skipping",
+			zap.Int("categories", len(existing)),
+		)
+		return nil
+	}
+
+	categoryIDs := make(map[string]uuid.UUID, len(existing))
+	for _, c := range existing {
+		categoryIDs[c.Name] = c.ID
+	}
+	var categories []domain.RoadmapCategory
+	var entries []domain.RoadmapProblem
+	for _, p := range problems {
+		if len(p.Topics) == 0 {
+			continue
 		}
+		// A problem is placed under its primary (first) topic
+		name := p.Topics[0]
+		categoryID, ok := categoryIDs[name]
+		if !ok {
+			categoryID = uuid.New()
+			categoryIDs[name] = categoryID
+			categories = append(categories, domain.RoadmapCategory{
+				ID:       categoryID,
+				Name:     name,
+				Position: len(existing) + len(categories) + 1,
+			})
+		}
+		entries = append(entries, domain.RoadmapProblem{
+			CategoryID: categoryID,
+			ProblemID:  p.ID,
+			Position:   p.OrderIndex,
+		})
 	}
 
-	// Batch insert
-	if err := s.db.CreateInBatches(problems, 50).Error; err != nil {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if len(categories) > 0 {
+			if err := tx.Create(&categories).Error; err != nil {
+				return err
+			}
+		}
+		return tx.CreateInBatches(entries, 100).Error
+	})
+	if err != nil {
 		return err
 	}
 
-	s.logger.Info("Successfully seeded problems",
-		zap.Int("count", len(problems)),
+	s.logger.Info("Successfully seeded roadmap",
+		zap.Int("categories", len(categories)),
+		zap.Int("problems", len(entries)),
 	)
 
 	return nil
 }
 
+// checkAcyclic rejects a prerequisite graph containing a cycle, which would
+// make the problems on it impossible to unlock
+func checkAcyclic(edges []prerequisiteJSON) error {
+	requires := make(map[string][]string, len(edges))
+	for _, edge := range edges {
+		requires[edge.Problem] = append(requires[edge.Problem], edge.Requires...)
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(requires))
+	var visit func(slug string) error
+	visit = func(slug string) error {
+		switch state[slug] {
+		case visiting:
+			return fmt.Errorf("prerequisite cycle through %q", slug)
+		case done:
+			return nil
+		}
+		state[slug] = visiting
+		for _, next := range requires[slug] {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[slug] = done
+		return nil
+	}
+
+	for _, edge := range edges {
+		if err := visit(edge.Problem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetEmbeddedProblems returns the embedded NeetCode 150 problems
 // Useful for testing or direct access
 func GetEmbeddedProblems() ([]domain.Problem, error) {
@@ -100,16 +454,7 @@ func GetEmbeddedProblems() ([]domain.Problem, error) {
 
 	problems := make([]domain.Problem, len(problemsJSON))
 	for i, p := range problemsJSON {
-		problems[i] = domain.Problem{
-			ID:          uuid.New(),
-			Title:       p.Title,
-			Slug:        p.Slug,
-			Difficulty:  domain.Difficulty(p.Difficulty),
-			Topics:      p.Topics,
-			LeetCodeURL: p.LeetCodeURL,
-			NeetCodeURL: p.NeetCodeURL,
-			OrderIndex:  p.OrderIndex,
-		}
+		problems[i] = p.toProblem()
 	}
 
 	return problems, nil
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CohortWeek is the persisted activity of one signup cohort in one week after
+// signup. Weeks run Monday to Sunday in UTC.
+type CohortWeek struct {
+	CohortStart       time.Time `gorm:"primaryKey"`                     // Monday the cohort's users signed up
+	WeekIndex         int       `gorm:"primaryKey;autoIncrement:false"` // 0 is the signup week
+	CohortSize        int       `gorm:"not null"`
+	ActiveUsers       int       `gorm:"not null"` // Users who created a contest or solved a problem that week
+	ContestsStarted   int       `gorm:"not null"`
+	ContestsCompleted int       `gorm:"not null"`
+	ComputedAt        time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (CohortWeek) TableName() string {
+	return "cohort_weeks"
+}
+
+// UserSignup is when a user joined
+type UserSignup struct {
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+// Kinds of user activity counted for cohorts
+const (
+	ActivityContest = "contest"
+	ActivitySolve   = "solve"
+)
+
+// UserActivity is a contest created or a problem solved by a user
+type UserActivity struct {
+	UserID    uuid.UUID
+	At        time.Time
+	Kind      string
+	Completed bool // For contests, whether the contest was completed
+}
+
+// AnalyticsRepository defines the interface for analytics snapshots and the
+// activity they are computed from
+type AnalyticsRepository interface {
+	// FindSignups returns users who signed up at or after since
+	FindSignups(since time.Time) ([]UserSignup, error)
+	// FindActivity returns contests and solves since the given time by users who signed up since then
+	FindActivity(since time.Time) ([]UserActivity, error)
+	// ReplaceCohortWeeks swaps the stored snapshot for the given rows in one transaction
+	ReplaceCohortWeeks(weeks []CohortWeek) error
+	FindCohortWeeks() ([]CohortWeek, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) AnalyticsRepository
+}
+
+// CohortWeekStats is a cohort's activity in one week after signup
+type CohortWeekStats struct {
+	Week              int     `json:"week"` // Weeks since signup; 0 is the signup week
+	ActiveUsers       int     `json:"active_users"`
+	Retention         float64 `json:"retention"` // Share of the cohort active that week
+	ContestsStarted   int     `json:"contests_started"`
+	ContestsCompleted int     `json:"contests_completed"`
+	Complete          bool    `json:"complete"` // False while the week was still running at computation time
+}
+
+// Cohort groups the users who signed up in the same week
+type Cohort struct {
+	Week  time.Time         `json:"week"` // Monday of the signup week, UTC
+	Size  int               `json:"size"`
+	Weeks []CohortWeekStats `json:"weeks"`
+}
+
+// CohortsResponse is the latest cohort retention snapshot, newest cohort first
+type CohortsResponse struct {
+	ComputedAt *time.Time `json:"computed_at"` // Nil before the first snapshot
+	Cohorts    []Cohort   `json:"cohorts"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssignmentSource is who assigned a piece of work
+type AssignmentSource string
+
+const (
+	AssignmentFromOrg    AssignmentSource = "org"        // An instructor of one of the user's organizations
+	AssignmentFromMentor AssignmentSource = "mentorship" // One of the user's mentors
+)
+
+// PendingAssignment is an assignment the user has not completed yet, from any
+// organization they are a student of or any mentor they accepted
+type PendingAssignment struct {
+	Source       AssignmentSource `json:"source"`
+	SourceID     uuid.UUID        `json:"source_id"`   // The organization or mentorship ID
+	SourceName   string           `json:"source_name"` // The organization name or the mentor's username
+	AssignmentID uuid.UUID        `json:"assignment_id"`
+	Kind         AssignmentKind   `json:"kind"`
+	Title        string           `json:"title"`
+	DueAt        *time.Time       `json:"due_at"`
+	Status       AssignmentStatus `json:"status"` // not_started or in_progress
+	ContestID    *uuid.UUID       `json:"contest_id,omitempty"`
+	Solved       int              `json:"solved"`
+	Total        int              `json:"total"`
+	Overdue      bool             `json:"overdue"`
+}
+
+// IsPending reports whether the progress still leaves work to do
+func (p *AssignmentProgress) IsPending() bool {
+	return p.Status == AssignmentNotStarted || p.Status == AssignmentInProgress
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttemptOutcome is how an attempt at a problem ended
+type AttemptOutcome string
+
+const (
+	AttemptFailed AttemptOutcome = "failed"
+	AttemptSolved AttemptOutcome = "solved"
+)
+
+// Attempt is one try at a problem inside a contest. A problem can be attempted
+// any number of times; the first solved attempt also creates the Submission,
+// which stays the record of whether a problem is solved.
+type Attempt struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primary_key"`
+	UserID          uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index:idx_attempts_user_problem,priority:1"`
+	ProblemID       uuid.UUID      `json:"problem_id" gorm:"type:uuid;not null;index:idx_attempts_user_problem,priority:2"`
+	ContestID       *uuid.UUID     `json:"contest_id" gorm:"type:uuid;index"` // Nil for solves outside a contest
+	Outcome         AttemptOutcome `json:"outcome" gorm:"type:varchar(16);not null"`
+	DurationSeconds int            `json:"duration_seconds" gorm:"not null;default:0"` // Since the contest started or the previous attempt in it
+	AttemptedAt     time.Time      `json:"attempted_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Attempt) TableName() string {
+	return "attempts"
+}
+
+// RecordAttemptRequest is the body of the record attempt endpoint
+type RecordAttemptRequest struct {
+	Outcome AttemptOutcome `json:"outcome" binding:"required,oneof=failed solved"`
+}
+
+// AttemptHistory lists the user's attempts at one problem, oldest first
+type AttemptHistory struct {
+	ProblemID            uuid.UUID `json:"problem_id"`
+	Attempts             []Attempt `json:"attempts"`
+	Solved               bool      `json:"solved"`
+	SolvedOnFirstAttempt bool      `json:"solved_on_first_attempt"`
+}
+
+// NewAttemptHistory builds the history from the attempts in chronological order
+func NewAttemptHistory(problemID uuid.UUID, attempts []Attempt) *AttemptHistory {
+	history := &AttemptHistory{ProblemID: problemID, Attempts: attempts}
+	for _, a := range attempts {
+		if a.Outcome == AttemptSolved {
+			history.Solved = true
+			break
+		}
+	}
+	history.SolvedOnFirstAttempt = len(attempts) > 0 && attempts[0].Outcome == AttemptSolved
+	return history
+}
+
+// AttemptStats summarizes the user's attempts across all problems
+type AttemptStats struct {
+	TotalAttempts        int     `json:"total_attempts"`
+	FailedAttempts       int     `json:"failed_attempts"`
+	SolvedProblems       int     `json:"solved_problems"`         // Problems with at least one solved attempt
+	SolvedOnFirstAttempt int     `json:"solved_on_first_attempt"` // Problems whose first attempt was solved
+	FirstAttemptRate     float64 `json:"first_attempt_rate"`      // SolvedOnFirstAttempt share of SolvedProblems, 0 without solves
+}
+
+// AttemptRepository defines the interface for attempt data access
+type AttemptRepository interface {
+	Create(attempt *Attempt) error
+	FindByUserAndProblem(userID, problemID uuid.UUID) ([]Attempt, error) // Oldest first
+	// FindLatestInContest returns the user's latest attempt at the problem in the contest, nil if none
+	FindLatestInContest(userID, contestID, problemID uuid.UUID) (*Attempt, error)
+	Stats(userID uuid.UUID) (AttemptStats, error)
+	// BackfillFromSubmissions records a solved attempt for every submission
+	// without attempts, returning how many were created
+	BackfillFromSubmissions() (int64, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) AttemptRepository
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupFormat and BackupFormatVersion identify the manifest layout. The version
+// is bumped when a change needs a restore to convert old backups.
+const (
+	BackupFormat        = "contest-maker-backup"
+	BackupFormatVersion = 2
+)
+
+// BackupTable is a table a backup exports
+type BackupTable string
+
+const (
+	BackupTenants         BackupTable = "tenants"
+	BackupUsers           BackupTable = "users"
+	BackupProblems        BackupTable = "problems" // Custom and tenant problems; the shared catalog is seeded
+	BackupContests        BackupTable = "contests"
+	BackupContestProblems BackupTable = "contest_problems"
+	BackupContestTags     BackupTable = "contest_tags"
+	BackupSubmissions     BackupTable = "submissions"
+	BackupAttempts        BackupTable = "attempts"
+)
+
+// BackupTables lists the exported tables in restore order, so each row's
+// references are inserted before it
+var BackupTables = []BackupTable{
+	BackupTenants,
+	BackupUsers,
+	BackupProblems,
+	BackupContests,
+	BackupContestProblems,
+	BackupContestTags,
+	BackupSubmissions,
+	BackupAttempts,
+}
+
+// backupTableVersions records the format version that added a table; backups
+// of an earlier version have no file for it
+var backupTableVersions = map[BackupTable]int{
+	BackupTenants: 2,
+}
+
+// BackupProblemColumns are the columns that reference problems. Catalog problem
+// IDs are generated by each database's seeder, so a restore maps them by slug.
+var BackupProblemColumns = []string{"problem_id", "warmup_problem_id"}
+
+// BackupRow is one exported row keyed by column name. Values keep their JSON
+// form, so a backup restores into either database driver.
+type BackupRow map[string]json.RawMessage
+
+// BackupFile describes one table file of a backup
+type BackupFile struct {
+	Table  BackupTable `json:"table"`
+	Name   string      `json:"name"` // Object name inside the backup, gzipped JSON lines
+	Rows   int64       `json:"rows"`
+	Bytes  int64       `json:"bytes"`
+	SHA256 string      `json:"sha256"` // Hex checksum of the stored object
+}
+
+// BackupManifest is stored next to the table files and lists them with their
+// checksums. A backup without a manifest is incomplete and is never listed.
+type BackupManifest struct {
+	Format    string       `json:"format"`
+	Version   int          `json:"version"`
+	ID        string       `json:"id"`
+	Driver    string       `json:"driver"` // Database driver the backup was taken from
+	CreatedAt time.Time    `json:"created_at"`
+	Duration  float64      `json:"duration_seconds"`
+	Files     []BackupFile `json:"files"`
+
+	// CatalogSlugs maps the shared catalog's problem IDs in the source database to their slugs
+	CatalogSlugs map[uuid.UUID]string `json:"catalog_slugs,omitempty"`
+}
+
+// TotalRows sums the rows of every file
+func (m *BackupManifest) TotalRows() int64 {
+	var total int64
+	for _, f := range m.Files {
+		total += f.Rows
+	}
+	return total
+}
+
+// File returns the manifest entry of table, or nil when the backup lacks it
+func (m *BackupManifest) File(table BackupTable) *BackupFile {
+	for i := range m.Files {
+		if m.Files[i].Table == table {
+			return &m.Files[i]
+		}
+	}
+	return nil
+}
+
+// Includes reports whether a backup of the manifest's version exports table;
+// a table added later is restored empty
+func (m *BackupManifest) Includes(table BackupTable) bool {
+	return m.Version >= backupTableVersions[table]
+}
+
+// BackupListResponse lists the stored backups, newest first
+type BackupListResponse struct {
+	Backups []BackupManifest `json:"backups"`
+}
+
+// RestoreReport is the outcome of a restore
+type RestoreReport struct {
+	BackupID   string                `json:"backup_id"`
+	Rows       map[BackupTable]int64 `json:"rows"`
+	StartedAt  time.Time             `json:"started_at"`
+	FinishedAt time.Time             `json:"finished_at"`
+}
+
+// BackupSource feeds the rows of one table to insert, in batches
+type BackupSource func(table BackupTable, insert func(rows []BackupRow) error) error
+
+// BackupRepository exports and imports the backed up tables
+type BackupRepository interface {
+	// Export streams the rows of each table, in order, from one consistent snapshot
+	Export(tables []BackupTable, emit func(table BackupTable, row BackupRow) error) error
+	// CatalogSlugs maps every problem ID of the shared catalog to its slug
+	CatalogSlugs() (map[uuid.UUID]string, error)
+	// HasUsers reports whether any user exists; restores only go into an empty database
+	HasUsers() (bool, error)
+	// Import inserts the rows source yields for each table in one transaction
+	Import(tables []BackupTable, source BackupSource) error
+	// Driver returns the name of the database driver
+	Driver() string
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) BackupRepository
+}
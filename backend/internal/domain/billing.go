@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription statuses reported by the payment provider
+const (
+	SubscriptionActive            = "active"
+	SubscriptionTrialing          = "trialing"
+	SubscriptionPastDue           = "past_due" // Renewal failed and is being retried
+	SubscriptionCanceled          = "canceled"
+	SubscriptionUnpaid            = "unpaid"
+	SubscriptionIncomplete        = "incomplete"
+	SubscriptionIncompleteExpired = "incomplete_expired"
+	SubscriptionPaused            = "paused"
+)
+
+// SubscriptionPlan returns the plan a subscription in the given status pays
+// for. Past-due subscriptions keep premium while the provider retries.
+func SubscriptionPlan(status string) Plan {
+	switch status {
+	case SubscriptionActive, SubscriptionTrialing, SubscriptionPastDue:
+		return PlanPremium
+	}
+	return PlanFree
+}
+
+// BillingEvent records a processed payment provider webhook so redeliveries are ignored
+type BillingEvent struct {
+	ID          string    `gorm:"type:varchar(255);primaryKey"`
+	Type        string    `gorm:"type:varchar(100);not null"`
+	ProcessedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (BillingEvent) TableName() string {
+	return "billing_events"
+}
+
+// BillingState is the billing part of a user record
+type BillingState struct {
+	Plan               Plan
+	CustomerID         string
+	SubscriptionID     string
+	SubscriptionStatus string
+	PlanRenewsAt       *time.Time
+	EventAt            time.Time // Creation time of the event the state comes from
+}
+
+// BillingState returns the user's current billing state
+func (u *User) BillingState() BillingState {
+	return BillingState{
+		Plan:               u.Plan,
+		CustomerID:         u.BillingCustomerID,
+		SubscriptionID:     u.SubscriptionID,
+		SubscriptionStatus: u.SubscriptionStatus,
+		PlanRenewsAt:       u.PlanRenewsAt,
+	}
+}
+
+// BillingRepository defines the interface for billing data access
+type BillingRepository interface {
+	// FindUserByCustomerID returns the user linked to the provider's customer, or ErrUserNotFound
+	FindUserByCustomerID(customerID string) (*User, error)
+	// UpdateUserBilling stores the state unless the user already has one from a
+	// newer event, and reports whether it was applied
+	UpdateUserBilling(userID uuid.UUID, state BillingState) (bool, error)
+	HasEvent(id string) (bool, error)
+	SaveEvent(event *BillingEvent) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) BillingRepository
+}
+
+// CheckoutSessionResponse points the client at the provider's hosted checkout page
+type CheckoutSessionResponse struct {
+	ID  string `json:"id"`
+	URL string `json:"url"` // Redirect the user here to pay
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContestChallenge is an invite to replay a contest: the friend who accepts it gets
+// a contest with the same problems and duration, and both results can be compared
+type ContestChallenge struct {
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	Code              string     `json:"code" gorm:"type:varchar(32);uniqueIndex;not null"`
+	ContestID         uuid.UUID  `json:"contest_id" gorm:"type:uuid;not null;index"`
+	ChallengerID      uuid.UUID  `json:"challenger_id" gorm:"type:uuid;not null;index"`
+	OpponentID        *uuid.UUID `json:"opponent_id" gorm:"type:uuid;index"`
+	OpponentContestID *uuid.UUID `json:"opponent_contest_id" gorm:"type:uuid"`
+	AcceptedAt        *time.Time `json:"accepted_at"`
+	ExpiresAt         time.Time  `json:"expires_at" gorm:"not null"`
+	CreatedAt         time.Time  `json:"created_at"`
+
+	// Invite code for read-only spectators of the live standings, nil while none is open
+	SpectatorCode *string `json:"-" gorm:"type:varchar(32);uniqueIndex"`
+	// AnonymizeSpectated hides the participants' names from spectators
+	AnonymizeSpectated bool `json:"-" gorm:"not null;default:false"`
+	// SilentMode closes the chat while either participant's contest is running
+	SilentMode bool `json:"silent_mode" gorm:"not null;default:false"`
+}
+
+// TableName specifies the table name for GORM
+func (ContestChallenge) TableName() string {
+	return "contest_challenges"
+}
+
+// IsAccepted reports whether a friend already accepted the challenge
+func (c *ContestChallenge) IsAccepted() bool {
+	return c.OpponentID != nil
+}
+
+// IsExpired reports whether the invite can no longer be accepted
+func (c *ContestChallenge) IsExpired() bool {
+	return !c.IsAccepted() && time.Now().After(c.ExpiresAt)
+}
+
+// IsParticipant reports whether the user is the challenger or the opponent
+func (c *ContestChallenge) IsParticipant(userID uuid.UUID) bool {
+	return c.ChallengerID == userID || (c.OpponentID != nil && *c.OpponentID == userID)
+}
+
+// ChallengeRepository defines the interface for contest challenge data access
+type ChallengeRepository interface {
+	Create(challenge *ContestChallenge) error
+	FindByCode(code string) (*ContestChallenge, error)
+	// Accept records the opponent and their contest. It reports false when
+	// another user accepted the challenge first.
+	Accept(id, opponentID, opponentContestID uuid.UUID, acceptedAt time.Time) (bool, error)
+	FindBySpectatorCode(code string) (*ContestChallenge, error)
+	// SetSpectatorInvite replaces the spectator invite; a nil code closes it
+	SetSpectatorInvite(id uuid.UUID, code *string, anonymize bool) error
+	// FindFriends lists the users who accepted a challenge of the user or
+	// whose challenge the user accepted
+	FindFriends(userID uuid.UUID) ([]uuid.UUID, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ChallengeRepository
+}
+
+// CreateChallengeRequest is the optional body of the create challenge endpoint
+type CreateChallengeRequest struct {
+	SilentMode bool `json:"silent_mode"`
+}
+
+// ChallengeResponse represents a challenge invite in API responses
+type ChallengeResponse struct {
+	Code               string     `json:"code"`
+	ContestID          uuid.UUID  `json:"contest_id"`
+	ChallengerID       uuid.UUID  `json:"challenger_id"`
+	ChallengerUsername string     `json:"challenger_username"`
+	ProblemCount       int        `json:"problem_count"`
+	DurationMinutes    int        `json:"duration_minutes"`
+	Accepted           bool       `json:"accepted"`
+	SilentMode         bool       `json:"silent_mode"`
+	AcceptedAt         *time.Time `json:"accepted_at,omitempty"`
+	ExpiresAt          time.Time  `json:"expires_at"`
+}
+
+// ChallengeComparison compares the challenger's and the opponent's results side by side
+type ChallengeComparison struct {
+	Code       string                       `json:"code"`
+	Challenger ChallengeResult              `json:"challenger"`
+	Opponent   ChallengeResult              `json:"opponent"`
+	Problems   []ChallengeProblemComparison `json:"problems"`
+	WinnerID   *uuid.UUID                   `json:"winner_id"` // nil on a tie
+}
+
+// ChallengeResult is one participant's result in a challenge
+type ChallengeResult struct {
+	UserID         uuid.UUID     `json:"user_id"`
+	Username       string        `json:"username"`
+	ContestID      uuid.UUID     `json:"contest_id"`
+	Status         ContestStatus `json:"status"`
+	Solved         int           `json:"solved"`
+	ElapsedSeconds int           `json:"elapsed_seconds"`
+}
+
+// ChallengeStandings is the live view of a challenge while it runs: how many
+// problems each participant solved so far and whether they are competing now
+type ChallengeStandings struct {
+	Code         string              `json:"code"`
+	Participants []ChallengeStanding `json:"participants"` // Challenger first; the opponent once accepted
+}
+
+// ChallengeStanding is one participant's live standing in a challenge
+type ChallengeStanding struct {
+	UserID    uuid.UUID     `json:"user_id"`
+	Username  string        `json:"username"`
+	ContestID uuid.UUID     `json:"contest_id"`
+	Status    ContestStatus `json:"status"`
+	Solved    int           `json:"solved"`
+	Total     int           `json:"total"`
+	Presence  Presence      `json:"presence"`
+}
+
+// SpectatorInviteRequest is the body of the open spectator invite endpoint
+type SpectatorInviteRequest struct {
+	Anonymize bool `json:"anonymize"` // Show spectators "Participant 1" and "Participant 2" instead of usernames
+}
+
+// SpectatorInvite is an open invite to watch a challenge
+type SpectatorInvite struct {
+	SpectatorCode string `json:"spectator_code"`
+	Anonymize     bool   `json:"anonymize"`
+}
+
+// SpectatorView is the read-only standings of a challenge shown to spectators.
+// It leaves out user and contest IDs, and names when the invite anonymizes them.
+type SpectatorView struct {
+	Anonymized   bool                `json:"anonymized"`
+	Participants []SpectatorStanding `json:"participants"`
+}
+
+// SpectatorStanding is one participant's standing as seen by spectators
+type SpectatorStanding struct {
+	Name     string         `json:"name"`
+	Status   ContestStatus  `json:"status"`
+	Solved   int            `json:"solved"`
+	Total    int            `json:"total"`
+	Presence PresenceStatus `json:"presence"`
+}
+
+// ChallengeProblemComparison shows which participants solved a problem
+type ChallengeProblemComparison struct {
+	Problem          ProblemResponse `json:"problem"`
+	ChallengerSolved bool            `json:"challenger_solved"`
+	OpponentSolved   bool            `json:"opponent_solved"`
+}
+
+// NewChallengeResult summarizes a finished contest for the comparison
+func NewChallengeResult(user *User, contest *Contest) ChallengeResult {
+	result := ChallengeResult{
+		UserID:    user.ID,
+		Username:  user.Username,
+		ContestID: contest.ID,
+		Status:    contest.Status,
+		Solved:    contest.CompletedCount(),
+	}
+	if contest.EndedAt != nil {
+		elapsed := contest.EndedAt.Sub(contest.StartedAt)
+		if limit := time.Duration(contest.DurationMinutes) * time.Minute; elapsed > limit {
+			elapsed = limit
+		}
+		if elapsed > 0 {
+			result.ElapsedSeconds = int(elapsed.Seconds())
+		}
+	}
+	return result
+}
+
+// Beats reports whether the result wins against another: more problems solved,
+// then less time used
+func (r ChallengeResult) Beats(other ChallengeResult) bool {
+	if r.Solved != other.Solved {
+		return r.Solved > other.Solved
+	}
+	return r.ElapsedSeconds < other.ElapsedSeconds
+}
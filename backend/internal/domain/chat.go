@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxChatMessageLength is the longest chat message accepted, in characters
+const MaxChatMessageLength = 500
+
+// ChatMessage is a message in the chat between the participants of a challenge
+type ChatMessage struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	ChallengeID uuid.UUID `json:"challenge_id" gorm:"type:uuid;not null;index:idx_chat_messages_challenge_created,priority:1"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Body        string    `json:"body" gorm:"type:varchar(500);not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null;index:idx_chat_messages_challenge_created,priority:2"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for GORM
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
+
+// ChatRepository defines the interface for challenge chat data access
+type ChatRepository interface {
+	Create(message *ChatMessage) error
+	// FindByChallenge returns up to limit messages newer than since (all when
+	// nil), oldest first, with their authors loaded
+	FindByChallenge(challengeID uuid.UUID, since *time.Time, limit int) ([]ChatMessage, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ChatRepository
+}
+
+// PostChatMessageRequest is the body of the post chat message endpoint
+type PostChatMessageRequest struct {
+	Body string `json:"body" binding:"required,max=500"`
+}
+
+// ChatQuery is the query of the chat endpoint. Clients poll with since set to
+// the created_at of the latest message they have.
+type ChatQuery struct {
+	Since *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ChatMessageResponse represents a chat message in API responses
+type ChatMessageResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse converts a ChatMessage to a ChatMessageResponse
+func (m *ChatMessage) ToResponse() ChatMessageResponse {
+	return ChatMessageResponse{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Username:  m.User.Username,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ChatMessages is a page of a challenge chat
+type ChatMessages struct {
+	Messages []ChatMessageResponse `json:"messages"`
+	Silenced bool                  `json:"silenced"` // Silent mode holds new messages until both contests finish
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// complexityNoise is dropped before comparing complexities, so "O(n * log n)"
+// matches "O(nlogn)"
+var complexityNoise = strings.NewReplacer(" ", "", "\t", "", "*", "", "·", "", "×", "", "²", "^2", "³", "^3")
+
+// NormalizeComplexity returns a comparable form of a big-O expression: lower
+// case, without spaces or multiplication signs, and wrapped in o(...) when the
+// O was left out
+func NormalizeComplexity(s string) string {
+	s = complexityNoise.Replace(strings.ToLower(strings.TrimSpace(s)))
+	if s == "" {
+		return ""
+	}
+	if !strings.HasPrefix(s, "o(") {
+		s = "o(" + s + ")"
+	}
+	return s
+}
+
+// ComplexityMatches reports whether a stated complexity matches the canonical
+// one; nil when either is missing, so there is nothing to grade
+func ComplexityMatches(stated, canonical string) *bool {
+	if stated == "" || canonical == "" {
+		return nil
+	}
+	correct := NormalizeComplexity(stated) == NormalizeComplexity(canonical)
+	return &correct
+}
+
+// SetProblemComplexityRequest sets the canonical complexity of a problem's
+// optimal solution; empty values clear it
+type SetProblemComplexityRequest struct {
+	TimeComplexity  string `json:"time_complexity" binding:"max=32"`
+	SpaceComplexity string `json:"space_complexity" binding:"max=32"`
+}
+
+// ProblemComplexity is the canonical complexity of a problem (admin only)
+type ProblemComplexity struct {
+	ProblemID       uuid.UUID `json:"problem_id"`
+	Title           string    `json:"title"`
+	TimeComplexity  string    `json:"time_complexity"`
+	SpaceComplexity string    `json:"space_complexity"`
+}
+
+// StateComplexityRequest records the complexity the user states for their
+// solution of a completed contest problem; empty values clear it
+type StateComplexityRequest struct {
+	TimeComplexity  string `json:"time_complexity" binding:"max=32"`
+	SpaceComplexity string `json:"space_complexity" binding:"max=32"`
+}
+
+// ComplexityResult is the complexity stated for a contest problem. Once the
+// contest is over it also carries the canonical answer and whether each
+// stated value matches it; the correctness is null when either side is missing.
+type ComplexityResult struct {
+	TimeComplexity  string `json:"time_complexity"`
+	SpaceComplexity string `json:"space_complexity"`
+
+	ExpectedTimeComplexity  string `json:"expected_time_complexity,omitempty"`
+	ExpectedSpaceComplexity string `json:"expected_space_complexity,omitempty"`
+	TimeCorrect             *bool  `json:"time_correct"`
+	SpaceCorrect            *bool  `json:"space_correct"`
+}
+
+// ComplexityScore sums up the graded complexity answers of a finished contest
+type ComplexityScore struct {
+	Answered int `json:"answered"` // Problems with a stated time or space complexity
+	Graded   int `json:"graded"`   // Stated values that had a canonical answer to compare with
+	Correct  int `json:"correct"`
+}
+
+// ComplexityResult returns the complexity stated for the problem, or nil if
+// none was. reveal adds the canonical answer and grading.
+func (cp *ContestProblem) ComplexityResult(reveal bool) *ComplexityResult {
+	if cp.StatedTimeComplexity == "" && cp.StatedSpaceComplexity == "" {
+		return nil
+	}
+	result := &ComplexityResult{
+		TimeComplexity:  cp.StatedTimeComplexity,
+		SpaceComplexity: cp.StatedSpaceComplexity,
+	}
+	if reveal {
+		result.ExpectedTimeComplexity = cp.Problem.TimeComplexity
+		result.ExpectedSpaceComplexity = cp.Problem.SpaceComplexity
+		result.TimeCorrect = ComplexityMatches(cp.StatedTimeComplexity, cp.Problem.TimeComplexity)
+		result.SpaceCorrect = ComplexityMatches(cp.StatedSpaceComplexity, cp.Problem.SpaceComplexity)
+	}
+	return result
+}
+
+// ComplexityScore grades the complexities stated for the scored problems of a
+// finished contest, or returns nil until it is finished or when none were stated
+func (c *Contest) ComplexityScore() *ComplexityScore {
+	if !c.IsFinished() {
+		return nil
+	}
+	score := &ComplexityScore{}
+	for i := range c.ContestProblems {
+		cp := &c.ContestProblems[i]
+		result := cp.ComplexityResult(true)
+		if cp.IsWarmup || result == nil {
+			continue
+		}
+		score.Answered++
+		for _, correct := range []*bool{result.TimeCorrect, result.SpaceCorrect} {
+			if correct == nil {
+				continue
+			}
+			score.Graded++
+			if *correct {
+				score.Correct++
+			}
+		}
+	}
+	if score.Answered == 0 {
+		return nil
+	}
+	return score
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"time"
+)
+
+// Confidence bounds of the self-rating recorded with a solve
+const (
+	MinConfidence = 1 // Barely got there, would not manage again
+	MaxConfidence = 5 // Could solve it again without hesitation
+)
+
+// defaultReviewInterval spaces the reviews of solves without a confidence rating
+const defaultReviewInterval = 7 * 24 * time.Hour
+
+// reviewIntervals is how long after a solve the problem comes up for review,
+// by confidence: shaky solves return within days, confident ones after a month
+var reviewIntervals = map[int]time.Duration{
+	1: 24 * time.Hour,
+	2: 3 * 24 * time.Hour,
+	3: 7 * 24 * time.Hour,
+	4: 14 * 24 * time.Hour,
+	5: 30 * 24 * time.Hour,
+}
+
+// confidenceWeights is how much a solve counts toward the adaptive selector's
+// solve rate, by confidence; unrated solves count fully
+var confidenceWeights = map[int]float64{
+	1: 0.5,
+	2: 0.75,
+	3: 1,
+	4: 1.1,
+	5: 1.2,
+}
+
+// ReviewInterval returns how long after a solve with the given confidence the
+// problem is due for review
+func ReviewInterval(confidence *int) time.Duration {
+	if confidence != nil {
+		if interval, ok := reviewIntervals[*confidence]; ok {
+			return interval
+		}
+	}
+	return defaultReviewInterval
+}
+
+// ConfidenceWeight returns how much a solve with the given confidence counts
+// toward the recent solve rate
+func ConfidenceWeight(confidence int) float64 {
+	if weight, ok := confidenceWeights[confidence]; ok {
+		return weight
+	}
+	return 1
+}
+
+// LatestSolve returns when the problem was last solved, which starts its review interval
+func (s *Submission) LatestSolve() time.Time {
+	if s.LastSolvedAt != nil && s.LastSolvedAt.After(s.SolvedAt) {
+		return *s.LastSolvedAt
+	}
+	return s.SolvedAt
+}
+
+// ReviewDueAt returns when the solved problem is due for review
+func (s *Submission) ReviewDueAt() time.Time {
+	return s.LatestSolve().Add(ReviewInterval(s.Confidence))
+}
+
+// ReviewItem is a solved problem in the user's spaced-repetition queue
+type ReviewItem struct {
+	Problem      ProblemResponse `json:"problem"`
+	Confidence   *int            `json:"confidence"` // Latest rating, null if never rated
+	LastSolvedAt time.Time       `json:"last_solved_at"`
+	DueAt        time.Time       `json:"due_at"`
+	Due          bool            `json:"due"`
+}
+
+// ReviewQueue lists solved problems by review date, earliest first
+type ReviewQueue struct {
+	Reviews []ReviewItem `json:"reviews"`
+	Due     int          `json:"due"` // Problems due for review now
+}
+
+// ReviewQueueQuery is the query of the review queue endpoint
+type ReviewQueueQuery struct {
+	DueOnly bool `form:"due_only"`
+	Limit   int  `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// ConfidenceStats summarizes the confidence ratings of the user's solved problems
+type ConfidenceStats struct {
+	Rated        int         `json:"rated"`
+	Unrated      int         `json:"unrated"`
+	Average      float64     `json:"average"`      // Mean rating, 0 without ratings
+	Distribution map[int]int `json:"distribution"` // Solved problems per rating, 1 to 5
+}
+
+// NewConfidenceStats builds the stats from solved problem counts per rating;
+// key 0 counts the unrated ones
+func NewConfidenceStats(counts map[int]int) ConfidenceStats {
+	stats := ConfidenceStats{
+		Unrated:      counts[0],
+		Distribution: make(map[int]int, MaxConfidence),
+	}
+	sum := 0
+	for c := MinConfidence; c <= MaxConfidence; c++ {
+		stats.Distribution[c] = counts[c]
+		stats.Rated += counts[c]
+		sum += c * counts[c]
+	}
+	if stats.Rated > 0 {
+		stats.Average = float64(sum) / float64(stats.Rated)
+	}
+	return stats
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,22 +16,64 @@ const (
 	ContestStatusActive    ContestStatus = "active"
 	ContestStatusCompleted ContestStatus = "completed"
 	ContestStatusAbandoned ContestStatus = "abandoned"
+	ContestStatusPending   ContestStatus = "pending" // Assigned to the user and not started yet; its timer has not run
+)
+
+// ContestOrdering controls the order in which a contest's problems are presented
+type ContestOrdering string
+
+const (
+	OrderingAscending   ContestOrdering = "ascending"   // Easy → Hard
+	OrderingDescending  ContestOrdering = "descending"  // Hard → Easy
+	OrderingShuffled    ContestOrdering = "shuffled"    // Random order
+	OrderingInterleaved ContestOrdering = "interleaved" // Round-robin across difficulties (Easy, Medium, Hard, Easy, ...)
+	OrderingRoadmap     ContestOrdering = "roadmap"     // Roadmap order; set for roadmap contests without an explicit ordering
+	OrderingAssigned    ContestOrdering = "assigned"    // The order an instructor gave the problems of an assignment
+	OrderingList        ContestOrdering = "list"        // The order of the community list the contest was built from
 )
 
 // Contest represents a timed coding challenge session
 type Contest struct {
-	ID              uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID          uuid.UUID     `json:"user_id" gorm:"type:uuid;not null;index"`
-	DurationMinutes int           `json:"duration_minutes" gorm:"not null"`
-	StartedAt       time.Time     `json:"started_at" gorm:"not null"`
-	EndedAt         *time.Time    `json:"ended_at"`
-	Status          ContestStatus `json:"status" gorm:"type:varchar(20);not null;default:'active'"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	UserID          uuid.UUID       `json:"user_id" gorm:"type:uuid;not null;index;index:idx_contests_user_profile,priority:1;index:idx_contests_user_created,priority:1;index:idx_contests_user_status,priority:1"`
+	DurationMinutes int             `json:"duration_minutes" gorm:"not null"`
+	StartedAt       time.Time       `json:"started_at" gorm:"not null"`
+	EndedAt         *time.Time      `json:"ended_at"`
+	Status          ContestStatus   `json:"status" gorm:"type:varchar(20);not null;default:'active';index:idx_contests_user_status,priority:2"`
+	Ordering        ContestOrdering `json:"ordering" gorm:"type:varchar(20);not null;default:'ascending'"`
+
+	// Optional warmup problem served between CreatedAt and StartedAt, before the timer runs.
+	// It is not part of ContestProblems and never counts toward score or submissions.
+	WarmupProblemID *uuid.UUID `json:"warmup_problem_id" gorm:"type:uuid"`
+	WarmupCompleted bool       `json:"warmup_completed" gorm:"default:false"`
+
+	// Free-text post-contest reflection, editable once the contest is over
+	Retro          string     `json:"retro" gorm:"type:text;not null;default:''"`
+	RetroUpdatedAt *time.Time `json:"retro_updated_at"`
+
+	// Experiment arm that selected the problems; empty for users outside experiments
+	Experiment string `json:"-" gorm:"type:varchar(64);not null;default:'';index"`
+	Variant    string `json:"-" gorm:"type:varchar(32);not null;default:''"`
+
+	// Profile of the default mix a random contest was drawn with and how the
+	// user's ratings tuned it; empty for other contests, which do not tune it
+	DistributionProfile DistributionProfile `json:"-" gorm:"type:varchar(16);not null;default:'';index:idx_contests_user_profile,priority:2"`
+	Tuning              *DistributionTuning `json:"-" gorm:"type:text;serializer:json"`
+	// "How hard was this?" rating from 1 to 5, given once the contest is over
+	DifficultyRating *int       `json:"difficulty_rating"`
+	RatedAt          *time.Time `json:"-"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_contests_user_created,priority:2"`
+	UpdatedAt time.Time `json:"updated_at"`
 
 	// Relationships
 	User            User             `json:"-" gorm:"foreignKey:UserID"`
 	ContestProblems []ContestProblem `json:"problems,omitempty" gorm:"foreignKey:ContestID"`
+	WarmupProblem   *Problem         `json:"-" gorm:"foreignKey:WarmupProblemID"`
+	Tags            []ContestTag     `json:"-" gorm:"foreignKey:ContestID"`
+
+	// Warning is set on a freshly created contest whose problem mix differs from the request
+	Warning *ContestWarning `json:"-" gorm:"-"`
 }
 
 // TableName specifies the table name for GORM
@@ -39,9 +84,21 @@ func (Contest) TableName() string {
 // ContestProblem represents a problem within a specific contest
 type ContestProblem struct {
 	ContestID   uuid.UUID `json:"contest_id" gorm:"type:uuid;primaryKey"`
-	ProblemID   uuid.UUID `json:"problem_id" gorm:"type:uuid;primaryKey"`
+	ProblemID   uuid.UUID `json:"problem_id" gorm:"type:uuid;primaryKey;index"` // Indexed for finding the contests with a problem
 	Order       int       `json:"order" gorm:"not null"`
 	IsCompleted bool      `json:"is_completed" gorm:"default:false"`
+	// IsWarmup marks an already-solved problem served first (order 0) to get going;
+	// it is not scored and checking it off records no submission
+	IsWarmup bool `json:"is_warmup" gorm:"not null;default:false"`
+
+	// Complexity the user stated for their solution after completing the problem
+	StatedTimeComplexity  string `json:"stated_time_complexity" gorm:"type:varchar(32);not null;default:''"`
+	StatedSpaceComplexity string `json:"stated_space_complexity" gorm:"type:varchar(32);not null;default:''"`
+
+	// Problem timer: TimerStartedAt is set while the user is working on the problem,
+	// and each stretch is added to TimeSpentSeconds when the timer stops
+	TimerStartedAt   *time.Time `json:"timer_started_at"`
+	TimeSpentSeconds int        `json:"time_spent_seconds" gorm:"not null;default:0"`
 
 	// Relationships (for loading)
 	Problem Problem `json:"problem" gorm:"foreignKey:ProblemID"`
@@ -52,23 +109,205 @@ func (ContestProblem) TableName() string {
 	return "contest_problems"
 }
 
+// TimeSpent returns the seconds spent on the problem up to now, including the
+// stretch still running
+func (cp *ContestProblem) TimeSpent(now time.Time) int {
+	spent := cp.TimeSpentSeconds
+	if cp.TimerStartedAt != nil && now.After(*cp.TimerStartedAt) {
+		spent += int(now.Sub(*cp.TimerStartedAt).Seconds())
+	}
+	return spent
+}
+
+// ContestTag is a user-defined label on a contest, e.g. "pre-interview" or "graph-week"
+type ContestTag struct {
+	ContestID uuid.UUID `json:"contest_id" gorm:"type:uuid;primaryKey"`
+	Tag       string    `json:"tag" gorm:"type:varchar(32);primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ContestTag) TableName() string {
+	return "contest_tags"
+}
+
+// TagCount is a tag together with how many of the user's contests carry it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// TopicContestStats is how often a topic came up in the user's finished
+// contests and how much of it they completed; the warm-up is left out
+type TopicContestStats struct {
+	Topic          string  `json:"topic"`
+	Appearances    int     `json:"appearances"` // Contest problems of the topic
+	Contests       int     `json:"contests"`    // Contests with at least one of them
+	Completed      int     `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"` // Completed share of the appearances
+}
+
 // ContestRepository defines the interface for contest data access
 type ContestRepository interface {
 	Create(contest *Contest) error
 	FindByID(id uuid.UUID) (*Contest, error)
 	FindByIDWithProblems(id uuid.UUID) (*Contest, error)
-	FindByUserID(userID uuid.UUID) ([]Contest, error)
+	FindByUserID(userID uuid.UUID, filter ContestFilter) ([]Contest, error)
 	FindActiveByUserID(userID uuid.UUID) (*Contest, error)
+	// CountCreatedSince counts the contests the user created at or after since,
+	// finished or not; pending contests are left out until the user starts them
+	CountCreatedSince(userID uuid.UUID, since time.Time) (int64, error)
+	FindExpiredActive(now time.Time) ([]Contest, error)
 	Update(contest *Contest) error
-	UpdateProblemStatus(contestID, problemID uuid.UUID, isCompleted bool) error
+	// Finish moves an active contest to the contest's status and end time, counting
+	// it in the user's progress summary; it reports false if the contest was not active
+	Finish(contest *Contest) (bool, error)
+	SetWarmupCompleted(contestID uuid.UUID, completed bool) error
+	// Activate makes a pending contest active with its timer starting at
+	// startedAt, counting it in the user's progress summary; it reports false if
+	// the contest was not pending
+	Activate(contest *Contest, startedAt time.Time) (bool, error)
+	StartTimer(contestID uuid.UUID, startedAt time.Time) error
+	UpdateRetro(contestID uuid.UUID, retro string, updatedAt time.Time) error
+	UpdateRating(contestID uuid.UUID, rating int, ratedAt time.Time) error
+	// FindRecentRatings lists the user's limit most recent difficulty ratings
+	// of contests drawn with the profile, newest first
+	FindRecentRatings(userID uuid.UUID, profile DistributionProfile, limit int) ([]int, error)
+	SetTags(contestID uuid.UUID, tags []string) error
+	FindTagsByUserID(userID uuid.UUID, prefix string, limit int) ([]TagCount, error)
+	UpdateProblemStatus(contestID, problemID uuid.UUID, isCompleted bool) (bool, error)
+	// SetStatedComplexity stores the complexity stated for a completed contest problem
+	SetStatedComplexity(contestID, problemID uuid.UUID, timeComplexity, spaceComplexity string) error
+	// StartProblemTimer starts timing the problem, stopping the timer of any other problem of the contest
+	StartProblemTimer(contestID, problemID uuid.UUID, at time.Time) error
+	// StopProblemTimers stops the running timers of the contest; a non-nil problemID limits it to that problem
+	StopProblemTimers(contestID uuid.UUID, problemID *uuid.UUID, at time.Time) error
+	// FindSolveTimes averages the timed solves of the user's scored contest problems per difficulty
+	FindSolveTimes(userID uuid.UUID) (SolveTimeStats, error)
+	// FindTopicHistory counts the scored problems of each topic in the user's
+	// finished contests, most frequent first
+	FindTopicHistory(userID uuid.UUID) ([]TopicContestStats, error)
 	Delete(id uuid.UUID) error
 	AddProblems(contestID uuid.UUID, problems []ContestProblem) error
+	// FindVariantOutcomes aggregates the contests of an experiment per variant
+	FindVariantOutcomes(experiment string) ([]VariantOutcome, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ContestRepository
 }
 
 // CreateContestRequest represents the data needed to create a new contest
 type CreateContestRequest struct {
-	ProblemCount    int `json:"problem_count" binding:"required,min=1,max=20"`
-	DurationMinutes int `json:"duration_minutes" binding:"required,min=10,max=300"`
+	ProblemCount    int             `json:"problem_count" binding:"required,min=1,max=20"`
+	DurationMinutes int             `json:"duration_minutes" binding:"required,min=10,max=300"`
+	Ordering        ContestOrdering `json:"ordering" binding:"omitempty,oneof=ascending descending shuffled interleaved"` // Defaults to ascending
+	WarmupMinutes   int             `json:"warmup_minutes" binding:"omitempty,min=1,max=15"`                              // 0 means no warmup
+	Tags            []string        `json:"tags" binding:"omitempty,max=10,dive,min=1,max=32"`
+
+	// SolvedWarmup prepends one easy problem the user has already solved as an unscored warm-up
+	SolvedWarmup bool `json:"solved_warmup"`
+
+	// RespectPrerequisites only selects problems whose prerequisites the user has already solved
+	RespectPrerequisites bool `json:"respect_prerequisites"`
+	// Source defaults to random; roadmap takes the next unsolved problems in roadmap order
+	Source ContestSource `json:"source" binding:"omitempty,oneof=random roadmap"`
+	// Companies and Difficulties narrow the pool of a random contest (e.g. Amazon-tagged mediums)
+	Companies    []string     `json:"companies" binding:"omitempty,max=10,dive,min=1,max=64"`
+	Difficulties []Difficulty `json:"difficulties" binding:"omitempty,max=3,dive,oneof=Easy Medium Hard"`
+	// Weighting defaults to uniform; importance favors frequently asked problems
+	Weighting SelectionWeighting `json:"weighting" binding:"omitempty,oneof=uniform importance"`
+	// IncludeCustom adds the user's own custom problems to the pool of a random contest
+	IncludeCustom bool `json:"include_custom"`
+}
+
+// SelectionWeighting controls how problems are drawn within a difficulty bucket
+type SelectionWeighting string
+
+const (
+	WeightingUniform    SelectionWeighting = "uniform"    // Every candidate is equally likely
+	WeightingImportance SelectionWeighting = "importance" // Candidates are drawn proportionally to their importance score
+)
+
+// SelectionOptions narrows the problem pool of a random contest
+type SelectionOptions struct {
+	RespectPrerequisites bool
+	Companies            []string
+	Difficulties         []Difficulty
+	Weighting            SelectionWeighting
+	IncludeCustom        bool
+	Algorithm            SelectionAlgorithm  // Set by experiments; defaults to progressive
+	Tuning               *DistributionTuning // Replaces the default mix when set
+	Seed                 int64               // Seeds the draw, so the same pool gives the same problems; 0 draws a new seed
+}
+
+// SelectionOptions returns the pool restrictions of the request
+func (r *CreateContestRequest) SelectionOptions() SelectionOptions {
+	return SelectionOptions{
+		RespectPrerequisites: r.RespectPrerequisites,
+		Companies:            r.Companies,
+		Difficulties:         r.Difficulties,
+		Weighting:            r.Weighting,
+		IncludeCustom:        r.IncludeCustom,
+	}
+}
+
+// ContestFilter represents filtering options for listing a user's contests;
+// ranges include their bounds
+type ContestFilter struct {
+	Query  string        `form:"q" binding:"omitempty,max=200"`  // Case-insensitive match against retro notes
+	Tag    string        `form:"tag" binding:"omitempty,max=32"` // Only contests carrying this tag
+	Status ContestStatus `form:"status" binding:"omitempty,oneof=active completed abandoned pending"`
+	// From and To bound the UTC day the contest was created on
+	From        *time.Time `form:"from" time_format:"2006-01-02"`
+	To          *time.Time `form:"to" time_format:"2006-01-02"`
+	MinDuration int        `form:"min_duration" binding:"omitempty,min=1"` // Minutes
+	MaxDuration int        `form:"max_duration" binding:"omitempty,min=1"`
+	// MinScore and MaxScore bound the number of solved problems, the warm-up left out
+	MinScore *int   `form:"min_score" binding:"omitempty,min=0"`
+	MaxScore *int   `form:"max_score" binding:"omitempty,min=0"`
+	Problem  string `form:"problem" binding:"omitempty,max=255"` // Only contests with this problem, by ID or slug
+}
+
+// SetContestTagsRequest replaces the tags of a contest
+type SetContestTagsRequest struct {
+	Tags []string `json:"tags" binding:"max=10,dive,min=1,max=32"`
+}
+
+// TagSuggestionQuery is the query of the tag autocomplete endpoint
+type TagSuggestionQuery struct {
+	Prefix string `form:"prefix" binding:"omitempty,max=32"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// AvailabilityQuery is the query of the contest availability endpoint; its
+// options narrow the pool like the matching create contest fields
+type AvailabilityQuery struct {
+	RespectPrerequisites bool     `form:"respect_prerequisites"`
+	Companies            []string `form:"company" binding:"omitempty,max=10,dive,min=1,max=64"`
+	IncludeCustom        bool     `form:"include_custom"`
+}
+
+// SelectionOptions returns the pool restrictions of the query
+func (q *AvailabilityQuery) SelectionOptions() SelectionOptions {
+	return SelectionOptions{
+		RespectPrerequisites: q.RespectPrerequisites,
+		Companies:            q.Companies,
+		IncludeCustom:        q.IncludeCustom,
+	}
+}
+
+// ProblemAvailability counts the unsolved problems a random contest can still
+// draw for the user. A contest asking for more than Total is cut short, and one
+// asking for more of a difficulty than it holds is rebalanced.
+type ProblemAvailability struct {
+	Total        int                `json:"total"`
+	ByDifficulty map[Difficulty]int `json:"by_difficulty"`
+	ByTopic      map[string]int     `json:"by_topic"`
+}
+
+// UpdateRetroRequest represents the request to save a contest retro; an empty retro clears it
+type UpdateRetroRequest struct {
+	Retro string `json:"retro" binding:"max=10000"`
 }
 
 // ContestResponse represents a contest in API responses
@@ -78,35 +317,82 @@ type ContestResponse struct {
 	StartedAt       time.Time                `json:"started_at"`
 	EndedAt         *time.Time               `json:"ended_at"`
 	Status          ContestStatus            `json:"status"`
+	Ordering        ContestOrdering          `json:"ordering"`
 	Problems        []ContestProblemResponse `json:"problems"`
 	TimeRemaining   int                      `json:"time_remaining_seconds"`
+	Warmup          *ContestWarmupResponse   `json:"warmup,omitempty"`
+	Tags            []string                 `json:"tags"`
+	Retro           string                   `json:"retro"`
+	RetroUpdatedAt  *time.Time               `json:"retro_updated_at"`
+	Warning         *ContestWarning          `json:"warning,omitempty"`
+	Tuning          *DistributionTuning      `json:"tuning,omitempty"` // Set for random contests drawn with the default mix
+	Rating          *int                     `json:"difficulty_rating"`
+	ComplexityScore *ComplexityScore         `json:"complexity_score,omitempty"` // Set once the contest is over
+}
+
+// Contest warning codes
+const (
+	WarningDifficultyMixAdjusted = "DIFFICULTY_MIX_ADJUSTED"
+	WarningNotEnoughProblems     = "NOT_ENOUGH_PROBLEMS"
+)
+
+// ContestWarning reports that a contest was generated with a different problem mix than requested
+type ContestWarning struct {
+	Code      string             `json:"code"`
+	Message   string             `json:"message"`
+	Requested map[Difficulty]int `json:"requested"`
+	Delivered map[Difficulty]int `json:"delivered"`
 }
 
 // ContestProblemResponse represents a problem within a contest response
 type ContestProblemResponse struct {
-	Order       int             `json:"order"`
-	IsCompleted bool            `json:"is_completed"`
-	Problem     ProblemResponse `json:"problem"`
+	Order            int               `json:"order"`
+	IsCompleted      bool              `json:"is_completed"`
+	IsWarmup         bool              `json:"is_warmup"`
+	Problem          ProblemResponse   `json:"problem"`
+	Complexity       *ComplexityResult `json:"complexity,omitempty"`
+	TimeSpentSeconds int               `json:"time_spent_seconds"` // Includes the running stretch
+	TimerRunning     bool              `json:"timer_running"`
+}
+
+// ContestWarmupResponse represents the warmup problem of a contest
+type ContestWarmupResponse struct {
+	Problem       ProblemResponse `json:"problem"`
+	IsCompleted   bool            `json:"is_completed"`
+	EndsAt        time.Time       `json:"ends_at"`
+	TimeRemaining int             `json:"time_remaining_seconds"`
 }
 
 // ToResponse converts a Contest to a ContestResponse
 func (c *Contest) ToResponse() ContestResponse {
+	now := time.Now()
 	problems := make([]ContestProblemResponse, len(c.ContestProblems))
 	for i, cp := range c.ContestProblems {
 		problems[i] = ContestProblemResponse{
-			Order:       cp.Order,
-			IsCompleted: cp.IsCompleted,
-			Problem:     cp.Problem.ToResponse(),
+			Order:            cp.Order,
+			IsCompleted:      cp.IsCompleted,
+			IsWarmup:         cp.IsWarmup,
+			Problem:          cp.Problem.ToResponse(),
+			Complexity:       cp.ComplexityResult(c.IsFinished()),
+			TimeSpentSeconds: cp.TimeSpent(now),
+			TimerRunning:     cp.TimerStartedAt != nil,
 		}
 	}
 
-	// Calculate remaining time
-	var timeRemaining int
-	if c.Status == ContestStatusActive {
-		endTime := c.StartedAt.Add(time.Duration(c.DurationMinutes) * time.Minute)
-		remaining := time.Until(endTime)
-		if remaining > 0 {
-			timeRemaining = int(remaining.Seconds())
+	tags := make([]string, len(c.Tags))
+	for i, t := range c.Tags {
+		tags[i] = t.Tag
+	}
+
+	var warmup *ContestWarmupResponse
+	if c.WarmupProblem != nil {
+		warmup = &ContestWarmupResponse{
+			Problem:     c.WarmupProblem.ToResponse(),
+			IsCompleted: c.WarmupCompleted,
+			EndsAt:      c.StartedAt,
+		}
+		if c.InWarmup() {
+			warmup.TimeRemaining = int(time.Until(c.StartedAt).Seconds())
 		}
 	}
 
@@ -116,21 +402,142 @@ func (c *Contest) ToResponse() ContestResponse {
 		StartedAt:       c.StartedAt,
 		EndedAt:         c.EndedAt,
 		Status:          c.Status,
+		Ordering:        c.Ordering,
 		Problems:        problems,
-		TimeRemaining:   timeRemaining,
+		TimeRemaining:   c.TimeRemaining(),
+		Warning:         c.Warning,
+		Warmup:          warmup,
+		Tags:            tags,
+		Retro:           c.Retro,
+		RetroUpdatedAt:  c.RetroUpdatedAt,
+		Tuning:          c.Tuning,
+		Rating:          c.DifficultyRating,
+		ComplexityScore: c.ComplexityScore(),
+	}
+}
+
+// TimeRemaining returns the seconds left on the contest timer
+func (c *Contest) TimeRemaining() int {
+	switch c.Status {
+	case ContestStatusPending:
+		return c.DurationMinutes * 60 // The whole duration is left until it starts
+	case ContestStatusActive:
+		remaining := time.Until(c.EndTime())
+		if limit := time.Duration(c.DurationMinutes) * time.Minute; remaining > limit {
+			remaining = limit // Timer has not started yet (warmup)
+		}
+		if remaining > 0 {
+			return int(remaining.Seconds())
+		}
+	}
+	return 0
+}
+
+// ActiveContestConflict is the running contest that keeps a user from
+// starting another, as the 409 ACTIVE_CONTEST_EXISTS response details it
+type ActiveContestConflict struct {
+	ContestID     uuid.UUID          `json:"contest_id"`
+	EndsAt        time.Time          `json:"ends_at"`
+	TimeRemaining int                `json:"time_remaining_seconds"`
+	Links         ActiveContestLinks `json:"links"`
+}
+
+// ActiveContestLinks are the API paths to resume or finish the running contest
+type ActiveContestLinks struct {
+	Contest  string `json:"contest"`  // GET
+	Complete string `json:"complete"` // POST
+	Abandon  string `json:"abandon"`  // POST
+}
+
+// Conflict describes the contest as the one blocking a new contest
+func (c *Contest) Conflict() ActiveContestConflict {
+	path := "/api/contests/" + c.ID.String()
+	return ActiveContestConflict{
+		ContestID:     c.ID,
+		EndsAt:        c.EndTime(),
+		TimeRemaining: c.TimeRemaining(),
+		Links: ActiveContestLinks{
+			Contest:  path,
+			Complete: path + "/complete",
+			Abandon:  path + "/abandon",
+		},
 	}
 }
 
+// IsFinished reports whether the contest was completed or abandoned
+func (c *Contest) IsFinished() bool {
+	return c.Status == ContestStatusCompleted || c.Status == ContestStatusAbandoned
+}
+
 // IsExpired checks if the contest timer has expired
 func (c *Contest) IsExpired() bool {
 	if c.Status != ContestStatusActive {
 		return false
 	}
-	endTime := c.StartedAt.Add(time.Duration(c.DurationMinutes) * time.Minute)
-	return time.Now().After(endTime)
+	return time.Now().After(c.EndTime())
+}
+
+// NormalizeTags lowercases and trims tags, joins inner whitespace with dashes and
+// drops duplicates and empties, so "Graph Week" and "graph-week" are the same tag
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.Join(strings.Fields(strings.ToLower(t)), "-")
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		normalized = append(normalized, t)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// HasWarmup reports whether the contest was created with a warmup problem
+func (c *Contest) HasWarmup() bool {
+	return c.WarmupProblemID != nil
+}
+
+// InWarmup reports whether the contest is still in its warmup window, before the timer starts
+func (c *Contest) InWarmup() bool {
+	return c.Status == ContestStatusActive && c.HasWarmup() && time.Now().Before(c.StartedAt)
+}
+
+// EndTime returns when the contest timer runs out
+func (c *Contest) EndTime() time.Time {
+	return c.StartedAt.Add(time.Duration(c.DurationMinutes) * time.Minute)
+}
+
+// ScoredProblems returns the loaded contest problems without the solved warm-up
+func (c *Contest) ScoredProblems() []ContestProblem {
+	scored := make([]ContestProblem, 0, len(c.ContestProblems))
+	for _, cp := range c.ContestProblems {
+		if !cp.IsWarmup {
+			scored = append(scored, cp)
+		}
+	}
+	return scored
+}
+
+// CompletedCount returns how many of the loaded scored contest problems are completed
+func (c *Contest) CompletedCount() int {
+	completed := 0
+	for _, cp := range c.ContestProblems {
+		if cp.IsCompleted && !cp.IsWarmup {
+			completed++
+		}
+	}
+	return completed
 }
 
 // MarkProblemCompleteRequest represents the request to mark a problem as complete
 type MarkProblemCompleteRequest struct {
 	IsCompleted bool `json:"is_completed"`
+	// Confidence optionally rates the solve from 1 (shaky) to 5 (could do it again blind);
+	// it spaces out reviews and weights the adaptive difficulty mix
+	Confidence *int `json:"confidence,omitempty" binding:"omitempty,min=1,max=5"`
 }
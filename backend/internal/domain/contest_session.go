@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContestSession is where a user is in one of their contests, kept so they can
+// carry on from another device: the problem on screen and scratch notes per
+// problem. Version counts the saves; each save names the version its device
+// last read, so a device with stale state cannot overwrite notes written on
+// another one.
+type ContestSession struct {
+	ContestID        uuid.UUID         `json:"contest_id" gorm:"type:uuid;primaryKey"`
+	UserID           uuid.UUID         `json:"-" gorm:"type:uuid;not null;index"`
+	CurrentProblemID *uuid.UUID        `json:"current_problem_id" gorm:"type:uuid"`
+	Notes            map[string]string `json:"notes" gorm:"type:text;serializer:json"`             // Problem ID → scratch notes
+	Device           string            `json:"device" gorm:"type:varchar(64);not null;default:''"` // The device that saved last
+	Version          int               `json:"version" gorm:"not null;default:0"`                  // 0 until the first save
+	UpdatedAt        time.Time         `json:"updated_at"`
+
+	// Relationships
+	Contest Contest `json:"-" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (ContestSession) TableName() string {
+	return "contest_sessions"
+}
+
+// UpdateContestSessionRequest replaces the session state of a contest
+type UpdateContestSessionRequest struct {
+	CurrentProblemID *uuid.UUID        `json:"current_problem_id"`
+	Notes            map[string]string `json:"notes" binding:"omitempty,max=25,dive,max=5000"`
+	Device           string            `json:"device" binding:"omitempty,max=64"`
+	// Version is the version the device last read; a save from an older one
+	// fails with SESSION_CONFLICT and the current state
+	Version int `json:"version" binding:"min=0"`
+}
+
+// ContestSessionRepository defines the interface for contest session data access
+type ContestSessionRepository interface {
+	// FindByContestID returns the session of a contest, or nil, nil before its first save
+	FindByContestID(contestID uuid.UUID) (*ContestSession, error)
+	// Save stores the session as its next version if the stored one is still
+	// expectedVersion, reporting false when another save came first
+	Save(session *ContestSession, expectedVersion int) (bool, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ContestSessionRepository
+}
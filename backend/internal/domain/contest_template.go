@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// ContestTemplateVersion is the format of the templates this version exports
+// and imports
+const ContestTemplateVersion = 1
+
+// ContestTemplate is a contest's recipe, shareable with other users: its
+// duration, ordering and problems in order, referenced by catalog slug. The
+// title and difficulty of each problem are what they were at export time.
+type ContestTemplate struct {
+	Version         int               `json:"version" binding:"required"`
+	DurationMinutes int               `json:"duration_minutes" binding:"required,min=10,max=300"`
+	Ordering        ContestOrdering   `json:"ordering" binding:"required,oneof=ascending descending shuffled interleaved roadmap assigned list"`
+	Problems        []TemplateProblem `json:"problems" binding:"required,min=1,max=20,dive"`
+	ExportedAt      time.Time         `json:"exported_at"`
+}
+
+// TemplateProblem is a problem of a contest template
+type TemplateProblem struct {
+	Slug       string     `json:"slug" binding:"required,max=255"`
+	Title      string     `json:"title" binding:"max=255"`
+	Difficulty Difficulty `json:"difficulty" binding:"omitempty,oneof=Easy Medium Hard"`
+}
+
+// SignedContestTemplate is an exported template with the signature of the
+// deployment that exported it, so an import can tell it was not altered
+type SignedContestTemplate struct {
+	Template  ContestTemplate `json:"template" binding:"required"`
+	Signature string          `json:"signature" binding:"required,hexadecimal,len=64"`
+}
+
+// ImportContestTemplateRequest is the body of the template import endpoint
+type ImportContestTemplateRequest struct {
+	SignedContestTemplate
+	// SkipMissing starts the contest without the problems the catalog lacks
+	// instead of failing with the conflicts
+	SkipMissing bool `json:"skip_missing"`
+	// DryRun only reports the conflicts, without starting a contest
+	DryRun bool `json:"dry_run"`
+}
+
+// TemplateConflictReason is why a template problem does not match the catalog
+type TemplateConflictReason string
+
+const (
+	ConflictMissing           TemplateConflictReason = "missing"            // No catalog problem has the slug; blocks the import unless skipped
+	ConflictDuplicate         TemplateConflictReason = "duplicate"          // The slug is listed twice; the repeat is left out
+	ConflictDifficultyChanged TemplateConflictReason = "difficulty_changed" // The catalog rates the problem differently now; it is imported as is
+)
+
+// TemplateConflict is a template problem that does not match the local catalog
+type TemplateConflict struct {
+	Slug       string                 `json:"slug"`
+	Reason     TemplateConflictReason `json:"reason"`
+	Expected   Difficulty             `json:"expected,omitempty"` // Difficulty in the template
+	Difficulty Difficulty             `json:"difficulty,omitempty"`
+}
+
+// ContestTemplateImport is the result of importing a template: the started
+// contest, nil on a dry run, and what did not match the catalog
+type ContestTemplateImport struct {
+	Contest   *ContestResponse   `json:"contest"`
+	Problems  int                `json:"problems"` // Problems the contest has or, on a dry run, would have
+	Conflicts []TemplateConflict `json:"conflicts"`
+}
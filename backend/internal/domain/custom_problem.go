@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CustomProblemRequest represents the data needed to add or replace a private custom problem
+type CustomProblemRequest struct {
+	Title      string     `json:"title" binding:"required,min=1,max=200"`
+	URL        string     `json:"url" binding:"required,url,max=500,startswith=http"`
+	Difficulty Difficulty `json:"difficulty" binding:"required,oneof=Easy Medium Hard"`
+	Topics     []string   `json:"topics" binding:"omitempty,max=10,dive,min=1,max=64"`
+}
+
+// IsCustom reports whether the problem is a user's private custom problem
+func (p *Problem) IsCustom() bool {
+	return p.OwnerID != nil
+}
+
+// VisibleTo reports whether the user may see the problem. Catalog problems are
+// public; custom problems are only visible to their owner.
+func (p *Problem) VisibleTo(userID uuid.UUID) bool {
+	return p.OwnerID == nil || *p.OwnerID == userID
+}
+
+// Apply overwrites the problem's user-editable fields from the request
+func (r *CustomProblemRequest) Apply(p *Problem) {
+	p.Title = strings.TrimSpace(r.Title)
+	p.LeetCodeURL = strings.TrimSpace(r.URL)
+	p.Difficulty = r.Difficulty
+
+	topics := make(StringList, 0, len(r.Topics))
+	seen := make(map[string]struct{}, len(r.Topics))
+	for _, t := range r.Topics {
+		t = strings.Join(strings.Fields(t), " ")
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		topics = append(topics, t)
+	}
+	p.Topics = topics
+}
+
+// CustomProblemSlug builds a unique slug for a custom problem from its title and ID
+func CustomProblemSlug(title string, id uuid.UUID) string {
+	var b strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastDash = false
+		case !lastDash:
+			b.WriteByte('-')
+			lastDash = true
+		}
+		if b.Len() >= 60 {
+			break
+		}
+	}
+	base := strings.Trim(b.String(), "-")
+	if base == "" {
+		base = "problem"
+	}
+	return "custom-" + base + "-" + id.String()[:8]
+}
@@ -0,0 +1,52 @@
+package domain
+
+// Difficulty ratings a user gives a finished contest: "how hard was this?"
+const (
+	RatingMuchTooEasy = 1
+	RatingJustRight   = 3
+	RatingMuchTooHard = 5
+)
+
+// DistributionProfile names the default difficulty mix a random contest was
+// drawn with, which depends on its problem count. Ratings are aggregated per
+// profile, since a mix that suits short contests may not suit long ones.
+type DistributionProfile string
+
+const (
+	ProfileTiny     DistributionProfile = "tiny"     // Up to 3 problems: mostly easy
+	ProfileShort    DistributionProfile = "short"    // 4-5 problems: 2 easy, 2 medium, the rest hard
+	ProfileStandard DistributionProfile = "standard" // 6-10 problems: 30% easy, 40% medium, 30% hard
+	ProfileLong     DistributionProfile = "long"     // More: 25% easy, 50% medium, 25% hard
+)
+
+// DistributionProfileFor returns the profile of a random contest of count problems
+func DistributionProfileFor(count int) DistributionProfile {
+	switch {
+	case count <= 3:
+		return ProfileTiny
+	case count <= 5:
+		return ProfileShort
+	case count <= 10:
+		return ProfileStandard
+	default:
+		return ProfileLong
+	}
+}
+
+// DistributionTuning is how the default difficulty mix of a random contest
+// was tuned from the user's ratings of their recent contests of the same profile
+type DistributionTuning struct {
+	Profile    DistributionProfile `json:"profile"`
+	Ratings    int                 `json:"ratings"`     // Recent ratings of the profile taken into account
+	MeanRating float64             `json:"mean_rating"` // 0 without enough ratings to tune
+	Factor     float64             `json:"factor"`      // -1 (much easier) to 1 (much harder); 0 keeps the default mix
+	Base       map[Difficulty]int  `json:"base"`        // Default mix for the problem count
+	// Tuned is the mix the problems were drawn for, before experiments and
+	// difficulties running out of problems adjust it
+	Tuned map[Difficulty]int `json:"tuned"`
+}
+
+// RateContestRequest is the body of the contest difficulty rating endpoint
+type RateContestRequest struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5"` // 1 much too easy, 3 just right, 5 much too hard
+}
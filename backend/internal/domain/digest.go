@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DigestChannel is how a recommendation digest reached the user
+type DigestChannel string
+
+const (
+	DigestEmail DigestChannel = "email"  // Emailed, and shown in the app
+	DigestInApp DigestChannel = "in_app" // Only shown in the app, e.g. when no mail server is configured
+)
+
+// DigestSubscription is a user's opt-in to the weekly recommendation digest;
+// users without one get no digest
+type DigestSubscription struct {
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;primary_key"`
+	OptedInAt  time.Time  `json:"opted_in_at" gorm:"not null"`
+	LastSentAt *time.Time `json:"last_sent_at" gorm:"index"` // Nil until the first digest
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (DigestSubscription) TableName() string {
+	return "digest_subscriptions"
+}
+
+// RecommendationDigest is one weekly digest of suggested problems sent to a user
+type RecommendationDigest struct {
+	ID      uuid.UUID     `json:"id" gorm:"type:uuid;primary_key"`
+	UserID  uuid.UUID     `json:"user_id" gorm:"type:uuid;not null;index:idx_recommendation_digests_user_sent,priority:1"`
+	Channel DigestChannel `json:"channel" gorm:"type:varchar(16);not null"`
+	SentAt  time.Time     `json:"sent_at" gorm:"not null;index:idx_recommendation_digests_user_sent,priority:2;index"`
+
+	// Relationships
+	Items []DigestItem `json:"-" gorm:"foreignKey:DigestID"`
+	User  User         `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (RecommendationDigest) TableName() string {
+	return "recommendation_digests"
+}
+
+// DigestItem is a problem suggested in a digest. Its token identifies the
+// click-through link, so clicks are counted without the user signing in.
+type DigestItem struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	DigestID  uuid.UUID  `json:"digest_id" gorm:"type:uuid;not null;index"`
+	ProblemID uuid.UUID  `json:"problem_id" gorm:"type:uuid;not null;index"`
+	Position  int        `json:"position" gorm:"not null"` // 1-based rank in the digest
+	Token     string     `json:"-" gorm:"type:varchar(32);not null;uniqueIndex"`
+	ClickedAt *time.Time `json:"clicked_at"` // First click only
+
+	// Relationships
+	Digest  RecommendationDigest `json:"-" gorm:"foreignKey:DigestID;constraint:OnDelete:CASCADE"`
+	Problem Problem              `json:"-" gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (DigestItem) TableName() string {
+	return "recommendation_digest_items"
+}
+
+// DigestItemResponse is a suggested problem as shown in the app
+type DigestItemResponse struct {
+	ProblemResponse
+	Position int    `json:"position"`
+	ClickURL string `json:"click_url"` // API path that records the click and redirects to the problem page
+	Clicked  bool   `json:"clicked"`
+}
+
+// DigestResponse is a digest as shown in the app
+type DigestResponse struct {
+	ID      uuid.UUID            `json:"id"`
+	Channel DigestChannel        `json:"channel"`
+	SentAt  time.Time            `json:"sent_at"`
+	Items   []DigestItemResponse `json:"items"`
+}
+
+// DigestStatus is the user's digest opt-in together with the latest digest,
+// which doubles as the in-app notification
+type DigestStatus struct {
+	Enabled    bool            `json:"enabled"`
+	OptedInAt  *time.Time      `json:"opted_in_at"`
+	LastSentAt *time.Time      `json:"last_sent_at"`
+	Latest     *DigestResponse `json:"latest"`
+}
+
+// UpdateDigestRequest is the body of the digest preferences endpoint
+type UpdateDigestRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// DigestRunReport is the summary of one round of sending due digests
+type DigestRunReport struct {
+	Due     int `json:"due"`     // Subscribers whose digest was due
+	Sent    int `json:"sent"`    // Digests created, emailed or not
+	Emailed int `json:"emailed"` // Of those, delivered by email
+	Skipped int `json:"skipped"` // Subscribers with nothing left to suggest
+	Failed  int `json:"failed"`
+}
+
+// DigestStatsQuery holds the query parameters of the digest statistics endpoint
+type DigestStatsQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"` // Digests sent in this many days; defaults to 28
+}
+
+// DigestStats measures how useful digests are from their click-throughs
+type DigestStats struct {
+	Since          time.Time `json:"since"`
+	Subscribers    int64     `json:"subscribers"` // Currently opted in
+	Digests        int64     `json:"digests"`
+	Emailed        int64     `json:"emailed"`
+	Items          int64     `json:"items"`
+	Clicks         int64     `json:"clicks"`          // Items clicked at least once
+	ClickedDigests int64     `json:"clicked_digests"` // Digests with at least one click
+	SolvedItems    int64     `json:"solved_items"`    // Suggested problems the user solved after the digest
+
+	ClickRate        float64 `json:"click_rate"`         // Clicks per item
+	DigestClickRate  float64 `json:"digest_click_rate"`  // Share of digests with at least one click
+	SolveRate        float64 `json:"solve_rate"`         // Solved items per item
+	ClicksByPosition []int64 `json:"clicks_by_position"` // Index 0 is the top suggestion
+}
+
+// DigestRepository defines the interface for recommendation digest data access
+type DigestRepository interface {
+	FindSubscription(userID uuid.UUID) (*DigestSubscription, error) // Nil when the user has not opted in
+	Subscribe(sub *DigestSubscription) error                        // A user already opted in is left as is
+	Unsubscribe(userID uuid.UUID) error
+	// FindDue lists up to limit subscriptions without a digest since before,
+	// longest waiting first
+	FindDue(before time.Time, limit int) ([]DigestSubscription, error)
+	// Claim moves the subscription's last send time to now if no digest was
+	// sent since before, so of several instances only one sends it
+	Claim(userID uuid.UUID, before, now time.Time) (bool, error)
+
+	CreateDigest(digest *RecommendationDigest) error // With its items
+	MarkEmailed(digestID uuid.UUID) error
+	FindLatestDigest(userID uuid.UUID) (*RecommendationDigest, error) // Nil when none was sent; items preloaded with their problems
+	FindItemByToken(token string) (*DigestItem, error)                // Nil when unknown; problem preloaded
+	MarkClicked(itemID uuid.UUID, at time.Time) error                 // Keeps the first click
+
+	Stats(since time.Time) (*DigestStats, error) // Counts only; rates are derived by the caller
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) DigestRepository
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Domain errors - these are business logic errors that should be translated
 // to appropriate HTTP status codes by the handler layer
@@ -12,10 +17,25 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrInvalidToken       = errors.New("invalid or expired token")
 
+	// Token validation errors; all of them wrap ErrInvalidToken
+	ErrTokenExpired         = fmt.Errorf("%w: token has expired", ErrInvalidToken)
+	ErrTokenNotYetValid     = fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	ErrTokenMalformed       = fmt.Errorf("%w: token is malformed", ErrInvalidToken)
+	ErrTokenInvalidAudience = fmt.Errorf("%w: token has invalid audience", ErrInvalidToken)
+	ErrTokenInvalidIssuer   = fmt.Errorf("%w: token has invalid issuer", ErrInvalidToken)
+	ErrTokenRevoked         = fmt.Errorf("%w: token has been revoked", ErrInvalidToken)
+
+	ErrWeakPassword = errors.New("password does not meet policy requirements")
+
 	// Problem errors
-	ErrProblemNotFound     = errors.New("problem not found")
-	ErrNotEnoughProblems   = errors.New("not enough unsolved problems available")
-	ErrInvalidDifficulty   = errors.New("invalid difficulty level")
+	ErrProblemNotFound   = errors.New("problem not found")
+	ErrNotEnoughProblems = errors.New("not enough unsolved problems available")
+	ErrInvalidDifficulty = errors.New("invalid difficulty level")
+
+	// Custom problem errors
+	ErrTooManyCustomProblems = errors.New("custom problem limit reached")
+	ErrCustomProblemExists   = errors.New("a custom problem with this URL already exists")
+	ErrProblemInUse          = errors.New("problem is used by a contest or submission")
 
 	// Contest errors
 	ErrContestNotFound     = errors.New("contest not found")
@@ -23,16 +43,204 @@ var (
 	ErrContestExpired      = errors.New("contest has expired")
 	ErrActiveContestExists = errors.New("user already has an active contest")
 	ErrProblemNotInContest = errors.New("problem not found in this contest")
+	ErrContestNotStarted   = errors.New("contest timer has not started yet")
+	ErrNoWarmup            = errors.New("contest has no warmup problem")
+	ErrWarmupOver          = errors.New("warmup has already ended")
+	ErrContestInProgress   = errors.New("contest is still in progress")
+	ErrProblemNotCompleted = errors.New("problem is not completed")
+	ErrProblemCompleted    = errors.New("problem is already completed")
+	ErrNoActiveContest     = errors.New("user has no active contest")
+	ErrNothingToSkip       = errors.New("no other open problem to skip to")
+	ErrContestNotPending   = errors.New("contest is not pending")
+	ErrSessionConflict     = errors.New("contest session was saved from another device")
+
+	// Contest template errors
+	ErrInvalidTemplate  = errors.New("contest template is invalid or was altered")
+	ErrTemplateConflict = errors.New("contest template does not match the problem catalog")
+
+	// Community list errors
+	ErrListNotFound        = errors.New("problem list not found")
+	ErrListNotSubscribed   = errors.New("user is not subscribed to the problem list")
+	ErrListAlreadyReported = errors.New("user already reported the problem list")
+	ErrOwnList             = errors.New("users cannot subscribe to or report their own problem list")
+
+	// Challenge errors
+	ErrChallengeNotFound   = errors.New("challenge not found")
+	ErrChallengeAccepted   = errors.New("challenge has already been accepted")
+	ErrChallengeExpired    = errors.New("challenge invite has expired")
+	ErrChallengeInProgress = errors.New("challenge is still in progress")
+	ErrChatSilenced        = errors.New("chat is silenced while the contests run")
+
+	// Organization errors
+	ErrOrgNotFound          = errors.New("organization not found")
+	ErrOrgInviteNotFound    = errors.New("organization invite not found")
+	ErrOrgInviteExpired     = errors.New("organization invite has expired")
+	ErrAlreadyOrgMember     = errors.New("user is already a member of the organization")
+	ErrOrgFull              = errors.New("organization member limit reached")
+	ErrOrgOwner             = errors.New("the organization owner cannot be removed")
+	ErrAssignmentNotFound   = errors.New("assignment not found")
+	ErrAssignmentStarted    = errors.New("assignment has already been started")
+	ErrNotContestAssignment = errors.New("assignment is not a contest")
+	ErrContestNotProctored  = errors.New("contest is not an assignment contest")
+
+	// Mentorship errors
+	ErrMentorshipNotFound   = errors.New("mentorship not found")
+	ErrMentorshipExists     = errors.New("mentorship already exists")
+	ErrMentorshipNotPending = errors.New("mentorship is not pending")
+	ErrMentorshipNotActive  = errors.New("mentorship is not active")
+	ErrSelfMentorship       = errors.New("users cannot mentor themselves")
+
+	// Similarity errors
+	ErrSolutionNotFound       = errors.New("solution snippet not found")
+	ErrSimilarityFlagNotFound = errors.New("similarity flag not found")
+
+	// Tenant errors
+	ErrTenantNotFound  = errors.New("tenant not found")
+	ErrTenantSlugTaken = errors.New("tenant slug is already taken")
+
+	// Saved filter errors
+	ErrFilterNotFound  = errors.New("saved filter not found")
+	ErrFilterNameTaken = errors.New("a saved filter with this name already exists")
+	ErrTooManyFilters  = errors.New("saved filter limit reached")
 
 	// Submission errors
-	ErrSubmissionNotFound     = errors.New("submission not found")
-	ErrAlreadySolved          = errors.New("problem already solved by user")
+	ErrSubmissionNotFound = errors.New("submission not found")
+	ErrAlreadySolved      = errors.New("problem already solved by user")
+
+	// Feature flag errors
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+
+	// Maintenance errors
+	ErrMaintenance            = errors.New("down for maintenance")
+	ErrInvalidMaintenanceTime = errors.New("maintenance window ends before it starts")
 
 	// General errors
 	ErrInternalServer = errors.New("internal server error")
 	ErrBadRequest     = errors.New("bad request")
 	ErrUnauthorized   = errors.New("unauthorized")
 	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrRequestTimeout = errors.New("request timed out")
+	ErrOverloaded     = errors.New("server is overloaded")
+	ErrRateLimited    = errors.New("rate limit exceeded")
+	ErrQuotaExceeded  = errors.New("plan quota exceeded")
+
+	// Billing errors
+	ErrBillingDisabled         = errors.New("billing is not configured")
+	ErrAlreadySubscribed       = errors.New("user already has the premium plan")
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrPaymentProvider         = errors.New("payment provider request failed")
+
+	// Backup errors
+	ErrBackupDisabled     = errors.New("backup storage is not configured")
+	ErrBackupNotFound     = errors.New("backup not found")
+	ErrBackupCorrupt      = errors.New("backup failed verification")
+	ErrBackupIncompatible = errors.New("backup cannot be restored into this database")
+	ErrBackupInProgress   = errors.New("a backup is already running")
+	ErrRestoreNotEmpty    = errors.New("restore target already has users")
+
+	// Storage errors, classified from database driver errors by the repository layer
+	ErrConflict            = errors.New("conflicting change")
+	ErrForeignKeyViolation = errors.New("referenced record does not exist or is still referenced")
+	ErrTimeout             = errors.New("database operation timed out")
+	ErrUnavailable         = errors.New("database is unavailable")
+)
+
+// Machine-readable error codes returned in the API error envelope
+const (
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotFound             = "NOT_FOUND"
+	CodeInternal             = "INTERNAL_ERROR"
+	CodeRequestTimeout       = "REQUEST_TIMEOUT"
+	CodeOverloaded           = "OVERLOADED"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodeQuotaExceeded        = "QUOTA_EXCEEDED"
+	CodeBillingDisabled      = "BILLING_DISABLED"
+	CodeAlreadySubscribed    = "ALREADY_SUBSCRIBED"
+	CodeInvalidSignature     = "INVALID_SIGNATURE"
+	CodePaymentProvider      = "PAYMENT_PROVIDER_ERROR"
+	CodeConflict             = "CONFLICT"
+	CodeForeignKeyViolation  = "FOREIGN_KEY_VIOLATION"
+	CodeUnavailable          = "SERVICE_UNAVAILABLE"
+	CodeUserNotFound         = "USER_NOT_FOUND"
+	CodeUserAlreadyExists    = "USER_ALREADY_EXISTS"
+	CodeInvalidCredentials   = "INVALID_CREDENTIALS"
+	CodeInvalidToken         = "INVALID_TOKEN"
+	CodeTokenExpired         = "TOKEN_EXPIRED"
+	CodeTokenNotYetValid     = "TOKEN_NOT_YET_VALID"
+	CodeTokenMalformed       = "TOKEN_MALFORMED"
+	CodeTokenInvalidAudience = "TOKEN_INVALID_AUDIENCE"
+	CodeTokenInvalidIssuer   = "TOKEN_INVALID_ISSUER"
+	CodeTokenRevoked         = "TOKEN_REVOKED"
+	CodeWeakPassword         = "WEAK_PASSWORD"
+	CodeProblemNotFound      = "PROBLEM_NOT_FOUND"
+	CodeNotEnoughProblems    = "NOT_ENOUGH_PROBLEMS"
+	CodeInvalidDifficulty    = "INVALID_DIFFICULTY"
+	CodeTooManyCustom        = "TOO_MANY_CUSTOM_PROBLEMS"
+	CodeCustomProblemExists  = "CUSTOM_PROBLEM_EXISTS"
+	CodeProblemInUse         = "PROBLEM_IN_USE"
+	CodeContestNotFound      = "CONTEST_NOT_FOUND"
+	CodeContestNotActive     = "CONTEST_NOT_ACTIVE"
+	CodeContestExpired       = "CONTEST_EXPIRED"
+	CodeActiveContest        = "ACTIVE_CONTEST_EXISTS"
+	CodeProblemNotInContest  = "PROBLEM_NOT_IN_CONTEST"
+	CodeContestNotStarted    = "CONTEST_NOT_STARTED"
+	CodeNoWarmup             = "NO_WARMUP"
+	CodeWarmupOver           = "WARMUP_OVER"
+	CodeContestInProgress    = "CONTEST_IN_PROGRESS"
+	CodeProblemNotCompleted  = "PROBLEM_NOT_COMPLETED"
+	CodeProblemCompleted     = "PROBLEM_ALREADY_COMPLETED"
+	CodeNoActiveContest      = "NO_ACTIVE_CONTEST"
+	CodeNothingToSkip        = "NOTHING_TO_SKIP"
+	CodeContestNotPending    = "CONTEST_NOT_PENDING"
+	CodeSessionConflict      = "SESSION_CONFLICT"
+	CodeListNotFound         = "LIST_NOT_FOUND"
+	CodeListNotSubscribed    = "LIST_NOT_SUBSCRIBED"
+	CodeListAlreadyReported  = "LIST_ALREADY_REPORTED"
+	CodeOwnList              = "OWN_LIST"
+	CodeChallengeNotFound    = "CHALLENGE_NOT_FOUND"
+	CodeChallengeAccepted    = "CHALLENGE_ACCEPTED"
+	CodeChallengeExpired     = "CHALLENGE_EXPIRED"
+	CodeChallengeInProgress  = "CHALLENGE_IN_PROGRESS"
+	CodeChatSilenced         = "CHAT_SILENCED"
+	CodeOrgNotFound          = "ORG_NOT_FOUND"
+	CodeOrgInviteNotFound    = "ORG_INVITE_NOT_FOUND"
+	CodeOrgInviteExpired     = "ORG_INVITE_EXPIRED"
+	CodeAlreadyOrgMember     = "ALREADY_ORG_MEMBER"
+	CodeOrgFull              = "ORG_FULL"
+	CodeOrgOwner             = "ORG_OWNER"
+	CodeAssignmentNotFound   = "ASSIGNMENT_NOT_FOUND"
+	CodeAssignmentStarted    = "ASSIGNMENT_STARTED"
+	CodeNotContestAssignment = "NOT_CONTEST_ASSIGNMENT"
+	CodeContestNotProctored  = "CONTEST_NOT_PROCTORED"
+	CodeMentorshipNotFound   = "MENTORSHIP_NOT_FOUND"
+	CodeMentorshipExists     = "MENTORSHIP_EXISTS"
+	CodeMentorshipNotPending = "MENTORSHIP_NOT_PENDING"
+	CodeMentorshipNotActive  = "MENTORSHIP_NOT_ACTIVE"
+	CodeSelfMentorship       = "SELF_MENTORSHIP"
+	CodeSolutionNotFound     = "SOLUTION_NOT_FOUND"
+	CodeSimilarityNotFound   = "SIMILARITY_FLAG_NOT_FOUND"
+	CodeTenantNotFound       = "TENANT_NOT_FOUND"
+	CodeTenantSlugTaken      = "TENANT_SLUG_TAKEN"
+	CodeFilterNotFound       = "FILTER_NOT_FOUND"
+	CodeFilterNameTaken      = "FILTER_NAME_TAKEN"
+	CodeTooManyFilters       = "TOO_MANY_FILTERS"
+	CodeSubmissionNotFound   = "SUBMISSION_NOT_FOUND"
+	CodeAlreadySolved        = "ALREADY_SOLVED"
+	CodeFeatureFlagNotFound  = "FEATURE_FLAG_NOT_FOUND"
+	CodeMaintenance          = "MAINTENANCE"
+	CodeInvalidMaintenance   = "INVALID_MAINTENANCE_WINDOW"
+	CodeBackupDisabled       = "BACKUP_DISABLED"
+	CodeBackupNotFound       = "BACKUP_NOT_FOUND"
+	CodeBackupCorrupt        = "BACKUP_CORRUPT"
+	CodeBackupIncompatible   = "BACKUP_INCOMPATIBLE"
+	CodeBackupInProgress     = "BACKUP_IN_PROGRESS"
+	CodeRestoreNotEmpty      = "RESTORE_NOT_EMPTY"
+	CodeInvalidTemplate      = "INVALID_TEMPLATE"
+	CodeTemplateConflict     = "TEMPLATE_CONFLICT"
 )
 
 // DomainError wraps an error with additional context
@@ -40,6 +248,7 @@ type DomainError struct {
 	Err     error
 	Message string
 	Code    string
+	Details interface{} // Optional structured context for API clients
 }
 
 func (e *DomainError) Error() string {
@@ -61,6 +270,16 @@ func NewDomainError(err error, message string) *DomainError {
 	}
 }
 
+// NewValidationError creates a DomainError for malformed request input
+func NewValidationError(message string, details interface{}) *DomainError {
+	return &DomainError{
+		Err:     ErrBadRequest,
+		Message: message,
+		Code:    CodeValidationFailed,
+		Details: details,
+	}
+}
+
 // WrapError wraps an error with additional context
 func WrapError(err error, message string) error {
 	if err == nil {
@@ -71,3 +290,80 @@ func WrapError(err error, message string) error {
 		Message: message,
 	}
 }
+
+// PasswordViolation describes a single password policy rule that was not met
+type PasswordViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicyError reports every password policy rule a candidate failed,
+// so clients can show all problems at once instead of one per attempt
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrWeakPassword.Error()
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// ActiveContestError is ErrActiveContestExists with the contest that is still
+// running, so clients can offer to finish it instead of just failing
+type ActiveContestError struct {
+	Contest *Contest
+}
+
+func (e *ActiveContestError) Error() string {
+	return ErrActiveContestExists.Error()
+}
+
+func (e *ActiveContestError) Unwrap() error {
+	return ErrActiveContestExists
+}
+
+// RetryClass tells API clients whether repeating a failed request can succeed
+type RetryClass string
+
+const (
+	RetryNever      RetryClass = "never"      // Fails the same way until something else changes
+	RetrySafe       RetryClass = "safe"       // Rejected before anything changed; any request can be repeated
+	RetryIdempotent RetryClass = "idempotent" // May have partly run; repeat only idempotent requests
+)
+
+// RetryHint is how a client should treat a failed request
+type RetryHint struct {
+	Class RetryClass
+	After time.Duration // Suggested wait before the next attempt; zero leaves it to the client's backoff
+}
+
+// retryHints classifies the errors worth retrying; every other error is
+// RetryNever. Entries are matched with errors.Is in order.
+var retryHints = []struct {
+	err  error
+	hint RetryHint
+}{
+	{ErrOverloaded, RetryHint{RetrySafe, time.Second}},
+	{ErrRateLimited, RetryHint{RetrySafe, 0}}, // Retry-After is set from the limit's window
+	{ErrMaintenance, RetryHint{RetrySafe, 0}}, // Retry-After is set from the maintenance window
+	{ErrConflict, RetryHint{RetrySafe, time.Second}},
+	{ErrBackupInProgress, RetryHint{RetrySafe, 30 * time.Second}},
+	{ErrUnavailable, RetryHint{RetryIdempotent, 5 * time.Second}},
+	{ErrTimeout, RetryHint{RetryIdempotent, 2 * time.Second}},
+	{ErrRequestTimeout, RetryHint{RetryIdempotent, 2 * time.Second}},
+	{context.DeadlineExceeded, RetryHint{RetryIdempotent, 2 * time.Second}},
+	{ErrPaymentProvider, RetryHint{RetryIdempotent, 5 * time.Second}},
+}
+
+// RetryHintFor classifies err for API clients deciding whether to retry
+func RetryHintFor(err error) RetryHint {
+	for _, r := range retryHints {
+		if errors.Is(err, r.err) {
+			return r.hint
+		}
+	}
+	return RetryHint{Class: RetryNever}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Event names used for subscriptions on the event bus
+const (
+	EventContestCreated           = "contest.created"
+	EventProblemCompletionChanged = "contest.problem_completion_changed"
+	EventContestFinished          = "contest.finished"
+	EventProblemSolved            = "user.problem_solved"
+	EventChallengeAccepted        = "challenge.accepted"
+)
+
+// Event is a domain event published after a state change has been persisted
+type Event interface {
+	EventName() string
+}
+
+// EventPublisher publishes domain events to interested subscribers
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// ContestCreatedEvent is published when a contest and its problems are stored
+type ContestCreatedEvent struct {
+	ContestID  uuid.UUID
+	UserID     uuid.UUID
+	ProblemIDs []uuid.UUID
+}
+
+// EventName implements Event
+func (ContestCreatedEvent) EventName() string { return EventContestCreated }
+
+// ProblemCompletionChangedEvent is published when a contest problem is checked or unchecked
+type ProblemCompletionChangedEvent struct {
+	ContestID   uuid.UUID
+	UserID      uuid.UUID
+	ProblemID   uuid.UUID
+	IsCompleted bool
+}
+
+// EventName implements Event
+func (ProblemCompletionChangedEvent) EventName() string { return EventProblemCompletionChanged }
+
+// ContestFinishedEvent is published when a contest leaves the active state
+type ContestFinishedEvent struct {
+	ContestID uuid.UUID
+	UserID    uuid.UUID
+	Status    ContestStatus
+}
+
+// EventName implements Event
+func (ContestFinishedEvent) EventName() string { return EventContestFinished }
+
+// ProblemSolvedEvent is published when a user solves a problem for the first time
+type ProblemSolvedEvent struct {
+	UserID    uuid.UUID
+	ProblemID uuid.UUID
+	ContestID uuid.UUID
+}
+
+// EventName implements Event
+func (ProblemSolvedEvent) EventName() string { return EventProblemSolved }
+
+// ChallengeAcceptedEvent is published when a friend accepts a challenge
+type ChallengeAcceptedEvent struct {
+	ChallengeID  uuid.UUID
+	ChallengerID uuid.UUID
+	OpponentID   uuid.UUID
+	ContestID    uuid.UUID // The challenger's contest
+}
+
+// EventName implements Event
+func (ChallengeAcceptedEvent) EventName() string { return EventChallengeAccepted }
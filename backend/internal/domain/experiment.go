@@ -0,0 +1,60 @@
+package domain
+
+import "github.com/google/uuid"
+
+// SelectionAlgorithm decides the difficulty mix of a random contest
+type SelectionAlgorithm string
+
+const (
+	SelectionProgressive SelectionAlgorithm = "progressive" // Fixed mix ramping from easy to hard; the default
+	SelectionAdaptive    SelectionAlgorithm = "adaptive"    // Mix shifted toward the user's recent solve rate
+)
+
+// Experiment splits the users its feature flag is on for into variants. The
+// flag's rollout percentage controls how many users take part; the assignment
+// within the experiment is a separate hash, so changing the rollout never moves
+// a participant to another variant.
+type Experiment struct {
+	Key      string
+	Flag     string
+	Variants []string
+}
+
+// SelectionExperiment compares problem selection algorithms
+var SelectionExperiment = Experiment{
+	Key:      "selection_algorithm",
+	Flag:     FeatureSelectionExperiment,
+	Variants: []string{string(SelectionProgressive), string(SelectionAdaptive)},
+}
+
+// Experiments lists every experiment whose outcomes admins can review
+var Experiments = []Experiment{SelectionExperiment}
+
+// Variant deterministically assigns the user to one of the variants
+func (e Experiment) Variant(userID uuid.UUID) string {
+	return e.Variants[RolloutBucket(e.Key, userID)%len(e.Variants)]
+}
+
+// VariantOutcome aggregates the contests created under one experiment variant
+type VariantOutcome struct {
+	Variant        string  `json:"variant"`
+	Contests       int64   `json:"contests"`
+	Completed      int64   `json:"completed"`
+	Abandoned      int64   `json:"abandoned"`
+	CompletionRate float64 `json:"completion_rate"` // Completed share of finished contests
+	ProblemsServed int64   `json:"problems_served"`
+	ProblemsSolved int64   `json:"problems_solved"`
+	SolveRate      float64 `json:"solve_rate"` // Solved share of served problems
+}
+
+// ExperimentOutcome reports the outcome of every variant of an experiment
+type ExperimentOutcome struct {
+	Key      string           `json:"key"`
+	Flag     string           `json:"flag"`
+	Variants []VariantOutcome `json:"variants"`
+}
+
+// ExperimentsResponse lists the outcomes of all experiments
+type ExperimentsResponse struct {
+	Experiments []ExperimentOutcome `json:"experiments"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Feature flag keys. A flag must be listed in KnownFeatureFlags before it can be
+// configured or toggled, so a typo cannot silently create a new flag.
+const (
+	FeatureDuels               = "duels"
+	FeatureJudging             = "judging"
+	FeatureSelectionExperiment = "selection_experiment"
+)
+
+// KnownFeatureFlags maps every flag key to what it gates. Flags are off unless
+// FEATURE_FLAGS or an admin turns them on.
+var KnownFeatureFlags = map[string]string{
+	FeatureDuels:               "Live head-to-head contests between two users",
+	FeatureJudging:             "Running submitted code against test cases",
+	FeatureSelectionExperiment: "Enrolls users in the selection algorithm experiment",
+}
+
+// Where a flag's current setting comes from
+const (
+	FeatureFlagSourceDefault  = "default"  // Not configured; off
+	FeatureFlagSourceConfig   = "config"   // FEATURE_FLAGS environment variable
+	FeatureFlagSourceDatabase = "database" // Toggled by an admin; overrides the config
+)
+
+// FeatureFlag gates a feature that ships dark. An enabled flag is on for the
+// RolloutPercent of users whose bucket for the flag falls below it.
+type FeatureFlag struct {
+	Key            string    `json:"key" gorm:"type:varchar(64);primaryKey"`
+	Enabled        bool      `json:"enabled" gorm:"not null;default:false"`
+	RolloutPercent int       `json:"rollout_percent" gorm:"not null;default:100"`
+	UpdatedBy      uuid.UUID `json:"-" gorm:"type:uuid"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Description string `json:"description" gorm:"-"`
+	Source      string `json:"source" gorm:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
+
+// EnabledFor reports whether the flag is on for the user. Each user lands in a
+// stable bucket per flag, so raising the percentage only ever adds users.
+// Anonymous requests only see flags rolled out to everyone.
+func (f *FeatureFlag) EnabledFor(userID uuid.UUID) bool {
+	if !f.Enabled || f.RolloutPercent <= 0 {
+		return false
+	}
+	if f.RolloutPercent >= 100 {
+		return true
+	}
+	if userID == uuid.Nil {
+		return false
+	}
+	return RolloutBucket(f.Key, userID) < f.RolloutPercent
+}
+
+// RolloutBucket places a user in one of 100 buckets for the given key
+func RolloutBucket(key string, userID uuid.UUID) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write(userID[:])
+	return int(h.Sum32() % 100)
+}
+
+// FeatureFlagRepository defines the interface for feature flag overrides
+type FeatureFlagRepository interface {
+	FindAll() ([]FeatureFlag, error)
+	// Save creates or replaces the override for the flag's key
+	Save(flag *FeatureFlag) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) FeatureFlagRepository
+}
+
+// UpdateFeatureFlagRequest represents the request to toggle a feature flag
+type UpdateFeatureFlagRequest struct {
+	Enabled        *bool `json:"enabled" binding:"required"`
+	RolloutPercent *int  `json:"rollout_percent" binding:"omitempty,min=0,max=100"` // Defaults to 100
+}
+
+// FeatureFlagListResponse lists every known flag with its current setting
+type FeatureFlagListResponse struct {
+	Flags []FeatureFlag `json:"flags"`
+}
+
+// FeaturesResponse lists which flags are on for the current user
+type FeaturesResponse struct {
+	Features map[string]bool `json:"features"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedItemKind names what happened in an activity feed item
+type FeedItemKind string
+
+const (
+	FeedProblemSolved     FeedItemKind = "problem_solved"     // First solve of a problem
+	FeedContestCompleted  FeedItemKind = "contest_completed"  // A contest finished without being abandoned
+	FeedAchievement       FeedItemKind = "achievement"        // A solved-problems milestone, named in the detail
+	FeedChallengeAccepted FeedItemKind = "challenge_accepted" // The actor accepted a friend's challenge
+)
+
+// FeedItem is an entry of a user's activity feed. Activity is fanned out on
+// write: one item is stored in the feed of the user who did it and one in the
+// feed of each of their friends, so reading a feed is a single indexed scan.
+// Friends are users who played a challenge together.
+type FeedItem struct {
+	// ID is a UUIDv7, so items sort by creation and the ID serves as cursor
+	ID        uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;index:idx_feed_items_owner_id,priority:2"`
+	OwnerID   uuid.UUID    `json:"owner_id" gorm:"type:uuid;not null;index:idx_feed_items_owner_id,priority:1"` // Whose feed it is in
+	ActorID   uuid.UUID    `json:"actor_id" gorm:"type:uuid;not null;index"`                                    // Who did it
+	Kind      FeedItemKind `json:"kind" gorm:"type:varchar(32);not null"`
+	ContestID *uuid.UUID   `json:"contest_id" gorm:"type:uuid"`
+	ProblemID *uuid.UUID   `json:"problem_id" gorm:"type:uuid"`
+	Detail    string       `json:"detail" gorm:"type:varchar(64);not null;default:''"` // e.g. the milestone of an achievement
+	CreatedAt time.Time    `json:"created_at"`
+
+	// Relationships
+	Owner User `json:"-" gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	Actor User `json:"-" gorm:"foreignKey:ActorID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (FeedItem) TableName() string {
+	return "feed_items"
+}
+
+// FeedQuery holds the query parameters of the activity feed
+type FeedQuery struct {
+	Cursor string `form:"cursor"` // next_cursor of the previous page
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// FeedEntry is a feed item as shown to the feed's owner
+type FeedEntry struct {
+	ID            uuid.UUID    `json:"id"`
+	Kind          FeedItemKind `json:"kind"`
+	ActorID       uuid.UUID    `json:"actor_id"`
+	ActorUsername string       `json:"actor_username"`
+	Own           bool         `json:"own"` // The owner did it; otherwise a friend did
+	ContestID     *uuid.UUID   `json:"contest_id,omitempty"`
+	ProblemID     *uuid.UUID   `json:"problem_id,omitempty"`
+	ProblemTitle  string       `json:"problem_title,omitempty"`
+	Detail        string       `json:"detail,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// FeedResponse is a page of the activity feed, newest first
+type FeedResponse struct {
+	Items      []FeedEntry `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// FeedRepository defines the interface for activity feed data access
+type FeedRepository interface {
+	Create(items []FeedItem) error
+	// FindPage lists up to limit items of the owner's feed older than the item
+	// before, newest first; a nil before starts from the newest
+	FindPage(ownerID uuid.UUID, before *uuid.UUID, limit int) ([]FeedEntry, error)
+	// HasAchievement reports whether the user already reached the milestone
+	HasAchievement(userID uuid.UUID, detail string) (bool, error)
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) FeedRepository
+}
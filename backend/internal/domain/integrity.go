@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IntegrityIssue is a kind of inconsistency the integrity job detects and repairs
+type IntegrityIssue string
+
+const (
+	IssueDuplicateSubmissions    IntegrityIssue = "duplicate_submissions"     // Repeated submissions of a problem by a user; the earliest is kept
+	IssueOrphanedSubmissions     IntegrityIssue = "orphaned_submissions"      // Submissions whose user or problem no longer exists
+	IssueOrphanedContestProblems IntegrityIssue = "orphaned_contest_problems" // Contest problems whose contest or problem no longer exists
+	IssueProgressDrift           IntegrityIssue = "progress_drift"            // Progress summaries that differ from submissions and contests
+	IssueUsageDrift              IntegrityIssue = "usage_drift"               // Problem usage counters that differ from contest problems
+)
+
+// IntegrityIssues lists the issues in the order they are repaired: orphans and
+// duplicates go first so the recomputed summaries and counters do not count them
+var IntegrityIssues = []IntegrityIssue{
+	IssueDuplicateSubmissions,
+	IssueOrphanedSubmissions,
+	IssueOrphanedContestProblems,
+	IssueProgressDrift,
+	IssueUsageDrift,
+}
+
+// IntegrityFinding reports how many rows had an issue and how many were repaired
+type IntegrityFinding struct {
+	Issue    IntegrityIssue `json:"issue"`
+	Found    int64          `json:"found"`
+	Repaired int64          `json:"repaired"` // Always 0 in a dry run
+}
+
+// IntegrityReport is the outcome of an integrity run
+type IntegrityReport struct {
+	DryRun     bool               `json:"dry_run"`
+	Findings   []IntegrityFinding `json:"findings"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+}
+
+// RunIntegrityRequest is the optional body of the integrity endpoint
+type RunIntegrityRequest struct {
+	DryRun bool `json:"dry_run"` // Only detect issues, leave the data untouched
+}
+
+// IntegrityRepository detects and repairs inconsistencies between raw and derived data
+type IntegrityRepository interface {
+	// Count returns how many rows have the issue
+	Count(issue IntegrityIssue) (int64, error)
+	// Repair fixes the issue and returns how many rows it changed
+	Repair(issue IntegrityIssue) (int64, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) IntegrityRepository
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// JobRun records when a scheduled background job last ran on any instance, so
+// instances whose tickers fire in the same interval do not each run it
+type JobRun struct {
+	Name      string    `gorm:"type:varchar(64);primaryKey"`
+	StartedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (JobRun) TableName() string {
+	return "job_runs"
+}
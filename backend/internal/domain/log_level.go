@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogLevelOverride is the single row holding a log level set by an admin at
+// runtime. It replaces LOG_LEVEL on every instance until it expires or is reset.
+type LogLevelOverride struct {
+	ID        int        `gorm:"primaryKey;autoIncrement:false"`
+	Level     string     `gorm:"type:varchar(10);not null;default:''"` // Empty uses LOG_LEVEL
+	ExpiresAt *time.Time // Nil lasts until reset
+	UpdatedBy uuid.UUID  `gorm:"type:uuid"`
+	UpdatedAt time.Time
+}
+
+// TableName specifies the table name for GORM
+func (LogLevelOverride) TableName() string {
+	return "log_level_overrides"
+}
+
+// LogLevelOverrideID is the primary key of the only log level override row
+const LogLevelOverrideID = 1
+
+// LogLevelDefault in a SetLogLevelRequest drops the override and returns to LOG_LEVEL
+const LogLevelDefault = "default"
+
+// Active reports whether the override applies at the given time
+func (o *LogLevelOverride) Active(now time.Time) bool {
+	return o.Level != "" && (o.ExpiresAt == nil || now.Before(*o.ExpiresAt))
+}
+
+// LogLevelRepository defines the interface for the log level override
+type LogLevelRepository interface {
+	// Find returns the stored override, or an empty one if none was ever saved
+	Find() (*LogLevelOverride, error)
+	Save(override *LogLevelOverride) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) LogLevelRepository
+}
+
+// SetLogLevelRequest changes the log level of every instance
+type SetLogLevelRequest struct {
+	Level           string `json:"level" binding:"required,oneof=debug info warn error default"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1,max=1440"` // Omit to keep the level until reset
+}
+
+// LogLevelStatus reports the log level in effect
+type LogLevelStatus struct {
+	Level     string     `json:"level"`      // Level in effect
+	Default   string     `json:"default"`    // Level from LOG_LEVEL, used without an override
+	ExpiresAt *time.Time `json:"expires_at"` // When the override ends; nil without an override or when it lasts until reset
+}
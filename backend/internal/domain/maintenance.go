@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceWindow is the single row describing planned or ongoing maintenance.
+// While it is active, write endpoints answer 503 and reads keep working.
+type MaintenanceWindow struct {
+	ID        int        `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	Enabled   bool       `json:"enabled" gorm:"not null;default:false"`
+	Message   string     `json:"message" gorm:"type:varchar(500);not null;default:''"`
+	StartsAt  *time.Time `json:"starts_at"` // Nil starts immediately
+	EndsAt    *time.Time `json:"ends_at"`   // Nil lasts until turned off
+	UpdatedBy uuid.UUID  `json:"-" gorm:"type:uuid"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MaintenanceWindow) TableName() string {
+	return "maintenance_windows"
+}
+
+// MaintenanceWindowID is the primary key of the only maintenance window row
+const MaintenanceWindowID = 1
+
+// Active reports whether writes are blocked at the given time
+func (w *MaintenanceWindow) Active(now time.Time) bool {
+	if !w.Enabled {
+		return false
+	}
+	if w.StartsAt != nil && now.Before(*w.StartsAt) {
+		return false
+	}
+	return w.EndsAt == nil || now.Before(*w.EndsAt)
+}
+
+// Upcoming reports whether the window is scheduled but has not started yet
+func (w *MaintenanceWindow) Upcoming(now time.Time) bool {
+	return w.Enabled && w.StartsAt != nil && now.Before(*w.StartsAt)
+}
+
+// MaintenanceRepository defines the interface for the maintenance window
+type MaintenanceRepository interface {
+	// Find returns the stored window, or a disabled one if none was ever saved
+	Find() (*MaintenanceWindow, error)
+	Save(window *MaintenanceWindow) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) MaintenanceRepository
+}
+
+// SetMaintenanceRequest schedules, starts or ends maintenance
+type SetMaintenanceRequest struct {
+	Enabled  *bool      `json:"enabled" binding:"required"`
+	Message  string     `json:"message" binding:"max=500"`
+	StartsAt *time.Time `json:"starts_at"` // Omit to start immediately
+	EndsAt   *time.Time `json:"ends_at"`   // Must be after StartsAt, or after now
+}
+
+// MaintenanceStatus announces ongoing or upcoming maintenance to clients
+type MaintenanceStatus struct {
+	Active            bool       `json:"active"`
+	Upcoming          bool       `json:"upcoming"`
+	Message           string     `json:"message"`
+	StartsAt          *time.Time `json:"starts_at"`
+	EndsAt            *time.Time `json:"ends_at"`
+	RetryAfterSeconds int        `json:"retry_after_seconds"` // Suggested wait before retrying a rejected write
+}
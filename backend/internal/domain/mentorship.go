@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MentorshipStatus is where a mentorship is in its invite, consent and revocation cycle
+type MentorshipStatus string
+
+const (
+	MentorshipPending  MentorshipStatus = "pending"  // Invited by the mentor; the mentee has not answered
+	MentorshipActive   MentorshipStatus = "active"   // The mentee consented; the mentor can read their data
+	MentorshipDeclined MentorshipStatus = "declined" // The mentee refused the invite
+	MentorshipRevoked  MentorshipStatus = "revoked"  // Ended by either side; access stops
+)
+
+// Mentorship grants a mentor read access to a mentee's contests, timing
+// analytics and retro notes once the mentee consents. A pair has one row,
+// reused when the mentor invites again after a decline or revocation.
+type Mentorship struct {
+	ID          uuid.UUID        `json:"id" gorm:"type:uuid;primary_key"`
+	MentorID    uuid.UUID        `json:"mentor_id" gorm:"type:uuid;not null;uniqueIndex:idx_mentorships_pair,priority:1"`
+	MenteeID    uuid.UUID        `json:"mentee_id" gorm:"type:uuid;not null;uniqueIndex:idx_mentorships_pair,priority:2;index"`
+	Status      MentorshipStatus `json:"status" gorm:"type:varchar(16);not null"`
+	InvitedAt   time.Time        `json:"invited_at" gorm:"not null"`
+	RespondedAt *time.Time       `json:"responded_at"` // When the mentee accepted or declined
+	RevokedAt   *time.Time       `json:"revoked_at"`
+	RevokedBy   *uuid.UUID       `json:"revoked_by" gorm:"type:uuid"`
+	CreatedAt   time.Time        `json:"created_at"`
+
+	// Relationships
+	Mentor User `json:"-" gorm:"foreignKey:MentorID;constraint:OnDelete:CASCADE"`
+	Mentee User `json:"-" gorm:"foreignKey:MenteeID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (Mentorship) TableName() string {
+	return "mentorships"
+}
+
+// IsOpen reports whether the mentorship is pending or active, so a new invite would duplicate it
+func (m *Mentorship) IsOpen() bool {
+	return m.Status == MentorshipPending || m.Status == MentorshipActive
+}
+
+// MentorshipAction is what an audit trail entry records
+type MentorshipAction string
+
+const (
+	MentorshipInvited        MentorshipAction = "invited"
+	MentorshipAccepted       MentorshipAction = "accepted"
+	MentorshipDeclinedAction MentorshipAction = "declined"
+	MentorshipRevokedAction  MentorshipAction = "revoked"
+	MentorViewedContests     MentorshipAction = "viewed_contests"
+	MentorViewedContest      MentorshipAction = "viewed_contest"
+	MentorViewedTiming       MentorshipAction = "viewed_timing"
+	MentorViewedNotes        MentorshipAction = "viewed_notes"
+	MentorAssigned           MentorshipAction = "assigned"
+	MentorUnassigned         MentorshipAction = "unassigned"
+	MentorViewedAssignments  MentorshipAction = "viewed_assignments"
+)
+
+// MentorshipAuditEntry is one entry of a mentorship's audit trail: every
+// change of consent and every read of the mentee's data by the mentor
+type MentorshipAuditEntry struct {
+	ID           uuid.UUID        `json:"id" gorm:"type:uuid;primary_key"`
+	MentorshipID uuid.UUID        `json:"mentorship_id" gorm:"type:uuid;not null;index:idx_mentorship_audit_created,priority:1"`
+	ActorID      uuid.UUID        `json:"actor_id" gorm:"type:uuid;not null"`
+	Action       MentorshipAction `json:"action" gorm:"type:varchar(32);not null"`
+	ContestID    *uuid.UUID       `json:"contest_id,omitempty" gorm:"type:uuid"`    // Contest read, for viewed_contest
+	AssignmentID *uuid.UUID       `json:"assignment_id,omitempty" gorm:"type:uuid"` // For assigned and unassigned
+	CreatedAt    time.Time        `json:"created_at" gorm:"index:idx_mentorship_audit_created,priority:2"`
+
+	// Relationships
+	Mentorship Mentorship `json:"-" gorm:"foreignKey:MentorshipID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (MentorshipAuditEntry) TableName() string {
+	return "mentorship_audit_log"
+}
+
+// MentorAssignment is work a mentor assigns to their mentee: a contest with the
+// given settings, or a fixed problem set given to the mentee as a pending
+// contest right away
+type MentorAssignment struct {
+	ID              uuid.UUID             `json:"id" gorm:"type:uuid;primary_key"`
+	MentorshipID    uuid.UUID             `json:"mentorship_id" gorm:"type:uuid;not null;index"`
+	Kind            AssignmentKind        `json:"kind" gorm:"type:varchar(16);not null"`
+	Title           string                `json:"title" gorm:"type:varchar(100);not null"`
+	Contest         *CreateContestRequest `json:"contest,omitempty" gorm:"type:text;serializer:json"`     // Settings of a contest assignment
+	ProblemIDs      []uuid.UUID           `json:"problem_ids,omitempty" gorm:"type:text;serializer:json"` // Problems of a problem set, in order
+	DurationMinutes int                   `json:"duration_minutes,omitempty"`                             // Contest duration of a problem set
+	DueAt           time.Time             `json:"due_at" gorm:"not null"`
+	ContestID       *uuid.UUID            `json:"-" gorm:"type:uuid;uniqueIndex"` // The mentee's contest once they have one
+	CreatedAt       time.Time             `json:"created_at"`
+
+	// Relationships
+	Mentorship Mentorship `json:"-" gorm:"foreignKey:MentorshipID;constraint:OnDelete:CASCADE"`
+	// Deleting the contest frees the assignment to be started again
+	AssignedContest *Contest `json:"-" gorm:"foreignKey:ContestID;constraint:OnDelete:SET NULL"`
+}
+
+// TableName specifies the table name for GORM
+func (MentorAssignment) TableName() string {
+	return "mentor_assignments"
+}
+
+// MentorAssignmentResponse is a mentor assignment with the mentee's progress
+type MentorAssignmentResponse struct {
+	MentorAssignment
+	Status    AssignmentStatus `json:"status"`
+	ContestID *uuid.UUID       `json:"contest_id,omitempty"`
+	Solved    int              `json:"solved"`
+	Total     int              `json:"total"`
+	StartedAt *time.Time       `json:"started_at,omitempty"`
+	EndedAt   *time.Time       `json:"ended_at,omitempty"`
+	Overdue   bool             `json:"overdue"` // Past due and not completed
+	Late      bool             `json:"late"`    // Completed after the due date
+}
+
+// MentorshipResponse is a mentorship with the usernames of both sides
+type MentorshipResponse struct {
+	Mentorship
+	MentorUsername string `json:"mentor_username"`
+	MenteeUsername string `json:"mentee_username"`
+}
+
+// MentorshipList is the caller's mentorships on each side
+type MentorshipList struct {
+	Mentoring []MentorshipResponse `json:"mentoring"` // The caller is the mentor
+	Mentors   []MentorshipResponse `json:"mentors"`   // The caller is the mentee
+}
+
+// MenteeTiming is the timing analytics of a mentee shown to their mentor
+type MenteeTiming struct {
+	SolveTimes   SolveTimeStats    `json:"solve_times"`
+	ContestStats ContestStatistics `json:"contest_stats"`
+	LastActiveAt *time.Time        `json:"last_active_at"`
+}
+
+// MenteeNote is the retro note of one of the mentee's contests
+type MenteeNote struct {
+	ContestID uuid.UUID     `json:"contest_id"`
+	Status    ContestStatus `json:"status"`
+	StartedAt time.Time     `json:"started_at"`
+	Retro     string        `json:"retro"`
+	UpdatedAt *time.Time    `json:"updated_at"`
+}
+
+// MentorshipAudit is a page of a mentorship's audit trail, newest first
+type MentorshipAudit struct {
+	Entries []MentorshipAuditEntry `json:"entries"`
+}
+
+// CreateMentorshipRequest is the body of the mentor invite endpoint
+type CreateMentorshipRequest struct {
+	MenteeEmail string `json:"mentee_email" binding:"required,email"`
+}
+
+// CreateMentorAssignmentRequest is the body of the mentor assignment endpoint
+type CreateMentorAssignmentRequest struct {
+	Kind    AssignmentKind        `json:"kind" binding:"required,oneof=contest problem_set"`
+	Title   string                `json:"title" binding:"required,min=1,max=100"`
+	Contest *CreateContestRequest `json:"contest" binding:"required_if=Kind contest"`
+	// Problem IDs or slugs of a problem set, in order
+	Problems        []string  `json:"problems" binding:"required_if=Kind problem_set,max=20,dive,min=1,max=255"`
+	DurationMinutes int       `json:"duration_minutes" binding:"required_if=Kind problem_set,omitempty,min=10,max=300"`
+	DueAt           time.Time `json:"due_at" binding:"required"`
+}
+
+// MentorshipAuditQuery is the query of the audit trail endpoint
+type MentorshipAuditQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"` // Defaults to 50
+}
+
+// MentorshipRepository defines the interface for mentorship data access
+type MentorshipRepository interface {
+	Create(mentorship *Mentorship) error // Returns ErrMentorshipExists for an existing pair
+	FindByID(id uuid.UUID) (*Mentorship, error)
+	FindByPair(mentorID, menteeID uuid.UUID) (*Mentorship, error)
+	// FindForUser lists the user's mentorships on both sides with usernames, newest first
+	FindForUser(userID uuid.UUID) ([]MentorshipResponse, error)
+	// Update stores a status change, guarded by the status it was read with; it
+	// reports false when the mentorship changed in between
+	Update(mentorship *Mentorship, from MentorshipStatus) (bool, error)
+
+	CreateAssignment(assignment *MentorAssignment) error
+	FindAssignment(mentorshipID, id uuid.UUID) (*MentorAssignment, error)
+	// FindAssignments lists the assignments of the given mentorships, newest first
+	FindAssignments(mentorshipIDs []uuid.UUID) ([]MentorAssignment, error)
+	DeleteAssignment(mentorshipID, id uuid.UUID) error
+	// FindPendingContestIDs lists the mentorship's assigned contests that were
+	// not started yet, narrowed to one assignment when given
+	FindPendingContestIDs(mentorshipID uuid.UUID, assignmentID *uuid.UUID) ([]uuid.UUID, error)
+	// SetAssignmentContest records the mentee's contest for an assignment. It
+	// returns ErrAssignmentStarted when the assignment already has one.
+	SetAssignmentContest(id, contestID uuid.UUID) error
+	// FindAssignmentProgress reports the mentee's progress on each assignment, by assignment ID
+	FindAssignmentProgress(assignments []MentorAssignment) (map[uuid.UUID]AssignmentProgress, error)
+
+	AddAudit(entry *MentorshipAuditEntry) error
+	FindAudit(mentorshipID uuid.UUID, limit int) ([]MentorshipAuditEntry, error) // Newest first
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) MentorshipRepository
+}
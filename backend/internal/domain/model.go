@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Entity IDs are generated in the application instead of with a database default
+// (gen_random_uuid), so rows can be created on every supported database driver.
+
+func (u *User) BeforeCreate(*gorm.DB) error {
+	u.ID = ensureID(u.ID)
+	return nil
+}
+
+func (p *Problem) BeforeCreate(*gorm.DB) error {
+	p.ID = ensureID(p.ID)
+	return nil
+}
+
+func (c *Contest) BeforeCreate(*gorm.DB) error {
+	c.ID = ensureID(c.ID)
+	return nil
+}
+
+func (s *Submission) BeforeCreate(*gorm.DB) error {
+	s.ID = ensureID(s.ID)
+	return nil
+}
+
+func (a *Attempt) BeforeCreate(*gorm.DB) error {
+	a.ID = ensureID(a.ID)
+	return nil
+}
+
+func (m *ChatMessage) BeforeCreate(*gorm.DB) error {
+	m.ID = ensureID(m.ID)
+	return nil
+}
+
+func (l *QuickCommandLog) BeforeCreate(*gorm.DB) error {
+	l.ID = ensureID(l.ID)
+	return nil
+}
+
+func (c *RoadmapCategory) BeforeCreate(*gorm.DB) error {
+	c.ID = ensureID(c.ID)
+	return nil
+}
+
+func (f *SavedFilter) BeforeCreate(*gorm.DB) error {
+	f.ID = ensureID(f.ID)
+	return nil
+}
+
+func (c *ContestChallenge) BeforeCreate(*gorm.DB) error {
+	c.ID = ensureID(c.ID)
+	return nil
+}
+
+func (o *Organization) BeforeCreate(*gorm.DB) error {
+	o.ID = ensureID(o.ID)
+	return nil
+}
+
+func (i *OrgInvite) BeforeCreate(*gorm.DB) error {
+	i.ID = ensureID(i.ID)
+	return nil
+}
+
+func (a *OrgAssignment) BeforeCreate(*gorm.DB) error {
+	a.ID = ensureID(a.ID)
+	return nil
+}
+
+func (m *Mentorship) BeforeCreate(*gorm.DB) error {
+	m.ID = ensureID(m.ID)
+	return nil
+}
+
+func (e *MentorshipAuditEntry) BeforeCreate(*gorm.DB) error {
+	e.ID = ensureID(e.ID)
+	return nil
+}
+
+func (a *MentorAssignment) BeforeCreate(*gorm.DB) error {
+	a.ID = ensureID(a.ID)
+	return nil
+}
+
+func (e *ContestEvent) BeforeCreate(*gorm.DB) error {
+	e.ID = ensureID(e.ID)
+	return nil
+}
+
+func (d *RecommendationDigest) BeforeCreate(*gorm.DB) error {
+	d.ID = ensureID(d.ID)
+	return nil
+}
+
+func (i *DigestItem) BeforeCreate(*gorm.DB) error {
+	i.ID = ensureID(i.ID)
+	return nil
+}
+
+func (f *SimilarityFlag) BeforeCreate(*gorm.DB) error {
+	f.ID = ensureID(f.ID)
+	return nil
+}
+
+func (t *Tenant) BeforeCreate(*gorm.DB) error {
+	t.ID = ensureID(t.ID)
+	return nil
+}
+
+func (l *ProblemList) BeforeCreate(*gorm.DB) error {
+	l.ID = ensureID(l.ID)
+	return nil
+}
+
+// Feed item IDs are UUIDv7 so that they sort by creation, which is the order
+// the feed is paged in
+func (i *FeedItem) BeforeCreate(*gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.Must(uuid.NewV7())
+	}
+	return nil
+}
+
+func ensureID(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+	return id
+}
+
+// StringList is a list of strings stored as a native text[] column on Postgres
+// and as a JSON array in a text column on other databases (SQLite)
+type StringList []string
+
+// GormDataType marks the list as a column rather than an association
+func (StringList) GormDataType() string {
+	return "text"
+}
+
+// GormDBDataType returns the column type for the connected database
+func (StringList) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "text[]"
+	}
+	return "text"
+}
+
+// GormValue encodes the list for the connected database
+func (l StringList) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if db.Dialector.Name() == "postgres" {
+		value, err := pq.StringArray(l).Value()
+		if err != nil {
+			_ = db.AddError(err)
+		}
+		return clause.Expr{SQL: "?", Vars: []interface{}{value}}
+	}
+
+	if l == nil {
+		l = StringList{}
+	}
+	data, err := json.Marshal([]string(l))
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{string(data)}}
+}
+
+// Value encodes the list as a Postgres array literal when used outside GORM
+func (l StringList) Value() (driver.Value, error) {
+	return pq.StringArray(l).Value()
+}
+
+// Scan decodes either a Postgres array literal or a JSON array
+func (l *StringList) Scan(src interface{}) error {
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", src)
+	}
+
+	if strings.HasPrefix(raw, "[") {
+		return json.Unmarshal([]byte(raw), (*[]string)(l))
+	}
+
+	var arr pq.StringArray
+	if err := arr.Scan([]byte(raw)); err != nil {
+		return err
+	}
+	*l = StringList(arr)
+	return nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NoteKind names what a note search result was found in
+type NoteKind string
+
+const (
+	NoteKindRetro    NoteKind = "retro"    // The retro notes of a contest
+	NoteKindSolution NoteKind = "solution" // A solution snippet attached to a contest problem
+)
+
+// NoteMatch is a note of a user matching a search, as the database found it
+type NoteMatch struct {
+	Kind         NoteKind
+	ContestID    uuid.UUID
+	ProblemID    *uuid.UUID // Nil for retros
+	ProblemTitle string
+	Text         string
+	UpdatedAt    time.Time
+	Rank         float64 // Text search rank on Postgres; 0 where matches are not ranked
+}
+
+// NoteSearchQuery is the query of the note search endpoint
+type NoteSearchQuery struct {
+	Q     string `form:"q" binding:"required,min=1,max=200"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// NoteHighlight marks a matched word of a result's excerpt, in characters
+type NoteHighlight struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// NoteSearchResult is one of the user's notes matching a search, with the
+// part of it around the first match
+type NoteSearchResult struct {
+	Kind         NoteKind        `json:"kind"`
+	ContestID    uuid.UUID       `json:"contest_id"`
+	ProblemID    *uuid.UUID      `json:"problem_id,omitempty"`
+	ProblemTitle string          `json:"problem_title,omitempty"`
+	Excerpt      string          `json:"excerpt"` // Elided with "…" where the note goes on
+	Highlights   []NoteHighlight `json:"highlights"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// NoteSearchResponse lists the notes matching a search, most relevant first
+type NoteSearchResponse struct {
+	Query   string             `json:"query"`
+	Results []NoteSearchResult `json:"results"`
+	Count   int                `json:"count"`
+}
+
+// NoteSearchRepository defines the interface for searching the text users
+// wrote: contest retros and solution snippets
+type NoteSearchRepository interface {
+	// Search lists up to limit of the user's retros and snippets containing
+	// every word of query, most relevant first
+	Search(userID uuid.UUID, query string, limit int) ([]NoteMatch, error)
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) NoteSearchRepository
+}
@@ -0,0 +1,310 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgRole is a member's role within an organization
+type OrgRole string
+
+const (
+	OrgRoleInstructor OrgRole = "instructor" // Invites students, assigns work and sees the roster
+	OrgRoleStudent    OrgRole = "student"
+)
+
+// Organization is a class or team: instructors invite students and assign
+// contests or study plans to all of them at once
+type Organization struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null"`
+	OwnerID   uuid.UUID `json:"owner_id" gorm:"type:uuid;not null;index"`
+	TenantID  uuid.UUID `json:"-" gorm:"type:uuid;not null;default:'00000000-0000-0000-0000-000000000000';index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// OrgMember is a user's membership in an organization
+type OrgMember struct {
+	OrgID    uuid.UUID `json:"org_id" gorm:"type:uuid;primaryKey"`
+	UserID   uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	Role     OrgRole   `json:"role" gorm:"type:varchar(16);not null"`
+	JoinedAt time.Time `json:"joined_at" gorm:"not null"`
+
+	// Relationships
+	Organization Organization `json:"-" gorm:"foreignKey:OrgID;constraint:OnDelete:CASCADE"`
+	User         User         `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (OrgMember) TableName() string {
+	return "org_members"
+}
+
+// IsInstructor reports whether the member can manage the organization
+func (m *OrgMember) IsInstructor() bool {
+	return m.Role == OrgRoleInstructor
+}
+
+// OrgInvite is a join code an instructor shares with a class. Anyone with the
+// code can join with its role until it expires or is revoked.
+type OrgInvite struct {
+	ID        uuid.UUID `json:"-" gorm:"type:uuid;primary_key"`
+	OrgID     uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"`
+	Code      string    `json:"code" gorm:"type:varchar(32);uniqueIndex;not null"`
+	Role      OrgRole   `json:"role" gorm:"type:varchar(16);not null"`
+	CreatedBy uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Organization Organization `json:"-" gorm:"foreignKey:OrgID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (OrgInvite) TableName() string {
+	return "org_invites"
+}
+
+// IsExpired reports whether the invite can no longer be used
+func (i *OrgInvite) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// AssignmentKind is what an organization assignment asks students to do
+type AssignmentKind string
+
+const (
+	AssignmentContest    AssignmentKind = "contest"     // Each student runs a contest with the given settings
+	AssignmentStudyPlan  AssignmentKind = "study_plan"  // Each student solves every problem of a roadmap category
+	AssignmentProblemSet AssignmentKind = "problem_set" // Each student is given a pending contest of a fixed list of problems
+)
+
+// OrgAssignment is work assigned to every student of an organization
+type OrgAssignment struct {
+	ID         uuid.UUID             `json:"id" gorm:"type:uuid;primary_key"`
+	OrgID      uuid.UUID             `json:"org_id" gorm:"type:uuid;not null;index"`
+	Kind       AssignmentKind        `json:"kind" gorm:"type:varchar(16);not null"`
+	Title      string                `json:"title" gorm:"type:varchar(100);not null"`
+	Contest    *CreateContestRequest `json:"contest,omitempty" gorm:"type:text;serializer:json"`     // Settings of a contest assignment
+	CategoryID *uuid.UUID            `json:"category_id,omitempty" gorm:"type:uuid"`                 // Roadmap category of a study plan
+	ProblemIDs []uuid.UUID           `json:"problem_ids,omitempty" gorm:"type:text;serializer:json"` // Problems of a problem set, in order
+	// Contest duration of a problem set
+	DurationMinutes int        `json:"duration_minutes,omitempty"`
+	DueAt           *time.Time `json:"due_at"`
+	CreatedBy       uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Relationships
+	Organization Organization `json:"-" gorm:"foreignKey:OrgID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (OrgAssignment) TableName() string {
+	return "org_assignments"
+}
+
+// OrgAssignmentContest links a student to their contest for an assignment: the
+// one they started for a contest assignment, or the pending one they were given
+// for a problem set
+type OrgAssignmentContest struct {
+	AssignmentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ContestID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+
+	// Relationships
+	Assignment OrgAssignment `gorm:"foreignKey:AssignmentID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (OrgAssignmentContest) TableName() string {
+	return "org_assignment_contests"
+}
+
+// AssignmentStatus is a student's progress on an assignment
+type AssignmentStatus string
+
+const (
+	AssignmentNotStarted AssignmentStatus = "not_started"
+	AssignmentInProgress AssignmentStatus = "in_progress"
+	AssignmentCompleted  AssignmentStatus = "completed"
+	AssignmentAbandoned  AssignmentStatus = "abandoned" // The contest was abandoned; it cannot be restarted
+)
+
+// AssignmentProgress is one student's progress on one assignment
+type AssignmentProgress struct {
+	AssignmentID uuid.UUID        `json:"assignment_id"`
+	UserID       uuid.UUID        `json:"user_id"`
+	Status       AssignmentStatus `json:"status"`
+	ContestID    *uuid.UUID       `json:"contest_id,omitempty"` // Contest assignments once started, and problem sets
+	Solved       int              `json:"solved"`
+	Total        int              `json:"total"`
+	StartedAt    *time.Time       `json:"started_at,omitempty"` // When the contest timer started
+	EndedAt      *time.Time       `json:"ended_at,omitempty"`
+}
+
+// OrgSummary is an organization in the list of the caller's organizations
+type OrgSummary struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Role        OrgRole   `json:"role"` // The caller's role
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// OrgMemberResponse is a member in an organization's details
+type OrgMemberResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Role     OrgRole   `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// OrgResponse is an organization with its members
+type OrgResponse struct {
+	ID      uuid.UUID           `json:"id"`
+	Name    string              `json:"name"`
+	OwnerID uuid.UUID           `json:"owner_id"`
+	Role    OrgRole             `json:"role"` // The caller's role
+	Members []OrgMemberResponse `json:"members"`
+	// Open invites; instructors only
+	Invites   []OrgInvite `json:"invites,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// OrgAssignmentResponse is an assignment with the caller's own progress and,
+// for instructors, how many students completed it
+type OrgAssignmentResponse struct {
+	OrgAssignment
+	Status         AssignmentStatus `json:"status"`
+	ContestID      *uuid.UUID       `json:"contest_id,omitempty"`
+	Solved         int              `json:"solved"`
+	Total          int              `json:"total"`
+	Overdue        bool             `json:"overdue"`                   // Past due and not completed
+	CompletedCount *int             `json:"completed_count,omitempty"` // Instructors only
+}
+
+// OrgRosterMember is one student's row in the roster dashboard
+type OrgRosterMember struct {
+	MemberProgress
+	JoinedAt             time.Time `json:"joined_at"`
+	AssignmentsCompleted int       `json:"assignments_completed"`
+	AssignmentsOverdue   int       `json:"assignments_overdue"`
+}
+
+// OrgRoster is one page of the roster progress dashboard, ordered by username
+type OrgRoster struct {
+	Members     []OrgRosterMember `json:"members"`
+	Assignments int               `json:"assignments"` // Assignments every student has
+	Total       int64             `json:"total"`       // Students across all pages
+	Limit       int               `json:"limit"`
+	Offset      int               `json:"offset"`
+}
+
+// AssignmentReportRow is one student's row in an assignment report
+type AssignmentReportRow struct {
+	AssignmentProgress
+	Username string `json:"username"`
+	Overdue  bool   `json:"overdue"` // Past due and not completed
+	Late     bool   `json:"late"`    // Completed after the due date
+
+	// Focus losses and tab switches the client reported during the contest
+	ProctorCounts
+}
+
+// AssignmentReport is every student's progress on one assignment, by username
+type AssignmentReport struct {
+	Assignment OrgAssignment         `json:"assignment"`
+	Members    []AssignmentReportRow `json:"members"`
+	NotStarted int                   `json:"not_started"`
+	InProgress int                   `json:"in_progress"`
+	Completed  int                   `json:"completed"`
+	Abandoned  int                   `json:"abandoned"`
+	Overdue    int                   `json:"overdue"`
+}
+
+// CreateOrgRequest is the body of the create organization endpoint
+type CreateOrgRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// CreateOrgInviteRequest is the optional body of the create invite endpoint
+type CreateOrgInviteRequest struct {
+	Role OrgRole `json:"role" binding:"omitempty,oneof=instructor student"` // Defaults to student
+}
+
+// JoinOrgRequest is the body of the join organization endpoint
+type JoinOrgRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// CreateAssignmentRequest is the body of the create assignment endpoint
+type CreateAssignmentRequest struct {
+	Kind       AssignmentKind        `json:"kind" binding:"required,oneof=contest study_plan problem_set"`
+	Title      string                `json:"title" binding:"required,min=1,max=100"`
+	Contest    *CreateContestRequest `json:"contest" binding:"required_if=Kind contest"`
+	CategoryID *uuid.UUID            `json:"category_id" binding:"required_if=Kind study_plan"`
+	// Problem IDs or slugs of a problem set, in order
+	Problems        []string   `json:"problems" binding:"required_if=Kind problem_set,max=20,dive,min=1,max=255"`
+	DurationMinutes int        `json:"duration_minutes" binding:"required_if=Kind problem_set,omitempty,min=10,max=300"`
+	DueAt           *time.Time `json:"due_at" binding:"required_if=Kind problem_set"`
+}
+
+// OrgRosterQuery is the query of the roster dashboard endpoint
+type OrgRosterQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=200"` // Defaults to 50
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// AssignmentReportQuery is the query of the assignment report endpoint
+type AssignmentReportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv"` // Defaults to json
+}
+
+// OrgRepository defines the interface for organization data access
+type OrgRepository interface {
+	// Create creates an organization with its owner as the first instructor
+	Create(org *Organization, owner *OrgMember) error
+	FindByID(id uuid.UUID) (*Organization, error)
+	// FindForUser lists the organizations the user belongs to, by name
+	FindForUser(userID uuid.UUID) ([]OrgSummary, error)
+
+	FindMember(orgID, userID uuid.UUID) (*OrgMember, error) // Returns ErrOrgNotFound for non-members
+	FindMembers(orgID uuid.UUID) ([]OrgMemberResponse, error)
+	FindMemberIDs(orgID uuid.UUID, role OrgRole) ([]uuid.UUID, error)
+	CountMembers(orgID uuid.UUID) (int64, error)
+	AddMember(member *OrgMember) error // Returns ErrAlreadyOrgMember for existing members
+	RemoveMember(orgID, userID uuid.UUID) error
+
+	CreateInvite(invite *OrgInvite) error
+	FindInviteByCode(code string) (*OrgInvite, error)
+	FindOpenInvites(orgID uuid.UUID, now time.Time) ([]OrgInvite, error)
+	DeleteInvite(orgID uuid.UUID, code string) error
+
+	CreateAssignment(assignment *OrgAssignment) error
+	FindAssignment(orgID, id uuid.UUID) (*OrgAssignment, error)
+	FindAssignments(orgID uuid.UUID) ([]OrgAssignment, error) // Newest first
+	DeleteAssignment(orgID, id uuid.UUID) error
+	// LinkContest records a student's contest for an assignment. It returns
+	// ErrAssignmentStarted when the student already has one.
+	LinkContest(link *OrgAssignmentContest) error
+	// FindPendingContestIDs lists the linked contests of the organization that
+	// were not started yet, of one assignment and/or one user when given
+	FindPendingContestIDs(orgID uuid.UUID, assignmentID, userID *uuid.UUID) ([]uuid.UUID, error)
+	// FindContestLink finds the assignment a contest was given for, with the
+	// assignment loaded; ErrAssignmentNotFound when it was given for none
+	FindContestLink(contestID uuid.UUID) (*OrgAssignmentContest, error)
+	// FindProgress reports each user's progress on each assignment of the organization
+	FindProgress(orgID uuid.UUID, userIDs []uuid.UUID) ([]AssignmentProgress, error)
+	// CategoryExists reports whether a roadmap category exists
+	CategoryExists(id uuid.UUID) (bool, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) OrgRepository
+}
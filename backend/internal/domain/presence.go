@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PresenceStatus is whether a user is around, as seen from their heartbeats
+type PresenceStatus string
+
+const (
+	PresenceOffline   PresenceStatus = "offline"
+	PresenceOnline    PresenceStatus = "online"
+	PresenceInContest PresenceStatus = "in_contest" // Online with an active contest open
+)
+
+// UserPresence is the latest heartbeat of a user. Rows live in the database so
+// every instance sees the same presence, and stale ones are swept periodically.
+type UserPresence struct {
+	UserID     uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ContestID  *uuid.UUID `gorm:"type:uuid"` // Active contest open when the heartbeat was sent
+	LastSeenAt time.Time  `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (UserPresence) TableName() string {
+	return "user_presence"
+}
+
+// Presence is a user's presence in API responses
+type Presence struct {
+	Status     PresenceStatus `json:"status"`
+	ContestID  *uuid.UUID     `json:"contest_id,omitempty"`
+	LastSeenAt *time.Time     `json:"last_seen_at,omitempty"`
+}
+
+// Presence reports the user as online while the latest heartbeat is younger
+// than ttl; a nil row or an older heartbeat means offline
+func (p *UserPresence) Presence(now time.Time, ttl time.Duration) Presence {
+	if p == nil || now.Sub(p.LastSeenAt) > ttl {
+		return Presence{Status: PresenceOffline}
+	}
+	lastSeen := p.LastSeenAt
+	presence := Presence{Status: PresenceOnline, LastSeenAt: &lastSeen}
+	if p.ContestID != nil {
+		presence.Status = PresenceInContest
+		presence.ContestID = p.ContestID
+	}
+	return presence
+}
+
+// HeartbeatRequest is the body of the heartbeat endpoint
+type HeartbeatRequest struct {
+	ContestID *uuid.UUID `json:"contest_id"` // The contest the user has open, if any
+}
+
+// PresenceRepository defines the interface for presence data access
+type PresenceRepository interface {
+	// Touch records a heartbeat, replacing the user's previous one
+	Touch(userID uuid.UUID, contestID *uuid.UUID, seenAt time.Time) error
+	FindByUserIDs(userIDs []uuid.UUID) ([]UserPresence, error)
+	// DeleteSeenBefore removes heartbeats older than cutoff and returns how many were removed
+	DeleteSeenBefore(cutoff time.Time) (int64, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) PresenceRepository
+}
@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"context"
+	"encoding/xml"
+	"sort"
+	"strings"
+
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 )
 
 // Difficulty represents the difficulty level of a problem
@@ -14,6 +18,9 @@ const (
 	DifficultyHard   Difficulty = "Hard"
 )
 
+// AllDifficulties lists every difficulty, easiest first
+var AllDifficulties = []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard}
+
 // DifficultyWeight returns a numeric weight for sorting by difficulty
 func (d Difficulty) Weight() int {
 	switch d {
@@ -28,20 +35,37 @@ func (d Difficulty) Weight() int {
 	}
 }
 
-// Problem represents a coding problem from NeetCode 150
+// Problem represents a coding problem from NeetCode 150, or a user's private
+// custom problem when OwnerID is set. Catalog queries only return problems without an owner.
 type Problem struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Title       string         `json:"title" gorm:"not null"`
-	Slug        string         `json:"slug" gorm:"uniqueIndex;not null"`
-	Difficulty  Difficulty     `json:"difficulty" gorm:"type:varchar(10);not null"`
-	Topics      pq.StringArray `json:"topics" gorm:"type:text[]"`
-	LeetCodeURL string         `json:"leetcode_url" gorm:"not null"`
-	NeetCodeURL string         `json:"neetcode_url"`
-	OrderIndex  int            `json:"order_index" gorm:"not null"` // Original order in NeetCode 150
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	Title       string     `json:"title" gorm:"not null"`
+	Slug        string     `json:"slug" gorm:"uniqueIndex;not null"`
+	Difficulty  Difficulty `json:"difficulty" gorm:"type:varchar(10);not null"`
+	Topics      StringList `json:"topics"`
+	LeetCodeURL string     `json:"leetcode_url" gorm:"not null"`
+	NeetCodeURL string     `json:"neetcode_url"`
+	OrderIndex  int        `json:"order_index" gorm:"not null"`               // Original order in NeetCode 150
+	Importance  int        `json:"importance" gorm:"not null;default:0"`      // 1-100 interview frequency score; 0 until seeded
+	OwnerID     *uuid.UUID `json:"owner_id,omitempty" gorm:"type:uuid;index"` // Set for a user's private custom problem
+
+	// TenantID is the tenant the problem was added in; the default tenant's
+	// catalog is shared with every tenant
+	TenantID uuid.UUID `json:"-" gorm:"type:uuid;not null;default:'00000000-0000-0000-0000-000000000000';index"`
+
+	// Usage counters maintained from contest events
+	TimesSelected  int64 `json:"times_selected" gorm:"not null;default:0"`
+	TimesCompleted int64 `json:"times_completed" gorm:"not null;default:0"`
+
+	// Canonical complexity of the optimal solution, maintained by admins and
+	// only revealed in contest results
+	TimeComplexity  string `json:"-" gorm:"type:varchar(32);not null;default:''"`
+	SpaceComplexity string `json:"-" gorm:"type:varchar(32);not null;default:''"`
 
 	// Relationships
 	ContestProblems []ContestProblem `json:"-" gorm:"foreignKey:ProblemID"`
 	Submissions     []Submission     `json:"-" gorm:"foreignKey:ProblemID"`
+	Companies       []ProblemCompany `json:"-" gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE"`
 }
 
 // TableName specifies the table name for GORM
@@ -49,6 +73,122 @@ func (Problem) TableName() string {
 	return "problems"
 }
 
+// DefaultImportance is the selection weight of a problem without an importance score
+const DefaultImportance = 50
+
+// SelectionWeight returns the weight of the problem in importance-weighted selection
+func (p *Problem) SelectionWeight() int {
+	if p.Importance <= 0 {
+		return DefaultImportance
+	}
+	return p.Importance
+}
+
+// SetProblemImportanceRequest tunes the importance score of a problem
+type SetProblemImportanceRequest struct {
+	Importance int `json:"importance" binding:"required,min=1,max=100"`
+}
+
+// MaxCompaniesPerProblem caps how many company tags a problem can carry
+const MaxCompaniesPerProblem = 20
+
+// ProblemCompany tags a problem with a company known to ask it in interviews
+type ProblemCompany struct {
+	ProblemID uuid.UUID `json:"problem_id" gorm:"type:uuid;primaryKey"`
+	Company   string    `json:"company" gorm:"type:varchar(64);primaryKey;index"`
+}
+
+// TableName specifies the table name for GORM
+func (ProblemCompany) TableName() string {
+	return "problem_companies"
+}
+
+// CompanyCount is a company with the number of problems tagged with it
+type CompanyCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// CompanyNames returns the names of the companies the problem is tagged with
+func (p *Problem) CompanyNames() []string {
+	names := make([]string, len(p.Companies))
+	for i, c := range p.Companies {
+		names[i] = c.Company
+	}
+	return names
+}
+
+// HasCompany reports whether the problem is tagged with any of the companies (case-insensitive)
+func (p *Problem) HasCompany(companies []string) bool {
+	for _, want := range companies {
+		for _, c := range p.Companies {
+			if strings.EqualFold(c.Company, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NormalizeCompanies trims and collapses whitespace in company names, drops empty
+// and case-insensitive duplicates (keeping the first spelling), and sorts the result
+func NormalizeCompanies(companies []string) []string {
+	seen := make(map[string]struct{}, len(companies))
+	normalized := make([]string, 0, len(companies))
+	for _, c := range companies {
+		c = strings.Join(strings.Fields(c), " ")
+		if c == "" {
+			continue
+		}
+		key := strings.ToLower(c)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		normalized = append(normalized, c)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// SetProblemCompaniesRequest replaces the company tags of a problem
+type SetProblemCompaniesRequest struct {
+	Companies []string `json:"companies" binding:"max=20,dive,min=1,max=64"`
+}
+
+// MaxBatchProblems caps the IDs and slugs of one batch lookup at the size of the catalog
+const MaxBatchProblems = 150
+
+// ProblemBatchRequest looks up several problems at once. Each key is a problem
+// ID or a catalog slug.
+type ProblemBatchRequest struct {
+	Keys []string `json:"keys" binding:"required,min=1,max=150,dive,min=1,max=128"`
+}
+
+// ProblemBatchResponse holds the problems of a batch lookup in request order,
+// and the keys that matched no visible problem
+type ProblemBatchResponse struct {
+	Problems []ProblemResponse `json:"problems"`
+	Count    int               `json:"count"`
+	NotFound []string          `json:"not_found"`
+}
+
+// ProblemPrerequisite records that a problem should be solved before another one
+// (e.g. "Two Sum" before "3Sum")
+type ProblemPrerequisite struct {
+	ProblemID      uuid.UUID `json:"problem_id" gorm:"type:uuid;primaryKey"`
+	PrerequisiteID uuid.UUID `json:"prerequisite_id" gorm:"type:uuid;primaryKey;index"`
+
+	// Relationships
+	Problem      Problem `json:"-" gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE"`
+	Prerequisite Problem `json:"-" gorm:"foreignKey:PrerequisiteID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (ProblemPrerequisite) TableName() string {
+	return "problem_prerequisites"
+}
+
 // ProblemRepository defines the interface for problem data access
 type ProblemRepository interface {
 	Create(problem *Problem) error
@@ -56,11 +196,44 @@ type ProblemRepository interface {
 	FindByID(id uuid.UUID) (*Problem, error)
 	FindBySlug(slug string) (*Problem, error)
 	FindAll() ([]Problem, error)
+	// FindSearchCandidates returns the catalog problems whose title or a topic may
+	// match the lowercased query, a superset that the caller ranks
+	FindSearchCandidates(query string) ([]Problem, error)
+	// FindByIDsOrSlugs returns the problems with any of the IDs, and the catalog problems with any of the slugs
+	FindByIDsOrSlugs(ids []uuid.UUID, slugs []string) ([]Problem, error)
 	FindByDifficulty(difficulty Difficulty) ([]Problem, error)
 	FindByTopics(topics []string) ([]Problem, error)
 	FindUnsolvedByUser(userID uuid.UUID) ([]Problem, error)
-	FindUnsolvedByUserAndDifficulty(userID uuid.UUID, difficulty Difficulty) ([]Problem, error)
+	// FindUnsolvedByUserAndDifficulty also returns the user's own custom problems when includeCustom is set
+	FindUnsolvedByUserAndDifficulty(userID uuid.UUID, difficulty Difficulty, includeCustom bool) ([]Problem, error)
+	// FindSolvedByUserAndDifficulty returns the catalog problems of a difficulty the user has solved
+	FindSolvedByUserAndDifficulty(userID uuid.UUID, difficulty Difficulty) ([]Problem, error)
+	FindRecentlyServedIDs(userID uuid.UUID, lastContests int) ([]uuid.UUID, error)
+	// FindRecentSolveCounts counts the problems served and solved in the user's last finished contests
+	FindRecentSolveCounts(userID uuid.UUID, lastContests int) (served, solved int64, err error)
+	// FindRecentSolveConfidence counts the problems solved in those contests per confidence rating
+	FindRecentSolveConfidence(userID uuid.UUID, lastContests int) (map[int]int64, error)
 	Count() (int64, error)
+	IncrementTimesSelected(ids []uuid.UUID) error
+	AddTimesCompleted(id uuid.UUID, delta int) error
+	FindPrerequisites(problemID uuid.UUID) ([]Problem, error)
+	FindLockedIDsByUser(userID uuid.UUID) ([]uuid.UUID, error)
+	FindIDsByCompanies(companies []string) ([]uuid.UUID, error)
+	FindCompanies() ([]CompanyCount, error)
+	SetCompanies(problemID uuid.UUID, companies []string) error
+	SetImportance(id uuid.UUID, importance int) error
+	SetComplexity(id uuid.UUID, timeComplexity, spaceComplexity string) error
+
+	// Custom problems, always scoped to their owner
+	FindByOwner(ownerID uuid.UUID) ([]Problem, error)
+	FindByOwnerAndURL(ownerID uuid.UUID, url string) (*Problem, error) // Returns nil, nil when none exists
+	CountByOwner(ownerID uuid.UUID) (int64, error)
+	Update(problem *Problem) error
+	Delete(id uuid.UUID) error
+	IsReferenced(id uuid.UUID) (bool, error) // Whether any contest or submission uses the problem
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ProblemRepository
 }
 
 // ProblemResponse represents a problem in API responses
@@ -72,6 +245,47 @@ type ProblemResponse struct {
 	Topics      []string   `json:"topics"`
 	LeetCodeURL string     `json:"leetcode_url"`
 	NeetCodeURL string     `json:"neetcode_url"`
+	Companies   []string   `json:"companies,omitempty"`
+	Importance  int        `json:"importance"`
+	Custom      bool       `json:"custom,omitempty"`
+
+	Popularity *ProblemPopularity `json:"popularity,omitempty"`
+}
+
+// ProblemPopularity summarizes how often a problem is served and solved in contests
+type ProblemPopularity struct {
+	TimesSelected  int64   `json:"times_selected"`
+	TimesCompleted int64   `json:"times_completed"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// Popularity returns the problem's usage counters
+func (p *Problem) Popularity() ProblemPopularity {
+	popularity := ProblemPopularity{
+		TimesSelected:  p.TimesSelected,
+		TimesCompleted: p.TimesCompleted,
+	}
+	if p.TimesSelected > 0 {
+		popularity.CompletionRate = float64(p.TimesCompleted) / float64(p.TimesSelected)
+	}
+	return popularity
+}
+
+// ToResponseWithPopularity converts a Problem to a ProblemResponse including usage counters
+func (p *Problem) ToResponseWithPopularity() ProblemResponse {
+	resp := p.ToResponse()
+	popularity := p.Popularity()
+	resp.Popularity = &popularity
+	return resp
+}
+
+// ProblemCalibration is a row of the admin calibration view
+type ProblemCalibration struct {
+	ID         uuid.UUID         `json:"id"`
+	Title      string            `json:"title"`
+	Difficulty Difficulty        `json:"difficulty"`
+	Importance int               `json:"importance"`
+	Popularity ProblemPopularity `json:"popularity"`
 }
 
 // ToResponse converts a Problem to a ProblemResponse
@@ -84,14 +298,92 @@ func (p *Problem) ToResponse() ProblemResponse {
 		Topics:      p.Topics,
 		LeetCodeURL: p.LeetCodeURL,
 		NeetCodeURL: p.NeetCodeURL,
+		Companies:   p.CompanyNames(),
+		Importance:  p.Importance,
+		Custom:      p.IsCustom(),
 	}
 }
 
+// ProblemPrerequisitesResponse lists the direct prerequisites of a problem
+type ProblemPrerequisitesResponse struct {
+	ProblemID     uuid.UUID         `json:"problem_id"`
+	Prerequisites []ProblemResponse `json:"prerequisites"`
+	Count         int               `json:"count"`
+}
+
+// ProblemSearchQuery is the query of the problem search endpoint
+type ProblemSearchQuery struct {
+	Q     string `form:"q" binding:"required,min=1,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// SearchField names the part of a problem a search term matched
+type SearchField string
+
+const (
+	SearchFieldTitle SearchField = "title"
+	SearchFieldTopic SearchField = "topic"
+)
+
+// SearchHighlight marks a matched range of a problem field, in characters
+type SearchHighlight struct {
+	Field  SearchField `json:"field"`
+	Index  int         `json:"index"` // Position in the topics list; 0 for the title
+	Start  int         `json:"start"`
+	Length int         `json:"length"`
+}
+
+// ProblemSearchResult is a problem matching a search, with its relevance from 0 to 1
+type ProblemSearchResult struct {
+	Problem    ProblemResponse   `json:"problem"`
+	Score      float64           `json:"score"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// ProblemSearchResponse lists the matches of a search, most relevant first
+type ProblemSearchResponse struct {
+	Query   string                `json:"query"`
+	Results []ProblemSearchResult `json:"results"`
+	Count   int                   `json:"count"`
+}
+
+// ProblemPage is a catalog problem with the metadata a public, indexable page needs
+type ProblemPage struct {
+	Problem       ProblemResponse   `json:"problem"`
+	SEO           PageMetadata      `json:"seo"`
+	Prerequisites []ProblemResponse `json:"prerequisites"`
+}
+
+// PageMetadata holds the tags of a public page for search engines and link previews
+type PageMetadata struct {
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	CanonicalURL string   `json:"canonical_url"`
+	Keywords     []string `json:"keywords"`
+}
+
+// SitemapNamespace is the XML namespace of the sitemaps.org protocol
+const SitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// Sitemap lists the public pages of the frontend in the sitemaps.org format
+type Sitemap struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+// SitemapURL is one page of a Sitemap
+type SitemapURL struct {
+	Loc        string `xml:"loc"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"` // 0.0-1.0 relative to the site's other pages
+}
+
 // ProblemStats represents statistics about the problem set
 type ProblemStats struct {
-	Total      int            `json:"total"`
+	Total        int                `json:"total"`
 	ByDifficulty map[Difficulty]int `json:"by_difficulty"`
-	ByTopic    map[string]int `json:"by_topic"`
+	ByTopic      map[string]int     `json:"by_topic"`
 }
 
 // ProblemFilter represents filtering options for problem queries
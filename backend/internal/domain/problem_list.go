@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProblemListStatus is whether a community list can be found by other users
+type ProblemListStatus string
+
+const (
+	ProblemListPublished ProblemListStatus = "published"
+	ProblemListHidden    ProblemListStatus = "hidden" // By a moderator, or once enough users reported it; only its owner sees it
+)
+
+// ProblemListSort orders the community list browser
+type ProblemListSort string
+
+const (
+	ProblemListSortPopular ProblemListSort = "popular" // Most subscribers first (default)
+	ProblemListSortNewest  ProblemListSort = "newest"
+	ProblemListSortReports ProblemListSort = "reports" // Most reported first; the moderation queue's order
+)
+
+// ProblemList is a curated, ordered list of catalog problems a user published
+// for the community. Other users subscribe to it and build contests from it.
+type ProblemList struct {
+	ID           uuid.UUID         `json:"id" gorm:"type:uuid;primary_key"`
+	OwnerID      uuid.UUID         `json:"owner_id" gorm:"type:uuid;not null;index"`
+	TenantID     uuid.UUID         `json:"-" gorm:"type:uuid;not null;default:'00000000-0000-0000-0000-000000000000';index"`
+	Title        string            `json:"title" gorm:"type:varchar(100);not null"`
+	Description  string            `json:"description" gorm:"type:text;not null;default:''"`
+	ProblemIDs   []uuid.UUID       `json:"problem_ids" gorm:"type:text;serializer:json"` // In list order
+	ProblemCount int               `json:"problem_count" gorm:"not null;default:0"`
+	Status       ProblemListStatus `json:"status" gorm:"type:varchar(16);not null;default:'published';index"`
+	// HiddenReason tells the owner why the list was hidden
+	HiddenReason string    `json:"hidden_reason,omitempty" gorm:"type:varchar(200);not null;default:''"`
+	Subscribers  int       `json:"subscribers" gorm:"not null;default:0"`
+	Reports      int       `json:"reports" gorm:"not null;default:0"` // Since it was last published
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Relationships
+	Owner User `json:"-" gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (ProblemList) TableName() string {
+	return "problem_lists"
+}
+
+// ProblemListSubscription is a user's subscription to a community list
+type ProblemListSubscription struct {
+	ListID    uuid.UUID `json:"list_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	List ProblemList `json:"-" gorm:"foreignKey:ListID;constraint:OnDelete:CASCADE"`
+	User User        `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (ProblemListSubscription) TableName() string {
+	return "problem_list_subscriptions"
+}
+
+// ProblemListReport is a user's report of a community list to the moderators;
+// each user reports a list once
+type ProblemListReport struct {
+	ListID    uuid.UUID `json:"list_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Reason    string    `json:"reason" gorm:"type:varchar(500);not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	List ProblemList `json:"-" gorm:"foreignKey:ListID;constraint:OnDelete:CASCADE"`
+	User User        `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (ProblemListReport) TableName() string {
+	return "problem_list_reports"
+}
+
+// ProblemListRequest is the body of the publish and replace list endpoints
+type ProblemListRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+	// Problem IDs or catalog slugs, in order; custom problems cannot be listed
+	Problems []string `json:"problems" binding:"required,min=1,max=100,dive,min=1,max=255"`
+}
+
+// BrowseListsQuery holds the query parameters of the community list browser
+type BrowseListsQuery struct {
+	Query      string          `form:"q" binding:"omitempty,max=100"` // Case-insensitive match against title and description
+	Sort       ProblemListSort `form:"sort" binding:"omitempty,oneof=popular newest"`
+	Subscribed bool            `form:"subscribed"` // Only lists the caller subscribes to
+	Mine       bool            `form:"mine"`       // Only the caller's own lists, hidden ones included
+	Limit      int             `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int             `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ProblemListFilter selects lists for the repository; OwnerID and
+// SubscriberID narrow to one user's lists when set
+type ProblemListFilter struct {
+	Query        string
+	Sort         ProblemListSort
+	Status       ProblemListStatus // Empty matches every status
+	OwnerID      *uuid.UUID
+	SubscriberID *uuid.UUID
+	Reported     bool // Only lists with reports
+	Limit        int
+	Offset       int
+}
+
+// ProblemListSummary is a community list in the list browser
+type ProblemListSummary struct {
+	ID            uuid.UUID         `json:"id"`
+	OwnerID       uuid.UUID         `json:"owner_id"`
+	OwnerUsername string            `json:"owner_username"`
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	ProblemCount  int               `json:"problem_count"`
+	Subscribers   int               `json:"subscribers"`
+	Subscribed    bool              `json:"subscribed"` // The caller subscribes to it
+	Status        ProblemListStatus `json:"status"`
+	HiddenReason  string            `json:"hidden_reason,omitempty"`
+	Reports       int               `json:"reports,omitempty"` // Owners and moderators only
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// ProblemListPage is one page of the list browser
+type ProblemListPage struct {
+	Lists  []ProblemListSummary `json:"lists"`
+	Total  int64                `json:"total"` // Lists across all pages
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// ProblemListProblem is a problem of a list with whether the caller solved it
+type ProblemListProblem struct {
+	ProblemResponse
+	Position int  `json:"position"`
+	Solved   bool `json:"solved"`
+}
+
+// ProblemListResponse is a community list with its problems in order.
+// Problems deleted from the catalog since they were listed are left out.
+type ProblemListResponse struct {
+	ProblemListSummary
+	Problems []ProblemListProblem `json:"problems"`
+	Solved   int                  `json:"solved"` // Listed problems the caller solved
+}
+
+// CreateListContestRequest is the body of the build contest from list endpoint
+type CreateListContestRequest struct {
+	ProblemCount    int `json:"problem_count" binding:"required,min=1,max=20"`
+	DurationMinutes int `json:"duration_minutes" binding:"required,min=10,max=300"`
+	// IncludeSolved starts from the top of the list instead of skipping the
+	// problems the caller already solved
+	IncludeSolved bool `json:"include_solved"`
+}
+
+// ReportProblemListRequest is the body of the report list endpoint
+type ReportProblemListRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500"`
+}
+
+// ModerationListQuery holds the query parameters of the moderation queue
+type ModerationListQuery struct {
+	Status ProblemListStatus `form:"status" binding:"omitempty,oneof=published hidden"`
+	Limit  int               `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int               `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ModerateProblemListRequest is the body of the moderator's list endpoint
+type ModerateProblemListRequest struct {
+	Status ProblemListStatus `json:"status" binding:"required,oneof=published hidden"`
+	Reason string            `json:"reason" binding:"max=200"` // Shown to the owner of a hidden list
+}
+
+// ProblemListRepository defines the interface for community list data access
+type ProblemListRepository interface {
+	Create(list *ProblemList) error
+	FindByID(id uuid.UUID) (*ProblemList, error)
+	// FindPage lists a page of the lists matching the filter with the total,
+	// each marked with whether viewerID subscribes to it
+	FindPage(filter ProblemListFilter, viewerID uuid.UUID) ([]ProblemListSummary, int64, error)
+	// FindSummary returns one list as the browser shows it to viewerID
+	FindSummary(id, viewerID uuid.UUID) (*ProblemListSummary, error)
+	Update(list *ProblemList) error
+	Delete(id uuid.UUID) error
+	// Subscribe subscribes the user, counting a new subscriber; subscribing
+	// again changes nothing
+	Subscribe(listID, userID uuid.UUID) error
+	// Unsubscribe removes the user's subscription, if any
+	Unsubscribe(listID, userID uuid.UUID) error
+	IsSubscribed(listID, userID uuid.UUID) (bool, error)
+	// AddReport records the user's report and returns the list's report count,
+	// failing with ErrListAlreadyReported when they reported it before
+	AddReport(report *ProblemListReport) (int, error)
+	// SetStatus publishes or hides a list; publishing clears its reports
+	SetStatus(id uuid.UUID, status ProblemListStatus, reason string) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ProblemListRepository
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProctorEventType is a focus or visibility change the client reports during a contest
+type ProctorEventType string
+
+const (
+	ProctorFocusLost   ProctorEventType = "focus_lost"   // The contest window lost focus
+	ProctorFocusGained ProctorEventType = "focus_gained" // The contest window got focus back
+	ProctorTabHidden   ProctorEventType = "tab_hidden"   // The contest tab was switched away from
+	ProctorTabVisible  ProctorEventType = "tab_visible"  // The contest tab was shown again
+)
+
+// IsAway reports whether the event starts a period away from the contest
+func (t ProctorEventType) IsAway() bool {
+	return t == ProctorFocusLost || t == ProctorTabHidden
+}
+
+// ContestEvent is a focus or visibility change reported by the client during
+// an organization assignment contest. The client's timestamp is kept next to
+// the time it reached the server, so delayed batches can be told apart.
+type ContestEvent struct {
+	ID         uuid.UUID        `json:"id" gorm:"type:uuid;primary_key"`
+	ContestID  uuid.UUID        `json:"contest_id" gorm:"type:uuid;not null;index:idx_contest_events_occurred,priority:1"`
+	Type       ProctorEventType `json:"type" gorm:"type:varchar(16);not null"`
+	OccurredAt time.Time        `json:"occurred_at" gorm:"not null;index:idx_contest_events_occurred,priority:2"`
+	ReceivedAt time.Time        `json:"received_at" gorm:"not null"`
+
+	// Relationships
+	Contest Contest `json:"-" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (ContestEvent) TableName() string {
+	return "contest_events"
+}
+
+// ProctorCounts is how often a contest lost focus and switched tabs
+type ProctorCounts struct {
+	FocusLosses int `json:"focus_losses"`
+	TabSwitches int `json:"tab_switches"`
+}
+
+// ProctoringSummary is the integrity summary of a student's assignment contest
+// shown to the instructor
+type ProctoringSummary struct {
+	ContestID    uuid.UUID     `json:"contest_id"`
+	AssignmentID uuid.UUID     `json:"assignment_id"`
+	UserID       uuid.UUID     `json:"user_id"`
+	Status       ContestStatus `json:"status"`
+	ProctorCounts
+	AwaySeconds        int            `json:"away_seconds"`         // Total time away from the contest
+	LongestAwaySeconds int            `json:"longest_away_seconds"` // Longest single period away
+	Truncated          bool           `json:"truncated"`            // Events past the per-contest cap were dropped
+	Events             []ContestEvent `json:"events"`               // In the order they occurred
+}
+
+// ContestEventInput is one event of the contest events endpoint
+type ContestEventInput struct {
+	Type       ProctorEventType `json:"type" binding:"required,oneof=focus_lost focus_gained tab_hidden tab_visible"`
+	OccurredAt time.Time        `json:"occurred_at" binding:"required"`
+}
+
+// RecordContestEventsRequest is the body of the contest events endpoint;
+// clients batch events and send them while the contest runs
+type RecordContestEventsRequest struct {
+	Events []ContestEventInput `json:"events" binding:"required,min=1,max=50,dive"`
+}
+
+// ContestEventRepository defines the interface for proctoring event data access
+type ContestEventRepository interface {
+	Create(events []ContestEvent) error
+	Count(contestID uuid.UUID) (int64, error)
+	FindByContest(contestID uuid.UUID) ([]ContestEvent, error) // In the order they occurred
+	// FindCounts counts focus losses and tab switches per contest
+	FindCounts(contestIDs []uuid.UUID) (map[uuid.UUID]ProctorCounts, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) ContestEventRepository
+}
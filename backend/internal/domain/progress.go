@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserProgressSummary is the materialized progress of a user. It is updated in
+// the same transaction as the submission and contest writes it counts and
+// periodically rebuilt from submissions and contests, so reading progress is a
+// single-row lookup.
+type UserProgressSummary struct {
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	EasySolved   int       `json:"easy_solved" gorm:"not null;default:0"`
+	MediumSolved int       `json:"medium_solved" gorm:"not null;default:0"`
+	HardSolved   int       `json:"hard_solved" gorm:"not null;default:0"`
+
+	TotalContests     int `json:"total_contests" gorm:"not null;default:0"`
+	CompletedContests int `json:"completed_contests" gorm:"not null;default:0"`
+	AbandonedContests int `json:"abandoned_contests" gorm:"not null;default:0"`
+
+	// LastActiveAt is the latest contest start, contest end or first solve; nil if never active
+	LastActiveAt *time.Time `json:"last_active_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserProgressSummary) TableName() string {
+	return "user_progress"
+}
+
+// ToProgress converts the summary to the progress API response
+func (p *UserProgressSummary) ToProgress() *UserProgress {
+	return &UserProgress{
+		TotalSolved:   p.EasySolved + p.MediumSolved + p.HardSolved,
+		EasySolved:    p.EasySolved,
+		MediumSolved:  p.MediumSolved,
+		HardSolved:    p.HardSolved,
+		TopicProgress: make(map[string]TopicStats),
+		ContestStats: ContestStatistics{
+			TotalContests:     p.TotalContests,
+			CompletedContests: p.CompletedContests,
+			AbandonedContests: p.AbandonedContests,
+		},
+		LastActiveAt: p.LastActiveAt,
+	}
+}
+
+// MemberProgress is one user's row in a progress report covering many users,
+// such as the members of a class
+type MemberProgress struct {
+	UserID            uuid.UUID  `json:"user_id"`
+	Username          string     `json:"username"`
+	TotalSolved       int        `json:"total_solved"`
+	EasySolved        int        `json:"easy_solved"`
+	MediumSolved      int        `json:"medium_solved"`
+	HardSolved        int        `json:"hard_solved"`
+	TotalContests     int        `json:"total_contests"`
+	CompletedContests int        `json:"completed_contests"`
+	CompletionRate    float64    `json:"completion_rate"` // Completed share of all contests, 0 without any
+	LastActiveAt      *time.Time `json:"last_active_at"`  // Latest contest change or solve; nil if never active
+}
+
+// MemberProgressPage is one page of a progress report, ordered by username
+type MemberProgressPage struct {
+	Members []MemberProgress `json:"members"`
+	Total   int64            `json:"total"` // Users in the report across all pages
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// UserProgressRepository defines the interface for progress summary data access
+type UserProgressRepository interface {
+	FindByUserID(userID uuid.UUID) (*UserProgressSummary, error) // Returns nil, nil for users without a summary yet
+	// FindMembers reports the progress of the given users in a single query,
+	// ordered by username, along with how many of them exist in total
+	FindMembers(userIDs []uuid.UUID, limit, offset int) ([]MemberProgress, int64, error)
+	// Rebuild recomputes every user's summary from submissions and contests,
+	// correcting drift from dropped events, and returns the number of rows written
+	Rebuild() (int64, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) UserProgressRepository
+}
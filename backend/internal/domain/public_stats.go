@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PublicStatsMinGroup is the fewest users or contests a published figure may
+// describe, so no public number can be traced back to one person's activity
+const PublicStatsMinGroup = 5
+
+// MaxPublicProblems is how many of the most attempted problems are published
+const MaxPublicProblems = 50
+
+// PopularProblem is a catalog problem with how often users attempted it
+type PopularProblem struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Slug       string     `json:"slug"`
+	Difficulty Difficulty `json:"difficulty"`
+	Attempts   int64      `json:"attempts"`
+	Users      int64      `json:"users"`      // Distinct users who attempted it
+	Solvers    int64      `json:"-"`          // Distinct users who solved it
+	SolveRate  float64    `json:"solve_rate"` // Share of those users who solved it
+}
+
+// ContestSizeCount is how many finished contests served a number of problems
+type ContestSizeCount struct {
+	ProblemCount int   `json:"problem_count"`
+	Contests     int64 `json:"contests"`
+}
+
+// PublicStats is the anonymized platform overview shown on the landing page.
+// Groups smaller than PublicStatsMinGroup are left out.
+type PublicStats struct {
+	ContestsFinished int64              `json:"contests_finished"`
+	CompletionRate   *float64           `json:"completion_rate"` // Completed share of finished contests; null while too few finished
+	ContestSizes     []ContestSizeCount `json:"contest_sizes"`   // Most common first
+	ComputedAt       time.Time          `json:"computed_at"`
+}
+
+// PublicProblemStats lists the most attempted catalog problems, most attempted first
+type PublicProblemStats struct {
+	Problems   []PopularProblem `json:"problems"`
+	Count      int              `json:"count"`
+	ComputedAt time.Time        `json:"computed_at"`
+}
+
+// PublicProblemStatsQuery is the query of the public problem stats endpoint
+type PublicProblemStatsQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// PublicStatsRepository aggregates platform activity across all users
+type PublicStatsRepository interface {
+	// FindMostAttempted returns the catalog problems attempted by at least minUsers users, most attempts first
+	FindMostAttempted(limit, minUsers int) ([]PopularProblem, error)
+	// CountFinishedContests counts completed and abandoned contests
+	CountFinishedContests() (completed, abandoned int64, err error)
+	// CountContestSizes counts finished contests per problem count, leaving out sizes with fewer than minContests
+	CountContestSizes(minContests int) ([]ContestSizeCount, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) PublicStatsRepository
+}
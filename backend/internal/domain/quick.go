@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuickVerb is the action of a quick command
+type QuickVerb string
+
+const (
+	QuickStart   QuickVerb = "start"   // start <problems>x<minutes>: create a contest
+	QuickGo      QuickVerb = "go"      // go <n>: start timing problem n
+	QuickDone    QuickVerb = "done"    // done <n>: mark problem n complete
+	QuickUndo    QuickVerb = "undo"    // undo <n>: mark problem n not complete
+	QuickSkip    QuickVerb = "skip"    // skip: time the next open problem instead of the current one
+	QuickFinish  QuickVerb = "finish"  // finish: complete the active contest
+	QuickAbandon QuickVerb = "abandon" // abandon: abandon the active contest
+	QuickStatus  QuickVerb = "status"  // status: show the active contest
+)
+
+// QuickUsage is the quick command syntax, reported with commands that do not parse
+const QuickUsage = "start <problems>x<minutes> | go <n> | done <n> | undo <n> | skip | finish | abandon | status"
+
+// QuickCommand is a parsed quick command. Problems are numbered by their order
+// in the contest, as they are shown.
+type QuickCommand struct {
+	Verb            QuickVerb `json:"verb" gorm:"type:varchar(16);not null;default:''"`     // Empty when the input did not parse
+	Problem         int       `json:"problem,omitempty" gorm:"not null;default:0"`          // For go, done and undo
+	ProblemCount    int       `json:"problem_count,omitempty" gorm:"not null;default:0"`    // For start
+	DurationMinutes int       `json:"duration_minutes,omitempty" gorm:"not null;default:0"` // For start
+}
+
+// ParseQuickCommand parses a command such as "start 5x90", "done 3" or "skip".
+// Verbs are case-insensitive; the bounds of start are those of CreateContestRequest.
+func ParseQuickCommand(input string) (QuickCommand, error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return QuickCommand{}, errors.New("empty command")
+	}
+
+	cmd := QuickCommand{Verb: QuickVerb(fields[0])}
+	args := fields[1:]
+	switch cmd.Verb {
+	case QuickStart:
+		if len(args) != 1 {
+			return QuickCommand{}, errors.New("start takes <problems>x<minutes>, e.g. start 5x90")
+		}
+		count, minutes, ok := strings.Cut(args[0], "x")
+		var err error
+		if cmd.ProblemCount, err = strconv.Atoi(count); !ok || err != nil || cmd.ProblemCount < 1 || cmd.ProblemCount > 20 {
+			return QuickCommand{}, errors.New("start takes 1 to 20 problems")
+		}
+		if cmd.DurationMinutes, err = strconv.Atoi(minutes); err != nil || cmd.DurationMinutes < 10 || cmd.DurationMinutes > 300 {
+			return QuickCommand{}, errors.New("start takes 10 to 300 minutes")
+		}
+	case QuickGo, QuickDone, QuickUndo:
+		if len(args) != 1 {
+			return QuickCommand{}, fmt.Errorf("%s takes a problem number", cmd.Verb)
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return QuickCommand{}, fmt.Errorf("%q is not a problem number", args[0])
+		}
+		cmd.Problem = n
+	case QuickSkip, QuickFinish, QuickAbandon, QuickStatus:
+		if len(args) != 0 {
+			return QuickCommand{}, fmt.Errorf("%s takes no arguments", cmd.Verb)
+		}
+	default:
+		return QuickCommand{}, fmt.Errorf("unknown command %q", fields[0])
+	}
+	return cmd, nil
+}
+
+// QuickCommandLog is the audit trail entry of a quick command, kept whether or
+// not it parsed and succeeded
+type QuickCommandLog struct {
+	ID        uuid.UUID    `json:"id" gorm:"type:uuid;primary_key"`
+	UserID    uuid.UUID    `json:"-" gorm:"type:uuid;not null;index:idx_quick_commands_user_created,priority:1"`
+	Input     string       `json:"input" gorm:"type:varchar(200);not null"`
+	Command   QuickCommand `json:"command" gorm:"embedded"`
+	ContestID *uuid.UUID   `json:"contest_id" gorm:"type:uuid"`                        // Contest the command acted on, if any
+	Error     string       `json:"error" gorm:"type:varchar(255);not null;default:''"` // Empty on success
+	CreatedAt time.Time    `json:"created_at" gorm:"index:idx_quick_commands_user_created,priority:2"`
+}
+
+// TableName specifies the table name for GORM
+func (QuickCommandLog) TableName() string {
+	return "quick_commands"
+}
+
+// QuickCommandRepository defines the interface for the quick command audit trail
+type QuickCommandRepository interface {
+	Create(entry *QuickCommandLog) error
+	FindByUserID(userID uuid.UUID, limit int) ([]QuickCommandLog, error) // Newest first
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) QuickCommandRepository
+}
+
+// QuickCommandRequest is the body of the quick command endpoint
+type QuickCommandRequest struct {
+	Command string `json:"command" binding:"required,max=200"`
+}
+
+// QuickHistoryQuery is the query of the quick command history endpoint
+type QuickHistoryQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"` // Defaults to 20
+}
+
+// QuickResult is the outcome of a quick command
+type QuickResult struct {
+	Command QuickCommand     `json:"command"`
+	Message string           `json:"message"`           // One line for a terminal
+	Contest *ContestResponse `json:"contest,omitempty"` // The contest after the command; absent without one
+}
+
+// QuickHistory is the user's quick command audit trail, newest first
+type QuickHistory struct {
+	Commands []QuickCommandLog `json:"commands"`
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Plan is the subscription tier that decides a user's default quotas
+type Plan string
+
+const (
+	PlanFree    Plan = "free"
+	PlanPremium Plan = "premium"
+)
+
+// QuotaKind names one allowance of a plan
+type QuotaKind string
+
+const (
+	QuotaContestsPerDay QuotaKind = "contests_per_day" // Contests created since midnight UTC, including accepted challenges
+	QuotaCustomProblems QuotaKind = "custom_problems"  // Custom problems kept at once
+)
+
+// PlanQuotas holds the limits of a plan or of an override; 0 means unlimited
+type PlanQuotas struct {
+	ContestsPerDay int
+	CustomProblems int
+}
+
+// QuotaOverride replaces some of a user's plan limits, set by an admin for
+// support cases or trials. A nil limit keeps the plan's value.
+type QuotaOverride struct {
+	UserID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ContestsPerDay *int
+	CustomProblems *int
+	Reason         string    `gorm:"type:varchar(200);not null;default:''"`
+	UpdatedBy      uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt      time.Time
+}
+
+// TableName specifies the table name for GORM
+func (QuotaOverride) TableName() string {
+	return "quota_overrides"
+}
+
+// Apply returns the plan limits with the override's limits put in their place
+func (o *QuotaOverride) Apply(quotas PlanQuotas) PlanQuotas {
+	if o == nil {
+		return quotas
+	}
+	if o.ContestsPerDay != nil {
+		quotas.ContestsPerDay = *o.ContestsPerDay
+	}
+	if o.CustomProblems != nil {
+		quotas.CustomProblems = *o.CustomProblems
+	}
+	return quotas
+}
+
+// QuotaRepository defines the interface for per-user quota overrides
+type QuotaRepository interface {
+	// FindOverride returns the user's override, or nil if there is none
+	FindOverride(userID uuid.UUID) (*QuotaOverride, error)
+	SaveOverride(override *QuotaOverride) error
+	DeleteOverride(userID uuid.UUID) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) QuotaRepository
+}
+
+// SetQuotaOverrideRequest sets a user's plan and replaces their quota override.
+// Omitted or null limits follow the plan; 0 lifts the limit.
+type SetQuotaOverrideRequest struct {
+	Plan           Plan   `json:"plan" binding:"required,oneof=free premium"`
+	ContestsPerDay *int   `json:"contests_per_day" binding:"omitempty,min=0,max=1000"`
+	CustomProblems *int   `json:"custom_problems" binding:"omitempty,min=0,max=10000"`
+	Reason         string `json:"reason" binding:"max=200"`
+}
+
+// QuotaUsage reports one allowance and how much of it is used
+type QuotaUsage struct {
+	Kind       QuotaKind  `json:"kind"`
+	Limit      *int       `json:"limit"`     // Nil when unlimited
+	Used       int        `json:"used"`      // Usage counted against the limit
+	Remaining  *int       `json:"remaining"` // Nil when unlimited
+	ResetsAt   *time.Time `json:"resets_at"` // Nil for allowances that do not reset
+	Overridden bool       `json:"overridden"`
+}
+
+// QuotaStatus reports a user's plan and remaining allowances
+type QuotaStatus struct {
+	UserID uuid.UUID    `json:"user_id"`
+	Plan   Plan         `json:"plan"`
+	Reason string       `json:"reason,omitempty"` // Why an admin overrode the plan's limits
+	Quotas []QuotaUsage `json:"quotas"`
+}
+
+// NewQuotaUsage builds the usage of an allowance; a limit of 0 is unlimited
+func NewQuotaUsage(kind QuotaKind, limit, used int, resetsAt *time.Time, overridden bool) QuotaUsage {
+	usage := QuotaUsage{Kind: kind, Used: used, ResetsAt: resetsAt, Overridden: overridden}
+	if limit > 0 {
+		remaining := max(limit-used, 0)
+		usage.Limit = &limit
+		usage.Remaining = &remaining
+	}
+	return usage
+}
+
+// Exhausted reports whether the allowance has nothing left
+func (u QuotaUsage) Exhausted() bool {
+	return u.Remaining != nil && *u.Remaining == 0
+}
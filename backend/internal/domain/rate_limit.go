@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitCounter counts one client's requests to a class of expensive
+// endpoints in a fixed window. Counters live in the database so every instance
+// enforces the same limit.
+type RateLimitCounter struct {
+	Bucket      string    `gorm:"primaryKey;type:varchar(200)"` // "<class>:<user ID or client IP>"
+	WindowStart time.Time `gorm:"primaryKey"`
+	Count       int       `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"` // End of the window
+}
+
+// TableName specifies the table name for GORM
+func (RateLimitCounter) TableName() string {
+	return "rate_limit_counters"
+}
+
+// RateLimitRepository defines the interface for the shared rate limit counters
+type RateLimitRepository interface {
+	// Increment counts a request in the bucket's window and returns the count
+	// including it. Starting a new window prunes counters of ended windows.
+	Increment(bucket string, windowStart, expiresAt time.Time) (int, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) RateLimitRepository
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionPolicy is a kind of old data the retention job deletes
+type RetentionPolicy string
+
+const (
+	RetentionAbandonedContests RetentionPolicy = "abandoned_contests" // Abandoned contests with their problems and tags; solves and attempts in them are kept
+	RetentionQuickCommandLogs  RetentionPolicy = "quick_command_logs" // Quick command audit trail entries
+	RetentionBillingEvents     RetentionPolicy = "billing_events"     // IDs of processed payment webhooks, kept to ignore redeliveries
+)
+
+// RetentionPolicies lists the policies in the order a run applies them
+var RetentionPolicies = []RetentionPolicy{
+	RetentionAbandonedContests,
+	RetentionQuickCommandLogs,
+	RetentionBillingEvents,
+}
+
+// RetentionResult reports what a policy found and deleted in one run
+type RetentionResult struct {
+	Policy  RetentionPolicy `json:"policy"`
+	Enabled bool            `json:"enabled"`          // False when the policy keeps data forever
+	Cutoff  *time.Time      `json:"cutoff,omitempty"` // Rows older than this are deleted
+	Found   int64           `json:"found"`
+	Purged  int64           `json:"purged"` // Always 0 in a dry run
+}
+
+// RetentionReport is the summary of a retention run
+type RetentionReport struct {
+	DryRun     bool              `json:"dry_run"`
+	Results    []RetentionResult `json:"results"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+}
+
+// RunRetentionRequest is the optional body of the retention endpoint
+type RunRetentionRequest struct {
+	DryRun bool `json:"dry_run"` // Only count what would be deleted
+}
+
+// RetentionRepository counts and deletes data older than a cutoff
+type RetentionRepository interface {
+	// Count returns how many rows the policy would delete
+	Count(policy RetentionPolicy, cutoff time.Time) (int64, error)
+	// Purge deletes up to limit of those rows in one transaction and returns how many it deleted
+	Purge(policy RetentionPolicy, cutoff time.Time, limit int) (int64, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) RetentionRepository
+}
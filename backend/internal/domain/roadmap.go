@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// RoadmapCategory is an ordered section of the curriculum (e.g. "Arrays & Hashing")
+type RoadmapCategory struct {
+	ID       uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name     string    `json:"name" gorm:"type:varchar(64);uniqueIndex;not null"`
+	Position int       `json:"position" gorm:"not null"`
+
+	// Relationships
+	Problems []RoadmapProblem `json:"-" gorm:"foreignKey:CategoryID"`
+}
+
+// TableName specifies the table name for GORM
+func (RoadmapCategory) TableName() string {
+	return "roadmap_categories"
+}
+
+// RoadmapProblem places a problem at a position within a roadmap category.
+// Each problem appears on the roadmap at most once.
+type RoadmapProblem struct {
+	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;not null;index"`
+	ProblemID  uuid.UUID `json:"problem_id" gorm:"type:uuid;primaryKey"`
+	Position   int       `json:"position" gorm:"not null"`
+
+	// Relationships
+	Category RoadmapCategory `json:"-" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+	Problem  Problem         `json:"-" gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (RoadmapProblem) TableName() string {
+	return "roadmap_problems"
+}
+
+// RoadmapRepository defines the interface for roadmap data access
+type RoadmapRepository interface {
+	// FindCategories returns all categories in order, each with its problems in order
+	FindCategories() ([]RoadmapCategory, error)
+	// FindNextUnsolved returns up to n problems the user has not solved, in roadmap order
+	FindNextUnsolved(userID uuid.UUID, n int) ([]Problem, error)
+	FindSolvedProblemIDs(userID uuid.UUID) ([]uuid.UUID, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) RoadmapRepository
+}
+
+// ContestSource selects where the problems of a new contest come from
+type ContestSource string
+
+const (
+	// SourceRandom draws a difficulty-balanced random set (default)
+	SourceRandom ContestSource = "random"
+	// SourceRoadmap takes the next unsolved problems from the user's roadmap position
+	SourceRoadmap ContestSource = "roadmap"
+)
+
+// RoadmapResponse is the curriculum with optional per-user completion
+type RoadmapResponse struct {
+	Categories []RoadmapCategoryResponse `json:"categories"`
+	Total      int                       `json:"total"`
+	Completed  *int                      `json:"completed,omitempty"` // Only for authenticated users
+}
+
+// RoadmapCategoryResponse is a roadmap category in API responses
+type RoadmapCategoryResponse struct {
+	ID        uuid.UUID                `json:"id"`
+	Name      string                   `json:"name"`
+	Position  int                      `json:"position"`
+	Problems  []RoadmapProblemResponse `json:"problems"`
+	Total     int                      `json:"total"`
+	Completed *int                     `json:"completed,omitempty"`
+}
+
+// RoadmapProblemResponse is a roadmap entry in API responses
+type RoadmapProblemResponse struct {
+	ProblemResponse
+	Position int   `json:"position"`
+	Solved   *bool `json:"solved,omitempty"`
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SolvedState restricts problems by whether the user has solved them
+type SolvedState string
+
+const (
+	SolvedStateAny      SolvedState = "any"
+	SolvedStateSolved   SolvedState = "solved"
+	SolvedStateUnsolved SolvedState = "unsolved"
+)
+
+// MaxSavedFiltersPerUser caps how many named filters a user can keep
+const MaxSavedFiltersPerUser = 50
+
+// ProblemCriteria selects problems by difficulty, topic, company and solved state.
+// Empty lists match everything.
+type ProblemCriteria struct {
+	Difficulties []Difficulty `json:"difficulties" binding:"omitempty,max=3,dive,oneof=Easy Medium Hard"`
+	Topics       []string     `json:"topics" binding:"omitempty,max=20,dive,min=1,max=64"`
+	Companies    []string     `json:"companies" binding:"omitempty,max=20,dive,min=1,max=64"`
+	SolvedState  SolvedState  `json:"solved_state" binding:"omitempty,oneof=any solved unsolved"`
+}
+
+// NeedsUser reports whether applying the criteria requires knowing the user
+func (c ProblemCriteria) NeedsUser() bool {
+	return c.SolvedState == SolvedStateSolved || c.SolvedState == SolvedStateUnsolved
+}
+
+// Matches reports whether a problem satisfies the difficulty, company and topic criteria.
+// Company matching needs the problem's companies loaded.
+func (c ProblemCriteria) Matches(p *Problem) bool {
+	if len(c.Difficulties) > 0 {
+		found := false
+		for _, d := range c.Difficulties {
+			if p.Difficulty == d {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(c.Companies) > 0 && !p.HasCompany(c.Companies) {
+		return false
+	}
+
+	if len(c.Topics) > 0 {
+		for _, want := range c.Topics {
+			for _, topic := range p.Topics {
+				if topic == want {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// SavedFilter is a named set of problem criteria persisted per user,
+// so the problem browser looks the same on every device
+type SavedFilter struct {
+	ID           uuid.UUID   `json:"id" gorm:"type:uuid;primary_key"`
+	UserID       uuid.UUID   `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_saved_filters_user_name"`
+	Name         string      `json:"name" gorm:"type:varchar(64);not null;uniqueIndex:idx_saved_filters_user_name"`
+	Difficulties StringList  `json:"difficulties"`
+	Topics       StringList  `json:"topics"`
+	Companies    StringList  `json:"companies"`
+	SolvedState  SolvedState `json:"solved_state" gorm:"type:varchar(10);not null;default:'any'"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SavedFilter) TableName() string {
+	return "saved_filters"
+}
+
+// Criteria returns the problem criteria stored in the filter
+func (f *SavedFilter) Criteria() ProblemCriteria {
+	difficulties := make([]Difficulty, len(f.Difficulties))
+	for i, d := range f.Difficulties {
+		difficulties[i] = Difficulty(d)
+	}
+	return ProblemCriteria{
+		Difficulties: difficulties,
+		Topics:       f.Topics,
+		Companies:    f.Companies,
+		SolvedState:  f.SolvedState,
+	}
+}
+
+// Apply overwrites the filter's criteria
+func (f *SavedFilter) Apply(criteria ProblemCriteria) {
+	f.Difficulties = make(StringList, len(criteria.Difficulties))
+	for i, d := range criteria.Difficulties {
+		f.Difficulties[i] = string(d)
+	}
+	f.Topics = StringList(criteria.Topics)
+	if f.Topics == nil {
+		f.Topics = StringList{}
+	}
+	f.Companies = StringList(criteria.Companies)
+	if f.Companies == nil {
+		f.Companies = StringList{}
+	}
+	f.SolvedState = criteria.SolvedState
+	if f.SolvedState == "" {
+		f.SolvedState = SolvedStateAny
+	}
+}
+
+// SavedFilterRepository defines the interface for saved filter data access
+type SavedFilterRepository interface {
+	Create(filter *SavedFilter) error
+	FindByID(id uuid.UUID) (*SavedFilter, error)
+	FindByUserID(userID uuid.UUID) ([]SavedFilter, error)
+	FindByUserAndName(userID uuid.UUID, name string) (*SavedFilter, error)
+	CountByUserID(userID uuid.UUID) (int64, error)
+	Update(filter *SavedFilter) error
+	Delete(id uuid.UUID) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) SavedFilterRepository
+}
+
+// SavedFilterRequest represents the data needed to create or replace a saved filter
+type SavedFilterRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=64"`
+	ProblemCriteria
+}
+
+// ProblemListQuery represents the query parameters of the problem list endpoint.
+// A saved filter is applied when FilterID is set; otherwise the inline criteria are used.
+type ProblemListQuery struct {
+	FilterID     string   `form:"filter_id" binding:"omitempty,uuid"`
+	Difficulties []string `form:"difficulty" binding:"omitempty,dive,oneof=Easy Medium Hard"`
+	Topics       []string `form:"topic" binding:"omitempty,max=20"`
+	Companies    []string `form:"company" binding:"omitempty,max=20"`
+	Solved       string   `form:"solved" binding:"omitempty,oneof=any solved unsolved"`
+}
+
+// Criteria returns the inline criteria of the query
+func (q ProblemListQuery) Criteria() ProblemCriteria {
+	difficulties := make([]Difficulty, len(q.Difficulties))
+	for i, d := range q.Difficulties {
+		difficulties[i] = Difficulty(d)
+	}
+	return ProblemCriteria{
+		Difficulties: difficulties,
+		Topics:       q.Topics,
+		Companies:    q.Companies,
+		SolvedState:  SolvedState(q.Solved),
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// SeedVersion records which version of an embedded seed data set was last
+// applied, so startup only reconciles the database when the data changed
+type SeedVersion struct {
+	Name      string    `json:"name" gorm:"type:varchar(64);primaryKey"`   // Data set, e.g. "problems"
+	Checksum  string    `json:"checksum" gorm:"type:varchar(64);not null"` // SHA-256 of the embedded file
+	AppliedAt time.Time `json:"applied_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (SeedVersion) TableName() string {
+	return "seed_versions"
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SolutionSnippet is the code a user attached to a problem of their contest.
+// Snippets of organization assignment contests are compared with those of the
+// other students of the assignment by the similarity checker.
+type SolutionSnippet struct {
+	ContestID uuid.UUID `json:"contest_id" gorm:"type:uuid;primaryKey"`
+	ProblemID uuid.UUID `json:"problem_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	Language  string    `json:"language" gorm:"type:varchar(32);not null;default:''"`
+	Code      string    `json:"code" gorm:"type:text;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+
+	// Winnowing fingerprints of the normalized code and how many tokens it has,
+	// kept so the checker does not tokenize every peer again
+	Fingerprints []uint64 `json:"-" gorm:"type:text;serializer:json"`
+	TokenCount   int      `json:"-" gorm:"not null;default:0"`
+	// Revision counts the saves of the snippet; it is checked again whenever
+	// it moves past the revision the checker last compared
+	Revision        int `json:"-" gorm:"not null;default:1"`
+	CheckedRevision int `json:"-" gorm:"not null;default:0"`
+
+	// Relationships
+	Contest Contest `json:"-" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (SolutionSnippet) TableName() string {
+	return "solution_snippets"
+}
+
+// SimilarityStatus is where a flagged pair stands in the instructor's review
+type SimilarityStatus string
+
+const (
+	SimilarityPending   SimilarityStatus = "pending"   // Not reviewed yet
+	SimilarityConfirmed SimilarityStatus = "confirmed" // The instructor judged the solutions copied
+	SimilarityDismissed SimilarityStatus = "dismissed" // The instructor judged the match harmless
+)
+
+// SimilarityFlag is a pair of students' solutions to the same problem of a
+// group assignment that are similar enough for an instructor to look at.
+// ContestAID sorts before ContestBID, so each pair is flagged once.
+type SimilarityFlag struct {
+	ID           uuid.UUID        `json:"id" gorm:"type:uuid;primary_key"`
+	AssignmentID uuid.UUID        `json:"assignment_id" gorm:"type:uuid;not null;uniqueIndex:idx_similarity_flags_pair,priority:1"`
+	ProblemID    uuid.UUID        `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_similarity_flags_pair,priority:2"`
+	ContestAID   uuid.UUID        `json:"contest_a_id" gorm:"type:uuid;not null;uniqueIndex:idx_similarity_flags_pair,priority:3"`
+	ContestBID   uuid.UUID        `json:"contest_b_id" gorm:"type:uuid;not null;uniqueIndex:idx_similarity_flags_pair,priority:4"`
+	UserAID      uuid.UUID        `json:"user_a_id" gorm:"type:uuid;not null"`
+	UserBID      uuid.UUID        `json:"user_b_id" gorm:"type:uuid;not null"`
+	Similarity   float64          `json:"similarity" gorm:"not null"` // Share of fingerprints the two solutions have in common, 0-1
+	Status       SimilarityStatus `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	Note         string           `json:"note" gorm:"type:varchar(500);not null;default:''"`
+	DetectedAt   time.Time        `json:"detected_at" gorm:"not null"`
+	ReviewedBy   *uuid.UUID       `json:"reviewed_by" gorm:"type:uuid"`
+	ReviewedAt   *time.Time       `json:"reviewed_at"`
+
+	// Relationships
+	Assignment OrgAssignment `json:"-" gorm:"foreignKey:AssignmentID;constraint:OnDelete:CASCADE"`
+	ContestA   Contest       `json:"-" gorm:"foreignKey:ContestAID;constraint:OnDelete:CASCADE"`
+	ContestB   Contest       `json:"-" gorm:"foreignKey:ContestBID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (SimilarityFlag) TableName() string {
+	return "solution_similarity_flags"
+}
+
+// SimilarityFlagResponse is a flagged pair with the names an instructor needs
+type SimilarityFlagResponse struct {
+	SimilarityFlag
+	ProblemTitle string `json:"problem_title"`
+	UsernameA    string `json:"username_a"`
+	UsernameB    string `json:"username_b"`
+}
+
+// SimilarityFlagDetail is a flagged pair with both solutions side by side
+type SimilarityFlagDetail struct {
+	SimilarityFlagResponse
+	SnippetA SolutionSnippet `json:"snippet_a"`
+	SnippetB SolutionSnippet `json:"snippet_b"`
+}
+
+// SimilarityRunReport is the summary of one round of the similarity checker
+type SimilarityRunReport struct {
+	Checked  int `json:"checked"`  // Snippets compared with their peers
+	Compared int `json:"compared"` // Pairs of snippets compared
+	Flagged  int `json:"flagged"`  // Pairs at or above the threshold, new or updated
+	Cleared  int `json:"cleared"`  // Pending flags dropped because the code changed
+}
+
+// AttachSolutionRequest is the body of the solution snippet endpoint
+type AttachSolutionRequest struct {
+	Language string `json:"language" binding:"max=32"`
+	Code     string `json:"code" binding:"required,max=20000"`
+}
+
+// SimilarityFlagQuery is the query of the similarity flags endpoint
+type SimilarityFlagQuery struct {
+	Status SimilarityStatus `form:"status" binding:"omitempty,oneof=pending confirmed dismissed"`
+}
+
+// ReviewSimilarityRequest is the body of the similarity review endpoint
+type ReviewSimilarityRequest struct {
+	Status SimilarityStatus `json:"status" binding:"required,oneof=pending confirmed dismissed"`
+	Note   string           `json:"note" binding:"max=500"`
+}
+
+// SimilarityRepository defines the interface for solution snippet and
+// similarity flag data access
+type SimilarityRepository interface {
+	SaveSnippet(snippet *SolutionSnippet) error // Creates or replaces the snippet
+	FindSnippet(contestID, problemID uuid.UUID) (*SolutionSnippet, error)
+	// FindUnchecked lists up to limit snippets of assignment contests changed
+	// since they were last checked, with the assignment each belongs to
+	FindUnchecked(limit int) ([]AssignmentSnippet, error)
+	// FindPeers lists the snippets for the same problem from the assignment's
+	// other contests
+	FindPeers(assignmentID, problemID, contestID uuid.UUID) ([]SolutionSnippet, error)
+	// MarkChecked records that the snippet's revision was checked; a newer
+	// revision stays unchecked
+	MarkChecked(contestID, problemID uuid.UUID, revision int) error
+
+	// UpsertFlag stores a flag, updating the similarity of an existing one and keeping its review
+	UpsertFlag(flag *SimilarityFlag) error
+	// DeletePendingFlag removes the pair's flag unless it was reviewed, reporting whether one was removed
+	DeletePendingFlag(assignmentID, problemID, contestAID, contestBID uuid.UUID) (bool, error)
+	FindFlag(id uuid.UUID) (*SimilarityFlagResponse, error)
+	// FindFlags lists the assignment's flags, most similar first
+	FindFlags(assignmentID uuid.UUID, status SimilarityStatus) ([]SimilarityFlagResponse, error)
+	UpdateReview(flag *SimilarityFlag) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) SimilarityRepository
+}
+
+// AssignmentSnippet is a snippet with the organization assignment its contest was given for
+type AssignmentSnippet struct {
+	SolutionSnippet
+	AssignmentID uuid.UUID
+}
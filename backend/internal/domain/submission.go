@@ -1,20 +1,27 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 // Submission represents a user's completion of a problem
-// This tracks when a user marks a problem as solved, for avoiding repeats
+// This tracks when a user marks a problem as solved, for avoiding repeats.
+// There is at most one per user and problem; every try, failed or solved,
+// is recorded separately as an Attempt.
 type Submission struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
-	ProblemID uuid.UUID  `json:"problem_id" gorm:"type:uuid;not null;index"`
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_submissions_user_problem_unique,priority:1"`
+	ProblemID uuid.UUID  `json:"problem_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_submissions_user_problem_unique,priority:2"` // The composite index also serves the unsolved anti-join
 	ContestID *uuid.UUID `json:"contest_id" gorm:"type:uuid;index"` // Optional, can solve outside contest
 	SolvedAt  time.Time  `json:"solved_at" gorm:"not null"`
 
+	// Confidence is the user's 1-5 self-rating from the latest solve, nil if not rated
+	Confidence   *int       `json:"confidence" gorm:"type:smallint"`
+	LastSolvedAt *time.Time `json:"last_solved_at"` // Latest re-solve, which restarts the review interval
+
 	// Relationships
 	User    User    `json:"-" gorm:"foreignKey:UserID"`
 	Problem Problem `json:"problem" gorm:"foreignKey:ProblemID"`
@@ -28,31 +35,46 @@ func (Submission) TableName() string {
 
 // SubmissionRepository defines the interface for submission data access
 type SubmissionRepository interface {
-	Create(submission *Submission) error
+	// Create inserts the submission and counts the solve in the user's progress
+	// summary in one transaction. A submission the user already has for the
+	// problem is kept as it is; Create then reports false without error.
+	Create(submission *Submission) (bool, error)
 	FindByID(id uuid.UUID) (*Submission, error)
 	FindByUserID(userID uuid.UUID) ([]Submission, error)
 	FindByUserAndProblem(userID, problemID uuid.UUID) (*Submission, error)
 	FindByContestID(contestID uuid.UUID) ([]Submission, error)
 	ExistsByUserAndProblem(userID, problemID uuid.UUID) (bool, error)
 	CountByUserID(userID uuid.UUID) (int64, error)
-	CountByUserAndDifficulty(userID uuid.UUID, difficulty Difficulty) (int64, error)
+	CountSolvedByDifficulty(userID uuid.UUID) (map[Difficulty]int, error)
+	// CountByTopic counts the catalog problems of every topic and how many of them the user solved
+	CountByTopic(userID uuid.UUID) (map[string]TopicStats, error)
+	// RecordResolve notes that the user solved the problem again; a nil confidence keeps the previous rating
+	RecordResolve(userID, problemID uuid.UUID, confidence *int, solvedAt time.Time) error
+	UpdateConfidence(userID, problemID uuid.UUID, confidence int) error
+	// CountByConfidence counts the user's solved problems per confidence rating, 0 for unrated
+	CountByConfidence(userID uuid.UUID) (map[int]int, error)
 	Delete(id uuid.UUID) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) SubmissionRepository
 }
 
 // SubmissionResponse represents a submission in API responses
 type SubmissionResponse struct {
-	ID        uuid.UUID       `json:"id"`
-	Problem   ProblemResponse `json:"problem"`
-	ContestID *uuid.UUID      `json:"contest_id"`
-	SolvedAt  time.Time       `json:"solved_at"`
+	ID         uuid.UUID       `json:"id"`
+	Problem    ProblemResponse `json:"problem"`
+	ContestID  *uuid.UUID      `json:"contest_id"`
+	SolvedAt   time.Time       `json:"solved_at"`
+	Confidence *int            `json:"confidence"`
 }
 
 // ToResponse converts a Submission to a SubmissionResponse
 func (s *Submission) ToResponse() SubmissionResponse {
 	return SubmissionResponse{
-		ID:        s.ID,
-		Problem:   s.Problem.ToResponse(),
-		ContestID: s.ContestID,
-		SolvedAt:  s.SolvedAt,
+		ID:         s.ID,
+		Problem:    s.Problem.ToResponse(),
+		ContestID:  s.ContestID,
+		SolvedAt:   s.SolvedAt,
+		Confidence: s.Confidence,
 	}
 }
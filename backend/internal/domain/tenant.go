@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID is the tenant of every row created outside a tenant: the
+// platform's own instance, whose problem catalog all tenants share
+var DefaultTenantID = uuid.Nil
+
+// Tenant is a private instance hosted on the platform, such as a company's
+// interview practice site. Its users, organizations and leaderboard are
+// isolated from other tenants; its problems overlay the shared catalog.
+type Tenant struct {
+	ID   uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Slug string    `json:"slug" gorm:"type:varchar(63);uniqueIndex;not null"` // Subdomain and header value selecting the tenant
+	Name string    `json:"name" gorm:"type:varchar(100);not null"`
+	// AdminEmail is granted the admin role when it signs up in the tenant
+	AdminEmail string    `json:"admin_email" gorm:"type:varchar(255);not null"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// TenantInfo is what anyone may learn about the tenant a request is for
+type TenantInfo struct {
+	ID   uuid.UUID `json:"id"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+}
+
+// TenantShared is implemented by tenant-owned models whose default-tenant rows
+// every tenant reads as well, so a tenant's rows overlay the shared ones. Rows
+// are still only changed within the tenant that owns them.
+type TenantShared interface {
+	SharedAcrossTenants()
+}
+
+// SharedAcrossTenants makes the catalog visible to every tenant, under the
+// tenant's own problems
+func (Problem) SharedAcrossTenants() {}
+
+// LeaderboardEntry is a user's standing among the users of their tenant
+type LeaderboardEntry struct {
+	Rank         int       `json:"rank"`
+	UserID       uuid.UUID `json:"user_id"`
+	Username     string    `json:"username"`
+	Solved       int64     `json:"solved"`         // Distinct catalog problems solved
+	LastSolvedAt time.Time `json:"last_solved_at"` // Ties are ranked by who got there first
+}
+
+// CreateTenantRequest represents the payload for hosting a new tenant
+type CreateTenantRequest struct {
+	Slug       string `json:"slug" binding:"required,min=2,max=63"`
+	Name       string `json:"name" binding:"required,min=1,max=100"`
+	AdminEmail string `json:"admin_email" binding:"required,email"`
+}
+
+// LeaderboardQuery holds the query parameters of the tenant leaderboard
+type LeaderboardQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// TenantRepository defines the interface for tenant data access
+type TenantRepository interface {
+	Create(tenant *Tenant) error
+	FindBySlug(slug string) (*Tenant, error)
+	FindByID(id uuid.UUID) (*Tenant, error)
+	FindAll() ([]Tenant, error)
+	// FindLeaderboard ranks the users of the request's tenant by solved problems
+	FindLeaderboard(limit int) ([]LeaderboardEntry, error)
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) TenantRepository
+}
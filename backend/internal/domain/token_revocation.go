@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokedToken is a token that stopped being valid before its expiry, keyed by
+// its jti claim. Rows are only needed until the token would have expired anyway.
+type RevokedToken struct {
+	JTI       string    `json:"jti" gorm:"column:jti;type:varchar(64);primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	RevokedAt time.Time `json:"revoked_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// TokenRevocationRepository defines the interface for token revocation data access
+type TokenRevocationRepository interface {
+	// Revoke records the tokens as revoked and prunes rows of tokens that have expired
+	Revoke(tokens []RevokedToken) error
+	// IsRevoked reports whether the token with the given jti was revoked, or was
+	// issued for an older token version than the user's current one
+	IsRevoked(jti string, userID uuid.UUID, tokenVersion int) (bool, error)
+	// RevokeAllForUser bumps the user's token version, invalidating every token issued so far
+	RevokeAllForUser(userID uuid.UUID) error
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) TokenRevocationRepository
+}
+
+// LogoutRequest optionally names the refresh token to revoke along with the access token
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -1,20 +1,57 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Role represents a user's authorization level
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// Token scopes granted to roles and checked by route middleware
+const (
+	ScopeProblemsRead  = "problems:read"
+	ScopeContestsWrite = "contests:write"
+	ScopeProfile       = "profile"
+	ScopeAdmin         = "admin"
+)
+
+// Scopes returns the token scopes granted to the role
+func (r Role) Scopes() []string {
+	scopes := []string{ScopeProblemsRead, ScopeContestsWrite, ScopeProfile}
+	if r == RoleAdmin {
+		scopes = append(scopes, ScopeAdmin)
+	}
+	return scopes
+}
+
 // User represents a registered user of the platform
 type User struct {
-	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Email        string    `json:"email" gorm:"uniqueIndex;not null"`
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	TenantID     uuid.UUID `json:"-" gorm:"type:uuid;not null;default:'00000000-0000-0000-0000-000000000000';uniqueIndex:idx_users_tenant_email,priority:1"`
+	Email        string    `json:"email" gorm:"not null;uniqueIndex:idx_users_tenant_email,priority:2"` // Unique within the tenant
 	Username     string    `json:"username" gorm:"not null"`
 	PasswordHash string    `json:"-" gorm:"not null"`
+	Role         Role      `json:"role" gorm:"type:varchar(20);not null;default:'user'"`
+	Plan         Plan      `json:"plan" gorm:"type:varchar(20);not null;default:'free'"`
+	TokenVersion int       `json:"-" gorm:"not null;default:0"` // Bumped to revoke every token issued so far
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
+	// Billing state, kept in sync with the payment provider by its webhooks
+	BillingCustomerID  string     `json:"-" gorm:"type:varchar(255);not null;default:'';index"`
+	SubscriptionID     string     `json:"-" gorm:"type:varchar(255);not null;default:''"`
+	SubscriptionStatus string     `json:"-" gorm:"type:varchar(32);not null;default:''"`
+	PlanRenewsAt       *time.Time `json:"-"` // End of the paid period
+	BillingEventAt     *time.Time `json:"-"` // Creation time of the last applied billing event; older ones are ignored
+
 	// Relationships
 	Contests    []Contest    `json:"contests,omitempty" gorm:"foreignKey:UserID"`
 	Submissions []Submission `json:"submissions,omitempty" gorm:"foreignKey:UserID"`
@@ -25,6 +62,19 @@ func (User) TableName() string {
 	return "users"
 }
 
+// EffectivePlan returns the plan the user is entitled to at now. A paid
+// premium plan lapses once its period has been over for longer than grace
+// without a renewal; a premium plan granted by an admin never does.
+func (u *User) EffectivePlan(now time.Time, grace time.Duration) Plan {
+	if u.Plan != PlanPremium {
+		return PlanFree
+	}
+	if u.SubscriptionID != "" && u.PlanRenewsAt != nil && now.After(u.PlanRenewsAt.Add(grace)) {
+		return PlanFree
+	}
+	return PlanPremium
+}
+
 // UserRepository defines the interface for user data access
 // This abstraction allows for easy testing and swapping implementations
 type UserRepository interface {
@@ -34,13 +84,22 @@ type UserRepository interface {
 	Update(user *User) error
 	Delete(id uuid.UUID) error
 	GetSolvedProblemIDs(userID uuid.UUID) ([]uuid.UUID, error)
+
+	// WithContext scopes queries to ctx so cancellation and deadlines reach the database
+	WithContext(ctx context.Context) UserRepository
 }
 
 // UserCreateRequest represents the data needed to create a new user
 type UserCreateRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Username string `json:"username" binding:"required,min=3,max=50"`
-	Password string `json:"password" binding:"required,min=8"`
+	Password string `json:"password" binding:"required"` // Strength is enforced by the password policy
+}
+
+// ChangePasswordRequest represents the data needed to change a user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
 }
 
 // UserResponse represents the public user data returned by the API
@@ -48,7 +107,12 @@ type UserResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
 	Username  string    `json:"username"`
+	Role      Role      `json:"role"`
+	Plan      Plan      `json:"plan"`
 	CreatedAt time.Time `json:"created_at"`
+
+	SubscriptionStatus string     `json:"subscription_status,omitempty"` // Set once the user has subscribed
+	PlanRenewsAt       *time.Time `json:"plan_renews_at,omitempty"`
 }
 
 // ToResponse converts a User to a UserResponse (hides sensitive data)
@@ -57,18 +121,37 @@ func (u *User) ToResponse() UserResponse {
 		ID:        u.ID,
 		Email:     u.Email,
 		Username:  u.Username,
+		Role:      u.Role,
+		Plan:      u.Plan,
 		CreatedAt: u.CreatedAt,
+
+		SubscriptionStatus: u.SubscriptionStatus,
+		PlanRenewsAt:       u.PlanRenewsAt,
 	}
 }
 
 // UserProgress represents the user's overall progress statistics
 type UserProgress struct {
-	TotalSolved   int                    `json:"total_solved"`
-	EasySolved    int                    `json:"easy_solved"`
-	MediumSolved  int                    `json:"medium_solved"`
-	HardSolved    int                    `json:"hard_solved"`
-	TopicProgress map[string]TopicStats  `json:"topic_progress"`
-	ContestStats  ContestStatistics      `json:"contest_stats"`
+	TotalSolved   int                   `json:"total_solved"`
+	EasySolved    int                   `json:"easy_solved"`
+	MediumSolved  int                   `json:"medium_solved"`
+	HardSolved    int                   `json:"hard_solved"`
+	TopicProgress map[string]TopicStats `json:"topic_progress"`
+	ContestStats  ContestStatistics     `json:"contest_stats"`
+	Confidence    ConfidenceStats       `json:"confidence"`
+	Attempts      AttemptStats          `json:"attempts"`
+	SolveTimes    SolveTimeStats        `json:"solve_times"`
+	LastActiveAt  *time.Time            `json:"last_active_at"` // Latest contest start, contest end or first solve
+}
+
+// SolveTimeStats maps each difficulty to the user's timed contest solves of it;
+// difficulties without timed solves are absent
+type SolveTimeStats map[Difficulty]SolveTiming
+
+// SolveTiming summarizes how long the user's timed solves of a difficulty took
+type SolveTiming struct {
+	TimedSolves    int `json:"timed_solves"`
+	AverageSeconds int `json:"average_seconds"`
 }
 
 // TopicStats represents progress within a specific topic
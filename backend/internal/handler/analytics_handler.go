@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// AnalyticsHandler handles product analytics HTTP requests
+type AnalyticsHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetCohorts returns weekly signup cohorts with their retention and contest
+// activity from the latest nightly snapshot (admin only)
+// GET /api/admin/analytics/cohorts
+func (h *AnalyticsHandler) GetCohorts(c *gin.Context) {
+	cohorts, err := h.analyticsService.GetCohorts(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cohorts)
+}
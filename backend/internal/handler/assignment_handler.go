@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// AssignmentHandler handles the caller's assignments across organizations and mentors
+type AssignmentHandler struct {
+	assignmentService *service.AssignmentService
+}
+
+// NewAssignmentHandler creates a new assignment handler
+func NewAssignmentHandler(assignmentService *service.AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{
+		assignmentService: assignmentService,
+	}
+}
+
+// GetPendingAssignments lists the caller's unfinished assignments, soonest due first
+// GET /api/assignments
+func (h *AssignmentHandler) GetPendingAssignments(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.assignmentService.GetPendingAssignments(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
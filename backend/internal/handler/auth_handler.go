@@ -1,21 +1,24 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
 	"github.com/contest-maker-150/backend/internal/service"
 )
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
-	userService *service.UserService
+	userService service.UserServicer
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(userService *service.UserService) *AuthHandler {
+func NewAuthHandler(userService service.UserServicer) *AuthHandler {
 	return &AuthHandler{
 		userService: userService,
 	}
@@ -38,25 +41,13 @@ type AuthResponse struct {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req domain.UserCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
 		return
 	}
 
 	user, tokens, err := h.userService.Register(c.Request.Context(), &req)
 	if err != nil {
-		switch err {
-		case domain.ErrUserAlreadyExists:
-			c.JSON(http.StatusConflict, gin.H{
-				"error": "User with this email already exists",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to create user",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
@@ -71,25 +62,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
 		return
 	}
 
 	user, tokens, err := h.userService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		switch err {
-		case domain.ErrInvalidCredentials:
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid email or password",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to login",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
@@ -109,18 +88,13 @@ type RefreshRequest struct {
 func (h *AuthHandler) Refresh(c *gin.Context) {
 	var req RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
 		return
 	}
 
 	tokens, err := h.userService.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid or expired refresh token",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -128,3 +102,47 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		"tokens": tokens,
 	})
 }
+
+// Logout revokes the access token of the request and, when given, its refresh token.
+// The body is optional.
+// POST /api/auth/logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.Error(domain.ErrUnauthorized)
+		return
+	}
+
+	var req domain.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := h.userService.Logout(c.Request.Context(), claims, req.RefreshToken); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out",
+	})
+}
+
+// LogoutAll revokes every token issued to the current user, signing them out on all devices
+// POST /api/auth/logout-all
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.RevokeAllTokens(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out on all devices",
+	})
+}
@@ -0,0 +1,160 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/service"
+	"github.com/contest-maker-150/backend/internal/service/mocks"
+)
+
+var tokens = &service.TokenPair{AccessToken: "access", RefreshToken: "refresh"}
+
+// newAuthRouter routes the auth endpoints to a handler on users; logging out
+// requires a token, as in the API
+func newAuthRouter(users *mocks.UserServicer) *gin.Engine {
+	router, auth := newRouter(users)
+	h := handler.NewAuthHandler(users)
+	group := router.Group("/api/auth")
+	group.POST("/signup", h.Register)
+	group.POST("/login", h.Login)
+	group.POST("/refresh", h.Refresh)
+	group.POST("/logout", auth, h.Logout)
+	group.POST("/logout-all", auth, h.LogoutAll)
+	return router
+}
+
+func TestRegister(t *testing.T) {
+	users := &mocks.UserServicer{
+		RegisterFunc: func(_ context.Context, req *domain.UserCreateRequest) (*domain.User, *service.TokenPair, error) {
+			if req.Email == "taken@example.com" {
+				return nil, nil, domain.ErrUserAlreadyExists
+			}
+			if req.Password == "short" {
+				return nil, nil, domain.ErrWeakPassword
+			}
+			return &domain.User{ID: userID, Email: req.Email, Username: req.Username}, tokens, nil
+		},
+	}
+	router := newAuthRouter(users)
+
+	var resp handler.AuthResponse
+	req := domain.UserCreateRequest{Email: "alice@example.com", Username: "alice", Password: "correct horse"}
+	expectStatus(t, serve(t, router, http.MethodPost, "/api/auth/signup", req, ""), http.StatusCreated, &resp)
+	if resp.User.ID != userID || resp.Tokens == nil || resp.Tokens.AccessToken != tokens.AccessToken {
+		t.Fatalf("signup response = %+v", resp)
+	}
+
+	tests := []struct {
+		name   string
+		req    domain.UserCreateRequest
+		status int
+		code   string
+	}{
+		{"taken email", domain.UserCreateRequest{Email: "taken@example.com", Username: "taken", Password: "correct horse"}, http.StatusConflict, domain.CodeUserAlreadyExists},
+		{"weak password", domain.UserCreateRequest{Email: "bob@example.com", Username: "bob", Password: "short"}, http.StatusBadRequest, domain.CodeWeakPassword},
+		{"invalid email", domain.UserCreateRequest{Email: "bob", Username: "bob", Password: "correct horse"}, http.StatusBadRequest, domain.CodeValidationFailed},
+		{"short username", domain.UserCreateRequest{Email: "bob@example.com", Username: "b", Password: "correct horse"}, http.StatusBadRequest, domain.CodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, serve(t, router, http.MethodPost, "/api/auth/signup", tt.req, ""), tt.status, tt.code)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	users := &mocks.UserServicer{
+		LoginFunc: func(_ context.Context, email, password string) (*domain.User, *service.TokenPair, error) {
+			if password != "correct horse" {
+				return nil, nil, domain.ErrInvalidCredentials
+			}
+			return &domain.User{ID: userID, Email: email}, tokens, nil
+		},
+	}
+	router := newAuthRouter(users)
+
+	var resp handler.AuthResponse
+	login := handler.LoginRequest{Email: "alice@example.com", Password: "correct horse"}
+	expectStatus(t, serve(t, router, http.MethodPost, "/api/auth/login", login, ""), http.StatusOK, &resp)
+	if resp.User.Email != login.Email || resp.Tokens == nil {
+		t.Fatalf("login response = %+v", resp)
+	}
+
+	login.Password = "wrong"
+	expectError(t, serve(t, router, http.MethodPost, "/api/auth/login", login, ""), http.StatusUnauthorized, domain.CodeInvalidCredentials)
+	expectError(t, serve(t, router, http.MethodPost, "/api/auth/login", map[string]string{"email": "alice@example.com"}, ""),
+		http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestRefresh(t *testing.T) {
+	users := &mocks.UserServicer{
+		RefreshTokenFunc: func(_ context.Context, refreshToken string) (*service.TokenPair, error) {
+			if refreshToken != tokens.RefreshToken {
+				return nil, domain.ErrTokenRevoked
+			}
+			return tokens, nil
+		},
+	}
+	router := newAuthRouter(users)
+
+	var resp struct {
+		Tokens service.TokenPair `json:"tokens"`
+	}
+	rec := serve(t, router, http.MethodPost, "/api/auth/refresh", handler.RefreshRequest{RefreshToken: tokens.RefreshToken}, "")
+	expectStatus(t, rec, http.StatusOK, &resp)
+	if resp.Tokens.AccessToken != tokens.AccessToken {
+		t.Fatalf("refreshed tokens = %+v", resp.Tokens)
+	}
+
+	rec = serve(t, router, http.MethodPost, "/api/auth/refresh", handler.RefreshRequest{RefreshToken: "used"}, "")
+	expectError(t, rec, http.StatusUnauthorized, domain.CodeTokenRevoked)
+	expectError(t, serve(t, router, http.MethodPost, "/api/auth/refresh", struct{}{}, ""), http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestLogout(t *testing.T) {
+	var revoked string
+	users := &mocks.UserServicer{
+		LogoutFunc: func(_ context.Context, claims *service.TokenClaims, refreshToken string) error {
+			if claims == nil || claims.Subject != userID.String() {
+				t.Errorf("logout claims = %+v", claims)
+			}
+			revoked = refreshToken
+			return nil
+		},
+	}
+	router := newAuthRouter(users)
+
+	// The body is optional
+	expectStatus(t, serve(t, router, http.MethodPost, "/api/auth/logout", nil, validToken), http.StatusOK, nil)
+	body := domain.LogoutRequest{RefreshToken: tokens.RefreshToken}
+	expectStatus(t, serve(t, router, http.MethodPost, "/api/auth/logout", body, validToken), http.StatusOK, nil)
+	if revoked != tokens.RefreshToken {
+		t.Fatalf("revoked refresh token %q, want %q", revoked, tokens.RefreshToken)
+	}
+
+	expectError(t, serve(t, router, http.MethodPost, "/api/auth/logout", nil, ""), http.StatusUnauthorized, domain.CodeUnauthorized)
+}
+
+func TestLogoutAll(t *testing.T) {
+	var revoked uuid.UUID
+	users := &mocks.UserServicer{
+		RevokeAllTokensFunc: func(_ context.Context, id uuid.UUID) error {
+			revoked = id
+			return nil
+		},
+	}
+	router := newAuthRouter(users)
+
+	expectStatus(t, serve(t, router, http.MethodPost, "/api/auth/logout-all", nil, validToken), http.StatusOK, nil)
+	if revoked != userID {
+		t.Fatalf("revoked tokens of %s, want %s", revoked, userID)
+	}
+	expectError(t, serve(t, router, http.MethodPost, "/api/auth/logout-all", nil, "forged"), http.StatusUnauthorized, domain.CodeInvalidToken)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// BackupHandler handles backup HTTP requests. Restores only run from the
+// backup command, since they need a database without users.
+type BackupHandler struct {
+	backupService *service.BackupService
+}
+
+// NewBackupHandler creates a new backup handler
+func NewBackupHandler(backupService *service.BackupService) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+	}
+}
+
+// CreateBackup exports the user data to the backup storage (admin only)
+// POST /api/admin/backups
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	manifest, err := h.backupService.Create(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	manifest.CatalogSlugs = nil
+	c.JSON(http.StatusCreated, manifest)
+}
+
+// ListBackups lists the stored backups, newest first (admin only)
+// GET /api/admin/backups
+func (h *BackupHandler) ListBackups(c *gin.Context) {
+	backups, err := h.backupService.List(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.BackupListResponse{Backups: backups})
+}
+
+// VerifyBackup downloads a backup and checks its checksums and row counts (admin only)
+// POST /api/admin/backups/:id/verify
+func (h *BackupHandler) VerifyBackup(c *gin.Context) {
+	manifest, err := h.backupService.Verify(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	manifest.CatalogSlugs = nil
+	c.JSON(http.StatusOK, manifest)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// maxWebhookBytes caps the webhook payloads read into memory; Stripe events are
+// a few kilobytes
+const maxWebhookBytes = 64 << 10
+
+// BillingHandler handles premium plan billing HTTP requests
+type BillingHandler struct {
+	billingService *service.BillingService
+}
+
+// NewBillingHandler creates a new billing handler
+func NewBillingHandler(billingService *service.BillingService) *BillingHandler {
+	return &BillingHandler{
+		billingService: billingService,
+	}
+}
+
+// CreateCheckoutSession starts a premium subscription checkout for the current user
+// POST /api/billing/checkout
+func (h *BillingHandler) CreateCheckoutSession(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.billingService.CreateCheckoutSession(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// HandleWebhook applies a signed Stripe webhook event. The raw body is needed
+// to verify the signature, so it is not bound as JSON.
+// POST /api/billing/webhook
+func (h *BillingHandler) HandleWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBytes+1))
+	if err != nil {
+		c.Error(domain.NewValidationError("Could not read webhook payload", nil))
+		return
+	}
+	if len(payload) > maxWebhookBytes {
+		c.Error(domain.NewValidationError("Webhook payload is too large", nil))
+		return
+	}
+
+	if err := h.billingService.HandleWebhook(c.Request.Context(), payload, c.GetHeader("Stripe-Signature")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Event processed"})
+}
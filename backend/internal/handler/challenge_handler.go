@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ChallengeHandler handles "challenge a friend" invites
+type ChallengeHandler struct {
+	challengeService *service.ChallengeService
+}
+
+// NewChallengeHandler creates a new challenge handler
+func NewChallengeHandler(challengeService *service.ChallengeService) *ChallengeHandler {
+	return &ChallengeHandler{
+		challengeService: challengeService,
+	}
+}
+
+// CreateChallenge generates an invite to replay a contest
+// POST /api/contests/:id/challenge
+func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	// The body is optional
+	var req domain.CreateChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	challenge, err := h.challengeService.CreateChallenge(c.Request.Context(), userID, contestID, req.SilentMode)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, challenge)
+}
+
+// GetChallenge returns a challenge invite
+// GET /api/challenges/:code
+func (h *ChallengeHandler) GetChallenge(c *gin.Context) {
+	challenge, err := h.challengeService.GetChallenge(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, challenge)
+}
+
+// AcceptChallenge starts a contest with the challenger's problem set
+// POST /api/challenges/:code/accept
+func (h *ChallengeHandler) AcceptChallenge(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contest, err := h.challengeService.AcceptChallenge(c.Request.Context(), userID, c.Param("code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contest.ToResponse())
+}
+
+// GetComparison compares both participants' results
+// GET /api/challenges/:code/comparison
+func (h *ChallengeHandler) GetComparison(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	comparison, err := h.challengeService.GetComparison(c.Request.Context(), userID, c.Param("code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+// GetStandings returns the live standings and presence of a challenge's participants
+// GET /api/challenges/:code/standings
+func (h *ChallengeHandler) GetStandings(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	standings, err := h.challengeService.GetStandings(c.Request.Context(), userID, c.Param("code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
+
+// OpenSpectatorInvite opens an invite for read-only spectators of the challenge
+// POST /api/challenges/:code/spectators
+func (h *ChallengeHandler) OpenSpectatorInvite(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SpectatorInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	invite, err := h.challengeService.OpenSpectatorInvite(c.Request.Context(), userID, c.Param("code"), req.Anonymize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+// CloseSpectatorInvite stops spectators from watching the challenge
+// DELETE /api/challenges/:code/spectators
+func (h *ChallengeHandler) CloseSpectatorInvite(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.CloseSpectatorInvite(c.Request.Context(), userID, c.Param("code")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Spectator invite closed",
+	})
+}
+
+// Spectate returns the read-only standings behind a spectator invite
+// GET /api/spectate/:spectatorCode
+func (h *ChallengeHandler) Spectate(c *gin.Context) {
+	view, err := h.challengeService.Spectate(c.Request.Context(), c.Param("spectatorCode"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ChatHandler handles challenge chat HTTP requests
+type ChatHandler struct {
+	chatService *service.ChatService
+}
+
+// NewChatHandler creates a new chat handler
+func NewChatHandler(chatService *service.ChatService) *ChatHandler {
+	return &ChatHandler{
+		chatService: chatService,
+	}
+}
+
+// GetMessages returns the challenge chat, or the messages after since
+// GET /api/challenges/:code/chat
+func (h *ChatHandler) GetMessages(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.ChatQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	messages, err := h.chatService.GetMessages(c.Request.Context(), userID, c.Param("code"), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// PostMessage posts a message to the challenge chat
+// POST /api/challenges/:code/chat
+func (h *ChatHandler) PostMessage(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.PostChatMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	message, err := h.chatService.PostMessage(c.Request.Context(), userID, c.Param("code"), req.Body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
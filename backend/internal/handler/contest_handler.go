@@ -13,11 +13,11 @@ import (
 
 // ContestHandler handles contest-related HTTP requests
 type ContestHandler struct {
-	contestService *service.ContestService
+	contestService service.ContestServicer
 }
 
 // NewContestHandler creates a new contest handler
-func NewContestHandler(contestService *service.ContestService) *ContestHandler {
+func NewContestHandler(contestService service.ContestServicer) *ContestHandler {
 	return &ContestHandler{
 		contestService: contestService,
 	}
@@ -33,29 +33,13 @@ func (h *ContestHandler) CreateContest(c *gin.Context) {
 
 	var req domain.CreateContestRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
 		return
 	}
 
 	contest, err := h.contestService.CreateContest(c.Request.Context(), userID, &req)
 	if err != nil {
-		switch err {
-		case domain.ErrActiveContestExists:
-			c.JSON(http.StatusConflict, gin.H{
-				"error": "You already have an active contest. Complete or abandon it first.",
-			})
-		case domain.ErrNotEnoughProblems:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Not enough unsolved problems available. Try with fewer problems.",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to create contest",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
@@ -70,11 +54,15 @@ func (h *ContestHandler) GetContests(c *gin.Context) {
 		return
 	}
 
-	contests, err := h.contestService.GetUserContests(c.Request.Context(), userID)
+	var filter domain.ContestFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	contests, err := h.contestService.GetUserContests(c.Request.Context(), userID, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve contests",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -89,6 +77,75 @@ func (h *ContestHandler) GetContests(c *gin.Context) {
 	})
 }
 
+// GetTagSuggestions returns the user's tags matching a prefix
+// GET /api/contests/tags
+func (h *ContestHandler) GetTagSuggestions(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.TagSuggestionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	tags, err := h.contestService.SuggestTags(c.Request.Context(), userID, query.Prefix, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags": tags,
+	})
+}
+
+// GetAvailability returns how many unsolved problems are left for the user's
+// random contests, by difficulty and topic
+// GET /api/contests/availability
+func (h *ContestHandler) GetAvailability(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.AvailabilityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	availability, err := h.contestService.GetAvailability(c.Request.Context(), userID, &query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// GetTopicHistory returns how often each topic came up in the user's finished
+// contests and how much of it they completed
+// GET /api/users/me/contests/by-topic
+func (h *ContestHandler) GetTopicHistory(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	topics, err := h.contestService.GetTopicHistory(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topics": topics,
+	})
+}
+
 // GetActiveContest returns the user's active contest if any
 // GET /api/contests/active
 func (h *ContestHandler) GetActiveContest(c *gin.Context) {
@@ -99,9 +156,7 @@ func (h *ContestHandler) GetActiveContest(c *gin.Context) {
 
 	contest, err := h.contestService.GetActiveContest(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve active contest",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -128,32 +183,19 @@ func (h *ContestHandler) GetContest(c *gin.Context) {
 	contestIDStr := c.Param("id")
 	contestID, err := uuid.Parse(contestIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid contest ID",
-		})
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
 		return
 	}
 
 	contest, err := h.contestService.GetContestByID(c.Request.Context(), contestID)
 	if err != nil {
-		switch err {
-		case domain.ErrContestNotFound:
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Contest not found",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to retrieve contest",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
 	// Verify ownership
 	if contest.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "You don't have access to this contest",
-		})
+		c.Error(domain.NewDomainError(domain.ErrForbidden, "You don't have access to this contest"))
 		return
 	}
 
@@ -171,57 +213,26 @@ func (h *ContestHandler) MarkProblemComplete(c *gin.Context) {
 	contestIDStr := c.Param("id")
 	contestID, err := uuid.Parse(contestIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid contest ID",
-		})
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
 		return
 	}
 
 	problemIDStr := c.Param("problemId")
 	problemID, err := uuid.Parse(problemIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid problem ID",
-		})
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
 		return
 	}
 
 	var req domain.MarkProblemCompleteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
 		return
 	}
 
-	err = h.contestService.MarkProblemComplete(c.Request.Context(), userID, contestID, problemID, req.IsCompleted)
-	if err != nil {
-		switch err {
-		case domain.ErrContestNotFound:
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Contest not found",
-			})
-		case domain.ErrForbidden:
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "You don't have access to this contest",
-			})
-		case domain.ErrContestNotActive:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Contest is not active",
-			})
-		case domain.ErrContestExpired:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Contest has expired",
-			})
-		case domain.ErrProblemNotInContest:
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Problem not found in this contest",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to update problem status",
-			})
-		}
+	err = h.contestService.MarkProblemComplete(c.Request.Context(), userID, contestID, problemID, req.IsCompleted, req.Confidence)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -230,6 +241,260 @@ func (h *ContestHandler) MarkProblemComplete(c *gin.Context) {
 	})
 }
 
+// StateComplexity records the complexity the user states for a completed problem
+// PUT /api/contests/:id/problems/:problemId/complexity
+func (h *ContestHandler) StateComplexity(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	var req domain.StateComplexityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := h.contestService.StateComplexity(c.Request.Context(), userID, contestID, problemID, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Complexity saved",
+	})
+}
+
+// RecordAttempt records a failed or solved attempt at a contest problem
+// POST /api/contests/:id/problems/:problemId/attempts
+func (h *ContestHandler) RecordAttempt(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	var req domain.RecordAttemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	history, err := h.contestService.RecordAttempt(c.Request.Context(), userID, contestID, problemID, req.Outcome)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, history)
+}
+
+// StartProblem starts timing the problem the user begins working on
+// POST /api/contests/:id/problems/:problemId/start
+func (h *ContestHandler) StartProblem(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	contest, err := h.contestService.StartProblem(c.Request.Context(), userID, contestID, problemID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contest.ToResponse())
+}
+
+// MarkWarmupComplete marks the contest's warmup problem as completed
+// PATCH /api/contests/:id/warmup
+func (h *ContestHandler) MarkWarmupComplete(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestIDStr := c.Param("id")
+	contestID, err := uuid.Parse(contestIDStr)
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	var req domain.MarkProblemCompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	err = h.contestService.MarkWarmupComplete(c.Request.Context(), userID, contestID, req.IsCompleted)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Warmup status updated",
+	})
+}
+
+// StartContest ends the warmup and starts the contest timer
+// POST /api/contests/:id/start
+func (h *ContestHandler) StartContest(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestIDStr := c.Param("id")
+	contestID, err := uuid.Parse(contestIDStr)
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	err = h.contestService.StartContest(c.Request.Context(), userID, contestID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contest started",
+	})
+}
+
+// UpdateRetro saves the retro notes of a finished contest
+// PATCH /api/contests/:id/retro
+func (h *ContestHandler) UpdateRetro(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestIDStr := c.Param("id")
+	contestID, err := uuid.Parse(contestIDStr)
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	var req domain.UpdateRetroRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	err = h.contestService.UpdateRetro(c.Request.Context(), userID, contestID, req.Retro)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Retro saved",
+	})
+}
+
+// RateContest records how hard the user found a finished contest
+// PUT /api/contests/:id/rating
+func (h *ContestHandler) RateContest(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestIDStr := c.Param("id")
+	contestID, err := uuid.Parse(contestIDStr)
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	var req domain.RateContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	err = h.contestService.RateContest(c.Request.Context(), userID, contestID, req.Rating)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Rating saved",
+	})
+}
+
+// SetContestTags replaces the tags of a contest
+// PUT /api/contests/:id/tags
+func (h *ContestHandler) SetContestTags(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestIDStr := c.Param("id")
+	contestID, err := uuid.Parse(contestIDStr)
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	var req domain.SetContestTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	tags, err := h.contestService.SetContestTags(c.Request.Context(), userID, contestID, req.Tags)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags": tags,
+	})
+}
+
 // CompleteContest manually completes a contest
 // POST /api/contests/:id/complete
 func (h *ContestHandler) CompleteContest(c *gin.Context) {
@@ -241,32 +506,13 @@ func (h *ContestHandler) CompleteContest(c *gin.Context) {
 	contestIDStr := c.Param("id")
 	contestID, err := uuid.Parse(contestIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid contest ID",
-		})
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
 		return
 	}
 
 	err = h.contestService.CompleteContest(c.Request.Context(), userID, contestID)
 	if err != nil {
-		switch err {
-		case domain.ErrContestNotFound:
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Contest not found",
-			})
-		case domain.ErrForbidden:
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "You don't have access to this contest",
-			})
-		case domain.ErrContestNotActive:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Contest is not active",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to complete contest",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
@@ -286,32 +532,13 @@ func (h *ContestHandler) AbandonContest(c *gin.Context) {
 	contestIDStr := c.Param("id")
 	contestID, err := uuid.Parse(contestIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid contest ID",
-		})
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
 		return
 	}
 
 	err = h.contestService.AbandonContest(c.Request.Context(), userID, contestID)
 	if err != nil {
-		switch err {
-		case domain.ErrContestNotFound:
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Contest not found",
-			})
-		case domain.ErrForbidden:
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "You don't have access to this contest",
-			})
-		case domain.ErrContestNotActive:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Contest is not active",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to abandon contest",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
@@ -319,3 +546,17 @@ func (h *ContestHandler) AbandonContest(c *gin.Context) {
 		"message": "Contest abandoned",
 	})
 }
+
+// GetExperiments returns completion rates per variant of every experiment (admin only)
+// GET /api/admin/experiments
+func (h *ContestHandler) GetExperiments(c *gin.Context) {
+	outcomes, err := h.contestService.GetExperimentOutcomes(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ExperimentsResponse{
+		Experiments: outcomes,
+	})
+}
@@ -0,0 +1,172 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/service/mocks"
+)
+
+// newContestRouter routes the contest endpoints the tests exercise to a
+// handler on contests, behind the auth middleware
+func newContestRouter(contests *mocks.ContestServicer) *gin.Engine {
+	router, auth := newRouter(&mocks.UserServicer{})
+	h := handler.NewContestHandler(contests)
+	group := router.Group("/api/contests", auth)
+	group.POST("", h.CreateContest)
+	group.GET("", h.GetContests)
+	group.GET("/:id", h.GetContest)
+	group.PATCH("/:id/problems/:problemId", h.MarkProblemComplete)
+	group.POST("/:id/complete", h.CompleteContest)
+	return router
+}
+
+func TestCreateContest(t *testing.T) {
+	contests := &mocks.ContestServicer{
+		CreateContestFunc: func(_ context.Context, uid uuid.UUID, req *domain.CreateContestRequest) (*domain.Contest, error) {
+			if uid != userID {
+				t.Errorf("created for %s, want %s", uid, userID)
+			}
+			return &domain.Contest{ID: uuid.New(), UserID: uid, DurationMinutes: req.DurationMinutes, Status: domain.ContestStatusActive}, nil
+		},
+	}
+	router := newContestRouter(contests)
+
+	var contest domain.ContestResponse
+	rec := serve(t, router, http.MethodPost, "/api/contests", domain.CreateContestRequest{ProblemCount: 3, DurationMinutes: 45}, validToken)
+	expectStatus(t, rec, http.StatusCreated, &contest)
+	if contest.DurationMinutes != 45 || contest.Status != domain.ContestStatusActive {
+		t.Fatalf("created contest = %+v", contest)
+	}
+}
+
+func TestCreateContestErrors(t *testing.T) {
+	valid := domain.CreateContestRequest{ProblemCount: 3, DurationMinutes: 45}
+	tests := []struct {
+		name   string
+		body   interface{}
+		token  string
+		err    error // Returned by the service
+		status int
+		code   string
+	}{
+		{"no token", valid, "", nil, http.StatusUnauthorized, domain.CodeUnauthorized},
+		{"invalid token", valid, "forged", nil, http.StatusUnauthorized, domain.CodeInvalidToken},
+		{"missing fields", map[string]int{"problem_count": 3}, validToken, nil, http.StatusBadRequest, domain.CodeValidationFailed},
+		{"too many problems", domain.CreateContestRequest{ProblemCount: 21, DurationMinutes: 45}, validToken, nil, http.StatusBadRequest, domain.CodeValidationFailed},
+		{"active contest", valid, validToken, domain.ErrActiveContestExists, http.StatusConflict, domain.CodeActiveContest},
+		{"not enough problems", valid, validToken, domain.ErrNotEnoughProblems, http.StatusBadRequest, domain.CodeNotEnoughProblems},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contests := &mocks.ContestServicer{}
+			if tt.err != nil {
+				contests.CreateContestFunc = func(context.Context, uuid.UUID, *domain.CreateContestRequest) (*domain.Contest, error) {
+					return nil, tt.err
+				}
+			}
+			// Requests rejected before the service leave CreateContestFunc unset, so reaching it panics
+			rec := serve(t, newContestRouter(contests), http.MethodPost, "/api/contests", tt.body, tt.token)
+			expectError(t, rec, tt.status, tt.code)
+		})
+	}
+}
+
+func TestGetContests(t *testing.T) {
+	contests := &mocks.ContestServicer{
+		GetUserContestsFunc: func(_ context.Context, _ uuid.UUID, filter domain.ContestFilter) ([]domain.Contest, error) {
+			if filter.Status != domain.ContestStatusCompleted {
+				t.Errorf("status filter = %q", filter.Status)
+			}
+			return []domain.Contest{{ID: uuid.New(), UserID: userID}, {ID: uuid.New(), UserID: userID}}, nil
+		},
+	}
+	router := newContestRouter(contests)
+
+	var resp struct {
+		Contests []domain.ContestResponse `json:"contests"`
+	}
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/contests?status=completed", nil, validToken), http.StatusOK, &resp)
+	if len(resp.Contests) != 2 {
+		t.Fatalf("got %d contests, want 2", len(resp.Contests))
+	}
+
+	rec := serve(t, router, http.MethodGet, "/api/contests?status=paused", nil, validToken)
+	expectError(t, rec, http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestGetContest(t *testing.T) {
+	own, other := uuid.New(), uuid.New()
+	contests := &mocks.ContestServicer{
+		GetContestByIDFunc: func(_ context.Context, id uuid.UUID) (*domain.Contest, error) {
+			switch id {
+			case own:
+				return &domain.Contest{ID: id, UserID: userID}, nil
+			case other:
+				return &domain.Contest{ID: id, UserID: uuid.New()}, nil
+			}
+			return nil, domain.ErrContestNotFound
+		},
+	}
+	router := newContestRouter(contests)
+
+	var contest domain.ContestResponse
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/contests/"+own.String(), nil, validToken), http.StatusOK, &contest)
+	if contest.ID != own {
+		t.Fatalf("got contest %s, want %s", contest.ID, own)
+	}
+
+	expectError(t, serve(t, router, http.MethodGet, "/api/contests/"+other.String(), nil, validToken), http.StatusForbidden, domain.CodeForbidden)
+	expectError(t, serve(t, router, http.MethodGet, "/api/contests/"+uuid.NewString(), nil, validToken), http.StatusNotFound, domain.CodeContestNotFound)
+	expectError(t, serve(t, router, http.MethodGet, "/api/contests/not-a-uuid", nil, validToken), http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestMarkProblemComplete(t *testing.T) {
+	contestID, problemID := uuid.New(), uuid.New()
+	var marked bool
+	contests := &mocks.ContestServicer{
+		MarkProblemCompleteFunc: func(_ context.Context, _, cid, pid uuid.UUID, isCompleted bool, _ *int) error {
+			if cid != contestID || pid != problemID {
+				return domain.ErrProblemNotInContest
+			}
+			marked = isCompleted
+			return nil
+		},
+	}
+	router := newContestRouter(contests)
+	path := "/api/contests/" + contestID.String() + "/problems/"
+
+	rec := serve(t, router, http.MethodPatch, path+problemID.String(), domain.MarkProblemCompleteRequest{IsCompleted: true}, validToken)
+	expectStatus(t, rec, http.StatusOK, nil)
+	if !marked {
+		t.Fatal("problem was not marked completed")
+	}
+
+	rec = serve(t, router, http.MethodPatch, path+uuid.NewString(), domain.MarkProblemCompleteRequest{IsCompleted: true}, validToken)
+	expectError(t, rec, http.StatusNotFound, domain.CodeProblemNotInContest)
+	rec = serve(t, router, http.MethodPatch, path+"42", domain.MarkProblemCompleteRequest{IsCompleted: true}, validToken)
+	expectError(t, rec, http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestCompleteContest(t *testing.T) {
+	active, finished := uuid.New(), uuid.New()
+	contests := &mocks.ContestServicer{
+		CompleteContestFunc: func(_ context.Context, _ uuid.UUID, id uuid.UUID) error {
+			if id == finished {
+				return domain.ErrContestNotActive
+			}
+			return nil
+		},
+	}
+	router := newContestRouter(contests)
+
+	expectStatus(t, serve(t, router, http.MethodPost, "/api/contests/"+active.String()+"/complete", nil, validToken), http.StatusOK, nil)
+	rec := serve(t, router, http.MethodPost, "/api/contests/"+finished.String()+"/complete", nil, validToken)
+	expectError(t, rec, http.StatusBadRequest, domain.CodeContestNotActive)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ContestSessionHandler handles contest session state HTTP requests
+type ContestSessionHandler struct {
+	sessionService *service.ContestSessionService
+}
+
+// NewContestSessionHandler creates a new contest session handler
+func NewContestSessionHandler(sessionService *service.ContestSessionService) *ContestSessionHandler {
+	return &ContestSessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// GetState returns the session state of the caller's contest
+// GET /api/contests/:id/state
+func (h *ContestSessionHandler) GetState(c *gin.Context) {
+	userID, contestID, ok := sessionParams(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.GetState(c.Request.Context(), userID, contestID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// SaveState replaces the session state of the caller's contest
+// PUT /api/contests/:id/state
+func (h *ContestSessionHandler) SaveState(c *gin.Context) {
+	userID, contestID, ok := sessionParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateContestSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	session, err := h.sessionService.SaveState(c.Request.Context(), userID, contestID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// sessionParams reads the caller and the contest ID of a session state route,
+// reporting a validation error when the ID is malformed
+func sessionParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, contestID, true
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ContestTemplateHandler handles contest template export and import requests
+type ContestTemplateHandler struct {
+	templateService *service.ContestTemplateService
+}
+
+// NewContestTemplateHandler creates a new contest template handler
+func NewContestTemplateHandler(templateService *service.ContestTemplateService) *ContestTemplateHandler {
+	return &ContestTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// ExportTemplate returns the signed template of one of the user's contests
+// GET /api/contests/:id/template
+func (h *ContestTemplateHandler) ExportTemplate(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	template, err := h.templateService.ExportTemplate(c.Request.Context(), userID, contestID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// ImportTemplate starts a contest from a template another user shared
+// POST /api/contests/import
+func (h *ContestTemplateHandler) ImportTemplate(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ImportContestTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	result, err := h.templateService.ImportTemplate(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if req.DryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// CustomProblemHandler handles the user's private custom problems
+type CustomProblemHandler struct {
+	customService *service.CustomProblemService
+}
+
+// NewCustomProblemHandler creates a new custom problem handler
+func NewCustomProblemHandler(customService *service.CustomProblemService) *CustomProblemHandler {
+	return &CustomProblemHandler{
+		customService: customService,
+	}
+}
+
+// GetCustomProblems returns the user's custom problems
+// GET /api/users/me/problems
+func (h *CustomProblemHandler) GetCustomProblems(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	problems, err := h.customService.ListCustomProblems(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]domain.ProblemResponse, len(problems))
+	for i, p := range problems {
+		response[i] = p.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"problems": response,
+		"count":    len(response),
+	})
+}
+
+// CreateCustomProblem adds a private custom problem
+// POST /api/users/me/problems
+func (h *CustomProblemHandler) CreateCustomProblem(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CustomProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	problem, err := h.customService.CreateCustomProblem(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, problem.ToResponse())
+}
+
+// UpdateCustomProblem replaces a custom problem
+// PUT /api/users/me/problems/:problemId
+func (h *CustomProblemHandler) UpdateCustomProblem(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	var req domain.CustomProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	problem, err := h.customService.UpdateCustomProblem(c.Request.Context(), userID, problemID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, problem.ToResponse())
+}
+
+// DeleteCustomProblem deletes a custom problem
+// DELETE /api/users/me/problems/:problemId
+func (h *CustomProblemHandler) DeleteCustomProblem(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	if err := h.customService.DeleteCustomProblem(c.Request.Context(), userID, problemID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom problem deleted"})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// DigestHandler handles the weekly recommendation digest HTTP requests
+type DigestHandler struct {
+	digestService *service.DigestService
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(digestService *service.DigestService) *DigestHandler {
+	return &DigestHandler{
+		digestService: digestService,
+	}
+}
+
+// GetDigest returns the caller's digest opt-in and latest digest
+// GET /api/users/me/digest
+func (h *DigestHandler) GetDigest(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.digestService.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UpdateDigest opts the caller in to or out of the weekly digest
+// PUT /api/users/me/digest
+func (h *DigestHandler) UpdateDigest(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateDigestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	status, err := h.digestService.SetEnabled(c.Request.Context(), userID, *req.Enabled)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Click records a click on a suggested problem and redirects to its page
+// GET /api/digests/click/:token
+func (h *DigestHandler) Click(c *gin.Context) {
+	target, err := h.digestService.Click(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, target)
+}
+
+// SendDigests sends the digests that are due now instead of waiting for the
+// next scheduled round (admin only)
+// POST /api/admin/digests/send
+func (h *DigestHandler) SendDigests(c *gin.Context) {
+	report, err := h.digestService.SendDue(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetStats reports the click-through and solve rates of recent digests (admin only)
+// GET /api/admin/digests/stats
+func (h *DigestHandler) GetStats(c *gin.Context) {
+	var query domain.DigestStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	stats, err := h.digestService.GetStats(c.Request.Context(), query.Days)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// FeatureFlagHandler handles feature flag HTTP requests
+type FeatureFlagHandler struct {
+	flagService *service.FeatureFlagService
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler
+func NewFeatureFlagHandler(flagService *service.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flagService: flagService,
+	}
+}
+
+// GetFeatures returns which feature flags are on for the current user
+// GET /api/users/me/features
+func (h *FeatureFlagHandler) GetFeatures(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.FeaturesResponse{
+		Features: h.flagService.GetFeatures(c.Request.Context(), userID),
+	})
+}
+
+// GetFlags lists every feature flag with its rollout (admin only)
+// GET /api/admin/feature-flags
+func (h *FeatureFlagHandler) GetFlags(c *gin.Context) {
+	c.JSON(http.StatusOK, domain.FeatureFlagListResponse{
+		Flags: h.flagService.ListFlags(c.Request.Context()),
+	})
+}
+
+// UpdateFlag turns a feature flag on or off for a share of users (admin only)
+// PUT /api/admin/feature-flags/:key
+func (h *FeatureFlagHandler) UpdateFlag(c *gin.Context) {
+	adminID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	flag, err := h.flagService.UpdateFlag(c.Request.Context(), adminID, c.Param("key"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
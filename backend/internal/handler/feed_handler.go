@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// FeedHandler handles activity feed HTTP requests
+type FeedHandler struct {
+	feedService *service.FeedService
+}
+
+// NewFeedHandler creates a new feed handler
+func NewFeedHandler(feedService *service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// GetFeed returns a page of the current user's activity feed and their friends'
+// GET /api/users/me/feed
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.FeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	feed, err := h.feedService.GetFeed(c.Request.Context(), userID, &query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
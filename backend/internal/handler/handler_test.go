@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+	"github.com/contest-maker-150/backend/internal/service/mocks"
+)
+
+// validToken is the only access token the test users mock accepts; it signs
+// in as userID
+const validToken = "valid-token"
+
+var userID = uuid.MustParse("5f0c6a38-33a4-4b0e-9d1f-7a1b2c3d4e5f")
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newRouter returns an engine rendering handler errors as the API does, and
+// the auth middleware backed by users. Unless the test set one, users accepts
+// validToken as userID and rejects any other token.
+func newRouter(users *mocks.UserServicer) (*gin.Engine, gin.HandlerFunc) {
+	if users.ValidateAccessTokenFunc == nil {
+		users.ValidateAccessTokenFunc = func(_ context.Context, token string) (*service.TokenClaims, error) {
+			if token != validToken {
+				return nil, domain.ErrInvalidToken
+			}
+			return &service.TokenClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: userID.String()}, Type: "access"}, nil
+		}
+	}
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware())
+	return router, middleware.AuthMiddleware(users)
+}
+
+// serve sends a request with a JSON body, if any, and the bearer token, if any
+func serve(t *testing.T, router http.Handler, method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	var raw []byte
+	if body != nil {
+		var err error
+		if raw, err = json.Marshal(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(raw))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set(middleware.AuthorizationHeader, middleware.BearerPrefix+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// expectStatus fails unless the response has the status, and decodes its body into out
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, status int, out interface{}) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, status, rec.Body)
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("decode %s: %v", rec.Body, err)
+		}
+	}
+}
+
+// expectError fails unless the response is an error envelope with the status and code
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) middleware.APIError {
+	t.Helper()
+	var resp middleware.ErrorResponse
+	expectStatus(t, rec, status, &resp)
+	if resp.Error.Code != code {
+		t.Fatalf("error code = %q, want %q; body: %s", resp.Error.Code, code, rec.Body)
+	}
+	if resp.Error.Message == "" {
+		t.Fatalf("error %s has no message", code)
+	}
+	return resp.Error
+}
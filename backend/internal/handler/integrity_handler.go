@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// IntegrityHandler handles data integrity HTTP requests
+type IntegrityHandler struct {
+	integrityService *service.IntegrityService
+}
+
+// NewIntegrityHandler creates a new integrity handler
+func NewIntegrityHandler(integrityService *service.IntegrityService) *IntegrityHandler {
+	return &IntegrityHandler{
+		integrityService: integrityService,
+	}
+}
+
+// RunIntegrity detects and repairs inconsistent data and recomputes derived data (admin only)
+// POST /api/admin/integrity
+func (h *IntegrityHandler) RunIntegrity(c *gin.Context) {
+	var req domain.RunIntegrityRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	report, err := h.integrityService.Run(c.Request.Context(), req.DryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// LogLevelHandler handles runtime log level HTTP requests
+type LogLevelHandler struct {
+	logLevelService *service.LogLevelService
+}
+
+// NewLogLevelHandler creates a new log level handler
+func NewLogLevelHandler(logLevelService *service.LogLevelService) *LogLevelHandler {
+	return &LogLevelHandler{
+		logLevelService: logLevelService,
+	}
+}
+
+// GetLogLevel reports the log level in effect (admin only)
+// GET /api/admin/log-level
+func (h *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, h.logLevelService.GetLogLevel(c.Request.Context()))
+}
+
+// SetLogLevel changes the log level of every instance without a redeploy (admin only)
+// PUT /api/admin/log-level
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	adminID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SetLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	status, err := h.logLevelService.SetLogLevel(c.Request.Context(), adminID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// MaintenanceHandler handles maintenance mode HTTP requests
+type MaintenanceHandler struct {
+	maintenanceService *service.MaintenanceService
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(maintenanceService *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// GetStatus announces ongoing or upcoming maintenance so clients can show a banner
+// GET /api/maintenance
+func (h *MaintenanceHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.maintenanceService.GetStatus(c.Request.Context()))
+}
+
+// SetMaintenance starts, schedules or ends maintenance (admin only)
+// PUT /api/admin/maintenance
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	adminID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	status, err := h.maintenanceService.SetMaintenance(c.Request.Context(), adminID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// MentorshipHandler handles mentor/mentee links and the mentor's read access
+type MentorshipHandler struct {
+	mentorshipService *service.MentorshipService
+}
+
+// NewMentorshipHandler creates a new mentorship handler
+func NewMentorshipHandler(mentorshipService *service.MentorshipService) *MentorshipHandler {
+	return &MentorshipHandler{
+		mentorshipService: mentorshipService,
+	}
+}
+
+// Invite invites a user to be mentored by the caller
+// POST /api/mentorships
+func (h *MentorshipHandler) Invite(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateMentorshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	mentorship, err := h.mentorshipService.Invite(c.Request.Context(), userID, req.MenteeEmail)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mentorship)
+}
+
+// GetMentorships lists the caller's mentorships as mentor and as mentee
+// GET /api/mentorships
+func (h *MentorshipHandler) GetMentorships(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.mentorshipService.GetMentorships(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Accept consents to a mentorship invite
+// POST /api/mentorships/:id/accept
+func (h *MentorshipHandler) Accept(c *gin.Context) {
+	h.respond(c, true)
+}
+
+// Decline refuses a mentorship invite
+// POST /api/mentorships/:id/decline
+func (h *MentorshipHandler) Decline(c *gin.Context) {
+	h.respond(c, false)
+}
+
+// respond records the mentee's answer to an invite
+func (h *MentorshipHandler) respond(c *gin.Context, accept bool) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	mentorship, err := h.mentorshipService.Respond(c.Request.Context(), userID, mentorshipID, accept)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mentorship)
+}
+
+// Revoke ends a mentorship or withdraws an invite
+// DELETE /api/mentorships/:id
+func (h *MentorshipHandler) Revoke(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.mentorshipService.Revoke(c.Request.Context(), userID, mentorshipID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Mentorship revoked"})
+}
+
+// GetMenteeContests lists the mentee's contests
+// GET /api/mentorships/:id/contests
+func (h *MentorshipHandler) GetMenteeContests(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	contests, err := h.mentorshipService.GetMenteeContests(c.Request.Context(), userID, mentorshipID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]domain.ContestResponse, len(contests))
+	for i, contest := range contests {
+		responses[i] = contest.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{"contests": responses})
+}
+
+// GetMenteeContest returns one of the mentee's contests
+// GET /api/mentorships/:id/contests/:contestId
+func (h *MentorshipHandler) GetMenteeContest(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("contestId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	contest, err := h.mentorshipService.GetMenteeContest(c.Request.Context(), userID, mentorshipID, contestID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contest.ToResponse())
+}
+
+// GetMenteeTiming returns the mentee's timing analytics
+// GET /api/mentorships/:id/timing
+func (h *MentorshipHandler) GetMenteeTiming(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	timing, err := h.mentorshipService.GetMenteeTiming(c.Request.Context(), userID, mentorshipID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timing)
+}
+
+// GetMenteeNotes lists the mentee's retro notes
+// GET /api/mentorships/:id/notes
+func (h *MentorshipHandler) GetMenteeNotes(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	notes, err := h.mentorshipService.GetMenteeNotes(c.Request.Context(), userID, mentorshipID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"notes": notes})
+}
+
+// GetAudit returns the mentorship's audit trail
+// GET /api/mentorships/:id/audit
+func (h *MentorshipHandler) GetAudit(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	var query domain.MentorshipAuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	audit, err := h.mentorshipService.GetAudit(c.Request.Context(), userID, mentorshipID, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, audit)
+}
+
+// GetAssignments lists the mentorship's assignments with the mentee's progress
+// GET /api/mentorships/:id/assignments
+func (h *MentorshipHandler) GetAssignments(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.mentorshipService.GetAssignments(c.Request.Context(), userID, mentorshipID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
+
+// CreateAssignment assigns a contest or problem set to the mentee
+// POST /api/mentorships/:id/assignments
+func (h *MentorshipHandler) CreateAssignment(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateMentorAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	assignment, err := h.mentorshipService.CreateAssignment(c.Request.Context(), userID, mentorshipID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// DeleteAssignment deletes an assignment
+// DELETE /api/mentorships/:id/assignments/:assignmentId
+func (h *MentorshipHandler) DeleteAssignment(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid assignment ID", nil))
+		return
+	}
+
+	if err := h.mentorshipService.DeleteAssignment(c.Request.Context(), userID, mentorshipID, assignmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Assignment deleted"})
+}
+
+// StartAssignment starts the mentee's contest for an assignment
+// POST /api/mentorships/:id/assignments/:assignmentId/start
+func (h *MentorshipHandler) StartAssignment(c *gin.Context) {
+	userID, mentorshipID, ok := mentorshipParams(c)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid assignment ID", nil))
+		return
+	}
+
+	contest, err := h.mentorshipService.StartAssignment(c.Request.Context(), userID, mentorshipID, assignmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contest.ToResponse())
+}
+
+// mentorshipParams returns the caller and the mentorship ID of the path,
+// reporting the error when either is missing
+func mentorshipParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	mentorshipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid mentorship ID", nil))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, mentorshipID, true
+}
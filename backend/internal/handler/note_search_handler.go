@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// NoteSearchHandler handles searches of a user's own notes
+type NoteSearchHandler struct {
+	noteSearchService *service.NoteSearchService
+}
+
+// NewNoteSearchHandler creates a new note search handler
+func NewNoteSearchHandler(noteSearchService *service.NoteSearchService) *NoteSearchHandler {
+	return &NoteSearchHandler{
+		noteSearchService: noteSearchService,
+	}
+}
+
+// Search finds the current user's contest retros and solution snippets
+// containing the words of ?q
+// GET /api/users/me/search
+func (h *NoteSearchHandler) Search(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.NoteSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchResults
+	}
+
+	results, err := h.noteSearchService.Search(c.Request.Context(), userID, query.Q, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
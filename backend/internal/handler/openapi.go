@@ -0,0 +1,518 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/openapi"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+//go:generate go run ../../cmd/openapi -out ../../api/openapi.json
+//go:generate go run ../../cmd/clientgen -spec ../../api/openapi.json -go ../../../clients/go -ts ../../../clients/typescript/src
+
+// messageResponse documents the {"message": "..."} body returned by action endpoints
+var messageResponse = openapi.Object{"message": ""}
+
+// includeParam documents the optional ?include=popularity flag on problem endpoints
+var includeParam = openapi.Param{Name: "include", In: "query", Description: "Set to \"popularity\" to include usage counters", Example: ""}
+
+// APIOperations documents every route served under /api.
+// Keep in sync with the router; the server logs any drift at startup.
+func APIOperations() []openapi.Operation {
+	return []openapi.Operation{
+		// Auth
+		{Method: http.MethodPost, Path: "/api/auth/signup", Summary: "Register new user", Tags: []string{"auth"},
+			Request: domain.UserCreateRequest{}, Responses: map[int]interface{}{http.StatusCreated: AuthResponse{}}},
+		{Method: http.MethodPost, Path: "/api/auth/login", Summary: "Login user", Tags: []string{"auth"},
+			Request: LoginRequest{}, Responses: map[int]interface{}{http.StatusOK: AuthResponse{}}},
+		{Method: http.MethodPost, Path: "/api/auth/refresh", Summary: "Refresh access token", Tags: []string{"auth"},
+			Request: RefreshRequest{}, Responses: map[int]interface{}{http.StatusOK: openapi.Object{"tokens": service.TokenPair{}}}},
+		{Method: http.MethodPost, Path: "/api/auth/logout", Summary: "Revoke the current access token and optionally its refresh token", Tags: []string{"auth"}, Auth: true,
+			Request: domain.LogoutRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/auth/logout-all", Summary: "Revoke every token of the current user", Tags: []string{"auth"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+
+		// Users
+		{Method: http.MethodGet, Path: "/api/users/me", Summary: "Get current user", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.UserResponse{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/progress", Summary: "Get user progress stats", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.UserProgress{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/reviews", Summary: "Solved problems in spaced-repetition order", Tags: []string{"users"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "due_only", In: "query", Description: "Only problems due for review now", Example: false},
+				{Name: "limit", In: "query", Description: "Maximum number of problems (1-200)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.ReviewQueue{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/attempts/:problemId", Summary: "Your attempt history at a problem", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.AttemptHistory{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/contests/by-topic", Summary: "How often each topic came up in your finished contests and your completion rate", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"topics": []domain.TopicContestStats{}}}},
+		{Method: http.MethodPut, Path: "/api/users/me/password", Summary: "Change password", Tags: []string{"users"}, Auth: true,
+			Request: domain.ChangePasswordRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/users/me/filters", Summary: "List saved problem filters", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"filters": []domain.SavedFilter{}, "count": 0}}},
+		{Method: http.MethodPost, Path: "/api/users/me/filters", Summary: "Save a problem filter", Tags: []string{"users"}, Auth: true,
+			Request: domain.SavedFilterRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.SavedFilter{}}},
+		{Method: http.MethodPut, Path: "/api/users/me/filters/:filterId", Summary: "Replace a saved problem filter", Tags: []string{"users"}, Auth: true,
+			Request: domain.SavedFilterRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.SavedFilter{}}},
+		{Method: http.MethodDelete, Path: "/api/users/me/filters/:filterId", Summary: "Delete a saved problem filter", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/users/me/problems", Summary: "List private custom problems", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"problems": []domain.ProblemResponse{}, "count": 0}}},
+		{Method: http.MethodPost, Path: "/api/users/me/problems", Summary: "Add a private custom problem", Tags: []string{"users"}, Auth: true,
+			Request: domain.CustomProblemRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ProblemResponse{}}},
+		{Method: http.MethodPut, Path: "/api/users/me/problems/:problemId", Summary: "Replace a private custom problem", Tags: []string{"users"}, Auth: true,
+			Request: domain.CustomProblemRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/users/me/problems/:problemId", Summary: "Delete a private custom problem", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/users/me/features", Summary: "Feature flags that are on for the current user", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.FeaturesResponse{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/quotas", Summary: "Plan and remaining allowances of the current user", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.QuotaStatus{}}},
+		{Method: http.MethodPost, Path: "/api/users/me/heartbeat", Summary: "Mark yourself online, or in your contest", Tags: []string{"users"}, Auth: true,
+			Request: domain.HeartbeatRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.Presence{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/digest", Summary: "Weekly recommendation digest opt-in and the latest digest", Tags: []string{"users"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.DigestStatus{}}},
+		{Method: http.MethodPut, Path: "/api/users/me/digest", Summary: "Opt in to or out of the weekly recommendation digest", Tags: []string{"users"}, Auth: true,
+			Request: domain.UpdateDigestRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.DigestStatus{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/search", Summary: "Search the user's contest retros and solution snippets", Tags: []string{"users"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "q", In: "query", Description: "Words that must all appear, e.g. \"sliding window\"", Required: true, Example: ""},
+				{Name: "limit", In: "query", Description: "Maximum number of results (1-50, default 20)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.NoteSearchResponse{}}},
+		{Method: http.MethodGet, Path: "/api/users/me/feed", Summary: "Activity feed of the user and their friends, newest first", Tags: []string{"users"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "cursor", In: "query", Description: "next_cursor of the previous page", Example: ""},
+				{Name: "limit", In: "query", Description: "Maximum number of items (1-100, default 20)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.FeedResponse{}}},
+
+		// Problems
+		{Method: http.MethodGet, Path: "/api/problems", Summary: "List all problems", Tags: []string{"problems"},
+			Params: []openapi.Param{
+				includeParam,
+				{Name: "filter_id", In: "query", Description: "Apply one of the user's saved filters (requires auth)", Example: ""},
+				{Name: "difficulty", In: "query", Description: "Only problems of this difficulty (repeatable)", Example: []string{}},
+				{Name: "topic", In: "query", Description: "Only problems with this topic (repeatable)", Example: []string{}},
+				{Name: "company", In: "query", Description: "Only problems tagged with this company (repeatable)", Example: []string{}},
+				{Name: "solved", In: "query", Description: "\"any\", \"solved\" or \"unsolved\" (solved states require auth)", Example: ""},
+			},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"problems": []domain.ProblemResponse{}, "count": 0}}},
+		{Method: http.MethodGet, Path: "/api/problems/stats", Summary: "Get problem statistics", Tags: []string{"problems"},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemStats{}}},
+		{Method: http.MethodGet, Path: "/api/problems/search", Summary: "Search problems by title or topic with fuzzy matching", Tags: []string{"problems"},
+			Params: []openapi.Param{
+				{Name: "q", In: "query", Description: "Search text, e.g. \"palindrom\" or \"binary tree\"", Required: true, Example: ""},
+				{Name: "limit", In: "query", Description: "Maximum number of results (1-50, default 20)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemSearchResponse{}}},
+		{Method: http.MethodPost, Path: "/api/problems/batch", Summary: "Look up several problems by ID or slug", Tags: []string{"problems"},
+			Params:  []openapi.Param{includeParam},
+			Request: domain.ProblemBatchRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemBatchResponse{}}},
+		{Method: http.MethodGet, Path: "/api/problems/slug/:slug", Summary: "Public page of a problem with SEO metadata", Tags: []string{"problems"},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemPage{}}},
+		{Method: http.MethodGet, Path: "/api/sitemap.xml", Summary: "Sitemap of the public problem pages", Tags: []string{"problems"},
+			ContentType: "application/xml", Responses: map[int]interface{}{http.StatusOK: ""}},
+		{Method: http.MethodGet, Path: "/api/problems/:id", Summary: "Get single problem", Tags: []string{"problems"},
+			Params:    []openapi.Param{includeParam},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemResponse{}}},
+		{Method: http.MethodGet, Path: "/api/problems/:id/prerequisites", Summary: "List problem prerequisites", Tags: []string{"problems"},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemPrerequisitesResponse{}}},
+
+		// Companies
+		{Method: http.MethodGet, Path: "/api/companies", Summary: "List companies with tagged problem counts", Tags: []string{"problems"},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"companies": []domain.CompanyCount{}}}},
+
+		// Public statistics
+		{Method: http.MethodGet, Path: "/api/public/stats", Summary: "Anonymized platform statistics", Tags: []string{"public"},
+			Responses: map[int]interface{}{http.StatusOK: domain.PublicStats{}}},
+		{Method: http.MethodGet, Path: "/api/public/stats/problems", Summary: "Most attempted problems", Tags: []string{"public"},
+			Params: []openapi.Param{
+				{Name: "limit", In: "query", Description: "Maximum number of problems (1-50, default 10)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.PublicProblemStats{}}},
+
+		// Recommendation digests
+		{Method: http.MethodGet, Path: "/api/digests/click/:token", Summary: "Record a click on a digest's suggested problem and redirect to its page", Tags: []string{"public"},
+			ContentType: "text/html", Responses: map[int]interface{}{http.StatusFound: ""}},
+
+		// Tenants
+		{Method: http.MethodGet, Path: "/api/tenant", Summary: "The tenant the request is for, selected by subdomain or the X-Tenant header", Tags: []string{"tenant"},
+			Responses: map[int]interface{}{http.StatusOK: domain.TenantInfo{}}},
+		{Method: http.MethodGet, Path: "/api/tenant/leaderboard", Summary: "Users of your tenant ranked by solved problems", Tags: []string{"tenant"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "limit", In: "query", Description: "Maximum number of users (1-100, default 25)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"entries": []domain.LeaderboardEntry{}}}},
+		{Method: http.MethodPost, Path: "/api/tenant/problems", Summary: "Add a problem to your tenant's catalog (tenant admins)", Tags: []string{"tenant"}, Auth: true,
+			Request: domain.CustomProblemRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ProblemResponse{}}},
+
+		// Maintenance
+		{Method: http.MethodGet, Path: "/api/maintenance", Summary: "Ongoing or upcoming maintenance", Tags: []string{"maintenance"},
+			Responses: map[int]interface{}{http.StatusOK: domain.MaintenanceStatus{}}},
+
+		// Roadmap
+		{Method: http.MethodGet, Path: "/api/roadmap", Summary: "Get the roadmap with completion overlay", Tags: []string{"roadmap"},
+			Responses: map[int]interface{}{http.StatusOK: domain.RoadmapResponse{}}},
+
+		// Contests
+		{Method: http.MethodPost, Path: "/api/contests", Summary: "Create new contest", Tags: []string{"contests"}, Auth: true,
+			Request: domain.CreateContestRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ContestResponse{}}},
+		{Method: http.MethodGet, Path: "/api/contests", Summary: "List and filter user's contests", Tags: []string{"contests"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "q", In: "query", Description: "Only contests whose retro notes contain this text", Example: ""},
+				{Name: "tag", In: "query", Description: "Only contests carrying this tag", Example: ""},
+				{Name: "status", In: "query", Description: "Only contests in this status (active, completed, abandoned, pending)", Example: ""},
+				{Name: "from", In: "query", Description: "Only contests created on or after this UTC day (YYYY-MM-DD)", Example: ""},
+				{Name: "to", In: "query", Description: "Only contests created on or before this UTC day (YYYY-MM-DD)", Example: ""},
+				{Name: "min_duration", In: "query", Description: "Only contests lasting at least this many minutes", Example: 0},
+				{Name: "max_duration", In: "query", Description: "Only contests lasting at most this many minutes", Example: 0},
+				{Name: "min_score", In: "query", Description: "Only contests with at least this many solved problems", Example: 0},
+				{Name: "max_score", In: "query", Description: "Only contests with at most this many solved problems", Example: 0},
+				{Name: "problem", In: "query", Description: "Only contests containing this problem, by ID or slug", Example: ""},
+			},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"contests": []domain.ContestResponse{}}}},
+		{Method: http.MethodGet, Path: "/api/contests/active", Summary: "Get active contest", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"contest": &domain.ContestResponse{}}}},
+		{Method: http.MethodGet, Path: "/api/contests/tags", Summary: "Autocomplete contest tags", Tags: []string{"contests"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "prefix", In: "query", Description: "Only tags starting with this text", Example: ""},
+				{Name: "limit", In: "query", Description: "Maximum number of suggestions (1-50, default 10)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"tags": []domain.TagCount{}}}},
+		{Method: http.MethodGet, Path: "/api/contests/availability", Summary: "Count the unsolved problems left for random contests, by difficulty and topic", Tags: []string{"contests"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "respect_prerequisites", In: "query", Description: "Only problems whose prerequisites you solved", Example: false},
+				{Name: "company", In: "query", Description: "Only problems tagged with this company (repeatable)", Example: []string{}},
+				{Name: "include_custom", In: "query", Description: "Count your own custom problems too", Example: false},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemAvailability{}}},
+		{Method: http.MethodPost, Path: "/api/contests/import", Summary: "Start a contest from a shared contest template", Tags: []string{"contests"}, Auth: true,
+			Request: domain.ImportContestTemplateRequest{}, Responses: map[int]interface{}{
+				http.StatusCreated: domain.ContestTemplateImport{},
+				http.StatusOK:      domain.ContestTemplateImport{}, // Dry run
+			}},
+		{Method: http.MethodGet, Path: "/api/contests/:id", Summary: "Get contest by ID", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ContestResponse{}}},
+		{Method: http.MethodPatch, Path: "/api/contests/:id/problems/:problemId", Summary: "Mark problem complete", Tags: []string{"contests"}, Auth: true,
+			Request: domain.MarkProblemCompleteRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPut, Path: "/api/contests/:id/problems/:problemId/complexity", Summary: "State the complexity of a completed problem's solution", Tags: []string{"contests"}, Auth: true,
+			Request: domain.StateComplexityRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/problems/:problemId/attempts", Summary: "Record a failed or solved attempt at a contest problem", Tags: []string{"contests"}, Auth: true,
+			Request: domain.RecordAttemptRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.AttemptHistory{}}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/problems/:problemId/start", Summary: "Start the timer of a contest problem", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ContestResponse{}}},
+		{Method: http.MethodGet, Path: "/api/contests/:id/problems/:problemId/solution", Summary: "Get the solution snippet attached to a contest problem", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.SolutionSnippet{}}},
+		{Method: http.MethodPut, Path: "/api/contests/:id/problems/:problemId/solution", Summary: "Attach a solution snippet to a contest problem, replacing the previous one", Tags: []string{"contests"}, Auth: true,
+			Request: domain.AttachSolutionRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.SolutionSnippet{}}},
+		{Method: http.MethodGet, Path: "/api/contests/:id/state", Summary: "Get the contest's session state: the problem on screen and scratch notes", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ContestSession{}}},
+		{Method: http.MethodPut, Path: "/api/contests/:id/state", Summary: "Save the contest's session state, failing when another device saved since it was read", Tags: []string{"contests"}, Auth: true,
+			Request: domain.UpdateContestSessionRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ContestSession{}}},
+		{Method: http.MethodPatch, Path: "/api/contests/:id/warmup", Summary: "Mark warmup problem complete", Tags: []string{"contests"}, Auth: true,
+			Request: domain.MarkProblemCompleteRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/start", Summary: "End warmup, or start an assigned pending contest, and start the contest timer", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPatch, Path: "/api/contests/:id/retro", Summary: "Save contest retro notes", Tags: []string{"contests"}, Auth: true,
+			Request: domain.UpdateRetroRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPut, Path: "/api/contests/:id/rating", Summary: "Rate how hard a finished contest was, tuning the mix of later ones", Tags: []string{"contests"}, Auth: true,
+			Request: domain.RateContestRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPut, Path: "/api/contests/:id/tags", Summary: "Replace contest tags", Tags: []string{"contests"}, Auth: true,
+			Request: domain.SetContestTagsRequest{}, Responses: map[int]interface{}{http.StatusOK: openapi.Object{"tags": []string{}}}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/complete", Summary: "Complete contest", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/abandon", Summary: "Abandon contest", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/events", Summary: "Report focus and tab switch events of a running organization assignment contest", Tags: []string{"contests"}, Auth: true,
+			Request: domain.RecordContestEventsRequest{}, Responses: map[int]interface{}{http.StatusOK: openapi.Object{"recorded": 0}}},
+		{Method: http.MethodPost, Path: "/api/contests/:id/challenge", Summary: "Challenge a friend to the same contest", Tags: []string{"contests"}, Auth: true,
+			Request: domain.CreateChallengeRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ChallengeResponse{}}},
+		{Method: http.MethodGet, Path: "/api/contests/:id/template", Summary: "Export a contest as a signed, shareable template", Tags: []string{"contests"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.SignedContestTemplate{}}},
+
+		// Challenges
+		{Method: http.MethodGet, Path: "/api/challenges/:code", Summary: "Get challenge invite", Tags: []string{"challenges"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ChallengeResponse{}}},
+		{Method: http.MethodPost, Path: "/api/challenges/:code/accept", Summary: "Accept challenge and start its contest", Tags: []string{"challenges"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusCreated: domain.ContestResponse{}}},
+		{Method: http.MethodGet, Path: "/api/challenges/:code/comparison", Summary: "Compare challenge results", Tags: []string{"challenges"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ChallengeComparison{}}},
+		{Method: http.MethodGet, Path: "/api/challenges/:code/standings", Summary: "Live challenge standings with participant presence", Tags: []string{"challenges"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ChallengeStandings{}}},
+		{Method: http.MethodPost, Path: "/api/challenges/:code/spectators", Summary: "Invite read-only spectators of the live standings", Tags: []string{"challenges"}, Auth: true,
+			Request: domain.SpectatorInviteRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.SpectatorInvite{}}},
+		{Method: http.MethodDelete, Path: "/api/challenges/:code/spectators", Summary: "Close the spectator invite", Tags: []string{"challenges"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/challenges/:code/chat", Summary: "Read the challenge chat", Tags: []string{"challenges"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "since", In: "query", Description: "Only messages created after this time (RFC 3339); without it the latest messages", Example: "2024-01-01T00:00:00Z"},
+				{Name: "limit", In: "query", Description: "Maximum number of messages (1-100, default 50)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.ChatMessages{}}},
+		{Method: http.MethodPost, Path: "/api/challenges/:code/chat", Summary: "Post to the challenge chat", Tags: []string{"challenges"}, Auth: true,
+			Request: domain.PostChatMessageRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ChatMessageResponse{}}},
+		{Method: http.MethodGet, Path: "/api/spectate/:spectatorCode", Summary: "Watch a challenge's standings as a spectator", Tags: []string{"challenges"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.SpectatorView{}}},
+
+		// Organizations
+		{Method: http.MethodPost, Path: "/api/orgs", Summary: "Create an organization (class) with the caller as instructor", Tags: []string{"orgs"}, Auth: true,
+			Request: domain.CreateOrgRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.OrgResponse{}}},
+		{Method: http.MethodGet, Path: "/api/orgs", Summary: "List the caller's organizations", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"orgs": []domain.OrgSummary{}}}},
+		{Method: http.MethodPost, Path: "/api/orgs/join", Summary: "Join an organization with an invite code", Tags: []string{"orgs"}, Auth: true,
+			Request: domain.JoinOrgRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.OrgResponse{}}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id", Summary: "Get an organization with its members", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.OrgResponse{}}},
+		{Method: http.MethodPost, Path: "/api/orgs/:id/invites", Summary: "Create an invite code (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Request: domain.CreateOrgInviteRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.OrgInvite{}}},
+		{Method: http.MethodDelete, Path: "/api/orgs/:id/invites/:code", Summary: "Revoke an invite code (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodDelete, Path: "/api/orgs/:id/members/:userId", Summary: "Remove a member (instructors), or leave the organization", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id/roster", Summary: "Roster progress dashboard of the students (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "limit", In: "query", Description: "Maximum number of students (1-200, default 50)", Example: 0},
+				{Name: "offset", In: "query", Description: "Number of students to skip", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.OrgRoster{}}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id/assignments", Summary: "List assignments with the caller's progress", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"assignments": []domain.OrgAssignmentResponse{}}}},
+		{Method: http.MethodPost, Path: "/api/orgs/:id/assignments", Summary: "Assign a contest, study plan or problem set to the class (instructors); problem sets are pushed to students as pending contests", Tags: []string{"orgs"}, Auth: true,
+			Request: domain.CreateAssignmentRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.OrgAssignmentResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/orgs/:id/assignments/:assignmentId", Summary: "Delete an assignment (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/orgs/:id/assignments/:assignmentId/start", Summary: "Start the contest of a contest assignment, or the pending contest of a problem set", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusCreated: domain.ContestResponse{}}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id/assignments/:assignmentId/report", Summary: "Per-student completion report of an assignment (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "format", In: "query", Description: "Set to \"csv\" to download the report as CSV", Example: ""},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.AssignmentReport{}}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id/contests/:contestId/proctoring", Summary: "Focus and tab switch summary of a student's assignment contest (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ProctoringSummary{}}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id/assignments/:assignmentId/similarity", Summary: "Pairs of students' solutions to an assignment flagged as highly similar (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "status", In: "query", Description: "Only flags with this review status: pending, confirmed or dismissed", Example: ""},
+			},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"flags": []domain.SimilarityFlagResponse{}}}},
+		{Method: http.MethodGet, Path: "/api/orgs/:id/similarity/:flagId", Summary: "A flagged solution pair with both solutions (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.SimilarityFlagDetail{}}},
+		{Method: http.MethodPatch, Path: "/api/orgs/:id/similarity/:flagId", Summary: "Confirm or dismiss a flagged solution pair (instructors)", Tags: []string{"orgs"}, Auth: true,
+			Request: domain.ReviewSimilarityRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.SimilarityFlagResponse{}}},
+
+		// Mentorships
+		{Method: http.MethodPost, Path: "/api/mentorships", Summary: "Invite a user, by email, to be mentored by the caller", Tags: []string{"mentorships"}, Auth: true,
+			Request: domain.CreateMentorshipRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.Mentorship{}}},
+		{Method: http.MethodGet, Path: "/api/mentorships", Summary: "The caller's mentorships as mentor and as mentee", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.MentorshipList{}}},
+		{Method: http.MethodPost, Path: "/api/mentorships/:id/accept", Summary: "Consent to a mentorship invite (mentee)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.Mentorship{}}},
+		{Method: http.MethodPost, Path: "/api/mentorships/:id/decline", Summary: "Decline a mentorship invite (mentee)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.Mentorship{}}},
+		{Method: http.MethodDelete, Path: "/api/mentorships/:id", Summary: "Revoke a mentorship or withdraw an invite (either side)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/mentorships/:id/contests", Summary: "The mentee's contests (mentor)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"contests": []domain.ContestResponse{}}}},
+		{Method: http.MethodGet, Path: "/api/mentorships/:id/contests/:contestId", Summary: "One of the mentee's contests (mentor)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ContestResponse{}}},
+		{Method: http.MethodGet, Path: "/api/mentorships/:id/timing", Summary: "The mentee's solve times and contest statistics (mentor)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.MenteeTiming{}}},
+		{Method: http.MethodGet, Path: "/api/mentorships/:id/notes", Summary: "The mentee's contest retro notes (mentor)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"notes": []domain.MenteeNote{}}}},
+		{Method: http.MethodGet, Path: "/api/mentorships/:id/audit", Summary: "Audit trail of consent changes and mentor reads (either side)", Tags: []string{"mentorships"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "limit", In: "query", Description: "Maximum number of entries (1-200, default 50)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.MentorshipAudit{}}},
+		{Method: http.MethodGet, Path: "/api/mentorships/:id/assignments", Summary: "Assignments with the mentee's progress (either side)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"assignments": []domain.MentorAssignmentResponse{}}}},
+		{Method: http.MethodPost, Path: "/api/mentorships/:id/assignments", Summary: "Assign a contest or problem set with a due date (mentor); problem sets are pushed to the mentee as a pending contest", Tags: []string{"mentorships"}, Auth: true,
+			Request: domain.CreateMentorAssignmentRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.MentorAssignmentResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/mentorships/:id/assignments/:assignmentId", Summary: "Delete an assignment (mentor)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/mentorships/:id/assignments/:assignmentId/start", Summary: "Start the contest of an assignment (mentee)", Tags: []string{"mentorships"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusCreated: domain.ContestResponse{}}},
+
+		// Community problem lists
+		{Method: http.MethodGet, Path: "/api/lists", Summary: "Browse published community problem lists", Tags: []string{"lists"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "q", In: "query", Description: "Only lists whose title or description contain this text", Example: ""},
+				{Name: "sort", In: "query", Description: "popular (most subscribers, default) or newest", Example: ""},
+				{Name: "subscribed", In: "query", Description: "Only lists you subscribe to", Example: false},
+				{Name: "mine", In: "query", Description: "Only your own lists, hidden ones included", Example: false},
+				{Name: "limit", In: "query", Description: "Page size (1-100, default 20)", Example: 0},
+				{Name: "offset", In: "query", Description: "Lists to skip", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemListPage{}}},
+		{Method: http.MethodPost, Path: "/api/lists", Summary: "Publish a curated, ordered list of catalog problems", Tags: []string{"lists"}, Auth: true,
+			Request: domain.ProblemListRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ProblemListResponse{}}},
+		{Method: http.MethodGet, Path: "/api/lists/:id", Summary: "Get a community list with its problems and which you solved", Tags: []string{"lists"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemListResponse{}}},
+		{Method: http.MethodPut, Path: "/api/lists/:id", Summary: "Replace one of your lists", Tags: []string{"lists"}, Auth: true,
+			Request: domain.ProblemListRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemListResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/lists/:id", Summary: "Delete one of your lists", Tags: []string{"lists"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPut, Path: "/api/lists/:id/subscription", Summary: "Subscribe to a community list", Tags: []string{"lists"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemListSummary{}}},
+		{Method: http.MethodDelete, Path: "/api/lists/:id/subscription", Summary: "Unsubscribe from a community list", Tags: []string{"lists"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodPost, Path: "/api/lists/:id/contests", Summary: "Start a contest from the next problems of a list you own or subscribe to", Tags: []string{"lists"}, Auth: true,
+			Request: domain.CreateListContestRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.ContestResponse{}}},
+		{Method: http.MethodPost, Path: "/api/lists/:id/reports", Summary: "Report a community list to the moderators", Tags: []string{"lists"}, Auth: true,
+			Request: domain.ReportProblemListRequest{}, Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+
+		// Assignments
+		{Method: http.MethodGet, Path: "/api/assignments", Summary: "The caller's unfinished assignments from their organizations and mentors, soonest due first", Tags: []string{"assignments"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"assignments": []domain.PendingAssignment{}}}},
+
+		// Quick commands
+		{Method: http.MethodPost, Path: "/api/quick", Summary: "Run a quick command such as \"start 5x90\", \"done 3\" or \"skip\"", Tags: []string{"quick"}, Auth: true,
+			Request: domain.QuickCommandRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.QuickResult{}}},
+		{Method: http.MethodGet, Path: "/api/quick/history", Summary: "List the latest quick commands and their outcome", Tags: []string{"quick"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "limit", In: "query", Description: "Maximum number of commands (1-100, default 20)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.QuickHistory{}}},
+
+		// Billing
+		{Method: http.MethodPost, Path: "/api/billing/checkout", Summary: "Start a premium subscription checkout", Tags: []string{"billing"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusCreated: domain.CheckoutSessionResponse{}}},
+		{Method: http.MethodPost, Path: "/api/billing/webhook", Summary: "Receive a signed Stripe webhook event", Tags: []string{"billing"},
+			Params: []openapi.Param{
+				{Name: "Stripe-Signature", In: "header", Description: "Signature Stripe computed over the raw body", Example: ""},
+			},
+			Request:   openapi.Object{"id": "", "type": "", "created": 0, "data": openapi.Object{"object": openapi.Object{}}},
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+
+		// Admin
+		{Method: http.MethodGet, Path: "/api/admin/problems/calibration", Summary: "Per-problem usage counters", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"problems": []domain.ProblemCalibration{}}}},
+		{Method: http.MethodPut, Path: "/api/admin/problems/:id/companies", Summary: "Replace problem company tags", Tags: []string{"admin"}, Auth: true,
+			Request: domain.SetProblemCompaniesRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemResponse{}}},
+		{Method: http.MethodPatch, Path: "/api/admin/problems/:id/importance", Summary: "Tune problem importance score", Tags: []string{"admin"}, Auth: true,
+			Request: domain.SetProblemImportanceRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemResponse{}}},
+		{Method: http.MethodPut, Path: "/api/admin/problems/:id/complexity", Summary: "Set the canonical complexity of a problem", Tags: []string{"admin"}, Auth: true,
+			Request: domain.SetProblemComplexityRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemComplexity{}}},
+		{Method: http.MethodPost, Path: "/api/admin/users/:id/revoke-tokens", Summary: "Sign a user out on all devices", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: messageResponse}},
+		{Method: http.MethodGet, Path: "/api/admin/users/:id/quotas", Summary: "Plan and remaining allowances of a user", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.QuotaStatus{}}},
+		{Method: http.MethodPut, Path: "/api/admin/users/:id/quotas", Summary: "Set a user's plan and override their quotas", Tags: []string{"admin"}, Auth: true,
+			Request: domain.SetQuotaOverrideRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.QuotaStatus{}}},
+		{Method: http.MethodDelete, Path: "/api/admin/users/:id/quotas", Summary: "Drop a user's quota override", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.QuotaStatus{}}},
+		{Method: http.MethodGet, Path: "/api/admin/feature-flags", Summary: "List feature flags and their rollout", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.FeatureFlagListResponse{}}},
+		{Method: http.MethodPut, Path: "/api/admin/feature-flags/:key", Summary: "Turn a feature flag on or off for a share of users", Tags: []string{"admin"}, Auth: true,
+			Request: domain.UpdateFeatureFlagRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.FeatureFlag{}}},
+		{Method: http.MethodGet, Path: "/api/admin/experiments", Summary: "Completion rates per experiment variant", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.ExperimentsResponse{}}},
+		{Method: http.MethodPut, Path: "/api/admin/maintenance", Summary: "Start, schedule or end maintenance", Tags: []string{"admin"}, Auth: true,
+			Request: domain.SetMaintenanceRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.MaintenanceStatus{}}},
+		{Method: http.MethodGet, Path: "/api/admin/analytics/cohorts", Summary: "Weekly signup cohorts with retention and contest activity", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.CohortsResponse{}}},
+		{Method: http.MethodGet, Path: "/api/admin/log-level", Summary: "Log level in effect", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.LogLevelStatus{}}},
+		{Method: http.MethodPut, Path: "/api/admin/log-level", Summary: "Change the log level of every instance at runtime", Tags: []string{"admin"}, Auth: true,
+			Request: domain.SetLogLevelRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.LogLevelStatus{}}},
+		{Method: http.MethodPost, Path: "/api/admin/integrity", Summary: "Detect and repair inconsistent data and recompute progress and usage counters", Tags: []string{"admin"}, Auth: true,
+			Request: domain.RunIntegrityRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.IntegrityReport{}}},
+		{Method: http.MethodPost, Path: "/api/admin/retention", Summary: "Delete data older than its configured retention and report what each policy found", Tags: []string{"admin"}, Auth: true,
+			Request: domain.RunRetentionRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.RetentionReport{}}},
+		{Method: http.MethodPost, Path: "/api/admin/digests/send", Summary: "Send the recommendation digests that are due now", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.DigestRunReport{}}},
+		{Method: http.MethodGet, Path: "/api/admin/digests/stats", Summary: "Click-through and solve rates of recent recommendation digests", Tags: []string{"admin"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "days", In: "query", Description: "Digests sent in this many days (1-365, default 28)", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.DigestStats{}}},
+		{Method: http.MethodPost, Path: "/api/admin/similarity/check", Summary: "Compare the solution snippets changed since the last similarity round", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.SimilarityRunReport{}}},
+		{Method: http.MethodGet, Path: "/api/admin/tenants", Summary: "List the hosted tenants", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{"tenants": []domain.Tenant{}}}},
+		{Method: http.MethodPost, Path: "/api/admin/tenants", Summary: "Host a new tenant with isolated users, organizations and leaderboard", Tags: []string{"admin"}, Auth: true,
+			Request: domain.CreateTenantRequest{}, Responses: map[int]interface{}{http.StatusCreated: domain.Tenant{}}},
+		{Method: http.MethodGet, Path: "/api/admin/backups", Summary: "List the stored backups, newest first", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.BackupListResponse{}}},
+		{Method: http.MethodPost, Path: "/api/admin/backups", Summary: "Back up users, custom problems, contests, submissions and attempts to object storage", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusCreated: domain.BackupManifest{}}},
+		{Method: http.MethodPost, Path: "/api/admin/backups/:id/verify", Summary: "Check the checksums and row counts of a stored backup", Tags: []string{"admin"}, Auth: true,
+			Responses: map[int]interface{}{http.StatusOK: domain.BackupManifest{}}},
+		{Method: http.MethodGet, Path: "/api/admin/lists", Summary: "Moderation queue of community lists, most reported first", Tags: []string{"admin"}, Auth: true,
+			Params: []openapi.Param{
+				{Name: "status", In: "query", Description: "published or hidden; every reported list when omitted", Example: ""},
+				{Name: "limit", In: "query", Description: "Page size (1-100, default 20)", Example: 0},
+				{Name: "offset", In: "query", Description: "Lists to skip", Example: 0},
+			},
+			Responses: map[int]interface{}{http.StatusOK: domain.ProblemListPage{}}},
+		{Method: http.MethodPatch, Path: "/api/admin/lists/:id", Summary: "Hide or restore a community list; restoring clears its reports", Tags: []string{"admin"}, Auth: true,
+			Request: domain.ModerateProblemListRequest{}, Responses: map[int]interface{}{http.StatusOK: domain.ProblemListSummary{}}},
+
+		// Documentation
+		{Method: http.MethodGet, Path: "/api/openapi.json", Summary: "OpenAPI specification", Tags: []string{"docs"},
+			Responses: map[int]interface{}{http.StatusOK: openapi.Object{}}},
+		{Method: http.MethodGet, Path: "/api/docs", Summary: "Interactive API documentation", Tags: []string{"docs"},
+			ContentType: "text/html", Responses: map[int]interface{}{http.StatusOK: ""}},
+	}
+}
+
+// BuildOpenAPI builds the OpenAPI document for the API
+func BuildOpenAPI(version string) *openapi.Document {
+	return openapi.Build(openapi.Info{
+		Title:       "Contest Maker 150 API",
+		Version:     version,
+		Description: "Timed coding contests generated from the NeetCode 150 problem set.",
+	}, APIOperations(), middleware.ErrorResponse{})
+}
+
+// DocsHandler serves the OpenAPI specification and an interactive docs UI
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler creates a new docs handler, rendering the spec once up front
+func NewDocsHandler(version string) (*DocsHandler, error) {
+	spec, err := BuildOpenAPI(version).JSON()
+	if err != nil {
+		return nil, err
+	}
+	return &DocsHandler{spec: spec}, nil
+}
+
+// GetSpec returns the OpenAPI specification
+// GET /api/openapi.json
+func (h *DocsHandler) GetSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", h.spec)
+}
+
+// GetDocs returns the interactive documentation page
+// GET /api/docs
+func (h *DocsHandler) GetDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+// swaggerUIPage renders Swagger UI against the served specification
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Contest Maker 150 API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: '/api/openapi.json', dom_id: '#swagger-ui' });
+    };
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// OrgHandler handles organizations (classrooms), their invites, assignments and roster
+type OrgHandler struct {
+	orgService *service.OrgService
+}
+
+// NewOrgHandler creates a new organization handler
+func NewOrgHandler(orgService *service.OrgService) *OrgHandler {
+	return &OrgHandler{
+		orgService: orgService,
+	}
+}
+
+// CreateOrg creates an organization with the caller as its instructor
+// POST /api/orgs
+func (h *OrgHandler) CreateOrg(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateOrgRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	org, err := h.orgService.CreateOrg(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// GetOrgs lists the caller's organizations
+// GET /api/orgs
+func (h *OrgHandler) GetOrgs(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	orgs, err := h.orgService.GetOrgs(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orgs": orgs})
+}
+
+// GetOrg returns an organization with its members
+// GET /api/orgs/:id
+func (h *OrgHandler) GetOrg(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.GetOrg(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// JoinOrg joins the organization of an invite code
+// POST /api/orgs/join
+func (h *OrgHandler) JoinOrg(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.JoinOrgRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	org, err := h.orgService.JoinOrg(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// CreateInvite creates an invite code for the organization
+// POST /api/orgs/:id/invites
+func (h *OrgHandler) CreateInvite(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional
+	var req domain.CreateOrgInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	invite, err := h.orgService.CreateInvite(c.Request.Context(), userID, orgID, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+// RevokeInvite revokes an invite code
+// DELETE /api/orgs/:id/invites/:code
+func (h *OrgHandler) RevokeInvite(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.orgService.RevokeInvite(c.Request.Context(), userID, orgID, c.Param("code")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invite revoked"})
+}
+
+// RemoveMember removes a member, or lets the caller leave
+// DELETE /api/orgs/:id/members/:userId
+func (h *OrgHandler) RemoveMember(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid user ID", nil))
+		return
+	}
+
+	if err := h.orgService.RemoveMember(c.Request.Context(), userID, orgID, memberID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// GetAssignments lists the organization's assignments with the caller's progress
+// GET /api/orgs/:id/assignments
+func (h *OrgHandler) GetAssignments(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.orgService.GetAssignments(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
+
+// CreateAssignment assigns a contest, study plan or problem set to the class
+// POST /api/orgs/:id/assignments
+func (h *OrgHandler) CreateAssignment(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	assignment, err := h.orgService.CreateAssignment(c.Request.Context(), userID, orgID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// DeleteAssignment deletes an assignment
+// DELETE /api/orgs/:id/assignments/:assignmentId
+func (h *OrgHandler) DeleteAssignment(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid assignment ID", nil))
+		return
+	}
+
+	if err := h.orgService.DeleteAssignment(c.Request.Context(), userID, orgID, assignmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Assignment deleted"})
+}
+
+// StartAssignment starts the caller's contest for a contest assignment or problem set
+// POST /api/orgs/:id/assignments/:assignmentId/start
+func (h *OrgHandler) StartAssignment(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid assignment ID", nil))
+		return
+	}
+
+	contest, err := h.orgService.StartAssignment(c.Request.Context(), userID, orgID, assignmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contest.ToResponse())
+}
+
+// GetAssignmentReport returns every student's progress on an assignment, as
+// JSON or as a CSV download
+// GET /api/orgs/:id/assignments/:assignmentId/report
+func (h *OrgHandler) GetAssignmentReport(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid assignment ID", nil))
+		return
+	}
+
+	var query domain.AssignmentReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	report, err := h.orgService.GetAssignmentReport(c.Request.Context(), userID, orgID, assignmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if query.Format == "csv" {
+		writeReportCSV(c, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// writeReportCSV writes an assignment report as a CSV attachment, one student per row
+func writeReportCSV(c *gin.Context, report *domain.AssignmentReport) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="assignment-%s.csv"`, report.Assignment.ID))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"user_id", "username", "status", "solved", "total", "started_at", "ended_at", "overdue", "late", "focus_losses", "tab_switches"})
+	for _, m := range report.Members {
+		_ = w.Write([]string{
+			m.UserID.String(),
+			m.Username,
+			string(m.Status),
+			strconv.Itoa(m.Solved),
+			strconv.Itoa(m.Total),
+			csvTime(m.StartedAt),
+			csvTime(m.EndedAt),
+			strconv.FormatBool(m.Overdue),
+			strconv.FormatBool(m.Late),
+			strconv.Itoa(m.FocusLosses),
+			strconv.Itoa(m.TabSwitches),
+		})
+	}
+	w.Flush()
+}
+
+// csvTime formats an optional time for a CSV cell, empty when unset
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// GetRoster returns the roster progress dashboard
+// GET /api/orgs/:id/roster
+func (h *OrgHandler) GetRoster(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	var query domain.OrgRosterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	roster, err := h.orgService.GetRoster(c.Request.Context(), userID, orgID, query.Limit, query.Offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// orgParams returns the caller and the organization ID of the path, reporting
+// the error when either is missing
+func orgParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid organization ID", nil))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, orgID, true
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// PresenceHandler handles presence HTTP requests
+type PresenceHandler struct {
+	presenceService *service.PresenceService
+}
+
+// NewPresenceHandler creates a new presence handler
+func NewPresenceHandler(presenceService *service.PresenceService) *PresenceHandler {
+	return &PresenceHandler{
+		presenceService: presenceService,
+	}
+}
+
+// Heartbeat marks the current user as online, or in their contest when one is given
+// POST /api/users/me/heartbeat
+func (h *PresenceHandler) Heartbeat(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional: without a contest the user is only online
+	var req domain.HeartbeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	presence, err := h.presenceService.Heartbeat(c.Request.Context(), userID, req.ContestID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presence)
+}
@@ -1,42 +1,79 @@
 package handler
 
 import (
+	"encoding/xml"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
 	"github.com/contest-maker-150/backend/internal/service"
 )
 
+// publicPageMaxAge is how long, in seconds, clients and CDNs may cache public
+// problem pages and the sitemap; the catalog rarely changes
+const publicPageMaxAge = "3600"
+
 // ProblemHandler handles problem-related HTTP requests
 type ProblemHandler struct {
-	problemService *service.ProblemService
+	problemService service.ProblemServicer
+	filterService  service.SavedFilterServicer
 }
 
 // NewProblemHandler creates a new problem handler
-func NewProblemHandler(problemService *service.ProblemService) *ProblemHandler {
+func NewProblemHandler(problemService service.ProblemServicer, filterService service.SavedFilterServicer) *ProblemHandler {
 	return &ProblemHandler{
 		problemService: problemService,
+		filterService:  filterService,
 	}
 }
 
-// GetProblems returns all problems
+// GetProblems returns all problems, optionally narrowed by inline criteria
+// or a saved filter (?filter_id=)
 // GET /api/problems
 func (h *ProblemHandler) GetProblems(c *gin.Context) {
-	problems, err := h.problemService.GetAllProblems(c.Request.Context())
+	var query domain.ProblemListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	criteria := query.Criteria()
+	userID, _ := middleware.GetUserID(c)
+	if query.FilterID != "" || criteria.NeedsUser() {
+		var ok bool
+		if userID, ok = middleware.RequireUser(c); !ok {
+			return
+		}
+	}
+
+	if query.FilterID != "" {
+		filter, err := h.filterService.GetFilter(c.Request.Context(), userID, uuid.MustParse(query.FilterID))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		criteria = filter.Criteria()
+	}
+
+	problems, err := h.problemService.FindProblems(c.Request.Context(), userID, criteria)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve problems",
-		})
+		c.Error(err)
 		return
 	}
 
 	// Convert to response format
+	withPopularity := includesPopularity(c)
 	responses := make([]domain.ProblemResponse, len(problems))
 	for i, problem := range problems {
-		responses[i] = problem.ToResponse()
+		if withPopularity {
+			responses[i] = problem.ToResponseWithPopularity()
+		} else {
+			responses[i] = problem.ToResponse()
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -45,46 +82,258 @@ func (h *ProblemHandler) GetProblems(c *gin.Context) {
 	})
 }
 
+// defaultSearchResults is how many matches a search returns without ?limit
+const defaultSearchResults = 20
+
+// SearchProblems finds problems by title or topic with fuzzy and prefix matching
+// GET /api/problems/search
+func (h *ProblemHandler) SearchProblems(c *gin.Context) {
+	var query domain.ProblemSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchResults
+	}
+
+	results, err := h.problemService.SearchProblems(c.Request.Context(), query.Q, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
 // GetProblem returns a specific problem by ID
 // GET /api/problems/:id
 func (h *ProblemHandler) GetProblem(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid problem ID",
-		})
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
 		return
 	}
 
-	problem, err := h.problemService.GetProblemByID(c.Request.Context(), id)
+	viewerID, _ := middleware.GetUserID(c)
+	problem, err := h.problemService.GetProblemByID(c.Request.Context(), id, viewerID)
 	if err != nil {
-		switch err {
-		case domain.ErrProblemNotFound:
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Problem not found",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to retrieve problem",
-			})
-		}
+		c.Error(err)
 		return
 	}
 
+	if includesPopularity(c) {
+		c.JSON(http.StatusOK, problem.ToResponseWithPopularity())
+		return
+	}
 	c.JSON(http.StatusOK, problem.ToResponse())
 }
 
+// GetProblemsBatch returns the problems with the given IDs or slugs in one response
+// POST /api/problems/batch
+func (h *ProblemHandler) GetProblemsBatch(c *gin.Context) {
+	var req domain.ProblemBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError(fmt.Sprintf("Provide 1 to %d problem IDs or slugs", domain.MaxBatchProblems), err.Error()))
+		return
+	}
+
+	viewerID, _ := middleware.GetUserID(c)
+	problems, notFound, err := h.problemService.GetProblemsBatch(c.Request.Context(), req.Keys, viewerID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	withPopularity := includesPopularity(c)
+	responses := make([]domain.ProblemResponse, len(problems))
+	for i, problem := range problems {
+		if withPopularity {
+			responses[i] = problem.ToResponseWithPopularity()
+		} else {
+			responses[i] = problem.ToResponse()
+		}
+	}
+
+	c.JSON(http.StatusOK, domain.ProblemBatchResponse{
+		Problems: responses,
+		Count:    len(responses),
+		NotFound: notFound,
+	})
+}
+
+// GetProblemPage returns a catalog problem by slug with the metadata its public page needs
+// GET /api/problems/slug/:slug
+func (h *ProblemHandler) GetProblemPage(c *gin.Context) {
+	page, err := h.problemService.GetProblemPage(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age="+publicPageMaxAge)
+	c.JSON(http.StatusOK, page)
+}
+
+// GetSitemap returns the sitemap of the public problem pages
+// GET /api/sitemap.xml
+func (h *ProblemHandler) GetSitemap(c *gin.Context) {
+	sitemap, err := h.problemService.GetSitemap(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	body, err := xml.MarshalIndent(sitemap, "", "  ")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+publicPageMaxAge)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+// GetPrerequisites returns the direct prerequisites of a problem
+// GET /api/problems/:id/prerequisites
+func (h *ProblemHandler) GetPrerequisites(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	prerequisites, err := h.problemService.GetPrerequisites(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]domain.ProblemResponse, len(prerequisites))
+	for i, problem := range prerequisites {
+		responses[i] = problem.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, domain.ProblemPrerequisitesResponse{
+		ProblemID:     id,
+		Prerequisites: responses,
+		Count:         len(responses),
+	})
+}
+
 // GetProblemStats returns statistics about the problem set
 // GET /api/problems/stats
 func (h *ProblemHandler) GetProblemStats(c *gin.Context) {
 	stats, err := h.problemService.GetProblemStats(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve problem statistics",
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, stats)
 }
+
+// GetCompanies returns every company with its number of tagged problems
+// GET /api/companies
+func (h *ProblemHandler) GetCompanies(c *gin.Context) {
+	companies, err := h.problemService.GetCompanies(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"companies": companies,
+	})
+}
+
+// SetProblemCompanies replaces the company tags of a problem
+// PUT /api/admin/problems/:id/companies
+func (h *ProblemHandler) SetProblemCompanies(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	var req domain.SetProblemCompaniesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	problem, err := h.problemService.SetProblemCompanies(c.Request.Context(), id, req.Companies)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, problem.ToResponse())
+}
+
+// SetProblemImportance tunes the importance score of a problem
+// PATCH /api/admin/problems/:id/importance
+func (h *ProblemHandler) SetProblemImportance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	var req domain.SetProblemImportanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	problem, err := h.problemService.SetProblemImportance(c.Request.Context(), id, req.Importance)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, problem.ToResponse())
+}
+
+// SetProblemComplexity sets the canonical complexity of a problem's optimal solution
+// PUT /api/admin/problems/:id/complexity
+func (h *ProblemHandler) SetProblemComplexity(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	var req domain.SetProblemComplexityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	complexity, err := h.problemService.SetProblemComplexity(c.Request.Context(), id, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, complexity)
+}
+
+// GetCalibration returns per-problem usage counters for difficulty calibration
+// GET /api/admin/problems/calibration
+func (h *ProblemHandler) GetCalibration(c *gin.Context) {
+	calibration, err := h.problemService.GetCalibration(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"problems": calibration,
+	})
+}
+
+// includesPopularity reports whether the client asked for usage counters (?include=popularity)
+func includesPopularity(c *gin.Context) bool {
+	return c.Query("include") == "popularity"
+}
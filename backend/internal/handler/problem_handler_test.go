@@ -0,0 +1,133 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service/mocks"
+)
+
+// newProblemRouter routes the problem endpoints to a handler on problems and
+// filters; as in the API, signing in is optional
+func newProblemRouter(problems *mocks.ProblemServicer, filters *mocks.SavedFilterServicer) *gin.Engine {
+	users := &mocks.UserServicer{}
+	router, _ := newRouter(users)
+	h := handler.NewProblemHandler(problems, filters)
+	group := router.Group("/api/problems", middleware.OptionalAuthMiddleware(users))
+	group.GET("", h.GetProblems)
+	group.GET("/:id", h.GetProblem)
+	return router
+}
+
+var catalog = []domain.Problem{
+	{ID: uuid.New(), Title: "Two Sum", Slug: "two-sum", Difficulty: domain.DifficultyEasy},
+	{ID: uuid.New(), Title: "LRU Cache", Slug: "lru-cache", Difficulty: domain.DifficultyMedium},
+}
+
+func TestGetProblems(t *testing.T) {
+	problems := &mocks.ProblemServicer{
+		FindProblemsFunc: func(_ context.Context, _ uuid.UUID, criteria domain.ProblemCriteria) ([]domain.Problem, error) {
+			if len(criteria.Difficulties) == 0 {
+				return catalog, nil
+			}
+			var found []domain.Problem
+			for _, p := range catalog {
+				for _, d := range criteria.Difficulties {
+					if p.Difficulty == d {
+						found = append(found, p)
+					}
+				}
+			}
+			return found, nil
+		},
+	}
+	router := newProblemRouter(problems, &mocks.SavedFilterServicer{})
+
+	var resp struct {
+		Problems []domain.ProblemResponse `json:"problems"`
+		Count    int                      `json:"count"`
+	}
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/problems", nil, ""), http.StatusOK, &resp)
+	if resp.Count != len(catalog) || len(resp.Problems) != len(catalog) {
+		t.Fatalf("listed %d problems (count %d), want %d", len(resp.Problems), resp.Count, len(catalog))
+	}
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/problems?difficulty=Medium", nil, ""), http.StatusOK, &resp)
+	if resp.Count != 1 || resp.Problems[0].Slug != "lru-cache" {
+		t.Fatalf("medium problems = %+v", resp.Problems)
+	}
+}
+
+func TestGetProblemsWithSavedFilter(t *testing.T) {
+	filterID := uuid.New()
+	filters := &mocks.SavedFilterServicer{
+		GetFilterFunc: func(_ context.Context, uid, id uuid.UUID) (*domain.SavedFilter, error) {
+			if uid != userID || id != filterID {
+				return nil, domain.ErrFilterNotFound
+			}
+			return &domain.SavedFilter{ID: id, UserID: uid, Difficulties: domain.StringList{string(domain.DifficultyEasy)}}, nil
+		},
+	}
+	problems := &mocks.ProblemServicer{
+		FindProblemsFunc: func(_ context.Context, uid uuid.UUID, criteria domain.ProblemCriteria) ([]domain.Problem, error) {
+			if uid != userID || len(criteria.Difficulties) != 1 || criteria.Difficulties[0] != domain.DifficultyEasy {
+				t.Errorf("found problems for %s with %+v, want the saved filter's criteria", uid, criteria)
+			}
+			return catalog[:1], nil
+		},
+	}
+	router := newProblemRouter(problems, filters)
+
+	var resp struct {
+		Count int `json:"count"`
+	}
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/problems?filter_id="+filterID.String(), nil, validToken), http.StatusOK, &resp)
+	if resp.Count != 1 {
+		t.Fatalf("filtered count = %d, want 1", resp.Count)
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		token  string
+		status int
+		code   string
+	}{
+		{"signed out", "filter_id=" + filterID.String(), "", http.StatusUnauthorized, domain.CodeUnauthorized},
+		{"unknown filter", "filter_id=" + uuid.NewString(), validToken, http.StatusNotFound, domain.CodeFilterNotFound},
+		{"malformed filter", "filter_id=recent", validToken, http.StatusBadRequest, domain.CodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, serve(t, router, http.MethodGet, "/api/problems?"+tt.query, nil, tt.token), tt.status, tt.code)
+		})
+	}
+}
+
+func TestGetProblem(t *testing.T) {
+	problems := &mocks.ProblemServicer{
+		GetProblemByIDFunc: func(_ context.Context, id, _ uuid.UUID) (*domain.Problem, error) {
+			for _, p := range catalog {
+				if p.ID == id {
+					return &p, nil
+				}
+			}
+			return nil, domain.ErrProblemNotFound
+		},
+	}
+	router := newProblemRouter(problems, &mocks.SavedFilterServicer{})
+
+	var problem domain.ProblemResponse
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/problems/"+catalog[1].ID.String(), nil, ""), http.StatusOK, &problem)
+	if problem.ID != catalog[1].ID || problem.Title != catalog[1].Title {
+		t.Fatalf("problem = %+v", problem)
+	}
+	expectError(t, serve(t, router, http.MethodGet, "/api/problems/"+uuid.NewString(), nil, ""), http.StatusNotFound, domain.CodeProblemNotFound)
+	expectError(t, serve(t, router, http.MethodGet, "/api/problems/two-sum", nil, ""), http.StatusBadRequest, domain.CodeValidationFailed)
+}
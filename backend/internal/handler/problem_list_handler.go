@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ProblemListHandler handles community problem list requests
+type ProblemListHandler struct {
+	listService *service.ProblemListService
+}
+
+// NewProblemListHandler creates a new community list handler
+func NewProblemListHandler(listService *service.ProblemListService) *ProblemListHandler {
+	return &ProblemListHandler{
+		listService: listService,
+	}
+}
+
+// BrowseLists returns a page of the published community lists
+// GET /api/lists
+func (h *ProblemListHandler) BrowseLists(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.BrowseListsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	page, err := h.listService.BrowseLists(c.Request.Context(), userID, &query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// PublishList publishes a new community list
+// POST /api/lists
+func (h *ProblemListHandler) PublishList(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ProblemListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	list, err := h.listService.PublishList(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// GetList returns a community list with its problems
+// GET /api/lists/:id
+func (h *ProblemListHandler) GetList(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.listService.GetList(c.Request.Context(), userID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// UpdateList replaces one of the user's lists
+// PUT /api/lists/:id
+func (h *ProblemListHandler) UpdateList(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ProblemListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	list, err := h.listService.UpdateList(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteList deletes one of the user's lists
+// DELETE /api/lists/:id
+func (h *ProblemListHandler) DeleteList(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.listService.DeleteList(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "List deleted"})
+}
+
+// Subscribe subscribes the user to a community list
+// PUT /api/lists/:id/subscription
+func (h *ProblemListHandler) Subscribe(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.listService.Subscribe(c.Request.Context(), userID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Unsubscribe removes the user's subscription to a community list
+// DELETE /api/lists/:id/subscription
+func (h *ProblemListHandler) Unsubscribe(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.listService.Unsubscribe(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed from list"})
+}
+
+// CreateContest starts a contest from the next problems of a community list
+// POST /api/lists/:id/contests
+func (h *ProblemListHandler) CreateContest(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateListContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	contest, err := h.listService.CreateContest(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contest.ToResponse())
+}
+
+// ReportList reports a community list to the moderators
+// POST /api/lists/:id/reports
+func (h *ProblemListHandler) ReportList(c *gin.Context) {
+	userID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ReportProblemListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := h.listService.ReportList(c.Request.Context(), userID, id, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "List reported"})
+}
+
+// GetModerationQueue returns the reported community lists
+// GET /api/admin/lists
+func (h *ProblemListHandler) GetModerationQueue(c *gin.Context) {
+	var query domain.ModerationListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	page, err := h.listService.GetModerationQueue(c.Request.Context(), &query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ModerateList hides or restores a community list
+// PATCH /api/admin/lists/:id
+func (h *ProblemListHandler) ModerateList(c *gin.Context) {
+	moderatorID, id, ok := listParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ModerateProblemListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	list, err := h.listService.ModerateList(c.Request.Context(), moderatorID, id, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// listParams returns the caller and the list ID of the path, reporting the
+// error when either is missing
+func listParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid list ID", nil))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, listID, true
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ProctoringHandler handles focus and tab switch events of assignment contests
+type ProctoringHandler struct {
+	proctoringService *service.ProctoringService
+}
+
+// NewProctoringHandler creates a new proctoring handler
+func NewProctoringHandler(proctoringService *service.ProctoringService) *ProctoringHandler {
+	return &ProctoringHandler{
+		proctoringService: proctoringService,
+	}
+}
+
+// RecordEvents records focus and tab switch events the client saw during the contest
+// POST /api/contests/:id/events
+func (h *ProctoringHandler) RecordEvents(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	var req domain.RecordContestEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	recorded, err := h.proctoringService.RecordEvents(c.Request.Context(), userID, contestID, req.Events)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recorded": recorded})
+}
+
+// GetSummary returns the integrity summary of a student's assignment contest
+// GET /api/orgs/:id/contests/:contestId/proctoring
+func (h *ProctoringHandler) GetSummary(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	contestID, err := uuid.Parse(c.Param("contestId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return
+	}
+
+	summary, err := h.proctoringService.GetSummary(c.Request.Context(), userID, orgID, contestID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
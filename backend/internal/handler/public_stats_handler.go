@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// defaultPublicProblems is how many problems the public problem stats list without ?limit
+const defaultPublicProblems = 10
+
+// PublicStatsHandler handles the unauthenticated platform statistics
+type PublicStatsHandler struct {
+	statsService *service.PublicStatsService
+	cacheControl string
+}
+
+// NewPublicStatsHandler creates a new public stats handler
+func NewPublicStatsHandler(statsService *service.PublicStatsService) *PublicStatsHandler {
+	return &PublicStatsHandler{
+		statsService: statsService,
+		cacheControl: "public, max-age=" + strconv.Itoa(int(statsService.CacheTTL().Seconds())),
+	}
+}
+
+// GetStats returns anonymized platform figures for the landing page
+// GET /api/public/stats
+func (h *PublicStatsHandler) GetStats(c *gin.Context) {
+	stats, err := h.statsService.GetStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", h.cacheControl)
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetProblemStats returns the most attempted catalog problems
+// GET /api/public/stats/problems
+func (h *PublicStatsHandler) GetProblemStats(c *gin.Context) {
+	var query domain.PublicProblemStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPublicProblems
+	}
+
+	stats, err := h.statsService.GetProblemStats(c.Request.Context(), query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", h.cacheControl)
+	c.JSON(http.StatusOK, stats)
+}
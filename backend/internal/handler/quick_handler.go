@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// QuickHandler handles quick command HTTP requests
+type QuickHandler struct {
+	quickService *service.QuickService
+	startAllowed func(c *gin.Context) bool // The contest creation rate limit, applied to start
+}
+
+// NewQuickHandler creates a new quick command handler. startAllowed is the
+// rate limit check of POST /api/contests, so that start commands share its budget.
+func NewQuickHandler(quickService *service.QuickService, startAllowed func(c *gin.Context) bool) *QuickHandler {
+	return &QuickHandler{
+		quickService: quickService,
+		startAllowed: startAllowed,
+	}
+}
+
+// Execute runs a quick command against the user's active contest
+// POST /api/quick
+func (h *QuickHandler) Execute(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.QuickCommandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	// Commands that do not parse are left to the service, which records them
+	if cmd, err := domain.ParseQuickCommand(req.Command); err == nil && cmd.Verb == domain.QuickStart {
+		if !h.startAllowed(c) {
+			return
+		}
+	}
+
+	result, err := h.quickService.Execute(c.Request.Context(), userID, req.Command)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetHistory returns the user's latest quick commands
+// GET /api/quick/history
+func (h *QuickHandler) GetHistory(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.QuickHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	history, err := h.quickService.GetHistory(c.Request.Context(), userID, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
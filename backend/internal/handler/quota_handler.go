@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// QuotaHandler handles plan quota HTTP requests
+type QuotaHandler struct {
+	quotaService *service.QuotaService
+}
+
+// NewQuotaHandler creates a new quota handler
+func NewQuotaHandler(quotaService *service.QuotaService) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+	}
+}
+
+// GetMyQuotas reports the current user's plan and remaining allowances
+// GET /api/users/me/quotas
+func (h *QuotaHandler) GetMyQuotas(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.quotaService.GetQuotas(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetUserQuotas reports a user's plan and remaining allowances (admin only)
+// GET /api/admin/users/:id/quotas
+func (h *QuotaHandler) GetUserQuotas(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid user ID", nil))
+		return
+	}
+
+	status, err := h.quotaService.GetQuotas(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// SetUserQuotas sets a user's plan and overrides their quotas (admin only)
+// PUT /api/admin/users/:id/quotas
+func (h *QuotaHandler) SetUserQuotas(c *gin.Context) {
+	adminID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid user ID", nil))
+		return
+	}
+
+	var req domain.SetQuotaOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	status, err := h.quotaService.SetOverride(c.Request.Context(), adminID, userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// ClearUserQuotas drops a user's quota override so their plan's limits apply (admin only)
+// DELETE /api/admin/users/:id/quotas
+func (h *QuotaHandler) ClearUserQuotas(c *gin.Context) {
+	adminID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid user ID", nil))
+		return
+	}
+
+	status, err := h.quotaService.ClearOverride(c.Request.Context(), adminID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
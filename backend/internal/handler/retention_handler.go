@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// RetentionHandler handles data retention HTTP requests
+type RetentionHandler struct {
+	retentionService *service.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retentionService *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// RunRetention deletes data older than its configured retention (admin only)
+// POST /api/admin/retention
+func (h *RetentionHandler) RunRetention(c *gin.Context) {
+	var req domain.RunRetentionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	report, err := h.retentionService.Run(c.Request.Context(), req.DryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// RoadmapHandler handles roadmap HTTP requests
+type RoadmapHandler struct {
+	roadmapService *service.RoadmapService
+}
+
+// NewRoadmapHandler creates a new roadmap handler
+func NewRoadmapHandler(roadmapService *service.RoadmapService) *RoadmapHandler {
+	return &RoadmapHandler{
+		roadmapService: roadmapService,
+	}
+}
+
+// GetRoadmap returns the roadmap, with completion for authenticated users
+// GET /api/roadmap
+func (h *RoadmapHandler) GetRoadmap(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	roadmap, err := h.roadmapService.GetRoadmap(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roadmap)
+}
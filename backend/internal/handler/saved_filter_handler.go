@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// SavedFilterHandler handles the user's saved problem filters
+type SavedFilterHandler struct {
+	filterService *service.SavedFilterService
+}
+
+// NewSavedFilterHandler creates a new saved filter handler
+func NewSavedFilterHandler(filterService *service.SavedFilterService) *SavedFilterHandler {
+	return &SavedFilterHandler{
+		filterService: filterService,
+	}
+}
+
+// GetFilters returns the user's saved filters
+// GET /api/users/me/filters
+func (h *SavedFilterHandler) GetFilters(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	filters, err := h.filterService.ListFilters(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"filters": filters,
+		"count":   len(filters),
+	})
+}
+
+// CreateFilter saves a new named filter
+// POST /api/users/me/filters
+func (h *SavedFilterHandler) CreateFilter(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	filter, err := h.filterService.CreateFilter(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, filter)
+}
+
+// UpdateFilter replaces a saved filter
+// PUT /api/users/me/filters/:filterId
+func (h *SavedFilterHandler) UpdateFilter(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	filterID, err := uuid.Parse(c.Param("filterId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid filter ID", nil))
+		return
+	}
+
+	var req domain.SavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	filter, err := h.filterService.UpdateFilter(c.Request.Context(), userID, filterID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
+
+// DeleteFilter deletes a saved filter
+// DELETE /api/users/me/filters/:filterId
+func (h *SavedFilterHandler) DeleteFilter(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	filterID, err := uuid.Parse(c.Param("filterId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid filter ID", nil))
+		return
+	}
+
+	if err := h.filterService.DeleteFilter(c.Request.Context(), userID, filterID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Filter deleted"})
+}
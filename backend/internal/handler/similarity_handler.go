@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/middleware"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// SimilarityHandler handles solution snippet and duplicate solution review HTTP requests
+type SimilarityHandler struct {
+	similarityService *service.SimilarityService
+}
+
+// NewSimilarityHandler creates a new similarity handler
+func NewSimilarityHandler(similarityService *service.SimilarityService) *SimilarityHandler {
+	return &SimilarityHandler{
+		similarityService: similarityService,
+	}
+}
+
+// AttachSolution attaches the caller's code to a problem of their contest
+// PUT /api/contests/:id/problems/:problemId/solution
+func (h *SimilarityHandler) AttachSolution(c *gin.Context) {
+	userID, contestID, problemID, ok := solutionParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AttachSolutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	snippet, err := h.similarityService.AttachSolution(c.Request.Context(), userID, contestID, problemID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snippet)
+}
+
+// GetSolution returns the code the caller attached to a problem of their contest
+// GET /api/contests/:id/problems/:problemId/solution
+func (h *SimilarityHandler) GetSolution(c *gin.Context) {
+	userID, contestID, problemID, ok := solutionParams(c)
+	if !ok {
+		return
+	}
+
+	snippet, err := h.similarityService.GetSolution(c.Request.Context(), userID, contestID, problemID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snippet)
+}
+
+// ListFlags lists the flagged solution pairs of an assignment (instructors only)
+// GET /api/orgs/:id/assignments/:assignmentId/similarity
+func (h *SimilarityHandler) ListFlags(c *gin.Context) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid assignment ID", nil))
+		return
+	}
+
+	var query domain.SimilarityFlagQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	flags, err := h.similarityService.ListFlags(c.Request.Context(), userID, orgID, assignmentID, query.Status)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flags": flags})
+}
+
+// GetFlag returns a flagged pair with both solutions (instructors only)
+// GET /api/orgs/:id/similarity/:flagId
+func (h *SimilarityHandler) GetFlag(c *gin.Context) {
+	userID, orgID, flagID, ok := flagParams(c)
+	if !ok {
+		return
+	}
+
+	detail, err := h.similarityService.GetFlag(c.Request.Context(), userID, orgID, flagID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// ReviewFlag records the instructor's verdict on a flagged pair
+// PATCH /api/orgs/:id/similarity/:flagId
+func (h *SimilarityHandler) ReviewFlag(c *gin.Context) {
+	userID, orgID, flagID, ok := flagParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ReviewSimilarityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	flag, err := h.similarityService.ReviewFlag(c.Request.Context(), userID, orgID, flagID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// CheckNow compares the snippets changed since the last round instead of
+// waiting for the next scheduled one (admin only)
+// POST /api/admin/similarity/check
+func (h *SimilarityHandler) CheckNow(c *gin.Context) {
+	report, err := h.similarityService.CheckChanged(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// solutionParams reads the caller and the contest and problem IDs of a
+// solution snippet route, reporting a validation error when one is malformed
+func solutionParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid contest ID", nil))
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	return userID, contestID, problemID, true
+}
+
+// flagParams reads the caller and the organization and flag IDs of a
+// similarity review route
+func flagParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userID, orgID, ok := orgParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	flagID, err := uuid.Parse(c.Param("flagId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid flag ID", nil))
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	return userID, orgID, flagID, true
+}
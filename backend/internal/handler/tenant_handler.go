@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// TenantHandler handles tenant management and per-tenant HTTP requests
+type TenantHandler struct {
+	tenantService *service.TenantService
+}
+
+// NewTenantHandler creates a new tenant handler
+func NewTenantHandler(tenantService *service.TenantService) *TenantHandler {
+	return &TenantHandler{
+		tenantService: tenantService,
+	}
+}
+
+// GetCurrent returns the tenant the request is for
+// GET /api/tenant
+func (h *TenantHandler) GetCurrent(c *gin.Context) {
+	tenant, err := h.tenantService.GetCurrent(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tenant)
+}
+
+// GetLeaderboard ranks the users of the caller's tenant by solved problems
+// GET /api/tenant/leaderboard
+func (h *TenantHandler) GetLeaderboard(c *gin.Context) {
+	var query domain.LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	entries, err := h.tenantService.GetLeaderboard(c.Request.Context(), query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// CreateProblem adds a problem to the tenant's catalog (tenant admins only)
+// POST /api/tenant/problems
+func (h *TenantHandler) CreateProblem(c *gin.Context) {
+	var req domain.CustomProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	problem, err := h.tenantService.CreateProblem(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, problem.ToResponse())
+}
+
+// CreateTenant hosts a new tenant (admin only)
+// POST /api/admin/tenants
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	var req domain.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	tenant, err := h.tenantService.CreateTenant(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tenant)
+}
+
+// ListTenants lists the hosted tenants (admin only)
+// GET /api/admin/tenants
+func (h *TenantHandler) ListTenants(c *gin.Context) {
+	tenants, err := h.tenantService.ListTenants(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tenants": tenants})
+}
@@ -4,18 +4,20 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"github.com/contest-maker-150/backend/internal/domain"
 	"github.com/contest-maker-150/backend/internal/middleware"
 	"github.com/contest-maker-150/backend/internal/service"
 )
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	userService *service.UserService
+	userService service.UserServicer
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(userService *service.UserService) *UserHandler {
+func NewUserHandler(userService service.UserServicer) *UserHandler {
 	return &UserHandler{
 		userService: userService,
 	}
@@ -31,9 +33,7 @@ func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 
 	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve user",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -50,11 +50,102 @@ func (h *UserHandler) GetUserProgress(c *gin.Context) {
 
 	progress, err := h.userService.GetUserProgress(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve progress",
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, progress)
 }
+
+// GetReviewQueue lists the user's solved problems by when they are due for review
+// GET /api/users/me/reviews
+func (h *UserHandler) GetReviewQueue(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var query domain.ReviewQueueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(domain.NewValidationError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	queue, err := h.userService.GetReviewQueue(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// GetAttemptHistory lists the user's attempts at a problem
+// GET /api/users/me/attempts/:problemId
+func (h *UserHandler) GetAttemptHistory(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	problemID, err := uuid.Parse(c.Param("problemId"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid problem ID", nil))
+		return
+	}
+
+	history, err := h.userService.GetAttemptHistory(c.Request.Context(), userID, problemID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// ChangePassword changes the current user's password
+// PUT /api/users/me/password
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.RequireUser(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("Invalid request body", err.Error()))
+		return
+	}
+
+	err := h.userService.ChangePassword(c.Request.Context(), userID, &req)
+	if err != nil {
+		if err == domain.ErrInvalidCredentials {
+			err = domain.NewDomainError(err, "Current password is incorrect")
+		}
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password changed",
+	})
+}
+
+// RevokeUserTokens signs a user out everywhere by revoking all their tokens (admin only)
+// POST /api/admin/users/:id/revoke-tokens
+func (h *UserHandler) RevokeUserTokens(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(domain.NewValidationError("Invalid user ID", nil))
+		return
+	}
+
+	if err := h.userService.RevokeAllTokens(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User tokens revoked",
+	})
+}
@@ -0,0 +1,131 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/service/mocks"
+)
+
+// newUserRouter routes the current user's endpoints to a handler on users,
+// behind the auth middleware
+func newUserRouter(users *mocks.UserServicer) *gin.Engine {
+	router, auth := newRouter(users)
+	h := handler.NewUserHandler(users)
+	group := router.Group("/api/users/me", auth)
+	group.GET("", h.GetCurrentUser)
+	group.GET("/progress", h.GetUserProgress)
+	group.GET("/reviews", h.GetReviewQueue)
+	group.GET("/attempts/:problemId", h.GetAttemptHistory)
+	group.PUT("/password", h.ChangePassword)
+	return router
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	users := &mocks.UserServicer{
+		GetUserByIDFunc: func(_ context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: id, Email: "alice@example.com", Username: "alice", PasswordHash: "secret"}, nil
+		},
+	}
+	router := newUserRouter(users)
+
+	var me domain.UserResponse
+	rec := serve(t, router, http.MethodGet, "/api/users/me", nil, validToken)
+	expectStatus(t, rec, http.StatusOK, &me)
+	if me.ID != userID || me.Username != "alice" {
+		t.Fatalf("current user = %+v", me)
+	}
+	expectError(t, serve(t, router, http.MethodGet, "/api/users/me", nil, ""), http.StatusUnauthorized, domain.CodeUnauthorized)
+
+	users.GetUserByIDFunc = func(context.Context, uuid.UUID) (*domain.User, error) {
+		return nil, domain.ErrUserNotFound
+	}
+	expectError(t, serve(t, router, http.MethodGet, "/api/users/me", nil, validToken), http.StatusNotFound, domain.CodeUserNotFound)
+}
+
+func TestGetUserProgress(t *testing.T) {
+	users := &mocks.UserServicer{
+		GetUserProgressFunc: func(context.Context, uuid.UUID) (*domain.UserProgress, error) {
+			return &domain.UserProgress{TotalSolved: 3, EasySolved: 2, MediumSolved: 1}, nil
+		},
+	}
+
+	var progress domain.UserProgress
+	expectStatus(t, serve(t, newUserRouter(users), http.MethodGet, "/api/users/me/progress", nil, validToken), http.StatusOK, &progress)
+	if progress.TotalSolved != 3 || progress.EasySolved != 2 {
+		t.Fatalf("progress = %+v", progress)
+	}
+}
+
+func TestGetReviewQueue(t *testing.T) {
+	users := &mocks.UserServicer{
+		GetReviewQueueFunc: func(_ context.Context, _ uuid.UUID, query domain.ReviewQueueQuery) (*domain.ReviewQueue, error) {
+			if !query.DueOnly || query.Limit != 5 {
+				t.Errorf("review query = %+v", query)
+			}
+			return &domain.ReviewQueue{Due: 1}, nil
+		},
+	}
+	router := newUserRouter(users)
+
+	var queue domain.ReviewQueue
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/users/me/reviews?due_only=true&limit=5", nil, validToken), http.StatusOK, &queue)
+	if queue.Due != 1 {
+		t.Fatalf("review queue = %+v", queue)
+	}
+	expectError(t, serve(t, router, http.MethodGet, "/api/users/me/reviews?limit=500", nil, validToken), http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestGetAttemptHistory(t *testing.T) {
+	problemID := uuid.New()
+	users := &mocks.UserServicer{
+		GetAttemptHistoryFunc: func(_ context.Context, _, pid uuid.UUID) (*domain.AttemptHistory, error) {
+			if pid != problemID {
+				return nil, domain.ErrProblemNotFound
+			}
+			return &domain.AttemptHistory{ProblemID: pid, Solved: true}, nil
+		},
+	}
+	router := newUserRouter(users)
+
+	var history domain.AttemptHistory
+	expectStatus(t, serve(t, router, http.MethodGet, "/api/users/me/attempts/"+problemID.String(), nil, validToken), http.StatusOK, &history)
+	if history.ProblemID != problemID || !history.Solved {
+		t.Fatalf("attempt history = %+v", history)
+	}
+	expectError(t, serve(t, router, http.MethodGet, "/api/users/me/attempts/"+uuid.NewString(), nil, validToken), http.StatusNotFound, domain.CodeProblemNotFound)
+	expectError(t, serve(t, router, http.MethodGet, "/api/users/me/attempts/two-sum", nil, validToken), http.StatusBadRequest, domain.CodeValidationFailed)
+}
+
+func TestChangePassword(t *testing.T) {
+	users := &mocks.UserServicer{
+		ChangePasswordFunc: func(_ context.Context, _ uuid.UUID, req *domain.ChangePasswordRequest) error {
+			switch {
+			case req.CurrentPassword != "correct horse":
+				return domain.ErrInvalidCredentials
+			case req.NewPassword == "short":
+				return domain.ErrWeakPassword
+			}
+			return nil
+		},
+	}
+	router := newUserRouter(users)
+	const path = "/api/users/me/password"
+
+	req := domain.ChangePasswordRequest{CurrentPassword: "correct horse", NewPassword: "battery staple"}
+	expectStatus(t, serve(t, router, http.MethodPut, path, req, validToken), http.StatusOK, nil)
+
+	wrong := domain.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "battery staple"}
+	if e := expectError(t, serve(t, router, http.MethodPut, path, wrong, validToken), http.StatusUnauthorized, domain.CodeInvalidCredentials); e.Message != "Current password is incorrect" {
+		t.Fatalf("error message = %q", e.Message)
+	}
+	weak := domain.ChangePasswordRequest{CurrentPassword: "correct horse", NewPassword: "short"}
+	expectError(t, serve(t, router, http.MethodPut, path, weak, validToken), http.StatusBadRequest, domain.CodeWeakPassword)
+	expectError(t, serve(t, router, http.MethodPut, path, struct{}{}, validToken), http.StatusBadRequest, domain.CodeValidationFailed)
+}
@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookAlertNotifier posts alert notifications as JSON. The payload carries
+// a "text" field, so a Slack incoming webhook URL works without an adapter.
+type WebhookAlertNotifier struct {
+	url        string
+	httpClient *http.Client
+	breaker    *Breaker
+}
+
+// NewWebhookAlertNotifier creates a notifier posting to url through breaker,
+// whose timeout bounds each attempt so a slow receiver cannot stall evaluation
+func NewWebhookAlertNotifier(url string, breaker *Breaker) *WebhookAlertNotifier {
+	return &WebhookAlertNotifier{
+		url:        url,
+		httpClient: &http.Client{},
+		breaker:    breaker,
+	}
+}
+
+// Notify posts the notification to the webhook, retrying failed attempts
+func (n *WebhookAlertNotifier) Notify(ctx context.Context, notification AlertNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	return n.breaker.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+		if err != nil {
+			return Permanent(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := n.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err := fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+			// The receiver rejected the payload; sending it again will not help
+			if resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+				return Permanent(err)
+			}
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,286 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Built-in alert rules
+const (
+	AlertHighErrorRate           = "high_error_rate"
+	AlertHighLatency             = "high_latency_p95"
+	AlertContestCreationFailures = "contest_creation_failures"
+)
+
+// Alert states sent in notifications
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+// latencyBounds are the upper bounds of the latency histogram buckets, 1ms
+// growing by 20% per bucket up to about a minute, so p95 is estimated within 20%
+var latencyBounds = func() []time.Duration {
+	var bounds []time.Duration
+	for b := float64(time.Millisecond); b < float64(time.Minute); b *= 1.2 {
+		bounds = append(bounds, time.Duration(b))
+	}
+	return bounds
+}()
+
+// AlertNotification is sent to the webhook when a rule starts or stops firing
+type AlertNotification struct {
+	Text          string    `json:"text"` // Human-readable summary; the field Slack displays
+	Alert         string    `json:"alert"`
+	Status        string    `json:"status"`
+	Value         float64   `json:"value"`
+	Threshold     float64   `json:"threshold"`
+	WindowSeconds int       `json:"window_seconds"`
+	Instance      string    `json:"instance"`
+	At            time.Time `json:"at"`
+}
+
+// AlertNotifier delivers alert notifications
+type AlertNotifier interface {
+	Notify(ctx context.Context, n AlertNotification) error
+}
+
+// alertSlot holds the traffic of one evaluation interval
+type alertSlot struct {
+	requests        int
+	serverErrors    int
+	latency         []int // Counts per latencyBounds bucket, plus one overflow bucket
+	contestFailures int
+}
+
+// alertReading is the value of one rule over the window
+type alertReading struct {
+	rule      string
+	value     float64
+	threshold float64
+	firing    bool
+	summary   string
+}
+
+// AlertEvaluator watches API error rate, p95 latency and contest creation
+// failures over a sliding window and notifies when a rule starts or stops
+// firing. It needs no external alertmanager; each instance judges its own traffic.
+type AlertEvaluator struct {
+	config   *AlertConfig
+	notifier AlertNotifier
+	instance string
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	slots   []alertSlot // Ring of evaluation intervals covering the window
+	current int
+	firing  map[string]bool
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewAlertEvaluator creates an alert evaluator. notifier may be nil, in which
+// case alerts are only logged.
+func NewAlertEvaluator(config *AlertConfig, notifier AlertNotifier, instance string, logger *zap.Logger) *AlertEvaluator {
+	n := 1
+	if config.EvaluationInterval > 0 && config.Window > config.EvaluationInterval {
+		n = int(math.Ceil(float64(config.Window) / float64(config.EvaluationInterval)))
+	}
+	slots := make([]alertSlot, n)
+	for i := range slots {
+		slots[i].latency = make([]int, len(latencyBounds)+1)
+	}
+	return &AlertEvaluator{
+		config:   config,
+		notifier: notifier,
+		instance: instance,
+		logger:   logger,
+		slots:    slots,
+		firing:   make(map[string]bool),
+	}
+}
+
+// ObserveRequest records a finished API request
+func (e *AlertEvaluator) ObserveRequest(status int, duration time.Duration) {
+	bucket := sort.Search(len(latencyBounds), func(i int) bool { return latencyBounds[i] >= duration })
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	slot := &e.slots[e.current]
+	slot.requests++
+	if status >= 500 {
+		slot.serverErrors++
+	}
+	slot.latency[bucket]++
+}
+
+// ObserveContestCreationFailure records a contest creation that failed on the server side
+func (e *AlertEvaluator) ObserveContestCreationFailure() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.slots[e.current].contestFailures++
+}
+
+// Start launches the evaluation loop in the background. A zero interval disables it.
+func (e *AlertEvaluator) Start(ctx context.Context) {
+	if e.config.EvaluationInterval <= 0 {
+		return
+	}
+	ctx, e.cancel = context.WithCancel(ctx)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.config.EvaluationInterval)
+		defer ticker.Stop()
+
+		e.logger.Info("Alert evaluator started",
+			zap.Duration("interval", e.config.EvaluationInterval),
+			zap.Duration("window", e.config.Window),
+			zap.Bool("webhook", e.notifier != nil),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				e.Evaluate(ctx, now)
+			}
+		}
+	}()
+}
+
+// Stop stops the evaluation loop and waits for pending notifications, or until ctx is done
+func (e *AlertEvaluator) Stop(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	if err := WaitContext(ctx, &e.wg); err != nil {
+		return err
+	}
+	e.logger.Info("Alert evaluator stopped")
+	return nil
+}
+
+// Evaluate checks every rule against the window, notifies about rules that
+// changed state and starts a new evaluation interval
+func (e *AlertEvaluator) Evaluate(ctx context.Context, now time.Time) {
+	readings := e.rotate()
+
+	for _, r := range readings {
+		if r.firing == e.firing[r.rule] {
+			continue
+		}
+		e.firing[r.rule] = r.firing
+
+		status := AlertResolved
+		if r.firing {
+			status = AlertFiring
+		}
+		n := AlertNotification{
+			Text:          fmt.Sprintf("[%s] %s on %s: %s", status, r.rule, e.instance, r.summary),
+			Alert:         r.rule,
+			Status:        status,
+			Value:         r.value,
+			Threshold:     r.threshold,
+			WindowSeconds: int(e.config.Window.Seconds()),
+			Instance:      e.instance,
+			At:            now.UTC(),
+		}
+
+		fields := []zap.Field{
+			zap.String("alert", r.rule),
+			zap.Float64("value", r.value),
+			zap.Float64("threshold", r.threshold),
+		}
+		if r.firing {
+			e.logger.Warn("Alert firing", fields...)
+		} else {
+			e.logger.Info("Alert resolved", fields...)
+		}
+
+		if e.notifier == nil {
+			continue
+		}
+		if err := e.notifier.Notify(ctx, n); err != nil {
+			e.logger.Error("Failed to send alert notification", zap.String("alert", r.rule), zap.Error(err))
+		}
+	}
+}
+
+// rotate computes the readings over the window and starts a new interval
+func (e *AlertEvaluator) rotate() []alertReading {
+	e.mu.Lock()
+	total := alertSlot{latency: make([]int, len(latencyBounds)+1)}
+	for _, slot := range e.slots {
+		total.requests += slot.requests
+		total.serverErrors += slot.serverErrors
+		total.contestFailures += slot.contestFailures
+		for i, count := range slot.latency {
+			total.latency[i] += count
+		}
+	}
+	e.current = (e.current + 1) % len(e.slots)
+	next := &e.slots[e.current]
+	next.requests, next.serverErrors, next.contestFailures = 0, 0, 0
+	clear(next.latency)
+	e.mu.Unlock()
+
+	window := e.config.Window.String()
+	enoughTraffic := total.requests > 0 && total.requests >= e.config.MinRequests
+
+	var readings []alertReading
+	if e.config.ErrorRate > 0 {
+		r := alertReading{rule: AlertHighErrorRate, threshold: e.config.ErrorRate}
+		if total.requests > 0 {
+			r.value = float64(total.serverErrors) / float64(total.requests)
+		}
+		r.firing = enoughTraffic && r.value > r.threshold
+		r.summary = fmt.Sprintf("%.1f%% of %d API requests failed with a 5xx in the last %s (threshold %.1f%%)",
+			r.value*100, total.requests, window, r.threshold*100)
+		readings = append(readings, r)
+	}
+	if e.config.LatencyP95 > 0 {
+		r := alertReading{rule: AlertHighLatency, threshold: float64(e.config.LatencyP95.Milliseconds())}
+		r.value = float64(percentile(total.latency, total.requests, 0.95).Milliseconds())
+		r.firing = enoughTraffic && r.value > r.threshold
+		r.summary = fmt.Sprintf("p95 latency of %d API requests was about %.0fms in the last %s (threshold %.0fms)",
+			total.requests, r.value, window, r.threshold)
+		readings = append(readings, r)
+	}
+	if e.config.ContestFailures > 0 {
+		r := alertReading{rule: AlertContestCreationFailures, threshold: float64(e.config.ContestFailures)}
+		r.value = float64(total.contestFailures)
+		r.firing = r.value >= r.threshold
+		r.summary = fmt.Sprintf("%d contest creations failed in the last %s (threshold %d)",
+			total.contestFailures, window, e.config.ContestFailures)
+		readings = append(readings, r)
+	}
+	return readings
+}
+
+// percentile returns the upper bound of the histogram bucket holding the q-th
+// quantile of count observations
+func percentile(buckets []int, count int, q float64) time.Duration {
+	if count == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q * float64(count)))
+	seen := 0
+	for i, n := range buckets {
+		seen += n
+		if seen >= rank && i < len(latencyBounds) {
+			return latencyBounds[i]
+		}
+	}
+	return latencyBounds[len(latencyBounds)-1]
+}
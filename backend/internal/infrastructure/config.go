@@ -3,15 +3,44 @@ package infrastructure
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server    ServerConfig
-	Database  DatabaseConfig
-	JWT       JWTConfig
-	Telemetry TelemetryConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	Password    PasswordConfig
+	Contest     ContestConfig
+	Problems    ProblemConfig
+	PublicStats PublicStatsConfig
+	Progress    ProgressConfig
+	Presence    PresenceConfig
+	Chat        ChatConfig
+	Lists       ListConfig
+	Orgs        OrgConfig
+	Analytics   AnalyticsConfig
+	Digest      DigestConfig
+	Mail        MailConfig
+	Similarity  SimilarityConfig
+	Tenancy     TenancyConfig
+	Features    FeatureFlagConfig
+	Maintenance MaintenanceConfig
+	Alerts      AlertConfig
+	RateLimits  RateLimitConfig
+	Quotas      QuotaConfig
+	Billing     BillingConfig
+	Backup      BackupConfig
+	Retention   RetentionConfig
+	LoadShed    LoadShedConfig
+	Shutdown    ShutdownConfig
+	Readiness   ReadinessConfig
+	Resilience  ResilienceConfig
+	Logging     LoggingConfig
+	CrashReport CrashReportConfig
+	Telemetry   TelemetryConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -21,10 +50,23 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	Environment  string
+	Region       string // Deployment region, reported in X-Served-By and /health; empty for a single-region deployment
+
+	// Handler deadlines; keep them below WriteTimeout so clients get a 504 instead of a dropped connection
+	HandlerTimeout     time.Duration
+	SlowHandlerTimeout time.Duration // Contest creation and admin reports
 }
 
+// Supported database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 // DatabaseConfig holds database connection configuration
 type DatabaseConfig struct {
+	Driver          string // "postgres" or "sqlite"
+	SQLitePath      string // Database file for the sqlite driver; ":memory:" for an in-memory database
 	Host            string
 	Port            int
 	User            string
@@ -34,6 +76,16 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+
+	// RequestTransactions runs each mutating API request in one transaction
+	RequestTransactions bool
+
+	// Read replicas (postgres only) serve reporting reads while their
+	// replication lag stays within ReplicaMaxLag; they share the primary's
+	// credentials and database name
+	ReplicaHosts         []string // host or host:port
+	ReplicaMaxLag        time.Duration
+	ReplicaCheckInterval time.Duration // How often replication lag is measured
 }
 
 // JWTConfig holds JWT authentication configuration
@@ -42,6 +94,277 @@ type JWTConfig struct {
 	AccessTokenExpiry  time.Duration
 	RefreshTokenExpiry time.Duration
 	Issuer             string
+	Audience           string
+	ClockSkew          time.Duration // Tolerance applied to exp, nbf and iat checks
+}
+
+// PasswordConfig holds password policy configuration
+type PasswordConfig struct {
+	MinLength          int
+	RequireUpper       bool
+	RequireLower       bool
+	RequireDigit       bool
+	RequireSymbol      bool
+	BannedPasswords    []string // Extra banned passwords on top of the built-in list
+	BreachCheckEnabled bool
+	BreachCheckURL     string
+	BreachCheckTimeout time.Duration
+
+	// Hashing parameters; changing them rehashes existing users on next login
+	HashAlgorithm     string // "bcrypt" or "argon2id"
+	BcryptCost        int
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// ContestConfig holds contest lifecycle configuration
+type ContestConfig struct {
+	ExpirySweepInterval time.Duration // How often the background worker finalizes expired contests
+	AbandonPolicy       string        // "no_activity" abandons untouched expired contests, "none" always completes
+	AbandonGracePeriod  time.Duration // How long past expiry an untouched contest waits before being abandoned
+
+	// ProblemCooldownContests is how many of a user's most recent contests count as
+	// "recently served"; those problems are only picked once fresh ones run out (0 disables)
+	ProblemCooldownContests int
+
+	ChallengeInviteTTL time.Duration // How long a challenge invite can be accepted
+
+	// TemplateSecret signs exported contest templates; only templates signed
+	// with it are imported
+	TemplateSecret string
+}
+
+// ProblemConfig holds problem catalog configuration
+type ProblemConfig struct {
+	StatsCacheTTL time.Duration // How long GET /api/problems/stats serves a cached result
+	SiteURL       string        // Base URL of the public frontend, for canonical links and the sitemap
+
+	// SelectionSeed seeds the seeds drawn for contest problem selections, so the
+	// same sequence of contest requests draws the same problems (0 seeds from the clock)
+	SelectionSeed int64
+}
+
+// PublicStatsConfig holds the unauthenticated platform statistics configuration
+type PublicStatsConfig struct {
+	CacheTTL time.Duration // How long the public stats serve a cached result; also sent as Cache-Control max-age
+}
+
+// ProgressConfig holds user progress summary configuration
+type ProgressConfig struct {
+	BackfillInterval time.Duration // How often summaries are rebuilt after the startup backfill (0 disables)
+}
+
+// PresenceConfig holds online presence configuration
+type PresenceConfig struct {
+	TTL           time.Duration // How long after the last heartbeat a user still counts as online
+	SweepInterval time.Duration // How often heartbeats older than TTL are deleted (0 disables)
+}
+
+// ChatConfig holds challenge chat configuration
+type ChatConfig struct {
+	BannedWords []string // Words the default filter masks in chat messages, matched case-insensitively
+}
+
+// ListConfig holds community problem list moderation settings
+type ListConfig struct {
+	ReportThreshold int      // Reports from distinct users that hide a list until a moderator reviews it (0 never hides)
+	BannedWords     []string // Words masked in list titles and descriptions, matched case-insensitively
+}
+
+// OrgConfig holds organization (classroom) configuration
+type OrgConfig struct {
+	InviteTTL  time.Duration // How long an invite code can be used to join
+	MaxMembers int           // Members per organization, instructors included
+}
+
+// AnalyticsConfig holds product analytics configuration
+type AnalyticsConfig struct {
+	CohortWeeks           int           // How many weekly signup cohorts the snapshot covers
+	CohortRefreshInterval time.Duration // How often the cohort snapshot is recomputed (0 only computes a missing one at startup)
+}
+
+// DigestConfig holds the weekly recommendation digest that opted-in users receive
+type DigestConfig struct {
+	Interval  time.Duration // How often due digests are looked for (0 disables sending; admins can still trigger a round)
+	Period    time.Duration // Time between two digests of a user
+	Size      int           // Problems suggested per digest
+	BatchSize int           // Digests sent per round at most
+	APIURL    string        // Public base URL of this API, for the click-through links in emails
+}
+
+// MailConfig holds the SMTP server outgoing email is sent through. Email is off
+// until a host is set; digests are then only shown in the app.
+type MailConfig struct {
+	SMTPHost string
+	SMTPPort int
+	Username string // Empty skips authentication
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// SimilarityConfig holds the checker that compares solution snippets within
+// organization group assignments
+type SimilarityConfig struct {
+	Interval  time.Duration // How often changed snippets are checked (0 disables the checker; admins can still trigger a round)
+	Threshold float64       // Similarity from which a pair is flagged, 0-1
+	MinTokens int           // Snippets with fewer tokens are too short to tell copying apart and are not compared
+	BatchSize int           // Snippets checked per round at most
+}
+
+// TenancyConfig holds how requests are mapped to tenants, the private instances
+// hosted on one deployment. Without tenancy every request is the default instance.
+type TenancyConfig struct {
+	Enabled    bool
+	BaseDomain string        // Requests to <slug>.<BaseDomain> are for the tenant with that slug
+	Header     string        // Header naming the tenant slug, checked before the host
+	CacheTTL   time.Duration // How long a resolved tenant is reused before it is looked up again
+}
+
+// FeatureFlagConfig holds feature flag defaults; admin toggles in the database override them
+type FeatureFlagConfig struct {
+	Defaults        []string      // "key" turns a flag on for everyone, "key=percent" for a share of users
+	RefreshInterval time.Duration // How often toggles made on other instances are picked up
+}
+
+// MaintenanceConfig holds maintenance mode settings; a window scheduled by an admin
+// is stored in the database
+type MaintenanceConfig struct {
+	Forced          bool          // Blocks writes regardless of the stored window, for maintenance that needs a deploy
+	Message         string        // Shown while Forced is set
+	RetryAfter      time.Duration // Suggested wait when the window has no end time
+	RefreshInterval time.Duration // How often windows set on other instances are picked up
+}
+
+// AlertConfig holds the built-in alert rules, evaluated on each instance's own
+// traffic; a threshold of 0 disables its rule
+type AlertConfig struct {
+	WebhookURL         string        // Receives firing and resolved notifications; a Slack incoming webhook works as is
+	EvaluationInterval time.Duration // How often the rules are checked (0 disables alerting)
+	Window             time.Duration // How far back the rules look
+	MinRequests        int           // The error rate and latency rules stay quiet below this many requests in the window
+	ErrorRate          float64       // Share of API requests answered with a 5xx
+	LatencyP95         time.Duration // 95th percentile API request latency
+	ContestFailures    int           // Contest creations failing with a 5xx in the window
+	WebhookTimeout     time.Duration // Per delivery attempt to the webhook
+}
+
+// RateLimitConfig holds per-user limits on expensive endpoints; the counters
+// are shared by all instances through the database. A limit of 0 disables it.
+type RateLimitConfig struct {
+	Enabled           bool
+	ContestsPerHour   int // Contest creations, including accepted challenges
+	SearchesPerMinute int // Problem searches and tag suggestions
+	ReportsPerMinute  int // Progress, challenge comparison and admin reports
+	ChatPerMinute     int // Challenge chat messages
+	PublicPerMinute   int // Unauthenticated statistics
+}
+
+// QuotaConfig holds the default quotas of each plan; a limit of 0 is unlimited.
+// Admins can override them per user.
+type QuotaConfig struct {
+	FreeContestsPerDay    int
+	FreeCustomProblems    int
+	PremiumContestsPerDay int
+	PremiumCustomProblems int
+
+	// PremiumGracePeriod keeps a paid plan past the end of its billing period while
+	// the renewal has not been confirmed, so a late webhook does not downgrade anyone
+	PremiumGracePeriod time.Duration
+}
+
+// BillingConfig holds the Stripe integration that sells the premium plan.
+// Billing is off until the secret key and price are set.
+type BillingConfig struct {
+	StripeSecretKey     string
+	StripeWebhookSecret string // Signing secret of the webhook endpoint
+	StripePriceID       string // Recurring price of the premium plan
+	StripeAPIURL        string
+	SuccessURL          string // Where checkout returns after payment
+	CancelURL           string // Where checkout returns when abandoned
+	Timeout             time.Duration
+}
+
+// Supported backup storage backends
+const (
+	BackupStorageFile = "file"
+	BackupStorageS3   = "s3"
+)
+
+// BackupConfig holds where backups are stored and how often they are taken.
+// Backups are off until a storage backend is set.
+type BackupConfig struct {
+	Storage  string        // "file", "s3" (any S3-compatible service) or empty to disable
+	Dir      string        // Root directory of the file storage
+	Prefix   string        // Key prefix of every backup object
+	Interval time.Duration // How often the scheduled backup runs (0 disables; admins can still trigger one)
+	Timeout  time.Duration // Per request to the object storage
+
+	S3Endpoint  string // e.g. https://s3.eu-west-1.amazonaws.com or a MinIO URL; buckets are addressed by path
+	S3Bucket    string
+	S3Region    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// RetentionConfig holds how long old data is kept; a retention of 0 keeps it forever
+type RetentionConfig struct {
+	Interval               time.Duration // How often the retention job runs (0 disables; admins can still run it)
+	AbandonedContestMonths int           // Abandoned contests older than this are deleted
+	QuickCommandLogDays    int           // Quick command audit entries older than this are deleted
+	BillingEventDays       int           // Processed payment webhook IDs older than this are deleted
+	BatchSize              int           // Rows deleted per transaction
+}
+
+// LoadShedConfig holds the adaptive concurrency limit that sheds API requests under saturation
+type LoadShedConfig struct {
+	Enabled          bool
+	InitialLimit     int
+	MinLimit         int
+	MaxLimit         int
+	LatencyThreshold time.Duration // Requests slower than this shrink the limit
+}
+
+// ShutdownConfig bounds how long each stage of a graceful shutdown may take
+type ShutdownConfig struct {
+	HTTPTimeout      time.Duration // In-flight requests finish before connections are closed
+	WorkerTimeout    time.Duration // Per background worker, e.g. a running expiry sweep
+	EventTimeout     time.Duration // Delivering events still queued on the bus
+	TelemetryTimeout time.Duration // Flushing buffered spans and metrics
+}
+
+// ReadinessConfig holds how often /readyz probes the external dependencies
+type ReadinessConfig struct {
+	Interval time.Duration // How often every dependency is probed
+	Timeout  time.Duration // Per probe; a slower dependency counts as down
+}
+
+// ResilienceConfig holds how calls to external dependencies (the trace
+// collector, the mail server and the alert webhook) are retried and when their
+// circuit breakers open. Each dependency has its own attempt timeout.
+type ResilienceConfig struct {
+	MaxAttempts      int           // Per call, including the first; 1 disables retries
+	BaseDelay        time.Duration // Longest wait before the first retry, doubling per retry
+	MaxDelay         time.Duration // Longest wait between two attempts
+	FailureThreshold int           // Consecutive failed calls that open a breaker
+	OpenDuration     time.Duration // How long an open breaker fails calls fast before a trial call
+}
+
+// LoggingConfig holds log level and request log sampling settings
+type LoggingConfig struct {
+	Level        string        // Base log level; empty uses debug in development and info otherwise
+	LevelRefresh time.Duration // How often a level set through the admin API is picked up
+	SampleRate   float64       // Share of successful request logs kept
+	SampleRoutes []string      // Per-route overrides, "METHOD /route=rate"
+}
+
+// CrashReportConfig holds reporting of recovered panics to a Sentry-compatible error tracker
+type CrashReportConfig struct {
+	DSN         string        // Sentry DSN; empty only logs panics
+	SampleRate  float64       // Share of panics reported
+	ScrubFields []string      // Header and query parameter names masked besides credentials
+	Timeout     time.Duration // Deadline for sending one report
 }
 
 // TelemetryConfig holds observability configuration
@@ -51,19 +374,39 @@ type TelemetryConfig struct {
 	ServiceVersion  string
 	OTLPEndpoint    string
 	MetricsEndpoint string
+	DBStatsInterval time.Duration // How often connection pool statistics are exported
+	Exemplars       bool          // Attach the trace ID of sampled requests to histogram buckets
+	Region          string        // Added to the resource and, when set, as a label on every metric
+
+	// Trace sampling: SampleRatio of traces are sampled up front; with tail
+	// sampling, traces of requests that fail with a 5xx or take longer than
+	// SlowTraceThreshold are kept as well
+	SampleRatio        float64
+	TailSampling       bool
+	SlowTraceThreshold time.Duration
+
+	ExportTimeout time.Duration // Per attempt to export a batch of spans to the collector
 }
 
 // LoadConfig loads configuration from environment variables with sensible defaults
 func LoadConfig() *Config {
+	region := getEnv("REGION", "")
+
 	return &Config{
 		Server: ServerConfig{
 			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
 			Port:         getEnvInt("SERVER_PORT", 8080),
+			Region:       region,
 			ReadTimeout:  time.Duration(getEnvInt("SERVER_READ_TIMEOUT", 10)) * time.Second,
 			WriteTimeout: time.Duration(getEnvInt("SERVER_WRITE_TIMEOUT", 30)) * time.Second,
 			Environment:  getEnv("ENVIRONMENT", "development"),
+
+			HandlerTimeout:     time.Duration(getEnvInt("SERVER_HANDLER_TIMEOUT", 10)) * time.Second,
+			SlowHandlerTimeout: time.Duration(getEnvInt("SERVER_SLOW_HANDLER_TIMEOUT", 25)) * time.Second,
 		},
 		Database: DatabaseConfig{
+			Driver:          getEnv("DB_DRIVER", DriverPostgres),
+			SQLitePath:      getEnv("DATABASE_SQLITE_PATH", "contest_maker.db"),
 			Host:            getEnv("DATABASE_HOST", "localhost"),
 			Port:            getEnvInt("DATABASE_PORT", 5432),
 			User:            getEnv("DATABASE_USER", "postgres"),
@@ -73,12 +416,201 @@ func LoadConfig() *Config {
 			MaxOpenConns:    getEnvInt("DATABASE_MAX_OPEN_CONNS", 25),
 			MaxIdleConns:    getEnvInt("DATABASE_MAX_IDLE_CONNS", 5),
 			ConnMaxLifetime: time.Duration(getEnvInt("DATABASE_CONN_MAX_LIFETIME", 300)) * time.Second,
+
+			RequestTransactions: getEnvBool("DATABASE_REQUEST_TRANSACTIONS", false),
+
+			ReplicaHosts:         getEnvList("DATABASE_REPLICA_HOSTS", nil),
+			ReplicaMaxLag:        time.Duration(getEnvInt("DATABASE_REPLICA_MAX_LAG_SECONDS", 10)) * time.Second,
+			ReplicaCheckInterval: time.Duration(getEnvInt("DATABASE_REPLICA_CHECK_SECONDS", 5)) * time.Second,
 		},
 		JWT: JWTConfig{
 			SecretKey:          getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
 			AccessTokenExpiry:  time.Duration(getEnvInt("JWT_ACCESS_EXPIRY_MINUTES", 15)) * time.Minute,
 			RefreshTokenExpiry: time.Duration(getEnvInt("JWT_REFRESH_EXPIRY_HOURS", 168)) * time.Hour, // 7 days
 			Issuer:             getEnv("JWT_ISSUER", "contest-maker-150"),
+			Audience:           getEnv("JWT_AUDIENCE", "contest-maker-150-api"),
+			ClockSkew:          time.Duration(getEnvInt("JWT_CLOCK_SKEW_SECONDS", 30)) * time.Second,
+		},
+		Password: PasswordConfig{
+			MinLength:          getEnvInt("PASSWORD_MIN_LENGTH", 8),
+			RequireUpper:       getEnvBool("PASSWORD_REQUIRE_UPPER", true),
+			RequireLower:       getEnvBool("PASSWORD_REQUIRE_LOWER", true),
+			RequireDigit:       getEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+			RequireSymbol:      getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+			BannedPasswords:    getEnvList("PASSWORD_BANNED", nil),
+			BreachCheckEnabled: getEnvBool("PASSWORD_BREACH_CHECK_ENABLED", false),
+			BreachCheckURL:     getEnv("PASSWORD_BREACH_CHECK_URL", "https://api.pwnedpasswords.com/range/"),
+			BreachCheckTimeout: time.Duration(getEnvInt("PASSWORD_BREACH_CHECK_TIMEOUT_MS", 2000)) * time.Millisecond,
+			HashAlgorithm:      getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt"),
+			BcryptCost:         getEnvInt("PASSWORD_BCRYPT_COST", 10),
+			Argon2Memory:       uint32(getEnvInt("PASSWORD_ARGON2_MEMORY_KB", 64*1024)),
+			Argon2Iterations:   uint32(getEnvInt("PASSWORD_ARGON2_ITERATIONS", 3)),
+			Argon2Parallelism:  uint8(getEnvInt("PASSWORD_ARGON2_PARALLELISM", 2)),
+		},
+		Contest: ContestConfig{
+			ExpirySweepInterval:     time.Duration(getEnvInt("CONTEST_EXPIRY_SWEEP_INTERVAL_SECONDS", 60)) * time.Second,
+			AbandonPolicy:           getEnv("CONTEST_ABANDON_POLICY", "no_activity"),
+			AbandonGracePeriod:      time.Duration(getEnvInt("CONTEST_ABANDON_GRACE_HOURS", 24)) * time.Hour,
+			ProblemCooldownContests: getEnvInt("CONTEST_PROBLEM_COOLDOWN_CONTESTS", 3),
+			ChallengeInviteTTL:      time.Duration(getEnvInt("CHALLENGE_INVITE_TTL_HOURS", 72)) * time.Hour,
+			TemplateSecret:          getEnv("CONTEST_TEMPLATE_SECRET", getEnv("JWT_SECRET", "your-super-secret-key-change-in-production")),
+		},
+		Problems: ProblemConfig{
+			StatsCacheTTL: time.Duration(getEnvInt("PROBLEM_STATS_CACHE_SECONDS", 30)) * time.Second,
+			SiteURL:       strings.TrimRight(getEnv("SITE_URL", "http://localhost:5173"), "/"),
+			SelectionSeed: int64(getEnvInt("PROBLEM_SELECTION_SEED", 0)),
+		},
+		PublicStats: PublicStatsConfig{
+			CacheTTL: time.Duration(getEnvInt("PUBLIC_STATS_CACHE_SECONDS", 300)) * time.Second,
+		},
+		Progress: ProgressConfig{
+			BackfillInterval: time.Duration(getEnvInt("PROGRESS_BACKFILL_INTERVAL_MINUTES", 360)) * time.Minute,
+		},
+		Presence: PresenceConfig{
+			TTL:           time.Duration(getEnvInt("PRESENCE_TTL_SECONDS", 60)) * time.Second,
+			SweepInterval: time.Duration(getEnvInt("PRESENCE_SWEEP_INTERVAL_SECONDS", 300)) * time.Second,
+		},
+		Chat: ChatConfig{
+			BannedWords: getEnvList("CHAT_BANNED_WORDS", nil),
+		},
+		Lists: ListConfig{
+			ReportThreshold: getEnvInt("LIST_REPORT_THRESHOLD", 3),
+			BannedWords:     getEnvList("LIST_BANNED_WORDS", nil),
+		},
+		Orgs: OrgConfig{
+			InviteTTL:  time.Duration(getEnvInt("ORG_INVITE_TTL_HOURS", 168)) * time.Hour,
+			MaxMembers: getEnvInt("ORG_MAX_MEMBERS", 200),
+		},
+		Analytics: AnalyticsConfig{
+			CohortWeeks:           getEnvInt("ANALYTICS_COHORT_WEEKS", 12),
+			CohortRefreshInterval: time.Duration(getEnvInt("ANALYTICS_COHORT_REFRESH_HOURS", 24)) * time.Hour,
+		},
+		Digest: DigestConfig{
+			Interval:  time.Duration(getEnvInt("DIGEST_INTERVAL_MINUTES", 60)) * time.Minute,
+			Period:    time.Duration(getEnvInt("DIGEST_PERIOD_DAYS", 7)) * 24 * time.Hour,
+			Size:      getEnvInt("DIGEST_SIZE", 5),
+			BatchSize: getEnvInt("DIGEST_BATCH_SIZE", 200),
+			APIURL:    strings.TrimRight(getEnv("API_URL", "http://localhost:8080"), "/"),
+		},
+		Mail: MailConfig{
+			SMTPHost: getEnv("SMTP_HOST", ""),
+			SMTPPort: getEnvInt("SMTP_PORT", 587),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("MAIL_FROM", "Contest Maker <no-reply@localhost>"),
+			Timeout:  time.Duration(getEnvInt("SMTP_TIMEOUT_SECONDS", 10)) * time.Second,
+		},
+		Similarity: SimilarityConfig{
+			Interval:  time.Duration(getEnvInt("SIMILARITY_INTERVAL_MINUTES", 10)) * time.Minute,
+			Threshold: getEnvFloat("SIMILARITY_THRESHOLD", 0.8),
+			MinTokens: getEnvInt("SIMILARITY_MIN_TOKENS", 30),
+			BatchSize: getEnvInt("SIMILARITY_BATCH_SIZE", 200),
+		},
+		Tenancy: TenancyConfig{
+			Enabled:    getEnvBool("TENANCY_ENABLED", false),
+			BaseDomain: strings.ToLower(getEnv("TENANCY_BASE_DOMAIN", "")),
+			Header:     getEnv("TENANCY_HEADER", "X-Tenant"),
+			CacheTTL:   time.Duration(getEnvInt("TENANCY_CACHE_SECONDS", 60)) * time.Second,
+		},
+		Features: FeatureFlagConfig{
+			Defaults:        getEnvList("FEATURE_FLAGS", nil),
+			RefreshInterval: time.Duration(getEnvInt("FEATURE_FLAGS_REFRESH_SECONDS", 30)) * time.Second,
+		},
+		Maintenance: MaintenanceConfig{
+			Forced:          getEnvBool("MAINTENANCE_MODE", false),
+			Message:         getEnv("MAINTENANCE_MESSAGE", ""),
+			RetryAfter:      time.Duration(getEnvInt("MAINTENANCE_RETRY_AFTER_SECONDS", 300)) * time.Second,
+			RefreshInterval: time.Duration(getEnvInt("MAINTENANCE_REFRESH_SECONDS", 10)) * time.Second,
+		},
+		Alerts: AlertConfig{
+			WebhookURL:         getEnv("ALERT_WEBHOOK_URL", ""),
+			EvaluationInterval: time.Duration(getEnvInt("ALERT_EVALUATION_SECONDS", 30)) * time.Second,
+			Window:             time.Duration(getEnvInt("ALERT_WINDOW_SECONDS", 300)) * time.Second,
+			MinRequests:        getEnvInt("ALERT_MIN_REQUESTS", 20),
+			ErrorRate:          getEnvFloat("ALERT_ERROR_RATE", 0.05),
+			LatencyP95:         time.Duration(getEnvInt("ALERT_P95_LATENCY_MS", 2000)) * time.Millisecond,
+			ContestFailures:    getEnvInt("ALERT_CONTEST_FAILURES", 3),
+			WebhookTimeout:     time.Duration(getEnvInt("ALERT_WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		},
+		RateLimits: RateLimitConfig{
+			Enabled:           getEnvBool("RATE_LIMIT_ENABLED", true),
+			ContestsPerHour:   getEnvInt("RATE_LIMIT_CONTESTS_PER_HOUR", 30),
+			SearchesPerMinute: getEnvInt("RATE_LIMIT_SEARCHES_PER_MINUTE", 120),
+			ReportsPerMinute:  getEnvInt("RATE_LIMIT_REPORTS_PER_MINUTE", 30),
+			ChatPerMinute:     getEnvInt("RATE_LIMIT_CHAT_PER_MINUTE", 20),
+			PublicPerMinute:   getEnvInt("RATE_LIMIT_PUBLIC_PER_MINUTE", 60),
+		},
+		Quotas: QuotaConfig{
+			FreeContestsPerDay:    getEnvInt("QUOTA_FREE_CONTESTS_PER_DAY", 10),
+			FreeCustomProblems:    getEnvInt("QUOTA_FREE_CUSTOM_PROBLEMS", getEnvInt("CUSTOM_PROBLEMS_PER_USER", 100)),
+			PremiumContestsPerDay: getEnvInt("QUOTA_PREMIUM_CONTESTS_PER_DAY", 0),
+			PremiumCustomProblems: getEnvInt("QUOTA_PREMIUM_CUSTOM_PROBLEMS", 1000),
+			PremiumGracePeriod:    time.Duration(getEnvInt("QUOTA_PREMIUM_GRACE_HOURS", 72)) * time.Hour,
+		},
+		Billing: BillingConfig{
+			StripeSecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
+			StripeWebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			StripePriceID:       getEnv("STRIPE_PREMIUM_PRICE_ID", ""),
+			StripeAPIURL:        getEnv("STRIPE_API_URL", "https://api.stripe.com"),
+			SuccessURL:          getEnv("BILLING_SUCCESS_URL", "http://localhost:5173/?checkout=success"),
+			CancelURL:           getEnv("BILLING_CANCEL_URL", "http://localhost:5173/?checkout=cancelled"),
+			Timeout:             time.Duration(getEnvInt("STRIPE_TIMEOUT_SECONDS", 10)) * time.Second,
+		},
+		Backup: BackupConfig{
+			Storage:  getEnv("BACKUP_STORAGE", ""),
+			Dir:      getEnv("BACKUP_DIR", "backups"),
+			Prefix:   getEnv("BACKUP_PREFIX", "backups/"),
+			Interval: time.Duration(getEnvInt("BACKUP_INTERVAL_HOURS", 24)) * time.Hour,
+			Timeout:  time.Duration(getEnvInt("BACKUP_TIMEOUT_SECONDS", 60)) * time.Second,
+
+			S3Endpoint:  getEnv("BACKUP_S3_ENDPOINT", ""),
+			S3Bucket:    getEnv("BACKUP_S3_BUCKET", ""),
+			S3Region:    getEnv("BACKUP_S3_REGION", "us-east-1"),
+			S3AccessKey: getEnv("BACKUP_S3_ACCESS_KEY", ""),
+			S3SecretKey: getEnv("BACKUP_S3_SECRET_KEY", ""),
+		},
+		Retention: RetentionConfig{
+			Interval:               time.Duration(getEnvInt("RETENTION_INTERVAL_HOURS", 24)) * time.Hour,
+			AbandonedContestMonths: getEnvInt("RETENTION_ABANDONED_CONTEST_MONTHS", 0),
+			QuickCommandLogDays:    getEnvInt("RETENTION_QUICK_COMMAND_LOG_DAYS", 90),
+			BillingEventDays:       getEnvInt("RETENTION_BILLING_EVENT_DAYS", 90),
+			BatchSize:              getEnvInt("RETENTION_BATCH_SIZE", 500),
+		},
+		LoadShed: LoadShedConfig{
+			Enabled:          getEnvBool("LOAD_SHED_ENABLED", true),
+			InitialLimit:     getEnvInt("LOAD_SHED_INITIAL_LIMIT", 20),
+			MinLimit:         getEnvInt("LOAD_SHED_MIN_LIMIT", 5),
+			MaxLimit:         getEnvInt("LOAD_SHED_MAX_LIMIT", 200),
+			LatencyThreshold: time.Duration(getEnvInt("LOAD_SHED_LATENCY_THRESHOLD_MS", 500)) * time.Millisecond,
+		},
+		Shutdown: ShutdownConfig{
+			HTTPTimeout:      time.Duration(getEnvInt("SHUTDOWN_HTTP_TIMEOUT", 30)) * time.Second,
+			WorkerTimeout:    time.Duration(getEnvInt("SHUTDOWN_WORKER_TIMEOUT", 10)) * time.Second,
+			EventTimeout:     time.Duration(getEnvInt("SHUTDOWN_EVENT_TIMEOUT", 10)) * time.Second,
+			TelemetryTimeout: time.Duration(getEnvInt("SHUTDOWN_TELEMETRY_TIMEOUT", 5)) * time.Second,
+		},
+		Readiness: ReadinessConfig{
+			Interval: time.Duration(getEnvInt("READINESS_CHECK_SECONDS", 15)) * time.Second,
+			Timeout:  time.Duration(getEnvInt("READINESS_CHECK_TIMEOUT_SECONDS", 5)) * time.Second,
+		},
+		Resilience: ResilienceConfig{
+			MaxAttempts:      getEnvInt("OUTBOUND_MAX_ATTEMPTS", 3),
+			BaseDelay:        time.Duration(getEnvInt("OUTBOUND_RETRY_BASE_DELAY_MS", 200)) * time.Millisecond,
+			MaxDelay:         time.Duration(getEnvInt("OUTBOUND_RETRY_MAX_DELAY_MS", 5000)) * time.Millisecond,
+			FailureThreshold: getEnvInt("OUTBOUND_BREAKER_FAILURES", 5),
+			OpenDuration:     time.Duration(getEnvInt("OUTBOUND_BREAKER_OPEN_SECONDS", 30)) * time.Second,
+		},
+		Logging: LoggingConfig{
+			Level:        getEnv("LOG_LEVEL", ""),
+			LevelRefresh: time.Duration(getEnvInt("LOG_LEVEL_REFRESH_SECONDS", 10)) * time.Second,
+			SampleRate:   getEnvFloat("LOG_SAMPLE_RATE", 1),
+			SampleRoutes: getEnvList("LOG_SAMPLE_ROUTES", nil),
+		},
+		CrashReport: CrashReportConfig{
+			DSN:         getEnv("SENTRY_DSN", ""),
+			SampleRate:  getEnvFloat("CRASH_REPORT_SAMPLE_RATE", 1),
+			ScrubFields: getEnvList("CRASH_REPORT_SCRUB_FIELDS", nil),
+			Timeout:     time.Duration(getEnvInt("CRASH_REPORT_TIMEOUT_SECONDS", 5)) * time.Second,
 		},
 		Telemetry: TelemetryConfig{
 			Enabled:         getEnvBool("TELEMETRY_ENABLED", true),
@@ -86,6 +618,15 @@ func LoadConfig() *Config {
 			ServiceVersion:  getEnv("SERVICE_VERSION", "1.0.0"),
 			OTLPEndpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://otel-collector:4318"),
 			MetricsEndpoint: getEnv("METRICS_ENDPOINT", "/metrics"),
+			DBStatsInterval: time.Duration(getEnvInt("DB_STATS_INTERVAL_SECONDS", 15)) * time.Second,
+			Exemplars:       getEnvBool("TELEMETRY_EXEMPLARS", true),
+			Region:          region,
+
+			SampleRatio:        getEnvFloat("TELEMETRY_SAMPLE_RATIO", 0.1),
+			TailSampling:       getEnvBool("TELEMETRY_TAIL_SAMPLING", true),
+			SlowTraceThreshold: time.Duration(getEnvInt("TELEMETRY_SLOW_TRACE_THRESHOLD_MS", 1000)) * time.Millisecond,
+
+			ExportTimeout: time.Duration(getEnvInt("TELEMETRY_EXPORT_TIMEOUT_SECONDS", 10)) * time.Second,
 		},
 	}
 }
@@ -108,6 +649,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat retrieves an environment variable as a float or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvBool retrieves an environment variable as a boolean or returns a default value
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -118,6 +669,21 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getEnvList retrieves a comma-separated environment variable as a slice or returns a default value
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // DSN returns the database connection string
 func (c *DatabaseConfig) DSN() string {
 	return "host=" + c.Host +
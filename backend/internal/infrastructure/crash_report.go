@@ -0,0 +1,212 @@
+package infrastructure
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxPendingCrashReports bounds the reports being sent at once; a panic storm
+// drops reports instead of piling up goroutines
+const maxPendingCrashReports = 8
+
+// CrashReport is a structured description of a recovered panic
+type CrashReport struct {
+	EventID   string // 32 hex characters, as Sentry expects
+	Timestamp time.Time
+	Message   string // The panic value
+	Stack     []StackFrame
+
+	Method    string
+	Route     string // Route pattern, e.g. "GET /api/contests/:id"
+	URL       string
+	Query     string
+	Headers   map[string]string
+	UserID    string
+	RequestID string
+	TraceID   string
+	SpanID    string
+}
+
+// StackFrame is one frame of a panic stack trace, innermost last
+type StackFrame struct {
+	Function string
+	Module   string
+	File     string
+	Line     int
+	InApp    bool // Part of this codebase rather than a dependency or the runtime
+}
+
+// CrashReportSender delivers crash reports to an error tracker
+type CrashReportSender interface {
+	Send(ctx context.Context, report *CrashReport) error
+}
+
+// CrashReporter scrubs, samples and sends reports of recovered panics in the
+// background. Without a DSN panics are only logged.
+type CrashReporter struct {
+	config *CrashReportConfig
+	sender CrashReportSender
+	scrub  map[string]bool // Lower-cased extra header and query parameter names to mask
+	logger *zap.Logger
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewCrashReporter creates a crash reporter sending to the configured DSN
+func NewCrashReporter(config *CrashReportConfig, release, environment string, logger *zap.Logger) (*CrashReporter, error) {
+	r := &CrashReporter{
+		config: config,
+		scrub:  make(map[string]bool, len(config.ScrubFields)),
+		logger: logger,
+		slots:  make(chan struct{}, maxPendingCrashReports),
+	}
+	for _, field := range config.ScrubFields {
+		r.scrub[strings.ToLower(field)] = true
+	}
+	if config.DSN != "" {
+		sender, err := NewSentrySender(config.DSN, release, environment, config.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		r.sender = sender
+	}
+	return r, nil
+}
+
+// NewReport describes a panic raised while serving req. It must be called from
+// the deferred function that recovered, so the panicking stack is still there.
+func (r *CrashReporter) NewReport(req *http.Request, route string, recovered interface{}) *CrashReport {
+	return &CrashReport{
+		EventID:   newEventID(),
+		Timestamp: time.Now().UTC(),
+		Message:   RedactString(fmt.Sprint(recovered)),
+		Stack:     panicStack(),
+		Method:    req.Method,
+		Route:     route,
+		URL:       RedactString(req.URL.Path),
+		Query:     r.scrubQuery(req.URL.RawQuery),
+		Headers:   r.scrubHeaders(req.Header),
+	}
+}
+
+// Capture sends the report unless it is sampled out, without waiting for the error tracker
+func (r *CrashReporter) Capture(report *CrashReport) {
+	if r.sender == nil || r.config.SampleRate <= 0 {
+		return
+	}
+	if r.config.SampleRate < 1 && rand.Float64() >= r.config.SampleRate {
+		return
+	}
+
+	select {
+	case r.slots <- struct{}{}:
+	default:
+		r.logger.Warn("Dropping crash report, too many pending", zap.String("event_id", report.EventID))
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.slots }()
+
+		if err := r.sender.Send(context.Background(), report); err != nil {
+			r.logger.Error("Failed to send crash report", zap.String("event_id", report.EventID), zap.Error(err))
+		}
+	}()
+}
+
+// Stop waits for pending reports to be sent, or until ctx is done
+func (r *CrashReporter) Stop(ctx context.Context) error {
+	return WaitContext(ctx, &r.wg)
+}
+
+func (r *CrashReporter) scrubbed(key string) bool {
+	return SensitiveKey(key) || r.scrub[strings.ToLower(key)]
+}
+
+func (r *CrashReporter) scrubHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		if r.scrubbed(key) {
+			headers[key] = Redacted
+			continue
+		}
+		headers[key] = RedactString(strings.Join(values, ", "))
+	}
+	return headers
+}
+
+func (r *CrashReporter) scrubQuery(rawQuery string) string {
+	if len(r.scrub) == 0 || rawQuery == "" {
+		return RedactQuery(rawQuery)
+	}
+	parts := strings.Split(rawQuery, "&")
+	for i, part := range parts {
+		if key, _, _ := strings.Cut(part, "="); r.scrub[strings.ToLower(key)] {
+			parts[i] = key + "=" + Redacted
+		}
+	}
+	return RedactQuery(strings.Join(parts, "&"))
+}
+
+// panicStack returns the stack of the panicking goroutine, outermost frame
+// first, without the frames of the recovery itself
+func panicStack() []StackFrame {
+	pcs := make([]uintptr, 100)
+	n := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []StackFrame
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.gopanic" {
+			// Frames collected so far belong to the recovery
+			stack = stack[:0]
+		} else {
+			module, function := splitFunction(frame.Function)
+			stack = append(stack, StackFrame{
+				Function: function,
+				Module:   module,
+				File:     frame.File,
+				Line:     frame.Line,
+				InApp:    strings.HasPrefix(module, "github.com/contest-maker-150/"),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+
+	// Sentry expects the innermost frame last
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack
+}
+
+// splitFunction splits "github.com/x/pkg.(*T).Method" into its package path and function
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot], name[slash+2+dot:]
+	}
+	return "", name
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = cryptorand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -2,9 +2,15 @@ package infrastructure
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
+	"github.com/glebarez/sqlite"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the "pgx" driver for the replica pools
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -16,8 +22,12 @@ import (
 // Database wraps the GORM database connection with additional utilities
 type Database struct {
 	*gorm.DB
-	config *DatabaseConfig
-	logger *zap.Logger
+	// Reader serves reads that tolerate replication lag. It routes queries to
+	// the read replicas through Replicas; without replicas it is DB.
+	Reader   *gorm.DB
+	Replicas *ReplicaRouter // nil without replicas
+	config   *DatabaseConfig
+	logger   *zap.Logger
 }
 
 // NewDatabase creates a new database connection with connection pooling
@@ -33,7 +43,12 @@ func NewDatabase(config *DatabaseConfig, zapLogger *zap.Logger) (*Database, erro
 		},
 	)
 
-	db, err := gorm.Open(postgres.Open(config.DSN()), &gorm.Config{
+	dialector, err := openDialector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger:                 gormLogger,
 		SkipDefaultTransaction: true, // Better performance for read operations
 		PrepareStmt:            true, // Cache prepared statements
@@ -42,6 +57,13 @@ func NewDatabase(config *DatabaseConfig, zapLogger *zap.Logger) (*Database, erro
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := RegisterErrorClassifier(db); err != nil {
+		return nil, fmt.Errorf("failed to register error classifier: %w", err)
+	}
+	if err := RegisterTenantScope(db); err != nil {
+		return nil, fmt.Errorf("failed to register tenant scope: %w", err)
+	}
+
 	// Get underlying SQL DB for connection pool configuration
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -53,35 +75,210 @@ func NewDatabase(config *DatabaseConfig, zapLogger *zap.Logger) (*Database, erro
 	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 
-	zapLogger.Info("Database connection established",
-		zap.String("host", config.Host),
-		zap.Int("port", config.Port),
-		zap.String("database", config.DBName),
-		zap.Int("max_open_conns", config.MaxOpenConns),
-	)
+	if config.Driver == DriverSQLite {
+		// SQLite allows a single writer; one connection avoids "database is locked" errors
+		// and keeps an in-memory database alive for the lifetime of the pool
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+
+		zapLogger.Info("Database connection established",
+			zap.String("driver", config.Driver),
+			zap.String("path", config.SQLitePath),
+		)
+	} else {
+		zapLogger.Info("Database connection established",
+			zap.String("driver", config.Driver),
+			zap.String("host", config.Host),
+			zap.Int("port", config.Port),
+			zap.String("database", config.DBName),
+			zap.Int("max_open_conns", config.MaxOpenConns),
+		)
+	}
 
-	return &Database{
+	database := &Database{
 		DB:     db,
+		Reader: db,
 		config: config,
 		logger: zapLogger,
-	}, nil
+	}
+	if config.Driver == DriverPostgres && len(config.ReplicaHosts) > 0 {
+		if err := database.openReplicas(sqlDB, gormLogger); err != nil {
+			_ = sqlDB.Close()
+			return nil, err
+		}
+	}
+	return database, nil
+}
+
+// openReplicas opens a pool per read replica and the Reader that routes between them
+func (d *Database) openReplicas(primary *sql.DB, gormLogger logger.Interface) error {
+	pools := make(map[string]*sql.DB, len(d.config.ReplicaHosts))
+	closeAll := func() {
+		for _, pool := range pools {
+			_ = pool.Close()
+		}
+	}
+	for _, host := range d.config.ReplicaHosts {
+		replicaConfig := *d.config
+		replicaConfig.Host = host
+		if h, port, err := net.SplitHostPort(host); err == nil {
+			replicaConfig.Host = h
+			if replicaConfig.Port, err = strconv.Atoi(port); err != nil {
+				closeAll()
+				return fmt.Errorf("invalid read replica %q: %w", host, err)
+			}
+		}
+		pool, err := sql.Open("pgx", replicaConfig.DSN())
+		if err != nil {
+			closeAll()
+			return fmt.Errorf("failed to open read replica %q: %w", host, err)
+		}
+		pool.SetMaxOpenConns(d.config.MaxOpenConns)
+		pool.SetMaxIdleConns(d.config.MaxIdleConns)
+		pool.SetConnMaxLifetime(d.config.ConnMaxLifetime)
+		pools[host] = pool
+	}
+
+	router := NewReplicaRouter(primary, pools, d.config, d.logger)
+	reader, err := gorm.Open(postgres.New(postgres.Config{Conn: router}), &gorm.Config{
+		Logger:                 gormLogger,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		closeAll()
+		return fmt.Errorf("failed to open read database: %w", err)
+	}
+	if err := RegisterErrorClassifier(reader); err != nil {
+		closeAll()
+		return fmt.Errorf("failed to register error classifier: %w", err)
+	}
+	if err := RegisterTenantScope(reader); err != nil {
+		closeAll()
+		return fmt.Errorf("failed to register tenant scope: %w", err)
+	}
+
+	d.Reader = reader
+	d.Replicas = router
+	d.logger.Info("Read replicas configured",
+		zap.Strings("replicas", d.config.ReplicaHosts),
+		zap.Duration("max_lag", d.config.ReplicaMaxLag),
+	)
+	return nil
+}
+
+// openDialector returns the GORM dialector for the configured driver
+func openDialector(config *DatabaseConfig) (gorm.Dialector, error) {
+	switch config.Driver {
+	case DriverPostgres:
+		return postgres.Open(config.DSN()), nil
+	case DriverSQLite:
+		return sqlite.Open(config.SQLitePath + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", config.Driver)
+	}
 }
 
 // AutoMigrate runs database migrations for all domain entities
 func (d *Database) AutoMigrate() error {
 	d.logger.Info("Running database migrations...")
-	
+
 	err := d.DB.AutoMigrate(
 		&domain.User{},
 		&domain.Problem{},
+		&domain.ProblemCompany{},
+		&domain.ProblemPrerequisite{},
+		&domain.RoadmapCategory{},
+		&domain.RoadmapProblem{},
 		&domain.Contest{},
 		&domain.ContestProblem{},
+		&domain.ContestTag{},
+		&domain.ContestChallenge{},
+		&domain.ChatMessage{},
+		&domain.QuickCommandLog{},
 		&domain.Submission{},
+		&domain.Attempt{},
+		&domain.SavedFilter{},
+		&domain.UserProgressSummary{},
+		&domain.RevokedToken{},
+		&domain.FeatureFlag{},
+		&domain.MaintenanceWindow{},
+		&domain.CohortWeek{},
+		&domain.LogLevelOverride{},
+		&domain.RateLimitCounter{},
+		&domain.UserPresence{},
+		&domain.QuotaOverride{},
+		&domain.BillingEvent{},
+		&domain.Organization{},
+		&domain.OrgMember{},
+		&domain.OrgInvite{},
+		&domain.OrgAssignment{},
+		&domain.OrgAssignmentContest{},
+		&domain.Mentorship{},
+		&domain.MentorshipAuditEntry{},
+		&domain.MentorAssignment{},
+		&domain.ContestEvent{},
+		&domain.DigestSubscription{},
+		&domain.RecommendationDigest{},
+		&domain.DigestItem{},
+		&domain.SolutionSnippet{},
+		&domain.SimilarityFlag{},
+		&domain.Tenant{},
+		&domain.FeedItem{},
+		&domain.SeedVersion{},
+		&domain.ProblemList{},
+		&domain.ProblemListSubscription{},
+		&domain.ProblemListReport{},
+		&domain.ContestSession{},
+		&domain.JobRun{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// The unique index replaced this plain one on the same columns
+	if d.DB.Migrator().HasIndex(&domain.Submission{}, "idx_submissions_user_problem") {
+		if err := d.DB.Migrator().DropIndex(&domain.Submission{}, "idx_submissions_user_problem"); err != nil {
+			return fmt.Errorf("failed to drop superseded submissions index: %w", err)
+		}
+	}
+
+	// Emails became unique per tenant, so the global index would block a
+	// tenant's user from signing up with an email the default tenant has
+	if d.DB.Migrator().HasIndex(&domain.User{}, "idx_users_email") {
+		if err := d.DB.Migrator().DropIndex(&domain.User{}, "idx_users_email"); err != nil {
+			return fmt.Errorf("failed to drop superseded users index: %w", err)
+		}
+	}
+
+	// Fuzzy problem search matches titles through a trigram index; pg_trgm is a
+	// trusted extension, so the database owner can create it
+	if d.DB.Dialector.Name() == DriverPostgres {
+		for _, stmt := range []string{
+			"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+			"CREATE INDEX IF NOT EXISTS idx_problems_title_trgm ON problems USING gin (lower(title) gin_trgm_ops)",
+		} {
+			if err := d.DB.Exec(stmt).Error; err != nil {
+				return fmt.Errorf("failed to create problem search index: %w", err)
+			}
+		}
+	}
+
+	// Note search matches retros and solution snippets through text search
+	// indexes led by user_id, so a search only reads the user's own notes;
+	// btree_gin, trusted like pg_trgm, lets a GIN index hold the uuid column.
+	// The expressions match the queries of the note search repository.
+	if d.DB.Dialector.Name() == DriverPostgres {
+		for _, stmt := range []string{
+			"CREATE EXTENSION IF NOT EXISTS btree_gin",
+			"CREATE INDEX IF NOT EXISTS idx_contests_retro_fts ON contests USING gin (user_id, to_tsvector('english', retro))",
+			"CREATE INDEX IF NOT EXISTS idx_solution_snippets_code_fts ON solution_snippets USING gin (user_id, to_tsvector('simple', code))",
+		} {
+			if err := d.DB.Exec(stmt).Error; err != nil {
+				return fmt.Errorf("failed to create note search index: %w", err)
+			}
+		}
+	}
+
 	d.logger.Info("Database migrations completed successfully")
 	return nil
 }
@@ -95,13 +292,17 @@ func (d *Database) HealthCheck(ctx context.Context) error {
 	return sqlDB.PingContext(ctx)
 }
 
-// Close closes the database connection
+// Close closes the database connection and the read replica pools
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
 	if err != nil {
 		return err
 	}
-	return sqlDB.Close()
+	var replicaErr error
+	if d.Replicas != nil {
+		replicaErr = d.Replicas.Close()
+	}
+	return errors.Join(sqlDB.Close(), replicaErr)
 }
 
 // WithContext returns a DB with the given context for tracing
@@ -0,0 +1,125 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// pgErrorClasses maps Postgres SQLSTATE codes to domain errors
+// (https://www.postgresql.org/docs/current/errcodes-appendix.html)
+var pgErrorClasses = map[string]error{
+	"23505": domain.ErrConflict,            // unique_violation
+	"40001": domain.ErrConflict,            // serialization_failure
+	"40P01": domain.ErrConflict,            // deadlock_detected
+	"23503": domain.ErrForeignKeyViolation, // foreign_key_violation
+	"57014": domain.ErrTimeout,             // query_canceled (statement_timeout)
+	"55P03": domain.ErrTimeout,             // lock_not_available (lock_timeout)
+	"23502": domain.ErrBadRequest,          // not_null_violation
+	"23514": domain.ErrBadRequest,          // check_violation
+	"22001": domain.ErrBadRequest,          // string_data_right_truncation
+	"22P02": domain.ErrBadRequest,          // invalid_text_representation
+	"53300": domain.ErrUnavailable,         // too_many_connections
+	"57P01": domain.ErrUnavailable,         // admin_shutdown
+	"57P02": domain.ErrUnavailable,         // crash_shutdown
+	"57P03": domain.ErrUnavailable,         // cannot_connect_now
+}
+
+// sqliteErrorClasses maps SQLite extended result codes to domain errors
+// (https://www.sqlite.org/rescode.html), so the sqlite driver behaves like Postgres
+var sqliteErrorClasses = map[int]error{
+	2067: domain.ErrConflict,            // SQLITE_CONSTRAINT_UNIQUE
+	1555: domain.ErrConflict,            // SQLITE_CONSTRAINT_PRIMARYKEY
+	787:  domain.ErrForeignKeyViolation, // SQLITE_CONSTRAINT_FOREIGNKEY
+	5:    domain.ErrTimeout,             // SQLITE_BUSY, after busy_timeout elapsed
+	9:    domain.ErrTimeout,             // SQLITE_INTERRUPT
+	1299: domain.ErrBadRequest,          // SQLITE_CONSTRAINT_NOTNULL
+	275:  domain.ErrBadRequest,          // SQLITE_CONSTRAINT_CHECK
+	10:   domain.ErrUnavailable,         // SQLITE_IOERR
+	14:   domain.ErrUnavailable,         // SQLITE_CANTOPEN
+}
+
+// ClassifyDBError translates a driver error into a domain error that wraps it, so
+// callers can match it with errors.Is while logs keep the driver's message.
+// Errors that are already classified, not-found results and unknown errors are
+// returned unchanged.
+func ClassifyDBError(err error) error {
+	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) || isClassified(err) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if class, ok := pgErrorClasses[pgErr.Code]; ok {
+			return fmt.Errorf("%w: %w", class, err)
+		}
+		// Class 08 is connection_exception
+		if strings.HasPrefix(pgErr.Code, "08") {
+			return fmt.Errorf("%w: %w", domain.ErrUnavailable, err)
+		}
+		return err
+	}
+
+	// The database could not be reached or dropped the connection; a query
+	// cut off by the request's deadline is a timeout, not an outage
+	var connectErr *pgconn.ConnectError
+	var netErr *net.OpError
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) &&
+		(errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+			errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone)) {
+		return fmt.Errorf("%w: %w", domain.ErrUnavailable, err)
+	}
+
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		if class, ok := sqliteErrorClasses[sqliteErr.Code()]; ok {
+			return fmt.Errorf("%w: %w", class, err)
+		}
+	}
+	return err
+}
+
+// isClassified reports whether err already carries a domain error class
+func isClassified(err error) bool {
+	for _, class := range []error{domain.ErrConflict, domain.ErrForeignKeyViolation, domain.ErrTimeout, domain.ErrBadRequest, domain.ErrUnavailable} {
+		if errors.Is(err, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterErrorClassifier classifies the error of every GORM operation with
+// ClassifyDBError, so repositories never leak raw driver errors
+func RegisterErrorClassifier(db *gorm.DB) error {
+	classify := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			tx.Error = ClassifyDBError(tx.Error)
+		}
+	}
+
+	// GORM's processor types are unexported, so each operation is registered explicitly
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().After("gorm:create").Register("errors:classify_create", classify),
+		cb.Query().After("gorm:query").Register("errors:classify_query", classify),
+		cb.Update().After("gorm:update").Register("errors:classify_update", classify),
+		cb.Delete().After("gorm:delete").Register("errors:classify_delete", classify),
+		cb.Row().After("gorm:row").Register("errors:classify_row", classify),
+		cb.Raw().After("gorm:raw").Register("errors:classify_raw", classify),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
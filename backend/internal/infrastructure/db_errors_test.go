@@ -0,0 +1,152 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// sqliteError stands in for the sqlite driver's error, which exposes its
+// extended result code
+type sqliteError int
+
+func (e sqliteError) Error() string { return fmt.Sprintf("sqlite error %d", int(e)) }
+func (e sqliteError) Code() int     { return int(e) }
+
+func TestClassifyDBErrorPostgresCodes(t *testing.T) {
+	tests := []struct {
+		code string
+		want error
+	}{
+		{"23505", domain.ErrConflict},            // unique_violation
+		{"40001", domain.ErrConflict},            // serialization_failure
+		{"40P01", domain.ErrConflict},            // deadlock_detected
+		{"23503", domain.ErrForeignKeyViolation}, // foreign_key_violation
+		{"57014", domain.ErrTimeout},             // query_canceled by statement_timeout
+		{"55P03", domain.ErrTimeout},             // lock_not_available
+		{"23502", domain.ErrBadRequest},          // not_null_violation
+		{"23514", domain.ErrBadRequest},          // check_violation
+		{"22001", domain.ErrBadRequest},          // string_data_right_truncation
+		{"22P02", domain.ErrBadRequest},          // invalid_text_representation
+		{"53300", domain.ErrUnavailable},         // too_many_connections
+		{"57P01", domain.ErrUnavailable},         // admin_shutdown
+		{"57P02", domain.ErrUnavailable},         // crash_shutdown
+		{"57P03", domain.ErrUnavailable},         // cannot_connect_now
+		// Class 08 is matched by prefix
+		{"08000", domain.ErrUnavailable}, // connection_exception
+		{"08006", domain.ErrUnavailable}, // connection_failure
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			pgErr := &pgconn.PgError{Code: tt.code, Message: "boom"}
+			got := ClassifyDBError(fmt.Errorf("query: %w", pgErr))
+			if !errors.Is(got, tt.want) {
+				t.Fatalf("ClassifyDBError(%s) = %v, want %v", tt.code, got, tt.want)
+			}
+			// The driver error stays reachable for logs and callers
+			var unwrapped *pgconn.PgError
+			if !errors.As(got, &unwrapped) || unwrapped != pgErr {
+				t.Fatalf("ClassifyDBError(%s) lost the driver error", tt.code)
+			}
+		})
+	}
+}
+
+func TestClassifyDBErrorUnknownPostgresCode(t *testing.T) {
+	err := &pgconn.PgError{Code: "42P01"} // undefined_table
+	if got := ClassifyDBError(err); got != error(err) {
+		t.Fatalf("ClassifyDBError(42P01) = %v, want it unchanged", got)
+	}
+}
+
+func TestClassifyDBErrorSQLiteCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+		want error
+	}{
+		{"constraint unique", 2067, domain.ErrConflict},
+		{"constraint primary key", 1555, domain.ErrConflict},
+		{"constraint foreign key", 787, domain.ErrForeignKeyViolation},
+		{"busy", 5, domain.ErrTimeout},
+		{"interrupt", 9, domain.ErrTimeout},
+		{"constraint not null", 1299, domain.ErrBadRequest},
+		{"constraint check", 275, domain.ErrBadRequest},
+		{"io error", 10, domain.ErrUnavailable},
+		{"cannot open", 14, domain.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyDBError(sqliteError(tt.code)); !errors.Is(got, tt.want) {
+				t.Fatalf("ClassifyDBError(sqlite %d) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+
+	err := sqliteError(1) // SQLITE_ERROR
+	if got := ClassifyDBError(err); got != error(err) {
+		t.Fatalf("ClassifyDBError(sqlite 1) = %v, want it unchanged", got)
+	}
+}
+
+func TestClassifyDBErrorConnection(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name        string
+		err         error
+		unavailable bool
+	}{
+		{"network error", refused, true},
+		{"bad connection", fmt.Errorf("exec: %w", driver.ErrBadConn), true},
+		{"connection done", sql.ErrConnDone, true},
+		{"connect error", &pgconn.ConnectError{}, true},
+		// A query cut off by the request's deadline is not an outage
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"deadline on the network", &net.OpError{Op: "read", Net: "tcp", Err: context.DeadlineExceeded}, false},
+		{"canceled", fmt.Errorf("%w: %w", context.Canceled, refused), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyDBError(tt.err)
+			if errors.Is(got, domain.ErrUnavailable) != tt.unavailable {
+				t.Fatalf("ClassifyDBError(%v) = %v, unavailable = %t", tt.err, got, tt.unavailable)
+			}
+			if !tt.unavailable && got != tt.err {
+				t.Fatalf("ClassifyDBError(%v) = %v, want it unchanged", tt.err, got)
+			}
+		})
+	}
+}
+
+func TestClassifyDBErrorPassesThrough(t *testing.T) {
+	classified := fmt.Errorf("%w: %w", domain.ErrConflict, &pgconn.PgError{Code: "53300"})
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"nil", nil},
+		{"not found", gorm.ErrRecordNotFound},
+		{"wrapped not found", fmt.Errorf("find: %w", gorm.ErrRecordNotFound)},
+		// Classified once already: not reclassified as unavailable by its driver code
+		{"already classified", classified},
+		{"unknown", errors.New("something else")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyDBError(tt.err); got != tt.err {
+				t.Fatalf("ClassifyDBError(%v) = %v, want it unchanged", tt.err, got)
+			}
+		})
+	}
+}
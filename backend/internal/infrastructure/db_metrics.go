@@ -0,0 +1,218 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// queryStartKey is the statement instance key holding when a query started
+	queryStartKey = "metrics:query_start"
+	// querySpanKey is the statement instance key holding the query's span
+	querySpanKey = "metrics:query_span"
+)
+
+// InstrumentQueries traces every GORM operation and records its duration in the
+// histogram, labelled with the operation (create, query, update, delete, row, raw)
+// and table. The duration is recorded under the query's span, so histogram
+// exemplars point at the span of a slow query.
+func InstrumentQueries(db *gorm.DB, tracer trace.Tracer, duration metric.Float64Histogram) error {
+	system := db.Dialector.Name()
+
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			tx.InstanceSet(queryStartKey, time.Now())
+			_, span := startQuerySpan(tx.Statement.Context, tracer, "db."+operation,
+				attribute.String("db.system", system),
+				attribute.String("db.operation", operation),
+			)
+			tx.InstanceSet(querySpanKey, span)
+		}
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			v, ok := tx.InstanceGet(queryStartKey)
+			if !ok {
+				return
+			}
+			start, ok := v.(time.Time)
+			if !ok {
+				return
+			}
+
+			table := tx.Statement.Table
+			if table == "" {
+				table = "unknown" // Raw SQL without a model
+			}
+			failed := tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound)
+
+			ctx := tx.Statement.Context
+			if v, ok := tx.InstanceGet(querySpanKey); ok {
+				if span, ok := v.(trace.Span); ok {
+					span.SetName("db." + operation + " " + table)
+					span.SetAttributes(
+						attribute.String("db.sql.table", table),
+						attribute.String("db.statement", tx.Statement.SQL.String()),
+						attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+					)
+					if failed {
+						span.RecordError(tx.Error)
+						span.SetStatus(codes.Error, "query failed")
+					}
+					span.End()
+					ctx = trace.ContextWithSpan(ctx, span)
+				}
+			}
+
+			duration.Record(ctx, time.Since(start).Seconds(),
+				metric.WithAttributes(
+					attribute.String("db.operation", operation),
+					attribute.String("db.sql.table", table),
+					attribute.Bool("error", failed),
+				),
+			)
+		}
+	}
+
+	// GORM's processor types are unexported, so each operation is registered explicitly
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startQuerySpan starts a client span for a query. Queries inside a request are
+// children of the request's span. Queries running after that span ended, such as
+// in event handlers, which inherit the publishing request's context, start a new
+// trace linked to it instead of extending a finished trace.
+func startQuerySpan(ctx context.Context, tracer trace.Tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	}
+	parent := trace.SpanFromContext(ctx)
+	if sc := parent.SpanContext(); sc.IsSampled() && !parent.IsRecording() {
+		opts = append(opts, trace.WithNewRoot(), trace.WithLinks(trace.Link{SpanContext: sc}))
+	}
+	return tracer.Start(ctx, name, opts...)
+}
+
+// DBStatsExporter periodically publishes connection pool statistics (sql.DBStats)
+// as gauges, so pool saturation shows up next to request latency
+type DBStatsExporter struct {
+	db       *sql.DB
+	interval time.Duration
+	logger   *zap.Logger
+	wg       sync.WaitGroup
+	cancel   context.CancelFunc
+
+	maxOpen      metric.Int64Gauge
+	open         metric.Int64Gauge
+	inUse        metric.Int64Gauge
+	idle         metric.Int64Gauge
+	waitCount    metric.Int64Gauge
+	waitDuration metric.Float64Gauge
+}
+
+// NewDBStatsExporter creates the pool gauges on the meter
+func NewDBStatsExporter(meter metric.Meter, db *sql.DB, interval time.Duration, logger *zap.Logger) (*DBStatsExporter, error) {
+	e := &DBStatsExporter{db: db, interval: interval, logger: logger}
+
+	var err error
+	if e.maxOpen, err = meter.Int64Gauge("db.pool.connections.max_open",
+		metric.WithDescription("Maximum number of open connections allowed")); err != nil {
+		return nil, err
+	}
+	if e.open, err = meter.Int64Gauge("db.pool.connections.open",
+		metric.WithDescription("Number of established connections, in use or idle")); err != nil {
+		return nil, err
+	}
+	if e.inUse, err = meter.Int64Gauge("db.pool.connections.in_use",
+		metric.WithDescription("Number of connections currently in use")); err != nil {
+		return nil, err
+	}
+	if e.idle, err = meter.Int64Gauge("db.pool.connections.idle",
+		metric.WithDescription("Number of idle connections")); err != nil {
+		return nil, err
+	}
+	if e.waitCount, err = meter.Int64Gauge("db.pool.wait.count",
+		metric.WithDescription("Total number of connections waited for since startup")); err != nil {
+		return nil, err
+	}
+	if e.waitDuration, err = meter.Float64Gauge("db.pool.wait.duration",
+		metric.WithDescription("Total time blocked waiting for a connection since startup"),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Start samples the pool immediately and then on every interval
+func (e *DBStatsExporter) Start(ctx context.Context) {
+	ctx, e.cancel = context.WithCancel(ctx)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		e.Collect(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.Collect(ctx)
+			}
+		}
+	}()
+
+	e.logger.Info("Database pool metrics exporter started", zap.Duration("interval", e.interval))
+}
+
+// Stop stops sampling
+func (e *DBStatsExporter) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+}
+
+// Collect records the current pool statistics
+func (e *DBStatsExporter) Collect(ctx context.Context) {
+	stats := e.db.Stats()
+	e.maxOpen.Record(ctx, int64(stats.MaxOpenConnections))
+	e.open.Record(ctx, int64(stats.OpenConnections))
+	e.inUse.Record(ctx, int64(stats.InUse))
+	e.idle.Record(ctx, int64(stats.Idle))
+	e.waitCount.Record(ctx, stats.WaitCount)
+	e.waitDuration.Record(ctx, stats.WaitDuration.Seconds())
+}
@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// Pinger is implemented by the clients of external dependencies that can be
+// probed without side effects
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingFunc adapts a function to a Pinger
+type PingFunc func(ctx context.Context) error
+
+// Ping calls f
+func (f PingFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+// DependencyStatus is the result of the last probe of an external dependency
+type DependencyStatus struct {
+	Name      string    `json:"name"`
+	Required  bool      `json:"required"` // The instance is not ready while a required dependency is down
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMS float64   `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// dependency is one probed dependency and its last status
+type dependency struct {
+	pinger Pinger
+	mu     sync.Mutex
+	status DependencyStatus
+}
+
+// DependencyMonitor probes the external dependencies in the background, so
+// /readyz answers from the last probes instead of reaching out on every call.
+// Optional dependencies, such as the mail server, are reported but do not make
+// the instance unready: the features using them degrade on their own.
+type DependencyMonitor struct {
+	dependencies []*dependency
+	interval     time.Duration
+	timeout      time.Duration
+	logger       *zap.Logger
+	wg           sync.WaitGroup
+	cancel       context.CancelFunc
+}
+
+// NewDependencyMonitor creates a monitor without dependencies; Add them before Start
+func NewDependencyMonitor(config *ReadinessConfig, logger *zap.Logger) *DependencyMonitor {
+	return &DependencyMonitor{
+		interval: config.Interval,
+		timeout:  config.Timeout,
+		logger:   logger,
+	}
+}
+
+// Add registers a dependency to probe
+func (m *DependencyMonitor) Add(name string, required bool, pinger Pinger) {
+	m.dependencies = append(m.dependencies, &dependency{
+		pinger: pinger,
+		status: DependencyStatus{Name: name, Required: required},
+	})
+}
+
+// Start probes every dependency once, then keeps probing in the background
+func (m *DependencyMonitor) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.check(ctx)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the probes
+func (m *DependencyMonitor) Stop(ctx context.Context) error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	return WaitContext(ctx, &m.wg)
+}
+
+// check probes the dependencies concurrently, so one that hangs until its
+// timeout does not delay the others
+func (m *DependencyMonitor) check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, dep := range m.dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.probe(ctx, dep)
+		}()
+	}
+	wg.Wait()
+}
+
+// probe pings one dependency, logging when it goes down or recovers
+func (m *DependencyMonitor) probe(ctx context.Context, dep *dependency) {
+	probeCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	start := time.Now()
+	err := dep.pinger.Ping(probeCtx)
+	latency := time.Since(start)
+	cancel()
+	if ctx.Err() != nil {
+		return // Shutting down, not a failed probe
+	}
+
+	dep.mu.Lock()
+	checked := !dep.status.CheckedAt.IsZero()
+	wasHealthy := dep.status.Healthy
+	dep.status.Healthy = err == nil
+	dep.status.Error = ""
+	if err != nil {
+		dep.status.Error = err.Error()
+	}
+	dep.status.LatencyMS = float64(latency.Microseconds()) / 1000
+	dep.status.CheckedAt = time.Now().UTC()
+	status := dep.status
+	dep.mu.Unlock()
+
+	switch {
+	case err != nil && (wasHealthy || !checked):
+		m.logger.Warn("Dependency is down",
+			zap.String("dependency", status.Name),
+			zap.Bool("required", status.Required),
+			zap.Error(err),
+		)
+	case err == nil && !wasHealthy && checked:
+		m.logger.Info("Dependency recovered", zap.String("dependency", status.Name))
+	}
+}
+
+// Status reports the last probe of every dependency and whether the instance
+// is ready, which it is while every required dependency is healthy
+func (m *DependencyMonitor) Status() (bool, []DependencyStatus) {
+	ready := true
+	statuses := make([]DependencyStatus, len(m.dependencies))
+	for i, dep := range m.dependencies {
+		dep.mu.Lock()
+		statuses[i] = dep.status
+		dep.mu.Unlock()
+		if statuses[i].Required && !statuses[i].Healthy {
+			ready = false
+		}
+	}
+	return ready, statuses
+}
+
+// RegisterMetrics exports whether each dependency passed its last probe
+func (m *DependencyMonitor) RegisterMetrics(meter metric.Meter) error {
+	_, err := meter.Int64ObservableGauge(
+		"dependency.up",
+		metric.WithDescription("Whether each external dependency passed its last readiness probe (1) or not (0)"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			_, statuses := m.Status()
+			for _, status := range statuses {
+				var up int64
+				if status.Healthy {
+					up = 1
+				}
+				o.Observe(up, metric.WithAttributes(
+					attribute.String("dependency", status.Name),
+					attribute.Bool("dependency.required", status.Required),
+				))
+			}
+			return nil
+		}),
+	)
+	return err
+}
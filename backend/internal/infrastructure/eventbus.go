@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// EventHandler handles a single domain event
+type EventHandler func(ctx context.Context, event domain.Event) error
+
+// envelope carries an event together with the context it was published under
+type envelope struct {
+	ctx   context.Context
+	event domain.Event
+}
+
+// EventBus is an in-process, asynchronous publish/subscribe bus.
+// Events are queued and delivered by a single dispatcher goroutine so
+// publishers never block on subscriber work.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+	queue    chan envelope
+	done     chan struct{}
+	closeMu  sync.RWMutex
+	closed   bool
+	logger   *zap.Logger
+}
+
+// NewEventBus creates a new event bus with the given queue capacity and starts dispatching
+func NewEventBus(bufferSize int, logger *zap.Logger) *EventBus {
+	b := &EventBus{
+		handlers: make(map[string][]EventHandler),
+		queue:    make(chan envelope, bufferSize),
+		done:     make(chan struct{}),
+		logger:   logger,
+	}
+	go b.dispatch()
+	return b
+}
+
+// Subscribe registers a handler for the named event
+func (b *EventBus) Subscribe(eventName string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventName] = append(b.handlers[eventName], handler)
+}
+
+// Publish enqueues an event for delivery. If the queue is full the event is
+// dropped and logged rather than blocking the caller's request. An event
+// published inside a request transaction is enqueued once it commits, since
+// handlers could not see its changes before, and dropped if it rolls back.
+func (b *EventBus) Publish(ctx context.Context, event domain.Event) {
+	if InTx(ctx) {
+		AfterCommit(ctx, func() { b.Publish(WithoutTx(ctx), event) })
+		return
+	}
+
+	b.closeMu.RLock()
+	defer b.closeMu.RUnlock()
+
+	if b.closed {
+		b.logger.Warn("Event published after bus closed", zap.String("event", event.EventName()))
+		return
+	}
+
+	// Detach from request cancellation but keep trace/log values
+	env := envelope{ctx: context.WithoutCancel(ctx), event: event}
+	select {
+	case b.queue <- env:
+	default:
+		b.logger.Warn("Event bus queue full, dropping event", zap.String("event", event.EventName()))
+	}
+}
+
+// Close stops accepting events and waits until queued events are delivered
+// or the context expires
+func (b *EventBus) Close(ctx context.Context) error {
+	b.closeMu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.closeMu.Unlock()
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dispatch delivers queued events to their subscribers in order
+func (b *EventBus) dispatch() {
+	defer close(b.done)
+	for env := range b.queue {
+		b.mu.RLock()
+		handlers := b.handlers[env.event.EventName()]
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			b.deliver(env, handler)
+		}
+	}
+}
+
+// deliver runs one handler, isolating the bus from handler errors and panics
+func (b *EventBus) deliver(env envelope, handler EventHandler) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked",
+				zap.String("event", env.event.EventName()),
+				zap.Any("panic", r),
+			)
+		}
+	}()
+
+	if err := handler(env.ctx, env.event); err != nil {
+		b.logger.Error("Event handler failed",
+			zap.String("event", env.event.EventName()),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// FeatureFlags evaluates feature flags. Defaults come from FEATURE_FLAGS; flags
+// toggled by an admin are stored in the database and override them. Overrides
+// are reloaded every refresh interval, so all instances pick up a toggle.
+type FeatureFlags struct {
+	repo     domain.FeatureFlagRepository
+	defaults map[string]domain.FeatureFlag
+	flags    *refreshingValue[map[string]domain.FeatureFlag]
+}
+
+// NewFeatureFlags creates the flag evaluator. Configured entries are "key" for
+// a flag on for everyone or "key=percent" for a partial rollout.
+func NewFeatureFlags(repo domain.FeatureFlagRepository, config *FeatureFlagConfig, logger *zap.Logger) *FeatureFlags {
+	f := &FeatureFlags{
+		repo:     repo,
+		defaults: make(map[string]domain.FeatureFlag, len(domain.KnownFeatureFlags)),
+	}
+	for key, description := range domain.KnownFeatureFlags {
+		f.defaults[key] = domain.FeatureFlag{Key: key, Description: description, Source: domain.FeatureFlagSourceDefault}
+	}
+
+	for _, entry := range config.Defaults {
+		key, value, hasPercent := strings.Cut(entry, "=")
+		flag, ok := f.defaults[key]
+		if !ok {
+			logger.Warn("Ignoring unknown feature flag", zap.String("flag", key))
+			continue
+		}
+		percent := 100
+		if hasPercent {
+			p, err := strconv.Atoi(value)
+			if err != nil || p < 0 || p > 100 {
+				logger.Warn("Ignoring feature flag with invalid rollout percentage", zap.String("flag", entry))
+				continue
+			}
+			percent = p
+		}
+		flag.Enabled = percent > 0
+		flag.RolloutPercent = percent
+		flag.Source = domain.FeatureFlagSourceConfig
+		f.defaults[key] = flag
+	}
+	f.flags = newRefreshingValue("feature flags", f.defaults, config.RefreshInterval, logger, f.load)
+	return f
+}
+
+// Enabled reports whether the flag is on for the user; uuid.Nil is an anonymous user
+func (f *FeatureFlags) Enabled(ctx context.Context, key string, userID uuid.UUID) bool {
+	flag, ok := f.flags.Get(ctx)[key]
+	return ok && flag.EnabledFor(userID)
+}
+
+// EnabledFor returns the state of every known flag for the user
+func (f *FeatureFlags) EnabledFor(ctx context.Context, userID uuid.UUID) map[string]bool {
+	flags := f.flags.Get(ctx)
+	enabled := make(map[string]bool, len(flags))
+	for key, flag := range flags {
+		enabled[key] = flag.EnabledFor(userID)
+	}
+	return enabled
+}
+
+// List returns every known flag with its current setting, ordered by key
+func (f *FeatureFlags) List(ctx context.Context) []domain.FeatureFlag {
+	flags := f.flags.Get(ctx)
+	list := make([]domain.FeatureFlag, 0, len(flags))
+	for _, flag := range flags {
+		list = append(list, flag)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
+
+// Set stores an override for a known flag and applies it immediately on this instance
+func (f *FeatureFlags) Set(ctx context.Context, flag *domain.FeatureFlag) error {
+	if _, ok := domain.KnownFeatureFlags[flag.Key]; !ok {
+		return domain.ErrFeatureFlagNotFound
+	}
+	if err := f.repo.WithContext(ctx).Save(flag); err != nil {
+		return err
+	}
+	return f.flags.Reload(ctx)
+}
+
+// load merges the stored overrides into the defaults
+func (f *FeatureFlags) load(ctx context.Context) (map[string]domain.FeatureFlag, error) {
+	overrides, err := f.repo.WithContext(ctx).FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]domain.FeatureFlag, len(f.defaults))
+	for key, flag := range f.defaults {
+		flags[key] = flag
+	}
+	for _, override := range overrides {
+		flag, ok := flags[override.Key]
+		if !ok {
+			continue // A flag that was removed from the code
+		}
+		flag.Enabled = override.Enabled
+		flag.RolloutPercent = override.RolloutPercent
+		flag.UpdatedAt = override.UpdatedAt
+		flag.Source = domain.FeatureFlagSourceDatabase
+		flags[override.Key] = flag
+	}
+	return flags, nil
+}
+
+type featureFlagsKey struct{}
+
+// ContextWithFeatureFlags returns a copy of ctx carrying the flag evaluator
+func ContextWithFeatureFlags(ctx context.Context, flags *FeatureFlags) context.Context {
+	return context.WithValue(ctx, featureFlagsKey{}, flags)
+}
+
+// FeatureEnabled reports whether the flag is on for the user, using the
+// evaluator stored in ctx. Without one every flag is off.
+func FeatureEnabled(ctx context.Context, key string, userID uuid.UUID) bool {
+	flags, ok := ctx.Value(featureFlagsKey{}).(*FeatureFlags)
+	return ok && flags.Enabled(ctx, key, userID)
+}
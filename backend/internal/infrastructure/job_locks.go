@@ -0,0 +1,166 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// jobLockTimeout bounds taking and releasing a job lock
+const jobLockTimeout = 5 * time.Second
+
+// jobRunSlackDivisor sets how early, as a fraction of the interval, a
+// scheduled job may run again: a tick lands a little under one interval after
+// the previous run was recorded
+const jobRunSlackDivisor = 10
+
+// JobLocks keep a background job from running on several instances at once
+// when the API is scaled out. On Postgres each job takes a session advisory
+// lock on a connection of its own, which the database releases when the job
+// ends or the instance dies with the connection. A SQLite database belongs to
+// one process, so there an in-process lock does. Scheduled jobs also record
+// when they last ran, so every instance's ticker firing in the same interval
+// still runs the job once.
+type JobLocks struct {
+	db     *gorm.DB
+	logger *zap.Logger
+
+	mu    sync.Mutex
+	local map[string]bool // Jobs running in this process
+}
+
+// NewJobLocks creates the job locks of the database
+func NewJobLocks(db *gorm.DB, logger *zap.Logger) *JobLocks {
+	return &JobLocks{db: db, logger: logger, local: make(map[string]bool)}
+}
+
+// Run runs the named job unless it is already running here or on another
+// instance, in which case this round is skipped. It reports whether job ran.
+func (l *JobLocks) Run(ctx context.Context, name string, job func()) bool {
+	if !l.lockLocal(name) {
+		l.logger.Debug("Job skipped, already running in this process", zap.String("job", name))
+		return false
+	}
+	defer l.unlockLocal(name)
+
+	if l.db.Dialector.Name() != DriverPostgres {
+		job()
+		return true
+	}
+
+	conn, err := l.lockShared(ctx, name)
+	if err != nil {
+		l.logger.Error("Failed to take job lock, skipping this round", zap.String("job", name), zap.Error(err))
+		return false
+	}
+	if conn == nil {
+		l.logger.Debug("Job skipped, another instance is running it", zap.String("job", name))
+		return false
+	}
+	defer l.unlockShared(ctx, name, conn)
+
+	job()
+	return true
+}
+
+// RunEvery runs the named scheduled job under its lock, like Run, unless it
+// already ran on some instance less than interval ago. It reports whether job ran.
+func (l *JobLocks) RunEvery(ctx context.Context, name string, interval time.Duration, job func()) bool {
+	ran := false
+	l.Run(ctx, name, func() {
+		now := time.Now()
+		var last domain.JobRun
+		err := l.db.WithContext(ctx).Where("name = ?", name).Limit(1).Find(&last).Error
+		if err != nil {
+			l.logger.Error("Failed to read last job run, skipping this round", zap.String("job", name), zap.Error(err))
+			return
+		}
+		if !last.StartedAt.IsZero() && now.Sub(last.StartedAt) < interval-interval/jobRunSlackDivisor {
+			l.logger.Debug("Job skipped, it ran recently", zap.String("job", name), zap.Time("last_run", last.StartedAt))
+			return
+		}
+
+		// The run is recorded before it starts, so a run that fails still waits an interval
+		run := domain.JobRun{Name: name, StartedAt: now}
+		if err := l.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&run).Error; err != nil {
+			l.logger.Error("Failed to record job run, skipping this round", zap.String("job", name), zap.Error(err))
+			return
+		}
+		job()
+		ran = true
+	})
+	return ran
+}
+
+func (l *JobLocks) lockLocal(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.local[name] {
+		return false
+	}
+	l.local[name] = true
+	return true
+}
+
+func (l *JobLocks) unlockLocal(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.local, name)
+}
+
+// lockShared tries the job's advisory lock on a dedicated connection and
+// returns the connection holding it, or nil when another session holds it
+func (l *JobLocks) lockShared(ctx context.Context, name string) (*sql.Conn, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jobLockTimeout)
+	defer cancel()
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", jobLockKey(name)).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !locked {
+		conn.Close()
+		return nil, nil
+	}
+	return conn, nil
+}
+
+// unlockShared releases the job's advisory lock and returns its connection to
+// the pool. A connection that could not release the lock is closed instead, so
+// the lock goes with its session rather than staying held by an idle connection.
+func (l *JobLocks) unlockShared(ctx context.Context, name string, conn *sql.Conn) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobLockTimeout)
+	defer cancel()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", jobLockKey(name)); err != nil {
+		l.logger.Warn("Failed to release job lock, dropping its connection", zap.String("job", name), zap.Error(err))
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	conn.Close()
+}
+
+// jobLockKey maps a job name onto the advisory lock key space
+func jobLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("contest-maker/job/" + name))
+	return int64(h.Sum64())
+}
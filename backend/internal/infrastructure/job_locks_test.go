@@ -0,0 +1,115 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// newJobLocksDatabase returns an in-memory database with the job runs table
+func newJobLocksDatabase(t *testing.T) *Database {
+	t.Helper()
+	config := LoadConfig().Database
+	config.Driver = DriverSQLite
+	config.SQLitePath = ":memory:"
+	database, err := NewDatabase(&config, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	if err := database.DB.AutoMigrate(&domain.JobRun{}); err != nil {
+		t.Fatal(err)
+	}
+	return database
+}
+
+func TestRunEvery(t *testing.T) {
+	const interval = time.Hour
+	tests := []struct {
+		name    string
+		lastRun time.Duration // How long ago the job last ran; 0 for never
+		want    bool
+	}{
+		{"never ran", 0, true},
+		{"ran a minute ago", time.Minute, false},
+		{"ran half an interval ago", interval / 2, false},
+		{"tick slightly early", interval - time.Minute, true},
+		{"ran an interval ago", interval, true},
+		{"ran long ago", 10 * interval, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newJobLocksDatabase(t)
+			if tt.lastRun > 0 {
+				run := domain.JobRun{Name: "backup", StartedAt: time.Now().Add(-tt.lastRun)}
+				if err := database.DB.Create(&run).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ran := false
+			locks := NewJobLocks(database.DB, zap.NewNop())
+			if got := locks.RunEvery(context.Background(), "backup", interval, func() { ran = true }); got != tt.want || ran != tt.want {
+				t.Fatalf("RunEvery reported %v and ran %v, want %v", got, ran, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunEveryAcrossInstances(t *testing.T) {
+	database := newJobLocksDatabase(t)
+	// Two instances sharing the database, each with its own locks, tick together
+	instances := []*JobLocks{NewJobLocks(database.DB, zap.NewNop()), NewJobLocks(database.DB, zap.NewNop())}
+
+	var runs atomic.Int32
+	var wg sync.WaitGroup
+	for _, locks := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locks.RunEvery(context.Background(), "digest", time.Hour, func() { runs.Add(1) })
+		}()
+	}
+	wg.Wait()
+	if n := runs.Load(); n != 1 {
+		t.Fatalf("job ran %d times in one interval, want 1", n)
+	}
+
+	// Later ticks in the same interval skip it on every instance
+	for _, locks := range instances {
+		if locks.RunEvery(context.Background(), "digest", time.Hour, func() { runs.Add(1) }) {
+			t.Fatal("job ran again within the interval")
+		}
+	}
+	// Other jobs keep their own schedule
+	if !instances[1].RunEvery(context.Background(), "retention", time.Hour, func() {}) {
+		t.Fatal("another job was skipped")
+	}
+}
+
+func TestRunSkipsRunningJob(t *testing.T) {
+	locks := NewJobLocks(newJobLocksDatabase(t).DB, zap.NewNop())
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan bool)
+	go func() {
+		done <- locks.Run(context.Background(), "similarity", func() {
+			close(started)
+			<-release
+		})
+	}()
+	<-started
+
+	if locks.Run(context.Background(), "similarity", func() { t.Error("overlapping run") }) {
+		t.Fatal("Run reported running a job that was already running")
+	}
+	close(release)
+	if !<-done {
+		t.Fatal("first run reported as skipped")
+	}
+}
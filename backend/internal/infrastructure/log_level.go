@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// LogLevel changes the level of the process logger at runtime. An override set
+// by an admin is stored in the database and reloaded every refresh interval, so
+// every instance follows it, and it falls back to LOG_LEVEL once it expires.
+type LogLevel struct {
+	level    zap.AtomicLevel
+	base     zapcore.Level // Level the logger was built with
+	repo     domain.LogLevelRepository
+	config   *LoggingConfig
+	override *refreshingValue[*domain.LogLevelOverride]
+	logger   *zap.Logger
+
+	mu     sync.Mutex // Serializes level changes so each is logged once
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewLogLevel creates the runtime log level control for the logger built with level
+func NewLogLevel(level zap.AtomicLevel, repo domain.LogLevelRepository, config *LoggingConfig, logger *zap.Logger) *LogLevel {
+	l := &LogLevel{
+		level:  level,
+		base:   level.Level(),
+		repo:   repo,
+		config: config,
+		logger: logger,
+	}
+	initial := &domain.LogLevelOverride{ID: domain.LogLevelOverrideID}
+	l.override = newRefreshingValue("log level", initial, config.LevelRefresh, logger, func(ctx context.Context) (*domain.LogLevelOverride, error) {
+		return repo.WithContext(ctx).Find()
+	})
+	return l
+}
+
+// Start applies the stored override and keeps following it in the background,
+// so changes made on other instances and expiring overrides take effect without
+// traffic. A zero refresh interval only applies the override at startup.
+func (l *LogLevel) Start(ctx context.Context) {
+	ctx, l.cancel = context.WithCancel(ctx)
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+
+		l.Status(ctx)
+		if l.config.LevelRefresh <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(l.config.LevelRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.Status(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops following the stored override, or gives up when ctx is done
+func (l *LogLevel) Stop(ctx context.Context) error {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	return WaitContext(ctx, &l.wg)
+}
+
+// Status applies the current override and reports the level in effect
+func (l *LogLevel) Status(ctx context.Context) domain.LogLevelStatus {
+	now := time.Now()
+	override := l.override.Get(ctx)
+
+	status := domain.LogLevelStatus{Default: l.base.String()}
+	level := l.base
+	if override.Active(now) {
+		if parsed, err := zapcore.ParseLevel(override.Level); err == nil {
+			level = parsed
+			status.ExpiresAt = override.ExpiresAt
+		}
+	}
+	status.Level = level.String()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if previous := l.level.Level(); previous != level {
+		l.level.SetLevel(level)
+		// Warn so the change is recorded at any level
+		l.logger.Warn("Log level changed",
+			zap.String("from", previous.String()),
+			zap.String("to", level.String()),
+		)
+	}
+	return status
+}
+
+// Set stores the override and applies it immediately on this instance
+func (l *LogLevel) Set(ctx context.Context, override *domain.LogLevelOverride) (domain.LogLevelStatus, error) {
+	if err := l.repo.WithContext(ctx).Save(override); err != nil {
+		return domain.LogLevelStatus{}, err
+	}
+	if err := l.override.Reload(ctx); err != nil {
+		return domain.LogLevelStatus{}, err
+	}
+	return l.Status(ctx), nil
+}
@@ -1,6 +1,8 @@
 package infrastructure
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"time"
 
@@ -8,8 +10,9 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-// NewLogger creates a new structured logger using zap
-func NewLogger(environment string) (*zap.Logger, error) {
+// NewLogger creates a new structured logger using zap. The returned level
+// changes the logger's level at runtime.
+func NewLogger(environment string, logging *LoggingConfig) (*zap.Logger, zap.AtomicLevel, error) {
 	var config zap.Config
 
 	if environment == "production" {
@@ -21,21 +24,39 @@ func NewLogger(environment string) (*zap.Logger, error) {
 		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
 
+	if logging.Level != "" {
+		level, err := zapcore.ParseLevel(logging.Level)
+		if err != nil {
+			return nil, zap.AtomicLevel{}, fmt.Errorf("invalid LOG_LEVEL: %w", err)
+		}
+		config.Level.SetLevel(level)
+	}
+
 	// Common settings
 	config.EncoderConfig.MessageKey = "message"
 	config.EncoderConfig.LevelKey = "level"
 	config.EncoderConfig.CallerKey = "caller"
 	config.EncoderConfig.StacktraceKey = "stacktrace"
 
+	// Sampling wraps the redacting core, which does not consult the cores it wraps
+	sampling := config.Sampling
+	config.Sampling = nil
 	logger, err := config.Build(
 		zap.AddCaller(),
 		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			core = NewRedactingCore(core)
+			if sampling != nil {
+				core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+			}
+			return core
+		}),
 	)
 	if err != nil {
-		return nil, err
+		return nil, zap.AtomicLevel{}, err
 	}
 
-	return logger, nil
+	return logger, config.Level, nil
 }
 
 // LoggerWithContext returns a logger with additional context fields
@@ -95,3 +116,21 @@ func SyncLogger(logger *zap.Logger) {
 		}
 	}
 }
+
+// loggerKey is the context key of the request-scoped logger
+type loggerKey struct{}
+
+// ContextWithLogger returns a copy of ctx carrying logger, so code further down
+// the call chain logs with the request's correlation fields
+func ContextWithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the request-scoped logger carried by ctx, or
+// fallback when ctx carries none (background workers, startup)
+func LoggerFromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
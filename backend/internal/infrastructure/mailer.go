@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// Email is a plain text message to one recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers outgoing email
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SMTPMailer sends email through an SMTP server, upgrading the connection with
+// STARTTLS when the server offers it
+type SMTPMailer struct {
+	config  *MailConfig
+	from    *mail.Address
+	breaker *Breaker
+}
+
+// NewMailer creates the mailer the configuration asks for, or nil when no SMTP
+// host is set. Its sends go through a breaker of the given registry.
+func NewMailer(config *MailConfig, breakers *Breakers) (Mailer, error) {
+	if config.SMTPHost == "" {
+		return nil, nil
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	return &SMTPMailer{config: config, from: from, breaker: breakers.New("mail", config.Timeout)}, nil
+}
+
+// Send delivers the email, giving up when ctx is done. Each attempt may take
+// the configured timeout; attempts failing with a temporary error are retried.
+func (m *SMTPMailer) Send(ctx context.Context, email Email) error {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	return m.breaker.Do(ctx, func(ctx context.Context) error {
+		err := m.send(ctx, to, email)
+		// 5xx replies, such as an unknown recipient, fail the same way every time
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			return Permanent(err)
+		}
+		return err
+	})
+}
+
+// send makes one attempt at delivering the email
+func (m *SMTPMailer) send(ctx context.Context, to *mail.Address, email Email) error {
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.SMTPHost}); err != nil {
+			return err
+		}
+	}
+	if m.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.SMTPHost)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(m.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m.message(to, email)); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	// The server accepted the message; a failed QUIT must not make it be sent again
+	_ = client.Quit()
+	return nil
+}
+
+// Ping opens a session with the SMTP server and ends it without sending anything
+func (m *SMTPMailer) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Noop(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// dial connects to the SMTP server and reads its greeting
+func (m *SMTPMailer) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.config.SMTPHost, strconv.Itoa(m.config.SMTPPort))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// net/smtp does not take a context, so the deadline bounds the whole conversation
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// message renders the headers and body of an email
+func (m *SMTPMailer) message(to *mail.Address, email Email) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(email.Body)
+	return b.Bytes()
+}
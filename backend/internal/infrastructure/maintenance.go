@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// Maintenance tracks whether writes are blocked. MAINTENANCE_MODE blocks them
+// outright; otherwise the window an admin stored in the database applies, and
+// it is reloaded every refresh interval so all instances follow it.
+type Maintenance struct {
+	repo   domain.MaintenanceRepository
+	config *MaintenanceConfig
+	window *refreshingValue[*domain.MaintenanceWindow]
+}
+
+// NewMaintenance creates the maintenance mode tracker
+func NewMaintenance(repo domain.MaintenanceRepository, config *MaintenanceConfig, logger *zap.Logger) *Maintenance {
+	m := &Maintenance{repo: repo, config: config}
+	initial := &domain.MaintenanceWindow{ID: domain.MaintenanceWindowID}
+	m.window = newRefreshingValue("maintenance window", initial, config.RefreshInterval, logger, func(ctx context.Context) (*domain.MaintenanceWindow, error) {
+		return repo.WithContext(ctx).Find()
+	})
+	return m
+}
+
+// Window returns the stored maintenance window
+func (m *Maintenance) Window(ctx context.Context) *domain.MaintenanceWindow {
+	return m.window.Get(ctx)
+}
+
+// Status reports ongoing or upcoming maintenance
+func (m *Maintenance) Status(ctx context.Context) domain.MaintenanceStatus {
+	now := time.Now()
+	if m.config.Forced {
+		return domain.MaintenanceStatus{
+			Active:            true,
+			Message:           m.config.Message,
+			RetryAfterSeconds: seconds(m.config.RetryAfter),
+		}
+	}
+
+	window := m.window.Get(ctx)
+	if !window.Active(now) && !window.Upcoming(now) {
+		return domain.MaintenanceStatus{}
+	}
+	status := domain.MaintenanceStatus{
+		Active:            window.Active(now),
+		Upcoming:          window.Upcoming(now),
+		Message:           window.Message,
+		StartsAt:          window.StartsAt,
+		EndsAt:            window.EndsAt,
+		RetryAfterSeconds: seconds(m.config.RetryAfter),
+	}
+	if window.EndsAt != nil {
+		status.RetryAfterSeconds = seconds(window.EndsAt.Sub(now))
+	}
+	return status
+}
+
+// Set stores the window and applies it immediately on this instance
+func (m *Maintenance) Set(ctx context.Context, window *domain.MaintenanceWindow) error {
+	if err := m.repo.WithContext(ctx).Save(window); err != nil {
+		return err
+	}
+	return m.window.Reload(ctx)
+}
+
+// seconds rounds up so a client never retries before the window ends
+func seconds(d time.Duration) int {
+	return int(math.Max(1, math.Ceil(d.Seconds())))
+}
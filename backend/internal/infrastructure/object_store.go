@@ -0,0 +1,321 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrObjectNotFound is returned by ObjectStore.Get for a missing key
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is the blob storage backups are written to. Keys use "/" as the
+// separator on every backend.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// List returns the keys starting with prefix, in lexical order
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// NewObjectStore creates the store selected by the backup configuration, or
+// returns nil when backups are disabled
+func NewObjectStore(config *BackupConfig) (ObjectStore, error) {
+	switch config.Storage {
+	case "":
+		return nil, nil
+	case BackupStorageFile:
+		if config.Dir == "" {
+			return nil, errors.New("BACKUP_DIR is required for file backup storage")
+		}
+		return &FileStore{dir: config.Dir}, nil
+	case BackupStorageS3:
+		if config.S3Endpoint == "" || config.S3Bucket == "" || config.S3AccessKey == "" || config.S3SecretKey == "" {
+			return nil, errors.New("BACKUP_S3_ENDPOINT, BACKUP_S3_BUCKET, BACKUP_S3_ACCESS_KEY and BACKUP_S3_SECRET_KEY are required for s3 backup storage")
+		}
+		return &S3Store{config: config, httpClient: &http.Client{Timeout: config.Timeout}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backup storage %q", config.Storage)
+	}
+}
+
+// FileStore keeps objects as files below a directory, for single-host
+// deployments and local development
+type FileStore struct {
+	dir string
+}
+
+// Put writes the object through a temporary file, so a crash never leaves a
+// partial object behind
+func (s *FileStore) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Ping checks that the backup directory can be created and written to
+func (s *FileStore) Ping(_ context.Context) error {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".ping-*")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	return os.Remove(tmp.Name())
+}
+
+// Get reads the object
+func (s *FileStore) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return data, err
+}
+
+// List walks the directory for keys starting with prefix
+func (s *FileStore) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+// S3Store talks to an S3-compatible API with path-style bucket addressing and
+// Signature Version 4, which AWS, MinIO, R2 and most others accept
+type S3Store struct {
+	config     *BackupConfig
+	httpClient *http.Client
+}
+
+// Put uploads the object in a single request
+func (s *S3Store) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get downloads the object
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// Ping checks that the bucket exists and the credentials may access it
+func (s *S3Store) Ping(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// s3ListResult is the subset of a ListObjectsV2 response List reads
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through ListObjectsV2
+func (s *S3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := map[string]string{"list-type": "2", "prefix": prefix}
+		if token != "" {
+			query["continuation-token"] = token
+		}
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode s3 listing: %w", err)
+		}
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// do sends a signed request for key (the bucket itself when empty) and returns
+// the response of a 2xx status; the caller closes its body
+func (s *S3Store) do(ctx context.Context, method, key string, query map[string]string, body []byte) (*http.Response, error) {
+	path := "/" + s3Escape(s.config.S3Bucket, true)
+	if key != "" {
+		path += "/" + s3Escape(key, false)
+	}
+	rawQuery := s3CanonicalQuery(query)
+	target := strings.TrimRight(s.config.S3Endpoint, "/") + path
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, path, rawQuery, body, time.Now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound && key != "" {
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		var apiErr struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		}
+		_ = xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&apiErr)
+		return nil, fmt.Errorf("s3 %s %s returned %d: %s %s", method, path, resp.StatusCode, apiErr.Code, apiErr.Message)
+	}
+	return resp, nil
+}
+
+// sign adds the Signature Version 4 headers, signing the host, the payload hash and the date
+func (s *S3Store) sign(req *http.Request, path, rawQuery string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		rawQuery,
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.S3Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := []byte("AWS4" + s.config.S3SecretKey)
+	for _, part := range []string{date, s.config.S3Region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.config.S3AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// s3CanonicalQuery encodes the query sorted by key, as the signature requires
+func s3CanonicalQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = s3Escape(key, true) + "=" + s3Escape(query[key], true)
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything but the RFC 3986 unreserved characters,
+// and "/" unless escapeSlash is set
+func s3Escape(value string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PwnedPasswordsClient queries the HaveIBeenPwned range API using k-anonymity:
+// only the first 5 characters of the SHA-1 hash ever leave the process
+type PwnedPasswordsClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewPwnedPasswordsClient creates a new breach check client
+func NewPwnedPasswordsClient(baseURL string, timeout time.Duration) *PwnedPasswordsClient {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &PwnedPasswordsClient{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// IsBreached reports whether the password appears in a known breach corpus
+func (c *PwnedPasswordsClient) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the real number of matches from network observers
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("breach check returned status %d", resp.StatusCode)
+	}
+
+	// Each line is "<hash suffix>:<count>"; padded entries have a count of 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if found && candidate == suffix && count != "0" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
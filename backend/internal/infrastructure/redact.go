@@ -0,0 +1,261 @@
+package infrastructure
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces sensitive values in logs and spans
+const Redacted = "[REDACTED]"
+
+// sensitiveKeyParts mark log field, span attribute and query parameter names
+// whose values are never recorded
+var sensitiveKeyParts = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "email", "api_key", "apikey"}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`)
+)
+
+// SensitiveKey reports whether values recorded under key must be redacted
+func SensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactString masks email addresses, JWTs and bearer credentials inside free text
+func RedactString(s string) string {
+	if strings.Contains(s, "@") {
+		s = emailPattern.ReplaceAllString(s, Redacted)
+	}
+	if strings.Contains(s, "eyJ") {
+		s = jwtPattern.ReplaceAllString(s, Redacted)
+	}
+	if len(s) > 6 && strings.Contains(strings.ToLower(s), "bearer") {
+		s = bearerPattern.ReplaceAllString(s, "Bearer "+Redacted)
+	}
+	return s
+}
+
+// RedactQuery masks the values of sensitive parameters in a raw query string
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return RedactString(rawQuery)
+	}
+	changed := false
+	for key, list := range values {
+		if SensitiveKey(key) {
+			values[key] = []string{Redacted}
+			changed = true
+			continue
+		}
+		for i, value := range list {
+			if redacted := RedactString(value); redacted != value {
+				list[i] = redacted
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return rawQuery
+	}
+	return values.Encode()
+}
+
+// RedactURL returns u as a string with sensitive query parameters and credentials masked
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User(Redacted)
+	}
+	redacted.RawQuery = RedactQuery(u.RawQuery)
+	// Free text is redacted part by part, since masked user info followed by
+	// the host reads as an email address
+	redacted.Opaque = RedactString(u.Opaque)
+	redacted.Path, redacted.RawPath = RedactString(u.Path), ""
+	redacted.Fragment, redacted.RawFragment = RedactString(u.Fragment), ""
+	return redacted.String()
+}
+
+// RedactAttributes masks sensitive span attributes
+func RedactAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	var out []attribute.KeyValue
+	for i, kv := range attrs {
+		redacted := redactAttribute(kv)
+		if out == nil && redacted == kv {
+			continue
+		}
+		if out == nil {
+			out = append(make([]attribute.KeyValue, 0, len(attrs)), attrs[:i]...)
+		}
+		out = append(out, redacted)
+	}
+	if out == nil {
+		return attrs
+	}
+	return out
+}
+
+func redactAttribute(kv attribute.KeyValue) attribute.KeyValue {
+	if SensitiveKey(string(kv.Key)) {
+		return kv.Key.String(Redacted)
+	}
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		if s := kv.Value.AsString(); RedactString(s) != s {
+			return kv.Key.String(RedactString(s))
+		}
+	case attribute.STRINGSLICE:
+		values := kv.Value.AsStringSlice()
+		changed := false
+		for i, s := range values {
+			if r := RedactString(s); r != s {
+				values[i] = r
+				changed = true
+			}
+		}
+		if changed {
+			return kv.Key.StringSlice(values)
+		}
+	}
+	return kv
+}
+
+// redactingCore masks sensitive fields and secrets in messages before entries
+// reach the wrapped core
+type redactingCore struct {
+	zapcore.Core
+}
+
+// NewRedactingCore wraps core so emails, tokens and passwords never reach the log output
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return redactingCore{Core: core}
+}
+
+// With redacts the fields before they are attached to the child core
+func (c redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+// Check routes entries the wrapped core is enabled for through this core.
+// Samplers must wrap this core rather than sit inside it, since only its
+// level is consulted.
+func (c redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(entry.Level) {
+		return checked
+	}
+	return checked.AddCore(entry, c)
+}
+
+// Write redacts the message and fields, then writes them to the wrapped core
+func (c redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = RedactString(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = redactField(f)
+	}
+	return out
+}
+
+func redactField(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.SkipType, zapcore.NamespaceType:
+		return f
+	}
+	if SensitiveKey(f.Key) {
+		return zap.String(f.Key, Redacted)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = RedactString(f.String)
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok {
+			return zap.String(f.Key, RedactString(string(b)))
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			if msg := err.Error(); RedactString(msg) != msg {
+				return zap.String(f.Key, RedactString(msg))
+			}
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(interface{ String() string }); ok && s != nil {
+			if str := s.String(); RedactString(str) != str {
+				return zap.String(f.Key, RedactString(str))
+			}
+		}
+	}
+	return f
+}
+
+// redactingSpanExporter masks sensitive span data before spans leave the process
+type redactingSpanExporter struct {
+	sdktrace.SpanExporter
+}
+
+// NewRedactingSpanExporter wraps next so sensitive span attributes, event
+// attributes and status messages are masked on export
+func NewRedactingSpanExporter(next sdktrace.SpanExporter) sdktrace.SpanExporter {
+	return redactingSpanExporter{SpanExporter: next}
+}
+
+// ExportSpans exports redacted views of the spans
+func (e redactingSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	redacted := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, span := range spans {
+		redacted[i] = redactedSpan{ReadOnlySpan: span}
+	}
+	return e.SpanExporter.ExportSpans(ctx, redacted)
+}
+
+// redactedSpan is a read-only span whose attributes, events and status are redacted
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s redactedSpan) Name() string {
+	return RedactString(s.ReadOnlySpan.Name())
+}
+
+func (s redactedSpan) Attributes() []attribute.KeyValue {
+	return RedactAttributes(s.ReadOnlySpan.Attributes())
+}
+
+func (s redactedSpan) Events() []sdktrace.Event {
+	events := s.ReadOnlySpan.Events()
+	out := make([]sdktrace.Event, len(events))
+	for i, event := range events {
+		event.Name = RedactString(event.Name)
+		event.Attributes = RedactAttributes(event.Attributes)
+		out[i] = event
+	}
+	return out
+}
+
+func (s redactedSpan) Status() sdktrace.Status {
+	status := s.ReadOnlySpan.Status()
+	status.Description = RedactString(status.Description)
+	return status
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// refreshingValue caches a value loaded from the database and reloads it once
+// it is older than the refresh interval, so settings changed on one instance
+// reach the others. Readers of a stale value wait for a single reload; when it
+// fails, the previous value stays in use until the next interval.
+type refreshingValue[T any] struct {
+	load    func(ctx context.Context) (T, error)
+	refresh time.Duration
+	name    string // Used in log messages
+	logger  *zap.Logger
+
+	reloadMu sync.Mutex // Serializes reloads so a stale value is reloaded once
+	mu       sync.RWMutex
+	value    T
+	loadedAt time.Time
+}
+
+func newRefreshingValue[T any](name string, initial T, refresh time.Duration, logger *zap.Logger, load func(ctx context.Context) (T, error)) *refreshingValue[T] {
+	return &refreshingValue[T]{load: load, refresh: refresh, name: name, logger: logger, value: initial}
+}
+
+// Get returns the current value, reloading it first when it is stale
+func (v *refreshingValue[T]) Get(ctx context.Context) T {
+	if value, fresh := v.current(); fresh {
+		return value
+	}
+
+	v.reloadMu.Lock()
+	defer v.reloadMu.Unlock()
+	if value, fresh := v.current(); fresh {
+		return value // Another caller reloaded while this one waited
+	}
+
+	if err := v.Reload(ctx); err != nil {
+		LoggerFromContext(ctx, v.logger).Warn("Failed to reload "+v.name+", keeping the previous value", zap.Error(err))
+		v.mu.Lock()
+		v.loadedAt = time.Now()
+		v.mu.Unlock()
+	}
+	value, _ := v.current()
+	return value
+}
+
+// Reload loads the value now
+func (v *refreshingValue[T]) Reload(ctx context.Context) error {
+	value, err := v.load(ctx)
+	if err != nil {
+		return err
+	}
+	v.mu.Lock()
+	v.value = value
+	v.loadedAt = time.Now()
+	v.mu.Unlock()
+	return nil
+}
+
+func (v *refreshingValue[T]) current() (T, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.value, time.Since(v.loadedAt) < v.refresh
+}
@@ -0,0 +1,211 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// replicaLagQuery measures how far a Postgres standby is behind. A standby that
+// has replayed everything it received reports no lag, even when the primary has
+// been idle since its last commit.
+const replicaLagQuery = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// replica is one read replica and its last measured state
+type replica struct {
+	host    string
+	db      *sql.DB
+	lag     atomic.Int64 // Nanoseconds
+	healthy atomic.Bool  // The last lag check succeeded
+}
+
+// ReplicaRouter is the connection pool of the read database. Each query goes to
+// the next replica, round robin, whose replication lag is within the limit, and
+// to the primary when none is. Lag is measured in the background, so a replica
+// falling behind stops serving reads within one check interval.
+type ReplicaRouter struct {
+	primary  *sql.DB
+	replicas []*replica
+	maxLag   time.Duration
+	interval time.Duration
+	logger   *zap.Logger
+	next     atomic.Uint64
+	wg       sync.WaitGroup
+	cancel   context.CancelFunc
+}
+
+// NewReplicaRouter creates a router over the replicas, keyed by host. Replicas
+// serve no reads until their first lag check.
+func NewReplicaRouter(primary *sql.DB, replicas map[string]*sql.DB, config *DatabaseConfig, logger *zap.Logger) *ReplicaRouter {
+	router := &ReplicaRouter{
+		primary:  primary,
+		maxLag:   config.ReplicaMaxLag,
+		interval: config.ReplicaCheckInterval,
+		logger:   logger,
+	}
+	for _, host := range config.ReplicaHosts {
+		if db, ok := replicas[host]; ok {
+			router.replicas = append(router.replicas, &replica{host: host, db: db})
+		}
+	}
+	return router
+}
+
+// pick returns the pool the next read goes to
+func (r *ReplicaRouter) pick() *sql.DB {
+	n := len(r.replicas)
+	start := int(r.next.Add(1) % uint64(n))
+	for i := range n {
+		candidate := r.replicas[(start+i)%n]
+		if candidate.healthy.Load() && time.Duration(candidate.lag.Load()) <= r.maxLag {
+			return candidate.db
+		}
+	}
+	return r.primary
+}
+
+// PrepareContext implements gorm.ConnPool
+func (r *ReplicaRouter) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.pick().PrepareContext(ctx, query)
+}
+
+// ExecContext implements gorm.ConnPool; statements that write always go to the primary
+func (r *ReplicaRouter) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// QueryContext implements gorm.ConnPool
+func (r *ReplicaRouter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.pick().QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext implements gorm.ConnPool
+func (r *ReplicaRouter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.pick().QueryRowContext(ctx, query, args...)
+}
+
+// Start checks the lag of every replica once, then keeps checking in the background
+func (r *ReplicaRouter) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.check(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.check(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the lag checks
+func (r *ReplicaRouter) Stop(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	return WaitContext(ctx, &r.wg)
+}
+
+// check measures the lag of each replica, logging when one starts or stops serving reads
+func (r *ReplicaRouter) check(ctx context.Context) {
+	for _, rep := range r.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, r.interval)
+		var seconds float64
+		err := rep.db.QueryRowContext(checkCtx, replicaLagQuery).Scan(&seconds)
+		cancel()
+
+		wasServing := rep.healthy.Load() && time.Duration(rep.lag.Load()) <= r.maxLag
+		rep.healthy.Store(err == nil)
+		if err == nil {
+			rep.lag.Store(int64(seconds * float64(time.Second)))
+		}
+		serving := err == nil && time.Duration(rep.lag.Load()) <= r.maxLag
+
+		switch {
+		case wasServing && !serving:
+			r.logger.Warn("Read replica stopped serving reads",
+				zap.String("replica", rep.host),
+				zap.Duration("lag", time.Duration(rep.lag.Load())),
+				zap.Duration("max_lag", r.maxLag),
+				zap.Error(err),
+			)
+		case !wasServing && serving:
+			r.logger.Info("Read replica serving reads",
+				zap.String("replica", rep.host),
+				zap.Duration("lag", time.Duration(rep.lag.Load())),
+			)
+		}
+	}
+}
+
+// ReplicaStatus is the last measured state of a read replica
+type ReplicaStatus struct {
+	Host       string  `json:"host"`
+	Healthy    bool    `json:"healthy"`
+	LagSeconds float64 `json:"lag_seconds"`
+	Serving    bool    `json:"serving"` // Healthy and within the lag limit
+}
+
+// Status reports the state of every replica
+func (r *ReplicaRouter) Status() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, len(r.replicas))
+	for i, rep := range r.replicas {
+		lag := time.Duration(rep.lag.Load())
+		statuses[i] = ReplicaStatus{
+			Host:       rep.host,
+			Healthy:    rep.healthy.Load(),
+			LagSeconds: lag.Seconds(),
+			Serving:    rep.healthy.Load() && lag <= r.maxLag,
+		}
+	}
+	return statuses
+}
+
+// RegisterMetrics exports each replica's replication lag
+func (r *ReplicaRouter) RegisterMetrics(meter metric.Meter) error {
+	_, err := meter.Float64ObservableGauge(
+		"db.replica.lag",
+		metric.WithDescription("Replication lag of each read replica at its last check"),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			for _, rep := range r.replicas {
+				o.Observe(time.Duration(rep.lag.Load()).Seconds(),
+					metric.WithAttributes(
+						attribute.String("db.replica", rep.host),
+						attribute.Bool("db.replica.healthy", rep.healthy.Load()),
+					))
+			}
+			return nil
+		}),
+	)
+	return err
+}
+
+// Close closes the replica pools; the primary belongs to the caller
+func (r *ReplicaRouter) Close() error {
+	var firstErr error
+	for _, rep := range r.replicas {
+		if err := rep.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,280 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned instead of calling a dependency whose circuit
+// breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a dependency's circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // Calls go through
+	BreakerOpen     BreakerState = "open"      // Calls fail fast until the open duration has passed
+	BreakerHalfOpen BreakerState = "half_open" // One trial call decides whether the breaker closes or opens again
+)
+
+// breakerStateValues are the values of the state gauge
+var breakerStateValues = map[BreakerState]int64{BreakerClosed: 0, BreakerHalfOpen: 1, BreakerOpen: 2}
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as a failure that retrying cannot fix, such as a request
+// the dependency rejected. It is returned without retries and does not count
+// against the breaker, since the dependency did answer.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// Breaker guards the calls to one external dependency. Each attempt gets the
+// dependency's timeout, failed attempts are retried with jittered exponential
+// backoff, and once calls keep failing the breaker opens and fails them fast,
+// letting one trial call through after a while to see whether it recovered.
+type Breaker struct {
+	name    string
+	timeout time.Duration
+	config  *ResilienceConfig
+	logger  *zap.Logger
+
+	mu          sync.Mutex
+	state       BreakerState
+	failures    int // Consecutive failed calls
+	openedAt    time.Time
+	trial       bool // A half-open trial call is in flight
+	transitions map[BreakerState]int64
+}
+
+// Do calls the dependency through the breaker, retrying failed attempts
+func (b *Breaker) Do(ctx context.Context, call func(ctx context.Context) error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.retry(ctx, call)
+	b.record(ctx, err)
+
+	var permanent permanentError
+	if errors.As(err, &permanent) {
+		return permanent.err
+	}
+	return err
+}
+
+// allow admits a call unless the breaker is open, turning an open breaker
+// half-open once its open duration has passed
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.config.OpenDuration {
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		b.transition(BreakerHalfOpen)
+	case BreakerHalfOpen:
+		if b.trial {
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+	default:
+		return nil
+	}
+	b.trial = true
+	return nil
+}
+
+// retry makes up to MaxAttempts attempts, each within the timeout, until one
+// succeeds, fails permanently or ctx is done
+func (b *Breaker) retry(ctx context.Context, call func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, b.timeout)
+		err := call(attemptCtx)
+		cancel()
+
+		var permanent permanentError
+		if err == nil || errors.As(err, &permanent) || attempt >= b.config.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(b.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait after the given failed attempt: a random duration up
+// to the base delay doubled per attempt, capped at the maximum delay ("full
+// jitter"), so callers retrying together spread out
+func (b *Breaker) backoff(attempt int) time.Duration {
+	ceiling := b.config.MaxDelay
+	if shift := attempt - 1; shift < 32 && b.config.BaseDelay<<shift < ceiling {
+		ceiling = b.config.BaseDelay << shift
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// record counts the outcome of a call. Calls the caller gave up on and
+// permanent failures say nothing about the dependency's health.
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var permanent permanentError
+	switch {
+	case err != nil && ctx.Err() != nil:
+		b.trial = false
+		return
+	case err == nil || errors.As(err, &permanent):
+		b.failures = 0
+		b.trial = false
+		if b.state != BreakerClosed {
+			b.transition(BreakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	b.trial = false
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.config.FailureThreshold) {
+		b.openedAt = time.Now()
+		b.transition(BreakerOpen)
+		b.logger.Warn("Circuit breaker opened",
+			zap.String("dependency", b.name),
+			zap.Int("consecutive_failures", b.failures),
+			zap.Duration("open_for", b.config.OpenDuration),
+			zap.Error(err),
+		)
+	}
+}
+
+// transition moves the breaker to state; the caller holds the lock
+func (b *Breaker) transition(state BreakerState) {
+	b.state = state
+	b.transitions[state]++
+	if state == BreakerClosed {
+		b.logger.Info("Circuit breaker closed", zap.String("dependency", b.name))
+	}
+}
+
+// BreakerStatus is the current state of a dependency's circuit breaker
+type BreakerStatus struct {
+	Name     string       `json:"name"`
+	State    BreakerState `json:"state"`
+	Failures int          `json:"consecutive_failures"`
+}
+
+// Status reports the breaker's state
+func (b *Breaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return BreakerStatus{Name: b.name, State: b.state, Failures: b.failures}
+}
+
+// Breakers creates the circuit breakers of the outbound dependencies, one per
+// dependency, and reports on all of them
+type Breakers struct {
+	config *ResilienceConfig
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	breakers []*Breaker
+}
+
+// NewBreakers creates a registry of breakers sharing the retry and breaker settings
+func NewBreakers(config *ResilienceConfig, logger *zap.Logger) *Breakers {
+	return &Breakers{config: config, logger: logger}
+}
+
+// New creates the breaker of a dependency whose attempts may take up to timeout
+func (r *Breakers) New(name string, timeout time.Duration) *Breaker {
+	breaker := &Breaker{
+		name:        name,
+		timeout:     timeout,
+		config:      r.config,
+		logger:      r.logger,
+		state:       BreakerClosed,
+		transitions: make(map[BreakerState]int64),
+	}
+	r.mu.Lock()
+	r.breakers = append(r.breakers, breaker)
+	r.mu.Unlock()
+	return breaker
+}
+
+// Status reports the state of every breaker
+func (r *Breakers) Status() []BreakerStatus {
+	r.mu.Lock()
+	breakers := append([]*Breaker(nil), r.breakers...)
+	r.mu.Unlock()
+
+	statuses := make([]BreakerStatus, len(breakers))
+	for i, b := range breakers {
+		statuses[i] = b.Status()
+	}
+	return statuses
+}
+
+// RegisterMetrics exports each breaker's state (0 closed, 1 half-open, 2 open)
+// and its transitions into each state, including breakers created later
+func (r *Breakers) RegisterMetrics(meter metric.Meter) error {
+	state, err := meter.Int64ObservableGauge("circuit_breaker.state",
+		metric.WithDescription("State of each outbound dependency's circuit breaker: 0 closed, 1 half-open, 2 open"))
+	if err != nil {
+		return err
+	}
+	transitions, err := meter.Int64ObservableCounter("circuit_breaker.transitions",
+		metric.WithDescription("Circuit breaker transitions into each state"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.Lock()
+		breakers := append([]*Breaker(nil), r.breakers...)
+		r.mu.Unlock()
+
+		for _, b := range breakers {
+			b.mu.Lock()
+			dependency := attribute.String("dependency", b.name)
+			o.ObserveInt64(state, breakerStateValues[b.state], metric.WithAttributes(dependency))
+			for to, count := range b.transitions {
+				o.ObserveInt64(transitions, count, metric.WithAttributes(dependency, attribute.String("state", string(to))))
+			}
+			b.mu.Unlock()
+		}
+		return nil
+	}, state, transitions)
+	return err
+}
@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// maxPendingTraces bounds how many unsampled traces are buffered at once;
+	// spans of further traces are dropped until some complete
+	maxPendingTraces = 10000
+	// maxSpansPerTrace bounds the spans buffered for one trace
+	maxSpansPerTrace = 512
+	// pendingTraceTTL is how long a trace waits for its root span, and how long
+	// a kept trace still accepts late spans such as those of event handlers
+	pendingTraceTTL = time.Minute
+)
+
+// recordAllSampler records every span so the tail sampler can still keep a
+// trace the head sampler dropped. Spans it would have dropped are recorded
+// but not sampled, so they only reach the exporter through TailSamplingProcessor.
+type recordAllSampler struct {
+	head sdktrace.Sampler
+}
+
+// RecordAllSampler wraps the head sampler so dropped spans are recorded instead
+func RecordAllSampler(head sdktrace.Sampler) sdktrace.Sampler {
+	return recordAllSampler{head: head}
+}
+
+func (s recordAllSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.head.ShouldSample(p)
+	if result.Decision == sdktrace.Drop {
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
+}
+
+func (s recordAllSampler) Description() string {
+	return "RecordAll{" + s.head.Description() + "}"
+}
+
+// TailSamplingProcessor passes sampled spans straight through and buffers the
+// spans of unsampled traces until the trace's local root span ends. The trace
+// is then exported if the root failed (a 5xx response) or took longer than the
+// slow threshold, and discarded otherwise.
+type TailSamplingProcessor struct {
+	next          sdktrace.SpanProcessor
+	slowThreshold time.Duration
+
+	mu        sync.Mutex
+	pending   map[trace.TraceID]*pendingTrace
+	kept      map[trace.TraceID]time.Time
+	lastSweep time.Time
+}
+
+type pendingTrace struct {
+	started time.Time
+	spans   []sdktrace.ReadOnlySpan
+}
+
+// NewTailSamplingProcessor creates a tail sampler in front of the given processor
+func NewTailSamplingProcessor(next sdktrace.SpanProcessor, slowThreshold time.Duration) *TailSamplingProcessor {
+	return &TailSamplingProcessor{
+		next:          next,
+		slowThreshold: slowThreshold,
+		pending:       make(map[trace.TraceID]*pendingTrace),
+		kept:          make(map[trace.TraceID]time.Time),
+		lastSweep:     time.Now(),
+	}
+}
+
+// OnStart forwards to the next processor
+func (p *TailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd forwards sampled spans and decides on unsampled traces when their root ends
+func (p *TailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+
+	traceID := s.SpanContext().TraceID()
+	now := time.Now()
+
+	p.mu.Lock()
+	if now.Sub(p.lastSweep) > pendingTraceTTL {
+		p.sweep(now)
+	}
+	if _, ok := p.kept[traceID]; ok {
+		// A late span of a trace that was already kept
+		p.mu.Unlock()
+		p.next.OnEnd(sampledSpan{s})
+		return
+	}
+
+	pending := p.pending[traceID]
+	if pending == nil {
+		if len(p.pending) >= maxPendingTraces {
+			p.mu.Unlock()
+			return
+		}
+		pending = &pendingTrace{started: now}
+		p.pending[traceID] = pending
+	}
+	if len(pending.spans) < maxSpansPerTrace {
+		pending.spans = append(pending.spans, s)
+	}
+
+	if !isLocalRoot(s) {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.pending, traceID)
+	keep := p.interesting(s)
+	if keep {
+		p.kept[traceID] = now
+	}
+	p.mu.Unlock()
+
+	if keep {
+		for _, span := range pending.spans {
+			p.next.OnEnd(sampledSpan{span})
+		}
+	}
+}
+
+// interesting reports whether a root span's trace should be kept
+func (p *TailSamplingProcessor) interesting(root sdktrace.ReadOnlySpan) bool {
+	if root.Status().Code == codes.Error {
+		return true
+	}
+	for _, attr := range root.Attributes() {
+		if attr.Key == semconv.HTTPResponseStatusCodeKey && attr.Value.AsInt64() >= 500 {
+			return true
+		}
+	}
+	return p.slowThreshold > 0 && root.EndTime().Sub(root.StartTime()) > p.slowThreshold
+}
+
+// sweep drops traces whose root never ended and forgets old keep decisions
+func (p *TailSamplingProcessor) sweep(now time.Time) {
+	for id, pending := range p.pending {
+		if now.Sub(pending.started) > pendingTraceTTL {
+			delete(p.pending, id)
+		}
+	}
+	for id, kept := range p.kept {
+		if now.Sub(kept) > pendingTraceTTL {
+			delete(p.kept, id)
+		}
+	}
+	p.lastSweep = now
+}
+
+// Shutdown drops buffered traces and shuts down the next processor
+func (p *TailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.pending = make(map[trace.TraceID]*pendingTrace)
+	p.mu.Unlock()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor; undecided traces stay buffered
+func (p *TailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// isLocalRoot reports whether the span is the first span of its trace in this
+// process, which for requests is the server span
+func isLocalRoot(s sdktrace.ReadOnlySpan) bool {
+	return !s.Parent().IsValid() || s.Parent().IsRemote()
+}
+
+// sampledSpan marks a kept span as sampled, since span processors and
+// exporters skip spans without the sampled flag
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
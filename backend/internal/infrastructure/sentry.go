@@ -0,0 +1,179 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// SentrySender posts crash reports to a Sentry-compatible envelope endpoint.
+// It speaks the protocol directly, so any service accepting Sentry DSNs works.
+type SentrySender struct {
+	endpoint    string
+	auth        string
+	release     string
+	environment string
+	serverName  string
+	httpClient  *http.Client
+}
+
+// NewSentrySender parses dsn ("https://<key>@<host>/<project id>")
+func NewSentrySender(dsn, release, environment string, timeout time.Duration) (*SentrySender, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid SENTRY_DSN")
+	}
+	path := strings.Trim(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if projectID == "" {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: missing project ID")
+	}
+	prefix := ""
+	if slash >= 0 {
+		prefix = "/" + path[:slash]
+	}
+
+	serverName, _ := os.Hostname()
+	return &SentrySender{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, projectID),
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=contest-maker/%s, sentry_key=%s", release, u.User.Username()),
+		release:     release,
+		environment: environment,
+		serverName:  serverName,
+		httpClient:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// sentryEvent is the subset of the Sentry event payload crash reports fill in
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Platform    string                 `json:"platform"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Transaction string                 `json:"transaction,omitempty"`
+	Exception   sentryExceptions       `json:"exception"`
+	Request     sentryRequest          `json:"request"`
+	User        *sentryUser            `json:"user,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Contexts    map[string]interface{} `json:"contexts,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Stacktrace sentryStacktrace `json:"stacktrace"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+type sentryRequest struct {
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	QueryString string            `json:"query_string,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+// Send posts the report as a Sentry envelope
+func (s *SentrySender) Send(ctx context.Context, report *CrashReport) error {
+	event := sentryEvent{
+		EventID:     report.EventID,
+		Timestamp:   report.Timestamp.Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "fatal",
+		Logger:      "panic",
+		ServerName:  s.serverName,
+		Release:     s.release,
+		Environment: s.environment,
+		Transaction: report.Route,
+		Exception: sentryExceptions{Values: []sentryException{{
+			Type:  "panic",
+			Value: report.Message,
+		}}},
+		Request: sentryRequest{
+			Method:      report.Method,
+			URL:         report.URL,
+			QueryString: report.Query,
+			Headers:     report.Headers,
+		},
+	}
+	if report.RequestID != "" {
+		event.Tags = map[string]string{"request_id": report.RequestID}
+	}
+	for _, frame := range report.Stack {
+		event.Exception.Values[0].Stacktrace.Frames = append(event.Exception.Values[0].Stacktrace.Frames, sentryFrame{
+			Function: frame.Function,
+			Module:   frame.Module,
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+			InApp:    frame.InApp,
+		})
+	}
+	if report.UserID != "" {
+		event.User = &sentryUser{ID: report.UserID}
+	}
+	if report.TraceID != "" {
+		event.Contexts = map[string]interface{}{
+			"trace": map[string]string{"trace_id": report.TraceID, "span_id": report.SpanID},
+		}
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	header, _ := json.Marshal(map[string]string{"event_id": report.EventID, "sent_at": time.Now().UTC().Format(time.RFC3339Nano)})
+	item, _ := json.Marshal(map[string]interface{}{"type": "event", "length": len(payload)})
+
+	var body bytes.Buffer
+	for _, line := range [][]byte{header, item, payload} {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error tracker returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+)
+
+// WaitContext waits for wg like wg.Wait, but gives up when ctx is done so a
+// stuck component cannot hold up the rest of a shutdown
+func WaitContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,209 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// stripeSignatureTolerance is how old a signed webhook may be before it is
+// rejected as a possible replay
+const stripeSignatureTolerance = 5 * time.Minute
+
+// StripeClient talks to the Stripe REST API directly: creating checkout
+// sessions and verifying webhook signatures is all billing needs
+type StripeClient struct {
+	config     *BillingConfig
+	httpClient *http.Client
+}
+
+// NewStripeClient creates a Stripe client from the billing configuration
+func NewStripeClient(config *BillingConfig) *StripeClient {
+	return &StripeClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Enabled reports whether checkout can be offered
+func (s *StripeClient) Enabled() bool {
+	return s.config.StripeSecretKey != "" && s.config.StripePriceID != ""
+}
+
+// WebhooksEnabled reports whether webhook signatures can be verified
+func (s *StripeClient) WebhooksEnabled() bool {
+	return s.config.StripeWebhookSecret != ""
+}
+
+// CheckoutSessionParams describes a subscription checkout for one user
+type CheckoutSessionParams struct {
+	UserID        string // Sent as client_reference_id and subscription metadata
+	CustomerID    string // Existing customer to reuse; empty creates one
+	CustomerEmail string // Prefills checkout when there is no customer yet
+}
+
+// StripeCheckoutSession is the subset of a Stripe checkout session billing reads
+type StripeCheckoutSession struct {
+	ID                string `json:"id"`
+	URL               string `json:"url"`
+	Mode              string `json:"mode"`
+	PaymentStatus     string `json:"payment_status"`
+	ClientReferenceID string `json:"client_reference_id"`
+	Customer          string `json:"customer"`
+	Subscription      string `json:"subscription"`
+}
+
+// StripeSubscription is the subset of a Stripe subscription billing reads
+type StripeSubscription struct {
+	ID               string            `json:"id"`
+	Customer         string            `json:"customer"`
+	Status           string            `json:"status"`
+	CurrentPeriodEnd int64             `json:"current_period_end"`
+	Metadata         map[string]string `json:"metadata"`
+	Items            struct {
+		Data []struct {
+			CurrentPeriodEnd int64 `json:"current_period_end"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// PeriodEnd returns the end of the paid period, which newer API versions
+// report per subscription item, or nil if neither field is set
+func (s *StripeSubscription) PeriodEnd() *time.Time {
+	end := s.CurrentPeriodEnd
+	for _, item := range s.Items.Data {
+		end = max(end, item.CurrentPeriodEnd)
+	}
+	if end == 0 {
+		return nil
+	}
+	t := time.Unix(end, 0).UTC()
+	return &t
+}
+
+// StripeEvent is a verified webhook event; Data.Object holds the event's resource
+type StripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// CreateCheckoutSession creates a hosted checkout page for the premium price
+func (s *StripeClient) CreateCheckoutSession(ctx context.Context, params CheckoutSessionParams) (*StripeCheckoutSession, error) {
+	form := url.Values{
+		"mode":                                 {"subscription"},
+		"line_items[0][price]":                 {s.config.StripePriceID},
+		"line_items[0][quantity]":              {"1"},
+		"success_url":                          {s.config.SuccessURL},
+		"cancel_url":                           {s.config.CancelURL},
+		"client_reference_id":                  {params.UserID},
+		"subscription_data[metadata][user_id]": {params.UserID},
+	}
+	if params.CustomerID != "" {
+		form.Set("customer", params.CustomerID)
+	} else if params.CustomerEmail != "" {
+		form.Set("customer_email", params.CustomerEmail)
+	}
+
+	var session StripeCheckoutSession
+	if err := s.post(ctx, "/v1/checkout/sessions", form, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// post sends a form-encoded API request and decodes the JSON response into out
+func (s *StripeClient) post(ctx context.Context, path string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(s.config.StripeAPIURL, "/")+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.config.StripeSecretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		return fmt.Errorf("stripe %s returned %d: %s %s", path, resp.StatusCode, apiErr.Error.Type, apiErr.Error.Message)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// ConstructEvent verifies the Stripe-Signature header of a webhook payload and
+// decodes the event. Signatures older than five minutes are rejected.
+func (s *StripeClient) ConstructEvent(payload []byte, header string, now time.Time) (*StripeEvent, error) {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return nil, domain.NewDomainError(domain.ErrInvalidWebhookSignature, "Malformed Stripe-Signature header")
+	}
+	if age := now.Sub(time.Unix(signedAt, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return nil, domain.NewDomainError(domain.ErrInvalidWebhookSignature, "Webhook signature timestamp is outside the tolerance")
+	}
+
+	expected := []byte(StripeSignature(s.config.StripeWebhookSecret, timestamp, payload))
+	valid := false
+	for _, sig := range signatures {
+		if hmac.Equal(expected, []byte(sig)) {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, domain.ErrInvalidWebhookSignature
+	}
+
+	var event StripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil || event.ID == "" {
+		return nil, domain.NewValidationError("Webhook payload is not a Stripe event", nil)
+	}
+	return &event, nil
+}
+
+// StripeSignature computes the v1 signature Stripe sends for a payload signed at timestamp
+func StripeSignature(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
@@ -25,86 +26,124 @@ type Telemetry struct {
 	PrometheusExporter *prometheus.Exporter
 	Tracer             trace.Tracer
 	Meter              metric.Meter
+	Breakers           *Breakers // Circuit breakers of the outbound dependencies, starting with the trace collector
 	config             *TelemetryConfig
 	logger             *zap.Logger
 }
 
 // TelemetryMetrics contains pre-created metrics for common operations
 type TelemetryMetrics struct {
-	HTTPRequestDuration metric.Float64Histogram
-	HTTPRequestCount    metric.Int64Counter
-	ActiveContests      metric.Int64UpDownCounter
-	DBQueryDuration     metric.Float64Histogram
-	ProblemsSolved      metric.Int64Counter
+	HTTPRequestDuration    metric.Float64Histogram
+	HTTPRequestCount       metric.Int64Counter
+	HTTPRequestsShed       metric.Int64Counter
+	HTTPRequestsDeprecated metric.Int64Counter
+	ActiveContests         metric.Int64UpDownCounter
+	DBQueryDuration        metric.Float64Histogram
+	ProblemsSolved         metric.Int64Counter
 }
 
 // NewTelemetry initializes OpenTelemetry with tracing and metrics
-func NewTelemetry(ctx context.Context, config *TelemetryConfig, logger *zap.Logger) (*Telemetry, error) {
+func NewTelemetry(ctx context.Context, config *TelemetryConfig, resilience *ResilienceConfig, logger *zap.Logger) (*Telemetry, error) {
+	breakers := NewBreakers(resilience, logger)
 	if !config.Enabled {
 		logger.Info("Telemetry disabled, using noop providers")
 		return &Telemetry{
-			Tracer: otel.Tracer(config.ServiceName),
-			Meter:  otel.Meter(config.ServiceName),
-			config: config,
-			logger: logger,
+			Tracer:   otel.Tracer(config.ServiceName),
+			Meter:    otel.Meter(config.ServiceName),
+			Breakers: breakers,
+			config:   config,
+			logger:   logger,
 		}, nil
 	}
 
 	// Create resource with service information
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(config.ServiceVersion),
+		attribute.String("environment", "production"),
+	}
+	if config.Region != "" {
+		attrs = append(attrs, semconv.CloudRegion(config.Region))
+	}
 	res, err := resource.Merge(
 		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(config.ServiceName),
-			semconv.ServiceVersion(config.ServiceVersion),
-			attribute.String("environment", "production"),
-		),
+		resource.NewWithAttributes(semconv.SchemaURL, attrs...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// Initialize trace exporter
+	// Initialize trace exporter; failed exports are retried by its circuit
+	// breaker rather than by the exporter itself, so the breaker sees each failure
 	traceExporter, err := otlptracehttp.New(ctx,
 		otlptracehttp.WithEndpoint(config.OTLPEndpoint),
 		otlptracehttp.WithInsecure(), // Use TLS in production
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
 	}
 
 	// Create tracer provider with batching for performance
+	// Spans are redacted on export, so attributes set anywhere cannot leak credentials
+	var processor sdktrace.SpanProcessor = sdktrace.NewBatchSpanProcessor(
+		NewRedactingSpanExporter(breakerSpanExporter{SpanExporter: traceExporter, breaker: breakers.New("otlp", config.ExportTimeout)}),
+		sdktrace.WithBatchTimeout(5*time.Second),
+		sdktrace.WithMaxExportBatchSize(512),
+	)
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))
+	if config.TailSampling {
+		processor = NewTailSamplingProcessor(processor, config.SlowTraceThreshold)
+		sampler = RecordAllSampler(sampler)
+	}
 	tracerProvider := sdktrace.NewTracerProvider(
 		sdktrace.WithResource(res),
-		sdktrace.WithBatcher(traceExporter,
-			sdktrace.WithBatchTimeout(5*time.Second),
-			sdktrace.WithMaxExportBatchSize(512),
-		),
-		sdktrace.WithSampler(sdktrace.ParentBased(
-			sdktrace.TraceIDRatioBased(0.1), // Sample 10% of traces
-		)),
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithSampler(sampler),
 	)
 
 	// Initialize Prometheus exporter for metrics
-	promExporter, err := prometheus.New()
+	// With a region, every series carries a cloud_region label so dashboards
+	// can split and compare regions scraped into one Prometheus
+	var promOpts []prometheus.Option
+	if config.Region != "" {
+		promOpts = append(promOpts, prometheus.WithResourceAsConstantLabels(attribute.NewAllowKeysFilter(semconv.CloudRegionKey)))
+	}
+	promExporter, err := prometheus.New(promOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
 	}
 
+	// Histogram buckets keep the trace ID of a recent sampled measurement as an
+	// exemplar, so a latency spike on a dashboard links to a trace that caused it
+	exemplarFilter := exemplar.AlwaysOffFilter
+	if config.Exemplars {
+		exemplarFilter = exemplar.TraceBasedFilter
+	}
+
 	// Create meter provider
 	meterProvider := sdkmetric.NewMeterProvider(
 		sdkmetric.WithResource(res),
 		sdkmetric.WithReader(promExporter),
+		sdkmetric.WithExemplarFilter(exemplarFilter),
 	)
 
 	// Set global providers
 	otel.SetTracerProvider(tracerProvider)
 	otel.SetMeterProvider(meterProvider)
 
+	meter := meterProvider.Meter(config.ServiceName)
+	if err := breakers.RegisterMetrics(meter); err != nil {
+		return nil, fmt.Errorf("failed to create circuit breaker metrics: %w", err)
+	}
+
 	logger.Info("Telemetry initialized",
 		zap.String("service", config.ServiceName),
 		zap.String("version", config.ServiceVersion),
+		zap.String("region", config.Region),
 		zap.String("otlp_endpoint", config.OTLPEndpoint),
+		zap.Float64("sample_ratio", config.SampleRatio),
+		zap.Bool("tail_sampling", config.TailSampling),
 	)
 
 	return &Telemetry{
@@ -112,12 +151,27 @@ func NewTelemetry(ctx context.Context, config *TelemetryConfig, logger *zap.Logg
 		MeterProvider:      meterProvider,
 		PrometheusExporter: promExporter,
 		Tracer:             tracerProvider.Tracer(config.ServiceName),
-		Meter:              meterProvider.Meter(config.ServiceName),
+		Meter:              meter,
+		Breakers:           breakers,
 		config:             config,
 		logger:             logger,
 	}, nil
 }
 
+// breakerSpanExporter exports spans through the trace collector's circuit
+// breaker, so a collector that is down costs one fast failure per batch
+type breakerSpanExporter struct {
+	sdktrace.SpanExporter
+	breaker *Breaker
+}
+
+// ExportSpans exports the spans, retrying failed attempts
+func (e breakerSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return e.breaker.Do(ctx, func(ctx context.Context) error {
+		return e.SpanExporter.ExportSpans(ctx, spans)
+	})
+}
+
 // CreateMetrics initializes all application metrics
 func (t *Telemetry) CreateMetrics() (*TelemetryMetrics, error) {
 	httpDuration, err := t.Meter.Float64Histogram(
@@ -137,6 +191,22 @@ func (t *Telemetry) CreateMetrics() (*TelemetryMetrics, error) {
 		return nil, err
 	}
 
+	httpShed, err := t.Meter.Int64Counter(
+		"http.requests.shed",
+		metric.WithDescription("API requests rejected by the adaptive concurrency limit"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	httpDeprecated, err := t.Meter.Int64Counter(
+		"http.requests.deprecated",
+		metric.WithDescription("Calls to deprecated API operations by route and client"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	activeContests, err := t.Meter.Int64UpDownCounter(
 		"contests.active",
 		metric.WithDescription("Number of currently active contests"),
@@ -163,11 +233,13 @@ func (t *Telemetry) CreateMetrics() (*TelemetryMetrics, error) {
 	}
 
 	return &TelemetryMetrics{
-		HTTPRequestDuration: httpDuration,
-		HTTPRequestCount:    httpCount,
-		ActiveContests:      activeContests,
-		DBQueryDuration:     dbDuration,
-		ProblemsSolved:      problemsSolved,
+		HTTPRequestDuration:    httpDuration,
+		HTTPRequestCount:       httpCount,
+		HTTPRequestsShed:       httpShed,
+		HTTPRequestsDeprecated: httpDeprecated,
+		ActiveContests:         activeContests,
+		DBQueryDuration:        dbDuration,
+		ProblemsSolved:         problemsSolved,
 	}, nil
 }
 
@@ -0,0 +1,130 @@
+package infrastructure
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// tenantKey is the context key of the tenant a request is for
+type tenantKey struct{}
+
+// tenantField is the field of tenant-owned models
+const tenantField = "TenantID"
+
+// WithTenant returns a context for the tenant; queries run with it only see and
+// change that tenant's rows
+func WithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// AllTenants returns a context whose queries are not scoped to a tenant, even
+// when ctx is a request's, for jobs that cover the whole deployment
+func AllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, nil)
+}
+
+// TenantFrom returns the tenant in ctx. Without one, as in background workers
+// or with tenancy disabled, queries are not scoped to a tenant.
+func TenantFrom(ctx context.Context) (uuid.UUID, bool) {
+	if ctx == nil {
+		return uuid.Nil, false
+	}
+	tenantID, ok := ctx.Value(tenantKey{}).(uuid.UUID)
+	return tenantID, ok
+}
+
+// RegisterTenantScope scopes every GORM operation on a model with a TenantID
+// field to the tenant in the statement's context: reads, updates and deletes
+// only match the tenant's rows, and created rows are stamped with it. Reads of
+// models implementing domain.TenantShared also match the default tenant's rows.
+//
+// Queries naming their table themselves, such as aliased joins, and raw SQL
+// are not scoped; repositories scope those explicitly.
+func RegisterTenantScope(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("tenancy:stamp_create", stampTenant),
+		cb.Query().Before("gorm:query").Register("tenancy:scope_query", scopeTenant(true)),
+		cb.Row().Before("gorm:row").Register("tenancy:scope_row", scopeTenant(true)),
+		cb.Update().Before("gorm:update").Register("tenancy:scope_update", scopeTenant(false)),
+		cb.Delete().Before("gorm:delete").Register("tenancy:scope_delete", scopeTenant(false)),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tenantOwned returns the tenant field of the statement's model when it is
+// tenant-owned and the statement queries the model's own table
+func tenantOwned(stmt *gorm.Statement) (*schema.Field, bool) {
+	if stmt.Schema == nil || stmt.Table != stmt.Schema.Table {
+		return nil, false
+	}
+	field := stmt.Schema.LookUpField(tenantField)
+	return field, field != nil
+}
+
+// scopeTenant adds the tenant condition to a statement; shared reads also
+// match the default tenant's rows
+func scopeTenant(read bool) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		tenantID, ok := TenantFrom(tx.Statement.Context)
+		if !ok || tx.Error != nil {
+			return
+		}
+		field, ok := tenantOwned(tx.Statement)
+		if !ok {
+			return
+		}
+
+		column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+		_, shared := reflect.New(tx.Statement.Schema.ModelType).Interface().(domain.TenantShared)
+		if read && shared && tenantID != domain.DefaultTenantID {
+			tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+				clause.IN{Column: column, Values: []interface{}{domain.DefaultTenantID, tenantID}},
+			}})
+			return
+		}
+		tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: column, Value: tenantID},
+		}})
+	}
+}
+
+// stampTenant sets the tenant of created rows that do not name one
+func stampTenant(tx *gorm.DB) {
+	tenantID, ok := TenantFrom(tx.Statement.Context)
+	if !ok || tenantID == domain.DefaultTenantID || tx.Error != nil {
+		return
+	}
+	field, ok := tenantOwned(tx.Statement)
+	if !ok {
+		return
+	}
+
+	ctx := tx.Statement.Context
+	stamp := func(row reflect.Value) {
+		if _, zero := field.ValueOf(ctx, row); zero {
+			if err := field.Set(ctx, row, tenantID); err != nil {
+				_ = tx.AddError(err)
+			}
+		}
+	}
+	switch rv := reflect.Indirect(tx.Statement.ReflectValue); rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			stamp(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		stamp(rv)
+	}
+}
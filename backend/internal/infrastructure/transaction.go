@@ -0,0 +1,109 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key of the request transaction
+type txKey struct{}
+
+// Tx is a transaction that spans one request. Repositories given a context
+// carrying it run their queries in it, and work that must only happen once its
+// changes are visible, such as publishing events, waits until it commits.
+type Tx struct {
+	db          *gorm.DB
+	mu          sync.Mutex
+	done        bool
+	afterCommit []func()
+}
+
+// BeginTx starts a transaction on db and returns a context carrying it. The
+// transaction is rolled back if ctx is cancelled before it commits.
+func BeginTx(ctx context.Context, db *gorm.DB) (context.Context, *Tx, error) {
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return ctx, nil, ClassifyDBError(tx.Error)
+	}
+	t := &Tx{db: tx}
+	return context.WithValue(ctx, txKey{}, t), t, nil
+}
+
+// Commit commits the transaction, then runs the work deferred until it did
+func (t *Tx) Commit() error {
+	t.mu.Lock()
+	t.done = true
+	deferred := t.afterCommit
+	t.afterCommit = nil
+	t.mu.Unlock()
+
+	if err := t.db.Commit().Error; err != nil {
+		return ClassifyDBError(err)
+	}
+	for _, fn := range deferred {
+		fn()
+	}
+	return nil
+}
+
+// Rollback rolls the transaction back and drops the work deferred until it committed
+func (t *Tx) Rollback() error {
+	t.mu.Lock()
+	t.done = true
+	t.afterCommit = nil
+	t.mu.Unlock()
+
+	return t.db.Rollback().Error
+}
+
+// txFrom returns the open transaction in ctx
+func txFrom(ctx context.Context) (*Tx, bool) {
+	t, ok := ctx.Value(txKey{}).(*Tx)
+	if !ok || t == nil {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t, !t.done
+}
+
+// InTx reports whether ctx carries an open request transaction
+func InTx(ctx context.Context) bool {
+	_, ok := txFrom(ctx)
+	return ok
+}
+
+// DBFor returns db bound to ctx, or the request transaction in ctx when there
+// is an open one, so a repository joins it without knowing about it
+func DBFor(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if t, ok := txFrom(ctx); ok {
+		return t.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// AfterCommit runs fn once the request transaction in ctx commits, and never if
+// it rolls back. Without a transaction fn runs right away.
+func AfterCommit(ctx context.Context, fn func()) {
+	if t, ok := ctx.Value(txKey{}).(*Tx); ok && t != nil {
+		t.mu.Lock()
+		if !t.done {
+			t.afterCommit = append(t.afterCommit, fn)
+			t.mu.Unlock()
+			return
+		}
+		t.mu.Unlock()
+	}
+	fn()
+}
+
+// WithoutTx returns ctx without its request transaction, for work that outlives
+// the request
+func WithoutTx(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(txKey{}).(*Tx); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, txKey{}, (*Tx)(nil))
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// contestCreationRoutes are the routes that create a contest
+var contestCreationRoutes = map[string]bool{
+	"POST /api/contests":                true,
+	"POST /api/challenges/:code/accept": true,
+}
+
+// AlertMiddleware feeds finished API requests to the alert evaluator. Register it
+// after MaintenanceMiddleware so planned maintenance does not count as errors.
+func AlertMiddleware(alerts *infrastructure.AlertEvaluator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		alerts.ObserveRequest(status, time.Since(start))
+		if status >= 500 && contestCreationRoutes[c.Request.Method+" "+c.FullPath()] {
+			alerts.ObserveContestCreationFailure()
+		}
+	}
+}
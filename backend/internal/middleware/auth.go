@@ -1,12 +1,13 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
+	"github.com/contest-maker-150/backend/internal/domain"
 	"github.com/contest-maker-150/backend/internal/service"
 )
 
@@ -17,54 +18,44 @@ const (
 	BearerPrefix = "Bearer "
 	// UserIDKey is the context key for the user ID
 	UserIDKey = "userID"
+	// ClaimsKey is the context key for the validated token claims
+	ClaimsKey = "claims"
 )
 
 // AuthMiddleware creates a new authentication middleware
-func AuthMiddleware(userService *service.UserService) gin.HandlerFunc {
+func AuthMiddleware(userService service.UserServicer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader(AuthorizationHeader)
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header is required",
-			})
-			c.Abort()
+			AbortWithError(c, domain.NewDomainError(domain.ErrUnauthorized, "Authorization header is required"))
 			return
 		}
 
 		if !strings.HasPrefix(authHeader, BearerPrefix) {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid authorization header format",
-			})
-			c.Abort()
+			AbortWithError(c, domain.NewDomainError(domain.ErrUnauthorized, "Invalid authorization header format"))
 			return
 		}
 
 		token := strings.TrimPrefix(authHeader, BearerPrefix)
 		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Token is required",
-			})
-			c.Abort()
+			AbortWithError(c, domain.NewDomainError(domain.ErrUnauthorized, "Token is required"))
 			return
 		}
 
-		userID, err := userService.ValidateAccessToken(token)
+		claims, err := userService.ValidateAccessToken(c.Request.Context(), token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid or expired token",
-			})
-			c.Abort()
+			AbortWithError(c, err)
 			return
 		}
 
-		// Set user ID in context for handlers to use
-		c.Set(UserIDKey, userID)
+		// Set user ID and claims in context for handlers to use
+		setClaims(c, claims)
 		c.Next()
 	}
 }
 
 // OptionalAuthMiddleware creates middleware that validates token if present but doesn't require it
-func OptionalAuthMiddleware(userService *service.UserService) gin.HandlerFunc {
+func OptionalAuthMiddleware(userService service.UserServicer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader(AuthorizationHeader)
 		if authHeader == "" {
@@ -83,15 +74,67 @@ func OptionalAuthMiddleware(userService *service.UserService) gin.HandlerFunc {
 			return
 		}
 
-		userID, err := userService.ValidateAccessToken(token)
-		if err == nil {
-			c.Set(UserIDKey, userID)
+		if claims, err := userService.ValidateAccessToken(c.Request.Context(), token); err == nil {
+			setClaims(c, claims)
 		}
 
 		c.Next()
 	}
 }
 
+// RequireRole creates middleware that only admits users with one of the given roles.
+// It must run after AuthMiddleware and relies solely on the token claims.
+func RequireRole(roles ...domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			AbortWithError(c, domain.ErrUnauthorized)
+			return
+		}
+		for _, role := range roles {
+			if claims.Role == role {
+				c.Next()
+				return
+			}
+		}
+		AbortWithError(c, domain.ErrForbidden)
+	}
+}
+
+// RequireScope creates middleware that only admits tokens granting the given scope
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			AbortWithError(c, domain.ErrUnauthorized)
+			return
+		}
+		if !claims.HasScope(scope) {
+			AbortWithError(c, domain.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// setClaims stores the token claims and the user ID they identify in the gin context
+func setClaims(c *gin.Context, claims *service.TokenClaims) {
+	userID, _ := claims.UserID() // Validated by ValidateAccessToken
+	c.Set(UserIDKey, userID)
+	c.Set(ClaimsKey, claims)
+	addLogFields(c, zap.String("user_id", userID.String()))
+}
+
+// GetClaims extracts the validated token claims from the gin context
+func GetClaims(c *gin.Context) (*service.TokenClaims, bool) {
+	claims, exists := c.Get(ClaimsKey)
+	if !exists {
+		return nil, false
+	}
+	tc, ok := claims.(*service.TokenClaims)
+	return tc, ok
+}
+
 // GetUserID extracts the user ID from the gin context
 func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 	userID, exists := c.Get(UserIDKey)
@@ -107,10 +150,7 @@ func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 func RequireUser(c *gin.Context) (uuid.UUID, bool) {
 	userID, ok := GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Authentication required",
-		})
-		c.Abort()
+		AbortWithError(c, domain.ErrUnauthorized)
 		return uuid.Nil, false
 	}
 	return userID, true
@@ -35,10 +35,21 @@ func DefaultCORSConfig() CORSConfig {
 			"Authorization",
 			"X-Requested-With",
 			"X-Request-ID",
+			"X-Client-Name",
+			"X-Tenant",
 		},
 		ExposeHeaders: []string{
 			"Content-Length",
 			"X-Request-ID",
+			"X-Served-By",
+			"Deprecation",
+			"Sunset",
+			"Link",
+			"Retry-After",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
 		},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
@@ -59,10 +70,21 @@ func ProductionCORSConfig(allowedOrigins []string) CORSConfig {
 			"Authorization",
 			"X-Requested-With",
 			"X-Request-ID",
+			"X-Client-Name",
+			"X-Tenant",
 		},
 		ExposeHeaders: []string{
 			"Content-Length",
 			"X-Request-ID",
+			"X-Served-By",
+			"Deprecation",
+			"Sunset",
+			"Link",
+			"Retry-After",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
 		},
 		AllowCredentials: true,
 		MaxAge:           86400,
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/openapi"
+)
+
+// ClientNameHeader lets API clients identify themselves in deprecation reports
+const ClientNameHeader = "X-Client-Name"
+
+// DeprecationMiddleware adds Deprecation (RFC 9745), Sunset (RFC 8594) and
+// successor Link headers to operations marked deprecated in the operation table,
+// and records who still calls them so removal can be planned from real usage
+func DeprecationMiddleware(ops []openapi.Operation, metrics *infrastructure.TelemetryMetrics, logger *zap.Logger) gin.HandlerFunc {
+	deprecated := make(map[openapi.RouteKey]*openapi.Deprecation)
+	for _, op := range ops {
+		if op.Deprecated != nil {
+			deprecated[openapi.RouteKey{Method: op.Method, Path: op.Path}] = op.Deprecated
+		}
+	}
+
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		d, ok := deprecated[openapi.RouteKey{Method: c.Request.Method, Path: route}]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		c.Header("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+		if !d.Sunset.IsZero() {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.Successor != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", d.Successor))
+		}
+
+		c.Next()
+
+		// Runs after the handler chain so the authenticated user is known
+		client := clientName(c)
+		metrics.HTTPRequestsDeprecated.Add(c.Request.Context(), 1, metric.WithAttributes(
+			attribute.String("http.method", c.Request.Method),
+			attribute.String("http.route", route),
+			attribute.String("client.name", client),
+		))
+
+		// The request logger already carries the method, path and client IP
+		fields := []zap.Field{
+			zap.String("route", route),
+			zap.String("client", client),
+			zap.String("user_agent", c.Request.UserAgent()),
+		}
+		if userID, ok := GetUserID(c); ok {
+			fields = append(fields, zap.String("user_id", userID.String()))
+		}
+		if !d.Sunset.IsZero() {
+			fields = append(fields, zap.Time("sunset", d.Sunset))
+		}
+		infrastructure.LoggerFromContext(c.Request.Context(), logger).Warn("Deprecated endpoint called", fields...)
+	}
+}
+
+// clientName identifies the caller by the X-Client-Name header, falling back to
+// the product token of the User-Agent (e.g. "okhttp" for "okhttp/4.12.0")
+func clientName(c *gin.Context) string {
+	if name := strings.TrimSpace(c.GetHeader(ClientNameHeader)); name != "" {
+		return truncate(name, 64)
+	}
+	product, _, _ := strings.Cut(c.Request.UserAgent(), "/")
+	if product = strings.TrimSpace(product); product != "" {
+		return truncate(product, 64)
+	}
+	return "unknown"
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
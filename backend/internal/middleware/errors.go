@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// APIError is the machine-readable error returned by every endpoint
+type APIError struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+
+	// Retry is "safe" when any request may be repeated, "idempotent" when only
+	// idempotent ones may, and "never" otherwise; RetryAfterSeconds mirrors the
+	// Retry-After header
+	Retry             domain.RetryClass `json:"retry"`
+	RetryAfterSeconds int               `json:"retry_after_seconds,omitempty"`
+}
+
+// ErrorResponse is the envelope wrapping an APIError
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// errorMapping describes how a domain error is rendered over HTTP
+type errorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+// errorMappings is the single source of truth for domain error → HTTP translation.
+// Entries are matched with errors.Is in order, so wrapped errors resolve correctly.
+var errorMappings = []errorMapping{
+	{domain.ErrUserNotFound, http.StatusNotFound, domain.CodeUserNotFound, "User not found"},
+	{domain.ErrUserAlreadyExists, http.StatusConflict, domain.CodeUserAlreadyExists, "User with this email already exists"},
+	{domain.ErrInvalidCredentials, http.StatusUnauthorized, domain.CodeInvalidCredentials, "Invalid email or password"},
+	{domain.ErrTokenExpired, http.StatusUnauthorized, domain.CodeTokenExpired, "Token has expired"},
+	{domain.ErrTokenNotYetValid, http.StatusUnauthorized, domain.CodeTokenNotYetValid, "Token is not valid yet"},
+	{domain.ErrTokenMalformed, http.StatusUnauthorized, domain.CodeTokenMalformed, "Token is malformed"},
+	{domain.ErrTokenInvalidAudience, http.StatusUnauthorized, domain.CodeTokenInvalidAudience, "Token was not issued for this API"},
+	{domain.ErrTokenInvalidIssuer, http.StatusUnauthorized, domain.CodeTokenInvalidIssuer, "Token was not issued by this service"},
+	{domain.ErrTokenRevoked, http.StatusUnauthorized, domain.CodeTokenRevoked, "Token has been revoked. Please sign in again."},
+	{domain.ErrInvalidToken, http.StatusUnauthorized, domain.CodeInvalidToken, "Invalid or expired token"},
+	{domain.ErrWeakPassword, http.StatusBadRequest, domain.CodeWeakPassword, "Password does not meet requirements"},
+	{domain.ErrProblemNotFound, http.StatusNotFound, domain.CodeProblemNotFound, "Problem not found"},
+	{domain.ErrNotEnoughProblems, http.StatusBadRequest, domain.CodeNotEnoughProblems, "Not enough unsolved problems available. Try with fewer problems."},
+	{domain.ErrInvalidDifficulty, http.StatusBadRequest, domain.CodeInvalidDifficulty, "Invalid difficulty level"},
+	{domain.ErrTooManyCustomProblems, http.StatusConflict, domain.CodeTooManyCustom, "Custom problem limit reached. Delete a custom problem first."},
+	{domain.ErrQuotaExceeded, http.StatusForbidden, domain.CodeQuotaExceeded, "Your plan's allowance is used up"},
+	{domain.ErrBillingDisabled, http.StatusServiceUnavailable, domain.CodeBillingDisabled, "Billing is not available"},
+	{domain.ErrAlreadySubscribed, http.StatusConflict, domain.CodeAlreadySubscribed, "You already have the premium plan"},
+	{domain.ErrInvalidWebhookSignature, http.StatusBadRequest, domain.CodeInvalidSignature, "Invalid webhook signature"},
+	{domain.ErrPaymentProvider, http.StatusBadGateway, domain.CodePaymentProvider, "The payment provider is unavailable. Please try again."},
+	{domain.ErrCustomProblemExists, http.StatusConflict, domain.CodeCustomProblemExists, "You already added a custom problem with this URL"},
+	{domain.ErrProblemInUse, http.StatusConflict, domain.CodeProblemInUse, "This problem is used by a contest and cannot be deleted"},
+	{domain.ErrContestNotFound, http.StatusNotFound, domain.CodeContestNotFound, "Contest not found"},
+	{domain.ErrContestNotActive, http.StatusBadRequest, domain.CodeContestNotActive, "Contest is not active"},
+	{domain.ErrContestExpired, http.StatusBadRequest, domain.CodeContestExpired, "Contest has expired"},
+	{domain.ErrActiveContestExists, http.StatusConflict, domain.CodeActiveContest, "You already have an active contest. Complete or abandon it first."},
+	{domain.ErrProblemNotInContest, http.StatusNotFound, domain.CodeProblemNotInContest, "Problem not found in this contest"},
+	{domain.ErrContestNotStarted, http.StatusBadRequest, domain.CodeContestNotStarted, "Contest timer has not started yet. Finish the warmup first."},
+	{domain.ErrNoWarmup, http.StatusNotFound, domain.CodeNoWarmup, "This contest has no warmup problem"},
+	{domain.ErrWarmupOver, http.StatusBadRequest, domain.CodeWarmupOver, "Warmup has already ended"},
+	{domain.ErrContestInProgress, http.StatusBadRequest, domain.CodeContestInProgress, "Finish the contest before writing a retro"},
+	{domain.ErrProblemNotCompleted, http.StatusBadRequest, domain.CodeProblemNotCompleted, "Mark the problem as completed before stating its complexity"},
+	{domain.ErrProblemCompleted, http.StatusConflict, domain.CodeProblemCompleted, "Problem is already completed in this contest"},
+	{domain.ErrNoActiveContest, http.StatusNotFound, domain.CodeNoActiveContest, "You have no active contest"},
+	{domain.ErrNothingToSkip, http.StatusConflict, domain.CodeNothingToSkip, "Every other problem of the contest is completed"},
+	{domain.ErrContestNotPending, http.StatusConflict, domain.CodeContestNotPending, "This assigned contest was already started"},
+	{domain.ErrSessionConflict, http.StatusConflict, domain.CodeSessionConflict, "The contest state was saved from another device since you loaded it. Merge with the current state and retry."},
+	{domain.ErrInvalidTemplate, http.StatusUnprocessableEntity, domain.CodeInvalidTemplate, "This template was not exported here or was altered since"},
+	{domain.ErrTemplateConflict, http.StatusConflict, domain.CodeTemplateConflict, "Some problems of this template are not in the catalog"},
+	{domain.ErrListNotFound, http.StatusNotFound, domain.CodeListNotFound, "Problem list not found"},
+	{domain.ErrListNotSubscribed, http.StatusForbidden, domain.CodeListNotSubscribed, "Subscribe to this list before building contests from it"},
+	{domain.ErrListAlreadyReported, http.StatusConflict, domain.CodeListAlreadyReported, "You already reported this list"},
+	{domain.ErrOwnList, http.StatusBadRequest, domain.CodeOwnList, "You cannot subscribe to or report your own list"},
+	{domain.ErrChallengeNotFound, http.StatusNotFound, domain.CodeChallengeNotFound, "Challenge not found"},
+	{domain.ErrChallengeAccepted, http.StatusConflict, domain.CodeChallengeAccepted, "This challenge has already been accepted"},
+	{domain.ErrChallengeExpired, http.StatusBadRequest, domain.CodeChallengeExpired, "This challenge invite has expired"},
+	{domain.ErrChallengeInProgress, http.StatusConflict, domain.CodeChallengeInProgress, "Both contests must finish before they can be compared"},
+	{domain.ErrChatSilenced, http.StatusConflict, domain.CodeChatSilenced, "This challenge is in silent mode: chat reopens once both contests finish"},
+	{domain.ErrOrgNotFound, http.StatusNotFound, domain.CodeOrgNotFound, "Organization not found"},
+	{domain.ErrOrgInviteNotFound, http.StatusNotFound, domain.CodeOrgInviteNotFound, "Invite not found"},
+	{domain.ErrOrgInviteExpired, http.StatusBadRequest, domain.CodeOrgInviteExpired, "This invite has expired. Ask your instructor for a new one."},
+	{domain.ErrAlreadyOrgMember, http.StatusConflict, domain.CodeAlreadyOrgMember, "You are already a member of this organization"},
+	{domain.ErrOrgFull, http.StatusConflict, domain.CodeOrgFull, "This organization has reached its member limit"},
+	{domain.ErrOrgOwner, http.StatusConflict, domain.CodeOrgOwner, "The owner cannot leave or be removed from the organization"},
+	{domain.ErrAssignmentNotFound, http.StatusNotFound, domain.CodeAssignmentNotFound, "Assignment not found"},
+	{domain.ErrAssignmentStarted, http.StatusConflict, domain.CodeAssignmentStarted, "You already started this assignment"},
+	{domain.ErrNotContestAssignment, http.StatusBadRequest, domain.CodeNotContestAssignment, "Only contest assignments and problem sets are started; study plans are worked through the roadmap"},
+	{domain.ErrContestNotProctored, http.StatusBadRequest, domain.CodeContestNotProctored, "Focus events are only recorded for organization assignment contests"},
+	{domain.ErrMentorshipNotFound, http.StatusNotFound, domain.CodeMentorshipNotFound, "Mentorship not found"},
+	{domain.ErrMentorshipExists, http.StatusConflict, domain.CodeMentorshipExists, "You already mentor or invited this user"},
+	{domain.ErrMentorshipNotPending, http.StatusConflict, domain.CodeMentorshipNotPending, "This mentorship invite was already answered or withdrawn"},
+	{domain.ErrMentorshipNotActive, http.StatusForbidden, domain.CodeMentorshipNotActive, "The mentee has not consented, or the mentorship was revoked"},
+	{domain.ErrSelfMentorship, http.StatusBadRequest, domain.CodeSelfMentorship, "You cannot mentor yourself"},
+	{domain.ErrSolutionNotFound, http.StatusNotFound, domain.CodeSolutionNotFound, "No solution is attached to this problem"},
+	{domain.ErrSimilarityFlagNotFound, http.StatusNotFound, domain.CodeSimilarityNotFound, "Similarity flag not found"},
+	{domain.ErrTenantNotFound, http.StatusNotFound, domain.CodeTenantNotFound, "Tenant not found"},
+	{domain.ErrTenantSlugTaken, http.StatusConflict, domain.CodeTenantSlugTaken, "Another tenant already uses this slug"},
+	{domain.ErrFilterNotFound, http.StatusNotFound, domain.CodeFilterNotFound, "Saved filter not found"},
+	{domain.ErrFilterNameTaken, http.StatusConflict, domain.CodeFilterNameTaken, "A saved filter with this name already exists"},
+	{domain.ErrTooManyFilters, http.StatusConflict, domain.CodeTooManyFilters, "Saved filter limit reached. Delete a filter first."},
+	{domain.ErrSubmissionNotFound, http.StatusNotFound, domain.CodeSubmissionNotFound, "Submission not found"},
+	{domain.ErrAlreadySolved, http.StatusConflict, domain.CodeAlreadySolved, "Problem already solved"},
+	{domain.ErrFeatureFlagNotFound, http.StatusNotFound, domain.CodeFeatureFlagNotFound, "Feature flag not found"},
+	{domain.ErrMaintenance, http.StatusServiceUnavailable, domain.CodeMaintenance, "Changes are paused for maintenance. Please retry later."},
+	{domain.ErrInvalidMaintenanceTime, http.StatusBadRequest, domain.CodeInvalidMaintenance, "The maintenance window must end after it starts"},
+	{domain.ErrBackupDisabled, http.StatusServiceUnavailable, domain.CodeBackupDisabled, "Backup storage is not configured"},
+	{domain.ErrBackupNotFound, http.StatusNotFound, domain.CodeBackupNotFound, "Backup not found"},
+	{domain.ErrBackupCorrupt, http.StatusUnprocessableEntity, domain.CodeBackupCorrupt, "The backup failed its integrity check"},
+	{domain.ErrBackupIncompatible, http.StatusUnprocessableEntity, domain.CodeBackupIncompatible, "The backup cannot be restored into this database"},
+	{domain.ErrBackupInProgress, http.StatusConflict, domain.CodeBackupInProgress, "A backup is already running"},
+	{domain.ErrRestoreNotEmpty, http.StatusConflict, domain.CodeRestoreNotEmpty, "Restores only go into a database without users"},
+	{domain.ErrConflict, http.StatusConflict, domain.CodeConflict, "The resource already exists or was changed concurrently. Please retry."},
+	{domain.ErrForeignKeyViolation, http.StatusConflict, domain.CodeForeignKeyViolation, "The request references a record that does not exist or is still in use"},
+	{domain.ErrTimeout, http.StatusGatewayTimeout, domain.CodeRequestTimeout, "The request took too long to process. Please try again."},
+	{domain.ErrUnavailable, http.StatusServiceUnavailable, domain.CodeUnavailable, "The service is temporarily unavailable. Please retry shortly."},
+	{domain.ErrBadRequest, http.StatusBadRequest, domain.CodeBadRequest, "Bad request"},
+	{domain.ErrUnauthorized, http.StatusUnauthorized, domain.CodeUnauthorized, "Authentication required"},
+	{domain.ErrForbidden, http.StatusForbidden, domain.CodeForbidden, "You don't have access to this resource"},
+	{domain.ErrNotFound, http.StatusNotFound, domain.CodeNotFound, "Not found"},
+	{domain.ErrOverloaded, http.StatusTooManyRequests, domain.CodeOverloaded, "The server is busy. Please retry shortly."},
+	{domain.ErrRateLimited, http.StatusTooManyRequests, domain.CodeRateLimited, "Too many requests of this kind. Retry after the limit resets."},
+	{domain.ErrRequestTimeout, http.StatusGatewayTimeout, domain.CodeRequestTimeout, "The request took too long to process. Please try again."},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, domain.CodeRequestTimeout, "The request took too long to process. Please try again."},
+}
+
+// MapError converts an error into an HTTP status and APIError.
+// Unknown errors become a generic 500 so internal details never leak.
+func MapError(err error) (int, APIError) {
+	status := http.StatusInternalServerError
+	apiErr := APIError{
+		Code:    domain.CodeInternal,
+		Message: "Internal server error",
+		Retry:   domain.RetryHintFor(err).Class,
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			status = m.status
+			apiErr.Code = m.code
+			apiErr.Message = m.message
+			break
+		}
+	}
+
+	// Password policy failures carry the violated rules as details
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		apiErr.Details = policyErr.Violations
+	}
+
+	// Active contest conflicts point at the running contest
+	var activeErr *domain.ActiveContestError
+	if errors.As(err, &activeErr) && activeErr.Contest != nil {
+		apiErr.Details = activeErr.Contest.Conflict()
+	}
+
+	// DomainError can override the message/code and attach details
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) && status != http.StatusInternalServerError {
+		if domainErr.Message != "" {
+			apiErr.Message = domainErr.Message
+		}
+		if domainErr.Code != "" {
+			apiErr.Code = domainErr.Code
+		}
+		if domainErr.Details != nil {
+			apiErr.Details = domainErr.Details
+		}
+	}
+
+	return status, apiErr
+}
+
+// ErrorHandlerMiddleware renders the last error attached via c.Error as an
+// ErrorResponse. Handlers only need to call c.Error(err) and return.
+func ErrorHandlerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		writeError(c, c.Errors.Last().Err)
+	}
+}
+
+// AbortWithError immediately writes the error envelope and aborts the chain.
+// Middleware that rejects a request before it reaches a handler uses this.
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	writeError(c, err)
+}
+
+// writeError writes the error envelope. A retryable error gets a Retry-After
+// header with the domain's suggested wait, unless the middleware that rejected
+// the request already set one from its own window.
+func writeError(c *gin.Context, err error) {
+	status, apiErr := MapError(err)
+	apiErr.RequestID = GetRequestID(c)
+
+	if apiErr.Retry != domain.RetryNever {
+		retryAfter := c.Writer.Header().Get("Retry-After")
+		if after := domain.RetryHintFor(err).After; retryAfter == "" && after > 0 {
+			retryAfter = strconv.Itoa(int(after.Seconds()))
+			c.Header("Retry-After", retryAfter)
+		}
+		apiErr.RetryAfterSeconds, _ = strconv.Atoi(retryAfter)
+	}
+	c.AbortWithStatusJSON(status, ErrorResponse{Error: apiErr})
+}
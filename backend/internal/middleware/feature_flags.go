@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// FeatureFlagsMiddleware makes the flag evaluator available to handlers and,
+// through the request context, to services
+func FeatureFlagsMiddleware(flags *infrastructure.FeatureFlags) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(infrastructure.ContextWithFeatureFlags(c.Request.Context(), flags))
+		c.Next()
+	}
+}
+
+// FeatureEnabled reports whether the flag is on for the authenticated user, or
+// for anonymous users when the request is not authenticated
+func FeatureEnabled(c *gin.Context, key string) bool {
+	userID, ok := GetUserID(c)
+	if !ok {
+		userID = uuid.Nil
+	}
+	return infrastructure.FeatureEnabled(c.Request.Context(), key, userID)
+}
+
+// RequireFeature hides routes behind a flag: while the flag is off for the
+// user, the route answers 404 as if it did not exist. It must run after the
+// auth middleware for per-user rollouts to apply.
+func RequireFeature(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !FeatureEnabled(c, key) {
+			AbortWithError(c, domain.ErrNotFound)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// loadShedBackoff is the multiplicative decrease applied to the limit on a slow request
+const loadShedBackoff = 0.9
+
+// AdaptiveLimiter is an AIMD concurrency limiter. The limit grows by one for
+// every fast request completed while the limit is well used, and shrinks by
+// loadShedBackoff for every request that exceeds the latency threshold or runs
+// out of time, so concurrency settles where the database keeps up.
+type AdaptiveLimiter struct {
+	config *infrastructure.LoadShedConfig
+
+	mu       sync.Mutex
+	limit    float64
+	inflight int
+}
+
+// NewAdaptiveLimiter creates a limiter starting at the configured initial limit
+func NewAdaptiveLimiter(config *infrastructure.LoadShedConfig) *AdaptiveLimiter {
+	l := &AdaptiveLimiter{config: config}
+	l.limit = l.clamp(float64(config.InitialLimit))
+	return l
+}
+
+// Acquire reserves a slot for a request, reporting false when the limit is reached
+func (l *AdaptiveLimiter) Acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight >= int(l.limit) {
+		return false
+	}
+	l.inflight++
+	return true
+}
+
+// Release frees the slot of a finished request and adapts the limit to how it went
+func (l *AdaptiveLimiter) Release(latency time.Duration, timedOut bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if timedOut || latency > l.config.LatencyThreshold {
+		l.limit = l.clamp(l.limit * loadShedBackoff)
+	} else if l.inflight*2 >= int(l.limit) {
+		// Only grow while the limit is actually being used
+		l.limit = l.clamp(l.limit + 1)
+	}
+	l.inflight--
+}
+
+// Limit returns the current concurrency limit
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Inflight returns the number of requests currently holding a slot
+func (l *AdaptiveLimiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight
+}
+
+// RegisterMetrics exports the current limit and in-flight requests as gauges
+func (l *AdaptiveLimiter) RegisterMetrics(meter metric.Meter) error {
+	limitGauge, err := meter.Int64ObservableGauge(
+		"http.concurrency.limit",
+		metric.WithDescription("Adaptive concurrency limit of API requests"),
+	)
+	if err != nil {
+		return err
+	}
+
+	inflightGauge, err := meter.Int64ObservableGauge(
+		"http.concurrency.inflight",
+		metric.WithDescription("API requests currently being processed"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		l.mu.Lock()
+		limit, inflight := int64(l.limit), int64(l.inflight)
+		l.mu.Unlock()
+
+		o.ObserveInt64(limitGauge, limit)
+		o.ObserveInt64(inflightGauge, inflight)
+		return nil
+	}, limitGauge, inflightGauge)
+	return err
+}
+
+// clamp keeps the limit within the configured bounds
+func (l *AdaptiveLimiter) clamp(limit float64) float64 {
+	return math.Max(float64(l.config.MinLimit), math.Min(float64(l.config.MaxLimit), limit))
+}
+
+// LoadSheddingMiddleware rejects requests over the adaptive concurrency limit with
+// 429 OVERLOADED instead of queueing them on the database pool, and counts every
+// shed request by route
+func LoadSheddingMiddleware(limiter *AdaptiveLimiter, metrics *infrastructure.TelemetryMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limiter.Acquire() {
+			path := c.FullPath()
+			if path == "" {
+				path = "unknown"
+			}
+			metrics.HTTPRequestsShed.Add(c.Request.Context(), 1,
+				metric.WithAttributes(attribute.String("http.route", path)),
+			)
+
+			c.Header("Retry-After", "1")
+			AbortWithError(c, domain.ErrOverloaded)
+			return
+		}
+
+		start := time.Now()
+		defer func() {
+			timedOut := errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+			limiter.Release(time.Since(start), timedOut)
+		}()
+
+		c.Next()
+	}
+}
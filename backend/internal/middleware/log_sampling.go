@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// LogSampler decides which successful request logs are kept, to cut the volume
+// of busy routes. Failed requests are always logged, and so is every request
+// while debug logging is on.
+type LogSampler struct {
+	defaultRate float64
+	routes      map[string]float64 // "METHOD /route" to the share of logs kept
+}
+
+// NewLogSampler creates a sampler from LOG_SAMPLE_RATE and LOG_SAMPLE_ROUTES;
+// invalid route entries are ignored with a warning
+func NewLogSampler(config *infrastructure.LoggingConfig, logger *zap.Logger) *LogSampler {
+	s := &LogSampler{
+		defaultRate: clampRate(config.SampleRate),
+		routes:      make(map[string]float64, len(config.SampleRoutes)),
+	}
+	for _, entry := range config.SampleRoutes {
+		route, value, found := strings.Cut(entry, "=")
+		rate, err := strconv.ParseFloat(value, 64)
+		if !found || err != nil || rate < 0 || rate > 1 || !strings.Contains(route, " /") {
+			logger.Warn("Ignoring invalid log sampling rule", zap.String("rule", entry))
+			continue
+		}
+		s.routes[route] = rate
+	}
+	return s
+}
+
+// Rate returns the share of successful request logs kept for the route
+func (s *LogSampler) Rate(route string) float64 {
+	if rate, ok := s.routes[route]; ok {
+		return rate
+	}
+	return s.defaultRate
+}
+
+// Keep reports whether a successful request log with the given rate is kept
+func (s *LogSampler) Keep(rate float64) bool {
+	return rate >= 1 || rand.Float64() < rate
+}
+
+func clampRate(rate float64) float64 {
+	return min(max(rate, 0), 1)
+}
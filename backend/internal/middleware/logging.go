@@ -5,7 +5,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
 const (
@@ -13,8 +18,9 @@ const (
 	RequestIDKey = "requestID"
 )
 
-// LoggingMiddleware creates a logging middleware that logs all requests
-func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
+// LoggingMiddleware creates a logging middleware that logs all failed requests
+// and the share of successful ones the sampler keeps
+func LoggingMiddleware(logger *zap.Logger, sampler *LogSampler) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 
@@ -23,17 +29,22 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		c.Set(RequestIDKey, requestID)
 		c.Header("X-Request-ID", requestID)
 
-		// Create request-scoped logger
+		// Create request-scoped logger; services pick it up from the request context,
+		// and later middleware add the trace and user IDs
 		reqLogger := logger.With(
 			zap.String("request_id", requestID),
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("client_ip", c.ClientIP()),
 		)
+		c.Request = c.Request.WithContext(infrastructure.ContextWithLogger(c.Request.Context(), reqLogger))
 
 		// Process request
 		c.Next()
 
+		// Pick up the fields added downstream
+		reqLogger = infrastructure.LoggerFromContext(c.Request.Context(), reqLogger)
+
 		// Calculate duration
 		duration := time.Since(start)
 
@@ -47,12 +58,7 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 
 		// Add query params if present
 		if c.Request.URL.RawQuery != "" {
-			logFields = append(logFields, zap.String("query", c.Request.URL.RawQuery))
-		}
-
-		// Add user ID if authenticated
-		if userID, exists := c.Get(UserIDKey); exists {
-			logFields = append(logFields, zap.String("user_id", userID.(uuid.UUID).String()))
+			logFields = append(logFields, zap.String("query", infrastructure.RedactQuery(c.Request.URL.RawQuery)))
 		}
 
 		// Add error if present
@@ -66,11 +72,25 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		case status >= 400:
 			reqLogger.Warn("Client error", logFields...)
 		default:
+			if rate := sampler.Rate(c.Request.Method + " " + c.FullPath()); rate < 1 && !reqLogger.Core().Enabled(zapcore.DebugLevel) {
+				if !sampler.Keep(rate) {
+					return
+				}
+				logFields = append(logFields, zap.Float64("sample_rate", rate))
+			}
 			reqLogger.Info("Request completed", logFields...)
 		}
 	}
 }
 
+// addLogFields adds fields to the request-scoped logger carried by the request context
+func addLogFields(c *gin.Context, fields ...zap.Field) {
+	ctx := c.Request.Context()
+	if logger := infrastructure.LoggerFromContext(ctx, nil); logger != nil {
+		c.Request = c.Request.WithContext(infrastructure.ContextWithLogger(ctx, logger.With(fields...)))
+	}
+}
+
 // GetRequestID extracts the request ID from the gin context
 func GetRequestID(c *gin.Context) string {
 	if requestID, exists := c.Get(RequestIDKey); exists {
@@ -79,26 +99,37 @@ func GetRequestID(c *gin.Context) string {
 	return ""
 }
 
-// RecoveryMiddleware creates a recovery middleware that recovers from panics
-func RecoveryMiddleware(logger *zap.Logger) gin.HandlerFunc {
+// RecoveryMiddleware creates a recovery middleware that recovers from panics,
+// logs them and hands a crash report with the request context to the reporter
+func RecoveryMiddleware(logger *zap.Logger, reporter *infrastructure.CrashReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				requestID := GetRequestID(c)
-
-				logger.Error("Panic recovered",
-					zap.String("request_id", requestID),
+				report := reporter.NewReport(c.Request, c.Request.Method+" "+c.FullPath(), err)
+				report.RequestID = GetRequestID(c)
+				if userID, ok := GetUserID(c); ok {
+					report.UserID = userID.String()
+				}
+				if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+					report.TraceID = sc.TraceID().String()
+					report.SpanID = sc.SpanID().String()
+				}
+
+				// The request-scoped logger already carries the request, trace and user IDs
+				reqLogger := infrastructure.LoggerFromContext(c.Request.Context(), logger.With(
+					zap.String("request_id", GetRequestID(c)),
 					zap.String("method", c.Request.Method),
 					zap.String("path", c.Request.URL.Path),
+				))
+
+				reqLogger.Error("Panic recovered",
 					zap.Any("error", err),
+					zap.String("event_id", report.EventID),
 					zap.Stack("stack"),
 				)
+				reporter.Capture(report)
 
-				c.JSON(500, gin.H{
-					"error":      "Internal server error",
-					"request_id": requestID,
-				})
-				c.Abort()
+				AbortWithError(c, domain.ErrInternalServer)
 			}
 		}()
 		c.Next()
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// maintenanceExempt lists writes that stay available during maintenance: signing
+// in and refreshing tokens only issue tokens, the batch problem lookup is a read
+// sent as a POST for its long ID list, and admins must be able to end it and to
+// turn up logging while it lasts
+var maintenanceExempt = map[string]bool{
+	"POST /api/problems/batch":   true,
+	"POST /api/auth/login":       true,
+	"POST /api/auth/refresh":     true,
+	"PUT /api/admin/maintenance": true,
+	"PUT /api/admin/log-level":   true,
+}
+
+// MaintenanceMiddleware rejects writes with 503 and a Retry-After header while
+// maintenance is active. Reads keep working.
+func MaintenanceMiddleware(maintenance *infrastructure.Maintenance) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if maintenanceExempt[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		status := maintenance.Status(c.Request.Context())
+		if !status.Active {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(status.RetryAfterSeconds))
+		var err error = domain.ErrMaintenance
+		if status.Message != "" {
+			err = domain.NewDomainError(domain.ErrMaintenance, status.Message)
+		}
+		AbortWithError(c, err)
+	}
+}
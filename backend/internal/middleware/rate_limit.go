@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// RateLimit is the number of requests a single user may make to a class of
+// expensive endpoints per fixed window
+type RateLimit struct {
+	Name   string // Class of endpoints sharing the budget, e.g. "contests"
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimitMiddleware limits each user, or each client IP for anonymous
+// requests, to limit.Limit requests per window using counters shared by all
+// instances. Every response carries the RateLimit-* headers; requests over the
+// limit are rejected with 429 RATE_LIMITED and a Retry-After header. When the
+// store fails the request is let through, since a limiter outage should not
+// take the endpoints down with it. A limit of 0 disables the middleware.
+func RateLimitMiddleware(store domain.RateLimitRepository, limit RateLimit, logger *zap.Logger) gin.HandlerFunc {
+	allow := RateLimitCheck(store, limit, logger)
+	return func(c *gin.Context) {
+		if allow(c) {
+			c.Next()
+		}
+	}
+}
+
+// RateLimitCheck is the check behind RateLimitMiddleware, for handlers that
+// rate limit only some of their requests. It counts the request, sets the
+// headers and reports whether it may proceed; a request over the limit has
+// already been aborted with 429 RATE_LIMITED.
+func RateLimitCheck(store domain.RateLimitRepository, limit RateLimit, logger *zap.Logger) func(c *gin.Context) bool {
+	if limit.Limit <= 0 {
+		return func(*gin.Context) bool { return true }
+	}
+	policy := strconv.Itoa(limit.Limit) + ";w=" + strconv.Itoa(int(limit.Window.Seconds()))
+
+	return func(c *gin.Context) bool {
+		client := c.ClientIP()
+		if userID, ok := GetUserID(c); ok {
+			client = userID.String()
+		}
+
+		now := time.Now().UTC()
+		windowStart := now.Truncate(limit.Window)
+		windowEnd := windowStart.Add(limit.Window)
+
+		count, err := store.WithContext(c.Request.Context()).Increment(limit.Name+":"+client, windowStart, windowEnd)
+		if err != nil {
+			logger.Warn("Rate limit check failed, allowing request",
+				zap.String("limit", limit.Name),
+				zap.Error(err),
+			)
+			return true
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(windowEnd.Sub(now).Seconds())))
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(max(limit.Limit-count, 0)))
+		c.Header("RateLimit-Reset", reset)
+		c.Header("RateLimit-Policy", policy)
+
+		if count > limit.Limit {
+			c.Header("Retry-After", reset)
+			AbortWithError(c, domain.ErrRateLimited)
+			return false
+		}
+		return true
+	}
+}
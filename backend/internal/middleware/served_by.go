@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ServedByHeader names the region and instance that handled a request, so
+// clients and edge routers can tell which deployment answered
+const ServedByHeader = "X-Served-By"
+
+// ServedByMiddleware sets X-Served-By to region/host, or to the host alone in a
+// single-region deployment
+func ServedByMiddleware(region, host string) gin.HandlerFunc {
+	value := host
+	if region != "" {
+		value = region + "/" + host
+	}
+	return func(c *gin.Context) {
+		c.Header(ServedByHeader, value)
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// TenancyMiddleware resolves the tenant a request is for from the tenant
+// header or, without one, the subdomain of the configured base domain, and
+// scopes the request's queries to it. Requests naming no tenant are for the
+// default one; naming an unknown tenant is a 404.
+func TenancyMiddleware(tenants *service.TenantService, config *infrastructure.TenancyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		slug := c.GetHeader(config.Header)
+		if slug == "" {
+			slug = subdomain(c.Request.Host, config.BaseDomain)
+		}
+
+		tenant, err := tenants.Resolve(c.Request.Context(), slug)
+		if err != nil {
+			AbortWithError(c, err)
+			return
+		}
+
+		tenantID := domain.DefaultTenantID
+		if tenant != nil {
+			tenantID = tenant.ID
+			addLogFields(c, zap.String("tenant", tenant.Slug))
+		}
+		c.Request = c.Request.WithContext(infrastructure.WithTenant(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
+
+// RequireDefaultTenant only admits requests for the default tenant, for routes
+// that manage the whole deployment rather than one tenant
+func RequireDefaultTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tenantID, ok := infrastructure.TenantFrom(c.Request.Context()); ok && tenantID != domain.DefaultTenantID {
+			AbortWithError(c, domain.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// subdomain returns the label of host in front of baseDomain, or "" when host
+// is not a subdomain of it
+func subdomain(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, "."+baseDomain) {
+		return ""
+	}
+	return strings.TrimSuffix(host, "."+baseDomain)
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// TimeoutConfig holds the handler deadline for every route
+type TimeoutConfig struct {
+	Default time.Duration            // Applied to routes without an override; 0 disables the deadline
+	Routes  map[string]time.Duration // Overrides keyed by "METHOD /full/path", e.g. "POST /api/contests"
+}
+
+// For returns the deadline for a route
+func (c TimeoutConfig) For(method, fullPath string) time.Duration {
+	if d, ok := c.Routes[method+" "+fullPath]; ok {
+		return d
+	}
+	return c.Default
+}
+
+// TimeoutMiddleware bounds the request context of each handler by its route's
+// deadline. Repositories run their queries with the request context, so a slow
+// query is cancelled instead of holding a connection past the server's
+// WriteTimeout. A handler that runs out of time without writing a response
+// gets a 504 REQUEST_TIMEOUT error.
+func TimeoutMiddleware(config TimeoutConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := config.For(c.Request.Method, c.FullPath())
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			_ = c.Error(domain.ErrRequestTimeout)
+		}
+	}
+}
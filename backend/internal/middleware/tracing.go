@@ -7,6 +7,9 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
 // TracingMiddleware creates a middleware that enables distributed tracing
@@ -27,7 +30,7 @@ func TracingMiddleware(tracer trace.Tracer) gin.HandlerFunc {
 			trace.WithSpanKind(trace.SpanKindServer),
 			trace.WithAttributes(
 				semconv.HTTPRequestMethodKey.String(c.Request.Method),
-				semconv.URLFull(c.Request.URL.String()),
+				semconv.URLFull(infrastructure.RedactURL(c.Request.URL)),
 				semconv.URLScheme(c.Request.URL.Scheme),
 				semconv.ServerAddress(c.Request.Host),
 				semconv.UserAgentOriginal(c.Request.UserAgent()),
@@ -44,6 +47,14 @@ func TracingMiddleware(tracer trace.Tracer) gin.HandlerFunc {
 		// Store the new context in the request
 		c.Request = c.Request.WithContext(ctx)
 
+		// Correlate log lines with the trace
+		if sc := span.SpanContext(); sc.IsValid() {
+			addLogFields(c,
+				zap.String("trace_id", sc.TraceID().String()),
+				zap.String("span_id", sc.SpanID().String()),
+			)
+		}
+
 		// Process request
 		c.Next()
 
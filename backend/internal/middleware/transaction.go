@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"maps"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// TransactionConfig holds the database and the routes the transaction middleware leaves alone
+type TransactionConfig struct {
+	DB   *gorm.DB
+	Skip map[string]bool // "METHOD /full/path" of mutating routes that manage their own transactions
+}
+
+// TransactionMiddleware runs each mutating request in one database
+// transaction, so its writes land all together or not at all. Repositories
+// join the transaction through the request context. It commits when the
+// handler succeeds, and rolls back on an error response, a handler error or a
+// panic. The response, headers included, is held back until the commit, so a
+// client never sees success, or any part of it, for changes that failed to
+// commit. Events published by the request are delivered only after the commit.
+func TransactionMiddleware(config TransactionConfig, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if config.Skip[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		ctx, tx, err := infrastructure.BeginTx(c.Request.Context(), config.DB)
+		if err != nil {
+			AbortWithError(c, err)
+			return
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, header: c.Writer.Header().Clone(), status: http.StatusOK, size: -1}
+		c.Writer = writer
+		finished := false
+		defer func() {
+			c.Writer = writer.ResponseWriter
+			// A panicking handler leaves the transaction open; the recovery middleware answers
+			if !finished {
+				rollback(c, tx, logger)
+			}
+		}()
+
+		c.Next()
+		finished = true
+
+		if len(c.Errors) > 0 || writer.Status() >= http.StatusBadRequest {
+			rollback(c, tx, logger)
+			writer.flush()
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			infrastructure.LoggerFromContext(ctx, logger).Error("Failed to commit request transaction", zap.Error(err))
+			// Nothing was sent yet, so the error handler answers instead of the handler's
+			// response, whose headers are dropped with it
+			_ = c.Error(err)
+			return
+		}
+		writer.flush()
+	}
+}
+
+// rollback rolls the request transaction back; one already rolled back by a
+// cancelled request context is not an error
+func rollback(c *gin.Context, tx *infrastructure.Tx, logger *zap.Logger) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		infrastructure.LoggerFromContext(c.Request.Context(), logger).Warn("Failed to roll back request transaction", zap.Error(err))
+	}
+}
+
+// bufferedWriter holds a response back until flush. Handlers set headers on a
+// copy of the ones set before the request transaction began.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	header http.Header
+	status int
+	size   int // -1 until the header is written
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) Header() http.Header { return w.header }
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.Written() {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.size = 0
+	}
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	n, err := w.body.Write(data)
+	w.size += n
+	return n, err
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	n, err := w.body.WriteString(s)
+	w.size += n
+	return n, err
+}
+
+func (w *bufferedWriter) Status() int   { return w.status }
+func (w *bufferedWriter) Size() int     { return w.size }
+func (w *bufferedWriter) Written() bool { return w.size != -1 }
+
+// Flush is a no-op; the response is sent by flush once the transaction finishes
+func (w *bufferedWriter) Flush() {}
+
+// flush sends the held-back response
+func (w *bufferedWriter) flush() {
+	header := w.ResponseWriter.Header()
+	clear(header)
+	maps.Copy(header, w.header)
+	w.ResponseWriter.WriteHeader(w.status)
+	if !w.Written() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,142 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/middleware"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTransactionRouter returns a router running POST /write in a request
+// transaction over an in-memory database with a parents table
+func newTransactionRouter(t *testing.T, handler gin.HandlerFunc) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	config := infrastructure.LoadConfig().Database
+	config.Driver = infrastructure.DriverSQLite
+	config.SQLitePath = ":memory:"
+	database, err := infrastructure.NewDatabase(&config, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	if err := database.DB.Exec("CREATE TABLE parents (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.Use(gin.RecoveryWithWriter(io.Discard), middleware.ErrorHandlerMiddleware())
+	router.Use(func(c *gin.Context) {
+		// Set before the transaction begins, so kept on every response
+		c.Header("X-Served-By", "test")
+		c.Next()
+	})
+	router.Use(middleware.TransactionMiddleware(middleware.TransactionConfig{DB: database.DB}, zap.NewNop()))
+	router.POST("/write", handler)
+	return router, database.DB
+}
+
+// insert runs statement in the request's transaction
+func insert(c *gin.Context, db *gorm.DB, statement string) {
+	if err := infrastructure.DBFor(c.Request.Context(), db).Exec(statement).Error; err != nil {
+		panic(err)
+	}
+}
+
+func countParents(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Table("parents").Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestTransactionMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		respond   func(c *gin.Context)
+		status    int
+		committed bool
+	}{
+		{"success commits", func(c *gin.Context) { c.JSON(http.StatusCreated, gin.H{"id": 1}) }, http.StatusCreated, true},
+		{"empty success commits", func(c *gin.Context) { c.Status(http.StatusNoContent) }, http.StatusNoContent, true},
+		{"error status rolls back", func(c *gin.Context) { c.JSON(http.StatusUnprocessableEntity, gin.H{}) }, http.StatusUnprocessableEntity, false},
+		{"handler error rolls back", func(c *gin.Context) { _ = c.Error(domain.ErrConflict) }, http.StatusConflict, false},
+		{"panic rolls back", func(c *gin.Context) { panic("boom") }, http.StatusInternalServerError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var db *gorm.DB
+			router, db := newTransactionRouter(t, func(c *gin.Context) {
+				insert(c, db, "INSERT INTO parents (id) VALUES (1)")
+				c.Header("X-Handler", "set")
+				tt.respond(c)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/write", nil))
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if got := countParents(t, db); (got == 1) != tt.committed {
+				t.Fatalf("%d rows after the request, committed = %t", got, tt.committed)
+			}
+			if rec.Header().Get("X-Served-By") != "test" {
+				t.Fatal("header set before the transaction was lost")
+			}
+		})
+	}
+}
+
+func TestTransactionMiddlewareCommitFailure(t *testing.T) {
+	var db *gorm.DB
+	router, db := newTransactionRouter(t, func(c *gin.Context) {
+		insert(c, db, "INSERT INTO parents (id) VALUES (1)")
+		// Ends the transaction behind the middleware's back, so its commit fails
+		insert(c, db, "ROLLBACK")
+		c.Header("Location", "/parents/1")
+		c.Header("Retry-After", "120")
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/write", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	var body middleware.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not an error envelope: %v: %s", err, rec.Body)
+	}
+	if body.Error.Code != domain.CodeInternal || body.Error.RetryAfterSeconds == 120 {
+		t.Fatalf("error = %+v, want %s without the handler's retry delay", body.Error, domain.CodeInternal)
+	}
+	// Only the error envelope's headers go out, not the discarded response's
+	for _, name := range []string{"Location", "Retry-After"} {
+		if value := rec.Header().Get(name); value != "" {
+			t.Errorf("%s = %q leaked from the discarded response", name, value)
+		}
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if rec.Header().Get("X-Served-By") != "test" {
+		t.Error("header set before the transaction was lost")
+	}
+	if got := countParents(t, db); got != 0 {
+		t.Fatalf("%d rows after a failed commit, want 0", got)
+	}
+}
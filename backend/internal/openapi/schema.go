@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of the OpenAPI 3 schema object used by this API
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+// Object describes an ad-hoc JSON object (typically a gin.H response) by
+// mapping each property name to a sample value of its Go type
+type Object map[string]interface{}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	objectType   = reflect.TypeOf(Object{})
+)
+
+// schemaRegistry converts Go types to schemas, collecting named structs as components
+type schemaRegistry struct {
+	components map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: make(map[string]*Schema)}
+}
+
+// schemaForValue returns the schema for a sample value
+func (r *schemaRegistry) schemaForValue(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	if obj, ok := v.(Object); ok {
+		return r.schemaForObject(obj)
+	}
+	return r.schemaForType(reflect.TypeOf(v))
+}
+
+// schemaForObject builds an inline object schema from an Object description
+func (r *schemaRegistry) schemaForObject(obj Object) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for name, sample := range obj {
+		schema.Properties[name] = r.schemaForValue(sample)
+	}
+	return schema
+}
+
+// schemaForType returns the schema for a Go type
+func (r *schemaRegistry) schemaForType(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var schema *Schema
+	switch {
+	case t == timeType:
+		schema = &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		schema = &Schema{Type: "string", Format: "uuid"}
+	case t == durationType:
+		schema = &Schema{Type: "integer", Format: "int64"}
+	case t == objectType:
+		schema = &Schema{Type: "object"}
+	default:
+		schema = r.schemaForKind(t)
+	}
+
+	if nullable && schema.Ref == "" {
+		schema.Nullable = true
+	}
+	return schema
+}
+
+// schemaForKind maps a Go kind to its schema
+func (r *schemaRegistry) schemaForKind(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaForType(t.Elem())}
+	case reflect.Struct:
+		return r.schemaForStruct(t)
+	default:
+		// interface{} and anything else accepts any JSON value
+		return &Schema{}
+	}
+}
+
+// schemaForStruct registers a named struct as a component and returns a reference to it
+func (r *schemaRegistry) schemaForStruct(t reflect.Type) *Schema {
+	name := t.Name()
+	if name == "" {
+		return r.buildStruct(t)
+	}
+	if _, ok := r.components[name]; !ok {
+		// Reserve the name first so self-referencing types terminate
+		r.components[name] = &Schema{}
+		r.components[name] = r.buildStruct(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// buildStruct builds an object schema from exported, JSON-visible struct fields
+func (r *schemaRegistry) buildStruct(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		// Embedded structs without a JSON name are flattened, as encoding/json does
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.addFields(schema, ft)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = r.schemaForType(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// jsonName returns the JSON property name of a field and whether it is skipped
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
@@ -0,0 +1,265 @@
+// Package openapi builds an OpenAPI 3 document from a declarative table of
+// operations, deriving request and response schemas from Go types by reflection.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Param describes a query or header parameter of an operation.
+// Path parameters are derived from the route pattern automatically.
+type Param struct {
+	Name        string
+	In          string // "query" or "header"
+	Description string
+	Required    bool
+	Example     interface{} // Sample value whose Go type determines the schema
+}
+
+// Operation documents a single HTTP endpoint
+type Operation struct {
+	Method      string
+	Path        string // Gin route pattern, e.g. /api/contests/:id
+	Summary     string
+	Tags        []string
+	Auth        bool
+	Params      []Param
+	Request     interface{}         // Sample request body, nil if none
+	Responses   map[int]interface{} // Status code → sample response body (nil for no body)
+	ContentType string              // Response content type, defaults to application/json
+	Deprecated  *Deprecation        // Set once the operation is scheduled for removal
+}
+
+// Deprecation marks an operation as on its way out. Responses carry Deprecation
+// and Sunset headers and every call is logged with its caller.
+type Deprecation struct {
+	Since     time.Time // When the operation was deprecated
+	Sunset    time.Time // When it will be removed; zero if not yet scheduled
+	Successor string    // Path of the replacement operation, if any
+}
+
+// Info holds document metadata
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is the OpenAPI 3 document root
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*pathItem `json:"paths"`
+	Components components                      `json:"components"`
+}
+
+type components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type pathItem struct {
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Sunset      string                `json:"x-sunset,omitempty"` // RFC 3339 removal date
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Build assembles the OpenAPI document. errorBody is a sample of the error
+// envelope, documented as the default response of every operation.
+func Build(info Info, ops []Operation, errorBody interface{}) *Document {
+	registry := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]map[string]*pathItem),
+		Components: components{
+			Schemas: registry.components,
+			SecuritySchemes: map[string]securityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, op := range ops {
+		path, pathParams := convertPath(op.Path)
+		item := &pathItem{
+			Summary:     op.Summary,
+			OperationID: operationID(op),
+			Tags:        op.Tags,
+			Responses:   make(map[string]*response),
+		}
+
+		for _, name := range pathParams {
+			item.Parameters = append(item.Parameters, parameter{
+				Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"},
+			})
+		}
+		for _, p := range op.Params {
+			item.Parameters = append(item.Parameters, parameter{
+				Name:        p.Name,
+				In:          p.In,
+				Description: p.Description,
+				Required:    p.Required,
+				Schema:      registry.schemaForValue(p.Example),
+			})
+		}
+
+		if op.Request != nil {
+			item.RequestBody = &requestBody{
+				Required: true,
+				Content:  map[string]*mediaType{"application/json": {Schema: registry.schemaForValue(op.Request)}},
+			}
+		}
+
+		contentType := op.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		for status, body := range op.Responses {
+			resp := &response{Description: http.StatusText(status)}
+			if body != nil {
+				resp.Content = map[string]*mediaType{contentType: {Schema: registry.schemaForValue(body)}}
+			}
+			item.Responses[strconv.Itoa(status)] = resp
+		}
+		if errorBody != nil {
+			item.Responses["default"] = &response{
+				Description: "Error",
+				Content:     map[string]*mediaType{"application/json": {Schema: registry.schemaForValue(errorBody)}},
+			}
+		}
+
+		if op.Auth {
+			item.Security = []map[string][]string{{"bearerAuth": {}}}
+		}
+		if op.Deprecated != nil {
+			item.Deprecated = true
+			if !op.Deprecated.Sunset.IsZero() {
+				item.Sunset = op.Deprecated.Sunset.UTC().Format(time.RFC3339)
+			}
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*pathItem)
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = item
+	}
+
+	return doc
+}
+
+// JSON renders the document as indented JSON with a trailing newline
+func (d *Document) JSON() ([]byte, error) {
+	out, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// RouteKey identifies a route by method and Gin path pattern
+type RouteKey struct {
+	Method string
+	Path   string
+}
+
+// Diff compares documented operations against registered routes and returns
+// routes missing from the spec and documented operations with no route
+func Diff(ops []Operation, routes []RouteKey) (undocumented, unregistered []RouteKey) {
+	documented := make(map[RouteKey]bool, len(ops))
+	for _, op := range ops {
+		documented[RouteKey{Method: op.Method, Path: op.Path}] = true
+	}
+
+	registered := make(map[RouteKey]bool, len(routes))
+	for _, r := range routes {
+		registered[r] = true
+		if !documented[r] {
+			undocumented = append(undocumented, r)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			unregistered = append(unregistered, key)
+		}
+	}
+
+	sortKeys(undocumented)
+	sortKeys(unregistered)
+	return undocumented, unregistered
+}
+
+func sortKeys(keys []RouteKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Path != keys[j].Path {
+			return keys[i].Path < keys[j].Path
+		}
+		return keys[i].Method < keys[j].Method
+	})
+}
+
+// convertPath turns a Gin pattern (/contests/:id) into an OpenAPI path (/contests/{id})
+func convertPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			name := seg[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable operation ID such as getApiContestsId
+func operationID(op Operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	for _, seg := range strings.Split(op.Path, "/") {
+		seg = strings.TrimLeft(seg, ":*")
+		for _, part := range strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ValidateResponse checks an actual response against the documented operation.
+// Success statuses must be documented; error statuses fall back to the default
+// error envelope. JSON bodies must match the response schema: properties the
+// schema does not declare are reported as drift, while absent properties are
+// allowed because omitempty fields may be left out.
+func (d *Document) ValidateResponse(method, ginPath string, status int, body []byte) error {
+	path, _ := convertPath(ginPath)
+	item, ok := d.Paths[path][strings.ToLower(method)]
+	if !ok {
+		return fmt.Errorf("%s %s is not documented", method, ginPath)
+	}
+
+	resp, ok := item.Responses[strconv.Itoa(status)]
+	if !ok {
+		if status < 400 {
+			return fmt.Errorf("status %d is not documented", status)
+		}
+		if resp, ok = item.Responses["default"]; !ok {
+			return fmt.Errorf("error status %d is not documented and there is no default response", status)
+		}
+	}
+
+	if resp.Content == nil {
+		if len(body) > 0 {
+			return fmt.Errorf("status %d is documented without a body but returned %d bytes", status, len(body))
+		}
+		return nil
+	}
+	media, ok := resp.Content["application/json"]
+	if !ok {
+		return nil // Non-JSON bodies (e.g. the HTML docs page) are not schema-checked
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("body is not valid JSON: %w", err)
+	}
+
+	var violations []string
+	d.validateValue(media.Schema, value, "$", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return errors.New(strings.Join(violations, "; "))
+}
+
+// validateValue appends every mismatch between value and schema to violations
+func (d *Document) validateValue(schema *Schema, value interface{}, at string, violations *[]string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := d.Components.Schemas[name]
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: unknown schema %s", at, schema.Ref))
+			return
+		}
+		schema = resolved
+	}
+	if schema.Type == "" {
+		return // Any JSON value
+	}
+
+	if value == nil {
+		// Nil Go slices and maps encode as null, so containers accept it too
+		if !schema.Nullable && schema.Type != "array" && schema.Type != "object" {
+			*violations = append(*violations, fmt.Sprintf("%s: null for non-nullable %s", at, schema.Type))
+		}
+		return
+	}
+
+	mismatch := func() {
+		*violations = append(*violations, fmt.Sprintf("%s: expected %s, got %s", at, schema.Type, jsonType(value)))
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		d.validateObject(schema, obj, at, violations)
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		for i, item := range arr {
+			d.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", at, i), violations)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			mismatch()
+			return
+		}
+		if msg := checkFormat(schema.Format, s); msg != "" {
+			*violations = append(*violations, fmt.Sprintf("%s: %s", at, msg))
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok {
+			mismatch()
+			return
+		}
+		if n != math.Trunc(n) {
+			*violations = append(*violations, fmt.Sprintf("%s: expected integer, got %v", at, n))
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			mismatch()
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			mismatch()
+		}
+	}
+}
+
+// validateObject checks the properties of an object. An object schema without
+// declared properties (a free-form object) accepts any keys.
+func (d *Document) validateObject(schema *Schema, obj map[string]interface{}, at string, violations *[]string) {
+	if len(schema.Properties) == 0 && schema.AdditionalProperties == nil {
+		return
+	}
+	for key, v := range obj {
+		if prop, ok := schema.Properties[key]; ok {
+			d.validateValue(prop, v, at+"."+key, violations)
+			continue
+		}
+		if schema.AdditionalProperties != nil {
+			d.validateValue(schema.AdditionalProperties, v, at+"."+key, violations)
+			continue
+		}
+		*violations = append(*violations, fmt.Sprintf("%s.%s: undocumented property", at, key))
+	}
+}
+
+// checkFormat validates the string formats the schema generator emits
+func checkFormat(format, s string) string {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			return fmt.Sprintf("%q is not a date-time", s)
+		}
+	case "uuid":
+		if _, err := uuid.Parse(s); err != nil {
+			return fmt.Sprintf("%q is not a uuid", s)
+		}
+	}
+	return ""
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// cohortWeekBatchSize is how many snapshot rows are inserted per statement
+const cohortWeekBatchSize = 500
+
+// analyticsRepository implements domain.AnalyticsRepository using GORM
+type analyticsRepository struct {
+	db     *gorm.DB
+	reader *gorm.DB // Serves the cohort scans, which tolerate replication lag
+}
+
+// NewAnalyticsRepository creates a new analytics repository that scans
+// signups and activity through reader
+func NewAnalyticsRepository(db, reader *gorm.DB) domain.AnalyticsRepository {
+	return &analyticsRepository{db: db, reader: reader}
+}
+
+// FindSignups returns users who signed up at or after since
+func (r *analyticsRepository) FindSignups(since time.Time) ([]domain.UserSignup, error) {
+	var signups []domain.UserSignup
+	err := r.reader.Model(&domain.User{}).
+		Select("id AS user_id, created_at").
+		Where("created_at >= ?", since).
+		Scan(&signups).Error
+	return signups, err
+}
+
+// FindActivity returns contests and solves since the given time by users who signed up since then
+func (r *analyticsRepository) FindActivity(since time.Time) ([]domain.UserActivity, error) {
+	cohortUsers := r.reader.Model(&domain.User{}).Select("id").Where("created_at >= ?", since)
+
+	var contests []struct {
+		UserID    uuid.UUID
+		CreatedAt time.Time
+		Status    domain.ContestStatus
+	}
+	if err := r.reader.Model(&domain.Contest{}).
+		Select("user_id, created_at, status").
+		Where("created_at >= ? AND user_id IN (?) AND status <> ?", since, cohortUsers, domain.ContestStatusPending).
+		Scan(&contests).Error; err != nil {
+		return nil, err
+	}
+
+	var solves []domain.UserActivity
+	if err := r.reader.Model(&domain.Submission{}).
+		Select("user_id, solved_at AS at").
+		Where("solved_at >= ? AND user_id IN (?)", since, cohortUsers).
+		Scan(&solves).Error; err != nil {
+		return nil, err
+	}
+
+	activity := make([]domain.UserActivity, 0, len(contests)+len(solves))
+	for _, c := range contests {
+		activity = append(activity, domain.UserActivity{
+			UserID:    c.UserID,
+			At:        c.CreatedAt,
+			Kind:      domain.ActivityContest,
+			Completed: c.Status == domain.ContestStatusCompleted,
+		})
+	}
+	for _, solve := range solves {
+		solve.Kind = domain.ActivitySolve
+		activity = append(activity, solve)
+	}
+	return activity, nil
+}
+
+// ReplaceCohortWeeks swaps the stored snapshot for the given rows in one transaction
+func (r *analyticsRepository) ReplaceCohortWeeks(weeks []domain.CohortWeek) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&domain.CohortWeek{}).Error; err != nil {
+			return err
+		}
+		if len(weeks) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(weeks, cohortWeekBatchSize).Error
+	})
+}
+
+// FindCohortWeeks returns the stored snapshot, newest cohort first
+func (r *analyticsRepository) FindCohortWeeks() ([]domain.CohortWeek, error) {
+	var weeks []domain.CohortWeek
+	err := r.db.Order("cohort_start DESC, week_index ASC").Find(&weeks).Error
+	return weeks, err
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *analyticsRepository) WithContext(ctx context.Context) domain.AnalyticsRepository {
+	return &analyticsRepository{db: infrastructure.DBFor(ctx, r.db), reader: infrastructure.DBFor(ctx, r.reader)}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// attemptBackfillBatch is how many attempts the submission backfill inserts per statement
+const attemptBackfillBatch = 500
+
+// attemptRepository implements domain.AttemptRepository using GORM
+type attemptRepository struct {
+	db *gorm.DB
+}
+
+// NewAttemptRepository creates a new attempt repository
+func NewAttemptRepository(db *gorm.DB) domain.AttemptRepository {
+	return &attemptRepository{db: db}
+}
+
+// Create records an attempt
+func (r *attemptRepository) Create(attempt *domain.Attempt) error {
+	return r.db.Create(attempt).Error
+}
+
+// FindByUserAndProblem returns the user's attempts at a problem, oldest first
+func (r *attemptRepository) FindByUserAndProblem(userID, problemID uuid.UUID) ([]domain.Attempt, error) {
+	var attempts []domain.Attempt
+	result := r.db.
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		Order("attempted_at ASC").
+		Find(&attempts)
+	return attempts, result.Error
+}
+
+// FindLatestInContest returns the user's latest attempt at the problem in the contest
+func (r *attemptRepository) FindLatestInContest(userID, contestID, problemID uuid.UUID) (*domain.Attempt, error) {
+	var attempt domain.Attempt
+	result := r.db.
+		Where("user_id = ? AND contest_id = ? AND problem_id = ?", userID, contestID, problemID).
+		Order("attempted_at DESC").
+		First(&attempt)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &attempt, nil
+}
+
+// Stats summarizes the user's attempts. A problem counts as solved on the first
+// attempt when no attempt at it is older than its earliest solved one.
+func (r *attemptRepository) Stats(userID uuid.UUID) (domain.AttemptStats, error) {
+	var stats domain.AttemptStats
+	result := r.db.Model(&domain.Attempt{}).
+		Select(`COUNT(*) AS total_attempts,
+			COALESCE(SUM(CASE WHEN outcome = ? THEN 1 ELSE 0 END), 0) AS failed_attempts,
+			COUNT(DISTINCT CASE WHEN outcome = ? THEN problem_id END) AS solved_problems`,
+			domain.AttemptFailed, domain.AttemptSolved).
+		Where("user_id = ?", userID).
+		Scan(&stats)
+	if result.Error != nil {
+		return stats, result.Error
+	}
+
+	var firstAttempt int64
+	result = r.db.Table("attempts AS a").
+		Where("a.user_id = ? AND a.outcome = ?", userID, domain.AttemptSolved).
+		Where(`NOT EXISTS (SELECT 1 FROM attempts b
+			WHERE b.user_id = a.user_id AND b.problem_id = a.problem_id AND b.attempted_at < a.attempted_at)`).
+		Distinct("a.problem_id").
+		Count(&firstAttempt)
+	if result.Error != nil {
+		return stats, result.Error
+	}
+
+	stats.SolvedOnFirstAttempt = int(firstAttempt)
+	if stats.SolvedProblems > 0 {
+		stats.FirstAttemptRate = float64(stats.SolvedOnFirstAttempt) / float64(stats.SolvedProblems)
+	}
+	return stats, nil
+}
+
+// BackfillFromSubmissions gives solves recorded before attempts were tracked a
+// solved attempt at their solve time, so the first-attempt stats include them
+func (r *attemptRepository) BackfillFromSubmissions() (int64, error) {
+	var submissions []domain.Submission
+	err := r.db.
+		Select("user_id", "problem_id", "contest_id", "solved_at").
+		Where(`NOT EXISTS (SELECT 1 FROM attempts
+			WHERE attempts.user_id = submissions.user_id AND attempts.problem_id = submissions.problem_id)`).
+		Find(&submissions).Error
+	if err != nil || len(submissions) == 0 {
+		return 0, err
+	}
+
+	attempts := make([]domain.Attempt, len(submissions))
+	for i, sub := range submissions {
+		attempts[i] = domain.Attempt{
+			UserID:      sub.UserID,
+			ProblemID:   sub.ProblemID,
+			ContestID:   sub.ContestID,
+			Outcome:     domain.AttemptSolved,
+			AttemptedAt: sub.SolvedAt,
+		}
+	}
+	result := r.db.CreateInBatches(attempts, attemptBackfillBatch)
+	return result.RowsAffected, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *attemptRepository) WithContext(ctx context.Context) domain.AttemptRepository {
+	return &attemptRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
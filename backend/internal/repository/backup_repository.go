@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// backupInsertBatch is how many rows a restore inserts per statement
+const backupInsertBatch = 200
+
+// backupModels are the models of the backed up tables. Rows are converted
+// through the model's fields, so column types survive a change of driver.
+var backupModels = map[domain.BackupTable]interface{}{
+	domain.BackupTenants:         &domain.Tenant{},
+	domain.BackupUsers:           &domain.User{},
+	domain.BackupProblems:        &domain.Problem{},
+	domain.BackupContests:        &domain.Contest{},
+	domain.BackupContestProblems: &domain.ContestProblem{},
+	domain.BackupContestTags:     &domain.ContestTag{},
+	domain.BackupSubmissions:     &domain.Submission{},
+	domain.BackupAttempts:        &domain.Attempt{},
+}
+
+// backupScopes narrow the exported rows of a table; the shared catalog is
+// seeded, while tenant catalogs were added through the API
+var backupScopes = map[domain.BackupTable][]interface{}{
+	domain.BackupProblems: {"owner_id IS NOT NULL OR tenant_id <> ?", domain.DefaultTenantID},
+}
+
+// backupRepository implements domain.BackupRepository using GORM
+type backupRepository struct {
+	db *gorm.DB
+}
+
+// NewBackupRepository creates a new backup repository
+func NewBackupRepository(db *gorm.DB) domain.BackupRepository {
+	return &backupRepository{db: db}
+}
+
+// Export reads every table inside one transaction. Postgres runs it as a
+// read-only repeatable read so all tables come from the same snapshot; SQLite
+// transactions are serializable already.
+func (r *backupRepository) Export(tables []domain.BackupTable, emit func(table domain.BackupTable, row domain.BackupRow) error) error {
+	var opts *sql.TxOptions
+	if isPostgres(r.db) {
+		opts = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range tables {
+			if err := r.exportTable(tx, table, emit); err != nil {
+				return fmt.Errorf("export %s: %w", table, err)
+			}
+		}
+		return nil
+	}, opts)
+}
+
+func (r *backupRepository) exportTable(tx *gorm.DB, table domain.BackupTable, emit func(domain.BackupTable, domain.BackupRow) error) error {
+	model, fields, err := r.backupFields(table)
+	if err != nil {
+		return err
+	}
+
+	query := tx.Model(model)
+	if scope, ok := backupScopes[table]; ok {
+		query = query.Where(scope[0], scope[1:]...)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ctx := tx.Statement.Context
+	for rows.Next() {
+		record := reflect.New(reflect.TypeOf(model).Elem())
+		if err := tx.ScanRows(rows, record.Interface()); err != nil {
+			return err
+		}
+		row := make(domain.BackupRow, len(fields))
+		for _, field := range fields {
+			value, _ := field.ValueOf(ctx, record.Elem())
+			if field.Serializer != nil {
+				// Serialized fields come back wrapped in their serializer; the
+				// field itself encodes to what the import decodes
+				value = record.Elem().FieldByIndex(field.StructField.Index).Interface()
+			}
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("encode %s.%s: %w", table, field.DBName, err)
+			}
+			row[field.DBName] = raw
+		}
+		if err := emit(table, row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CatalogSlugs maps every problem ID of the shared catalog to its slug
+func (r *backupRepository) CatalogSlugs() (map[uuid.UUID]string, error) {
+	var problems []domain.Problem
+	err := r.db.Select("id", "slug").Where("owner_id IS NULL AND tenant_id = ?", domain.DefaultTenantID).Find(&problems).Error
+	if err != nil {
+		return nil, err
+	}
+	slugs := make(map[uuid.UUID]string, len(problems))
+	for _, p := range problems {
+		slugs[p.ID] = p.Slug
+	}
+	return slugs, nil
+}
+
+// HasUsers reports whether any user exists
+func (r *backupRepository) HasUsers() (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.User{}).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// Import inserts every table in one transaction, so a failed restore leaves
+// the database as it was. Columns the backup lacks keep their zero value and
+// columns the schema no longer has are ignored.
+func (r *backupRepository) Import(tables []domain.BackupTable, source domain.BackupSource) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ctx := tx.Statement.Context
+		for _, table := range tables {
+			model, fields, err := r.backupFields(table)
+			if err != nil {
+				return err
+			}
+			modelType := reflect.TypeOf(model).Elem()
+
+			err = source(table, func(rows []domain.BackupRow) error {
+				records := reflect.MakeSlice(reflect.SliceOf(modelType), len(rows), len(rows))
+				for i, row := range rows {
+					record := records.Index(i)
+					for _, field := range fields {
+						raw, ok := row[field.DBName]
+						if !ok || !field.Creatable {
+							continue
+						}
+						value := reflect.New(field.FieldType)
+						if err := json.Unmarshal(raw, value.Interface()); err != nil {
+							return fmt.Errorf("decode %s.%s: %w", table, field.DBName, err)
+						}
+						if err := field.Set(ctx, record, value.Elem().Interface()); err != nil {
+							return fmt.Errorf("set %s.%s: %w", table, field.DBName, err)
+						}
+					}
+				}
+				if len(rows) == 0 {
+					return nil
+				}
+				pointer := reflect.New(records.Type())
+				pointer.Elem().Set(records)
+				return tx.Omit(clause.Associations).CreateInBatches(pointer.Interface(), backupInsertBatch).Error
+			})
+			if err != nil {
+				return fmt.Errorf("import %s: %w", table, err)
+			}
+		}
+		return nil
+	})
+}
+
+// backupFields returns the model of table and its column fields
+func (r *backupRepository) backupFields(table domain.BackupTable) (interface{}, []*schema.Field, error) {
+	model, ok := backupModels[table]
+	if !ok {
+		return nil, nil, fmt.Errorf("table %s is not backed up", table)
+	}
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, nil, err
+	}
+	fields := make([]*schema.Field, 0, len(stmt.Schema.Fields))
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName != "" && field.Readable {
+			fields = append(fields, field)
+		}
+	}
+	return model, fields, nil
+}
+
+// Driver returns the name of the database driver
+func (r *backupRepository) Driver() string {
+	return r.db.Dialector.Name()
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *backupRepository) WithContext(ctx context.Context) domain.BackupRepository {
+	return &backupRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
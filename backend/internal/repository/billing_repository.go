@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// billingRepository implements domain.BillingRepository using GORM
+type billingRepository struct {
+	db *gorm.DB
+}
+
+// NewBillingRepository creates a new billing repository
+func NewBillingRepository(db *gorm.DB) domain.BillingRepository {
+	return &billingRepository{db: db}
+}
+
+// FindUserByCustomerID returns the user linked to the provider's customer
+func (r *billingRepository) FindUserByCustomerID(customerID string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.Where("billing_customer_id = ? AND billing_customer_id <> ''", customerID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUserBilling stores the state in one conditional update, so an event
+// delivered late never overwrites the state of a newer one
+func (r *billingRepository) UpdateUserBilling(userID uuid.UUID, state domain.BillingState) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND (billing_event_at IS NULL OR billing_event_at <= ?)", userID, state.EventAt).
+		Updates(map[string]interface{}{
+			"plan":                state.Plan,
+			"billing_customer_id": state.CustomerID,
+			"subscription_id":     state.SubscriptionID,
+			"subscription_status": state.SubscriptionStatus,
+			"plan_renews_at":      state.PlanRenewsAt,
+			"billing_event_at":    state.EventAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// HasEvent reports whether the event was already processed
+func (r *billingRepository) HasEvent(id string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.BillingEvent{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// SaveEvent records a processed event; recording it twice is not an error
+func (r *billingRepository) SaveEvent(event *domain.BillingEvent) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(event).Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *billingRepository) WithContext(ctx context.Context) domain.BillingRepository {
+	return &billingRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
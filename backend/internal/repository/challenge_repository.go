@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// challengeRepository implements domain.ChallengeRepository using GORM
+type challengeRepository struct {
+	db *gorm.DB
+}
+
+// NewChallengeRepository creates a new challenge repository
+func NewChallengeRepository(db *gorm.DB) domain.ChallengeRepository {
+	return &challengeRepository{db: db}
+}
+
+// Create creates a new challenge in the database
+func (r *challengeRepository) Create(challenge *domain.ContestChallenge) error {
+	return r.db.Create(challenge).Error
+}
+
+// FindByCode finds a challenge by its invite code
+func (r *challengeRepository) FindByCode(code string) (*domain.ContestChallenge, error) {
+	var challenge domain.ContestChallenge
+	result := r.db.Where("code = ?", code).First(&challenge)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrChallengeNotFound
+		}
+		return nil, result.Error
+	}
+	return &challenge, nil
+}
+
+// Accept records the opponent of a challenge that nobody has accepted yet.
+// The opponent_id guard makes concurrent accepts race-safe.
+func (r *challengeRepository) Accept(id, opponentID, opponentContestID uuid.UUID, acceptedAt time.Time) (bool, error) {
+	result := r.db.Model(&domain.ContestChallenge{}).
+		Where("id = ? AND opponent_id IS NULL", id).
+		Updates(map[string]interface{}{
+			"opponent_id":         opponentID,
+			"opponent_contest_id": opponentContestID,
+			"accepted_at":         acceptedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindBySpectatorCode finds a challenge by its open spectator invite code
+func (r *challengeRepository) FindBySpectatorCode(code string) (*domain.ContestChallenge, error) {
+	var challenge domain.ContestChallenge
+	result := r.db.Where("spectator_code = ?", code).First(&challenge)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrChallengeNotFound
+		}
+		return nil, result.Error
+	}
+	return &challenge, nil
+}
+
+// SetSpectatorInvite replaces the challenge's spectator invite
+func (r *challengeRepository) SetSpectatorInvite(id uuid.UUID, code *string, anonymize bool) error {
+	return r.db.Model(&domain.ContestChallenge{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"spectator_code":      code,
+			"anonymize_spectated": anonymize,
+		}).Error
+}
+
+// FindFriends lists the other participants of the user's accepted challenges, once each
+func (r *challengeRepository) FindFriends(userID uuid.UUID) ([]uuid.UUID, error) {
+	friends := []uuid.UUID{}
+	err := r.db.Raw(`SELECT opponent_id FROM contest_challenges WHERE challenger_id = ? AND opponent_id IS NOT NULL
+		UNION SELECT challenger_id FROM contest_challenges WHERE opponent_id = ?`, userID, userID).
+		Scan(&friends).Error
+	return friends, err
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *challengeRepository) WithContext(ctx context.Context) domain.ChallengeRepository {
+	return &challengeRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// chatRepository implements domain.ChatRepository using GORM
+type chatRepository struct {
+	db *gorm.DB
+}
+
+// NewChatRepository creates a new chat repository
+func NewChatRepository(db *gorm.DB) domain.ChatRepository {
+	return &chatRepository{db: db}
+}
+
+// Create stores a chat message
+func (r *chatRepository) Create(message *domain.ChatMessage) error {
+	return r.db.Create(message).Error
+}
+
+// FindByChallenge returns a challenge's messages oldest first. Without since it
+// returns the latest limit messages, so a client opening the chat sees the
+// recent conversation; with since it returns the next ones after it.
+func (r *chatRepository) FindByChallenge(challengeID uuid.UUID, since *time.Time, limit int) ([]domain.ChatMessage, error) {
+	var messages []domain.ChatMessage
+	query := r.db.Preload("User").Where("challenge_id = ?", challengeID)
+	if since != nil {
+		result := query.Where("created_at > ?", *since).Order("created_at ASC").Limit(limit).Find(&messages)
+		return messages, result.Error
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *chatRepository) WithContext(ctx context.Context) domain.ChatRepository {
+	return &chatRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
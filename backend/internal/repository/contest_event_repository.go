@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// contestEventRepository implements domain.ContestEventRepository using GORM
+type contestEventRepository struct {
+	db *gorm.DB
+}
+
+// NewContestEventRepository creates a new contest event repository
+func NewContestEventRepository(db *gorm.DB) domain.ContestEventRepository {
+	return &contestEventRepository{db: db}
+}
+
+// Create stores a batch of events in one statement
+func (r *contestEventRepository) Create(events []domain.ContestEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Omit("Contest").Create(&events).Error
+}
+
+// Count counts a contest's stored events
+func (r *contestEventRepository) Count(contestID uuid.UUID) (int64, error) {
+	var count int64
+	result := r.db.Model(&domain.ContestEvent{}).Where("contest_id = ?", contestID).Count(&count)
+	return count, result.Error
+}
+
+// FindByContest lists a contest's events in the order they occurred
+func (r *contestEventRepository) FindByContest(contestID uuid.UUID) ([]domain.ContestEvent, error) {
+	var events []domain.ContestEvent
+	result := r.db.
+		Where("contest_id = ?", contestID).
+		Order("occurred_at, received_at, id").
+		Find(&events)
+	return events, result.Error
+}
+
+// FindCounts counts focus losses and tab switches per contest; contests
+// without events are absent
+func (r *contestEventRepository) FindCounts(contestIDs []uuid.UUID) (map[uuid.UUID]domain.ProctorCounts, error) {
+	counts := make(map[uuid.UUID]domain.ProctorCounts, len(contestIDs))
+	if len(contestIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		ContestID uuid.UUID
+		domain.ProctorCounts
+	}
+	err := r.db.Model(&domain.ContestEvent{}).
+		Select(`contest_id,
+			COUNT(CASE WHEN type = ? THEN 1 END) AS focus_losses,
+			COUNT(CASE WHEN type = ? THEN 1 END) AS tab_switches`, domain.ProctorFocusLost, domain.ProctorTabHidden).
+		Where("contest_id IN ?", contestIDs).
+		Group("contest_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ContestID] = row.ProctorCounts
+	}
+	return counts, nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *contestEventRepository) WithContext(ctx context.Context) domain.ContestEventRepository {
+	return &contestEventRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -3,13 +3,22 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
+// finishedStatuses are the statuses of contests that are over
+var finishedStatuses = []domain.ContestStatus{domain.ContestStatusCompleted, domain.ContestStatusAbandoned}
+
 // contestRepository implements domain.ContestRepository using GORM
 type contestRepository struct {
 	db *gorm.DB
@@ -20,9 +29,19 @@ func NewContestRepository(db *gorm.DB) domain.ContestRepository {
 	return &contestRepository{db: db}
 }
 
-// Create creates a new contest in the database
+// Create creates a new contest in the database and counts it in the user's
+// progress summary in the same transaction. Pending contests are counted once
+// they are activated.
 func (r *contestRepository) Create(contest *domain.Contest) error {
-	return r.db.Create(contest).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contest).Error; err != nil {
+			return err
+		}
+		if contest.Status == domain.ContestStatusPending {
+			return nil
+		}
+		return addProgress(tx, contest.UserID, map[string]int{"total_contests": 1}, contest.CreatedAt)
+	})
 }
 
 // FindByID finds a contest by its ID (without problems)
@@ -43,12 +62,16 @@ func (r *contestRepository) FindByIDWithProblems(id uuid.UUID) (*domain.Contest,
 	var contest domain.Contest
 	result := r.db.
 		Preload("ContestProblems", func(db *gorm.DB) *gorm.DB {
-			return db.Order("contest_problems.order ASC")
+			return db.Order(`contest_problems."order" ASC`)
 		}).
 		Preload("ContestProblems.Problem").
+		Preload("WarmupProblem").
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tag ASC")
+		}).
 		Where("id = ?", id).
 		First(&contest)
-	
+
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrContestNotFound
@@ -58,18 +81,63 @@ func (r *contestRepository) FindByIDWithProblems(id uuid.UUID) (*domain.Contest,
 	return &contest, nil
 }
 
-// FindByUserID returns all contests for a user ordered by creation date
-func (r *contestRepository) FindByUserID(userID uuid.UUID) ([]domain.Contest, error) {
+// FindByUserID returns the contests of a user matching the filter, ordered by creation date
+func (r *contestRepository) FindByUserID(userID uuid.UUID, filter domain.ContestFilter) ([]domain.Contest, error) {
 	var contests []domain.Contest
-	result := r.db.
+	query := r.db.
 		Preload("ContestProblems", func(db *gorm.DB) *gorm.DB {
-			return db.Order("contest_problems.order ASC")
+			return db.Order(`contest_problems."order" ASC`)
 		}).
 		Preload("ContestProblems.Problem").
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Find(&contests)
-	
+		Preload("WarmupProblem").
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tag ASC")
+		}).
+		Where("user_id = ?", userID)
+
+	if filter.Tag != "" {
+		query = query.Where("id IN (?)", r.db.Model(&domain.ContestTag{}).Select("contest_id").Where("tag = ?", filter.Tag))
+	}
+	if filter.Query != "" {
+		query = query.Where("LOWER(retro) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(filter.Query))+"%")
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+	if filter.MinDuration > 0 {
+		query = query.Where("duration_minutes >= ?", filter.MinDuration)
+	}
+	if filter.MaxDuration > 0 {
+		query = query.Where("duration_minutes <= ?", filter.MaxDuration)
+	}
+	if filter.MinScore != nil || filter.MaxScore != nil {
+		solved := r.db.Model(&domain.ContestProblem{}).Select("COUNT(*)").
+			Where("contest_problems.contest_id = contests.id AND contest_problems.is_completed = ? AND contest_problems.is_warmup = ?", true, false)
+		if filter.MinScore != nil {
+			query = query.Where("(?) >= ?", solved, *filter.MinScore)
+		}
+		if filter.MaxScore != nil {
+			query = query.Where("(?) <= ?", solved, *filter.MaxScore)
+		}
+	}
+	if filter.Problem != "" {
+		problems := r.db.Model(&domain.ContestProblem{}).Select("contest_id")
+		if problemID, err := uuid.Parse(filter.Problem); err == nil {
+			problems = problems.Where("problem_id = ?", problemID)
+		} else {
+			problems = problems.Where("problem_id IN (?)", r.db.Model(&domain.Problem{}).Select("id").Where("slug = ?", filter.Problem))
+		}
+		query = query.Where("id IN (?)", problems)
+	}
+
+	result := query.Order("created_at DESC").Find(&contests)
+
 	return contests, result.Error
 }
 
@@ -78,12 +146,16 @@ func (r *contestRepository) FindActiveByUserID(userID uuid.UUID) (*domain.Contes
 	var contest domain.Contest
 	result := r.db.
 		Preload("ContestProblems", func(db *gorm.DB) *gorm.DB {
-			return db.Order("contest_problems.order ASC")
+			return db.Order(`contest_problems."order" ASC`)
 		}).
 		Preload("ContestProblems.Problem").
+		Preload("WarmupProblem").
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tag ASC")
+		}).
 		Where("user_id = ? AND status = ?", userID, domain.ContestStatusActive).
 		First(&contest)
-	
+
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil // No active contest is not an error
@@ -93,33 +165,334 @@ func (r *contestRepository) FindActiveByUserID(userID uuid.UUID) (*domain.Contes
 	return &contest, nil
 }
 
-// Update updates an existing contest
+// CountCreatedSince counts the contests the user created at or after since,
+// finished or not, leaving out pending ones
+func (r *contestRepository) CountCreatedSince(userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	result := r.db.Model(&domain.Contest{}).
+		Where("user_id = ? AND created_at >= ? AND status <> ?", userID, since, domain.ContestStatusPending).
+		Count(&count)
+	return count, result.Error
+}
+
+// FindExpiredActive returns active contests whose timer ran out before now.
+// Contest problems are loaded (without problem details) so activity can be inspected.
+func (r *contestRepository) FindExpiredActive(now time.Time) ([]domain.Contest, error) {
+	var started []domain.Contest
+	result := r.db.
+		Preload("ContestProblems").
+		Where("status = ? AND started_at < ?", domain.ContestStatusActive, now).
+		Find(&started)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// End times are compared in Go to keep the query portable across databases
+	contests := started[:0]
+	for _, c := range started {
+		if c.EndTime().Before(now) {
+			contests = append(contests, c)
+		}
+	}
+	return contests, nil
+}
+
+// Update updates an existing contest row; loaded associations are not saved
 func (r *contestRepository) Update(contest *domain.Contest) error {
-	return r.db.Save(contest).Error
+	return r.db.Omit(clause.Associations).Save(contest).Error
+}
+
+// Finish stores the contest's final status and end time if it is still active
+// and counts it as completed or abandoned in the same transaction
+func (r *contestRepository) Finish(contest *domain.Contest) (bool, error) {
+	var column string
+	switch contest.Status {
+	case domain.ContestStatusCompleted:
+		column = "completed_contests"
+	case domain.ContestStatusAbandoned:
+		column = "abandoned_contests"
+	default:
+		return false, fmt.Errorf("cannot finish a contest as %q", contest.Status)
+	}
+
+	var finished bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Contest{}).
+			Where("id = ? AND status = ?", contest.ID, domain.ContestStatusActive).
+			Updates(map[string]interface{}{"status": contest.Status, "ended_at": contest.EndedAt})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		finished = true
+		return addProgress(tx, contest.UserID, map[string]int{column: 1}, *contest.EndedAt)
+	})
+	return finished && err == nil, err
+}
+
+// Activate moves a pending contest to active and counts it in the user's
+// progress summary in the same transaction, as of its creation like Create does
+func (r *contestRepository) Activate(contest *domain.Contest, startedAt time.Time) (bool, error) {
+	var activated bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Contest{}).
+			Where("id = ? AND status = ?", contest.ID, domain.ContestStatusPending).
+			Updates(map[string]interface{}{"status": domain.ContestStatusActive, "started_at": startedAt})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		activated = true
+		return addProgress(tx, contest.UserID, map[string]int{"total_contests": 1}, contest.CreatedAt)
+	})
+	return activated && err == nil, err
+}
+
+// SetWarmupCompleted marks the contest's warmup problem as completed or not completed
+func (r *contestRepository) SetWarmupCompleted(contestID uuid.UUID, completed bool) error {
+	return r.db.Model(&domain.Contest{}).
+		Where("id = ?", contestID).
+		Update("warmup_completed", completed).Error
+}
+
+// StartTimer moves the start of the contest timer, ending any warmup still in progress
+func (r *contestRepository) StartTimer(contestID uuid.UUID, startedAt time.Time) error {
+	return r.db.Model(&domain.Contest{}).
+		Where("id = ?", contestID).
+		Update("started_at", startedAt).Error
+}
+
+// UpdateRetro saves the retro notes of a contest
+func (r *contestRepository) UpdateRetro(contestID uuid.UUID, retro string, updatedAt time.Time) error {
+	return r.db.Model(&domain.Contest{}).
+		Where("id = ?", contestID).
+		Updates(map[string]interface{}{"retro": retro, "retro_updated_at": updatedAt}).Error
+}
+
+// UpdateRating stores the difficulty rating of a contest, replacing an earlier one
+func (r *contestRepository) UpdateRating(contestID uuid.UUID, rating int, ratedAt time.Time) error {
+	return r.db.Model(&domain.Contest{}).
+		Where("id = ?", contestID).
+		Updates(map[string]interface{}{"difficulty_rating": rating, "rated_at": ratedAt}).Error
+}
+
+// FindRecentRatings lists the user's latest ratings of the profile through
+// idx_contests_user_profile
+func (r *contestRepository) FindRecentRatings(userID uuid.UUID, profile domain.DistributionProfile, limit int) ([]int, error) {
+	ratings := []int{}
+	err := r.db.Model(&domain.Contest{}).
+		Where("user_id = ? AND distribution_profile = ? AND difficulty_rating IS NOT NULL", userID, profile).
+		Order("rated_at DESC").
+		Limit(limit).
+		Pluck("difficulty_rating", &ratings).Error
+	return ratings, err
+}
+
+// SetTags replaces the tags of a contest
+func (r *contestRepository) SetTags(contestID uuid.UUID, tags []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.ContestTag{}, "contest_id = ?", contestID).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+
+		rows := make([]domain.ContestTag, len(tags))
+		for i, tag := range tags {
+			rows[i] = domain.ContestTag{ContestID: contestID, Tag: tag}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// FindTagsByUserID returns the user's tags starting with prefix, most used first
+func (r *contestRepository) FindTagsByUserID(userID uuid.UUID, prefix string, limit int) ([]domain.TagCount, error) {
+	var tags []domain.TagCount
+	query := r.db.Model(&domain.ContestTag{}).
+		Select("contest_tags.tag AS tag, COUNT(*) AS count").
+		Joins("JOIN contests ON contests.id = contest_tags.contest_id").
+		Where("contests.user_id = ?", userID)
+
+	if prefix != "" {
+		query = query.Where("contest_tags.tag LIKE ? ESCAPE '\\'", escapeLike(prefix)+"%")
+	}
+
+	result := query.
+		Group("contest_tags.tag").
+		Order("count DESC, tag ASC").
+		Limit(limit).
+		Scan(&tags)
+	return tags, result.Error
 }
 
-// UpdateProblemStatus marks a problem as completed or not completed
-func (r *contestRepository) UpdateProblemStatus(contestID, problemID uuid.UUID, isCompleted bool) error {
+// UpdateProblemStatus marks a problem as completed or not completed.
+// It reports whether the status actually changed.
+func (r *contestRepository) UpdateProblemStatus(contestID, problemID uuid.UUID, isCompleted bool) (bool, error) {
 	result := r.db.Model(&domain.ContestProblem{}).
-		Where("contest_id = ? AND problem_id = ?", contestID, problemID).
+		Where("contest_id = ? AND problem_id = ? AND is_completed <> ?", contestID, problemID, isCompleted).
 		Update("is_completed", isCompleted)
-	
+
 	if result.Error != nil {
-		return result.Error
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// Nothing updated: either already in the requested state or not in the contest
+	var count int64
+	if err := r.db.Model(&domain.ContestProblem{}).
+		Where("contest_id = ? AND problem_id = ?", contestID, problemID).
+		Count(&count).Error; err != nil {
+		return false, err
 	}
-	if result.RowsAffected == 0 {
+	if count == 0 {
+		return false, domain.ErrProblemNotInContest
+	}
+	return false, nil
+}
+
+// SetStatedComplexity stores the complexity stated for a completed contest problem
+func (r *contestRepository) SetStatedComplexity(contestID, problemID uuid.UUID, timeComplexity, spaceComplexity string) error {
+	var cp domain.ContestProblem
+	err := r.db.Where("contest_id = ? AND problem_id = ?", contestID, problemID).First(&cp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return domain.ErrProblemNotInContest
 	}
+	if err != nil {
+		return err
+	}
+	if !cp.IsCompleted {
+		return domain.ErrProblemNotCompleted
+	}
+
+	return r.db.Model(&domain.ContestProblem{}).
+		Where("contest_id = ? AND problem_id = ?", contestID, problemID).
+		UpdateColumns(map[string]interface{}{
+			"stated_time_complexity":  timeComplexity,
+			"stated_space_complexity": spaceComplexity,
+		}).Error
+}
+
+// StartProblemTimer stops the timer of any other problem of the contest and starts
+// the problem's one; a timer already running keeps its start
+func (r *contestRepository) StartProblemTimer(contestID, problemID uuid.UUID, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		others := func(cp *domain.ContestProblem) bool { return cp.ProblemID != problemID }
+		if err := stopProblemTimers(tx, contestID, at, others); err != nil {
+			return err
+		}
+		return tx.Model(&domain.ContestProblem{}).
+			Where("contest_id = ? AND problem_id = ? AND timer_started_at IS NULL", contestID, problemID).
+			Update("timer_started_at", at).Error
+	})
+}
+
+// StopProblemTimers stops the running timers of the contest, or only the
+// problem's one when problemID is set
+func (r *contestRepository) StopProblemTimers(contestID uuid.UUID, problemID *uuid.UUID, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		selected := func(cp *domain.ContestProblem) bool { return problemID == nil || cp.ProblemID == *problemID }
+		return stopProblemTimers(tx, contestID, at, selected)
+	})
+}
+
+// stopProblemTimers adds the running stretch of the selected timers of the
+// contest to their time spent and clears them. The update is conditional on
+// the start it read, so a concurrent stop cannot count a stretch twice.
+func stopProblemTimers(tx *gorm.DB, contestID uuid.UUID, at time.Time, selected func(*domain.ContestProblem) bool) error {
+	var running []domain.ContestProblem
+	if err := tx.Where("contest_id = ? AND timer_started_at IS NOT NULL", contestID).Find(&running).Error; err != nil {
+		return err
+	}
+	for i := range running {
+		cp := &running[i]
+		if !selected(cp) {
+			continue
+		}
+		err := tx.Model(&domain.ContestProblem{}).
+			Where("contest_id = ? AND problem_id = ? AND timer_started_at = ?", contestID, cp.ProblemID, *cp.TimerStartedAt).
+			UpdateColumns(map[string]interface{}{
+				"time_spent_seconds": cp.TimeSpent(at),
+				"timer_started_at":   nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// FindSolveTimes averages the time spent on the user's completed, timed contest
+// problems per difficulty in one GROUP BY query; the solved warm-up is left out
+func (r *contestRepository) FindSolveTimes(userID uuid.UUID) (domain.SolveTimeStats, error) {
+	var rows []struct {
+		Difficulty     domain.Difficulty
+		TimedSolves    int
+		AverageSeconds float64
+	}
+	result := r.db.Model(&domain.ContestProblem{}).
+		Select("problems.difficulty, COUNT(*) AS timed_solves, AVG(contest_problems.time_spent_seconds) AS average_seconds").
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Joins("JOIN problems ON problems.id = contest_problems.problem_id").
+		Where("contests.user_id = ? AND contest_problems.is_completed = ? AND contest_problems.is_warmup = ?", userID, true, false).
+		Where("contest_problems.time_spent_seconds > 0").
+		Group("problems.difficulty").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	stats := make(domain.SolveTimeStats, len(rows))
+	for _, row := range rows {
+		stats[row.Difficulty] = domain.SolveTiming{
+			TimedSolves:    row.TimedSolves,
+			AverageSeconds: int(math.Round(row.AverageSeconds)),
+		}
+	}
+	return stats, nil
+}
+
+// FindTopicHistory counts the scored problems of each topic served in the
+// user's finished contests in a single GROUP BY query. A problem counts once
+// under each of its topics.
+func (r *contestRepository) FindTopicHistory(userID uuid.UUID) ([]domain.TopicContestStats, error) {
+	topics, topic := topicsJoin(r.db)
+
+	var stats []domain.TopicContestStats
+	result := r.db.Model(&domain.ContestProblem{}).
+		Select(topic+` AS topic, COUNT(*) AS appearances,
+			COUNT(DISTINCT contest_problems.contest_id) AS contests,
+			SUM(CASE WHEN contest_problems.is_completed THEN 1 ELSE 0 END) AS completed`).
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Joins("JOIN problems ON problems.id = contest_problems.problem_id").
+		Joins(topics).
+		Where("contests.user_id = ? AND contests.status IN ? AND contest_problems.is_warmup = ?",
+			userID, []domain.ContestStatus{domain.ContestStatusCompleted, domain.ContestStatusAbandoned}, false).
+		Group(topic).
+		Order("appearances DESC, topic ASC").
+		Scan(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range stats {
+		if stats[i].Appearances > 0 {
+			stats[i].CompletionRate = float64(stats[i].Completed) / float64(stats[i].Appearances)
+		}
+	}
+	return stats, nil
+}
+
 // Delete deletes a contest by its ID
 func (r *contestRepository) Delete(id uuid.UUID) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Delete contest problems first (cascade)
+		// Delete contest problems and tags first (cascade)
 		if err := tx.Delete(&domain.ContestProblem{}, "contest_id = ?", id).Error; err != nil {
 			return err
 		}
+		if err := tx.Delete(&domain.ContestTag{}, "contest_id = ?", id).Error; err != nil {
+			return err
+		}
 		// Delete the contest
 		result := tx.Delete(&domain.Contest{}, "id = ?", id)
 		if result.Error != nil {
@@ -140,7 +513,32 @@ func (r *contestRepository) AddProblems(contestID uuid.UUID, problems []domain.C
 	return r.db.Create(&problems).Error
 }
 
-// WithContext returns a repository with the given context for tracing
+// FindVariantOutcomes counts contests by status and problems by completion for
+// each variant of the experiment; rates are left for the caller to derive
+func (r *contestRepository) FindVariantOutcomes(experiment string) ([]domain.VariantOutcome, error) {
+	var outcomes []domain.VariantOutcome
+	result := r.db.Model(&domain.Contest{}).
+		Select(`contests.variant AS variant,
+			COUNT(DISTINCT contests.id) AS contests,
+			COUNT(DISTINCT CASE WHEN contests.status = ? THEN contests.id END) AS completed,
+			COUNT(DISTINCT CASE WHEN contests.status = ? THEN contests.id END) AS abandoned,
+			COUNT(contest_problems.problem_id) AS problems_served,
+			COALESCE(SUM(CASE WHEN contest_problems.is_completed THEN 1 ELSE 0 END), 0) AS problems_solved`,
+			domain.ContestStatusCompleted, domain.ContestStatusAbandoned).
+		Joins("LEFT JOIN contest_problems ON contest_problems.contest_id = contests.id AND NOT contest_problems.is_warmup").
+		Where("contests.experiment = ?", experiment).
+		Group("contests.variant").
+		Scan(&outcomes)
+	return outcomes, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
 func (r *contestRepository) WithContext(ctx context.Context) domain.ContestRepository {
-	return &contestRepository{db: r.db.WithContext(ctx)}
+	return &contestRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// contestSessionRepository implements domain.ContestSessionRepository using GORM
+type contestSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewContestSessionRepository creates a new contest session repository
+func NewContestSessionRepository(db *gorm.DB) domain.ContestSessionRepository {
+	return &contestSessionRepository{db: db}
+}
+
+// FindByContestID finds the session of a contest, or returns nil, nil before its first save
+func (r *contestSessionRepository) FindByContestID(contestID uuid.UUID) (*domain.ContestSession, error) {
+	var session domain.ContestSession
+	result := r.db.Where("contest_id = ?", contestID).First(&session)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// Save stores the session as version expectedVersion+1. The first save inserts
+// the row and later ones update it only while it is still at expectedVersion,
+// so of two devices saving the same version one wins and the other gets false.
+func (r *contestSessionRepository) Save(session *domain.ContestSession, expectedVersion int) (bool, error) {
+	session.Version = expectedVersion + 1
+
+	if expectedVersion == 0 {
+		result := r.db.Omit("Contest").Clauses(clause.OnConflict{DoNothing: true}).Create(session)
+		return result.RowsAffected > 0, result.Error
+	}
+
+	result := r.db.Model(session).
+		Where("version = ?", expectedVersion).
+		Select("current_problem_id", "notes", "device", "version", "updated_at").
+		Updates(session)
+	return result.RowsAffected > 0, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *contestSessionRepository) WithContext(ctx context.Context) domain.ContestSessionRepository {
+	return &contestSessionRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// isPostgres reports whether db is connected to Postgres. Queries relying on
+// Postgres-only features (array operators) need a portable fallback otherwise.
+func isPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
+
+// topicsJoin returns the join that expands problems.topics into one row per
+// topic and the column naming it. Topics are a Postgres array, and a JSON array
+// elsewhere.
+func topicsJoin(db *gorm.DB) (join, column string) {
+	if isPostgres(db) {
+		return "CROSS JOIN LATERAL unnest(problems.topics) AS topics(topic)", "topics.topic"
+	}
+	return "CROSS JOIN json_each(problems.topics) AS topics", "topics.value"
+}
+
+// sqliteTimeLayouts are the text forms SQLite returns for timestamps that lost
+// their declared column type, e.g. the result of MAX() over a UNION
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+// nullTime scans a nullable timestamp from Postgres, which returns time.Time,
+// and from SQLite, which returns text for computed columns
+type nullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner
+func (t *nullTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = nullTime{}
+		return nil
+	case time.Time:
+		*t = nullTime{Time: v, Valid: true}
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	}
+	return fmt.Errorf("cannot scan %T into a timestamp", value)
+}
+
+// Value implements driver.Valuer, which GORM requires to treat the type as a column
+func (t nullTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+func (t *nullTime) parse(s string) error {
+	for _, layout := range sqliteTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			*t = nullTime{Time: parsed, Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse timestamp %q", s)
+}
+
+// Ptr returns the time, or nil when it was NULL
+func (t nullTime) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// digestRepository implements domain.DigestRepository using GORM
+type digestRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestRepository creates a new recommendation digest repository
+func NewDigestRepository(db *gorm.DB) domain.DigestRepository {
+	return &digestRepository{db: db}
+}
+
+// FindSubscription finds the user's digest opt-in, nil when there is none
+func (r *digestRepository) FindSubscription(userID uuid.UUID) (*domain.DigestSubscription, error) {
+	var sub domain.DigestSubscription
+	result := r.db.First(&sub, "user_id = ?", userID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &sub, nil
+}
+
+// Subscribe opts the user in; an existing opt-in keeps its time and last digest
+func (r *digestRepository) Subscribe(sub *domain.DigestSubscription) error {
+	return r.db.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(sub).Error
+}
+
+// Unsubscribe opts the user out; digests already sent are kept for the statistics
+func (r *digestRepository) Unsubscribe(userID uuid.UUID) error {
+	return r.db.Delete(&domain.DigestSubscription{}, "user_id = ?", userID).Error
+}
+
+// FindDue lists up to limit subscriptions without a digest since before,
+// those never sent one first
+func (r *digestRepository) FindDue(before time.Time, limit int) ([]domain.DigestSubscription, error) {
+	var subs []domain.DigestSubscription
+	result := r.db.
+		Where("last_sent_at IS NULL OR last_sent_at < ?", before).
+		Order("last_sent_at IS NOT NULL, last_sent_at, opted_in_at").
+		Limit(limit).
+		Find(&subs)
+	return subs, result.Error
+}
+
+// Claim moves the subscription's last send time to now if it is still before
+// the given time; false means another instance claimed it or the user opted out
+func (r *digestRepository) Claim(userID uuid.UUID, before, now time.Time) (bool, error) {
+	result := r.db.Model(&domain.DigestSubscription{}).
+		Where("user_id = ? AND (last_sent_at IS NULL OR last_sent_at < ?)", userID, before).
+		Update("last_sent_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CreateDigest creates a digest with its items in one transaction
+func (r *digestRepository) CreateDigest(digest *domain.RecommendationDigest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		items := digest.Items
+		if err := tx.Omit("Items", "User").Create(digest).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].DigestID = digest.ID
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Omit("Digest", "Problem").Create(&items).Error
+	})
+}
+
+// MarkEmailed records that a digest was delivered by email
+func (r *digestRepository) MarkEmailed(digestID uuid.UUID) error {
+	return r.db.Model(&domain.RecommendationDigest{}).
+		Where("id = ?", digestID).
+		Update("channel", domain.DigestEmail).Error
+}
+
+// FindLatestDigest finds the user's most recent digest with its items in rank
+// order, nil when none was sent
+func (r *digestRepository) FindLatestDigest(userID uuid.UUID) (*domain.RecommendationDigest, error) {
+	var digest domain.RecommendationDigest
+	result := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Items.Problem").
+		Where("user_id = ?", userID).
+		Order("sent_at DESC, id").
+		First(&digest)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &digest, nil
+}
+
+// FindItemByToken finds a digest item by its click-through token, nil when unknown
+func (r *digestRepository) FindItemByToken(token string) (*domain.DigestItem, error) {
+	var item domain.DigestItem
+	result := r.db.Preload("Problem").Where("token = ?", token).First(&item)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &item, nil
+}
+
+// MarkClicked records the first click of a digest item
+func (r *digestRepository) MarkClicked(itemID uuid.UUID, at time.Time) error {
+	return r.db.Model(&domain.DigestItem{}).
+		Where("id = ? AND clicked_at IS NULL", itemID).
+		Update("clicked_at", at).Error
+}
+
+// Stats counts subscribers and, for digests sent since the given time, their
+// items, clicks and the suggested problems solved afterwards
+func (r *digestRepository) Stats(since time.Time) (*domain.DigestStats, error) {
+	stats := &domain.DigestStats{Since: since, ClicksByPosition: []int64{}}
+
+	if err := r.db.Model(&domain.DigestSubscription{}).Count(&stats.Subscribers).Error; err != nil {
+		return nil, err
+	}
+
+	var digests struct {
+		Digests int64
+		Emailed int64
+	}
+	if err := r.db.Model(&domain.RecommendationDigest{}).
+		Select("COUNT(*) AS digests, COUNT(CASE WHEN channel = ? THEN 1 END) AS emailed", domain.DigestEmail).
+		Where("sent_at >= ?", since).
+		Scan(&digests).Error; err != nil {
+		return nil, err
+	}
+	stats.Digests, stats.Emailed = digests.Digests, digests.Emailed
+
+	var items struct {
+		Items          int64
+		Clicks         int64
+		ClickedDigests int64
+		SolvedItems    int64
+	}
+	if err := r.db.Table("recommendation_digest_items i").
+		Select(`COUNT(*) AS items,
+			COUNT(i.clicked_at) AS clicks,
+			COUNT(DISTINCT CASE WHEN i.clicked_at IS NOT NULL THEN i.digest_id END) AS clicked_digests,
+			COUNT(s.id) AS solved_items`).
+		Joins("JOIN recommendation_digests d ON d.id = i.digest_id").
+		Joins("LEFT JOIN submissions s ON s.user_id = d.user_id AND s.problem_id = i.problem_id AND s.solved_at >= d.sent_at").
+		Where("d.sent_at >= ?", since).
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	stats.Items, stats.Clicks = items.Items, items.Clicks
+	stats.ClickedDigests, stats.SolvedItems = items.ClickedDigests, items.SolvedItems
+
+	var positions []struct {
+		Position int
+		Clicks   int64
+	}
+	if err := r.db.Table("recommendation_digest_items i").
+		Select("i.position, COUNT(i.clicked_at) AS clicks").
+		Joins("JOIN recommendation_digests d ON d.id = i.digest_id").
+		Where("d.sent_at >= ?", since).
+		Group("i.position").
+		Order("i.position").
+		Scan(&positions).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range positions {
+		for len(stats.ClicksByPosition) < p.Position {
+			stats.ClicksByPosition = append(stats.ClicksByPosition, 0)
+		}
+		if p.Position > 0 {
+			stats.ClicksByPosition[p.Position-1] = p.Clicks
+		}
+	}
+	return stats, nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *digestRepository) WithContext(ctx context.Context) domain.DigestRepository {
+	return &digestRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
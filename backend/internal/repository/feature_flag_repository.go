@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// featureFlagRepository implements domain.FeatureFlagRepository using GORM
+type featureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository
+func NewFeatureFlagRepository(db *gorm.DB) domain.FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+// FindAll returns every stored flag override
+func (r *featureFlagRepository) FindAll() ([]domain.FeatureFlag, error) {
+	var flags []domain.FeatureFlag
+	if err := r.db.Order("key ASC").Find(&flags).Error; err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// Save creates or replaces the override for the flag's key
+func (r *featureFlagRepository) Save(flag *domain.FeatureFlag) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "rollout_percent", "updated_by", "updated_at"}),
+	}).Create(flag).Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *featureFlagRepository) WithContext(ctx context.Context) domain.FeatureFlagRepository {
+	return &featureFlagRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
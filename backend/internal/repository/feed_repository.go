@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// feedRepository implements domain.FeedRepository using GORM
+type feedRepository struct {
+	db *gorm.DB
+}
+
+// NewFeedRepository creates a new feed repository
+func NewFeedRepository(db *gorm.DB) domain.FeedRepository {
+	return &feedRepository{db: db}
+}
+
+// Create stores the items of one activity in every feed it fans out to
+func (r *feedRepository) Create(items []domain.FeedItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.Create(&items).Error
+}
+
+// FindPage lists a page of the owner's feed through idx_feed_items_owner_id.
+// Item IDs grow with time, so the page after an item is the items with a
+// smaller ID; unlike a timestamp, the ID never ties.
+func (r *feedRepository) FindPage(ownerID uuid.UUID, before *uuid.UUID, limit int) ([]domain.FeedEntry, error) {
+	query := r.db.Model(&domain.FeedItem{}).
+		Select(`feed_items.id, feed_items.kind, feed_items.actor_id, users.username AS actor_username,
+			feed_items.contest_id, feed_items.problem_id, COALESCE(problems.title, '') AS problem_title,
+			feed_items.detail, feed_items.created_at`).
+		Joins("JOIN users ON users.id = feed_items.actor_id").
+		Joins("LEFT JOIN problems ON problems.id = feed_items.problem_id").
+		Where("feed_items.owner_id = ?", ownerID)
+	if before != nil {
+		query = query.Where("feed_items.id < ?", *before)
+	}
+
+	entries := []domain.FeedEntry{}
+	if err := query.Order("feed_items.id DESC").Limit(limit).Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Own = entries[i].ActorID == ownerID
+	}
+	return entries, nil
+}
+
+// HasAchievement reports whether the user's own feed has the milestone
+func (r *feedRepository) HasAchievement(userID uuid.UUID, detail string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.FeedItem{}).
+		Where("owner_id = ? AND actor_id = ? AND kind = ? AND detail = ?", userID, userID, domain.FeedAchievement, detail).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *feedRepository) WithContext(ctx context.Context) domain.FeedRepository {
+	return &feedRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
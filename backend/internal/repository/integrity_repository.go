@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// rowIssue selects the rows of a table that have an issue; repairing deletes them
+type rowIssue struct {
+	model     interface{}
+	condition string
+}
+
+// rowIssues are the issues repaired by deleting the offending rows. The
+// duplicate condition keeps the earliest submission, breaking ties by ID.
+var rowIssues = map[domain.IntegrityIssue]rowIssue{
+	domain.IssueDuplicateSubmissions: {&domain.Submission{}, `EXISTS (
+		SELECT 1 FROM submissions o
+		WHERE o.user_id = submissions.user_id AND o.problem_id = submissions.problem_id
+		  AND (o.solved_at < submissions.solved_at OR (o.solved_at = submissions.solved_at AND o.id < submissions.id)))`},
+	domain.IssueOrphanedSubmissions: {&domain.Submission{}, `
+		NOT EXISTS (SELECT 1 FROM users u WHERE u.id = submissions.user_id)
+		OR NOT EXISTS (SELECT 1 FROM problems p WHERE p.id = submissions.problem_id)`},
+	domain.IssueOrphanedContestProblems: {&domain.ContestProblem{}, `
+		NOT EXISTS (SELECT 1 FROM contests c WHERE c.id = contest_problems.contest_id)
+		OR NOT EXISTS (SELECT 1 FROM problems p WHERE p.id = contest_problems.problem_id)`},
+}
+
+// Usage counters as maintained from contest events: the solved warm-up is not counted
+const (
+	selectedCount  = `(SELECT COUNT(*) FROM contest_problems cp WHERE cp.problem_id = problems.id AND NOT cp.is_warmup)`
+	completedCount = `(SELECT COUNT(*) FROM contest_problems cp WHERE cp.problem_id = problems.id AND cp.is_completed AND NOT cp.is_warmup)`
+)
+
+// integrityRepository implements domain.IntegrityRepository using GORM
+type integrityRepository struct {
+	db *gorm.DB
+}
+
+// NewIntegrityRepository creates a new integrity repository
+func NewIntegrityRepository(db *gorm.DB) domain.IntegrityRepository {
+	return &integrityRepository{db: db}
+}
+
+// Count returns how many rows have the issue
+func (r *integrityRepository) Count(issue domain.IntegrityIssue) (int64, error) {
+	if ri, ok := rowIssues[issue]; ok {
+		var count int64
+		err := r.db.Model(ri.model).Where(ri.condition).Count(&count).Error
+		return count, err
+	}
+
+	switch issue {
+	case domain.IssueProgressDrift:
+		drifted, err := r.driftedSummaries()
+		return int64(len(drifted)), err
+	case domain.IssueUsageDrift:
+		var count int64
+		err := r.db.Model(&domain.Problem{}).
+			Where("times_selected <> " + selectedCount + " OR times_completed <> " + completedCount).
+			Count(&count).Error
+		return count, err
+	}
+	return 0, fmt.Errorf("unknown integrity issue %q", issue)
+}
+
+// Repair fixes the issue and returns how many rows it deleted or rewrote
+func (r *integrityRepository) Repair(issue domain.IntegrityIssue) (int64, error) {
+	if ri, ok := rowIssues[issue]; ok {
+		result := r.db.Where(ri.condition).Delete(ri.model)
+		return result.RowsAffected, result.Error
+	}
+
+	switch issue {
+	case domain.IssueProgressDrift:
+		drifted, err := r.driftedSummaries()
+		if err != nil || len(drifted) == 0 {
+			return 0, err
+		}
+		result := r.db.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}},
+				UpdateAll: true,
+			}).
+			CreateInBatches(drifted, progressRebuildBatchSize)
+		return int64(len(drifted)), result.Error
+	case domain.IssueUsageDrift:
+		result := r.db.Exec(`UPDATE problems SET times_selected = ` + selectedCount + `, times_completed = ` + completedCount +
+			` WHERE times_selected <> ` + selectedCount + ` OR times_completed <> ` + completedCount)
+		return result.RowsAffected, result.Error
+	}
+	return 0, fmt.Errorf("unknown integrity issue %q", issue)
+}
+
+// driftedSummaries recomputes every user's progress summary and returns those
+// that are missing or differ from the stored one
+func (r *integrityRepository) driftedSummaries() ([]domain.UserProgressSummary, error) {
+	computed, err := (&progressRepository{db: r.db}).recompute(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []domain.UserProgressSummary
+	if err := r.db.Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	storedByUser := make(map[uuid.UUID]domain.UserProgressSummary, len(stored))
+	for _, s := range stored {
+		storedByUser[s.UserID] = s
+	}
+
+	var drifted []domain.UserProgressSummary
+	for userID, summary := range computed {
+		if have, ok := storedByUser[userID]; !ok || !sameSummary(have, *summary) {
+			drifted = append(drifted, *summary)
+		}
+	}
+	return drifted, nil
+}
+
+// sameSummary compares the counters and last activity of two summaries,
+// ignoring when they were written
+func sameSummary(a, b domain.UserProgressSummary) bool {
+	if (a.LastActiveAt == nil) != (b.LastActiveAt == nil) ||
+		a.LastActiveAt != nil && !a.LastActiveAt.Equal(*b.LastActiveAt) {
+		return false
+	}
+	a.LastActiveAt, b.LastActiveAt = nil, nil
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	return a == b
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *integrityRepository) WithContext(ctx context.Context) domain.IntegrityRepository {
+	return &integrityRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
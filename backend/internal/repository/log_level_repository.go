@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// logLevelRepository implements domain.LogLevelRepository using GORM
+type logLevelRepository struct {
+	db *gorm.DB
+}
+
+// NewLogLevelRepository creates a new log level repository
+func NewLogLevelRepository(db *gorm.DB) domain.LogLevelRepository {
+	return &logLevelRepository{db: db}
+}
+
+// Find returns the stored override, or an empty one if none was ever saved
+func (r *logLevelRepository) Find() (*domain.LogLevelOverride, error) {
+	var override domain.LogLevelOverride
+	err := r.db.First(&override, domain.LogLevelOverrideID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.LogLevelOverride{ID: domain.LogLevelOverrideID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// Save creates or replaces the override
+func (r *logLevelRepository) Save(override *domain.LogLevelOverride) error {
+	override.ID = domain.LogLevelOverrideID
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"level", "expires_at", "updated_by", "updated_at"}),
+	}).Create(override).Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *logLevelRepository) WithContext(ctx context.Context) domain.LogLevelRepository {
+	return &logLevelRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
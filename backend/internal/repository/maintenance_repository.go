@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// maintenanceRepository implements domain.MaintenanceRepository using GORM
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewMaintenanceRepository creates a new maintenance repository
+func NewMaintenanceRepository(db *gorm.DB) domain.MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+// Find returns the stored window, or a disabled one if none was ever saved
+func (r *maintenanceRepository) Find() (*domain.MaintenanceWindow, error) {
+	var window domain.MaintenanceWindow
+	err := r.db.First(&window, domain.MaintenanceWindowID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.MaintenanceWindow{ID: domain.MaintenanceWindowID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+// Save creates or replaces the window
+func (r *maintenanceRepository) Save(window *domain.MaintenanceWindow) error {
+	window.ID = domain.MaintenanceWindowID
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "message", "starts_at", "ends_at", "updated_by", "updated_at"}),
+	}).Create(window).Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *maintenanceRepository) WithContext(ctx context.Context) domain.MaintenanceRepository {
+	return &maintenanceRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// mentorshipRepository implements domain.MentorshipRepository using GORM
+type mentorshipRepository struct {
+	db *gorm.DB
+}
+
+// NewMentorshipRepository creates a new mentorship repository
+func NewMentorshipRepository(db *gorm.DB) domain.MentorshipRepository {
+	return &mentorshipRepository{db: db}
+}
+
+// Create creates a mentorship; the pair's unique index rejects a second one
+func (r *mentorshipRepository) Create(mentorship *domain.Mentorship) error {
+	err := r.db.Omit("Mentor", "Mentee").Create(mentorship).Error
+	if errors.Is(err, domain.ErrConflict) {
+		return domain.ErrMentorshipExists
+	}
+	return err
+}
+
+// FindByID finds a mentorship by its ID
+func (r *mentorshipRepository) FindByID(id uuid.UUID) (*domain.Mentorship, error) {
+	var mentorship domain.Mentorship
+	result := r.db.First(&mentorship, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMentorshipNotFound
+		}
+		return nil, result.Error
+	}
+	return &mentorship, nil
+}
+
+// FindByPair finds the mentorship between a mentor and a mentee
+func (r *mentorshipRepository) FindByPair(mentorID, menteeID uuid.UUID) (*domain.Mentorship, error) {
+	var mentorship domain.Mentorship
+	result := r.db.Where("mentor_id = ? AND mentee_id = ?", mentorID, menteeID).First(&mentorship)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMentorshipNotFound
+		}
+		return nil, result.Error
+	}
+	return &mentorship, nil
+}
+
+// FindForUser lists the mentorships the user is either side of, with both
+// usernames, most recently invited first
+func (r *mentorshipRepository) FindForUser(userID uuid.UUID) ([]domain.MentorshipResponse, error) {
+	var rows []struct {
+		domain.Mentorship
+		MentorUsername string
+		MenteeUsername string
+	}
+	result := r.db.Table("mentorships m").
+		Select("m.*, mentor.username AS mentor_username, mentee.username AS mentee_username").
+		Joins("JOIN users mentor ON mentor.id = m.mentor_id").
+		Joins("JOIN users mentee ON mentee.id = m.mentee_id").
+		Where("m.mentor_id = ? OR m.mentee_id = ?", userID, userID).
+		Order("m.invited_at DESC, m.id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	mentorships := make([]domain.MentorshipResponse, len(rows))
+	for i, row := range rows {
+		mentorships[i] = domain.MentorshipResponse{
+			Mentorship:     row.Mentorship,
+			MentorUsername: row.MentorUsername,
+			MenteeUsername: row.MenteeUsername,
+		}
+	}
+	return mentorships, nil
+}
+
+// Update stores the mentorship's status and timestamps if its status is still
+// from, so concurrent answers and revocations cannot overwrite each other
+func (r *mentorshipRepository) Update(mentorship *domain.Mentorship, from domain.MentorshipStatus) (bool, error) {
+	result := r.db.Model(&domain.Mentorship{}).
+		Where("id = ? AND status = ?", mentorship.ID, from).
+		Updates(map[string]interface{}{
+			"status":       mentorship.Status,
+			"invited_at":   mentorship.InvitedAt,
+			"responded_at": mentorship.RespondedAt,
+			"revoked_at":   mentorship.RevokedAt,
+			"revoked_by":   mentorship.RevokedBy,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CreateAssignment creates a mentor assignment
+func (r *mentorshipRepository) CreateAssignment(assignment *domain.MentorAssignment) error {
+	return r.db.Omit("Mentorship", "AssignedContest").Create(assignment).Error
+}
+
+// FindAssignment finds one of the mentorship's assignments
+func (r *mentorshipRepository) FindAssignment(mentorshipID, id uuid.UUID) (*domain.MentorAssignment, error) {
+	var assignment domain.MentorAssignment
+	result := r.db.Where("id = ? AND mentorship_id = ?", id, mentorshipID).First(&assignment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, result.Error
+	}
+	return &assignment, nil
+}
+
+// FindAssignments lists the assignments of the given mentorships, newest first
+func (r *mentorshipRepository) FindAssignments(mentorshipIDs []uuid.UUID) ([]domain.MentorAssignment, error) {
+	var assignments []domain.MentorAssignment
+	if len(mentorshipIDs) == 0 {
+		return assignments, nil
+	}
+	result := r.db.
+		Where("mentorship_id IN ?", mentorshipIDs).
+		Order("created_at DESC, id").
+		Find(&assignments)
+	return assignments, result.Error
+}
+
+// DeleteAssignment deletes one of the mentorship's assignments
+func (r *mentorshipRepository) DeleteAssignment(mentorshipID, id uuid.UUID) error {
+	result := r.db.Delete(&domain.MentorAssignment{}, "id = ? AND mentorship_id = ?", id, mentorshipID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAssignmentNotFound
+	}
+	return nil
+}
+
+// SetAssignmentContest records the mentee's contest for an assignment that has
+// none, so of two concurrent starts only one wins
+func (r *mentorshipRepository) SetAssignmentContest(id, contestID uuid.UUID) error {
+	result := r.db.Model(&domain.MentorAssignment{}).
+		Where("id = ? AND contest_id IS NULL", id).
+		Update("contest_id", contestID)
+	if result.Error != nil {
+		if errors.Is(result.Error, domain.ErrConflict) {
+			return domain.ErrAssignmentStarted
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAssignmentStarted
+	}
+	return nil
+}
+
+// FindPendingContestIDs lists the mentorship's assigned contests that were not
+// started yet, narrowed to one assignment when given
+func (r *mentorshipRepository) FindPendingContestIDs(mentorshipID uuid.UUID, assignmentID *uuid.UUID) ([]uuid.UUID, error) {
+	query := r.db.Table("mentor_assignments a").
+		Joins("JOIN contests c ON c.id = a.contest_id").
+		Where("a.mentorship_id = ? AND c.status = ?", mentorshipID, domain.ContestStatusPending)
+	if assignmentID != nil {
+		query = query.Where("a.id = ?", *assignmentID)
+	}
+
+	var ids []uuid.UUID
+	if err := query.Pluck("a.contest_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindAssignmentProgress reports the mentee's progress on each assignment from
+// its contest; an assignment without one is not started
+func (r *mentorshipRepository) FindAssignmentProgress(assignments []domain.MentorAssignment) (map[uuid.UUID]domain.AssignmentProgress, error) {
+	var contestIDs []uuid.UUID
+	for _, a := range assignments {
+		if a.ContestID != nil {
+			contestIDs = append(contestIDs, *a.ContestID)
+		}
+	}
+	contests, err := findAssignmentContests(r.db, contestIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make(map[uuid.UUID]domain.AssignmentProgress, len(assignments))
+	for _, a := range assignments {
+		p := domain.AssignmentProgress{AssignmentID: a.ID, Status: domain.AssignmentNotStarted}
+		var c assignmentContest
+		ok := false
+		if a.ContestID != nil {
+			c, ok = contests[*a.ContestID]
+		}
+		switch {
+		case ok:
+			c.apply(&p)
+		case a.Contest != nil:
+			p.Total = a.Contest.ProblemCount
+		default:
+			p.Total = len(a.ProblemIDs)
+		}
+		progress[a.ID] = p
+	}
+	return progress, nil
+}
+
+// AddAudit stores an audit trail entry
+func (r *mentorshipRepository) AddAudit(entry *domain.MentorshipAuditEntry) error {
+	return r.db.Omit("Mentorship").Create(entry).Error
+}
+
+// FindAudit returns the latest audit trail entries of a mentorship, newest first
+func (r *mentorshipRepository) FindAudit(mentorshipID uuid.UUID, limit int) ([]domain.MentorshipAuditEntry, error) {
+	var entries []domain.MentorshipAuditEntry
+	result := r.db.
+		Where("mentorship_id = ?", mentorshipID).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Find(&entries)
+	return entries, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *mentorshipRepository) WithContext(ctx context.Context) domain.MentorshipRepository {
+	return &mentorshipRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// Text search configurations of the note kinds. Retros are prose and are
+// stemmed as English; code is only split into words, since stemming
+// identifiers makes "sorted" find "sorts".
+const (
+	retroSearchConfig    = "english"
+	solutionSearchConfig = "simple"
+)
+
+// noteSearchRepository implements domain.NoteSearchRepository using GORM
+type noteSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewNoteSearchRepository creates a new note search repository
+func NewNoteSearchRepository(db *gorm.DB) domain.NoteSearchRepository {
+	return &noteSearchRepository{db: db}
+}
+
+// noteRow is a matching retro or snippet as scanned from either query
+type noteRow struct {
+	ContestID    uuid.UUID
+	ProblemID    *uuid.UUID
+	ProblemTitle string
+	Text         string
+	UpdatedAt    nullTime
+	Rank         float64
+}
+
+// Search lists the user's matching retros and snippets. On Postgres they are
+// matched through the idx_contests_retro_fts and idx_solution_snippets_code_fts
+// indexes, which lead with user_id so only the user's own notes are searched,
+// and ranked with ts_rank. SQLite has no text search; there every word must
+// appear as a substring and the most recently edited notes come first.
+func (r *noteSearchRepository) Search(userID uuid.UUID, query string, limit int) ([]domain.NoteMatch, error) {
+	words := strings.FieldsFunc(strings.ToLower(query), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	if len(words) == 0 {
+		return []domain.NoteMatch{}, nil
+	}
+
+	var retros []noteRow
+	rank, rankArgs := r.rank("contests.retro", retroSearchConfig, query)
+	err := r.db.Model(&domain.Contest{}).
+		Select("contests.id AS contest_id, contests.retro AS text, COALESCE(contests.retro_updated_at, contests.updated_at) AS updated_at, "+rank, rankArgs...).
+		Scopes(r.matching("contests.retro", retroSearchConfig, query, words)).
+		Where("contests.user_id = ? AND contests.retro <> ''", userID).
+		Order("rank DESC, updated_at DESC").
+		Limit(limit).
+		Scan(&retros).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var snippets []noteRow
+	rank, rankArgs = r.rank("solution_snippets.code", solutionSearchConfig, query)
+	err = r.db.Model(&domain.SolutionSnippet{}).
+		Select("solution_snippets.contest_id, solution_snippets.problem_id, problems.title AS problem_title, solution_snippets.code AS text, solution_snippets.updated_at, "+rank, rankArgs...).
+		Scopes(r.matching("solution_snippets.code", solutionSearchConfig, query, words)).
+		Joins("JOIN problems ON problems.id = solution_snippets.problem_id").
+		Where("solution_snippets.user_id = ?", userID).
+		Order("rank DESC, updated_at DESC").
+		Limit(limit).
+		Scan(&snippets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]domain.NoteMatch, 0, len(retros)+len(snippets))
+	for _, row := range retros {
+		matches = append(matches, row.match(domain.NoteKindRetro))
+	}
+	for _, row := range snippets {
+		matches = append(matches, row.match(domain.NoteKindSolution))
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Rank != matches[j].Rank {
+			return matches[i].Rank > matches[j].Rank
+		}
+		return matches[i].UpdatedAt.After(matches[j].UpdatedAt)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// rank returns the select expression ranking the rows by how well column
+// matches the query; rows are not ranked outside Postgres
+func (r *noteSearchRepository) rank(column, config, query string) (string, []interface{}) {
+	if !isPostgres(r.db) {
+		return "0 AS rank", nil
+	}
+	return "ts_rank(" + textSearchDocument(column, config) + ", plainto_tsquery('" + config + "', ?)) AS rank", []interface{}{query}
+}
+
+// matching restricts a query to the rows whose column contains every word of
+// the query
+func (r *noteSearchRepository) matching(column, config, query string, words []string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if isPostgres(r.db) {
+			return db.Where(textSearchDocument(column, config)+" @@ plainto_tsquery('"+config+"', ?)", query)
+		}
+		for _, word := range words {
+			db = db.Where("LOWER("+column+") LIKE ? ESCAPE '\\'", "%"+escapeLike(word)+"%")
+		}
+		return db
+	}
+}
+
+// textSearchDocument is the tsvector of column. It must stay identical to the
+// expression of the column's text search index for the planner to use it.
+func textSearchDocument(column, config string) string {
+	return "to_tsvector('" + config + "', " + column + ")"
+}
+
+// match converts the row into a match of the given kind
+func (row noteRow) match(kind domain.NoteKind) domain.NoteMatch {
+	return domain.NoteMatch{
+		Kind:         kind,
+		ContestID:    row.ContestID,
+		ProblemID:    row.ProblemID,
+		ProblemTitle: row.ProblemTitle,
+		Text:         row.Text,
+		UpdatedAt:    row.UpdatedAt.Time,
+		Rank:         row.Rank,
+	}
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *noteSearchRepository) WithContext(ctx context.Context) domain.NoteSearchRepository {
+	return &noteSearchRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
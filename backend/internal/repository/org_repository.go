@@ -0,0 +1,447 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// orgRepository implements domain.OrgRepository using GORM
+type orgRepository struct {
+	db *gorm.DB
+}
+
+// NewOrgRepository creates a new organization repository
+func NewOrgRepository(db *gorm.DB) domain.OrgRepository {
+	return &orgRepository{db: db}
+}
+
+// Create creates an organization and its owner's membership in one transaction
+func (r *orgRepository) Create(org *domain.Organization, owner *domain.OrgMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		owner.OrgID = org.ID
+		return tx.Omit("Organization", "User").Create(owner).Error
+	})
+}
+
+// FindByID finds an organization by its ID
+func (r *orgRepository) FindByID(id uuid.UUID) (*domain.Organization, error) {
+	var org domain.Organization
+	result := r.db.First(&org, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrgNotFound
+		}
+		return nil, result.Error
+	}
+	return &org, nil
+}
+
+// FindForUser lists the user's organizations with their role and member count
+func (r *orgRepository) FindForUser(userID uuid.UUID) ([]domain.OrgSummary, error) {
+	var orgs []domain.OrgSummary
+	result := r.db.Table("organizations o").
+		Select(`o.id, o.name, m.role, o.created_at,
+			(SELECT COUNT(*) FROM org_members c WHERE c.org_id = o.id) AS member_count`).
+		Joins("JOIN org_members m ON m.org_id = o.id AND m.user_id = ?", userID).
+		Order("o.name, o.id").
+		Scan(&orgs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return orgs, nil
+}
+
+// FindMember finds a user's membership in an organization
+func (r *orgRepository) FindMember(orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	var member domain.OrgMember
+	result := r.db.Where("org_id = ? AND user_id = ?", orgID, userID).First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrgNotFound
+		}
+		return nil, result.Error
+	}
+	return &member, nil
+}
+
+// FindMembers lists an organization's members, instructors first, then by username
+func (r *orgRepository) FindMembers(orgID uuid.UUID) ([]domain.OrgMemberResponse, error) {
+	var members []domain.OrgMemberResponse
+	result := r.db.Table("org_members m").
+		Select("m.user_id, u.username, m.role, m.joined_at").
+		Joins("JOIN users u ON u.id = m.user_id").
+		Where("m.org_id = ?", orgID).
+		Order("CASE WHEN m.role = 'instructor' THEN 0 ELSE 1 END, u.username").
+		Scan(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// FindMemberIDs returns the IDs of an organization's members with the role
+func (r *orgRepository) FindMemberIDs(orgID uuid.UUID, role domain.OrgRole) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	result := r.db.Model(&domain.OrgMember{}).
+		Where("org_id = ? AND role = ?", orgID, role).
+		Pluck("user_id", &ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return ids, nil
+}
+
+// CountMembers counts an organization's members
+func (r *orgRepository) CountMembers(orgID uuid.UUID) (int64, error) {
+	var count int64
+	result := r.db.Model(&domain.OrgMember{}).Where("org_id = ?", orgID).Count(&count)
+	return count, result.Error
+}
+
+// AddMember adds a user to an organization
+func (r *orgRepository) AddMember(member *domain.OrgMember) error {
+	err := r.db.Omit("Organization", "User").Create(member).Error
+	if errors.Is(err, domain.ErrConflict) {
+		return domain.ErrAlreadyOrgMember
+	}
+	return err
+}
+
+// RemoveMember removes a user from an organization, along with the links to
+// contests they started for its assignments
+func (r *orgRepository) RemoveMember(orgID, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("org_id = ? AND user_id = ?", orgID, userID).Delete(&domain.OrgMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrOrgNotFound
+		}
+		return tx.Where("user_id = ? AND assignment_id IN (?)", userID,
+			tx.Model(&domain.OrgAssignment{}).Select("id").Where("org_id = ?", orgID)).
+			Delete(&domain.OrgAssignmentContest{}).Error
+	})
+}
+
+// CreateInvite creates a new invite
+func (r *orgRepository) CreateInvite(invite *domain.OrgInvite) error {
+	return r.db.Omit("Organization").Create(invite).Error
+}
+
+// FindInviteByCode finds an invite by its code
+func (r *orgRepository) FindInviteByCode(code string) (*domain.OrgInvite, error) {
+	var invite domain.OrgInvite
+	result := r.db.Where("code = ?", code).First(&invite)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrgInviteNotFound
+		}
+		return nil, result.Error
+	}
+	return &invite, nil
+}
+
+// FindOpenInvites lists an organization's unexpired invites, newest first
+func (r *orgRepository) FindOpenInvites(orgID uuid.UUID, now time.Time) ([]domain.OrgInvite, error) {
+	var invites []domain.OrgInvite
+	result := r.db.Where("org_id = ? AND expires_at > ?", orgID, now).
+		Order("created_at DESC").
+		Find(&invites)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return invites, nil
+}
+
+// DeleteInvite revokes an invite of the organization
+func (r *orgRepository) DeleteInvite(orgID uuid.UUID, code string) error {
+	result := r.db.Where("org_id = ? AND code = ?", orgID, code).Delete(&domain.OrgInvite{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrOrgInviteNotFound
+	}
+	return nil
+}
+
+// CreateAssignment creates a new assignment
+func (r *orgRepository) CreateAssignment(assignment *domain.OrgAssignment) error {
+	return r.db.Omit("Organization").Create(assignment).Error
+}
+
+// FindAssignment finds an assignment of the organization
+func (r *orgRepository) FindAssignment(orgID, id uuid.UUID) (*domain.OrgAssignment, error) {
+	var assignment domain.OrgAssignment
+	result := r.db.Where("org_id = ? AND id = ?", orgID, id).First(&assignment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, result.Error
+	}
+	return &assignment, nil
+}
+
+// FindAssignments lists an organization's assignments, newest first
+func (r *orgRepository) FindAssignments(orgID uuid.UUID) ([]domain.OrgAssignment, error) {
+	var assignments []domain.OrgAssignment
+	result := r.db.Where("org_id = ?", orgID).Order("created_at DESC, id").Find(&assignments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return assignments, nil
+}
+
+// DeleteAssignment deletes an assignment; contests students started for it are kept
+func (r *orgRepository) DeleteAssignment(orgID, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("assignment_id = ?", id).Delete(&domain.OrgAssignmentContest{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("org_id = ? AND id = ?", orgID, id).Delete(&domain.OrgAssignment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrAssignmentNotFound
+		}
+		return nil
+	})
+}
+
+// LinkContest records a student's contest for an assignment; the primary key
+// rejects a second one
+func (r *orgRepository) LinkContest(link *domain.OrgAssignmentContest) error {
+	err := r.db.Omit("Assignment").Create(link).Error
+	if errors.Is(err, domain.ErrConflict) {
+		return domain.ErrAssignmentStarted
+	}
+	return err
+}
+
+// FindPendingContestIDs lists the organization's linked contests that were not
+// started yet, narrowed to one assignment and/or one user when given
+func (r *orgRepository) FindPendingContestIDs(orgID uuid.UUID, assignmentID, userID *uuid.UUID) ([]uuid.UUID, error) {
+	query := r.db.Table("org_assignment_contests l").
+		Joins("JOIN org_assignments a ON a.id = l.assignment_id").
+		Joins("JOIN contests c ON c.id = l.contest_id").
+		Where("a.org_id = ? AND c.status = ?", orgID, domain.ContestStatusPending)
+	if assignmentID != nil {
+		query = query.Where("l.assignment_id = ?", *assignmentID)
+	}
+	if userID != nil {
+		query = query.Where("l.user_id = ?", *userID)
+	}
+
+	var ids []uuid.UUID
+	if err := query.Pluck("l.contest_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindContestLink finds the assignment link of a contest, with its assignment
+func (r *orgRepository) FindContestLink(contestID uuid.UUID) (*domain.OrgAssignmentContest, error) {
+	var link domain.OrgAssignmentContest
+	result := r.db.Preload("Assignment").Where("contest_id = ?", contestID).First(&link)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, result.Error
+	}
+	return &link, nil
+}
+
+// FindProgress reports each user's progress on each of the organization's
+// assignments: from the linked contest for contest assignments and problem
+// sets, and from the user's solves of the category's problems for study plans
+func (r *orgRepository) FindProgress(orgID uuid.UUID, userIDs []uuid.UUID) ([]domain.AssignmentProgress, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	assignments, err := r.FindAssignments(orgID)
+	if err != nil || len(assignments) == 0 {
+		return nil, err
+	}
+
+	var links []domain.OrgAssignmentContest
+	err = r.db.Table("org_assignment_contests l").
+		Select("l.assignment_id, l.user_id, l.contest_id").
+		Joins("JOIN org_assignments a ON a.id = l.assignment_id").
+		Where("a.org_id = ? AND l.user_id IN ?", orgID, userIDs).
+		Scan(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	contestIDs := make([]uuid.UUID, len(links))
+	for i, l := range links {
+		contestIDs[i] = l.ContestID
+	}
+	contests, err := findAssignmentContests(r.db, contestIDs)
+	if err != nil {
+		return nil, err
+	}
+	started := make(map[[2]uuid.UUID]assignmentContest, len(links))
+	for _, l := range links {
+		if c, ok := contests[l.ContestID]; ok {
+			started[[2]uuid.UUID{l.AssignmentID, l.UserID}] = c
+		}
+	}
+
+	type planRow struct {
+		CategoryID uuid.UUID
+		UserID     uuid.UUID
+		Solved     int
+	}
+	var categoryIDs []uuid.UUID
+	for _, a := range assignments {
+		if a.Kind == domain.AssignmentStudyPlan && a.CategoryID != nil {
+			categoryIDs = append(categoryIDs, *a.CategoryID)
+		}
+	}
+	totals := make(map[uuid.UUID]int)
+	solved := make(map[[2]uuid.UUID]int)
+	if len(categoryIDs) > 0 {
+		var counts []struct {
+			CategoryID uuid.UUID
+			Total      int
+		}
+		err = r.db.Model(&domain.RoadmapProblem{}).
+			Select("category_id, COUNT(*) AS total").
+			Where("category_id IN ?", categoryIDs).
+			Group("category_id").
+			Scan(&counts).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range counts {
+			totals[c.CategoryID] = c.Total
+		}
+
+		var plans []planRow
+		err = r.db.Table("submissions s").
+			Select("rp.category_id, s.user_id, COUNT(*) AS solved").
+			Joins("JOIN roadmap_problems rp ON rp.problem_id = s.problem_id").
+			Where("rp.category_id IN ? AND s.user_id IN ?", categoryIDs, userIDs).
+			Group("rp.category_id, s.user_id").
+			Scan(&plans).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range plans {
+			solved[[2]uuid.UUID{p.CategoryID, p.UserID}] = p.Solved
+		}
+	}
+
+	progress := make([]domain.AssignmentProgress, 0, len(assignments)*len(userIDs))
+	for _, a := range assignments {
+		for _, userID := range userIDs {
+			p := domain.AssignmentProgress{AssignmentID: a.ID, UserID: userID, Status: domain.AssignmentNotStarted}
+			switch a.Kind {
+			case domain.AssignmentContest, domain.AssignmentProblemSet:
+				if c, ok := started[[2]uuid.UUID{a.ID, userID}]; ok {
+					c.apply(&p)
+				} else if a.Contest != nil {
+					p.Total = a.Contest.ProblemCount
+				} else {
+					p.Total = len(a.ProblemIDs)
+				}
+			case domain.AssignmentStudyPlan:
+				if a.CategoryID != nil {
+					p.Solved = solved[[2]uuid.UUID{*a.CategoryID, userID}]
+					p.Total = totals[*a.CategoryID]
+				}
+				switch {
+				case p.Total > 0 && p.Solved >= p.Total:
+					p.Status = domain.AssignmentCompleted
+				case p.Solved > 0:
+					p.Status = domain.AssignmentInProgress
+				}
+			}
+			progress = append(progress, p)
+		}
+	}
+	return progress, nil
+}
+
+// CategoryExists reports whether a roadmap category exists
+func (r *orgRepository) CategoryExists(id uuid.UUID) (bool, error) {
+	var count int64
+	result := r.db.Model(&domain.RoadmapCategory{}).Where("id = ?", id).Count(&count)
+	return count > 0, result.Error
+}
+
+// assignmentContest is the state of a contest given to a user for an assignment
+type assignmentContest struct {
+	ContestID uuid.UUID
+	Status    domain.ContestStatus
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Solved    int
+	Total     int
+}
+
+// findAssignmentContests loads the status and solved problems of the given
+// contests, keyed by contest ID; deleted contests are absent
+func findAssignmentContests(db *gorm.DB, contestIDs []uuid.UUID) (map[uuid.UUID]assignmentContest, error) {
+	contests := make(map[uuid.UUID]assignmentContest, len(contestIDs))
+	if len(contestIDs) == 0 {
+		return contests, nil
+	}
+	var rows []assignmentContest
+	err := db.Table("contests c").
+		Select(`c.id AS contest_id, c.status, c.started_at, c.ended_at,
+			COUNT(CASE WHEN cp.is_completed THEN 1 END) AS solved, COUNT(cp.problem_id) AS total`).
+		Joins("LEFT JOIN contest_problems cp ON cp.contest_id = c.id AND NOT cp.is_warmup").
+		Where("c.id IN ?", contestIDs).
+		Group("c.id, c.status, c.started_at, c.ended_at").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		contests[row.ContestID] = row
+	}
+	return contests, nil
+}
+
+// apply sets the assignment progress from the contest: a pending contest is
+// not started yet, and the assignment ends the way the contest did
+func (c assignmentContest) apply(p *domain.AssignmentProgress) {
+	contestID := c.ContestID
+	p.ContestID = &contestID
+	p.Solved, p.Total = c.Solved, c.Total
+	if c.Status != domain.ContestStatusPending {
+		startedAt := c.StartedAt
+		p.StartedAt, p.EndedAt = &startedAt, c.EndedAt
+	}
+	switch c.Status {
+	case domain.ContestStatusPending:
+		p.Status = domain.AssignmentNotStarted
+	case domain.ContestStatusCompleted:
+		p.Status = domain.AssignmentCompleted
+	case domain.ContestStatusAbandoned:
+		p.Status = domain.AssignmentAbandoned
+	default:
+		p.Status = domain.AssignmentInProgress
+	}
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *orgRepository) WithContext(ctx context.Context) domain.OrgRepository {
+	return &orgRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
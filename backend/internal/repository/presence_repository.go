@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// presenceRepository implements domain.PresenceRepository using GORM
+type presenceRepository struct {
+	db *gorm.DB
+}
+
+// NewPresenceRepository creates a new presence repository
+func NewPresenceRepository(db *gorm.DB) domain.PresenceRepository {
+	return &presenceRepository{db: db}
+}
+
+// Touch records a heartbeat with a single upsert
+func (r *presenceRepository) Touch(userID uuid.UUID, contestID *uuid.UUID, seenAt time.Time) error {
+	presence := domain.UserPresence{UserID: userID, ContestID: contestID, LastSeenAt: seenAt}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"contest_id", "last_seen_at"}),
+	}).Create(&presence).Error
+}
+
+// FindByUserIDs returns the latest heartbeats of the given users; users
+// without one are absent
+func (r *presenceRepository) FindByUserIDs(userIDs []uuid.UUID) ([]domain.UserPresence, error) {
+	var presences []domain.UserPresence
+	if len(userIDs) == 0 {
+		return presences, nil
+	}
+	result := r.db.Where("user_id IN ?", userIDs).Find(&presences)
+	return presences, result.Error
+}
+
+// DeleteSeenBefore removes heartbeats older than cutoff
+func (r *presenceRepository) DeleteSeenBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("last_seen_at < ?", cutoff).Delete(&domain.UserPresence{})
+	return result.RowsAffected, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *presenceRepository) WithContext(ctx context.Context) domain.PresenceRepository {
+	return &presenceRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// problemListRepository implements domain.ProblemListRepository using GORM
+type problemListRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemListRepository creates a new community list repository
+func NewProblemListRepository(db *gorm.DB) domain.ProblemListRepository {
+	return &problemListRepository{db: db}
+}
+
+// Create creates a new list in the database
+func (r *problemListRepository) Create(list *domain.ProblemList) error {
+	return r.db.Omit("Owner").Create(list).Error
+}
+
+// FindByID finds a list by its ID
+func (r *problemListRepository) FindByID(id uuid.UUID) (*domain.ProblemList, error) {
+	var list domain.ProblemList
+	result := r.db.Where("id = ?", id).First(&list)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrListNotFound
+		}
+		return nil, result.Error
+	}
+	return &list, nil
+}
+
+// summaries selects lists as the browser shows them to the viewer, with the
+// owner's name and the total count of matching lists
+func (r *problemListRepository) summaries(viewerID uuid.UUID) *gorm.DB {
+	return r.db.Model(&domain.ProblemList{}).
+		Select(`problem_lists.id, problem_lists.owner_id, users.username AS owner_username,
+			problem_lists.title, problem_lists.description, problem_lists.problem_count,
+			problem_lists.subscribers, problem_lists.status, problem_lists.hidden_reason,
+			problem_lists.reports, problem_lists.created_at, problem_lists.updated_at,
+			EXISTS (SELECT 1 FROM problem_list_subscriptions
+				WHERE problem_list_subscriptions.list_id = problem_lists.id
+				AND problem_list_subscriptions.user_id = ?) AS subscribed,
+			COUNT(*) OVER () AS total`, viewerID).
+		Joins("JOIN users ON users.id = problem_lists.owner_id")
+}
+
+// FindPage lists a page of the lists matching the filter. A page past the end
+// reports a total of 0.
+func (r *problemListRepository) FindPage(filter domain.ProblemListFilter, viewerID uuid.UUID) ([]domain.ProblemListSummary, int64, error) {
+	query := r.summaries(viewerID)
+	if filter.Status != "" {
+		query = query.Where("problem_lists.status = ?", filter.Status)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("problem_lists.owner_id = ?", *filter.OwnerID)
+	}
+	if filter.SubscriberID != nil {
+		query = query.Joins("JOIN problem_list_subscriptions mine ON mine.list_id = problem_lists.id AND mine.user_id = ?", *filter.SubscriberID)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Query)) + "%"
+		query = query.Where("(LOWER(problem_lists.title) LIKE ? ESCAPE '\\' OR LOWER(problem_lists.description) LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+
+	if filter.Reported {
+		query = query.Where("problem_lists.reports > 0")
+	}
+
+	switch filter.Sort {
+	case domain.ProblemListSortNewest:
+		query = query.Order("problem_lists.created_at DESC")
+	case domain.ProblemListSortReports:
+		query = query.Order("problem_lists.reports DESC, problem_lists.updated_at DESC")
+	default:
+		query = query.Order("problem_lists.subscribers DESC, problem_lists.created_at DESC")
+	}
+
+	var rows []struct {
+		domain.ProblemListSummary
+		Total int64
+	}
+	if err := query.Order("problem_lists.id").Limit(filter.Limit).Offset(filter.Offset).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	lists := make([]domain.ProblemListSummary, len(rows))
+	var total int64
+	for i, row := range rows {
+		lists[i] = row.ProblemListSummary
+		total = row.Total
+	}
+	return lists, total, nil
+}
+
+// FindSummary returns one list as the browser shows it to the viewer
+func (r *problemListRepository) FindSummary(id, viewerID uuid.UUID) (*domain.ProblemListSummary, error) {
+	var rows []struct {
+		domain.ProblemListSummary
+		Total int64
+	}
+	if err := r.summaries(viewerID).Where("problem_lists.id = ?", id).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrListNotFound
+	}
+	return &rows[0].ProblemListSummary, nil
+}
+
+// Update updates an existing list
+func (r *problemListRepository) Update(list *domain.ProblemList) error {
+	return r.db.Omit("Owner").Save(list).Error
+}
+
+// Delete deletes a list by its ID; its subscriptions and reports cascade
+func (r *problemListRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&domain.ProblemList{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrListNotFound
+	}
+	return nil
+}
+
+// Subscribe adds the subscription and counts the subscriber in one transaction
+func (r *problemListRepository) Subscribe(listID, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		sub := &domain.ProblemListSubscription{ListID: listID, UserID: userID}
+		result := tx.Omit("List", "User").Clauses(clause.OnConflict{DoNothing: true}).Create(sub)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&domain.ProblemList{}).Where("id = ?", listID).
+			UpdateColumn("subscribers", gorm.Expr("subscribers + 1")).Error
+	})
+}
+
+// Unsubscribe removes the subscription and uncounts the subscriber in one transaction
+func (r *problemListRepository) Unsubscribe(listID, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.ProblemListSubscription{}, "list_id = ? AND user_id = ?", listID, userID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&domain.ProblemList{}).Where("id = ? AND subscribers > 0", listID).
+			UpdateColumn("subscribers", gorm.Expr("subscribers - 1")).Error
+	})
+}
+
+// IsSubscribed reports whether the user subscribes to the list
+func (r *problemListRepository) IsSubscribed(listID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.ProblemListSubscription{}).
+		Where("list_id = ? AND user_id = ?", listID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// AddReport records the report and counts it in one transaction
+func (r *problemListRepository) AddReport(report *domain.ProblemListReport) (int, error) {
+	var reports int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("List", "User").Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrListAlreadyReported
+		}
+		if err := tx.Model(&domain.ProblemList{}).Where("id = ?", report.ListID).
+			UpdateColumn("reports", gorm.Expr("reports + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ProblemList{}).Where("id = ?", report.ListID).
+			Select("reports").Scan(&reports).Error
+	})
+	return reports, err
+}
+
+// SetStatus publishes or hides a list. Publishing clears the reports, so the
+// users who reported it can report it again.
+func (r *problemListRepository) SetStatus(id uuid.UUID, status domain.ProblemListStatus, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"status": status, "hidden_reason": reason}
+		if status == domain.ProblemListPublished {
+			updates["hidden_reason"] = ""
+			updates["reports"] = 0
+		}
+		result := tx.Model(&domain.ProblemList{}).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrListNotFound
+		}
+		if status == domain.ProblemListPublished {
+			return tx.Delete(&domain.ProblemListReport{}, "list_id = ?", id).Error
+		}
+		return nil
+	})
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *problemListRepository) WithContext(ctx context.Context) domain.ProblemListRepository {
+	return &problemListRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -3,11 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
 // problemRepository implements domain.ProblemRepository using GORM
@@ -33,7 +37,7 @@ func (r *problemRepository) CreateBatch(problems []domain.Problem) error {
 // FindByID finds a problem by its ID
 func (r *problemRepository) FindByID(id uuid.UUID) (*domain.Problem, error) {
 	var problem domain.Problem
-	result := r.db.Where("id = ?", id).First(&problem)
+	result := r.db.Preload("Companies", orderCompanies).Where("id = ?", id).First(&problem)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrProblemNotFound
@@ -46,7 +50,7 @@ func (r *problemRepository) FindByID(id uuid.UUID) (*domain.Problem, error) {
 // FindBySlug finds a problem by its slug
 func (r *problemRepository) FindBySlug(slug string) (*domain.Problem, error) {
 	var problem domain.Problem
-	result := r.db.Where("slug = ?", slug).First(&problem)
+	result := r.db.Scopes(catalogOnly).Preload("Companies", orderCompanies).Where("slug = ?", slug).First(&problem)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrProblemNotFound
@@ -56,68 +60,407 @@ func (r *problemRepository) FindBySlug(slug string) (*domain.Problem, error) {
 	return &problem, nil
 }
 
-// FindAll returns all problems ordered by order_index
+// FindAll returns all catalog problems ordered by order_index
 func (r *problemRepository) FindAll() ([]domain.Problem, error) {
 	var problems []domain.Problem
-	result := r.db.Order("order_index ASC").Find(&problems)
+	result := r.db.Scopes(catalogOnly).Preload("Companies", orderCompanies).Order("order_index ASC").Find(&problems)
+	return problems, result.Error
+}
+
+// FindByIDsOrSlugs returns the problems with any of the IDs, including custom
+// ones, and the catalog problems with any of the slugs, in one query
+func (r *problemRepository) FindByIDsOrSlugs(ids []uuid.UUID, slugs []string) ([]domain.Problem, error) {
+	var problems []domain.Problem
+	if len(ids) == 0 && len(slugs) == 0 {
+		return problems, nil
+	}
+
+	// Empty lists are left out because IN () is not valid SQL
+	var match *gorm.DB
+	if len(ids) > 0 {
+		match = r.db.Where("problems.id IN ?", ids)
+	}
+	if len(slugs) > 0 {
+		bySlug := r.db.Where("problems.owner_id IS NULL AND problems.slug IN ?", slugs)
+		if match == nil {
+			match = bySlug
+		} else {
+			match = match.Or(bySlug)
+		}
+	}
+
+	result := r.db.Preload("Companies", orderCompanies).Where(match).Find(&problems)
+	return problems, result.Error
+}
+
+// FindSearchCandidates returns the catalog problems whose title or a topic may
+// match the lowercased query. On Postgres the title is matched through the
+// idx_problems_title_trgm trigram index, fuzzily (%), by word (<%) or as a
+// substring; topics are few and short, so they are scanned. SQLite has no
+// trigram support and returns the whole catalog for the caller to rank.
+func (r *problemRepository) FindSearchCandidates(query string) ([]domain.Problem, error) {
+	db := r.db.Scopes(catalogOnly).Preload("Companies", orderCompanies)
+	if isPostgres(r.db) {
+		pattern := "%" + escapeLike(query) + "%"
+		db = db.Where(`lower(problems.title) % ? OR ? <% lower(problems.title) OR lower(problems.title) LIKE ? ESCAPE '\'
+			OR EXISTS (SELECT 1 FROM unnest(problems.topics) AS topic WHERE lower(topic) % ? OR lower(topic) LIKE ? ESCAPE '\')`,
+			query, query, pattern, query, pattern)
+	}
+
+	var problems []domain.Problem
+	result := db.Order("order_index ASC").Find(&problems)
 	return problems, result.Error
 }
 
 // FindByDifficulty returns all problems with the specified difficulty
 func (r *problemRepository) FindByDifficulty(difficulty domain.Difficulty) ([]domain.Problem, error) {
 	var problems []domain.Problem
-	result := r.db.Where("difficulty = ?", difficulty).Order("order_index ASC").Find(&problems)
+	result := r.db.Scopes(catalogOnly).Where("difficulty = ?", difficulty).Order("order_index ASC").Find(&problems)
 	return problems, result.Error
 }
 
 // FindByTopics returns all problems that match any of the given topics
 func (r *problemRepository) FindByTopics(topics []string) ([]domain.Problem, error) {
 	var problems []domain.Problem
-	result := r.db.Where("topics && ?", topics).Order("order_index ASC").Find(&problems)
+	query := r.db.Scopes(catalogOnly)
+	if isPostgres(r.db) {
+		query = query.Where("topics && ?", pq.StringArray(topics))
+	} else {
+		// Topics are stored as a JSON array outside Postgres
+		query = query.Where("EXISTS (SELECT 1 FROM json_each(problems.topics) WHERE json_each.value IN ?)", topics)
+	}
+	result := query.Order("order_index ASC").Find(&problems)
 	return problems, result.Error
 }
 
-// FindUnsolvedByUser returns all problems not yet solved by the user
+// FindUnsolvedByUser returns all catalog problems not yet solved by the user
 func (r *problemRepository) FindUnsolvedByUser(userID uuid.UUID) ([]domain.Problem, error) {
 	var problems []domain.Problem
-	
-	// Subquery to get solved problem IDs
-	solvedSubquery := r.db.Model(&domain.Submission{}).
-		Select("problem_id").
-		Where("user_id = ?", userID)
-	
-	result := r.db.Where("id NOT IN (?)", solvedSubquery).
+	result := r.db.Scopes(catalogOnly, unsolvedBy(userID)).
 		Order("order_index ASC").
 		Find(&problems)
-	
+
+	return problems, result.Error
+}
+
+// FindUnsolvedByUserAndDifficulty returns unsolved problems for a user filtered by difficulty,
+// including the user's own custom problems when includeCustom is set. Problems come back in
+// catalog order; callers sample from the full pool because their filters (companies,
+// prerequisites, cooldown, importance weights) need every candidate.
+func (r *problemRepository) FindUnsolvedByUserAndDifficulty(userID uuid.UUID, difficulty domain.Difficulty, includeCustom bool) ([]domain.Problem, error) {
+	var problems []domain.Problem
+
+	query := r.db.Scopes(catalogOnly)
+	if includeCustom {
+		query = r.db.Where("problems.owner_id IS NULL OR problems.owner_id = ?", userID)
+	}
+
+	result := query.Scopes(unsolvedBy(userID)).
+		Where("problems.difficulty = ?", difficulty).
+		Order("problems.order_index ASC").
+		Find(&problems)
+
 	return problems, result.Error
 }
 
-// FindUnsolvedByUserAndDifficulty returns unsolved problems for a user filtered by difficulty
-func (r *problemRepository) FindUnsolvedByUserAndDifficulty(userID uuid.UUID, difficulty domain.Difficulty) ([]domain.Problem, error) {
+// FindSolvedByUserAndDifficulty returns the catalog problems of a difficulty the user has solved
+func (r *problemRepository) FindSolvedByUserAndDifficulty(userID uuid.UUID, difficulty domain.Difficulty) ([]domain.Problem, error) {
 	var problems []domain.Problem
-	
+	result := r.db.Scopes(catalogOnly).
+		Where("EXISTS (SELECT 1 FROM submissions WHERE submissions.problem_id = problems.id AND submissions.user_id = ?)", userID).
+		Where("problems.difficulty = ?", difficulty).
+		Order("problems.order_index ASC").
+		Find(&problems)
+
+	return problems, result.Error
+}
+
+// FindRecentlyServedIDs returns the IDs of problems served in the user's last N contests,
+// regardless of whether they were completed
+func (r *problemRepository) FindRecentlyServedIDs(userID uuid.UUID, lastContests int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if lastContests <= 0 {
+		return ids, nil
+	}
+
+	recentContests := r.db.Model(&domain.Contest{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("started_at DESC").
+		Limit(lastContests)
+
+	result := r.db.Model(&domain.ContestProblem{}).
+		Distinct("problem_id").
+		Where("contest_id IN (?)", recentContests).
+		Pluck("problem_id", &ids)
+
+	return ids, result.Error
+}
+
+// FindRecentSolveCounts counts the problems served and solved in the user's last
+// finished contests; active contests are skipped since they are still in play
+func (r *problemRepository) FindRecentSolveCounts(userID uuid.UUID, lastContests int) (int64, int64, error) {
+	recentContests := r.db.Model(&domain.Contest{}).
+		Select("id").
+		Where("user_id = ? AND status IN ?", userID, finishedStatuses).
+		Order("started_at DESC").
+		Limit(lastContests)
+
+	var counts struct {
+		Served int64
+		Solved int64
+	}
+	result := r.db.Model(&domain.ContestProblem{}).
+		Select(`COUNT(*) AS served,
+			COALESCE(SUM(CASE WHEN is_completed THEN 1 ELSE 0 END), 0) AS solved`).
+		Where("contest_id IN (?) AND NOT is_warmup", recentContests).
+		Scan(&counts)
+	return counts.Served, counts.Solved, result.Error
+}
+
+// FindRecentSolveConfidence counts the problems solved in the user's last
+// finished contests per confidence rating; unrated solves are left out
+func (r *problemRepository) FindRecentSolveConfidence(userID uuid.UUID, lastContests int) (map[int]int64, error) {
+	recentContests := r.db.Model(&domain.Contest{}).
+		Select("id").
+		Where("user_id = ? AND status IN ?", userID, finishedStatuses).
+		Order("started_at DESC").
+		Limit(lastContests)
+
+	var rows []struct {
+		Confidence int
+		Count      int64
+	}
+	result := r.db.Model(&domain.ContestProblem{}).
+		Select("submissions.confidence, COUNT(*) AS count").
+		Joins("JOIN submissions ON submissions.problem_id = contest_problems.problem_id AND submissions.user_id = ?", userID).
+		Where("contest_problems.contest_id IN (?) AND contest_problems.is_completed AND NOT contest_problems.is_warmup", recentContests).
+		Where("submissions.confidence IS NOT NULL").
+		Group("submissions.confidence").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[int]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Confidence] = row.Count
+	}
+	return counts, nil
+}
+
+// Count returns the total number of catalog problems
+func (r *problemRepository) Count() (int64, error) {
+	var count int64
+	result := r.db.Model(&domain.Problem{}).Scopes(catalogOnly).Count(&count)
+	return count, result.Error
+}
+
+// IncrementTimesSelected bumps the selection counter of each given problem
+func (r *problemRepository) IncrementTimesSelected(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&domain.Problem{}).
+		Where("id IN ?", ids).
+		UpdateColumn("times_selected", gorm.Expr("times_selected + 1")).Error
+}
+
+// AddTimesCompleted adjusts the completion counter of a problem, never dropping below zero
+func (r *problemRepository) AddTimesCompleted(id uuid.UUID, delta int) error {
+	return r.db.Model(&domain.Problem{}).
+		Where("id = ?", id).
+		UpdateColumn("times_completed", gorm.Expr("CASE WHEN times_completed + ? < 0 THEN 0 ELSE times_completed + ? END", delta, delta)).Error
+}
+
+// FindPrerequisites returns the direct prerequisites of a problem
+func (r *problemRepository) FindPrerequisites(problemID uuid.UUID) ([]domain.Problem, error) {
+	var problems []domain.Problem
+
+	prerequisiteSubquery := r.db.Model(&domain.ProblemPrerequisite{}).
+		Select("prerequisite_id").
+		Where("problem_id = ?", problemID)
+
+	result := r.db.Where("id IN (?)", prerequisiteSubquery).
+		Order("order_index ASC").
+		Find(&problems)
+
+	return problems, result.Error
+}
+
+// FindLockedIDsByUser returns the IDs of problems with at least one prerequisite the user has not solved
+func (r *problemRepository) FindLockedIDsByUser(userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
 	// Subquery to get solved problem IDs
 	solvedSubquery := r.db.Model(&domain.Submission{}).
 		Select("problem_id").
 		Where("user_id = ?", userID)
-	
-	result := r.db.Where("id NOT IN (?)", solvedSubquery).
-		Where("difficulty = ?", difficulty).
-		Order("RANDOM()"). // Randomize selection within difficulty
+
+	result := r.db.Model(&domain.ProblemPrerequisite{}).
+		Distinct("problem_id").
+		Where("prerequisite_id NOT IN (?)", solvedSubquery).
+		Pluck("problem_id", &ids)
+
+	return ids, result.Error
+}
+
+// FindIDsByCompanies returns the IDs of problems tagged with any of the companies (case-insensitive)
+func (r *problemRepository) FindIDsByCompanies(companies []string) ([]uuid.UUID, error) {
+	lowered := make([]string, len(companies))
+	for i, c := range companies {
+		lowered[i] = strings.ToLower(c)
+	}
+
+	var ids []uuid.UUID
+	result := r.db.Model(&domain.ProblemCompany{}).
+		Distinct("problem_id").
+		Where("LOWER(company) IN ?", lowered).
+		Pluck("problem_id", &ids)
+	return ids, result.Error
+}
+
+// FindCompanies returns every company with its number of tagged problems, most common first
+func (r *problemRepository) FindCompanies() ([]domain.CompanyCount, error) {
+	var companies []domain.CompanyCount
+	result := r.db.Model(&domain.ProblemCompany{}).
+		Select("company AS name, COUNT(*) AS count").
+		Group("company").
+		Order("count DESC, company ASC").
+		Scan(&companies)
+	return companies, result.Error
+}
+
+// SetCompanies replaces the company tags of a problem
+func (r *problemRepository) SetCompanies(problemID uuid.UUID, companies []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("problem_id = ?", problemID).Delete(&domain.ProblemCompany{}).Error; err != nil {
+			return err
+		}
+		if len(companies) == 0 {
+			return nil
+		}
+		rows := make([]domain.ProblemCompany, len(companies))
+		for i, c := range companies {
+			rows[i] = domain.ProblemCompany{ProblemID: problemID, Company: c}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// SetImportance sets the importance score of a problem
+func (r *problemRepository) SetImportance(id uuid.UUID, importance int) error {
+	result := r.db.Model(&domain.Problem{}).
+		Where("id = ?", id).
+		UpdateColumn("importance", importance)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrProblemNotFound
+	}
+	return nil
+}
+
+// SetComplexity sets the canonical time and space complexity of a problem
+func (r *problemRepository) SetComplexity(id uuid.UUID, timeComplexity, spaceComplexity string) error {
+	result := r.db.Model(&domain.Problem{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"time_complexity":  timeComplexity,
+			"space_complexity": spaceComplexity,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrProblemNotFound
+	}
+	return nil
+}
+
+// FindByOwner returns a user's custom problems ordered by title
+func (r *problemRepository) FindByOwner(ownerID uuid.UUID) ([]domain.Problem, error) {
+	var problems []domain.Problem
+	result := r.db.Where("owner_id = ?", ownerID).
+		Order("title ASC").
 		Find(&problems)
-	
 	return problems, result.Error
 }
 
-// Count returns the total number of problems
-func (r *problemRepository) Count() (int64, error) {
+// FindByOwnerAndURL finds a user's custom problem by its URL, returning nil when none exists
+func (r *problemRepository) FindByOwnerAndURL(ownerID uuid.UUID, url string) (*domain.Problem, error) {
+	var problems []domain.Problem
+	result := r.db.Where("owner_id = ? AND leet_code_url = ?", ownerID, url).Limit(1).Find(&problems)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(problems) == 0 {
+		return nil, nil
+	}
+	return &problems[0], nil
+}
+
+// CountByOwner returns the number of custom problems owned by a user
+func (r *problemRepository) CountByOwner(ownerID uuid.UUID) (int64, error) {
 	var count int64
-	result := r.db.Model(&domain.Problem{}).Count(&count)
+	result := r.db.Model(&domain.Problem{}).Where("owner_id = ?", ownerID).Count(&count)
 	return count, result.Error
 }
 
-// WithContext returns a repository with the given context for tracing
+// Update updates an existing problem's columns
+func (r *problemRepository) Update(problem *domain.Problem) error {
+	return r.db.Omit(clause.Associations).Save(problem).Error
+}
+
+// Delete deletes a problem by its ID
+func (r *problemRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&domain.Problem{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrProblemNotFound
+	}
+	return nil
+}
+
+// IsReferenced reports whether any contest or submission uses the problem
+func (r *problemRepository) IsReferenced(id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.ContestProblem{}).Where("problem_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := r.db.Model(&domain.Submission{}).Where("problem_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// unsolvedBy excludes problems the user has a submission for. NOT EXISTS is planned as
+// an anti-join probing idx_submissions_user_problem, instead of materializing all of the
+// user's submissions for a NOT IN list (which also never matches if a NULL slips in).
+func unsolvedBy(userID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("NOT EXISTS (SELECT 1 FROM submissions WHERE submissions.problem_id = problems.id AND submissions.user_id = ?)", userID)
+	}
+}
+
+// catalogOnly restricts a query to the shared catalog, excluding users' custom problems
+func catalogOnly(db *gorm.DB) *gorm.DB {
+	return db.Where("problems.owner_id IS NULL")
+}
+
+// orderCompanies preloads a problem's companies in alphabetical order
+func orderCompanies(db *gorm.DB) *gorm.DB {
+	return db.Order("company ASC")
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
 func (r *problemRepository) WithContext(ctx context.Context) domain.ProblemRepository {
-	return &problemRepository{db: r.db.WithContext(ctx)}
+	return &problemRepository{db: infrastructure.DBFor(ctx, r.db)}
 }
@@ -0,0 +1,171 @@
+package repository_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/data"
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/repository"
+	"github.com/contest-maker-150/backend/internal/testutil"
+)
+
+// The unsolved-problem benchmarks run on in-memory SQLite, or on a Postgres
+// container when TEST_POSTGRES is set:
+//
+//	go test -run '^$' -bench FindUnsolved ./internal/repository/ -args -bench-users 3000 -bench-per-user 150
+var (
+	benchUsers   = flag.Int("bench-users", 2000, "synthetic users of the unsolved-problem benchmarks")
+	benchPerUser = flag.Int("bench-per-user", 100, "submissions per synthetic user")
+)
+
+// submissionsBench is the database shared by the unsolved-problem benchmarks
+// and the user whose unsolved problems they select
+var submissionsBench struct {
+	once     sync.Once
+	db       *gorm.DB
+	target   uuid.UUID
+	err      error
+	teardown []func()
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	code := m.Run()
+	// Close the database before stopping its server
+	teardown := submissionsBench.teardown
+	for i := len(teardown) - 1; i >= 0; i-- {
+		teardown[i]()
+	}
+	os.Exit(code)
+}
+
+// benchDatabase migrates and seeds the shared database on first use and fills
+// it with synthetic users and submissions
+func benchDatabase(b *testing.B) (*gorm.DB, uuid.UUID) {
+	b.Helper()
+	bench := &submissionsBench
+	bench.once.Do(func() {
+		config := infrastructure.LoadConfig().Database
+		config.Driver = infrastructure.DriverSQLite
+		config.SQLitePath = ":memory:"
+		if os.Getenv("TEST_POSTGRES") != "" {
+			pg, err := testutil.StartPostgres(context.Background())
+			if err != nil {
+				bench.err = err
+				return
+			}
+			bench.teardown = append(bench.teardown, func() { pg.Stop() })
+			config = pg.Config(config)
+		}
+
+		logger := zap.NewNop()
+		database, err := infrastructure.NewDatabase(&config, logger)
+		if err != nil {
+			bench.err = fmt.Errorf("connect: %w", err)
+			return
+		}
+		bench.teardown = append(bench.teardown, func() { database.Close() })
+		if err := database.AutoMigrate(); err != nil {
+			bench.err = fmt.Errorf("migrate: %w", err)
+			return
+		}
+		if err := data.NewSeeder(database.DB, logger).SeedProblems(); err != nil {
+			bench.err = fmt.Errorf("seed problems: %w", err)
+			return
+		}
+		bench.db = database.DB
+		bench.target, bench.err = populate(database.DB, *benchUsers, *benchPerUser)
+	})
+	if bench.err != nil {
+		b.Fatal(bench.err)
+	}
+	return bench.db, bench.target
+}
+
+// populate creates users with random submissions and returns the user whose
+// unsolved problems are selected
+func populate(db *gorm.DB, users, perUser int) (uuid.UUID, error) {
+	var problemIDs []uuid.UUID
+	if err := db.Model(&domain.Problem{}).Pluck("id", &problemIDs).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	var target uuid.UUID
+	for u := 0; u < users; u++ {
+		user := domain.User{
+			Email:        fmt.Sprintf("bench%d@example.com", u),
+			Username:     fmt.Sprintf("bench%d", u),
+			PasswordHash: "-",
+		}
+		if err := db.Create(&user).Error; err != nil {
+			return uuid.Nil, err
+		}
+		if u == 0 {
+			target = user.ID
+		}
+
+		// A user has at most one submission per problem
+		picks := rng.Perm(len(problemIDs))[:min(perUser, len(problemIDs))]
+		submissions := make([]domain.Submission, len(picks))
+		for i, pick := range picks {
+			submissions[i] = domain.Submission{
+				UserID:    user.ID,
+				ProblemID: problemIDs[pick],
+				SolvedAt:  time.Now(),
+			}
+		}
+		if err := db.CreateInBatches(submissions, 500).Error; err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return target, nil
+}
+
+// BenchmarkFindUnsolved_NotIn measures the NOT IN + ORDER BY RANDOM() query
+// FindUnsolvedByUserAndDifficulty used before the anti-join, for every difficulty
+func BenchmarkFindUnsolved_NotIn(b *testing.B) {
+	db, target := benchDatabase(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, d := range domain.AllDifficulties {
+			var problems []domain.Problem
+			solved := db.Model(&domain.Submission{}).Select("problem_id").Where("user_id = ?", target)
+			err := db.Where("owner_id IS NULL").
+				Where("id NOT IN (?)", solved).
+				Where("difficulty = ?", d).
+				Order("RANDOM()").
+				Find(&problems).Error
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkFindUnsolved_NotExists measures FindUnsolvedByUserAndDifficulty, a
+// NOT EXISTS anti-join on the submissions index, for every difficulty
+func BenchmarkFindUnsolved_NotExists(b *testing.B) {
+	db, target := benchDatabase(b)
+	repo := repository.NewProblemRepository(db)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, d := range domain.AllDifficulties {
+			if _, err := repo.FindUnsolvedByUserAndDifficulty(target, d, false); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
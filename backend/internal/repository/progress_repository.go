@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// progressRebuildBatchSize is how many summary rows are upserted per statement
+const progressRebuildBatchSize = 500
+
+// progressRepository implements domain.UserProgressRepository using GORM
+type progressRepository struct {
+	db *gorm.DB
+}
+
+// NewProgressRepository creates a new progress summary repository
+func NewProgressRepository(db *gorm.DB) domain.UserProgressRepository {
+	return &progressRepository{db: db}
+}
+
+// FindByUserID finds a user's progress summary, returning nil if there is none
+func (r *progressRepository) FindByUserID(userID uuid.UUID) (*domain.UserProgressSummary, error) {
+	var summary domain.UserProgressSummary
+	result := r.db.Where("user_id = ?", userID).First(&summary)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &summary, nil
+}
+
+// addSolved counts a newly solved problem in its difficulty within tx; custom
+// problems are not counted but still mark the user active
+func addSolved(tx *gorm.DB, userID, problemID uuid.UUID, at time.Time) error {
+	var problem domain.Problem
+	result := tx.Select("difficulty", "owner_id").Where("id = ?", problemID).First(&problem)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.ErrProblemNotFound
+		}
+		return result.Error
+	}
+	if problem.IsCustom() {
+		return addProgress(tx, userID, nil, at)
+	}
+
+	var column string
+	switch problem.Difficulty {
+	case domain.DifficultyEasy:
+		column = "easy_solved"
+	case domain.DifficultyMedium:
+		column = "medium_solved"
+	case domain.DifficultyHard:
+		column = "hard_solved"
+	default:
+		return domain.ErrInvalidDifficulty
+	}
+	return addProgress(tx, userID, map[string]int{column: 1}, at)
+}
+
+// addProgress adds deltas to the counter columns of the user's summary within
+// tx and moves its last activity forward to at, creating the row on first use
+func addProgress(tx *gorm.DB, userID uuid.UUID, deltas map[string]int, at time.Time) error {
+	summary := map[string]interface{}{
+		"user_id":        userID,
+		"last_active_at": at,
+		"updated_at":     time.Now(),
+	}
+	updates := map[string]interface{}{
+		"last_active_at": gorm.Expr("CASE WHEN user_progress.last_active_at IS NULL " +
+			"OR user_progress.last_active_at < excluded.last_active_at " +
+			"THEN excluded.last_active_at ELSE user_progress.last_active_at END"),
+		"updated_at": gorm.Expr("excluded.updated_at"),
+	}
+	for column, delta := range deltas {
+		summary[column] = delta
+		updates[column] = gorm.Expr("user_progress."+column+" + ?", delta)
+	}
+
+	return tx.Model(&domain.UserProgressSummary{}).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(updates),
+		}).
+		Create(summary).Error
+}
+
+// FindMembers joins the users to their materialized summaries, counting the
+// matching users with a window function so one query returns both the page and
+// the total. A page past the end reports a total of 0.
+func (r *progressRepository) FindMembers(userIDs []uuid.UUID, limit, offset int) ([]domain.MemberProgress, int64, error) {
+	if len(userIDs) == 0 {
+		return []domain.MemberProgress{}, 0, nil
+	}
+
+	var rows []struct {
+		domain.MemberProgress
+		LastActive nullTime
+		Total      int64
+	}
+	err := r.db.Table("users").
+		Select("users.id AS user_id, users.username, "+
+			"COALESCE(p.easy_solved, 0) AS easy_solved, "+
+			"COALESCE(p.medium_solved, 0) AS medium_solved, "+
+			"COALESCE(p.hard_solved, 0) AS hard_solved, "+
+			"COALESCE(p.total_contests, 0) AS total_contests, "+
+			"COALESCE(p.completed_contests, 0) AS completed_contests, "+
+			"p.last_active_at AS last_active, "+
+			"COUNT(*) OVER () AS total").
+		Joins("LEFT JOIN user_progress p ON p.user_id = users.id").
+		Where("users.id IN ?", userIDs).
+		Order("users.username ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members := make([]domain.MemberProgress, len(rows))
+	var total int64
+	for i, row := range rows {
+		member := row.MemberProgress
+		member.TotalSolved = member.EasySolved + member.MediumSolved + member.HardSolved
+		member.LastActiveAt = row.LastActive.Ptr()
+		if member.TotalContests > 0 {
+			member.CompletionRate = float64(member.CompletedContests) / float64(member.TotalContests)
+		}
+		members[i] = member
+		total = row.Total
+	}
+	return members, total, nil
+}
+
+// Rebuild recomputes every user's summary. Writes counted while a rebuild runs
+// may be overwritten; the next rebuild picks them up again.
+func (r *progressRepository) Rebuild() (int64, error) {
+	summaries, err := r.recompute(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([]domain.UserProgressSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		rows = append(rows, *summary)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			UpdateAll: true,
+		}).
+		CreateInBatches(rows, progressRebuildBatchSize)
+	return int64(len(rows)), result.Error
+}
+
+// recompute derives the summaries of the given users, or of every user when
+// none are given, from submissions and contests
+func (r *progressRepository) recompute(now time.Time, only ...uuid.UUID) (map[uuid.UUID]*domain.UserProgressSummary, error) {
+	// forUsers narrows a query on a table with a user_id column
+	forUsers := func(column string) func(*gorm.DB) *gorm.DB {
+		return func(db *gorm.DB) *gorm.DB {
+			if len(only) == 0 {
+				return db
+			}
+			return db.Where(column+" IN ?", only)
+		}
+	}
+
+	var userIDs []uuid.UUID
+	if err := r.db.Model(&domain.User{}).Scopes(forUsers("id")).Pluck("id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[uuid.UUID]*domain.UserProgressSummary, len(userIDs))
+	for _, id := range userIDs {
+		summaries[id] = &domain.UserProgressSummary{UserID: id, UpdatedAt: now}
+	}
+
+	var solved []struct {
+		UserID     uuid.UUID
+		Difficulty domain.Difficulty
+		Count      int
+	}
+	if err := r.db.Model(&domain.Submission{}).
+		Select("submissions.user_id, problems.difficulty, COUNT(DISTINCT submissions.problem_id) AS count").
+		Joins("JOIN problems ON submissions.problem_id = problems.id").
+		Where("problems.owner_id IS NULL").
+		Scopes(forUsers("submissions.user_id")).
+		Group("submissions.user_id, problems.difficulty").
+		Scan(&solved).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range solved {
+		summary, ok := summaries[row.UserID]
+		if !ok {
+			continue
+		}
+		switch row.Difficulty {
+		case domain.DifficultyEasy:
+			summary.EasySolved = row.Count
+		case domain.DifficultyMedium:
+			summary.MediumSolved = row.Count
+		case domain.DifficultyHard:
+			summary.HardSolved = row.Count
+		}
+	}
+
+	var contests []struct {
+		UserID    uuid.UUID
+		Total     int
+		Completed int
+		Abandoned int
+	}
+	if err := r.db.Model(&domain.Contest{}).
+		Select("user_id, COUNT(*) AS total, "+
+			"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS completed, "+
+			"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS abandoned",
+			domain.ContestStatusCompleted, domain.ContestStatusAbandoned).
+		Where("status <> ?", domain.ContestStatusPending).
+		Scopes(forUsers("user_id")).
+		Group("user_id").
+		Scan(&contests).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range contests {
+		if summary, ok := summaries[row.UserID]; ok {
+			summary.TotalContests = row.Total
+			summary.CompletedContests = row.Completed
+			summary.AbandonedContests = row.Abandoned
+		}
+	}
+
+	// The same events that move last_active_at forward as they are written
+	var activity []struct {
+		UserID     uuid.UUID
+		LastActive nullTime
+	}
+	if err := r.db.Table("(" +
+		"SELECT user_id, created_at AS at FROM contests WHERE status <> 'pending' " +
+		"UNION ALL SELECT user_id, ended_at AS at FROM contests WHERE ended_at IS NOT NULL " +
+		"UNION ALL SELECT user_id, solved_at AS at FROM submissions" +
+		") events").
+		Select("user_id, MAX(at) AS last_active").
+		Scopes(forUsers("user_id")).
+		Group("user_id").
+		Scan(&activity).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range activity {
+		if summary, ok := summaries[row.UserID]; ok {
+			summary.LastActiveAt = row.LastActive.Ptr()
+		}
+	}
+	return summaries, nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *progressRepository) WithContext(ctx context.Context) domain.UserProgressRepository {
+	return &progressRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// publicStatsRepository implements domain.PublicStatsRepository using GORM
+type publicStatsRepository struct {
+	db *gorm.DB
+}
+
+// NewPublicStatsRepository creates a new public stats repository
+func NewPublicStatsRepository(db *gorm.DB) domain.PublicStatsRepository {
+	return &publicStatsRepository{db: db}
+}
+
+// FindMostAttempted returns the catalog problems attempted by at least minUsers
+// users, most attempts first. Like the counts below, it only covers the users
+// of the request's tenant.
+func (r *publicStatsRepository) FindMostAttempted(limit, minUsers int) ([]domain.PopularProblem, error) {
+	var problems []domain.PopularProblem
+	err := r.db.Table("attempts").
+		Select(`problems.id, problems.title, problems.slug, problems.difficulty,
+			COUNT(*) AS attempts,
+			COUNT(DISTINCT attempts.user_id) AS users,
+			COUNT(DISTINCT CASE WHEN attempts.outcome = ? THEN attempts.user_id END) AS solvers`, domain.AttemptSolved).
+		Joins("JOIN problems ON problems.id = attempts.problem_id").
+		Joins("JOIN users ON users.id = attempts.user_id").
+		Where("problems.owner_id IS NULL").
+		Scopes(tenantScope("users.tenant_id")).
+		Group("problems.id, problems.title, problems.slug, problems.difficulty").
+		Having("COUNT(DISTINCT attempts.user_id) >= ?", minUsers).
+		Order("attempts DESC, problems.title ASC").
+		Limit(limit).
+		Scan(&problems).Error
+	return problems, err
+}
+
+// CountFinishedContests counts completed and abandoned contests
+func (r *publicStatsRepository) CountFinishedContests() (int64, int64, error) {
+	var rows []struct {
+		Status domain.ContestStatus
+		Total  int64
+	}
+	if err := r.db.Model(&domain.Contest{}).
+		Select("contests.status, COUNT(*) AS total").
+		Joins("JOIN users ON users.id = contests.user_id").
+		Where("contests.status IN ?", []domain.ContestStatus{domain.ContestStatusCompleted, domain.ContestStatusAbandoned}).
+		Scopes(tenantScope("users.tenant_id")).
+		Group("contests.status").
+		Scan(&rows).Error; err != nil {
+		return 0, 0, err
+	}
+
+	var completed, abandoned int64
+	for _, row := range rows {
+		if row.Status == domain.ContestStatusCompleted {
+			completed = row.Total
+		} else {
+			abandoned = row.Total
+		}
+	}
+	return completed, abandoned, nil
+}
+
+// CountContestSizes counts finished contests per problem count, leaving out
+// sizes with fewer than minContests contests
+func (r *publicStatsRepository) CountContestSizes(minContests int) ([]domain.ContestSizeCount, error) {
+	sizes := r.db.Table("contest_problems").
+		Select("contest_problems.contest_id, COUNT(*) AS problem_count").
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Joins("JOIN users ON users.id = contests.user_id").
+		Where("contests.status IN ?", finishedStatuses).
+		Scopes(tenantScope("users.tenant_id")).
+		Group("contest_problems.contest_id")
+
+	var counts []domain.ContestSizeCount
+	err := r.db.Table("(?) AS sizes", sizes).
+		Select("problem_count, COUNT(*) AS contests").
+		Group("problem_count").
+		Having("COUNT(*) >= ?", minContests).
+		Order("contests DESC, problem_count ASC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *publicStatsRepository) WithContext(ctx context.Context) domain.PublicStatsRepository {
+	return &publicStatsRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
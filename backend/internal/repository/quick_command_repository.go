@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// quickCommandRepository implements domain.QuickCommandRepository using GORM
+type quickCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewQuickCommandRepository creates a new quick command repository
+func NewQuickCommandRepository(db *gorm.DB) domain.QuickCommandRepository {
+	return &quickCommandRepository{db: db}
+}
+
+// Create stores an audit trail entry
+func (r *quickCommandRepository) Create(entry *domain.QuickCommandLog) error {
+	return r.db.Create(entry).Error
+}
+
+// FindByUserID returns the user's latest quick commands, newest first
+func (r *quickCommandRepository) FindByUserID(userID uuid.UUID, limit int) ([]domain.QuickCommandLog, error) {
+	var entries []domain.QuickCommandLog
+	result := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries)
+	return entries, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *quickCommandRepository) WithContext(ctx context.Context) domain.QuickCommandRepository {
+	return &quickCommandRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// quotaRepository implements domain.QuotaRepository using GORM
+type quotaRepository struct {
+	db *gorm.DB
+}
+
+// NewQuotaRepository creates a new quota repository
+func NewQuotaRepository(db *gorm.DB) domain.QuotaRepository {
+	return &quotaRepository{db: db}
+}
+
+// FindOverride returns the user's override, or nil if there is none
+func (r *quotaRepository) FindOverride(userID uuid.UUID) (*domain.QuotaOverride, error) {
+	var override domain.QuotaOverride
+	err := r.db.First(&override, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// SaveOverride creates or replaces the user's override
+func (r *quotaRepository) SaveOverride(override *domain.QuotaOverride) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"contests_per_day", "custom_problems", "reason", "updated_by", "updated_at"}),
+	}).Create(override).Error
+}
+
+// DeleteOverride removes the user's override; removing a missing one is not an error
+func (r *quotaRepository) DeleteOverride(userID uuid.UUID) error {
+	return r.db.Delete(&domain.QuotaOverride{}, "user_id = ?", userID).Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *quotaRepository) WithContext(ctx context.Context) domain.QuotaRepository {
+	return &quotaRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
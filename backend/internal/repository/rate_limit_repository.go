@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// rateLimitRepository implements domain.RateLimitRepository using GORM
+type rateLimitRepository struct {
+	db *gorm.DB
+}
+
+// NewRateLimitRepository creates a new rate limit repository
+func NewRateLimitRepository(db *gorm.DB) domain.RateLimitRepository {
+	return &rateLimitRepository{db: db}
+}
+
+// Increment counts a request in the bucket's window with a single upsert and
+// returns the count including it. Starting a new window prunes counters of ended windows.
+func (r *rateLimitRepository) Increment(bucket string, windowStart, expiresAt time.Time) (int, error) {
+	var count int
+	err := r.db.Raw(`INSERT INTO rate_limit_counters (bucket, window_start, count, expires_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (bucket, window_start) DO UPDATE SET count = rate_limit_counters.count + 1
+		RETURNING count`, bucket, windowStart, expiresAt).Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 {
+		if err := r.db.Where("expires_at < ?", windowStart).Delete(&domain.RateLimitCounter{}).Error; err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *rateLimitRepository) WithContext(ctx context.Context) domain.RateLimitRepository {
+	return &rateLimitRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// retentionRows are the policies that delete rows of a single table
+var retentionRows = map[domain.RetentionPolicy]struct {
+	model     interface{}
+	table     string
+	key       string
+	condition string
+}{
+	domain.RetentionQuickCommandLogs: {&domain.QuickCommandLog{}, "quick_commands", "id", "created_at < ?"},
+	domain.RetentionBillingEvents:    {&domain.BillingEvent{}, "billing_events", "id", "processed_at < ?"},
+}
+
+// expiredContests selects the abandoned contests past the cutoff. Contests of a
+// challenge are kept so the comparison stays available, and contests started
+// for an assignment so the instructor still sees them.
+const expiredContests = `status = 'abandoned' AND created_at < ? AND NOT EXISTS (
+	SELECT 1 FROM contest_challenges ch WHERE ch.contest_id = contests.id OR ch.opponent_contest_id = contests.id)
+	AND NOT EXISTS (SELECT 1 FROM org_assignment_contests l WHERE l.contest_id = contests.id)`
+
+// retentionRepository implements domain.RetentionRepository using GORM
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *gorm.DB) domain.RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+// Count returns how many rows the policy would delete
+func (r *retentionRepository) Count(policy domain.RetentionPolicy, cutoff time.Time) (int64, error) {
+	var count int64
+	if rows, ok := retentionRows[policy]; ok {
+		err := r.db.Model(rows.model).Where(rows.condition, cutoff).Count(&count).Error
+		return count, err
+	}
+	if policy == domain.RetentionAbandonedContests {
+		err := r.db.Model(&domain.Contest{}).Where(expiredContests, cutoff).Count(&count).Error
+		return count, err
+	}
+	return 0, fmt.Errorf("unknown retention policy %q", policy)
+}
+
+// Purge deletes up to limit rows of the policy in one transaction
+func (r *retentionRepository) Purge(policy domain.RetentionPolicy, cutoff time.Time, limit int) (int64, error) {
+	if rows, ok := retentionRows[policy]; ok {
+		result := r.db.Exec(
+			fmt.Sprintf("DELETE FROM %[1]s WHERE %[2]s IN (SELECT %[2]s FROM %[1]s WHERE %[3]s LIMIT ?)", rows.table, rows.key, rows.condition),
+			cutoff, limit)
+		return result.RowsAffected, result.Error
+	}
+	if policy == domain.RetentionAbandonedContests {
+		return r.purgeContests(cutoff, limit)
+	}
+	return 0, fmt.Errorf("unknown retention policy %q", policy)
+}
+
+// purgeContests deletes a batch of expired contests with their problems and
+// tags. Solves and attempts made in them are kept without the contest, and the
+// usage counters and progress summaries that counted them are recomputed.
+func (r *retentionRepository) purgeContests(cutoff time.Time, limit int) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var contests []domain.Contest
+		if err := tx.Select("id", "user_id").Where(expiredContests, cutoff).Limit(limit).Find(&contests).Error; err != nil {
+			return err
+		}
+		if len(contests) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(contests))
+		users := make(map[uuid.UUID]struct{})
+		for i, c := range contests {
+			ids[i] = c.ID
+			users[c.UserID] = struct{}{}
+		}
+
+		var problemIDs []uuid.UUID
+		if err := tx.Model(&domain.ContestProblem{}).Distinct().Where("contest_id IN ?", ids).Pluck("problem_id", &problemIDs).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&domain.Submission{}, &domain.Attempt{}, &domain.QuickCommandLog{}} {
+			if err := tx.Model(model).Where("contest_id IN ?", ids).Update("contest_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("contest_id IN ?", ids).Delete(&domain.ContestTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contest_id IN ?", ids).Delete(&domain.ContestProblem{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", ids).Delete(&domain.Contest{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+
+		if len(problemIDs) > 0 {
+			if err := tx.Exec(`UPDATE problems SET times_selected = `+selectedCount+`, times_completed = `+completedCount+
+				` WHERE id IN ?`, problemIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		userIDs := make([]uuid.UUID, 0, len(users))
+		for userID := range users {
+			userIDs = append(userIDs, userID)
+		}
+		summaries, err := (&progressRepository{db: tx}).recompute(time.Now(), userIDs...)
+		if err != nil {
+			return err
+		}
+		rows := make([]domain.UserProgressSummary, 0, len(summaries))
+		for _, summary := range summaries {
+			rows = append(rows, *summary)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			UpdateAll: true,
+		}).CreateInBatches(rows, progressRebuildBatchSize).Error
+	})
+	return purged, err
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *retentionRepository) WithContext(ctx context.Context) domain.RetentionRepository {
+	return &retentionRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// roadmapRepository implements domain.RoadmapRepository using GORM
+type roadmapRepository struct {
+	db *gorm.DB
+}
+
+// NewRoadmapRepository creates a new roadmap repository
+func NewRoadmapRepository(db *gorm.DB) domain.RoadmapRepository {
+	return &roadmapRepository{db: db}
+}
+
+// FindCategories returns all categories in order, each with its problems in order
+func (r *roadmapRepository) FindCategories() ([]domain.RoadmapCategory, error) {
+	var categories []domain.RoadmapCategory
+	result := r.db.
+		Preload("Problems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Problems.Problem").
+		Order("position ASC").
+		Find(&categories)
+	return categories, result.Error
+}
+
+// FindNextUnsolved returns up to n problems the user has not solved, in roadmap order
+func (r *roadmapRepository) FindNextUnsolved(userID uuid.UUID, n int) ([]domain.Problem, error) {
+	var problems []domain.Problem
+
+	// Subquery to get solved problem IDs
+	solvedSubquery := r.db.Model(&domain.Submission{}).
+		Select("problem_id").
+		Where("user_id = ?", userID)
+
+	result := r.db.
+		Joins("JOIN roadmap_problems ON roadmap_problems.problem_id = problems.id").
+		Joins("JOIN roadmap_categories ON roadmap_categories.id = roadmap_problems.category_id").
+		Where("problems.id NOT IN (?)", solvedSubquery).
+		Order("roadmap_categories.position ASC, roadmap_problems.position ASC").
+		Limit(n).
+		Find(&problems)
+
+	return problems, result.Error
+}
+
+// FindSolvedProblemIDs returns the IDs of all problems the user has solved
+func (r *roadmapRepository) FindSolvedProblemIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	result := r.db.Model(&domain.Submission{}).
+		Distinct("problem_id").
+		Where("user_id = ?", userID).
+		Pluck("problem_id", &ids)
+	return ids, result.Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *roadmapRepository) WithContext(ctx context.Context) domain.RoadmapRepository {
+	return &roadmapRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
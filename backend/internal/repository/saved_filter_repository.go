@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// savedFilterRepository implements domain.SavedFilterRepository using GORM
+type savedFilterRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedFilterRepository creates a new saved filter repository
+func NewSavedFilterRepository(db *gorm.DB) domain.SavedFilterRepository {
+	return &savedFilterRepository{db: db}
+}
+
+// Create creates a new saved filter in the database
+func (r *savedFilterRepository) Create(filter *domain.SavedFilter) error {
+	return r.db.Create(filter).Error
+}
+
+// FindByID finds a saved filter by its ID
+func (r *savedFilterRepository) FindByID(id uuid.UUID) (*domain.SavedFilter, error) {
+	var filter domain.SavedFilter
+	result := r.db.Where("id = ?", id).First(&filter)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrFilterNotFound
+		}
+		return nil, result.Error
+	}
+	return &filter, nil
+}
+
+// FindByUserID returns all saved filters of a user ordered by name
+func (r *savedFilterRepository) FindByUserID(userID uuid.UUID) ([]domain.SavedFilter, error) {
+	var filters []domain.SavedFilter
+	result := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&filters)
+	return filters, result.Error
+}
+
+// FindByUserAndName finds a user's saved filter by name, returning nil if there is none
+func (r *savedFilterRepository) FindByUserAndName(userID uuid.UUID, name string) (*domain.SavedFilter, error) {
+	var filter domain.SavedFilter
+	result := r.db.Where("user_id = ? AND name = ?", userID, name).First(&filter)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &filter, nil
+}
+
+// CountByUserID returns how many saved filters a user has
+func (r *savedFilterRepository) CountByUserID(userID uuid.UUID) (int64, error) {
+	var count int64
+	result := r.db.Model(&domain.SavedFilter{}).Where("user_id = ?", userID).Count(&count)
+	return count, result.Error
+}
+
+// Update updates an existing saved filter
+func (r *savedFilterRepository) Update(filter *domain.SavedFilter) error {
+	return r.db.Save(filter).Error
+}
+
+// Delete deletes a saved filter by its ID
+func (r *savedFilterRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&domain.SavedFilter{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrFilterNotFound
+	}
+	return nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *savedFilterRepository) WithContext(ctx context.Context) domain.SavedFilterRepository {
+	return &savedFilterRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// similarityRepository implements domain.SimilarityRepository using GORM
+type similarityRepository struct {
+	db *gorm.DB
+}
+
+// NewSimilarityRepository creates a new solution similarity repository
+func NewSimilarityRepository(db *gorm.DB) domain.SimilarityRepository {
+	return &similarityRepository{db: db}
+}
+
+// SaveSnippet creates the snippet or replaces the code of the existing one as
+// a new revision, which leaves it unchecked until the next round
+func (r *similarityRepository) SaveSnippet(snippet *domain.SolutionSnippet) error {
+	updates := clause.AssignmentColumns([]string{"language", "code", "fingerprints", "token_count", "updated_at"})
+	updates = append(updates, clause.Assignment{
+		Column: clause.Column{Name: "revision"},
+		Value:  gorm.Expr("solution_snippets.revision + 1"),
+	})
+	return r.db.Omit("Contest").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contest_id"}, {Name: "problem_id"}},
+		DoUpdates: updates,
+	}).Create(snippet).Error
+}
+
+// FindSnippet finds the snippet attached to a problem of a contest
+func (r *similarityRepository) FindSnippet(contestID, problemID uuid.UUID) (*domain.SolutionSnippet, error) {
+	var snippet domain.SolutionSnippet
+	result := r.db.Where("contest_id = ? AND problem_id = ?", contestID, problemID).First(&snippet)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSolutionNotFound
+		}
+		return nil, result.Error
+	}
+	return &snippet, nil
+}
+
+// FindUnchecked lists up to limit snippets of assignment contests whose current
+// version was not checked yet, oldest change first
+func (r *similarityRepository) FindUnchecked(limit int) ([]domain.AssignmentSnippet, error) {
+	var snippets []domain.AssignmentSnippet
+	result := r.db.Model(&domain.SolutionSnippet{}).
+		Select("solution_snippets.*, l.assignment_id").
+		Joins("JOIN org_assignment_contests l ON l.contest_id = solution_snippets.contest_id").
+		Where("solution_snippets.checked_revision < solution_snippets.revision").
+		Order("solution_snippets.updated_at").
+		Limit(limit).
+		Find(&snippets)
+	return snippets, result.Error
+}
+
+// FindPeers lists the snippets for the problem from the assignment's contests
+// other than the given one
+func (r *similarityRepository) FindPeers(assignmentID, problemID, contestID uuid.UUID) ([]domain.SolutionSnippet, error) {
+	var snippets []domain.SolutionSnippet
+	result := r.db.
+		Joins("JOIN org_assignment_contests l ON l.contest_id = solution_snippets.contest_id").
+		Where("l.assignment_id = ? AND solution_snippets.problem_id = ? AND solution_snippets.contest_id <> ?",
+			assignmentID, problemID, contestID).
+		Find(&snippets)
+	return snippets, result.Error
+}
+
+// MarkChecked records the checked revision; a snippet saved again in between
+// has a newer revision and stays unchecked
+func (r *similarityRepository) MarkChecked(contestID, problemID uuid.UUID, revision int) error {
+	return r.db.Model(&domain.SolutionSnippet{}).
+		Where("contest_id = ? AND problem_id = ? AND revision = ?", contestID, problemID, revision).
+		Update("checked_revision", revision).Error
+}
+
+// UpsertFlag stores a flag; a pair flagged before gets the new similarity and
+// keeps its review
+func (r *similarityRepository) UpsertFlag(flag *domain.SimilarityFlag) error {
+	return r.db.Omit("Assignment", "ContestA", "ContestB").Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "assignment_id"}, {Name: "problem_id"}, {Name: "contest_a_id"}, {Name: "contest_b_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"similarity", "detected_at"}),
+	}).Create(flag).Error
+}
+
+// DeletePendingFlag removes the pair's flag if no instructor reviewed it yet
+func (r *similarityRepository) DeletePendingFlag(assignmentID, problemID, contestAID, contestBID uuid.UUID) (bool, error) {
+	result := r.db.
+		Where("assignment_id = ? AND problem_id = ? AND contest_a_id = ? AND contest_b_id = ? AND status = ?",
+			assignmentID, problemID, contestAID, contestBID, domain.SimilarityPending).
+		Delete(&domain.SimilarityFlag{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindFlag finds a flag with its problem title and usernames
+func (r *similarityRepository) FindFlag(id uuid.UUID) (*domain.SimilarityFlagResponse, error) {
+	var flags []domain.SimilarityFlagResponse
+	if err := r.flags().Where("f.id = ?", id).Scan(&flags).Error; err != nil {
+		return nil, err
+	}
+	if len(flags) == 0 {
+		return nil, domain.ErrSimilarityFlagNotFound
+	}
+	return &flags[0], nil
+}
+
+// FindFlags lists the assignment's flags with the status, or all of them when
+// status is empty, most similar first
+func (r *similarityRepository) FindFlags(assignmentID uuid.UUID, status domain.SimilarityStatus) ([]domain.SimilarityFlagResponse, error) {
+	query := r.flags().Where("f.assignment_id = ?", assignmentID)
+	if status != "" {
+		query = query.Where("f.status = ?", status)
+	}
+
+	flags := []domain.SimilarityFlagResponse{}
+	if err := query.Order("f.similarity DESC, f.detected_at").Scan(&flags).Error; err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// flags selects flags joined with the names shown to instructors
+func (r *similarityRepository) flags() *gorm.DB {
+	return r.db.Table("solution_similarity_flags f").
+		Select("f.*, p.title AS problem_title, ua.username AS username_a, ub.username AS username_b").
+		Joins("JOIN problems p ON p.id = f.problem_id").
+		Joins("JOIN users ua ON ua.id = f.user_a_id").
+		Joins("JOIN users ub ON ub.id = f.user_b_id")
+}
+
+// UpdateReview saves an instructor's review of a flag
+func (r *similarityRepository) UpdateReview(flag *domain.SimilarityFlag) error {
+	return r.db.Model(flag).
+		Select("status", "note", "reviewed_by", "reviewed_at").
+		Updates(flag).Error
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *similarityRepository) WithContext(ctx context.Context) domain.SimilarityRepository {
+	return &similarityRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
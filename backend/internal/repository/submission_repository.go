@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
 // submissionRepository implements domain.SubmissionRepository using GORM
@@ -20,9 +23,23 @@ func NewSubmissionRepository(db *gorm.DB) domain.SubmissionRepository {
 	return &submissionRepository{db: db}
 }
 
-// Create creates a new submission record
-func (r *submissionRepository) Create(submission *domain.Submission) error {
-	return r.db.Create(submission).Error
+// Create inserts the submission, doing nothing when the unique (user_id,
+// problem_id) index already holds one, so concurrent solves cannot duplicate it.
+// An inserted submission is counted in the progress summary in the same transaction.
+func (r *submissionRepository) Create(submission *domain.Submission) (bool, error) {
+	var created bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "problem_id"}},
+			DoNothing: true,
+		}).Create(submission)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+		return addSolved(tx, submission.UserID, submission.ProblemID, submission.SolvedAt)
+	})
+	return created && err == nil, err
 }
 
 // FindByID finds a submission by its ID
@@ -96,15 +113,98 @@ func (r *submissionRepository) CountByUserID(userID uuid.UUID) (int64, error) {
 	return count, result.Error
 }
 
-// CountByUserAndDifficulty returns the count of solved problems by difficulty
-func (r *submissionRepository) CountByUserAndDifficulty(userID uuid.UUID, difficulty domain.Difficulty) (int64, error) {
-	var count int64
+// CountSolvedByDifficulty returns the number of solved catalog problems per difficulty
+// in a single GROUP BY query. Difficulties without solves are absent from the map.
+func (r *submissionRepository) CountSolvedByDifficulty(userID uuid.UUID) (map[domain.Difficulty]int, error) {
+	var rows []struct {
+		Difficulty domain.Difficulty
+		Count      int
+	}
 	result := r.db.Model(&domain.Submission{}).
+		Select("problems.difficulty, COUNT(DISTINCT submissions.problem_id) AS count").
 		Joins("JOIN problems ON submissions.problem_id = problems.id").
-		Where("submissions.user_id = ? AND problems.difficulty = ?", userID, difficulty).
-		Distinct("submissions.problem_id").
-		Count(&count)
-	return count, result.Error
+		Where("submissions.user_id = ? AND problems.owner_id IS NULL", userID).
+		Group("problems.difficulty").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[domain.Difficulty]int, len(rows))
+	for _, row := range rows {
+		counts[row.Difficulty] = row.Count
+	}
+	return counts, nil
+}
+
+// CountByTopic counts the catalog problems and the user's solved ones per topic
+// in a single GROUP BY query. A problem counts once under each of its topics.
+func (r *submissionRepository) CountByTopic(userID uuid.UUID) (map[string]domain.TopicStats, error) {
+	topics, topic := topicsJoin(r.db)
+
+	var rows []struct {
+		Topic  string
+		Total  int
+		Solved int
+	}
+	result := r.db.Model(&domain.Problem{}).
+		Select(topic+" AS topic, COUNT(*) AS total, COUNT(submissions.id) AS solved").
+		Joins(topics).
+		Joins("LEFT JOIN submissions ON submissions.problem_id = problems.id AND submissions.user_id = ?", userID).
+		Scopes(catalogOnly).
+		Group(topic).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	stats := make(map[string]domain.TopicStats, len(rows))
+	for _, row := range rows {
+		stats[row.Topic] = domain.TopicStats{Total: row.Total, Solved: row.Solved}
+	}
+	return stats, nil
+}
+
+// RecordResolve stamps the user's submission of the problem with the re-solve
+// and, when given, the new confidence rating
+func (r *submissionRepository) RecordResolve(userID, problemID uuid.UUID, confidence *int, solvedAt time.Time) error {
+	updates := map[string]interface{}{"last_solved_at": solvedAt}
+	if confidence != nil {
+		updates["confidence"] = *confidence
+	}
+	return r.db.Model(&domain.Submission{}).
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		UpdateColumns(updates).Error
+}
+
+// UpdateConfidence replaces the confidence rating of the user's submission of the problem
+func (r *submissionRepository) UpdateConfidence(userID, problemID uuid.UUID, confidence int) error {
+	return r.db.Model(&domain.Submission{}).
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		UpdateColumn("confidence", confidence).Error
+}
+
+// CountByConfidence counts the user's solved problems per confidence rating in
+// one GROUP BY query; unrated solves are counted under 0
+func (r *submissionRepository) CountByConfidence(userID uuid.UUID) (map[int]int, error) {
+	var rows []struct {
+		Confidence int
+		Count      int
+	}
+	result := r.db.Model(&domain.Submission{}).
+		Select("COALESCE(confidence, 0) AS confidence, COUNT(DISTINCT problem_id) AS count").
+		Where("user_id = ?", userID).
+		Group("COALESCE(confidence, 0)").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.Confidence] = row.Count
+	}
+	return counts, nil
 }
 
 // Delete deletes a submission by its ID
@@ -119,7 +219,8 @@ func (r *submissionRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
-// WithContext returns a repository with the given context for tracing
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
 func (r *submissionRepository) WithContext(ctx context.Context) domain.SubmissionRepository {
-	return &submissionRepository{db: r.db.WithContext(ctx)}
+	return &submissionRepository{db: infrastructure.DBFor(ctx, r.db)}
 }
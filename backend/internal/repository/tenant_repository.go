@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// tenantRepository implements domain.TenantRepository using GORM
+type tenantRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *gorm.DB) domain.TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// Create creates a new tenant
+func (r *tenantRepository) Create(tenant *domain.Tenant) error {
+	if err := r.db.Create(tenant).Error; err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return domain.ErrTenantSlugTaken
+		}
+		return err
+	}
+	return nil
+}
+
+// FindBySlug finds a tenant by its slug
+func (r *tenantRepository) FindBySlug(slug string) (*domain.Tenant, error) {
+	return r.find("slug = ?", slug)
+}
+
+// FindByID finds a tenant by its ID
+func (r *tenantRepository) FindByID(id uuid.UUID) (*domain.Tenant, error) {
+	return r.find("id = ?", id)
+}
+
+// find returns the tenant matching the condition
+func (r *tenantRepository) find(query string, arg interface{}) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	if err := r.db.Where(query, arg).First(&tenant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTenantNotFound
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// FindAll lists the tenants by slug
+func (r *tenantRepository) FindAll() ([]domain.Tenant, error) {
+	tenants := []domain.Tenant{}
+	err := r.db.Order("slug").Find(&tenants).Error
+	return tenants, err
+}
+
+// FindLeaderboard ranks users by the distinct catalog problems they solved,
+// the first to reach a count ahead on ties. The users query is scoped to the
+// request's tenant like every query on users.
+func (r *tenantRepository) FindLeaderboard(limit int) ([]domain.LeaderboardEntry, error) {
+	var rows []struct {
+		UserID       uuid.UUID
+		Username     string
+		Solved       int64
+		LastSolvedAt nullTime
+	}
+	err := r.db.Model(&domain.User{}).
+		Select("users.id AS user_id, users.username, COUNT(*) AS solved, MAX(submissions.solved_at) AS last_solved_at").
+		Joins("JOIN submissions ON submissions.user_id = users.id").
+		Joins("JOIN problems ON problems.id = submissions.problem_id").
+		Where("problems.owner_id IS NULL").
+		Group("users.id, users.username").
+		Order("solved DESC, last_solved_at ASC, users.username ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.LeaderboardEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = domain.LeaderboardEntry{
+			Rank:         i + 1,
+			UserID:       row.UserID,
+			Username:     row.Username,
+			Solved:       row.Solved,
+			LastSolvedAt: row.LastSolvedAt.Time,
+		}
+	}
+	return entries, nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *tenantRepository) WithContext(ctx context.Context) domain.TenantRepository {
+	return &tenantRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
+
+// tenantScope restricts a query that names its tables itself to the rows of the
+// request's tenant, given the qualified tenant column to compare. The tenant
+// scope GORM applies to models does not reach such queries.
+func tenantScope(column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenantID, ok := infrastructure.TenantFrom(db.Statement.Context); ok {
+			return db.Where(column+" = ?", tenantID)
+		}
+		return db
+	}
+}
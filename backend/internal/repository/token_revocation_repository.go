@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// tokenRevocationRepository implements domain.TokenRevocationRepository using GORM
+type tokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(db *gorm.DB) domain.TokenRevocationRepository {
+	return &tokenRevocationRepository{db: db}
+}
+
+// Revoke records the tokens as revoked and prunes rows of tokens that have expired
+func (r *tokenRevocationRepository) Revoke(tokens []domain.RevokedToken) error {
+	if len(tokens) > 0 {
+		// Revoking a token twice is a no-op
+		if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error; err != nil {
+			return err
+		}
+	}
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&domain.RevokedToken{}).Error
+}
+
+// IsRevoked checks the jti list and the user's token version in a single query,
+// since it runs for every authenticated request
+func (r *tokenRevocationRepository) IsRevoked(jti string, userID uuid.UUID, tokenVersion int) (bool, error) {
+	var revoked bool
+	err := r.db.Raw(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?)
+		OR EXISTS (SELECT 1 FROM users WHERE id = ? AND token_version > ?)`,
+		jti, userID, tokenVersion).Row().Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+	return revoked, nil
+}
+
+// RevokeAllForUser bumps the user's token version
+func (r *tokenRevocationRepository) RevokeAllForUser(userID uuid.UUID) error {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ?", userID).
+		UpdateColumn("token_version", gorm.Expr("token_version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
+func (r *tokenRevocationRepository) WithContext(ctx context.Context) domain.TokenRevocationRepository {
+	return &tokenRevocationRepository{db: infrastructure.DBFor(ctx, r.db)}
+}
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
 // userRepository implements domain.UserRepository using GORM
@@ -25,7 +26,7 @@ func (r *userRepository) Create(user *domain.User) error {
 	result := r.db.Create(user)
 	if result.Error != nil {
 		// Check for unique constraint violation
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		if errors.Is(result.Error, domain.ErrConflict) {
 			return domain.ErrUserAlreadyExists
 		}
 		return result.Error
@@ -59,9 +60,11 @@ func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
 	return &user, nil
 }
 
-// Update updates an existing user
+// Update updates an existing user. The token version is only ever bumped by
+// the revocation repository and the subscription columns by the billing
+// repository, so a stale copy of the user cannot roll either back.
 func (r *userRepository) Update(user *domain.User) error {
-	result := r.db.Save(user)
+	result := r.db.Omit("token_version", "billing_customer_id", "subscription_id", "subscription_status", "plan_renews_at", "billing_event_at").Save(user)
 	return result.Error
 }
 
@@ -90,7 +93,8 @@ func (r *userRepository) GetSolvedProblemIDs(userID uuid.UUID) ([]uuid.UUID, err
 	return problemIDs, nil
 }
 
-// WithContext returns a repository with the given context for tracing
+// WithContext returns a repository with the given context for tracing, joining
+// the request transaction it carries
 func (r *userRepository) WithContext(ctx context.Context) domain.UserRepository {
-	return &userRepository{db: r.db.WithContext(ctx)}
+	return &userRepository{db: infrastructure.DBFor(ctx, r.db)}
 }
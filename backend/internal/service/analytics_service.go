@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+const oneWeek = 7 * 24 * time.Hour
+
+// AnalyticsService computes and serves product analytics snapshots
+type AnalyticsService struct {
+	analyticsRepo domain.AnalyticsRepository
+	config        *infrastructure.AnalyticsConfig
+	tracer        trace.Tracer
+	logger        *zap.Logger
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(
+	analyticsRepo domain.AnalyticsRepository,
+	config *infrastructure.AnalyticsConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		config:        config,
+		tracer:        tracer,
+		logger:        logger,
+	}
+}
+
+// GetCohorts returns the latest cohort retention snapshot, newest cohort first
+func (s *AnalyticsService) GetCohorts(ctx context.Context) (*domain.CohortsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "AnalyticsService.GetCohorts")
+	defer span.End()
+
+	rows, err := s.analyticsRepo.WithContext(ctx).FindCohortWeeks()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.CohortsResponse{Cohorts: []domain.Cohort{}}
+	for _, row := range rows {
+		if resp.ComputedAt == nil {
+			computedAt := row.ComputedAt
+			resp.ComputedAt = &computedAt
+		}
+		if n := len(resp.Cohorts); n == 0 || !resp.Cohorts[n-1].Week.Equal(row.CohortStart) {
+			resp.Cohorts = append(resp.Cohorts, domain.Cohort{
+				Week:  row.CohortStart.UTC(),
+				Size:  row.CohortSize,
+				Weeks: []domain.CohortWeekStats{},
+			})
+		}
+		cohort := &resp.Cohorts[len(resp.Cohorts)-1]
+
+		stats := domain.CohortWeekStats{
+			Week:              row.WeekIndex,
+			ActiveUsers:       row.ActiveUsers,
+			ContestsStarted:   row.ContestsStarted,
+			ContestsCompleted: row.ContestsCompleted,
+			Complete:          !row.CohortStart.Add(time.Duration(row.WeekIndex+1) * oneWeek).After(row.ComputedAt),
+		}
+		if row.CohortSize > 0 {
+			stats.Retention = float64(row.ActiveUsers) / float64(row.CohortSize)
+		}
+		cohort.Weeks = append(cohort.Weeks, stats)
+	}
+
+	span.SetAttributes(attribute.Int("analytics.cohorts", len(resp.Cohorts)))
+	return resp, nil
+}
+
+// CohortsStale reports whether the stored snapshot is missing or older than the
+// refresh interval
+func (s *AnalyticsService) CohortsStale(ctx context.Context, now time.Time) (bool, error) {
+	rows, err := s.analyticsRepo.WithContext(ctx).FindCohortWeeks()
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 {
+		return true, nil
+	}
+	return s.config.CohortRefreshInterval > 0 && now.Sub(rows[0].ComputedAt) >= s.config.CohortRefreshInterval, nil
+}
+
+// RefreshCohorts recomputes the cohort snapshot for the configured number of
+// weeks up to now and replaces the stored one
+func (s *AnalyticsService) RefreshCohorts(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "AnalyticsService.RefreshCohorts")
+	defer span.End()
+
+	now = now.UTC()
+	since := startOfWeek(now).Add(-time.Duration(s.config.CohortWeeks-1) * oneWeek)
+
+	signups, err := s.analyticsRepo.WithContext(ctx).FindSignups(since)
+	if err != nil {
+		return 0, err
+	}
+	activity, err := s.analyticsRepo.WithContext(ctx).FindActivity(since)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := computeCohortWeeks(signups, activity, now)
+	if err := s.analyticsRepo.WithContext(ctx).ReplaceCohortWeeks(rows); err != nil {
+		logFor(ctx, s.logger).Error("Failed to store cohort snapshot", zap.Error(err))
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("analytics.signups", len(signups)),
+		attribute.Int("analytics.rows", len(rows)),
+	)
+	return len(rows), nil
+}
+
+// computeCohortWeeks buckets signups into weekly cohorts and counts each
+// cohort's activity per week since signup, through the week containing now.
+// Weeks without activity are kept so retention curves show the drop to zero.
+func computeCohortWeeks(signups []domain.UserSignup, activity []domain.UserActivity, now time.Time) []domain.CohortWeek {
+	type cohortKey struct {
+		start time.Time
+		week  int
+	}
+
+	cohortOf := make(map[uuid.UUID]time.Time, len(signups))
+	sizes := make(map[time.Time]int)
+	for _, signup := range signups {
+		start := startOfWeek(signup.CreatedAt)
+		cohortOf[signup.UserID] = start
+		sizes[start]++
+	}
+
+	rows := make(map[cohortKey]*domain.CohortWeek)
+	for start, size := range sizes {
+		weeks := int(startOfWeek(now).Sub(start) / oneWeek)
+		for i := 0; i <= weeks; i++ {
+			rows[cohortKey{start, i}] = &domain.CohortWeek{
+				CohortStart: start,
+				WeekIndex:   i,
+				CohortSize:  size,
+				ComputedAt:  now,
+			}
+		}
+	}
+
+	active := make(map[cohortKey]map[uuid.UUID]struct{})
+	for _, event := range activity {
+		start, ok := cohortOf[event.UserID]
+		if !ok {
+			continue
+		}
+		key := cohortKey{start, int(startOfWeek(event.At).Sub(start) / oneWeek)}
+		row, ok := rows[key]
+		if !ok {
+			continue
+		}
+
+		if active[key] == nil {
+			active[key] = make(map[uuid.UUID]struct{})
+		}
+		active[key][event.UserID] = struct{}{}
+
+		if event.Kind == domain.ActivityContest {
+			row.ContestsStarted++
+			if event.Completed {
+				row.ContestsCompleted++
+			}
+		}
+	}
+
+	result := make([]domain.CohortWeek, 0, len(rows))
+	for key, row := range rows {
+		row.ActiveUsers = len(active[key])
+		result = append(result, *row)
+	}
+	return result
+}
+
+// startOfWeek returns Monday 00:00 UTC of the week containing t
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// AssignmentService gathers the work assigned to a user from every
+// organization they are a student of and every mentor they accepted
+type AssignmentService struct {
+	orgService        *OrgService
+	mentorshipService *MentorshipService
+	tracer            trace.Tracer
+	logger            *zap.Logger
+}
+
+// NewAssignmentService creates a new assignment service
+func NewAssignmentService(
+	orgService *OrgService,
+	mentorshipService *MentorshipService,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *AssignmentService {
+	return &AssignmentService{
+		orgService:        orgService,
+		mentorshipService: mentorshipService,
+		tracer:            tracer,
+		logger:            logger,
+	}
+}
+
+// GetPendingAssignments lists the user's assignments that are not completed
+// yet, soonest due first; assignments without a due date come last
+func (s *AssignmentService) GetPendingAssignments(ctx context.Context, userID uuid.UUID) ([]domain.PendingAssignment, error) {
+	ctx, span := s.tracer.Start(ctx, "AssignmentService.GetPendingAssignments")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	fromOrgs, err := s.orgService.GetPendingAssignments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	fromMentors, err := s.mentorshipService.GetPendingAssignments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := append(fromOrgs, fromMentors...)
+	sort.SliceStable(pending, func(i, j int) bool {
+		a, b := pending[i].DueAt, pending[j].DueAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+	if pending == nil {
+		pending = []domain.PendingAssignment{}
+	}
+	return pending, nil
+}
+
+// validateAssignedContest checks the settings of an assigned contest: they are
+// reused for every assignee, so they cannot depend on the assigner's own
+// custom problems
+func validateAssignedContest(req *domain.CreateContestRequest) error {
+	if req.Source == domain.SourceRoadmap && (len(req.Companies) > 0 || len(req.Difficulties) > 0) {
+		return domain.NewValidationError("Companies and difficulties only apply to random contests", nil)
+	}
+	if req.IncludeCustom {
+		return domain.NewValidationError("Assigned contests cannot include custom problems", nil)
+	}
+	return nil
+}
+
+// resolveProblemSet resolves the problem IDs or slugs of a problem set, in
+// order, failing when any is unknown
+func resolveProblemSet(ctx context.Context, problemService *ProblemService, keys []string) ([]uuid.UUID, error) {
+	problems, notFound, err := problemService.GetProblemsBatch(ctx, keys, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(notFound) > 0 {
+		return nil, domain.NewValidationError("Problems not found", notFound)
+	}
+	if len(problems) == 0 {
+		return nil, domain.NewValidationError("A problem set needs at least one problem", nil)
+	}
+	ids := make([]uuid.UUID, len(problems))
+	for i, p := range problems {
+		ids[i] = p.ID
+	}
+	return ids, nil
+}
+
+// loadProblemSet loads the problems of a problem set in order, leaving out any
+// deleted since it was assigned
+func loadProblemSet(ctx context.Context, problemService *ProblemService, problemIDs []uuid.UUID) ([]domain.Problem, error) {
+	keys := make([]string, len(problemIDs))
+	for i, id := range problemIDs {
+		keys[i] = id.String()
+	}
+	problems, _, err := problemService.GetProblemsBatch(ctx, keys, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) == 0 {
+		return nil, domain.ErrProblemNotFound
+	}
+	return problems, nil
+}
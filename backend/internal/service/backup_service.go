@@ -0,0 +1,416 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+const (
+	// backupIDLayout names backups by their UTC start time, so keys sort by age
+	backupIDLayout = "20060102T150405Z"
+	// backupManifestName is written last; a backup without it is incomplete
+	backupManifestName = "manifest.json"
+	// restoreBatchSize is how many rows a restore hands to the repository at once
+	restoreBatchSize = 500
+	// maxBackupLine bounds one exported row when reading a backup back
+	maxBackupLine = 16 << 20
+)
+
+var backupIDPattern = regexp.MustCompile(`^\d{8}T\d{6}Z$`)
+
+// BackupService exports the tables users create to object storage and restores
+// them. Each backup is a directory of gzipped JSON lines files, one per table,
+// plus a manifest holding their row counts and SHA-256 checksums.
+type BackupService struct {
+	backupRepo domain.BackupRepository
+	store      infrastructure.ObjectStore // Nil when backups are disabled
+	config     *infrastructure.BackupConfig
+	tracer     trace.Tracer
+	logger     *zap.Logger
+	running    sync.Mutex // Held while a backup is taken on this instance
+}
+
+// NewBackupService creates a new backup service; store is nil when backups are disabled
+func NewBackupService(
+	backupRepo domain.BackupRepository,
+	store infrastructure.ObjectStore,
+	config *infrastructure.BackupConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *BackupService {
+	return &BackupService{
+		backupRepo: backupRepo,
+		store:      store,
+		config:     config,
+		tracer:     tracer,
+		logger:     logger,
+	}
+}
+
+// Enabled reports whether a backup storage is configured
+func (s *BackupService) Enabled() bool {
+	return s.store != nil
+}
+
+// tableFile accumulates one table's compressed rows and their checksum
+type tableFile struct {
+	buf  bytes.Buffer
+	gz   *gzip.Writer
+	rows int64
+}
+
+// Create exports every backed up table and uploads the files, then the manifest
+func (s *BackupService) Create(ctx context.Context) (*domain.BackupManifest, error) {
+	ctx, span := s.tracer.Start(ctx, "BackupService.Create")
+	defer span.End()
+	// An admin's request is for the default tenant; the backup covers them all
+	ctx = infrastructure.AllTenants(ctx)
+
+	if s.store == nil {
+		return nil, domain.ErrBackupDisabled
+	}
+	if !s.running.TryLock() {
+		return nil, domain.ErrBackupInProgress
+	}
+	defer s.running.Unlock()
+
+	started := time.Now().UTC()
+	manifest := &domain.BackupManifest{
+		Format:    domain.BackupFormat,
+		Version:   domain.BackupFormatVersion,
+		ID:        started.Format(backupIDLayout),
+		Driver:    s.backupRepo.Driver(),
+		CreatedAt: started,
+	}
+	span.SetAttributes(attribute.String("backup.id", manifest.ID))
+
+	slugs, err := s.backupRepo.WithContext(ctx).CatalogSlugs()
+	if err != nil {
+		return nil, fmt.Errorf("load catalog slugs: %w", err)
+	}
+	manifest.CatalogSlugs = slugs
+
+	files := make(map[domain.BackupTable]*tableFile, len(domain.BackupTables))
+	for _, table := range domain.BackupTables {
+		file := &tableFile{}
+		file.gz = gzip.NewWriter(&file.buf)
+		files[table] = file
+	}
+	err = s.backupRepo.WithContext(ctx).Export(domain.BackupTables, func(table domain.BackupTable, row domain.BackupRow) error {
+		line, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		file := files[table]
+		file.rows++
+		_, err = file.gz.Write(append(line, '\n'))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range domain.BackupTables {
+		file := files[table]
+		if err := file.gz.Close(); err != nil {
+			return nil, err
+		}
+		data := file.buf.Bytes()
+		sum := sha256.Sum256(data)
+		entry := domain.BackupFile{
+			Table:  table,
+			Name:   string(table) + ".jsonl.gz",
+			Rows:   file.rows,
+			Bytes:  int64(len(data)),
+			SHA256: hex.EncodeToString(sum[:]),
+		}
+		if err := s.store.Put(ctx, s.key(manifest.ID, entry.Name), data); err != nil {
+			return nil, fmt.Errorf("upload %s: %w", entry.Name, err)
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+
+	manifest.Duration = time.Since(started).Seconds()
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, s.key(manifest.ID, backupManifestName), raw); err != nil {
+		return nil, fmt.Errorf("upload manifest: %w", err)
+	}
+
+	logFor(ctx, s.logger).Info("Backup created",
+		zap.String("backup_id", manifest.ID),
+		zap.Int64("rows", manifest.TotalRows()),
+		zap.Duration("duration", time.Since(started)),
+	)
+	return manifest, nil
+}
+
+// List returns the manifests of the complete backups, newest first, without
+// their catalog slugs
+func (s *BackupService) List(ctx context.Context) ([]domain.BackupManifest, error) {
+	ctx, span := s.tracer.Start(ctx, "BackupService.List")
+	defer span.End()
+
+	if s.store == nil {
+		return nil, domain.ErrBackupDisabled
+	}
+	keys, err := s.store.List(ctx, s.config.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]domain.BackupManifest, 0)
+	for _, key := range keys {
+		id, name, ok := strings.Cut(strings.TrimPrefix(key, s.config.Prefix), "/")
+		if !ok || name != backupManifestName || !backupIDPattern.MatchString(id) {
+			continue
+		}
+		manifest, err := s.manifest(ctx, id)
+		if err != nil {
+			logFor(ctx, s.logger).Warn("Skipping unreadable backup manifest", zap.String("backup_id", id), zap.Error(err))
+			continue
+		}
+		manifest.CatalogSlugs = nil
+		manifests = append(manifests, *manifest)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].ID > manifests[j].ID })
+	return manifests, nil
+}
+
+// Verify downloads every file of a backup and checks its size, checksum and row count
+func (s *BackupService) Verify(ctx context.Context, id string) (*domain.BackupManifest, error) {
+	ctx, span := s.tracer.Start(ctx, "BackupService.Verify")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("backup.id", id))
+
+	manifest, _, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Restore verifies a backup and inserts its rows in one transaction. It only
+// restores into a database without users, e.g. one the API just migrated and
+// seeded; catalog problem references are mapped to the target's IDs by slug.
+func (s *BackupService) Restore(ctx context.Context, id string) (*domain.RestoreReport, error) {
+	ctx, span := s.tracer.Start(ctx, "BackupService.Restore")
+	defer span.End()
+	ctx = infrastructure.AllTenants(ctx)
+
+	span.SetAttributes(attribute.String("backup.id", id))
+
+	report := &domain.RestoreReport{
+		BackupID:  id,
+		Rows:      make(map[domain.BackupTable]int64, len(domain.BackupTables)),
+		StartedAt: time.Now(),
+	}
+
+	manifest, data, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	hasUsers, err := s.backupRepo.WithContext(ctx).HasUsers()
+	if err != nil {
+		return nil, err
+	}
+	if hasUsers {
+		return nil, domain.ErrRestoreNotEmpty
+	}
+
+	targetSlugs, err := s.backupRepo.WithContext(ctx).CatalogSlugs()
+	if err != nil {
+		return nil, fmt.Errorf("load catalog slugs: %w", err)
+	}
+	targetIDs := make(map[string]uuid.UUID, len(targetSlugs))
+	for problemID, slug := range targetSlugs {
+		targetIDs[slug] = problemID
+	}
+
+	err = s.backupRepo.WithContext(ctx).Import(domain.BackupTables, func(table domain.BackupTable, insert func([]domain.BackupRow) error) error {
+		if data[table] == nil {
+			return nil // Added after the backup's format version
+		}
+		batch := make([]domain.BackupRow, 0, restoreBatchSize)
+		err := eachBackupRow(data[table], func(row domain.BackupRow) error {
+			if err := remapProblems(row, manifest.CatalogSlugs, targetIDs); err != nil {
+				return err
+			}
+			batch = append(batch, row)
+			report.Rows[table]++
+			if len(batch) < restoreBatchSize {
+				return nil
+			}
+			err := insert(batch)
+			batch = batch[:0]
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return insert(batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.FinishedAt = time.Now()
+
+	logFor(ctx, s.logger).Info("Backup restored",
+		zap.String("backup_id", id),
+		zap.Int64("rows", manifest.TotalRows()),
+		zap.Duration("duration", report.FinishedAt.Sub(report.StartedAt)),
+	)
+	return report, nil
+}
+
+// load reads a backup's manifest and files and verifies them
+func (s *BackupService) load(ctx context.Context, id string) (*domain.BackupManifest, map[domain.BackupTable][]byte, error) {
+	if s.store == nil {
+		return nil, nil, domain.ErrBackupDisabled
+	}
+	manifest, err := s.manifest(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest.Format != domain.BackupFormat || manifest.Version > domain.BackupFormatVersion {
+		return nil, nil, domain.NewDomainError(domain.ErrBackupIncompatible,
+			fmt.Sprintf("Backup format %s version %d is not supported", manifest.Format, manifest.Version))
+	}
+
+	data := make(map[domain.BackupTable][]byte, len(manifest.Files))
+	for _, table := range domain.BackupTables {
+		file := manifest.File(table)
+		if file == nil && !manifest.Includes(table) {
+			continue
+		}
+		if file == nil {
+			return nil, nil, corruptBackup("the backup has no %s file", table)
+		}
+		raw, err := s.store.Get(ctx, s.key(id, file.Name))
+		if errors.Is(err, infrastructure.ErrObjectNotFound) {
+			return nil, nil, corruptBackup("%s is missing", file.Name)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("download %s: %w", file.Name, err)
+		}
+		sum := sha256.Sum256(raw)
+		if int64(len(raw)) != file.Bytes || hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, nil, corruptBackup("%s does not match its checksum", file.Name)
+		}
+		var rows int64
+		if err := eachBackupRow(raw, func(domain.BackupRow) error { rows++; return nil }); err != nil {
+			return nil, nil, corruptBackup("%s cannot be read: %v", file.Name, err)
+		}
+		if rows != file.Rows {
+			return nil, nil, corruptBackup("%s has %d rows, the manifest lists %d", file.Name, rows, file.Rows)
+		}
+		data[table] = raw
+	}
+	return manifest, data, nil
+}
+
+// manifest downloads and decodes the manifest of a backup
+func (s *BackupService) manifest(ctx context.Context, id string) (*domain.BackupManifest, error) {
+	if !backupIDPattern.MatchString(id) {
+		return nil, domain.ErrBackupNotFound
+	}
+	raw, err := s.store.Get(ctx, s.key(id, backupManifestName))
+	if errors.Is(err, infrastructure.ErrObjectNotFound) {
+		return nil, domain.ErrBackupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest domain.BackupManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, corruptBackup("the manifest cannot be read: %v", err)
+	}
+	return &manifest, nil
+}
+
+// key returns the object key of a file inside a backup
+func (s *BackupService) key(id, name string) string {
+	return s.config.Prefix + id + "/" + name
+}
+
+// eachBackupRow decompresses a table file and calls fn for each row
+func eachBackupRow(data []byte, fn func(domain.BackupRow) error) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBackupLine)
+	for scanner.Scan() {
+		var row domain.BackupRow
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// remapProblems points the problem references of a row at the target's catalog
+// problem with the same slug. Custom problems keep their IDs.
+func remapProblems(row domain.BackupRow, sourceSlugs map[uuid.UUID]string, targetIDs map[string]uuid.UUID) error {
+	for _, column := range domain.BackupProblemColumns {
+		raw, ok := row[column]
+		if !ok {
+			continue
+		}
+		var problemID *uuid.UUID
+		if err := json.Unmarshal(raw, &problemID); err != nil {
+			return err
+		}
+		if problemID == nil {
+			continue
+		}
+		slug, ok := sourceSlugs[*problemID]
+		if !ok {
+			continue
+		}
+		targetID, ok := targetIDs[slug]
+		if !ok {
+			return domain.NewDomainError(domain.ErrBackupIncompatible,
+				fmt.Sprintf("Catalog problem %q is not in this database", slug))
+		}
+		mapped, err := json.Marshal(targetID)
+		if err != nil {
+			return err
+		}
+		row[column] = mapped
+	}
+	return nil
+}
+
+func corruptBackup(format string, args ...interface{}) error {
+	return domain.NewDomainError(domain.ErrBackupCorrupt, "Backup failed verification: "+fmt.Sprintf(format, args...))
+}
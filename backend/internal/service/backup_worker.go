@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// BackupWorker takes a backup on a schedule. With several instances, the one
+// that takes the job lock first in an interval takes the backup.
+type BackupWorker struct {
+	backups *BackupService
+	config  *infrastructure.BackupConfig
+	locks   *infrastructure.JobLocks
+	logger  *zap.Logger
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+}
+
+// NewBackupWorker creates a new backup worker
+func NewBackupWorker(
+	backups *BackupService,
+	config *infrastructure.BackupConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *BackupWorker {
+	return &BackupWorker{
+		backups: backups,
+		config:  config,
+		locks:   locks,
+		logger:  logger,
+	}
+}
+
+// Start launches the backup loop in the background. It does nothing when
+// backups are disabled or the interval is zero.
+func (w *BackupWorker) Start(ctx context.Context) {
+	if !w.backups.Enabled() || w.config.Interval <= 0 {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		w.logger.Info("Backup worker started",
+			zap.Duration("interval", w.config.Interval),
+			zap.String("storage", w.config.Storage),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.runScheduled(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the backup loop and waits for an in-progress backup to finish,
+// or until ctx is done
+func (w *BackupWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Backup worker stopped")
+	return nil
+}
+
+// runScheduled takes the scheduled backup unless another instance is taking it
+// or took it less than an interval ago, and reports whether it did
+func (w *BackupWorker) runScheduled(ctx context.Context) bool {
+	return w.locks.RunEvery(ctx, "backup", w.config.Interval, func() { w.Backup(ctx) })
+}
+
+// Backup takes one backup; one triggered by an admin at the same time wins
+func (w *BackupWorker) Backup(ctx context.Context) {
+	if _, err := w.backups.Create(ctx); err != nil {
+		if errors.Is(err, domain.ErrBackupInProgress) {
+			return
+		}
+		w.logger.Error("Scheduled backup failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+	"github.com/contest-maker-150/backend/internal/repository"
+)
+
+func TestBackupWorkerRunsOncePerIntervalAcrossInstances(t *testing.T) {
+	dbConfig := infrastructure.LoadConfig().Database
+	dbConfig.Driver = infrastructure.DriverSQLite
+	dbConfig.SQLitePath = ":memory:"
+	database, err := infrastructure.NewDatabase(&dbConfig, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &infrastructure.BackupConfig{Storage: "file", Dir: t.TempDir(), Interval: time.Hour, Timeout: time.Minute}
+	store, err := infrastructure.NewObjectStore(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each replica has its own backup service and job locks over the shared
+	// database and storage
+	newReplica := func() *BackupWorker {
+		backups := NewBackupService(repository.NewBackupRepository(database.DB), store, config, noop.NewTracerProvider().Tracer(""), zap.NewNop())
+		return NewBackupWorker(backups, config, infrastructure.NewJobLocks(database.DB, zap.NewNop()), zap.NewNop())
+	}
+	workers := []*BackupWorker{newReplica(), newReplica()}
+
+	var ran atomic.Int32
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if worker.runScheduled(context.Background()) {
+				ran.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := ran.Load(); n != 1 {
+		t.Fatalf("%d replicas took the scheduled backup, want 1", n)
+	}
+
+	// The next tick within the interval is skipped on both replicas
+	for _, worker := range workers {
+		if worker.runScheduled(context.Background()) {
+			t.Fatal("backup taken again within the interval")
+		}
+	}
+	list, err := workers[0].backups.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("got %d backups, want 1", len(list))
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// Stripe webhook events that change a user's plan
+const (
+	eventCheckoutCompleted   = "checkout.session.completed"
+	eventSubscriptionCreated = "customer.subscription.created"
+	eventSubscriptionUpdated = "customer.subscription.updated"
+	eventSubscriptionDeleted = "customer.subscription.deleted"
+)
+
+// BillingService sells the premium plan through Stripe checkout and keeps
+// users' plans in step with their subscriptions
+type BillingService struct {
+	billingRepo domain.BillingRepository
+	userRepo    domain.UserRepository
+	stripe      *infrastructure.StripeClient
+	quotaConfig *infrastructure.QuotaConfig
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewBillingService creates a new billing service
+func NewBillingService(
+	billingRepo domain.BillingRepository,
+	userRepo domain.UserRepository,
+	stripe *infrastructure.StripeClient,
+	quotaConfig *infrastructure.QuotaConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *BillingService {
+	return &BillingService{
+		billingRepo: billingRepo,
+		userRepo:    userRepo,
+		stripe:      stripe,
+		quotaConfig: quotaConfig,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// CreateCheckoutSession starts a premium subscription checkout for the user
+func (s *BillingService) CreateCheckoutSession(ctx context.Context, userID uuid.UUID) (*domain.CheckoutSessionResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "BillingService.CreateCheckoutSession")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if !s.stripe.Enabled() {
+		return nil, domain.ErrBillingDisabled
+	}
+
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.EffectivePlan(time.Now(), s.quotaConfig.PremiumGracePeriod) == domain.PlanPremium {
+		return nil, domain.ErrAlreadySubscribed
+	}
+
+	session, err := s.stripe.CreateCheckoutSession(ctx, infrastructure.CheckoutSessionParams{
+		UserID:        user.ID.String(),
+		CustomerID:    user.BillingCustomerID,
+		CustomerEmail: user.Email,
+	})
+	if err != nil {
+		span.RecordError(err)
+		logFor(ctx, s.logger).Error("Stripe checkout session failed", zap.Error(err))
+		return nil, domain.NewDomainError(domain.ErrPaymentProvider, "Could not start checkout, please try again later")
+	}
+
+	logFor(ctx, s.logger).Info("Checkout session created",
+		zap.String("session_id", session.ID),
+	)
+	return &domain.CheckoutSessionResponse{ID: session.ID, URL: session.URL}, nil
+}
+
+// HandleWebhook verifies and applies a Stripe webhook event. Redelivered
+// events are acknowledged without being applied again, and an event older
+// than the one the user's billing state comes from never overwrites it.
+func (s *BillingService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	ctx, span := s.tracer.Start(ctx, "BillingService.HandleWebhook")
+	defer span.End()
+
+	if !s.stripe.WebhooksEnabled() {
+		return domain.ErrBillingDisabled
+	}
+
+	event, err := s.stripe.ConstructEvent(payload, signature, time.Now())
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(
+		attribute.String("billing.event_id", event.ID),
+		attribute.String("billing.event_type", event.Type),
+	)
+
+	seen, err := s.billingRepo.WithContext(ctx).HasEvent(event.ID)
+	if err != nil {
+		return err
+	}
+	if seen {
+		logFor(ctx, s.logger).Info("Duplicate billing event ignored", zap.String("event_id", event.ID))
+		return nil
+	}
+
+	eventAt := time.Unix(event.Created, 0).UTC()
+	switch event.Type {
+	case eventCheckoutCompleted:
+		err = s.applyCheckout(ctx, event, eventAt)
+	case eventSubscriptionCreated, eventSubscriptionUpdated, eventSubscriptionDeleted:
+		err = s.applySubscription(ctx, event, eventAt)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.billingRepo.WithContext(ctx).SaveEvent(&domain.BillingEvent{
+		ID:          event.ID,
+		Type:        event.Type,
+		ProcessedAt: time.Now(),
+	})
+}
+
+// applyCheckout links the customer and subscription from a completed checkout
+// to the user who started it
+func (s *BillingService) applyCheckout(ctx context.Context, event *infrastructure.StripeEvent, eventAt time.Time) error {
+	var session infrastructure.StripeCheckoutSession
+	if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+		return domain.NewValidationError("Malformed checkout session", nil)
+	}
+	if session.Mode != "subscription" {
+		return nil
+	}
+
+	user, err := s.findUser(ctx, session.ClientReferenceID, session.Customer)
+	if user == nil || err != nil {
+		return err
+	}
+
+	state := user.BillingState()
+	state.CustomerID = session.Customer
+	state.SubscriptionID = session.Subscription
+	state.EventAt = eventAt
+	if session.PaymentStatus == "paid" || session.PaymentStatus == "no_payment_required" {
+		state.Plan = domain.PlanPremium
+		state.SubscriptionStatus = domain.SubscriptionActive
+	}
+	return s.apply(ctx, user, state, event)
+}
+
+// applySubscription mirrors a subscription's status onto its user's plan
+func (s *BillingService) applySubscription(ctx context.Context, event *infrastructure.StripeEvent, eventAt time.Time) error {
+	var sub infrastructure.StripeSubscription
+	if err := json.Unmarshal(event.Data.Object, &sub); err != nil {
+		return domain.NewValidationError("Malformed subscription", nil)
+	}
+
+	user, err := s.findUser(ctx, sub.Metadata["user_id"], sub.Customer)
+	if user == nil || err != nil {
+		return err
+	}
+
+	// A replaced subscription ending must not downgrade the user who has
+	// already moved on to a new one
+	plan := domain.SubscriptionPlan(sub.Status)
+	if plan != domain.PlanPremium && user.SubscriptionID != "" && user.SubscriptionID != sub.ID {
+		logFor(ctx, s.logger).Info("Ignoring status of a replaced subscription",
+			zap.String("event_id", event.ID),
+			zap.String("subscription_status", sub.Status),
+		)
+		return nil
+	}
+
+	state := user.BillingState()
+	state.Plan = plan
+	state.CustomerID = sub.Customer
+	state.SubscriptionID = sub.ID
+	state.SubscriptionStatus = sub.Status
+	state.PlanRenewsAt = sub.PeriodEnd()
+	state.EventAt = eventAt
+	return s.apply(ctx, user, state, event)
+}
+
+// apply stores the new billing state unless a newer event already has
+func (s *BillingService) apply(ctx context.Context, user *domain.User, state domain.BillingState, event *infrastructure.StripeEvent) error {
+	applied, err := s.billingRepo.WithContext(ctx).UpdateUserBilling(user.ID, state)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		logFor(ctx, s.logger).Info("Stale billing event ignored",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.Type),
+		)
+		return nil
+	}
+
+	logFor(ctx, s.logger).Info("Billing state updated",
+		zap.String("event_id", event.ID),
+		zap.String("event_type", event.Type),
+		zap.String("target_user_id", user.ID.String()),
+		zap.String("plan", string(state.Plan)),
+		zap.String("subscription_status", state.SubscriptionStatus),
+	)
+	return nil
+}
+
+// findUser resolves the user an event belongs to, by the user ID the
+// checkout carried or else by customer. Events for unknown users return nil
+// without an error so Stripe does not keep retrying them.
+func (s *BillingService) findUser(ctx context.Context, userID, customerID string) (*domain.User, error) {
+	if id, err := uuid.Parse(userID); err == nil {
+		user, err := s.userRepo.WithContext(ctx).FindByID(id)
+		if err == nil || !errors.Is(err, domain.ErrUserNotFound) {
+			return user, err
+		}
+	} else if customerID != "" {
+		user, err := s.billingRepo.WithContext(ctx).FindUserByCustomerID(customerID)
+		if err == nil || !errors.Is(err, domain.ErrUserNotFound) {
+			return user, err
+		}
+	}
+
+	logFor(ctx, s.logger).Warn("Billing event for unknown user ignored",
+		zap.String("user_id", userID),
+		zap.String("customer_id", customerID),
+	)
+	return nil, nil
+}
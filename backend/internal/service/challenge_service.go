@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// challengeCodeBytes is the entropy of an invite code (16 base32 characters)
+const challengeCodeBytes = 10
+
+// ChallengeService handles "challenge a friend" invites: a friend replays one of
+// the user's contests and both results are compared once both are finished
+type ChallengeService struct {
+	challengeRepo  domain.ChallengeRepository
+	contestService *ContestService
+	userRepo       domain.UserRepository
+	presence       *PresenceService
+	events         domain.EventPublisher
+	config         *infrastructure.ContestConfig
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewChallengeService creates a new challenge service
+func NewChallengeService(
+	challengeRepo domain.ChallengeRepository,
+	contestService *ContestService,
+	userRepo domain.UserRepository,
+	presence *PresenceService,
+	events domain.EventPublisher,
+	config *infrastructure.ContestConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *ChallengeService {
+	return &ChallengeService{
+		challengeRepo:  challengeRepo,
+		contestService: contestService,
+		userRepo:       userRepo,
+		presence:       presence,
+		events:         events,
+		config:         config,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// CreateChallenge generates an invite to replay one of the user's contests.
+// In silent mode the challenge chat is closed while the contests run.
+func (s *ChallengeService) CreateChallenge(ctx context.Context, userID, contestID uuid.UUID, silentMode bool) (*domain.ChallengeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.CreateChallenge")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	contest, err := s.contestService.GetContestByID(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+
+	// Custom problems are private to their owner, so the friend could not see them
+	for _, cp := range contest.ContestProblems {
+		if cp.Problem.IsCustom() {
+			return nil, domain.NewDomainError(domain.ErrBadRequest, "Contests with custom problems cannot be shared")
+		}
+	}
+
+	code, err := newChallengeCode()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &domain.ContestChallenge{
+		Code:         code,
+		ContestID:    contest.ID,
+		ChallengerID: userID,
+		ExpiresAt:    time.Now().Add(s.config.ChallengeInviteTTL),
+		SilentMode:   silentMode,
+	}
+	if err := s.challengeRepo.WithContext(ctx).Create(challenge); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Challenge created",
+		zap.String("contest_id", contest.ID.String()),
+	)
+	return s.toResponse(ctx, challenge, contest)
+}
+
+// GetChallenge returns an invite so the friend can see what they are accepting
+func (s *ChallengeService) GetChallenge(ctx context.Context, code string) (*domain.ChallengeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.GetChallenge")
+	defer span.End()
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	contest, err := s.contestService.GetContestByID(ctx, challenge.ContestID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(ctx, challenge, contest)
+}
+
+// AcceptChallenge gives the user a contest with the challenger's problem set and duration
+func (s *ChallengeService) AcceptChallenge(ctx context.Context, userID uuid.UUID, code string) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.AcceptChallenge")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if challenge.ChallengerID == userID {
+		return nil, domain.NewDomainError(domain.ErrBadRequest, "You cannot accept your own challenge")
+	}
+	if challenge.IsAccepted() {
+		return nil, domain.ErrChallengeAccepted
+	}
+	if challenge.IsExpired() {
+		return nil, domain.ErrChallengeExpired
+	}
+
+	source, err := s.contestService.GetContestByID(ctx, challenge.ContestID)
+	if err != nil {
+		return nil, err
+	}
+
+	contest, err := s.contestService.CloneContest(ctx, userID, source)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := s.challengeRepo.WithContext(ctx).Accept(challenge.ID, userID, contest.ID, time.Now())
+	if err != nil || !accepted {
+		// Rollback: another friend was faster, or the challenge could not be updated
+		s.contestService.discardContest(ctx, contest.ID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrChallengeAccepted
+	}
+
+	s.events.Publish(ctx, domain.ChallengeAcceptedEvent{
+		ChallengeID:  challenge.ID,
+		ChallengerID: challenge.ChallengerID,
+		OpponentID:   userID,
+		ContestID:    challenge.ContestID,
+	})
+
+	logFor(ctx, s.logger).Info("Challenge accepted",
+		zap.String("challenge_id", challenge.ID.String()),
+		zap.String("contest_id", contest.ID.String()),
+	)
+	return contest, nil
+}
+
+// GetComparison compares both participants' results once both contests are finished
+func (s *ChallengeService) GetComparison(ctx context.Context, userID uuid.UUID, code string) (*domain.ChallengeComparison, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.GetComparison")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.IsParticipant(userID) {
+		return nil, domain.ErrForbidden
+	}
+	if !challenge.IsAccepted() {
+		return nil, domain.ErrChallengeInProgress
+	}
+
+	// GetContestByID completes contests whose timer ran out
+	mine, err := s.contestService.GetContestByID(ctx, challenge.ContestID)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := s.contestService.GetContestByID(ctx, *challenge.OpponentContestID)
+	if err != nil {
+		return nil, err
+	}
+	if !mine.IsFinished() || !theirs.IsFinished() {
+		return nil, domain.ErrChallengeInProgress
+	}
+
+	challenger, err := s.userRepo.WithContext(ctx).FindByID(challenge.ChallengerID)
+	if err != nil {
+		return nil, err
+	}
+	opponent, err := s.userRepo.WithContext(ctx).FindByID(*challenge.OpponentID)
+	if err != nil {
+		return nil, err
+	}
+
+	opponentSolved := make(map[uuid.UUID]bool, len(theirs.ContestProblems))
+	for _, cp := range theirs.ContestProblems {
+		opponentSolved[cp.ProblemID] = cp.IsCompleted
+	}
+	scored := mine.ScoredProblems()
+	problems := make([]domain.ChallengeProblemComparison, len(scored))
+	for i, cp := range scored {
+		problems[i] = domain.ChallengeProblemComparison{
+			Problem:          cp.Problem.ToResponse(),
+			ChallengerSolved: cp.IsCompleted,
+			OpponentSolved:   opponentSolved[cp.ProblemID],
+		}
+	}
+
+	comparison := &domain.ChallengeComparison{
+		Code:       challenge.Code,
+		Challenger: domain.NewChallengeResult(challenger, mine),
+		Opponent:   domain.NewChallengeResult(opponent, theirs),
+		Problems:   problems,
+	}
+	switch {
+	case comparison.Challenger.Beats(comparison.Opponent):
+		comparison.WinnerID = &challenger.ID
+	case comparison.Opponent.Beats(comparison.Challenger):
+		comparison.WinnerID = &opponent.ID
+	}
+	return comparison, nil
+}
+
+// GetStandings returns the live standings of a challenge to its participants,
+// with whether each of them is online or in their contest right now
+func (s *ChallengeService) GetStandings(ctx context.Context, userID uuid.UUID, code string) (*domain.ChallengeStandings, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.GetStandings")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.IsParticipant(userID) {
+		return nil, domain.ErrForbidden
+	}
+	return s.standings(ctx, challenge)
+}
+
+// OpenSpectatorInvite lets a participant invite read-only spectators of the
+// live standings. Opening it again replaces the code, locking out earlier spectators.
+func (s *ChallengeService) OpenSpectatorInvite(ctx context.Context, userID uuid.UUID, code string, anonymize bool) (*domain.SpectatorInvite, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.OpenSpectatorInvite")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("spectators.anonymize", anonymize),
+	)
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.IsParticipant(userID) {
+		return nil, domain.ErrForbidden
+	}
+
+	spectatorCode, err := newChallengeCode()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.challengeRepo.WithContext(ctx).SetSpectatorInvite(challenge.ID, &spectatorCode, anonymize); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Spectator invite opened",
+		zap.String("challenge_id", challenge.ID.String()),
+		zap.Bool("anonymize", anonymize),
+	)
+	return &domain.SpectatorInvite{SpectatorCode: spectatorCode, Anonymize: anonymize}, nil
+}
+
+// CloseSpectatorInvite stops spectators from watching the challenge
+func (s *ChallengeService) CloseSpectatorInvite(ctx context.Context, userID uuid.UUID, code string) error {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.CloseSpectatorInvite")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return err
+	}
+	if !challenge.IsParticipant(userID) {
+		return domain.ErrForbidden
+	}
+	if err := s.challengeRepo.WithContext(ctx).SetSpectatorInvite(challenge.ID, nil, false); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Spectator invite closed",
+		zap.String("challenge_id", challenge.ID.String()),
+	)
+	return nil
+}
+
+// Spectate returns the read-only standings of the challenge behind a spectator
+// invite code. Spectators do not need to take part in the challenge.
+func (s *ChallengeService) Spectate(ctx context.Context, spectatorCode string) (*domain.SpectatorView, error) {
+	ctx, span := s.tracer.Start(ctx, "ChallengeService.Spectate")
+	defer span.End()
+
+	challenge, err := s.challengeRepo.WithContext(ctx).FindBySpectatorCode(normalizeChallengeCode(spectatorCode))
+	if err != nil {
+		return nil, err
+	}
+	standings, err := s.standings(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &domain.SpectatorView{
+		Anonymized:   challenge.AnonymizeSpectated,
+		Participants: make([]domain.SpectatorStanding, len(standings.Participants)),
+	}
+	for i, p := range standings.Participants {
+		name := p.Username
+		if challenge.AnonymizeSpectated {
+			name = fmt.Sprintf("Participant %d", i+1)
+		}
+		view.Participants[i] = domain.SpectatorStanding{
+			Name:     name,
+			Status:   p.Status,
+			Solved:   p.Solved,
+			Total:    p.Total,
+			Presence: p.Presence.Status,
+		}
+	}
+	return view, nil
+}
+
+// standings builds the live standings of the challenger and, once accepted, the opponent
+func (s *ChallengeService) standings(ctx context.Context, challenge *domain.ContestChallenge) (*domain.ChallengeStandings, error) {
+	type participant struct {
+		userID    uuid.UUID
+		contestID uuid.UUID
+	}
+	participants := []participant{{challenge.ChallengerID, challenge.ContestID}}
+	if challenge.IsAccepted() {
+		participants = append(participants, participant{*challenge.OpponentID, *challenge.OpponentContestID})
+	}
+
+	userIDs := make([]uuid.UUID, len(participants))
+	for i, p := range participants {
+		userIDs[i] = p.userID
+	}
+	presence, err := s.presence.Statuses(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := &domain.ChallengeStandings{
+		Code:         challenge.Code,
+		Participants: make([]domain.ChallengeStanding, 0, len(participants)),
+	}
+	for _, p := range participants {
+		user, err := s.userRepo.WithContext(ctx).FindByID(p.userID)
+		if err != nil {
+			return nil, err
+		}
+		// GetContestByID completes contests whose timer ran out
+		contest, err := s.contestService.GetContestByID(ctx, p.contestID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the participant's own active contest counts as competing in this challenge
+		status := presence[p.userID]
+		if status.Status == domain.PresenceInContest && (contest.Status != domain.ContestStatusActive || *status.ContestID != p.contestID) {
+			status.Status = domain.PresenceOnline
+			status.ContestID = nil
+		}
+
+		standings.Participants = append(standings.Participants, domain.ChallengeStanding{
+			UserID:    user.ID,
+			Username:  user.Username,
+			ContestID: contest.ID,
+			Status:    contest.Status,
+			Solved:    contest.CompletedCount(),
+			Total:     len(contest.ScoredProblems()),
+			Presence:  status,
+		})
+	}
+	return standings, nil
+}
+
+// toResponse builds the API view of a challenge from its source contest
+func (s *ChallengeService) toResponse(ctx context.Context, challenge *domain.ContestChallenge, contest *domain.Contest) (*domain.ChallengeResponse, error) {
+	challenger, err := s.userRepo.WithContext(ctx).FindByID(challenge.ChallengerID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ChallengeResponse{
+		Code:               challenge.Code,
+		ContestID:          contest.ID,
+		ChallengerID:       challenger.ID,
+		ChallengerUsername: challenger.Username,
+		ProblemCount:       len(contest.ScoredProblems()),
+		DurationMinutes:    contest.DurationMinutes,
+		Accepted:           challenge.IsAccepted(),
+		SilentMode:         challenge.SilentMode,
+		AcceptedAt:         challenge.AcceptedAt,
+		ExpiresAt:          challenge.ExpiresAt,
+	}, nil
+}
+
+// newChallengeCode returns a random, URL-safe invite code
+func newChallengeCode() (string, error) {
+	b := make([]byte, challengeCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)), nil
+}
+
+// normalizeChallengeCode makes codes typed by hand match regardless of case
+func normalizeChallengeCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
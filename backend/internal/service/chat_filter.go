@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// MessageFilter cleans up chat messages before they are stored. It may rewrite
+// the message, or reject it by returning an error.
+type MessageFilter interface {
+	Filter(ctx context.Context, body string) (string, error)
+}
+
+// WordListFilter masks configured words in chat messages with asterisks,
+// matching whole words regardless of case
+type WordListFilter struct {
+	pattern *regexp.Regexp // nil without banned words
+}
+
+// NewWordListFilter creates a filter masking the given words
+func NewWordListFilter(words []string) *WordListFilter {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return &WordListFilter{}
+	}
+	return &WordListFilter{pattern: regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)}
+}
+
+// Filter masks every banned word in the message
+func (f *WordListFilter) Filter(_ context.Context, body string) (string, error) {
+	if f.pattern == nil {
+		return body, nil
+	}
+	return f.pattern.ReplaceAllStringFunc(body, func(word string) string {
+		return strings.Repeat("*", len([]rune(word)))
+	}), nil
+}
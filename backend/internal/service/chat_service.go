@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// defaultChatLimit is how many messages a chat page holds when the client does not ask
+const defaultChatLimit = 50
+
+// ChatService handles the chat between the participants of a challenge
+type ChatService struct {
+	chatRepo       domain.ChatRepository
+	challengeRepo  domain.ChallengeRepository
+	userRepo       domain.UserRepository
+	contestService *ContestService
+	filter         MessageFilter
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewChatService creates a new chat service
+func NewChatService(
+	chatRepo domain.ChatRepository,
+	challengeRepo domain.ChallengeRepository,
+	userRepo domain.UserRepository,
+	contestService *ContestService,
+	filter MessageFilter,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *ChatService {
+	return &ChatService{
+		chatRepo:       chatRepo,
+		challengeRepo:  challengeRepo,
+		userRepo:       userRepo,
+		contestService: contestService,
+		filter:         filter,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// GetMessages returns a page of the challenge chat to a participant
+func (s *ChatService) GetMessages(ctx context.Context, userID uuid.UUID, code string, query domain.ChatQuery) (*domain.ChatMessages, error) {
+	ctx, span := s.tracer.Start(ctx, "ChatService.GetMessages")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	challenge, err := s.participantChallenge(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	silenced, err := s.silenced(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultChatLimit
+	}
+	messages, err := s.chatRepo.WithContext(ctx).FindByChallenge(challenge.ID, query.Since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.ChatMessages{
+		Messages: make([]domain.ChatMessageResponse, len(messages)),
+		Silenced: silenced,
+	}
+	for i := range messages {
+		page.Messages[i] = messages[i].ToResponse()
+	}
+	span.SetAttributes(attribute.Int("messages.count", len(messages)))
+	return page, nil
+}
+
+// PostMessage adds a participant's message to the challenge chat after running
+// it through the message filter
+func (s *ChatService) PostMessage(ctx context.Context, userID uuid.UUID, code, body string) (*domain.ChatMessageResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ChatService.PostMessage")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	challenge, err := s.participantChallenge(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	silenced, err := s.silenced(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	if silenced {
+		return nil, domain.ErrChatSilenced
+	}
+
+	body, err = s.filter.Filter(ctx, strings.TrimSpace(body))
+	if err != nil {
+		return nil, err
+	}
+	if body == "" {
+		return nil, domain.NewValidationError("Message is empty", nil)
+	}
+
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domain.ChatMessage{
+		ChallengeID: challenge.ID,
+		UserID:      userID,
+		Body:        body,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.chatRepo.WithContext(ctx).Create(message); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Chat message posted",
+		zap.String("challenge_id", challenge.ID.String()),
+		zap.Int("length", len(body)),
+	)
+
+	message.User = *user
+	response := message.ToResponse()
+	return &response, nil
+}
+
+// participantChallenge loads a challenge and checks the user takes part in it
+func (s *ChatService) participantChallenge(ctx context.Context, userID uuid.UUID, code string) (*domain.ContestChallenge, error) {
+	challenge, err := s.challengeRepo.WithContext(ctx).FindByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.IsParticipant(userID) {
+		return nil, domain.ErrForbidden
+	}
+	return challenge, nil
+}
+
+// silenced reports whether silent mode currently closes the chat: it does
+// while either participant's contest is still running
+func (s *ChatService) silenced(ctx context.Context, challenge *domain.ContestChallenge) (bool, error) {
+	if !challenge.SilentMode {
+		return false, nil
+	}
+	contestIDs := []uuid.UUID{challenge.ContestID}
+	if challenge.OpponentContestID != nil {
+		contestIDs = append(contestIDs, *challenge.OpponentContestID)
+	}
+	for _, id := range contestIDs {
+		// GetContestByID completes contests whose timer ran out
+		contest, err := s.contestService.GetContestByID(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if contest.Status == domain.ContestStatusActive {
+			return true, nil
+		}
+	}
+	return false, nil
+}
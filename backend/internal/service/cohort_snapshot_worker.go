@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// CohortSnapshotWorker recomputes the cohort retention snapshot on a schedule.
+// On startup it only recomputes a missing or stale snapshot, so deploys do not
+// repeat the work.
+type CohortSnapshotWorker struct {
+	analytics *AnalyticsService
+	config    *infrastructure.AnalyticsConfig
+	locks     *infrastructure.JobLocks
+	logger    *zap.Logger
+	wg        sync.WaitGroup
+	cancel    context.CancelFunc
+}
+
+// NewCohortSnapshotWorker creates a new cohort snapshot worker
+func NewCohortSnapshotWorker(
+	analytics *AnalyticsService,
+	config *infrastructure.AnalyticsConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *CohortSnapshotWorker {
+	return &CohortSnapshotWorker{
+		analytics: analytics,
+		config:    config,
+		locks:     locks,
+		logger:    logger,
+	}
+}
+
+// Start refreshes a stale snapshot and launches the periodic loop in the background.
+// A zero interval only runs the startup check.
+func (w *CohortSnapshotWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		w.locks.Run(ctx, "cohort_snapshot", func() {
+			stale, err := w.analytics.CohortsStale(ctx, time.Now())
+			if err != nil {
+				w.logger.Error("Failed to check cohort snapshot age", zap.Error(err))
+			} else if stale {
+				w.Refresh(ctx)
+			}
+		})
+		if w.config.CohortRefreshInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(w.config.CohortRefreshInterval)
+		defer ticker.Stop()
+
+		w.logger.Info("Cohort snapshot worker started",
+			zap.Duration("interval", w.config.CohortRefreshInterval),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.locks.RunEvery(ctx, "cohort_snapshot", w.config.CohortRefreshInterval, func() { w.Refresh(ctx) })
+			}
+		}
+	}()
+}
+
+// Stop stops the refresh loop and waits for an in-progress refresh to finish,
+// or until ctx is done
+func (w *CohortSnapshotWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Cohort snapshot worker stopped")
+	return nil
+}
+
+// Refresh recomputes the cohort snapshot
+func (w *CohortSnapshotWorker) Refresh(ctx context.Context) {
+	start := time.Now()
+	rows, err := w.analytics.RefreshCohorts(ctx, start)
+	if err != nil {
+		w.logger.Error("Failed to refresh cohort snapshot", zap.Error(err))
+		return
+	}
+
+	w.logger.Info("Cohort snapshot refreshed",
+		zap.Int("rows", rows),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
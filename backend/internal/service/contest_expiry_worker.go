@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+const (
+	// AbandonPolicyNone always completes expired contests
+	AbandonPolicyNone = "none"
+	// AbandonPolicyNoActivity abandons expired contests with no completed problems
+	// once they are past the grace period
+	AbandonPolicyNoActivity = "no_activity"
+)
+
+// ContestExpiryWorker periodically finalizes active contests whose timer ran out,
+// so contests left open by users who never come back do not stay active forever
+type ContestExpiryWorker struct {
+	contestRepo domain.ContestRepository
+	events      domain.EventPublisher
+	config      *infrastructure.ContestConfig
+	locks       *infrastructure.JobLocks
+	logger      *zap.Logger
+	wg          sync.WaitGroup
+	cancel      context.CancelFunc
+}
+
+// NewContestExpiryWorker creates a new contest expiry worker
+func NewContestExpiryWorker(
+	contestRepo domain.ContestRepository,
+	events domain.EventPublisher,
+	config *infrastructure.ContestConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *ContestExpiryWorker {
+	return &ContestExpiryWorker{
+		contestRepo: contestRepo,
+		events:      events,
+		config:      config,
+		locks:       locks,
+		logger:      logger,
+	}
+}
+
+// Start launches the sweep loop in the background
+func (w *ContestExpiryWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.ExpirySweepInterval)
+		defer ticker.Stop()
+
+		w.logger.Info("Contest expiry worker started",
+			zap.Duration("interval", w.config.ExpirySweepInterval),
+			zap.String("abandon_policy", w.config.AbandonPolicy),
+			zap.Duration("abandon_grace_period", w.config.AbandonGracePeriod),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				w.locks.RunEvery(ctx, "contest_expiry", w.config.ExpirySweepInterval, func() { w.Sweep(now) })
+			}
+		}
+	}()
+}
+
+// Stop stops the sweep loop and waits for an in-progress sweep to finish, or
+// until ctx is done
+func (w *ContestExpiryWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Contest expiry worker stopped")
+	return nil
+}
+
+// Sweep finalizes every expired active contest according to the abandon policy
+func (w *ContestExpiryWorker) Sweep(now time.Time) {
+	contests, err := w.contestRepo.FindExpiredActive(now)
+	if err != nil {
+		w.logger.Error("Failed to find expired contests", zap.Error(err))
+		return
+	}
+
+	for i := range contests {
+		contest := &contests[i]
+
+		status, reason, ok := w.decide(contest, now)
+		if !ok {
+			continue
+		}
+
+		endedAt := contest.EndTime()
+		contest.Status = status
+		contest.EndedAt = &endedAt
+		finished, err := w.contestRepo.Finish(contest)
+		if err != nil {
+			w.logger.Error("Failed to finalize expired contest",
+				zap.String("contest_id", contest.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		if !finished {
+			continue // Finished by its user since the lookup
+		}
+		if err := w.contestRepo.StopProblemTimers(contest.ID, nil, endedAt); err != nil {
+			w.logger.Error("Failed to stop problem timers",
+				zap.String("contest_id", contest.ID.String()),
+				zap.Error(err),
+			)
+		}
+
+		w.logger.Info("Expired contest finalized",
+			zap.String("contest_id", contest.ID.String()),
+			zap.String("user_id", contest.UserID.String()),
+			zap.String("from", string(domain.ContestStatusActive)),
+			zap.String("to", string(status)),
+			zap.String("reason", reason),
+			zap.Duration("expired_for", now.Sub(contest.EndTime())),
+		)
+
+		w.events.Publish(context.Background(), domain.ContestFinishedEvent{
+			ContestID: contest.ID,
+			UserID:    contest.UserID,
+			Status:    status,
+		})
+	}
+}
+
+// decide applies the abandon policy to an expired contest. ok is false when the
+// contest should be left alone for now (untouched but still inside the grace period).
+func (w *ContestExpiryWorker) decide(contest *domain.Contest, now time.Time) (status domain.ContestStatus, reason string, ok bool) {
+	if w.config.AbandonPolicy != AbandonPolicyNoActivity {
+		return domain.ContestStatusCompleted, "expired", true
+	}
+
+	if contest.CompletedCount() > 0 {
+		return domain.ContestStatusCompleted, "expired_with_activity", true
+	}
+
+	if now.Sub(contest.EndTime()) < w.config.AbandonGracePeriod {
+		return "", "", false
+	}
+	return domain.ContestStatusAbandoned, "no_activity_past_grace_period", true
+}
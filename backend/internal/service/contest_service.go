@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,13 +12,22 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
 )
 
+// ratingWindowContests is how many of the user's latest ratings of a
+// distribution profile tune its default mix
+const ratingWindowContests = 5
+
 // ContestService handles contest-related business logic
 type ContestService struct {
 	contestRepo    domain.ContestRepository
 	problemService *ProblemService
+	roadmapService *RoadmapService
+	quotas         *QuotaService
 	subRepo        domain.SubmissionRepository
+	attemptRepo    domain.AttemptRepository
+	events         domain.EventPublisher
 	tracer         trace.Tracer
 	logger         *zap.Logger
 }
@@ -25,14 +36,22 @@ type ContestService struct {
 func NewContestService(
 	contestRepo domain.ContestRepository,
 	problemService *ProblemService,
+	roadmapService *RoadmapService,
+	quotas *QuotaService,
 	subRepo domain.SubmissionRepository,
+	attemptRepo domain.AttemptRepository,
+	events domain.EventPublisher,
 	tracer trace.Tracer,
 	logger *zap.Logger,
 ) *ContestService {
 	return &ContestService{
 		contestRepo:    contestRepo,
 		problemService: problemService,
+		roadmapService: roadmapService,
+		quotas:         quotas,
 		subRepo:        subRepo,
+		attemptRepo:    attemptRepo,
+		events:         events,
 		tracer:         tracer,
 		logger:         logger,
 	}
@@ -47,74 +66,275 @@ func (s *ContestService) CreateContest(ctx context.Context, userID uuid.UUID, re
 		attribute.String("user.id", userID.String()),
 		attribute.Int("problem.count", req.ProblemCount),
 		attribute.Int("duration.minutes", req.DurationMinutes),
+		attribute.String("ordering", string(req.Ordering)),
+		attribute.Int("warmup.minutes", req.WarmupMinutes),
+		attribute.Bool("warmup.solved", req.SolvedWarmup),
+		attribute.String("source", string(req.Source)),
 	)
 
-	// Check if user already has an active contest
-	activeContest, err := s.contestRepo.FindActiveByUserID(userID)
-	if err != nil {
+	if req.Source == domain.SourceRoadmap && (len(req.Companies) > 0 || len(req.Difficulties) > 0) {
+		return nil, domain.NewValidationError("Companies and difficulties only apply to random contests", nil)
+	}
+
+	if err := s.ensureNoActiveContest(ctx, userID); err != nil {
 		return nil, err
 	}
-	if activeContest != nil {
-		// Check if it's expired
-		if activeContest.IsExpired() {
-			// Auto-complete expired contest
-			now := time.Now()
-			activeContest.Status = domain.ContestStatusCompleted
-			activeContest.EndedAt = &now
-			if err := s.contestRepo.Update(activeContest); err != nil {
-				s.logger.Error("Failed to complete expired contest", zap.Error(err))
-			}
+	if err := s.quotas.CheckContestQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Select problems for the contest
+	var (
+		problems []domain.Problem
+		warning  *domain.ContestWarning
+		tuning   *domain.DistributionTuning
+		ordering = req.Ordering
+		variant  string
+		err      error
+	)
+	if req.Source == domain.SourceRoadmap {
+		problems, warning, err = s.roadmapService.SelectNextProblems(ctx, userID, req.ProblemCount)
+		if err != nil {
+			return nil, err
+		}
+		// Roadmap contests keep roadmap order unless an ordering is requested
+		if ordering == "" {
+			ordering = domain.OrderingRoadmap
 		} else {
-			return nil, domain.ErrActiveContestExists
+			sort.SliceStable(problems, func(i, j int) bool {
+				return problems[i].Difficulty.Weight() < problems[j].Difficulty.Weight()
+			})
+			problems = s.problemService.OrderProblems(problems, ordering)
 		}
+	} else {
+		opts := req.SelectionOptions()
+		if variant = selectionVariant(ctx, userID); variant != "" {
+			opts.Algorithm = domain.SelectionAlgorithm(variant)
+			span.SetAttributes(attribute.String("experiment.variant", variant))
+		}
+		// The default mix is tuned by the user's ratings; a mix of chosen
+		// difficulties is theirs, so it is neither tuned nor rated toward tuning
+		if len(req.Difficulties) == 0 {
+			tuning = s.tuneDistribution(ctx, userID, req.ProblemCount)
+			opts.Tuning = tuning
+			span.SetAttributes(attribute.Float64("selection.tuning_factor", tuning.Factor))
+		}
+		problems, warning, err = s.problemService.SelectProblemsForContest(ctx, userID, req.ProblemCount, opts)
+		if err != nil {
+			return nil, err
+		}
+		if ordering == "" {
+			ordering = domain.OrderingAscending
+		}
+		problems = s.problemService.OrderProblems(problems, ordering)
 	}
 
-	// Select problems for the contest
-	problems, err := s.problemService.SelectProblemsForContest(ctx, userID, req.ProblemCount)
-	if err != nil {
-		return nil, err
+	// The timer starts once the optional warmup window is over
+	startedAt := time.Now()
+	var warmup *domain.Problem
+	if req.WarmupMinutes > 0 {
+		warmup, err = s.problemService.SelectWarmupProblem(ctx, userID, problems)
+		if err != nil {
+			return nil, err
+		}
+		startedAt = startedAt.Add(time.Duration(req.WarmupMinutes) * time.Minute)
+	}
+	var solvedWarmup *domain.Problem
+	if req.SolvedWarmup {
+		solvedWarmup, err = s.problemService.SelectSolvedWarmupProblem(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create the contest
 	contest := &domain.Contest{
 		UserID:          userID,
 		DurationMinutes: req.DurationMinutes,
-		StartedAt:       time.Now(),
+		StartedAt:       startedAt,
 		Status:          domain.ContestStatusActive,
+		Ordering:        ordering,
+		Warning:         warning,
+	}
+	if tuning != nil {
+		contest.DistributionProfile = tuning.Profile
+		contest.Tuning = tuning
+	}
+	if variant != "" {
+		contest.Experiment = domain.SelectionExperiment.Key
+		contest.Variant = variant
+	}
+	if warmup != nil {
+		contest.WarmupProblemID = &warmup.ID
+	}
+
+	if err := s.contestRepo.WithContext(ctx).Create(contest); err != nil {
+		return nil, err
+	}
+	contest.WarmupProblem = warmup // Attached after insert so the problem row is not re-saved
+
+	if err := s.addProblems(ctx, contest, solvedWarmup, problems); err != nil {
+		return nil, err
+	}
+
+	if tags := domain.NormalizeTags(req.Tags); len(tags) > 0 {
+		if err := s.contestRepo.WithContext(ctx).SetTags(contest.ID, tags); err != nil {
+			_ = s.contestRepo.WithContext(ctx).Delete(contest.ID)
+			return nil, err
+		}
+		contest.Tags = make([]domain.ContestTag, len(tags))
+		for i, tag := range tags {
+			contest.Tags[i] = domain.ContestTag{ContestID: contest.ID, Tag: tag}
+		}
+	}
+
+	s.publishCreated(ctx, contest, problems)
+	return contest, nil
+}
+
+// CloneContest creates a contest for the user with the same problems, order and
+// duration as the source contest. Warmups and tags are not copied.
+func (s *ContestService) CloneContest(ctx context.Context, userID uuid.UUID, source *domain.Contest) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.CloneContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("source.contest.id", source.ID.String()),
+	)
+
+	scored := source.ScoredProblems()
+	problems := make([]domain.Problem, len(scored))
+	for i, cp := range scored {
+		problems[i] = cp.Problem
+	}
+	return s.CreateFromProblems(ctx, userID, problems, source.DurationMinutes, source.Ordering)
+}
+
+// CreateFromProblems creates a running contest for the user of the given
+// problems, in order, such as a replayed contest or an imported template
+func (s *ContestService) CreateFromProblems(ctx context.Context, userID uuid.UUID, problems []domain.Problem, durationMinutes int, ordering domain.ContestOrdering) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.CreateFromProblems")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("problem.count", len(problems)),
+		attribute.Int("duration.minutes", durationMinutes),
+	)
+
+	if err := s.ensureNoActiveContest(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.quotas.CheckContestQuota(ctx, userID); err != nil {
+		return nil, err
 	}
 
-	if err := s.contestRepo.Create(contest); err != nil {
+	contest := &domain.Contest{
+		UserID:          userID,
+		DurationMinutes: durationMinutes,
+		StartedAt:       time.Now(),
+		Status:          domain.ContestStatusActive,
+		Ordering:        ordering,
+	}
+	if err := s.contestRepo.WithContext(ctx).Create(contest); err != nil {
+		return nil, err
+	}
+	if err := s.addProblems(ctx, contest, nil, problems); err != nil {
 		return nil, err
 	}
 
-	// Create contest problems with order
-	contestProblems := make([]domain.ContestProblem, len(problems))
+	s.publishCreated(ctx, contest, problems)
+	return contest, nil
+}
+
+// discardContest deletes a freshly created contest when the operation that
+// created it fails afterwards. The cleanup survives a cancelled request.
+func (s *ContestService) discardContest(ctx context.Context, contestID uuid.UUID) {
+	if err := s.contestRepo.WithContext(context.WithoutCancel(ctx)).Delete(contestID); err != nil {
+		logFor(ctx, s.logger).Error("Failed to discard contest", zap.String("contest_id", contestID.String()), zap.Error(err))
+	}
+}
+
+// selectionVariant returns the user's variant of the selection experiment, or
+// an empty string when the experiment's feature flag is off for them
+func selectionVariant(ctx context.Context, userID uuid.UUID) string {
+	if !infrastructure.FeatureEnabled(ctx, domain.SelectionExperiment.Flag, userID) {
+		return ""
+	}
+	return domain.SelectionExperiment.Variant(userID)
+}
+
+// ensureNoActiveContest fails with an ActiveContestError carrying the user's
+// running contest, if any. An expired one is completed on the way.
+func (s *ContestService) ensureNoActiveContest(ctx context.Context, userID uuid.UUID) error {
+	activeContest, err := s.contestRepo.WithContext(ctx).FindActiveByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if activeContest != nil {
+		// Check if it's expired
+		if activeContest.IsExpired() {
+			// Auto-complete expired contest
+			s.completeExpired(ctx, activeContest)
+		} else {
+			return &domain.ActiveContestError{Contest: activeContest}
+		}
+	}
+	return nil
+}
+
+// addProblems attaches the problems to a newly created contest in the given order,
+// preceded by the optional solved warm-up, deleting the contest if that fails
+func (s *ContestService) addProblems(ctx context.Context, contest *domain.Contest, warmup *domain.Problem, problems []domain.Problem) error {
+	// Create contest problems with order; the warm-up goes first as order 0
+	contestProblems := make([]domain.ContestProblem, 0, len(problems)+1)
+	if warmup != nil {
+		contestProblems = append(contestProblems, domain.ContestProblem{
+			ContestID: contest.ID,
+			ProblemID: warmup.ID,
+			Order:     0,
+			IsWarmup:  true,
+			Problem:   *warmup,
+		})
+	}
 	for i, p := range problems {
-		contestProblems[i] = domain.ContestProblem{
+		contestProblems = append(contestProblems, domain.ContestProblem{
 			ContestID:   contest.ID,
 			ProblemID:   p.ID,
 			Order:       i + 1,
 			IsCompleted: false,
 			Problem:     p, // Include problem data for response
-		}
+		})
 	}
 
-	if err := s.contestRepo.AddProblems(contest.ID, contestProblems); err != nil {
+	if err := s.contestRepo.WithContext(ctx).AddProblems(contest.ID, contestProblems); err != nil {
 		// Rollback: delete the contest
-		_ = s.contestRepo.Delete(contest.ID)
-		return nil, err
+		s.discardContest(ctx, contest.ID)
+		return err
 	}
 
 	// Attach problems to contest for response
 	contest.ContestProblems = contestProblems
+	return nil
+}
+
+// publishCreated announces a new contest and logs it
+func (s *ContestService) publishCreated(ctx context.Context, contest *domain.Contest, problems []domain.Problem) {
+	problemIDs := make([]uuid.UUID, len(problems))
+	for i, p := range problems {
+		problemIDs[i] = p.ID
+	}
+	s.events.Publish(ctx, domain.ContestCreatedEvent{
+		ContestID:  contest.ID,
+		UserID:     contest.UserID,
+		ProblemIDs: problemIDs,
+	})
 
-	s.logger.Info("Contest created",
+	logFor(ctx, s.logger).Info("Contest created",
 		zap.String("contest_id", contest.ID.String()),
-		zap.String("user_id", userID.String()),
 		zap.Int("problem_count", len(problems)),
 	)
-
-	return contest, nil
 }
 
 // GetContestByID retrieves a contest by ID
@@ -124,31 +344,120 @@ func (s *ContestService) GetContestByID(ctx context.Context, contestID uuid.UUID
 
 	span.SetAttributes(attribute.String("contest.id", contestID.String()))
 
-	contest, err := s.contestRepo.FindByIDWithProblems(contestID)
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Check and update expired status
 	if contest.IsExpired() {
-		now := time.Now()
-		contest.Status = domain.ContestStatusCompleted
-		contest.EndedAt = &now
-		if err := s.contestRepo.Update(contest); err != nil {
-			s.logger.Error("Failed to complete expired contest", zap.Error(err))
-		}
+		s.completeExpired(ctx, contest)
 	}
 
 	return contest, nil
 }
 
-// GetUserContests retrieves all contests for a user
-func (s *ContestService) GetUserContests(ctx context.Context, userID uuid.UUID) ([]domain.Contest, error) {
+// GetUserContests retrieves the contests of a user matching the filter
+func (s *ContestService) GetUserContests(ctx context.Context, userID uuid.UUID, filter domain.ContestFilter) ([]domain.Contest, error) {
 	ctx, span := s.tracer.Start(ctx, "ContestService.GetUserContests")
 	defer span.End()
 
+	if filter.Tag != "" {
+		if tags := domain.NormalizeTags([]string{filter.Tag}); len(tags) > 0 {
+			filter.Tag = tags[0]
+		}
+	}
+
+	switch {
+	case filter.From != nil && filter.To != nil && filter.From.After(*filter.To):
+		return nil, domain.NewValidationError("Invalid query parameters", "from is after to")
+	case filter.MaxDuration > 0 && filter.MinDuration > filter.MaxDuration:
+		return nil, domain.NewValidationError("Invalid query parameters", "min_duration is above max_duration")
+	case filter.MinScore != nil && filter.MaxScore != nil && *filter.MinScore > *filter.MaxScore:
+		return nil, domain.NewValidationError("Invalid query parameters", "min_score is above max_score")
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("filter.query", filter.Query != ""),
+		attribute.String("filter.tag", filter.Tag),
+		attribute.String("filter.status", string(filter.Status)),
+		attribute.String("filter.problem", filter.Problem),
+	)
+	return s.contestRepo.WithContext(ctx).FindByUserID(userID, filter)
+}
+
+// SetContestTags replaces the tags of a contest and returns the normalized tags
+func (s *ContestService) SetContestTags(ctx context.Context, userID, contestID uuid.UUID, tags []string) ([]string, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.SetContestTags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.Int("tags.count", len(tags)),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+
+	normalized := domain.NormalizeTags(tags)
+	if err := s.contestRepo.WithContext(ctx).SetTags(contestID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// SuggestTags returns the user's existing tags matching a prefix, for autocomplete
+func (s *ContestService) SuggestTags(ctx context.Context, userID uuid.UUID, prefix string, limit int) ([]domain.TagCount, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.SuggestTags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("tags.prefix", prefix),
+	)
+
+	if limit <= 0 {
+		limit = 10
+	}
+	return s.contestRepo.WithContext(ctx).FindTagsByUserID(userID, strings.ToLower(strings.TrimSpace(prefix)), limit)
+}
+
+// GetTopicHistory breaks the user's finished contests down by topic: how often
+// each topic was served and how much of it they completed
+func (s *ContestService) GetTopicHistory(ctx context.Context, userID uuid.UUID) ([]domain.TopicContestStats, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.GetTopicHistory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	stats, err := s.contestRepo.WithContext(ctx).FindTopicHistory(userID)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		stats = []domain.TopicContestStats{}
+	}
+	return stats, nil
+}
+
+// GetAvailability counts the unsolved problems left for the user's random
+// contests, so clients can check a contest size before creating it
+func (s *ContestService) GetAvailability(ctx context.Context, userID uuid.UUID, query *domain.AvailabilityQuery) (*domain.ProblemAvailability, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.GetAvailability")
+	defer span.End()
+
 	span.SetAttributes(attribute.String("user.id", userID.String()))
-	return s.contestRepo.FindByUserID(userID)
+
+	return s.problemService.GetAvailability(ctx, userID, query.SelectionOptions())
 }
 
 // GetActiveContest retrieves the user's active contest if any
@@ -158,7 +467,7 @@ func (s *ContestService) GetActiveContest(ctx context.Context, userID uuid.UUID)
 
 	span.SetAttributes(attribute.String("user.id", userID.String()))
 
-	contest, err := s.contestRepo.FindActiveByUserID(userID)
+	contest, err := s.contestRepo.WithContext(ctx).FindActiveByUserID(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -169,19 +478,14 @@ func (s *ContestService) GetActiveContest(ctx context.Context, userID uuid.UUID)
 
 	// Check and update expired status
 	if contest.IsExpired() {
-		now := time.Now()
-		contest.Status = domain.ContestStatusCompleted
-		contest.EndedAt = &now
-		if err := s.contestRepo.Update(contest); err != nil {
-			s.logger.Error("Failed to complete expired contest", zap.Error(err))
-		}
+		s.completeExpired(ctx, contest)
 	}
 
 	return contest, nil
 }
 
 // MarkProblemComplete marks a problem as completed in a contest
-func (s *ContestService) MarkProblemComplete(ctx context.Context, userID, contestID, problemID uuid.UUID, isCompleted bool) error {
+func (s *ContestService) MarkProblemComplete(ctx context.Context, userID, contestID, problemID uuid.UUID, isCompleted bool, confidence *int) error {
 	ctx, span := s.tracer.Start(ctx, "ContestService.MarkProblemComplete")
 	defer span.End()
 
@@ -193,7 +497,7 @@ func (s *ContestService) MarkProblemComplete(ctx context.Context, userID, contes
 	)
 
 	// Get the contest
-	contest, err := s.contestRepo.FindByID(contestID)
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
 	if err != nil {
 		return err
 	}
@@ -213,33 +517,92 @@ func (s *ContestService) MarkProblemComplete(ctx context.Context, userID, contes
 		return domain.ErrContestExpired
 	}
 
+	// Contest problems unlock once the warmup is over
+	if contest.InWarmup() {
+		return domain.ErrContestNotStarted
+	}
+
 	// Update problem status
-	if err := s.contestRepo.UpdateProblemStatus(contestID, problemID, isCompleted); err != nil {
+	changed, err := s.contestRepo.WithContext(ctx).UpdateProblemStatus(contestID, problemID, isCompleted)
+	if err != nil {
 		return err
 	}
 
+	// The solved warm-up is not scored: no completion counters, submission or events
+	if isWarmupProblem(contest, problemID) {
+		logFor(ctx, s.logger).Info("Warm-up problem marked as complete",
+			zap.String("contest_id", contestID.String()),
+			zap.String("problem_id", problemID.String()),
+			zap.Bool("is_completed", isCompleted),
+		)
+		return nil
+	}
+
+	if changed {
+		s.events.Publish(ctx, domain.ProblemCompletionChangedEvent{
+			ContestID:   contestID,
+			UserID:      userID,
+			ProblemID:   problemID,
+			IsCompleted: isCompleted,
+		})
+	}
+
 	// If marking as complete, also create a submission record
 	if isCompleted {
+		if changed {
+			if err := s.contestRepo.WithContext(ctx).StopProblemTimers(contestID, &problemID, time.Now()); err != nil {
+				logFor(ctx, s.logger).Error("Failed to stop problem timer", zap.Error(err))
+			}
+			if _, err := s.recordAttempt(ctx, contest, problemID, domain.AttemptSolved); err != nil {
+				logFor(ctx, s.logger).Error("Failed to record solved attempt", zap.Error(err))
+			}
+		}
+
 		// Check if already submitted
-		existing, err := s.subRepo.FindByUserAndProblem(userID, problemID)
+		existing, err := s.subRepo.WithContext(ctx).FindByUserAndProblem(userID, problemID)
 		if err != nil {
-			s.logger.Error("Failed to check existing submission", zap.Error(err))
+			logFor(ctx, s.logger).Error("Failed to check existing submission", zap.Error(err))
+		}
+
+		// Solving it again in a later contest restarts the review interval;
+		// within the same contest a rating only replaces the previous one
+		if existing != nil {
+			sameSolve := existing.ContestID != nil && *existing.ContestID == contestID
+			switch {
+			case changed && !sameSolve:
+				err = s.subRepo.WithContext(ctx).RecordResolve(userID, problemID, confidence, time.Now())
+			case confidence != nil:
+				err = s.subRepo.WithContext(ctx).UpdateConfidence(userID, problemID, *confidence)
+			}
+			if err != nil {
+				logFor(ctx, s.logger).Error("Failed to record solve confidence", zap.Error(err))
+			}
 		}
 
 		if existing == nil {
 			submission := &domain.Submission{
-				UserID:    userID,
-				ProblemID: problemID,
-				ContestID: &contestID,
-				SolvedAt:  time.Now(),
+				UserID:     userID,
+				ProblemID:  problemID,
+				ContestID:  &contestID,
+				SolvedAt:   time.Now(),
+				Confidence: confidence,
 			}
-			if err := s.subRepo.Create(submission); err != nil {
-				s.logger.Error("Failed to create submission", zap.Error(err))
+			// A concurrent solve may have inserted it since the lookup;
+			// only the request that inserted the row publishes the solve
+			created, err := s.subRepo.WithContext(ctx).Create(submission)
+			if err != nil {
+				logFor(ctx, s.logger).Error("Failed to create submission", zap.Error(err))
+			} else if created {
+				s.events.Publish(ctx, domain.ProblemSolvedEvent{
+					UserID:    userID,
+					ProblemID: problemID,
+					ContestID: contestID,
+				})
 			}
 		}
 	}
 
-	s.logger.Info("Problem marked as complete",
+	logFor(ctx, s.logger).Info("Problem marked as complete",
 		zap.String("contest_id", contestID.String()),
 		zap.String("problem_id", problemID.String()),
 		zap.Bool("is_completed", isCompleted),
@@ -248,68 +611,659 @@ func (s *ContestService) MarkProblemComplete(ctx context.Context, userID, contes
 	return nil
 }
 
-// CompleteContest manually completes a contest
-func (s *ContestService) CompleteContest(ctx context.Context, userID, contestID uuid.UUID) error {
-	ctx, span := s.tracer.Start(ctx, "ContestService.CompleteContest")
+// RecordAttempt records a try at a contest problem. A solved attempt marks the
+// problem complete; a failed one is only allowed while it is not completed.
+// Returns the user's attempt history for the problem.
+func (s *ContestService) RecordAttempt(ctx context.Context, userID, contestID, problemID uuid.UUID, outcome domain.AttemptOutcome) (*domain.AttemptHistory, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.RecordAttempt")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user.id", userID.String()),
 		attribute.String("contest.id", contestID.String()),
+		attribute.String("problem.id", problemID.String()),
+		attribute.String("attempt.outcome", string(outcome)),
 	)
 
-	contest, err := s.contestRepo.FindByID(contestID)
+	if outcome == domain.AttemptSolved {
+		if err := s.MarkProblemComplete(ctx, userID, contestID, problemID, true, nil); err != nil {
+			return nil, err
+		}
+		return s.attemptHistory(ctx, userID, problemID)
+	}
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Verify ownership
 	if contest.UserID != userID {
-		return domain.ErrForbidden
+		return nil, domain.ErrForbidden
 	}
-
-	// Check if contest is already completed
 	if contest.Status != domain.ContestStatusActive {
-		return domain.ErrContestNotActive
+		return nil, domain.ErrContestNotActive
+	}
+	if contest.IsExpired() {
+		return nil, domain.ErrContestExpired
+	}
+	if contest.InWarmup() {
+		return nil, domain.ErrContestNotStarted
 	}
 
-	// Complete the contest
-	now := time.Now()
-	contest.Status = domain.ContestStatusCompleted
-	contest.EndedAt = &now
+	var contestProblem *domain.ContestProblem
+	for i := range contest.ContestProblems {
+		if contest.ContestProblems[i].ProblemID == problemID {
+			contestProblem = &contest.ContestProblems[i]
+			break
+		}
+	}
+	// The solved warm-up is not scored, so it has no attempts either
+	if contestProblem == nil || contestProblem.IsWarmup {
+		return nil, domain.ErrProblemNotInContest
+	}
+	if contestProblem.IsCompleted {
+		return nil, domain.ErrProblemCompleted
+	}
+
+	if _, err := s.recordAttempt(ctx, contest, problemID, outcome); err != nil {
+		return nil, err
+	}
 
-	return s.contestRepo.Update(contest)
+	logFor(ctx, s.logger).Info("Attempt recorded",
+		zap.String("contest_id", contestID.String()),
+		zap.String("problem_id", problemID.String()),
+		zap.String("outcome", string(outcome)),
+	)
+	return s.attemptHistory(ctx, userID, problemID)
 }
 
-// AbandonContest abandons a contest
-func (s *ContestService) AbandonContest(ctx context.Context, userID, contestID uuid.UUID) error {
-	ctx, span := s.tracer.Start(ctx, "ContestService.AbandonContest")
+// StartProblem records that the user begins working on a contest problem. The
+// timer of the problem worked on before stops, as does this one once the
+// problem is completed or the contest ends; the time spent is reported per
+// problem in the contest and feeds the solve time stats of the user's progress.
+func (s *ContestService) StartProblem(ctx context.Context, userID, contestID, problemID uuid.UUID) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.StartProblem")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user.id", userID.String()),
 		attribute.String("contest.id", contestID.String()),
+		attribute.String("problem.id", problemID.String()),
 	)
 
-	contest, err := s.contestRepo.FindByID(contestID)
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Verify ownership
 	if contest.UserID != userID {
-		return domain.ErrForbidden
+		return nil, domain.ErrForbidden
 	}
-
-	// Check if contest is active
 	if contest.Status != domain.ContestStatusActive {
-		return domain.ErrContestNotActive
+		return nil, domain.ErrContestNotActive
+	}
+	if contest.IsExpired() {
+		return nil, domain.ErrContestExpired
+	}
+	if contest.InWarmup() {
+		return nil, domain.ErrContestNotStarted
+	}
+
+	var contestProblem *domain.ContestProblem
+	for i := range contest.ContestProblems {
+		if contest.ContestProblems[i].ProblemID == problemID {
+			contestProblem = &contest.ContestProblems[i]
+			break
+		}
+	}
+	// The solved warm-up is not scored, so it is not timed either
+	if contestProblem == nil || contestProblem.IsWarmup {
+		return nil, domain.ErrProblemNotInContest
+	}
+	if contestProblem.IsCompleted {
+		return nil, domain.ErrProblemCompleted
+	}
+
+	if err := s.contestRepo.WithContext(ctx).StartProblemTimer(contestID, problemID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Problem started",
+		zap.String("contest_id", contestID.String()),
+		zap.String("problem_id", problemID.String()),
+	)
+	return s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
+}
+
+// recordAttempt stores an attempt at a contest problem, timed from the user's
+// previous attempt at it in the contest or else from the contest start
+func (s *ContestService) recordAttempt(ctx context.Context, contest *domain.Contest, problemID uuid.UUID, outcome domain.AttemptOutcome) (*domain.Attempt, error) {
+	previous, err := s.attemptRepo.WithContext(ctx).FindLatestInContest(contest.UserID, contest.ID, problemID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Abandon the contest
 	now := time.Now()
-	contest.Status = domain.ContestStatusAbandoned
-	contest.EndedAt = &now
+	since := contest.StartedAt
+	if previous != nil && previous.AttemptedAt.After(since) {
+		since = previous.AttemptedAt
+	}
+	duration := 0
+	if now.After(since) {
+		duration = int(now.Sub(since).Seconds())
+	}
+
+	contestID := contest.ID
+	attempt := &domain.Attempt{
+		UserID:          contest.UserID,
+		ProblemID:       problemID,
+		ContestID:       &contestID,
+		Outcome:         outcome,
+		DurationSeconds: duration,
+		AttemptedAt:     now,
+	}
+	if err := s.attemptRepo.WithContext(ctx).Create(attempt); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// attemptHistory loads the user's attempts at a problem
+func (s *ContestService) attemptHistory(ctx context.Context, userID, problemID uuid.UUID) (*domain.AttemptHistory, error) {
+	attempts, err := s.attemptRepo.WithContext(ctx).FindByUserAndProblem(userID, problemID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewAttemptHistory(problemID, attempts), nil
+}
+
+// isWarmupProblem reports whether the problem is the contest's solved warm-up
+func isWarmupProblem(contest *domain.Contest, problemID uuid.UUID) bool {
+	for _, cp := range contest.ContestProblems {
+		if cp.ProblemID == problemID {
+			return cp.IsWarmup
+		}
+	}
+	return false
+}
+
+// MarkWarmupComplete marks the warmup problem as completed or not completed.
+// Warmups are practice only: no submission is recorded and no events are published.
+func (s *ContestService) MarkWarmupComplete(ctx context.Context, userID, contestID uuid.UUID, isCompleted bool) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.MarkWarmupComplete")
+	defer span.End()
 
-	return s.contestRepo.Update(contest)
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.Bool("is_completed", isCompleted),
+	)
+
+	contest, err := s.warmupContest(ctx, userID, contestID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.contestRepo.WithContext(ctx).SetWarmupCompleted(contest.ID, isCompleted); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Warmup marked as complete",
+		zap.String("contest_id", contestID.String()),
+		zap.Bool("is_completed", isCompleted),
+	)
+	return nil
+}
+
+// StartContest starts the contest timer now: it ends the warmup early, or
+// starts a pending contest that was assigned to the user
+func (s *ContestService) StartContest(ctx context.Context, userID, contestID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.StartContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	if contest.Status == domain.ContestStatusPending {
+		return s.activate(ctx, contest)
+	}
+	if err := checkWarmup(contest); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.contestRepo.WithContext(ctx).StartTimer(contest.ID, now); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Contest started after warmup",
+		zap.String("contest_id", contestID.String()),
+		zap.Duration("warmup_used", now.Sub(contest.CreatedAt)),
+		zap.Bool("warmup_completed", contest.WarmupCompleted),
+	)
+	return nil
+}
+
+// warmupContest loads a contest owned by the user that is still in its warmup window
+func (s *ContestService) warmupContest(ctx context.Context, userID, contestID uuid.UUID) (*domain.Contest, error) {
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+
+	if err := checkWarmup(contest); err != nil {
+		return nil, err
+	}
+	return contest, nil
+}
+
+// checkWarmup fails unless the contest is still in its warmup window
+func checkWarmup(contest *domain.Contest) error {
+	if contest.Status != domain.ContestStatusActive {
+		return domain.ErrContestNotActive
+	}
+	if !contest.HasWarmup() {
+		return domain.ErrNoWarmup
+	}
+	if !contest.InWarmup() {
+		return domain.ErrWarmupOver
+	}
+	return nil
+}
+
+// CreateAssignedContest creates a pending contest of the given problems, in
+// order, for a user who was assigned them. Its timer starts when the user
+// starts it, so it neither counts against their quota nor conflicts with
+// their running contest until then.
+func (s *ContestService) CreateAssignedContest(ctx context.Context, userID uuid.UUID, problems []domain.Problem, durationMinutes int) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.CreateAssignedContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("problem.count", len(problems)),
+		attribute.Int("duration.minutes", durationMinutes),
+	)
+
+	contest := &domain.Contest{
+		UserID:          userID,
+		DurationMinutes: durationMinutes,
+		StartedAt:       time.Now(), // Reset when it starts
+		Status:          domain.ContestStatusPending,
+		Ordering:        domain.OrderingAssigned,
+	}
+	if err := s.contestRepo.WithContext(ctx).Create(contest); err != nil {
+		return nil, err
+	}
+	if err := s.addProblems(ctx, contest, nil, problems); err != nil {
+		return nil, err
+	}
+
+	s.publishCreated(ctx, contest, problems)
+	return contest, nil
+}
+
+// ActivateContest starts a pending contest of the user and returns it with its problems
+func (s *ContestService) ActivateContest(ctx context.Context, userID, contestID uuid.UUID) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.ActivateContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+
+	if err := s.activate(ctx, contest); err != nil {
+		return nil, err
+	}
+	return contest, nil
+}
+
+// activate starts the timer of a pending contest once the user has no other running one
+func (s *ContestService) activate(ctx context.Context, contest *domain.Contest) error {
+	if contest.Status != domain.ContestStatusPending {
+		return domain.ErrContestNotPending
+	}
+	if err := s.ensureNoActiveContest(ctx, contest.UserID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	activated, err := s.contestRepo.WithContext(ctx).Activate(contest, now)
+	if err != nil {
+		return err
+	}
+	if !activated {
+		return domain.ErrContestNotPending
+	}
+	contest.Status = domain.ContestStatusActive
+	contest.StartedAt = now
+
+	logFor(ctx, s.logger).Info("Assigned contest started",
+		zap.String("contest_id", contest.ID.String()),
+		zap.Duration("pending_for", now.Sub(contest.CreatedAt)),
+	)
+	return nil
+}
+
+// StateComplexity records the time and space complexity the user states for
+// their solution of a completed contest problem. It is graded against the
+// canonical answer in the contest results.
+func (s *ContestService) StateComplexity(ctx context.Context, userID, contestID, problemID uuid.UUID, req *domain.StateComplexityRequest) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.StateComplexity")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.String("problem.id", problemID.String()),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	timeComplexity := strings.TrimSpace(req.TimeComplexity)
+	spaceComplexity := strings.TrimSpace(req.SpaceComplexity)
+	if err := s.contestRepo.WithContext(ctx).SetStatedComplexity(contestID, problemID, timeComplexity, spaceComplexity); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Problem complexity stated",
+		zap.String("contest_id", contestID.String()),
+		zap.String("problem_id", problemID.String()),
+	)
+	return nil
+}
+
+// UpdateRetro saves the user's retro notes on a finished contest
+func (s *ContestService) UpdateRetro(ctx context.Context, userID, contestID uuid.UUID, retro string) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.UpdateRetro")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.Int("retro.length", len(retro)),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	// Retros are for reflection after the fact; expired contests count as finished
+	if !contest.IsFinished() && !contest.IsExpired() {
+		return domain.ErrContestInProgress
+	}
+	if contest.IsExpired() {
+		s.completeExpired(ctx, contest)
+	}
+
+	if err := s.contestRepo.WithContext(ctx).UpdateRetro(contestID, strings.TrimSpace(retro), time.Now()); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Contest retro saved",
+		zap.String("contest_id", contestID.String()),
+		zap.Int("length", len(retro)),
+	)
+	return nil
+}
+
+// RateContest records how hard the user found a finished contest, replacing an
+// earlier rating. Ratings of random contests tune the mix of later ones.
+func (s *ContestService) RateContest(ctx context.Context, userID, contestID uuid.UUID, rating int) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.RateContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.Int("contest.rating", rating),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	// Like retros, ratings look back on the contest; expired contests count as finished
+	if !contest.IsFinished() && !contest.IsExpired() {
+		return domain.ErrContestInProgress
+	}
+	if contest.IsExpired() {
+		s.completeExpired(ctx, contest)
+	}
+
+	if err := s.contestRepo.WithContext(ctx).UpdateRating(contestID, rating, time.Now()); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Contest rated",
+		zap.String("contest_id", contestID.String()),
+		zap.String("profile", string(contest.DistributionProfile)),
+		zap.Int("rating", rating),
+	)
+	return nil
+}
+
+// tuneDistribution tunes the default mix of a random contest of count problems
+// by the user's latest ratings of its profile. Failures are logged and leave
+// the default mix untuned.
+func (s *ContestService) tuneDistribution(ctx context.Context, userID uuid.UUID, count int) *domain.DistributionTuning {
+	profile := domain.DistributionProfileFor(count)
+	ratings, err := s.contestRepo.WithContext(ctx).FindRecentRatings(userID, profile, ratingWindowContests)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to fetch difficulty ratings, using the default mix",
+			zap.String("profile", string(profile)),
+			zap.Error(err),
+		)
+		ratings = nil
+	}
+	return s.problemService.TuneDistribution(count, ratings)
+}
+
+// CompleteContest manually completes a contest
+func (s *ContestService) CompleteContest(ctx context.Context, userID, contestID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.CompleteContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	// Check if contest is already completed
+	if contest.Status != domain.ContestStatusActive {
+		return domain.ErrContestNotActive
+	}
+
+	// Complete the contest
+	now := time.Now()
+	contest.Status = domain.ContestStatusCompleted
+	contest.EndedAt = &now
+
+	finished, err := s.contestRepo.WithContext(ctx).Finish(contest)
+	if err != nil {
+		return err
+	}
+	if !finished {
+		return domain.ErrContestNotActive
+	}
+
+	s.stopProblemTimers(ctx, contest)
+	s.publishFinished(ctx, contest)
+	return nil
+}
+
+// AbandonContest abandons a contest
+func (s *ContestService) AbandonContest(ctx context.Context, userID, contestID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "ContestService.AbandonContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+
+	// Check if contest is active
+	if contest.Status != domain.ContestStatusActive {
+		return domain.ErrContestNotActive
+	}
+
+	// Abandon the contest
+	now := time.Now()
+	contest.Status = domain.ContestStatusAbandoned
+	contest.EndedAt = &now
+
+	finished, err := s.contestRepo.WithContext(ctx).Finish(contest)
+	if err != nil {
+		return err
+	}
+	if !finished {
+		return domain.ErrContestNotActive
+	}
+
+	s.stopProblemTimers(ctx, contest)
+	s.publishFinished(ctx, contest)
+	return nil
+}
+
+// completeExpired marks an expired contest as completed when the user comes back to it
+func (s *ContestService) completeExpired(ctx context.Context, contest *domain.Contest) {
+	now := time.Now()
+	contest.Status = domain.ContestStatusCompleted
+	contest.EndedAt = &now
+
+	finished, err := s.contestRepo.WithContext(ctx).Finish(contest)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to complete expired contest", zap.Error(err))
+		return
+	}
+	if !finished {
+		return
+	}
+	s.stopProblemTimers(ctx, contest)
+	s.publishFinished(ctx, contest)
+}
+
+// stopProblemTimers stops the problem timer still running when a contest
+// ends; an expired contest counts it up to the contest end, not to now
+func (s *ContestService) stopProblemTimers(ctx context.Context, contest *domain.Contest) {
+	at := *contest.EndedAt
+	if end := contest.EndTime(); at.After(end) {
+		at = end
+	}
+	if err := s.contestRepo.WithContext(ctx).StopProblemTimers(contest.ID, nil, at); err != nil {
+		logFor(ctx, s.logger).Error("Failed to stop problem timers", zap.Error(err))
+	}
+}
+
+// publishFinished announces that a contest left the active state
+func (s *ContestService) publishFinished(ctx context.Context, contest *domain.Contest) {
+	s.events.Publish(ctx, domain.ContestFinishedEvent{
+		ContestID: contest.ID,
+		UserID:    contest.UserID,
+		Status:    contest.Status,
+	})
+}
+
+// GetExperimentOutcomes reports completion and solve rates per variant of every
+// experiment, listing variants without contests too
+func (s *ContestService) GetExperimentOutcomes(ctx context.Context) ([]domain.ExperimentOutcome, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestService.GetExperimentOutcomes")
+	defer span.End()
+
+	outcomes := make([]domain.ExperimentOutcome, 0, len(domain.Experiments))
+	for _, experiment := range domain.Experiments {
+		rows, err := s.contestRepo.WithContext(ctx).FindVariantOutcomes(experiment.Key)
+		if err != nil {
+			return nil, err
+		}
+		byVariant := make(map[string]domain.VariantOutcome, len(rows))
+		for _, row := range rows {
+			byVariant[row.Variant] = row
+		}
+
+		variants := make([]domain.VariantOutcome, len(experiment.Variants))
+		for i, variant := range experiment.Variants {
+			outcome := byVariant[variant]
+			outcome.Variant = variant
+			if finished := outcome.Completed + outcome.Abandoned; finished > 0 {
+				outcome.CompletionRate = float64(outcome.Completed) / float64(finished)
+			}
+			if outcome.ProblemsServed > 0 {
+				outcome.SolveRate = float64(outcome.ProblemsSolved) / float64(outcome.ProblemsServed)
+			}
+			variants[i] = outcome
+		}
+		outcomes = append(outcomes, domain.ExperimentOutcome{Key: experiment.Key, Flag: experiment.Flag, Variants: variants})
+	}
+	return outcomes, nil
 }
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// ContestSessionService keeps the session state of a user's contest, the
+// problem on screen and their scratch notes, so another device can pick up
+// where the last one left off
+type ContestSessionService struct {
+	sessionRepo domain.ContestSessionRepository
+	contestRepo domain.ContestRepository
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewContestSessionService creates a new contest session service
+func NewContestSessionService(
+	sessionRepo domain.ContestSessionRepository,
+	contestRepo domain.ContestRepository,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *ContestSessionService {
+	return &ContestSessionService{
+		sessionRepo: sessionRepo,
+		contestRepo: contestRepo,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// GetState returns the session state of the user's contest; before the first
+// save it is empty at version 0
+func (s *ContestSessionService) GetState(ctx context.Context, userID, contestID uuid.UUID) (*domain.ContestSession, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestSessionService.GetState")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	if _, err := s.ownContest(ctx, userID, contestID); err != nil {
+		return nil, err
+	}
+	return s.current(ctx, contestID)
+}
+
+// SaveState replaces the session state of the user's contest. The request
+// names the version its device last read; when another device saved since,
+// nothing is written and the error carries the current state to merge with.
+func (s *ContestSessionService) SaveState(ctx context.Context, userID, contestID uuid.UUID, req *domain.UpdateContestSessionRequest) (*domain.ContestSession, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestSessionService.SaveState")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.Int("session.version", req.Version),
+	)
+
+	contest, err := s.ownContest(ctx, userID, contestID)
+	if err != nil {
+		return nil, err
+	}
+	if contest.IsFinished() {
+		return nil, domain.ErrContestNotActive
+	}
+
+	problems := make(map[uuid.UUID]bool, len(contest.ContestProblems))
+	for _, cp := range contest.ContestProblems {
+		problems[cp.ProblemID] = true
+	}
+	if req.CurrentProblemID != nil && !problems[*req.CurrentProblemID] {
+		return nil, domain.ErrProblemNotInContest
+	}
+
+	notes := make(map[string]string, len(req.Notes))
+	for key, note := range req.Notes {
+		problemID, err := uuid.Parse(key)
+		if err != nil {
+			return nil, domain.NewValidationError("Invalid note key", fmt.Sprintf("notes are keyed by problem ID, got %q", key))
+		}
+		if !problems[problemID] {
+			return nil, domain.ErrProblemNotInContest
+		}
+		if note != "" {
+			notes[problemID.String()] = note
+		}
+	}
+
+	session := &domain.ContestSession{
+		ContestID:        contestID,
+		UserID:           userID,
+		CurrentProblemID: req.CurrentProblemID,
+		Notes:            notes,
+		Device:           req.Device,
+		UpdatedAt:        time.Now(),
+	}
+	saved, err := s.sessionRepo.WithContext(ctx).Save(session, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		current, err := s.current(ctx, contestID)
+		if err != nil {
+			return nil, err
+		}
+		logFor(ctx, s.logger).Debug("Contest session save lost to another device",
+			zap.String("contest_id", contestID.String()),
+			zap.Int("expected_version", req.Version),
+			zap.Int("current_version", current.Version),
+		)
+		return nil, &domain.DomainError{Err: domain.ErrSessionConflict, Details: current}
+	}
+	return session, nil
+}
+
+// ownContest loads the contest with its problems and checks that it is the user's
+func (s *ContestSessionService) ownContest(ctx context.Context, userID, contestID uuid.UUID) (*domain.Contest, error) {
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
+	if err != nil {
+		return nil, err
+	}
+	if contest.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+	return contest, nil
+}
+
+// current returns the stored session state, or the empty state before the first save
+func (s *ContestSessionService) current(ctx context.Context, contestID uuid.UUID) (*domain.ContestSession, error) {
+	session, err := s.sessionRepo.WithContext(ctx).FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return &domain.ContestSession{ContestID: contestID, Notes: map[string]string{}}, nil
+	}
+	if session.Notes == nil {
+		session.Notes = map[string]string{}
+	}
+	return session, nil
+}
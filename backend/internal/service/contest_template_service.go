@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// ContestTemplateService exports contests as signed templates and starts
+// contests from the templates other users share
+type ContestTemplateService struct {
+	contestService *ContestService
+	problemRepo    domain.ProblemRepository
+	config         *infrastructure.ContestConfig
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewContestTemplateService creates a new contest template service
+func NewContestTemplateService(
+	contestService *ContestService,
+	problemRepo domain.ProblemRepository,
+	config *infrastructure.ContestConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *ContestTemplateService {
+	return &ContestTemplateService{
+		contestService: contestService,
+		problemRepo:    problemRepo,
+		config:         config,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// ExportTemplate returns the signed template of one of the user's contests.
+// Warmups and tags are left out, like when a contest is replayed.
+func (s *ContestTemplateService) ExportTemplate(ctx context.Context, userID, contestID uuid.UUID) (*domain.SignedContestTemplate, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestTemplateService.ExportTemplate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	contest, err := s.contestService.GetContestByID(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if contest.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+
+	scored := contest.ScoredProblems()
+	template := domain.ContestTemplate{
+		Version:         domain.ContestTemplateVersion,
+		DurationMinutes: contest.DurationMinutes,
+		Ordering:        contest.Ordering,
+		Problems:        make([]domain.TemplateProblem, len(scored)),
+		ExportedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+	for i, cp := range scored {
+		// Custom problems are private to their owner, so nobody could import them
+		if cp.Problem.IsCustom() {
+			return nil, domain.NewDomainError(domain.ErrBadRequest, "Contests with custom problems cannot be shared")
+		}
+		template.Problems[i] = domain.TemplateProblem{
+			Slug:       cp.Problem.Slug,
+			Title:      cp.Problem.Title,
+			Difficulty: cp.Problem.Difficulty,
+		}
+	}
+
+	signature, err := s.sign(&template)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SignedContestTemplate{Template: template, Signature: signature}, nil
+}
+
+// ImportTemplate starts a contest for the user from a template exported by
+// this deployment, matching its problems to the catalog by slug. Problems the
+// catalog lacks fail the import with the conflicts unless they are skipped; a
+// dry run only reports the conflicts.
+func (s *ContestTemplateService) ImportTemplate(ctx context.Context, userID uuid.UUID, req *domain.ImportContestTemplateRequest) (*domain.ContestTemplateImport, error) {
+	ctx, span := s.tracer.Start(ctx, "ContestTemplateService.ImportTemplate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("template.problems", len(req.Template.Problems)),
+		attribute.Bool("template.dry_run", req.DryRun),
+	)
+
+	if err := s.verify(&req.SignedContestTemplate); err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, len(req.Template.Problems))
+	for i, p := range req.Template.Problems {
+		slugs[i] = p.Slug
+	}
+	found, err := s.problemRepo.WithContext(ctx).FindByIDsOrSlugs(nil, slugs)
+	if err != nil {
+		return nil, err
+	}
+	bySlug := make(map[string]domain.Problem, len(found))
+	for _, p := range found {
+		bySlug[p.Slug] = p
+	}
+
+	// Keep the template's order; repeats and problems the catalog lacks drop out
+	problems := make([]domain.Problem, 0, len(req.Template.Problems))
+	conflicts := []domain.TemplateConflict{}
+	seen := make(map[string]bool, len(req.Template.Problems))
+	missing := 0
+	for _, want := range req.Template.Problems {
+		problem, ok := bySlug[want.Slug]
+		switch {
+		case seen[want.Slug]:
+			conflicts = append(conflicts, domain.TemplateConflict{Slug: want.Slug, Reason: domain.ConflictDuplicate})
+			continue
+		case !ok:
+			missing++
+			conflicts = append(conflicts, domain.TemplateConflict{Slug: want.Slug, Reason: domain.ConflictMissing, Expected: want.Difficulty})
+			continue
+		case want.Difficulty != "" && problem.Difficulty != want.Difficulty:
+			conflicts = append(conflicts, domain.TemplateConflict{
+				Slug:       want.Slug,
+				Reason:     domain.ConflictDifficultyChanged,
+				Expected:   want.Difficulty,
+				Difficulty: problem.Difficulty,
+			})
+		}
+		seen[want.Slug] = true
+		problems = append(problems, problem)
+	}
+	span.SetAttributes(attribute.Int("template.conflicts", len(conflicts)))
+
+	result := &domain.ContestTemplateImport{Problems: len(problems), Conflicts: conflicts}
+	if req.DryRun {
+		return result, nil
+	}
+	if (missing > 0 && !req.SkipMissing) || len(problems) == 0 {
+		return nil, &domain.DomainError{
+			Err:     domain.ErrTemplateConflict,
+			Message: fmt.Sprintf("%d of the template's %d problems are not in the catalog", missing, len(req.Template.Problems)),
+			Details: conflicts,
+		}
+	}
+
+	contest, err := s.contestService.CreateFromProblems(ctx, userID, problems, req.Template.DurationMinutes, req.Template.Ordering)
+	if err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Contest template imported",
+		zap.String("contest_id", contest.ID.String()),
+		zap.Int("problem_count", len(problems)),
+		zap.Int("conflicts", len(conflicts)),
+	)
+	response := contest.ToResponse()
+	result.Contest = &response
+	return result, nil
+}
+
+// sign returns the hex HMAC-SHA256 of the template's JSON encoding
+func (s *ContestTemplateService) sign(template *domain.ContestTemplate) (string, error) {
+	payload, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(s.config.TemplateSecret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// verify fails with ErrInvalidTemplate unless the template is of the current
+// version and its signature matches. The template is signed as re-encoded
+// here, so formatting changes made while sharing it do not matter.
+func (s *ContestTemplateService) verify(signed *domain.SignedContestTemplate) error {
+	if signed.Template.Version != domain.ContestTemplateVersion {
+		return domain.NewDomainError(domain.ErrInvalidTemplate,
+			fmt.Sprintf("Templates of version %d cannot be imported; export it again", signed.Template.Version))
+	}
+	expected, err := s.sign(&signed.Template)
+	if err != nil {
+		return err
+	}
+	got, err := hex.DecodeString(signed.Signature)
+	if err != nil {
+		return domain.ErrInvalidTemplate
+	}
+	want, _ := hex.DecodeString(expected)
+	if !hmac.Equal(got, want) {
+		return domain.ErrInvalidTemplate
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// CustomProblemService handles users' private custom problems
+type CustomProblemService struct {
+	problemRepo domain.ProblemRepository
+	quotas      *QuotaService
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewCustomProblemService creates a new custom problem service
+func NewCustomProblemService(
+	problemRepo domain.ProblemRepository,
+	quotas *QuotaService,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *CustomProblemService {
+	return &CustomProblemService{
+		problemRepo: problemRepo,
+		quotas:      quotas,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// ListCustomProblems returns the user's custom problems
+func (s *CustomProblemService) ListCustomProblems(ctx context.Context, userID uuid.UUID) ([]domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "CustomProblemService.ListCustomProblems")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	return s.problemRepo.WithContext(ctx).FindByOwner(userID)
+}
+
+// CreateCustomProblem adds a private custom problem for the user
+func (s *CustomProblemService) CreateCustomProblem(ctx context.Context, userID uuid.UUID, req *domain.CustomProblemRequest) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "CustomProblemService.CreateCustomProblem")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if err := s.quotas.CheckCustomProblemQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	problem := &domain.Problem{ID: uuid.New(), OwnerID: &userID}
+	req.Apply(problem)
+	if err := s.ensureURLAvailable(ctx, userID, problem.LeetCodeURL, uuid.Nil); err != nil {
+		return nil, err
+	}
+	problem.Slug = domain.CustomProblemSlug(problem.Title, problem.ID)
+
+	if err := s.problemRepo.WithContext(ctx).Create(problem); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Custom problem created",
+		zap.String("problem_id", problem.ID.String()),
+	)
+	return problem, nil
+}
+
+// UpdateCustomProblem replaces the fields of one of the user's custom problems
+func (s *CustomProblemService) UpdateCustomProblem(ctx context.Context, userID, problemID uuid.UUID, req *domain.CustomProblemRequest) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "CustomProblemService.UpdateCustomProblem")
+	defer span.End()
+
+	problem, err := s.findOwned(ctx, userID, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Apply(problem)
+	if err := s.ensureURLAvailable(ctx, userID, problem.LeetCodeURL, problem.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.problemRepo.WithContext(ctx).Update(problem); err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
+
+// DeleteCustomProblem deletes one of the user's custom problems that no contest uses
+func (s *CustomProblemService) DeleteCustomProblem(ctx context.Context, userID, problemID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "CustomProblemService.DeleteCustomProblem")
+	defer span.End()
+
+	problem, err := s.findOwned(ctx, userID, problemID)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.problemRepo.WithContext(ctx).IsReferenced(problem.ID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrProblemInUse
+	}
+	return s.problemRepo.WithContext(ctx).Delete(problem.ID)
+}
+
+// findOwned returns a custom problem owned by the user. Catalog problems and
+// other users' problems are reported as not found.
+func (s *CustomProblemService) findOwned(ctx context.Context, userID, problemID uuid.UUID) (*domain.Problem, error) {
+	problem, err := s.problemRepo.WithContext(ctx).FindByID(problemID)
+	if err != nil {
+		return nil, err
+	}
+	if problem.OwnerID == nil || *problem.OwnerID != userID {
+		return nil, domain.ErrProblemNotFound
+	}
+	return problem, nil
+}
+
+// ensureURLAvailable rejects URLs already used by another of the user's custom problems
+func (s *CustomProblemService) ensureURLAvailable(ctx context.Context, userID uuid.UUID, url string, self uuid.UUID) error {
+	existing, err := s.problemRepo.WithContext(ctx).FindByOwnerAndURL(userID, url)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != self {
+		return domain.ErrCustomProblemExists
+	}
+	return nil
+}
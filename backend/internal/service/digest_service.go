@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+const (
+	// digestTokenBytes is the entropy of a click-through token (32 hex characters)
+	digestTokenBytes = 16
+	// defaultDigestStatsDays is the window of the digest statistics when none is given
+	defaultDigestStatsDays = 28
+)
+
+// DigestService composes the weekly digest of suggested problems for users who
+// opted in, delivers it by email when a mail server is configured and always in
+// the app, and tracks click-throughs to measure how useful the suggestions are
+type DigestService struct {
+	digestRepo     domain.DigestRepository
+	userRepo       domain.UserRepository
+	roadmapService *RoadmapService
+	mailer         infrastructure.Mailer // Nil when email is off
+	config         *infrastructure.DigestConfig
+	siteURL        string // Base URL of the public frontend
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewDigestService creates a new digest service
+func NewDigestService(
+	digestRepo domain.DigestRepository,
+	userRepo domain.UserRepository,
+	roadmapService *RoadmapService,
+	mailer infrastructure.Mailer,
+	config *infrastructure.DigestConfig,
+	problemConfig *infrastructure.ProblemConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *DigestService {
+	return &DigestService{
+		digestRepo:     digestRepo,
+		userRepo:       userRepo,
+		roadmapService: roadmapService,
+		mailer:         mailer,
+		config:         config,
+		siteURL:        problemConfig.SiteURL,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// GetStatus returns whether the user opted in to the digest, with their latest digest
+func (s *DigestService) GetStatus(ctx context.Context, userID uuid.UUID) (*domain.DigestStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "DigestService.GetStatus")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	sub, err := s.digestRepo.WithContext(ctx).FindSubscription(userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &domain.DigestStatus{}
+	if sub != nil {
+		status.Enabled = true
+		status.OptedInAt = &sub.OptedInAt
+		status.LastSentAt = sub.LastSentAt
+	}
+
+	digest, err := s.digestRepo.WithContext(ctx).FindLatestDigest(userID)
+	if err != nil {
+		return nil, err
+	}
+	if digest != nil {
+		status.Latest = s.toResponse(digest)
+	}
+	return status, nil
+}
+
+// SetEnabled opts the user in to or out of the digest. A user who opts in gets
+// their first digest on the next round; opting in again changes nothing.
+func (s *DigestService) SetEnabled(ctx context.Context, userID uuid.UUID, enabled bool) (*domain.DigestStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "DigestService.SetEnabled")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("digest.enabled", enabled),
+	)
+
+	var err error
+	if enabled {
+		err = s.digestRepo.WithContext(ctx).Subscribe(&domain.DigestSubscription{UserID: userID, OptedInAt: time.Now()})
+	} else {
+		err = s.digestRepo.WithContext(ctx).Unsubscribe(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Digest preference changed",
+		zap.String("user_id", userID.String()),
+		zap.Bool("enabled", enabled),
+	)
+	return s.GetStatus(ctx, userID)
+}
+
+// Click records the first click on a digest item and returns the page of its
+// problem. Unknown tokens lead to the site's home page, so stale links still land somewhere.
+func (s *DigestService) Click(ctx context.Context, token string) (string, error) {
+	ctx, span := s.tracer.Start(ctx, "DigestService.Click")
+	defer span.End()
+
+	item, err := s.digestRepo.WithContext(ctx).FindItemByToken(token)
+	if err != nil {
+		return "", err
+	}
+	if item == nil {
+		span.SetAttributes(attribute.Bool("digest.item_found", false))
+		return s.siteURL + "/", nil
+	}
+
+	span.SetAttributes(attribute.String("digest.id", item.DigestID.String()))
+	if item.ClickedAt == nil {
+		if err := s.digestRepo.WithContext(ctx).MarkClicked(item.ID, time.Now()); err != nil {
+			return "", err
+		}
+	}
+	return s.siteURL + "/problems/" + item.Problem.Slug, nil
+}
+
+// SendDue sends the digest of every subscriber whose last one is at least a
+// period old, up to the batch size. Each subscriber is claimed before their
+// digest is composed, so concurrent rounds on several instances never send
+// twice; a digest that then fails is skipped until the next period.
+func (s *DigestService) SendDue(ctx context.Context) (*domain.DigestRunReport, error) {
+	ctx, span := s.tracer.Start(ctx, "DigestService.SendDue")
+	defer span.End()
+
+	now := time.Now()
+	before := now.Add(-s.config.Period)
+	subs, err := s.digestRepo.WithContext(ctx).FindDue(before, max(s.config.BatchSize, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.DigestRunReport{}
+	for _, sub := range subs {
+		if ctx.Err() != nil {
+			break
+		}
+		claimed, err := s.digestRepo.WithContext(ctx).Claim(sub.UserID, before, now)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			continue
+		}
+		report.Due++
+
+		emailed, err := s.send(ctx, sub.UserID, now)
+		switch {
+		case errors.Is(err, domain.ErrNotEnoughProblems):
+			report.Skipped++
+		case err != nil:
+			report.Failed++
+			logFor(ctx, s.logger).Error("Failed to send recommendation digest",
+				zap.String("user_id", sub.UserID.String()),
+				zap.Error(err),
+			)
+		default:
+			report.Sent++
+			if emailed {
+				report.Emailed++
+			}
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("digest.sent", report.Sent),
+		attribute.Int("digest.failed", report.Failed),
+	)
+	if report.Due > 0 {
+		logFor(ctx, s.logger).Info("Recommendation digests sent",
+			zap.Int("due", report.Due),
+			zap.Int("sent", report.Sent),
+			zap.Int("emailed", report.Emailed),
+			zap.Int("skipped", report.Skipped),
+			zap.Int("failed", report.Failed),
+		)
+	}
+	return report, nil
+}
+
+// send composes the user's digest from their next roadmap problems, stores it
+// and emails it when possible; a failed email leaves the digest in the app only
+func (s *DigestService) send(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		return false, err
+	}
+	problems, _, err := s.roadmapService.SelectNextProblems(ctx, userID, max(s.config.Size, 1))
+	if err != nil {
+		return false, err
+	}
+
+	digest := &domain.RecommendationDigest{
+		UserID:  userID,
+		Channel: domain.DigestInApp,
+		SentAt:  now,
+		Items:   make([]domain.DigestItem, len(problems)),
+	}
+	for i, p := range problems {
+		token, err := newDigestToken()
+		if err != nil {
+			return false, err
+		}
+		digest.Items[i] = domain.DigestItem{ProblemID: p.ID, Position: i + 1, Token: token, Problem: p}
+	}
+	if err := s.digestRepo.WithContext(ctx).CreateDigest(digest); err != nil {
+		return false, err
+	}
+
+	if s.mailer == nil {
+		return false, nil
+	}
+	if err := s.mailer.Send(ctx, s.email(user, digest)); err != nil {
+		logFor(ctx, s.logger).Warn("Failed to email recommendation digest",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return false, nil
+	}
+	if err := s.digestRepo.WithContext(ctx).MarkEmailed(digest.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// email renders a digest as a plain text email
+func (s *DigestService) email(user *domain.User, digest *domain.RecommendationDigest) infrastructure.Email {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\nHere are this week's suggested problems from your roadmap:\n\n", user.Username)
+	for _, item := range digest.Items {
+		fmt.Fprintf(&b, "%d. %s (%s)\n   %s\n\n", item.Position, item.Problem.Title, item.Problem.Difficulty, s.config.APIURL+digestClickPath(item.Token))
+	}
+	fmt.Fprintf(&b, "You get this email because you turned on weekly recommendations. You can turn them off in your settings at %s.\n", s.siteURL)
+	return infrastructure.Email{
+		To:      user.Email,
+		Subject: "Your suggested problems this week",
+		Body:    b.String(),
+	}
+}
+
+// GetStats reports how digests sent in the last days were received
+func (s *DigestService) GetStats(ctx context.Context, days int) (*domain.DigestStats, error) {
+	ctx, span := s.tracer.Start(ctx, "DigestService.GetStats")
+	defer span.End()
+
+	if days <= 0 {
+		days = defaultDigestStatsDays
+	}
+	span.SetAttributes(attribute.Int("digest.days", days))
+
+	stats, err := s.digestRepo.WithContext(ctx).Stats(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	if stats.Items > 0 {
+		stats.ClickRate = float64(stats.Clicks) / float64(stats.Items)
+		stats.SolveRate = float64(stats.SolvedItems) / float64(stats.Items)
+	}
+	if stats.Digests > 0 {
+		stats.DigestClickRate = float64(stats.ClickedDigests) / float64(stats.Digests)
+	}
+	return stats, nil
+}
+
+// toResponse converts a digest with its items and problems to its app view
+func (s *DigestService) toResponse(digest *domain.RecommendationDigest) *domain.DigestResponse {
+	resp := &domain.DigestResponse{
+		ID:      digest.ID,
+		Channel: digest.Channel,
+		SentAt:  digest.SentAt,
+		Items:   make([]domain.DigestItemResponse, len(digest.Items)),
+	}
+	for i, item := range digest.Items {
+		resp.Items[i] = domain.DigestItemResponse{
+			ProblemResponse: item.Problem.ToResponse(),
+			Position:        item.Position,
+			ClickURL:        digestClickPath(item.Token),
+			Clicked:         item.ClickedAt != nil,
+		}
+	}
+	return resp
+}
+
+// digestClickPath is the API path that records a click on a digest item
+func digestClickPath(token string) string {
+	return "/api/digests/click/" + token
+}
+
+// newDigestToken returns a random click-through token
+func newDigestToken() (string, error) {
+	b := make([]byte, digestTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// DigestWorker sends due recommendation digests on a schedule, on one instance
+// at a time. Overlapping rounds would still be safe: each subscriber is
+// claimed before sending.
+type DigestWorker struct {
+	digests *DigestService
+	config  *infrastructure.DigestConfig
+	locks   *infrastructure.JobLocks
+	logger  *zap.Logger
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+}
+
+// NewDigestWorker creates a new digest worker
+func NewDigestWorker(
+	digests *DigestService,
+	config *infrastructure.DigestConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *DigestWorker {
+	return &DigestWorker{
+		digests: digests,
+		config:  config,
+		locks:   locks,
+		logger:  logger,
+	}
+}
+
+// Start launches the digest loop in the background. A zero interval disables it.
+func (w *DigestWorker) Start(ctx context.Context) {
+	if w.config.Interval <= 0 {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		w.logger.Info("Digest worker started",
+			zap.Duration("interval", w.config.Interval),
+			zap.Duration("period", w.config.Period),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.locks.RunEvery(ctx, "digest", w.config.Interval, func() {
+					if _, err := w.digests.SendDue(ctx); err != nil {
+						w.logger.Error("Digest round failed", zap.Error(err))
+					}
+				})
+			}
+		}
+	}()
+}
+
+// Stop stops the digest loop and waits for an in-progress round to finish the
+// digest it is sending, or until ctx is done
+func (w *DigestWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Digest worker stopped")
+	return nil
+}
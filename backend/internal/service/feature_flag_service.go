@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// FeatureFlagService exposes feature flags to users and lets admins toggle them
+type FeatureFlagService struct {
+	flags  *infrastructure.FeatureFlags
+	tracer trace.Tracer
+	logger *zap.Logger
+}
+
+// NewFeatureFlagService creates a new feature flag service
+func NewFeatureFlagService(
+	flags *infrastructure.FeatureFlags,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *FeatureFlagService {
+	return &FeatureFlagService{
+		flags:  flags,
+		tracer: tracer,
+		logger: logger,
+	}
+}
+
+// ListFlags returns every known flag with its current setting
+func (s *FeatureFlagService) ListFlags(ctx context.Context) []domain.FeatureFlag {
+	ctx, span := s.tracer.Start(ctx, "FeatureFlagService.ListFlags")
+	defer span.End()
+
+	return s.flags.List(ctx)
+}
+
+// GetFeatures returns which flags are on for the user
+func (s *FeatureFlagService) GetFeatures(ctx context.Context, userID uuid.UUID) map[string]bool {
+	ctx, span := s.tracer.Start(ctx, "FeatureFlagService.GetFeatures")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	return s.flags.EnabledFor(ctx, userID)
+}
+
+// UpdateFlag turns a flag on or off, optionally for a share of users only
+func (s *FeatureFlagService) UpdateFlag(ctx context.Context, adminID uuid.UUID, key string, req *domain.UpdateFeatureFlagRequest) (*domain.FeatureFlag, error) {
+	ctx, span := s.tracer.Start(ctx, "FeatureFlagService.UpdateFlag")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("feature_flag.key", key))
+
+	flag := &domain.FeatureFlag{
+		Key:            key,
+		Enabled:        *req.Enabled,
+		RolloutPercent: 100,
+		UpdatedBy:      adminID,
+		UpdatedAt:      time.Now(),
+	}
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+	if err := s.flags.Set(ctx, flag); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Feature flag updated",
+		zap.String("flag", key),
+		zap.Bool("enabled", flag.Enabled),
+		zap.Int("rollout_percent", flag.RolloutPercent),
+	)
+
+	for _, current := range s.flags.List(ctx) {
+		if current.Key == key {
+			return &current, nil
+		}
+	}
+	return nil, domain.ErrFeatureFlagNotFound
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// defaultFeedLimit is how many items a feed page holds when the client does not ask
+const defaultFeedLimit = 20
+
+// solvedMilestones are the solved-problem counts that earn an achievement
+var solvedMilestones = []int{1, 10, 25, 50, 100, 150}
+
+// FeedService builds users' activity feeds from domain events, fanning each
+// activity out to the feeds of the user's friends as it happens
+type FeedService struct {
+	feedRepo      domain.FeedRepository
+	challengeRepo domain.ChallengeRepository
+	problemRepo   domain.ProblemRepository
+	progressRepo  domain.UserProgressRepository
+	tracer        trace.Tracer
+	logger        *zap.Logger
+}
+
+// NewFeedService creates a new feed service
+func NewFeedService(
+	feedRepo domain.FeedRepository,
+	challengeRepo domain.ChallengeRepository,
+	problemRepo domain.ProblemRepository,
+	progressRepo domain.UserProgressRepository,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *FeedService {
+	return &FeedService{
+		feedRepo:      feedRepo,
+		challengeRepo: challengeRepo,
+		problemRepo:   problemRepo,
+		progressRepo:  progressRepo,
+		tracer:        tracer,
+		logger:        logger,
+	}
+}
+
+// GetFeed returns a page of the user's feed, newest first, starting after the
+// item the cursor names
+func (s *FeedService) GetFeed(ctx context.Context, userID uuid.UUID, query *domain.FeedQuery) (*domain.FeedResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FeedService.GetFeed")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var before *uuid.UUID
+	if query.Cursor != "" {
+		id, err := uuid.Parse(query.Cursor)
+		if err != nil {
+			return nil, domain.NewValidationError("Invalid feed cursor", nil)
+		}
+		before = &id
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
+
+	// One item more than the page tells whether another page follows
+	items, err := s.feedRepo.WithContext(ctx).FindPage(userID, before, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	response := &domain.FeedResponse{Items: items}
+	if len(items) > limit {
+		response.Items = items[:limit]
+		response.NextCursor = items[limit-1].ID.String()
+	}
+	return response, nil
+}
+
+// HandleProblemSolved adds a first solve to the feeds, and the milestone it
+// reached if any. Solves of custom problems stay in the user's own feed, since
+// friends cannot see the problem.
+func (s *FeedService) HandleProblemSolved(ctx context.Context, event domain.Event) error {
+	e, ok := event.(domain.ProblemSolvedEvent)
+	if !ok {
+		return nil
+	}
+
+	problem, err := s.problemRepo.WithContext(ctx).FindByID(e.ProblemID)
+	if err != nil {
+		return err
+	}
+	item := domain.FeedItem{Kind: domain.FeedProblemSolved, ContestID: &e.ContestID, ProblemID: &e.ProblemID}
+	if err := s.fanOut(ctx, e.UserID, item, problem.OwnerID == nil); err != nil {
+		return err
+	}
+	return s.recordMilestone(ctx, e.UserID)
+}
+
+// HandleContestFinished adds a completed contest to the feeds; abandoned
+// contests are left out
+func (s *FeedService) HandleContestFinished(ctx context.Context, event domain.Event) error {
+	e, ok := event.(domain.ContestFinishedEvent)
+	if !ok || e.Status != domain.ContestStatusCompleted {
+		return nil
+	}
+	item := domain.FeedItem{Kind: domain.FeedContestCompleted, ContestID: &e.ContestID}
+	return s.fanOut(ctx, e.UserID, item, true)
+}
+
+// HandleChallengeAccepted adds an accepted challenge to the feeds of the
+// opponent and their friends, who now include the challenger
+func (s *FeedService) HandleChallengeAccepted(ctx context.Context, event domain.Event) error {
+	e, ok := event.(domain.ChallengeAcceptedEvent)
+	if !ok {
+		return nil
+	}
+	item := domain.FeedItem{Kind: domain.FeedChallengeAccepted, ContestID: &e.ContestID}
+	return s.fanOut(ctx, e.OpponentID, item, true)
+}
+
+// recordMilestone adds an achievement for the highest milestone the user's
+// solved count reached, unless it was recorded before. Milestones below it
+// are not recorded afterwards, so a user whose solves predate the feed gets
+// one achievement rather than several at once.
+func (s *FeedService) recordMilestone(ctx context.Context, userID uuid.UUID) error {
+	progress, err := s.progressRepo.WithContext(ctx).FindByUserID(userID)
+	if err != nil || progress == nil {
+		return err
+	}
+	solved := progress.EasySolved + progress.MediumSolved + progress.HardSolved
+
+	milestone := 0
+	for _, m := range solvedMilestones {
+		if solved >= m {
+			milestone = m
+		}
+	}
+	if milestone == 0 {
+		return nil
+	}
+	detail := fmt.Sprintf("solved_%d", milestone)
+	reached, err := s.feedRepo.WithContext(ctx).HasAchievement(userID, detail)
+	if err != nil || reached {
+		return err
+	}
+	return s.fanOut(ctx, userID, domain.FeedItem{Kind: domain.FeedAchievement, Detail: detail}, true)
+}
+
+// fanOut stores the activity in the actor's feed and, when shared, in the
+// feed of each of their friends
+func (s *FeedService) fanOut(ctx context.Context, actorID uuid.UUID, item domain.FeedItem, shared bool) error {
+	owners := []uuid.UUID{actorID}
+	if shared {
+		friends, err := s.challengeRepo.WithContext(ctx).FindFriends(actorID)
+		if err != nil {
+			return err
+		}
+		owners = append(owners, friends...)
+	}
+
+	items := make([]domain.FeedItem, len(owners))
+	for i, owner := range owners {
+		items[i] = item
+		items[i].OwnerID = owner
+		items[i].ActorID = actorID
+	}
+	if err := s.feedRepo.WithContext(ctx).Create(items); err != nil {
+		return err
+	}
+
+	logFor(ctx, s.logger).Debug("Feed item fanned out",
+		zap.String("kind", string(item.Kind)),
+		zap.String("actor_id", actorID.String()),
+		zap.Int("feeds", len(items)),
+	)
+	return nil
+}
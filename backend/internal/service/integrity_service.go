@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// IntegrityService checks raw data for orphans and duplicates and recomputes the
+// data derived from it, repairing what it finds unless asked for a dry run
+type IntegrityService struct {
+	integrityRepo domain.IntegrityRepository
+	tracer        trace.Tracer
+	logger        *zap.Logger
+}
+
+// NewIntegrityService creates a new integrity service
+func NewIntegrityService(
+	integrityRepo domain.IntegrityRepository,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *IntegrityService {
+	return &IntegrityService{
+		integrityRepo: integrityRepo,
+		tracer:        tracer,
+		logger:        logger,
+	}
+}
+
+// Run checks every integrity issue in order and, unless dryRun is set, repairs
+// each one found before checking the next
+func (s *IntegrityService) Run(ctx context.Context, dryRun bool) (*domain.IntegrityReport, error) {
+	ctx, span := s.tracer.Start(ctx, "IntegrityService.Run")
+	defer span.End()
+
+	span.SetAttributes(attribute.Bool("integrity.dry_run", dryRun))
+
+	report := &domain.IntegrityReport{
+		DryRun:    dryRun,
+		Findings:  make([]domain.IntegrityFinding, 0, len(domain.IntegrityIssues)),
+		StartedAt: time.Now(),
+	}
+	for _, issue := range domain.IntegrityIssues {
+		finding := domain.IntegrityFinding{Issue: issue}
+
+		found, err := s.integrityRepo.WithContext(ctx).Count(issue)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", issue, err)
+		}
+		finding.Found = found
+
+		if found > 0 && !dryRun {
+			repaired, err := s.integrityRepo.WithContext(ctx).Repair(issue)
+			if err != nil {
+				return nil, fmt.Errorf("repair %s: %w", issue, err)
+			}
+			finding.Repaired = repaired
+		}
+
+		if found > 0 {
+			logFor(ctx, s.logger).Warn("Integrity issue found",
+				zap.String("issue", string(issue)),
+				zap.Int64("found", finding.Found),
+				zap.Int64("repaired", finding.Repaired),
+				zap.Bool("dry_run", dryRun),
+			)
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+	report.FinishedAt = time.Now()
+
+	logFor(ctx, s.logger).Info("Integrity run finished",
+		zap.Bool("dry_run", dryRun),
+		zap.Duration("duration", report.FinishedAt.Sub(report.StartedAt)),
+	)
+	return report, nil
+}
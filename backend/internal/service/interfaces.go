@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+//go:generate go run ../../cmd/mockgen -out mocks/services_gen.go ContestServicer UserServicer ProblemServicer SavedFilterServicer
+
+// The handlers depend on these interfaces rather than on the services, so
+// they can be exercised against the mocks in ./mocks without a database.
+// Each lists the methods its handlers and middleware call.
+
+// ContestServicer is the contest service as the contest handler uses it
+type ContestServicer interface {
+	CreateContest(ctx context.Context, userID uuid.UUID, req *domain.CreateContestRequest) (*domain.Contest, error)
+	GetUserContests(ctx context.Context, userID uuid.UUID, filter domain.ContestFilter) ([]domain.Contest, error)
+	GetContestByID(ctx context.Context, contestID uuid.UUID) (*domain.Contest, error)
+	GetActiveContest(ctx context.Context, userID uuid.UUID) (*domain.Contest, error)
+	SuggestTags(ctx context.Context, userID uuid.UUID, prefix string, limit int) ([]domain.TagCount, error)
+	GetAvailability(ctx context.Context, userID uuid.UUID, query *domain.AvailabilityQuery) (*domain.ProblemAvailability, error)
+	GetTopicHistory(ctx context.Context, userID uuid.UUID) ([]domain.TopicContestStats, error)
+	SetContestTags(ctx context.Context, userID, contestID uuid.UUID, tags []string) ([]string, error)
+	MarkProblemComplete(ctx context.Context, userID, contestID, problemID uuid.UUID, isCompleted bool, confidence *int) error
+	MarkWarmupComplete(ctx context.Context, userID, contestID uuid.UUID, isCompleted bool) error
+	RecordAttempt(ctx context.Context, userID, contestID, problemID uuid.UUID, outcome domain.AttemptOutcome) (*domain.AttemptHistory, error)
+	StartProblem(ctx context.Context, userID, contestID, problemID uuid.UUID) (*domain.Contest, error)
+	StateComplexity(ctx context.Context, userID, contestID, problemID uuid.UUID, req *domain.StateComplexityRequest) error
+	StartContest(ctx context.Context, userID, contestID uuid.UUID) error
+	UpdateRetro(ctx context.Context, userID, contestID uuid.UUID, retro string) error
+	RateContest(ctx context.Context, userID, contestID uuid.UUID, rating int) error
+	CompleteContest(ctx context.Context, userID, contestID uuid.UUID) error
+	AbandonContest(ctx context.Context, userID, contestID uuid.UUID) error
+	GetExperimentOutcomes(ctx context.Context) ([]domain.ExperimentOutcome, error)
+}
+
+// UserServicer is the user service as the auth and user handlers and the
+// auth middleware use it
+type UserServicer interface {
+	Register(ctx context.Context, req *domain.UserCreateRequest) (*domain.User, *TokenPair, error)
+	Login(ctx context.Context, email, password string) (*domain.User, *TokenPair, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, claims *TokenClaims, refreshToken string) error
+	RevokeAllTokens(ctx context.Context, userID uuid.UUID) error
+	ValidateAccessToken(ctx context.Context, tokenString string) (*TokenClaims, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetUserProgress(ctx context.Context, userID uuid.UUID) (*domain.UserProgress, error)
+	GetReviewQueue(ctx context.Context, userID uuid.UUID, query domain.ReviewQueueQuery) (*domain.ReviewQueue, error)
+	GetAttemptHistory(ctx context.Context, userID, problemID uuid.UUID) (*domain.AttemptHistory, error)
+	ChangePassword(ctx context.Context, userID uuid.UUID, req *domain.ChangePasswordRequest) error
+}
+
+// ProblemServicer is the problem service as the problem handler uses it
+type ProblemServicer interface {
+	FindProblems(ctx context.Context, userID uuid.UUID, criteria domain.ProblemCriteria) ([]domain.Problem, error)
+	SearchProblems(ctx context.Context, query string, limit int) (*domain.ProblemSearchResponse, error)
+	GetProblemByID(ctx context.Context, id, viewerID uuid.UUID) (*domain.Problem, error)
+	GetProblemsBatch(ctx context.Context, keys []string, viewerID uuid.UUID) ([]domain.Problem, []string, error)
+	GetProblemPage(ctx context.Context, slug string) (*domain.ProblemPage, error)
+	GetSitemap(ctx context.Context) (*domain.Sitemap, error)
+	GetCompanies(ctx context.Context) ([]domain.CompanyCount, error)
+	GetPrerequisites(ctx context.Context, problemID uuid.UUID) ([]domain.Problem, error)
+	GetProblemStats(ctx context.Context) (*domain.ProblemStats, error)
+	GetCalibration(ctx context.Context) ([]domain.ProblemCalibration, error)
+	SetProblemCompanies(ctx context.Context, problemID uuid.UUID, companies []string) (*domain.Problem, error)
+	SetProblemImportance(ctx context.Context, problemID uuid.UUID, importance int) (*domain.Problem, error)
+	SetProblemComplexity(ctx context.Context, problemID uuid.UUID, req *domain.SetProblemComplexityRequest) (*domain.ProblemComplexity, error)
+}
+
+// SavedFilterServicer is the saved filter service as the problem handler uses it
+type SavedFilterServicer interface {
+	GetFilter(ctx context.Context, userID, filterID uuid.UUID) (*domain.SavedFilter, error)
+}
+
+var (
+	_ ContestServicer     = (*ContestService)(nil)
+	_ UserServicer        = (*UserService)(nil)
+	_ ProblemServicer     = (*ProblemService)(nil)
+	_ SavedFilterServicer = (*SavedFilterService)(nil)
+)
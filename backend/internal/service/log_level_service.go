@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// LogLevelService lets admins change the log level of every instance at runtime
+type LogLevelService struct {
+	logLevel *infrastructure.LogLevel
+	tracer   trace.Tracer
+	logger   *zap.Logger
+}
+
+// NewLogLevelService creates a new log level service
+func NewLogLevelService(
+	logLevel *infrastructure.LogLevel,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *LogLevelService {
+	return &LogLevelService{
+		logLevel: logLevel,
+		tracer:   tracer,
+		logger:   logger,
+	}
+}
+
+// GetLogLevel reports the log level in effect
+func (s *LogLevelService) GetLogLevel(ctx context.Context) domain.LogLevelStatus {
+	ctx, span := s.tracer.Start(ctx, "LogLevelService.GetLogLevel")
+	defer span.End()
+
+	return s.logLevel.Status(ctx)
+}
+
+// SetLogLevel overrides the log level, optionally for a limited time, or returns to LOG_LEVEL
+func (s *LogLevelService) SetLogLevel(ctx context.Context, adminID uuid.UUID, req *domain.SetLogLevelRequest) (domain.LogLevelStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "LogLevelService.SetLogLevel")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("log.level", req.Level))
+
+	now := time.Now()
+	override := &domain.LogLevelOverride{
+		Level:     req.Level,
+		UpdatedBy: adminID,
+		UpdatedAt: now,
+	}
+	if req.Level == domain.LogLevelDefault {
+		override.Level = ""
+	} else if req.DurationMinutes > 0 {
+		expiresAt := now.Add(time.Duration(req.DurationMinutes) * time.Minute)
+		override.ExpiresAt = &expiresAt
+	}
+
+	status, err := s.logLevel.Set(ctx, override)
+	if err != nil {
+		return domain.LogLevelStatus{}, err
+	}
+
+	logFor(ctx, s.logger).Info("Log level override updated",
+		zap.String("level", req.Level),
+		zap.Timep("expires_at", override.ExpiresAt),
+	)
+
+	return status, nil
+}
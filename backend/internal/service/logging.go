@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// logFor returns the request-scoped logger carried by ctx (with the request,
+// trace and user IDs), or the service's own logger outside a request
+func logFor(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	return infrastructure.LoggerFromContext(ctx, logger)
+}
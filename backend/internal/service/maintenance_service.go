@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// MaintenanceService announces maintenance windows and lets admins schedule them
+type MaintenanceService struct {
+	maintenance *infrastructure.Maintenance
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewMaintenanceService creates a new maintenance service
+func NewMaintenanceService(
+	maintenance *infrastructure.Maintenance,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *MaintenanceService {
+	return &MaintenanceService{
+		maintenance: maintenance,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// GetStatus reports ongoing or upcoming maintenance
+func (s *MaintenanceService) GetStatus(ctx context.Context) domain.MaintenanceStatus {
+	ctx, span := s.tracer.Start(ctx, "MaintenanceService.GetStatus")
+	defer span.End()
+
+	return s.maintenance.Status(ctx)
+}
+
+// SetMaintenance starts, schedules or ends the maintenance window
+func (s *MaintenanceService) SetMaintenance(ctx context.Context, adminID uuid.UUID, req *domain.SetMaintenanceRequest) (domain.MaintenanceStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "MaintenanceService.SetMaintenance")
+	defer span.End()
+
+	span.SetAttributes(attribute.Bool("maintenance.enabled", *req.Enabled))
+
+	now := time.Now()
+	window := &domain.MaintenanceWindow{
+		Enabled:   *req.Enabled,
+		Message:   req.Message,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		UpdatedBy: adminID,
+		UpdatedAt: now,
+	}
+	if window.Enabled && window.EndsAt != nil {
+		start := now
+		if window.StartsAt != nil {
+			start = *window.StartsAt
+		}
+		if !window.EndsAt.After(start) {
+			return domain.MaintenanceStatus{}, domain.ErrInvalidMaintenanceTime
+		}
+	}
+
+	if err := s.maintenance.Set(ctx, window); err != nil {
+		return domain.MaintenanceStatus{}, err
+	}
+
+	logFor(ctx, s.logger).Info("Maintenance window updated",
+		zap.Bool("enabled", window.Enabled),
+		zap.Timep("starts_at", window.StartsAt),
+		zap.Timep("ends_at", window.EndsAt),
+	)
+
+	return s.maintenance.Status(ctx), nil
+}
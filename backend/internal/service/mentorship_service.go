@@ -0,0 +1,743 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// MentorshipService handles mentor/mentee links: a mentor invites a mentee,
+// the mentee consents or declines, and either side can revoke. While a link is
+// active the mentor can read the mentee's contests, timing analytics and retro
+// notes, and assign them contests or problem sets with a due date; every read,
+// assignment and change of consent goes to the link's audit trail.
+type MentorshipService struct {
+	mentorshipRepo domain.MentorshipRepository
+	userRepo       domain.UserRepository
+	progressRepo   domain.UserProgressRepository
+	contestRepo    domain.ContestRepository
+	contestService *ContestService
+	problemService *ProblemService
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewMentorshipService creates a new mentorship service
+func NewMentorshipService(
+	mentorshipRepo domain.MentorshipRepository,
+	userRepo domain.UserRepository,
+	progressRepo domain.UserProgressRepository,
+	contestRepo domain.ContestRepository,
+	contestService *ContestService,
+	problemService *ProblemService,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *MentorshipService {
+	return &MentorshipService{
+		mentorshipRepo: mentorshipRepo,
+		userRepo:       userRepo,
+		progressRepo:   progressRepo,
+		contestRepo:    contestRepo,
+		contestService: contestService,
+		problemService: problemService,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// Invite invites the user with the given email to be mentored by the caller.
+// Inviting again after a decline or revocation reopens the same mentorship.
+func (s *MentorshipService) Invite(ctx context.Context, mentorID uuid.UUID, menteeEmail string) (*domain.Mentorship, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.Invite")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", mentorID.String()))
+
+	mentee, err := s.userRepo.WithContext(ctx).FindByEmail(menteeEmail)
+	if err != nil {
+		return nil, err
+	}
+	if mentee.ID == mentorID {
+		return nil, domain.ErrSelfMentorship
+	}
+
+	now := time.Now()
+	mentorship, err := s.mentorshipRepo.WithContext(ctx).FindByPair(mentorID, mentee.ID)
+	switch {
+	case errors.Is(err, domain.ErrMentorshipNotFound):
+		mentorship = &domain.Mentorship{
+			MentorID:  mentorID,
+			MenteeID:  mentee.ID,
+			Status:    domain.MentorshipPending,
+			InvitedAt: now,
+		}
+		if err := s.mentorshipRepo.WithContext(ctx).Create(mentorship); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case mentorship.IsOpen():
+		return nil, domain.ErrMentorshipExists
+	default:
+		from := mentorship.Status
+		mentorship.Status = domain.MentorshipPending
+		mentorship.InvitedAt = now
+		mentorship.RespondedAt, mentorship.RevokedAt, mentorship.RevokedBy = nil, nil, nil
+		updated, err := s.mentorshipRepo.WithContext(ctx).Update(mentorship, from)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			return nil, domain.ErrMentorshipExists
+		}
+	}
+
+	if err := s.audit(ctx, mentorship, mentorID, domain.MentorshipInvited, nil); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Mentorship invite sent", zap.String("mentorship_id", mentorship.ID.String()))
+	return mentorship, nil
+}
+
+// GetMentorships lists the caller's mentorships as mentor and as mentee
+func (s *MentorshipService) GetMentorships(ctx context.Context, userID uuid.UUID) (*domain.MentorshipList, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetMentorships")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	mentorships, err := s.mentorshipRepo.WithContext(ctx).FindForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	list := &domain.MentorshipList{Mentoring: []domain.MentorshipResponse{}, Mentors: []domain.MentorshipResponse{}}
+	for _, m := range mentorships {
+		if m.MentorID == userID {
+			list.Mentoring = append(list.Mentoring, m)
+		} else {
+			list.Mentors = append(list.Mentors, m)
+		}
+	}
+	return list, nil
+}
+
+// Respond records the mentee's answer to a pending invite; accepting grants
+// the mentor read access
+func (s *MentorshipService) Respond(ctx context.Context, userID, mentorshipID uuid.UUID, accept bool) (*domain.Mentorship, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.Respond")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+		attribute.Bool("accept", accept),
+	)
+
+	mentorship, err := s.party(ctx, mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mentorship.MenteeID != userID {
+		return nil, domain.ErrForbidden
+	}
+	if mentorship.Status != domain.MentorshipPending {
+		return nil, domain.ErrMentorshipNotPending
+	}
+
+	now := time.Now()
+	mentorship.RespondedAt = &now
+	mentorship.Status = domain.MentorshipDeclined
+	action := domain.MentorshipDeclinedAction
+	if accept {
+		mentorship.Status = domain.MentorshipActive
+		action = domain.MentorshipAccepted
+	}
+	updated, err := s.mentorshipRepo.WithContext(ctx).Update(mentorship, domain.MentorshipPending)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, domain.ErrMentorshipNotPending
+	}
+	if err := s.audit(ctx, mentorship, userID, action, nil); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Mentorship invite answered",
+		zap.String("mentorship_id", mentorship.ID.String()),
+		zap.String("status", string(mentorship.Status)),
+	)
+	return mentorship, nil
+}
+
+// Revoke ends a pending or active mentorship; either side can revoke it. The
+// mentee's assigned contests they never started are deleted with it.
+func (s *MentorshipService) Revoke(ctx context.Context, userID, mentorshipID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.Revoke")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+	)
+
+	mentorship, err := s.party(ctx, mentorshipID, userID)
+	if err != nil {
+		return err
+	}
+	if !mentorship.IsOpen() {
+		return domain.ErrMentorshipNotActive
+	}
+
+	from := mentorship.Status
+	now := time.Now()
+	mentorship.Status = domain.MentorshipRevoked
+	mentorship.RevokedAt = &now
+	mentorship.RevokedBy = &userID
+	updated, err := s.mentorshipRepo.WithContext(ctx).Update(mentorship, from)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return domain.ErrMentorshipNotActive
+	}
+	if err := s.audit(ctx, mentorship, userID, domain.MentorshipRevokedAction, nil); err != nil {
+		return err
+	}
+	s.discardContests(ctx, mentorship.ID)
+
+	logFor(ctx, s.logger).Info("Mentorship revoked", zap.String("mentorship_id", mentorship.ID.String()))
+	return nil
+}
+
+// GetMenteeContests lists the mentee's contests for their mentor
+func (s *MentorshipService) GetMenteeContests(ctx context.Context, userID, mentorshipID uuid.UUID) ([]domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetMenteeContests")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+	)
+
+	mentorship, err := s.mentorAccess(ctx, mentorshipID, userID, domain.MentorViewedContests, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.contestService.GetUserContests(ctx, mentorship.MenteeID, domain.ContestFilter{})
+}
+
+// GetMenteeContest returns one of the mentee's contests for their mentor
+func (s *MentorshipService) GetMenteeContest(ctx context.Context, userID, mentorshipID, contestID uuid.UUID) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetMenteeContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	mentorship, err := s.mentorAccess(ctx, mentorshipID, userID, domain.MentorViewedContest, &contestID)
+	if err != nil {
+		return nil, err
+	}
+	contest, err := s.contestService.GetContestByID(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+	// Contests of anyone else are not found through the mentorship
+	if contest.UserID != mentorship.MenteeID {
+		return nil, domain.ErrContestNotFound
+	}
+	return contest, nil
+}
+
+// GetMenteeTiming returns the mentee's solve times and contest statistics for their mentor
+func (s *MentorshipService) GetMenteeTiming(ctx context.Context, userID, mentorshipID uuid.UUID) (*domain.MenteeTiming, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetMenteeTiming")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+	)
+
+	mentorship, err := s.mentorAccess(ctx, mentorshipID, userID, domain.MentorViewedTiming, nil)
+	if err != nil {
+		return nil, err
+	}
+	solveTimes, err := s.contestRepo.WithContext(ctx).FindSolveTimes(mentorship.MenteeID)
+	if err != nil {
+		return nil, err
+	}
+	timing := &domain.MenteeTiming{SolveTimes: solveTimes}
+
+	summary, err := s.progressRepo.WithContext(ctx).FindByUserID(mentorship.MenteeID)
+	if err != nil {
+		return nil, err
+	}
+	if summary != nil {
+		progress := summary.ToProgress()
+		timing.ContestStats = progress.ContestStats
+		timing.LastActiveAt = progress.LastActiveAt
+	}
+	return timing, nil
+}
+
+// GetMenteeNotes lists the retro notes of the mentee's contests for their mentor, newest contest first
+func (s *MentorshipService) GetMenteeNotes(ctx context.Context, userID, mentorshipID uuid.UUID) ([]domain.MenteeNote, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetMenteeNotes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+	)
+
+	mentorship, err := s.mentorAccess(ctx, mentorshipID, userID, domain.MentorViewedNotes, nil)
+	if err != nil {
+		return nil, err
+	}
+	contests, err := s.contestRepo.WithContext(ctx).FindByUserID(mentorship.MenteeID, domain.ContestFilter{})
+	if err != nil {
+		return nil, err
+	}
+	notes := []domain.MenteeNote{}
+	for _, c := range contests {
+		if c.Retro == "" {
+			continue
+		}
+		notes = append(notes, domain.MenteeNote{
+			ContestID: c.ID,
+			Status:    c.Status,
+			StartedAt: c.StartedAt,
+			Retro:     c.Retro,
+			UpdatedAt: c.RetroUpdatedAt,
+		})
+	}
+	return notes, nil
+}
+
+// GetAudit returns the latest audit trail entries of a mentorship; both sides can read it
+func (s *MentorshipService) GetAudit(ctx context.Context, userID, mentorshipID uuid.UUID, limit int) (*domain.MentorshipAudit, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetAudit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+	)
+
+	if _, err := s.party(ctx, mentorshipID, userID); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = 50
+	}
+	entries, err := s.mentorshipRepo.WithContext(ctx).FindAudit(mentorshipID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []domain.MentorshipAuditEntry{}
+	}
+	return &domain.MentorshipAudit{Entries: entries}, nil
+}
+
+// CreateAssignment assigns the mentee a contest with the given settings or a
+// problem set. A problem set is given to the mentee as a pending contest right
+// away.
+func (s *MentorshipService) CreateAssignment(ctx context.Context, userID, mentorshipID uuid.UUID, req *domain.CreateMentorAssignmentRequest) (*domain.MentorAssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.CreateAssignment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+		attribute.String("assignment.kind", string(req.Kind)),
+	)
+
+	mentorship, err := s.mentor(ctx, mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !req.DueAt.After(time.Now()) {
+		return nil, domain.NewValidationError("The due date must be in the future", nil)
+	}
+
+	assignment := &domain.MentorAssignment{
+		MentorshipID: mentorship.ID,
+		Kind:         req.Kind,
+		Title:        req.Title,
+		DueAt:        req.DueAt,
+	}
+	switch req.Kind {
+	case domain.AssignmentContest:
+		if err := validateAssignedContest(req.Contest); err != nil {
+			return nil, err
+		}
+		assignment.Contest = req.Contest
+	case domain.AssignmentProblemSet:
+		problemIDs, err := resolveProblemSet(ctx, s.problemService, req.Problems)
+		if err != nil {
+			return nil, err
+		}
+		assignment.ProblemIDs = problemIDs
+		assignment.DurationMinutes = req.DurationMinutes
+	}
+	if err := s.mentorshipRepo.WithContext(ctx).CreateAssignment(assignment); err != nil {
+		return nil, err
+	}
+	if err := s.auditAssignment(ctx, mentorship, userID, domain.MentorAssigned, assignment.ID); err != nil {
+		return nil, err
+	}
+	if assignment.Kind == domain.AssignmentProblemSet {
+		// A failure is logged, not returned: the mentee still gets the
+		// contest when they start the assignment
+		if _, err := s.assignContest(ctx, mentorship, assignment); err != nil {
+			logFor(ctx, s.logger).Error("Failed to push problem set",
+				zap.String("assignment_id", assignment.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	logFor(ctx, s.logger).Info("Mentor assignment created",
+		zap.String("mentorship_id", mentorship.ID.String()),
+		zap.String("assignment_id", assignment.ID.String()),
+		zap.String("kind", string(assignment.Kind)),
+	)
+
+	responses, err := s.assignmentResponses(ctx, []domain.MentorAssignment{*assignment})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// GetAssignments lists the mentorship's assignments with the mentee's
+// progress. Both sides can list them; the mentor's read is audited like any
+// other read of the mentee's data.
+func (s *MentorshipService) GetAssignments(ctx context.Context, userID, mentorshipID uuid.UUID) ([]domain.MentorAssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetAssignments")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+	)
+
+	mentorship, err := s.party(ctx, mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mentorship.MentorID == userID {
+		if _, err := s.mentorAccess(ctx, mentorshipID, userID, domain.MentorViewedAssignments, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	assignments, err := s.mentorshipRepo.WithContext(ctx).FindAssignments([]uuid.UUID{mentorship.ID})
+	if err != nil {
+		return nil, err
+	}
+	return s.assignmentResponses(ctx, assignments)
+}
+
+// DeleteAssignment deletes one of the mentor's assignments. A contest the
+// mentee started for it is kept; a pending one is deleted with it.
+func (s *MentorshipService) DeleteAssignment(ctx context.Context, userID, mentorshipID, assignmentID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.DeleteAssignment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+		attribute.String("assignment.id", assignmentID.String()),
+	)
+
+	mentorship, err := s.party(ctx, mentorshipID, userID)
+	if err != nil {
+		return err
+	}
+	if mentorship.MentorID != userID {
+		return domain.ErrForbidden
+	}
+	pending, err := s.mentorshipRepo.WithContext(ctx).FindPendingContestIDs(mentorship.ID, &assignmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.mentorshipRepo.WithContext(ctx).DeleteAssignment(mentorship.ID, assignmentID); err != nil {
+		return err
+	}
+	for _, id := range pending {
+		s.contestService.discardContest(ctx, id)
+	}
+	return s.auditAssignment(ctx, mentorship, userID, domain.MentorUnassigned, assignmentID)
+}
+
+// StartAssignment gives the mentee a contest with the assignment's settings,
+// or starts the pending contest of a problem set
+func (s *MentorshipService) StartAssignment(ctx context.Context, userID, mentorshipID, assignmentID uuid.UUID) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.StartAssignment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("mentorship.id", mentorshipID.String()),
+		attribute.String("assignment.id", assignmentID.String()),
+	)
+
+	mentorship, err := s.party(ctx, mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mentorship.MenteeID != userID {
+		return nil, domain.ErrForbidden
+	}
+	if mentorship.Status != domain.MentorshipActive {
+		return nil, domain.ErrMentorshipNotActive
+	}
+	assignment, err := s.mentorshipRepo.WithContext(ctx).FindAssignment(mentorship.ID, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.mentorshipRepo.WithContext(ctx).FindAssignmentProgress([]domain.MentorAssignment{*assignment})
+	if err != nil {
+		return nil, err
+	}
+	own := progress[assignment.ID]
+
+	if own.ContestID != nil {
+		if assignment.Kind != domain.AssignmentProblemSet || own.Status != domain.AssignmentNotStarted {
+			return nil, domain.ErrAssignmentStarted
+		}
+		return s.contestService.ActivateContest(ctx, userID, *own.ContestID)
+	}
+	if assignment.Kind == domain.AssignmentProblemSet {
+		// Pushing the problem set failed, or its contest was deleted
+		contest, err := s.assignContest(ctx, mentorship, assignment)
+		if err != nil {
+			return nil, err
+		}
+		return s.contestService.ActivateContest(ctx, userID, contest.ID)
+	}
+
+	settings := *assignment.Contest
+	contest, err := s.contestService.CreateContest(ctx, userID, &settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mentorshipRepo.WithContext(ctx).SetAssignmentContest(assignment.ID, contest.ID); err != nil {
+		// Rollback: a concurrent start won, or the contest could not be recorded
+		s.contestService.discardContest(ctx, contest.ID)
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Mentor assignment started",
+		zap.String("assignment_id", assignment.ID.String()),
+		zap.String("contest_id", contest.ID.String()),
+	)
+	return contest, nil
+}
+
+// GetPendingAssignments lists the user's unfinished assignments from every
+// mentor whose mentorship they accepted and that is still active
+func (s *MentorshipService) GetPendingAssignments(ctx context.Context, userID uuid.UUID) ([]domain.PendingAssignment, error) {
+	ctx, span := s.tracer.Start(ctx, "MentorshipService.GetPendingAssignments")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	mentorships, err := s.mentorshipRepo.WithContext(ctx).FindForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	mentors := make(map[uuid.UUID]string)
+	var mentorshipIDs []uuid.UUID
+	for _, m := range mentorships {
+		if m.MenteeID == userID && m.Status == domain.MentorshipActive {
+			mentors[m.ID] = m.MentorUsername
+			mentorshipIDs = append(mentorshipIDs, m.ID)
+		}
+	}
+	assignments, err := s.mentorshipRepo.WithContext(ctx).FindAssignments(mentorshipIDs)
+	if err != nil || len(assignments) == 0 {
+		return nil, err
+	}
+	progress, err := s.mentorshipRepo.WithContext(ctx).FindAssignmentProgress(assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []domain.PendingAssignment
+	now := time.Now()
+	for _, a := range assignments {
+		p := progress[a.ID]
+		if !p.IsPending() {
+			continue
+		}
+		dueAt := a.DueAt
+		pending = append(pending, domain.PendingAssignment{
+			Source:       domain.AssignmentFromMentor,
+			SourceID:     a.MentorshipID,
+			SourceName:   mentors[a.MentorshipID],
+			AssignmentID: a.ID,
+			Kind:         a.Kind,
+			Title:        a.Title,
+			DueAt:        &dueAt,
+			Status:       p.Status,
+			ContestID:    p.ContestID,
+			Solved:       p.Solved,
+			Total:        p.Total,
+			Overdue:      now.After(dueAt),
+		})
+	}
+	return pending, nil
+}
+
+// assignContest creates the mentee's pending contest of a problem set and
+// records it on the assignment, deleting it again when that fails
+func (s *MentorshipService) assignContest(ctx context.Context, mentorship *domain.Mentorship, assignment *domain.MentorAssignment) (*domain.Contest, error) {
+	problems, err := loadProblemSet(ctx, s.problemService, assignment.ProblemIDs)
+	if err != nil {
+		return nil, err
+	}
+	contest, err := s.contestService.CreateAssignedContest(ctx, mentorship.MenteeID, problems, assignment.DurationMinutes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mentorshipRepo.WithContext(ctx).SetAssignmentContest(assignment.ID, contest.ID); err != nil {
+		s.contestService.discardContest(ctx, contest.ID)
+		return nil, err
+	}
+	assignment.ContestID = &contest.ID
+	return contest, nil
+}
+
+// assignmentResponses adds the mentee's progress to assignments, with whether
+// each is overdue or was finished late
+func (s *MentorshipService) assignmentResponses(ctx context.Context, assignments []domain.MentorAssignment) ([]domain.MentorAssignmentResponse, error) {
+	responses := make([]domain.MentorAssignmentResponse, len(assignments))
+	if len(assignments) == 0 {
+		return responses, nil
+	}
+	progress, err := s.mentorshipRepo.WithContext(ctx).FindAssignmentProgress(assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i, a := range assignments {
+		p := progress[a.ID]
+		completed := p.Status == domain.AssignmentCompleted
+		responses[i] = domain.MentorAssignmentResponse{
+			MentorAssignment: a,
+			Status:           p.Status,
+			ContestID:        p.ContestID,
+			Solved:           p.Solved,
+			Total:            p.Total,
+			StartedAt:        p.StartedAt,
+			EndedAt:          p.EndedAt,
+			Overdue:          !completed && now.After(a.DueAt),
+			Late:             completed && p.EndedAt != nil && p.EndedAt.After(a.DueAt),
+		}
+	}
+	return responses, nil
+}
+
+// discardContests deletes the mentorship's assigned contests the mentee never
+// started. A failure is logged: the contests stay with the mentee.
+func (s *MentorshipService) discardContests(ctx context.Context, mentorshipID uuid.UUID) {
+	pending, err := s.mentorshipRepo.WithContext(ctx).FindPendingContestIDs(mentorshipID, nil)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to load pending assigned contests", zap.Error(err))
+		return
+	}
+	for _, id := range pending {
+		s.contestService.discardContest(ctx, id)
+	}
+}
+
+// party loads a mentorship the user is either side of; anyone else gets ErrMentorshipNotFound
+func (s *MentorshipService) party(ctx context.Context, mentorshipID, userID uuid.UUID) (*domain.Mentorship, error) {
+	mentorship, err := s.mentorshipRepo.WithContext(ctx).FindByID(mentorshipID)
+	if err != nil {
+		return nil, err
+	}
+	if mentorship.MentorID != userID && mentorship.MenteeID != userID {
+		return nil, domain.ErrMentorshipNotFound
+	}
+	return mentorship, nil
+}
+
+// mentor loads an active mentorship the user is the mentor of
+func (s *MentorshipService) mentor(ctx context.Context, mentorshipID, userID uuid.UUID) (*domain.Mentorship, error) {
+	mentorship, err := s.party(ctx, mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mentorship.MentorID != userID {
+		return nil, domain.ErrForbidden
+	}
+	if mentorship.Status != domain.MentorshipActive {
+		return nil, domain.ErrMentorshipNotActive
+	}
+	return mentorship, nil
+}
+
+// mentorAccess checks that the user is the mentor of an active mentorship and
+// records the read in its audit trail. The read is refused when it cannot be
+// recorded.
+func (s *MentorshipService) mentorAccess(ctx context.Context, mentorshipID, userID uuid.UUID, action domain.MentorshipAction, contestID *uuid.UUID) (*domain.Mentorship, error) {
+	mentorship, err := s.mentor(ctx, mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, mentorship, userID, action, contestID); err != nil {
+		return nil, err
+	}
+	return mentorship, nil
+}
+
+// audit appends an entry to the mentorship's audit trail
+func (s *MentorshipService) audit(ctx context.Context, mentorship *domain.Mentorship, actorID uuid.UUID, action domain.MentorshipAction, contestID *uuid.UUID) error {
+	return s.addAudit(ctx, &domain.MentorshipAuditEntry{
+		MentorshipID: mentorship.ID,
+		ActorID:      actorID,
+		Action:       action,
+		ContestID:    contestID,
+	})
+}
+
+// auditAssignment appends an assignment change to the mentorship's audit trail
+func (s *MentorshipService) auditAssignment(ctx context.Context, mentorship *domain.Mentorship, actorID uuid.UUID, action domain.MentorshipAction, assignmentID uuid.UUID) error {
+	return s.addAudit(ctx, &domain.MentorshipAuditEntry{
+		MentorshipID: mentorship.ID,
+		ActorID:      actorID,
+		Action:       action,
+		AssignmentID: &assignmentID,
+	})
+}
+
+// addAudit stores an audit trail entry, logging when it cannot
+func (s *MentorshipService) addAudit(ctx context.Context, entry *domain.MentorshipAuditEntry) error {
+	if err := s.mentorshipRepo.WithContext(ctx).AddAudit(entry); err != nil {
+		logFor(ctx, s.logger).Error("Failed to record mentorship audit entry",
+			zap.String("mentorship_id", entry.MentorshipID.String()),
+			zap.String("action", string(entry.Action)),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
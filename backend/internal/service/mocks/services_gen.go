@@ -0,0 +1,434 @@
+// Code generated by backend/cmd/mockgen from github.com/contest-maker-150/backend/internal/service. DO NOT EDIT.
+
+// Package mocks provides mocks of the service interfaces: set the Func field of
+// each method a test expects to be called.
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/service"
+)
+
+// ContestServicer mocks service.ContestServicer
+type ContestServicer struct {
+	CreateContestFunc         func(ctx context.Context, userID uuid.UUID, req *domain.CreateContestRequest) (*domain.Contest, error)
+	GetUserContestsFunc       func(ctx context.Context, userID uuid.UUID, filter domain.ContestFilter) ([]domain.Contest, error)
+	GetContestByIDFunc        func(ctx context.Context, contestID uuid.UUID) (*domain.Contest, error)
+	GetActiveContestFunc      func(ctx context.Context, userID uuid.UUID) (*domain.Contest, error)
+	SuggestTagsFunc           func(ctx context.Context, userID uuid.UUID, prefix string, limit int) ([]domain.TagCount, error)
+	GetAvailabilityFunc       func(ctx context.Context, userID uuid.UUID, query *domain.AvailabilityQuery) (*domain.ProblemAvailability, error)
+	GetTopicHistoryFunc       func(ctx context.Context, userID uuid.UUID) ([]domain.TopicContestStats, error)
+	SetContestTagsFunc        func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, tags []string) ([]string, error)
+	MarkProblemCompleteFunc   func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID, isCompleted bool, confidence *int) error
+	MarkWarmupCompleteFunc    func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, isCompleted bool) error
+	RecordAttemptFunc         func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID, outcome domain.AttemptOutcome) (*domain.AttemptHistory, error)
+	StartProblemFunc          func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID) (*domain.Contest, error)
+	StateComplexityFunc       func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID, req *domain.StateComplexityRequest) error
+	StartContestFunc          func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID) error
+	UpdateRetroFunc           func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, retro string) error
+	RateContestFunc           func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, rating int) error
+	CompleteContestFunc       func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID) error
+	AbandonContestFunc        func(ctx context.Context, userID uuid.UUID, contestID uuid.UUID) error
+	GetExperimentOutcomesFunc func(ctx context.Context) ([]domain.ExperimentOutcome, error)
+}
+
+var _ service.ContestServicer = (*ContestServicer)(nil)
+
+// CreateContest calls CreateContestFunc
+func (m *ContestServicer) CreateContest(ctx context.Context, userID uuid.UUID, req *domain.CreateContestRequest) (*domain.Contest, error) {
+	if m.CreateContestFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.CreateContest")
+	}
+	return m.CreateContestFunc(ctx, userID, req)
+}
+
+// GetUserContests calls GetUserContestsFunc
+func (m *ContestServicer) GetUserContests(ctx context.Context, userID uuid.UUID, filter domain.ContestFilter) ([]domain.Contest, error) {
+	if m.GetUserContestsFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.GetUserContests")
+	}
+	return m.GetUserContestsFunc(ctx, userID, filter)
+}
+
+// GetContestByID calls GetContestByIDFunc
+func (m *ContestServicer) GetContestByID(ctx context.Context, contestID uuid.UUID) (*domain.Contest, error) {
+	if m.GetContestByIDFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.GetContestByID")
+	}
+	return m.GetContestByIDFunc(ctx, contestID)
+}
+
+// GetActiveContest calls GetActiveContestFunc
+func (m *ContestServicer) GetActiveContest(ctx context.Context, userID uuid.UUID) (*domain.Contest, error) {
+	if m.GetActiveContestFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.GetActiveContest")
+	}
+	return m.GetActiveContestFunc(ctx, userID)
+}
+
+// SuggestTags calls SuggestTagsFunc
+func (m *ContestServicer) SuggestTags(ctx context.Context, userID uuid.UUID, prefix string, limit int) ([]domain.TagCount, error) {
+	if m.SuggestTagsFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.SuggestTags")
+	}
+	return m.SuggestTagsFunc(ctx, userID, prefix, limit)
+}
+
+// GetAvailability calls GetAvailabilityFunc
+func (m *ContestServicer) GetAvailability(ctx context.Context, userID uuid.UUID, query *domain.AvailabilityQuery) (*domain.ProblemAvailability, error) {
+	if m.GetAvailabilityFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.GetAvailability")
+	}
+	return m.GetAvailabilityFunc(ctx, userID, query)
+}
+
+// GetTopicHistory calls GetTopicHistoryFunc
+func (m *ContestServicer) GetTopicHistory(ctx context.Context, userID uuid.UUID) ([]domain.TopicContestStats, error) {
+	if m.GetTopicHistoryFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.GetTopicHistory")
+	}
+	return m.GetTopicHistoryFunc(ctx, userID)
+}
+
+// SetContestTags calls SetContestTagsFunc
+func (m *ContestServicer) SetContestTags(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, tags []string) ([]string, error) {
+	if m.SetContestTagsFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.SetContestTags")
+	}
+	return m.SetContestTagsFunc(ctx, userID, contestID, tags)
+}
+
+// MarkProblemComplete calls MarkProblemCompleteFunc
+func (m *ContestServicer) MarkProblemComplete(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID, isCompleted bool, confidence *int) error {
+	if m.MarkProblemCompleteFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.MarkProblemComplete")
+	}
+	return m.MarkProblemCompleteFunc(ctx, userID, contestID, problemID, isCompleted, confidence)
+}
+
+// MarkWarmupComplete calls MarkWarmupCompleteFunc
+func (m *ContestServicer) MarkWarmupComplete(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, isCompleted bool) error {
+	if m.MarkWarmupCompleteFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.MarkWarmupComplete")
+	}
+	return m.MarkWarmupCompleteFunc(ctx, userID, contestID, isCompleted)
+}
+
+// RecordAttempt calls RecordAttemptFunc
+func (m *ContestServicer) RecordAttempt(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID, outcome domain.AttemptOutcome) (*domain.AttemptHistory, error) {
+	if m.RecordAttemptFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.RecordAttempt")
+	}
+	return m.RecordAttemptFunc(ctx, userID, contestID, problemID, outcome)
+}
+
+// StartProblem calls StartProblemFunc
+func (m *ContestServicer) StartProblem(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID) (*domain.Contest, error) {
+	if m.StartProblemFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.StartProblem")
+	}
+	return m.StartProblemFunc(ctx, userID, contestID, problemID)
+}
+
+// StateComplexity calls StateComplexityFunc
+func (m *ContestServicer) StateComplexity(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, problemID uuid.UUID, req *domain.StateComplexityRequest) error {
+	if m.StateComplexityFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.StateComplexity")
+	}
+	return m.StateComplexityFunc(ctx, userID, contestID, problemID, req)
+}
+
+// StartContest calls StartContestFunc
+func (m *ContestServicer) StartContest(ctx context.Context, userID uuid.UUID, contestID uuid.UUID) error {
+	if m.StartContestFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.StartContest")
+	}
+	return m.StartContestFunc(ctx, userID, contestID)
+}
+
+// UpdateRetro calls UpdateRetroFunc
+func (m *ContestServicer) UpdateRetro(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, retro string) error {
+	if m.UpdateRetroFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.UpdateRetro")
+	}
+	return m.UpdateRetroFunc(ctx, userID, contestID, retro)
+}
+
+// RateContest calls RateContestFunc
+func (m *ContestServicer) RateContest(ctx context.Context, userID uuid.UUID, contestID uuid.UUID, rating int) error {
+	if m.RateContestFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.RateContest")
+	}
+	return m.RateContestFunc(ctx, userID, contestID, rating)
+}
+
+// CompleteContest calls CompleteContestFunc
+func (m *ContestServicer) CompleteContest(ctx context.Context, userID uuid.UUID, contestID uuid.UUID) error {
+	if m.CompleteContestFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.CompleteContest")
+	}
+	return m.CompleteContestFunc(ctx, userID, contestID)
+}
+
+// AbandonContest calls AbandonContestFunc
+func (m *ContestServicer) AbandonContest(ctx context.Context, userID uuid.UUID, contestID uuid.UUID) error {
+	if m.AbandonContestFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.AbandonContest")
+	}
+	return m.AbandonContestFunc(ctx, userID, contestID)
+}
+
+// GetExperimentOutcomes calls GetExperimentOutcomesFunc
+func (m *ContestServicer) GetExperimentOutcomes(ctx context.Context) ([]domain.ExperimentOutcome, error) {
+	if m.GetExperimentOutcomesFunc == nil {
+		panic("mocks: unexpected call to ContestServicer.GetExperimentOutcomes")
+	}
+	return m.GetExperimentOutcomesFunc(ctx)
+}
+
+// UserServicer mocks service.UserServicer
+type UserServicer struct {
+	RegisterFunc            func(ctx context.Context, req *domain.UserCreateRequest) (*domain.User, *service.TokenPair, error)
+	LoginFunc               func(ctx context.Context, email string, password string) (*domain.User, *service.TokenPair, error)
+	RefreshTokenFunc        func(ctx context.Context, refreshToken string) (*service.TokenPair, error)
+	LogoutFunc              func(ctx context.Context, claims *service.TokenClaims, refreshToken string) error
+	RevokeAllTokensFunc     func(ctx context.Context, userID uuid.UUID) error
+	ValidateAccessTokenFunc func(ctx context.Context, tokenString string) (*service.TokenClaims, error)
+	GetUserByIDFunc         func(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetUserProgressFunc     func(ctx context.Context, userID uuid.UUID) (*domain.UserProgress, error)
+	GetReviewQueueFunc      func(ctx context.Context, userID uuid.UUID, query domain.ReviewQueueQuery) (*domain.ReviewQueue, error)
+	GetAttemptHistoryFunc   func(ctx context.Context, userID uuid.UUID, problemID uuid.UUID) (*domain.AttemptHistory, error)
+	ChangePasswordFunc      func(ctx context.Context, userID uuid.UUID, req *domain.ChangePasswordRequest) error
+}
+
+var _ service.UserServicer = (*UserServicer)(nil)
+
+// Register calls RegisterFunc
+func (m *UserServicer) Register(ctx context.Context, req *domain.UserCreateRequest) (*domain.User, *service.TokenPair, error) {
+	if m.RegisterFunc == nil {
+		panic("mocks: unexpected call to UserServicer.Register")
+	}
+	return m.RegisterFunc(ctx, req)
+}
+
+// Login calls LoginFunc
+func (m *UserServicer) Login(ctx context.Context, email string, password string) (*domain.User, *service.TokenPair, error) {
+	if m.LoginFunc == nil {
+		panic("mocks: unexpected call to UserServicer.Login")
+	}
+	return m.LoginFunc(ctx, email, password)
+}
+
+// RefreshToken calls RefreshTokenFunc
+func (m *UserServicer) RefreshToken(ctx context.Context, refreshToken string) (*service.TokenPair, error) {
+	if m.RefreshTokenFunc == nil {
+		panic("mocks: unexpected call to UserServicer.RefreshToken")
+	}
+	return m.RefreshTokenFunc(ctx, refreshToken)
+}
+
+// Logout calls LogoutFunc
+func (m *UserServicer) Logout(ctx context.Context, claims *service.TokenClaims, refreshToken string) error {
+	if m.LogoutFunc == nil {
+		panic("mocks: unexpected call to UserServicer.Logout")
+	}
+	return m.LogoutFunc(ctx, claims, refreshToken)
+}
+
+// RevokeAllTokens calls RevokeAllTokensFunc
+func (m *UserServicer) RevokeAllTokens(ctx context.Context, userID uuid.UUID) error {
+	if m.RevokeAllTokensFunc == nil {
+		panic("mocks: unexpected call to UserServicer.RevokeAllTokens")
+	}
+	return m.RevokeAllTokensFunc(ctx, userID)
+}
+
+// ValidateAccessToken calls ValidateAccessTokenFunc
+func (m *UserServicer) ValidateAccessToken(ctx context.Context, tokenString string) (*service.TokenClaims, error) {
+	if m.ValidateAccessTokenFunc == nil {
+		panic("mocks: unexpected call to UserServicer.ValidateAccessToken")
+	}
+	return m.ValidateAccessTokenFunc(ctx, tokenString)
+}
+
+// GetUserByID calls GetUserByIDFunc
+func (m *UserServicer) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if m.GetUserByIDFunc == nil {
+		panic("mocks: unexpected call to UserServicer.GetUserByID")
+	}
+	return m.GetUserByIDFunc(ctx, id)
+}
+
+// GetUserProgress calls GetUserProgressFunc
+func (m *UserServicer) GetUserProgress(ctx context.Context, userID uuid.UUID) (*domain.UserProgress, error) {
+	if m.GetUserProgressFunc == nil {
+		panic("mocks: unexpected call to UserServicer.GetUserProgress")
+	}
+	return m.GetUserProgressFunc(ctx, userID)
+}
+
+// GetReviewQueue calls GetReviewQueueFunc
+func (m *UserServicer) GetReviewQueue(ctx context.Context, userID uuid.UUID, query domain.ReviewQueueQuery) (*domain.ReviewQueue, error) {
+	if m.GetReviewQueueFunc == nil {
+		panic("mocks: unexpected call to UserServicer.GetReviewQueue")
+	}
+	return m.GetReviewQueueFunc(ctx, userID, query)
+}
+
+// GetAttemptHistory calls GetAttemptHistoryFunc
+func (m *UserServicer) GetAttemptHistory(ctx context.Context, userID uuid.UUID, problemID uuid.UUID) (*domain.AttemptHistory, error) {
+	if m.GetAttemptHistoryFunc == nil {
+		panic("mocks: unexpected call to UserServicer.GetAttemptHistory")
+	}
+	return m.GetAttemptHistoryFunc(ctx, userID, problemID)
+}
+
+// ChangePassword calls ChangePasswordFunc
+func (m *UserServicer) ChangePassword(ctx context.Context, userID uuid.UUID, req *domain.ChangePasswordRequest) error {
+	if m.ChangePasswordFunc == nil {
+		panic("mocks: unexpected call to UserServicer.ChangePassword")
+	}
+	return m.ChangePasswordFunc(ctx, userID, req)
+}
+
+// ProblemServicer mocks service.ProblemServicer
+type ProblemServicer struct {
+	FindProblemsFunc         func(ctx context.Context, userID uuid.UUID, criteria domain.ProblemCriteria) ([]domain.Problem, error)
+	SearchProblemsFunc       func(ctx context.Context, query string, limit int) (*domain.ProblemSearchResponse, error)
+	GetProblemByIDFunc       func(ctx context.Context, id uuid.UUID, viewerID uuid.UUID) (*domain.Problem, error)
+	GetProblemsBatchFunc     func(ctx context.Context, keys []string, viewerID uuid.UUID) ([]domain.Problem, []string, error)
+	GetProblemPageFunc       func(ctx context.Context, slug string) (*domain.ProblemPage, error)
+	GetSitemapFunc           func(ctx context.Context) (*domain.Sitemap, error)
+	GetCompaniesFunc         func(ctx context.Context) ([]domain.CompanyCount, error)
+	GetPrerequisitesFunc     func(ctx context.Context, problemID uuid.UUID) ([]domain.Problem, error)
+	GetProblemStatsFunc      func(ctx context.Context) (*domain.ProblemStats, error)
+	GetCalibrationFunc       func(ctx context.Context) ([]domain.ProblemCalibration, error)
+	SetProblemCompaniesFunc  func(ctx context.Context, problemID uuid.UUID, companies []string) (*domain.Problem, error)
+	SetProblemImportanceFunc func(ctx context.Context, problemID uuid.UUID, importance int) (*domain.Problem, error)
+	SetProblemComplexityFunc func(ctx context.Context, problemID uuid.UUID, req *domain.SetProblemComplexityRequest) (*domain.ProblemComplexity, error)
+}
+
+var _ service.ProblemServicer = (*ProblemServicer)(nil)
+
+// FindProblems calls FindProblemsFunc
+func (m *ProblemServicer) FindProblems(ctx context.Context, userID uuid.UUID, criteria domain.ProblemCriteria) ([]domain.Problem, error) {
+	if m.FindProblemsFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.FindProblems")
+	}
+	return m.FindProblemsFunc(ctx, userID, criteria)
+}
+
+// SearchProblems calls SearchProblemsFunc
+func (m *ProblemServicer) SearchProblems(ctx context.Context, query string, limit int) (*domain.ProblemSearchResponse, error) {
+	if m.SearchProblemsFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.SearchProblems")
+	}
+	return m.SearchProblemsFunc(ctx, query, limit)
+}
+
+// GetProblemByID calls GetProblemByIDFunc
+func (m *ProblemServicer) GetProblemByID(ctx context.Context, id uuid.UUID, viewerID uuid.UUID) (*domain.Problem, error) {
+	if m.GetProblemByIDFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetProblemByID")
+	}
+	return m.GetProblemByIDFunc(ctx, id, viewerID)
+}
+
+// GetProblemsBatch calls GetProblemsBatchFunc
+func (m *ProblemServicer) GetProblemsBatch(ctx context.Context, keys []string, viewerID uuid.UUID) ([]domain.Problem, []string, error) {
+	if m.GetProblemsBatchFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetProblemsBatch")
+	}
+	return m.GetProblemsBatchFunc(ctx, keys, viewerID)
+}
+
+// GetProblemPage calls GetProblemPageFunc
+func (m *ProblemServicer) GetProblemPage(ctx context.Context, slug string) (*domain.ProblemPage, error) {
+	if m.GetProblemPageFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetProblemPage")
+	}
+	return m.GetProblemPageFunc(ctx, slug)
+}
+
+// GetSitemap calls GetSitemapFunc
+func (m *ProblemServicer) GetSitemap(ctx context.Context) (*domain.Sitemap, error) {
+	if m.GetSitemapFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetSitemap")
+	}
+	return m.GetSitemapFunc(ctx)
+}
+
+// GetCompanies calls GetCompaniesFunc
+func (m *ProblemServicer) GetCompanies(ctx context.Context) ([]domain.CompanyCount, error) {
+	if m.GetCompaniesFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetCompanies")
+	}
+	return m.GetCompaniesFunc(ctx)
+}
+
+// GetPrerequisites calls GetPrerequisitesFunc
+func (m *ProblemServicer) GetPrerequisites(ctx context.Context, problemID uuid.UUID) ([]domain.Problem, error) {
+	if m.GetPrerequisitesFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetPrerequisites")
+	}
+	return m.GetPrerequisitesFunc(ctx, problemID)
+}
+
+// GetProblemStats calls GetProblemStatsFunc
+func (m *ProblemServicer) GetProblemStats(ctx context.Context) (*domain.ProblemStats, error) {
+	if m.GetProblemStatsFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetProblemStats")
+	}
+	return m.GetProblemStatsFunc(ctx)
+}
+
+// GetCalibration calls GetCalibrationFunc
+func (m *ProblemServicer) GetCalibration(ctx context.Context) ([]domain.ProblemCalibration, error) {
+	if m.GetCalibrationFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.GetCalibration")
+	}
+	return m.GetCalibrationFunc(ctx)
+}
+
+// SetProblemCompanies calls SetProblemCompaniesFunc
+func (m *ProblemServicer) SetProblemCompanies(ctx context.Context, problemID uuid.UUID, companies []string) (*domain.Problem, error) {
+	if m.SetProblemCompaniesFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.SetProblemCompanies")
+	}
+	return m.SetProblemCompaniesFunc(ctx, problemID, companies)
+}
+
+// SetProblemImportance calls SetProblemImportanceFunc
+func (m *ProblemServicer) SetProblemImportance(ctx context.Context, problemID uuid.UUID, importance int) (*domain.Problem, error) {
+	if m.SetProblemImportanceFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.SetProblemImportance")
+	}
+	return m.SetProblemImportanceFunc(ctx, problemID, importance)
+}
+
+// SetProblemComplexity calls SetProblemComplexityFunc
+func (m *ProblemServicer) SetProblemComplexity(ctx context.Context, problemID uuid.UUID, req *domain.SetProblemComplexityRequest) (*domain.ProblemComplexity, error) {
+	if m.SetProblemComplexityFunc == nil {
+		panic("mocks: unexpected call to ProblemServicer.SetProblemComplexity")
+	}
+	return m.SetProblemComplexityFunc(ctx, problemID, req)
+}
+
+// SavedFilterServicer mocks service.SavedFilterServicer
+type SavedFilterServicer struct {
+	GetFilterFunc func(ctx context.Context, userID uuid.UUID, filterID uuid.UUID) (*domain.SavedFilter, error)
+}
+
+var _ service.SavedFilterServicer = (*SavedFilterServicer)(nil)
+
+// GetFilter calls GetFilterFunc
+func (m *SavedFilterServicer) GetFilter(ctx context.Context, userID uuid.UUID, filterID uuid.UUID) (*domain.SavedFilter, error) {
+	if m.GetFilterFunc == nil {
+		panic("mocks: unexpected call to SavedFilterServicer.GetFilter")
+	}
+	return m.GetFilterFunc(ctx, userID, filterID)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// Excerpts of matching notes show up to noteExcerptLength characters, starting
+// up to noteExcerptLead characters before the first match
+const (
+	noteExcerptLength = 160
+	noteExcerptLead   = 40
+)
+
+// NoteSearchService searches the text users wrote about their contests: retros
+// and solution snippets
+type NoteSearchService struct {
+	noteRepo domain.NoteSearchRepository
+	tracer   trace.Tracer
+	logger   *zap.Logger
+}
+
+// NewNoteSearchService creates a new note search service
+func NewNoteSearchService(noteRepo domain.NoteSearchRepository, tracer trace.Tracer, logger *zap.Logger) *NoteSearchService {
+	return &NoteSearchService{
+		noteRepo: noteRepo,
+		tracer:   tracer,
+		logger:   logger,
+	}
+}
+
+// Search returns the user's notes containing every word of the query, most
+// relevant first, each with an excerpt around its first match
+func (s *NoteSearchService) Search(ctx context.Context, userID uuid.UUID, query string, limit int) (*domain.NoteSearchResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "NoteSearchService.Search")
+	defer span.End()
+
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" {
+		return nil, domain.NewValidationError("Search query is empty", nil)
+	}
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	matches, err := s.noteRepo.WithContext(ctx).Search(userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := searchWords(query)
+	results := make([]domain.NoteSearchResult, len(matches))
+	for i, m := range matches {
+		excerpt, highlights := noteExcerpt(m.Text, terms)
+		results[i] = domain.NoteSearchResult{
+			Kind:         m.Kind,
+			ContestID:    m.ContestID,
+			ProblemID:    m.ProblemID,
+			ProblemTitle: m.ProblemTitle,
+			Excerpt:      excerpt,
+			Highlights:   highlights,
+			UpdatedAt:    m.UpdatedAt,
+		}
+	}
+
+	span.SetAttributes(attribute.Int("search.results", len(results)))
+	return &domain.NoteSearchResponse{
+		Query:   query,
+		Results: results,
+		Count:   len(results),
+	}, nil
+}
+
+// noteExcerpt cuts the part of text around its first matching word and
+// returns it with the words matching a term highlighted. Offsets are in
+// characters of the excerpt, including the leading "…" of an elided start.
+func noteExcerpt(text string, terms [][]rune) (string, []domain.NoteHighlight) {
+	runes := []rune(text)
+	var matches [][2]int
+	for _, w := range runeWords(runes) {
+		for _, term := range terms {
+			if stemMatches(runes[w[0]:w[1]], term) {
+				matches = append(matches, w)
+				break
+			}
+		}
+	}
+
+	start := 0
+	if len(matches) > 0 && matches[0][0] > noteExcerptLead {
+		start = matches[0][0] - noteExcerptLead
+		// Start at a word rather than inside one
+		for start < matches[0][0] && !unicode.IsSpace(runes[start-1]) {
+			start++
+		}
+	}
+	end := min(len(runes), start+noteExcerptLength)
+	if end < len(runes) {
+		for cut := end; cut > start; cut-- {
+			if unicode.IsSpace(runes[cut]) {
+				end = cut
+				break
+			}
+		}
+	}
+
+	elidedStart, elidedEnd := start > 0, end < len(runes)
+	for start < end && unicode.IsSpace(runes[start]) {
+		start++
+	}
+	for end > start && unicode.IsSpace(runes[end-1]) {
+		end--
+	}
+
+	var b strings.Builder
+	offset := -start
+	if elidedStart {
+		b.WriteString("…")
+		offset++
+	}
+	b.WriteString(string(runes[start:end]))
+	if elidedEnd {
+		b.WriteString("…")
+	}
+
+	highlights := []domain.NoteHighlight{}
+	for _, m := range matches {
+		if m[0] >= start && m[1] <= end {
+			highlights = append(highlights, domain.NoteHighlight{Start: m[0] + offset, Length: m[1] - m[0]})
+		}
+	}
+	return b.String(), highlights
+}
+
+// searchWords splits a query into the lowercased words the database matches
+func searchWords(query string) [][]rune {
+	runes := []rune(strings.ToLower(query))
+	var words [][]rune
+	for _, w := range runeWords(runes) {
+		words = append(words, runes[w[0]:w[1]])
+	}
+	return words
+}
+
+// runeWords returns the character ranges of the alphanumeric words in runes
+func runeWords(runes []rune) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range runes {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(runes)})
+	}
+	return spans
+}
+
+// stemMatches reports whether word is a form of the lowercase term: both share
+// the term's first characters, short of up to three suffix characters, so
+// "sorted" and "sorts" match "sorting" the way Postgres' stemming finds them
+func stemMatches(word, term []rune) bool {
+	stem := min(len(term), max(4, len(term)-3))
+	if len(word) < stem {
+		return false
+	}
+	for i := 0; i < stem; i++ {
+		if unicode.ToLower(word[i]) != term[i] {
+			return false
+		}
+	}
+	return true
+}
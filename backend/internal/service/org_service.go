@@ -0,0 +1,841 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// OrgService handles organizations (classrooms): instructors invite students
+// with join codes, assign contests, study plans or problem sets to the whole
+// class and follow each student's progress on a roster dashboard
+type OrgService struct {
+	orgRepo        domain.OrgRepository
+	progressRepo   domain.UserProgressRepository
+	eventRepo      domain.ContestEventRepository
+	contestService *ContestService
+	problemService *ProblemService
+	config         *infrastructure.OrgConfig
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewOrgService creates a new organization service
+func NewOrgService(
+	orgRepo domain.OrgRepository,
+	progressRepo domain.UserProgressRepository,
+	eventRepo domain.ContestEventRepository,
+	contestService *ContestService,
+	problemService *ProblemService,
+	config *infrastructure.OrgConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *OrgService {
+	return &OrgService{
+		orgRepo:        orgRepo,
+		progressRepo:   progressRepo,
+		eventRepo:      eventRepo,
+		contestService: contestService,
+		problemService: problemService,
+		config:         config,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// CreateOrg creates an organization with the user as its owner and first instructor
+func (s *OrgService) CreateOrg(ctx context.Context, userID uuid.UUID, req *domain.CreateOrgRequest) (*domain.OrgResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.CreateOrg")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	org := &domain.Organization{Name: req.Name, OwnerID: userID}
+	owner := &domain.OrgMember{UserID: userID, Role: domain.OrgRoleInstructor, JoinedAt: time.Now()}
+	if err := s.orgRepo.WithContext(ctx).Create(org, owner); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Organization created", zap.String("org_id", org.ID.String()))
+	return s.toResponse(ctx, org, owner)
+}
+
+// GetOrgs lists the organizations the user belongs to
+func (s *OrgService) GetOrgs(ctx context.Context, userID uuid.UUID) ([]domain.OrgSummary, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.GetOrgs")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	orgs, err := s.orgRepo.WithContext(ctx).FindForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if orgs == nil {
+		orgs = []domain.OrgSummary{}
+	}
+	return orgs, nil
+}
+
+// GetOrg returns an organization with its members; instructors also see the open invites
+func (s *OrgService) GetOrg(ctx context.Context, userID, orgID uuid.UUID) (*domain.OrgResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.GetOrg")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+
+	member, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.WithContext(ctx).FindByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(ctx, org, member)
+}
+
+// CreateInvite creates a join code for the organization
+func (s *OrgService) CreateInvite(ctx context.Context, userID, orgID uuid.UUID, role domain.OrgRole) (*domain.OrgInvite, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.CreateInvite")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+
+	if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	if role == "" {
+		role = domain.OrgRoleStudent
+	}
+
+	code, err := newChallengeCode()
+	if err != nil {
+		return nil, err
+	}
+	invite := &domain.OrgInvite{
+		OrgID:     orgID,
+		Code:      code,
+		Role:      role,
+		CreatedBy: userID,
+		ExpiresAt: time.Now().Add(s.config.InviteTTL),
+	}
+	if err := s.orgRepo.WithContext(ctx).CreateInvite(invite); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Organization invite created",
+		zap.String("org_id", orgID.String()),
+		zap.String("role", string(role)),
+	)
+	return invite, nil
+}
+
+// RevokeInvite deletes a join code so it can no longer be used
+func (s *OrgService) RevokeInvite(ctx context.Context, userID, orgID uuid.UUID, code string) error {
+	ctx, span := s.tracer.Start(ctx, "OrgService.RevokeInvite")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+
+	if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return err
+	}
+	return s.orgRepo.WithContext(ctx).DeleteInvite(orgID, normalizeChallengeCode(code))
+}
+
+// JoinOrg adds the user to the organization of an invite, with the invite's role
+func (s *OrgService) JoinOrg(ctx context.Context, userID uuid.UUID, code string) (*domain.OrgResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.JoinOrg")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	invite, err := s.orgRepo.WithContext(ctx).FindInviteByCode(normalizeChallengeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if invite.IsExpired() {
+		return nil, domain.ErrOrgInviteExpired
+	}
+	if _, err := s.orgRepo.WithContext(ctx).FindMember(invite.OrgID, userID); err == nil {
+		return nil, domain.ErrAlreadyOrgMember
+	}
+
+	count, err := s.orgRepo.WithContext(ctx).CountMembers(invite.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.config.MaxMembers) {
+		return nil, domain.ErrOrgFull
+	}
+
+	member := &domain.OrgMember{OrgID: invite.OrgID, UserID: userID, Role: invite.Role, JoinedAt: time.Now()}
+	if err := s.orgRepo.WithContext(ctx).AddMember(member); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.WithContext(ctx).FindByID(invite.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Joined organization",
+		zap.String("org_id", org.ID.String()),
+		zap.String("role", string(member.Role)),
+	)
+	if member.Role == domain.OrgRoleStudent {
+		s.pushOpenProblemSets(ctx, org.ID, userID)
+	}
+	return s.toResponse(ctx, org, member)
+}
+
+// RemoveMember removes a member from the organization. Members can remove
+// themselves; removing anyone else takes an instructor. The owner stays.
+func (s *OrgService) RemoveMember(ctx context.Context, userID, orgID, memberID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "OrgService.RemoveMember")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("member.id", memberID.String()),
+	)
+
+	if memberID == userID {
+		if _, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID); err != nil {
+			return err
+		}
+	} else if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	org, err := s.orgRepo.WithContext(ctx).FindByID(orgID)
+	if err != nil {
+		return err
+	}
+	if org.OwnerID == memberID {
+		return domain.ErrOrgOwner
+	}
+	pending, err := s.orgRepo.WithContext(ctx).FindPendingContestIDs(orgID, nil, &memberID)
+	if err != nil {
+		return err
+	}
+	if err := s.orgRepo.WithContext(ctx).RemoveMember(orgID, memberID); err != nil {
+		return err
+	}
+	s.discardContests(ctx, pending)
+
+	logFor(ctx, s.logger).Info("Organization member removed",
+		zap.String("org_id", orgID.String()),
+		zap.String("member_id", memberID.String()),
+	)
+	return nil
+}
+
+// CreateAssignment assigns a contest, a study plan or a problem set to every
+// student of the organization. A problem set is pushed to each student as a
+// pending contest right away.
+func (s *OrgService) CreateAssignment(ctx context.Context, userID, orgID uuid.UUID, req *domain.CreateAssignmentRequest) (*domain.OrgAssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.CreateAssignment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("assignment.kind", string(req.Kind)),
+	)
+
+	if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	assignment := &domain.OrgAssignment{
+		OrgID:     orgID,
+		Kind:      req.Kind,
+		Title:     req.Title,
+		DueAt:     req.DueAt,
+		CreatedBy: userID,
+	}
+	switch req.Kind {
+	case domain.AssignmentContest:
+		if err := validateAssignedContest(req.Contest); err != nil {
+			return nil, err
+		}
+		assignment.Contest = req.Contest
+	case domain.AssignmentStudyPlan:
+		exists, err := s.orgRepo.WithContext(ctx).CategoryExists(*req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.NewValidationError("Roadmap category not found", nil)
+		}
+		assignment.CategoryID = req.CategoryID
+	case domain.AssignmentProblemSet:
+		if !req.DueAt.After(time.Now()) {
+			return nil, domain.NewValidationError("The due date of a problem set must be in the future", nil)
+		}
+		problemIDs, err := resolveProblemSet(ctx, s.problemService, req.Problems)
+		if err != nil {
+			return nil, err
+		}
+		assignment.ProblemIDs = problemIDs
+		assignment.DurationMinutes = req.DurationMinutes
+	}
+	if err := s.orgRepo.WithContext(ctx).CreateAssignment(assignment); err != nil {
+		return nil, err
+	}
+	if assignment.Kind == domain.AssignmentProblemSet {
+		if err := s.pushProblemSet(ctx, assignment); err != nil {
+			return nil, err
+		}
+	}
+
+	logFor(ctx, s.logger).Info("Assignment created",
+		zap.String("org_id", orgID.String()),
+		zap.String("assignment_id", assignment.ID.String()),
+		zap.String("kind", string(assignment.Kind)),
+	)
+
+	assignments, err := s.assignmentResponses(ctx, userID, orgID, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range assignments {
+		if assignments[i].ID == assignment.ID {
+			return &assignments[i], nil
+		}
+	}
+	return nil, domain.ErrAssignmentNotFound
+}
+
+// GetAssignments lists the organization's assignments with the caller's own
+// progress, and for instructors how many students completed each
+func (s *OrgService) GetAssignments(ctx context.Context, userID, orgID uuid.UUID) ([]domain.OrgAssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.GetAssignments")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+
+	member, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.assignmentResponses(ctx, userID, orgID, member.IsInstructor())
+}
+
+// DeleteAssignment deletes an assignment. Contests students started for it are
+// kept; pending ones they never started are deleted with it.
+func (s *OrgService) DeleteAssignment(ctx context.Context, userID, orgID, assignmentID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "OrgService.DeleteAssignment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("assignment.id", assignmentID.String()),
+	)
+
+	if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return err
+	}
+	pending, err := s.orgRepo.WithContext(ctx).FindPendingContestIDs(orgID, &assignmentID, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.orgRepo.WithContext(ctx).DeleteAssignment(orgID, assignmentID); err != nil {
+		return err
+	}
+	s.discardContests(ctx, pending)
+	return nil
+}
+
+// StartAssignment gives the member a contest with the assignment's settings,
+// or starts the pending contest of a problem set
+func (s *OrgService) StartAssignment(ctx context.Context, userID, orgID, assignmentID uuid.UUID) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.StartAssignment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("assignment.id", assignmentID.String()),
+	)
+
+	if _, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID); err != nil {
+		return nil, err
+	}
+	assignment, err := s.orgRepo.WithContext(ctx).FindAssignment(orgID, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.Kind == domain.AssignmentStudyPlan {
+		return nil, domain.ErrNotContestAssignment
+	}
+
+	progress, err := s.orgRepo.WithContext(ctx).FindProgress(orgID, []uuid.UUID{userID})
+	if err != nil {
+		return nil, err
+	}
+	var own domain.AssignmentProgress
+	for _, p := range progress {
+		if p.AssignmentID == assignmentID {
+			own = p
+		}
+	}
+	if assignment.Kind == domain.AssignmentProblemSet {
+		return s.startProblemSet(ctx, assignment, userID, &own)
+	}
+	if own.ContestID != nil {
+		return nil, domain.ErrAssignmentStarted
+	}
+
+	settings := *assignment.Contest
+	contest, err := s.contestService.CreateContest(ctx, userID, &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &domain.OrgAssignmentContest{AssignmentID: assignmentID, UserID: userID, ContestID: contest.ID}
+	if err := s.orgRepo.WithContext(ctx).LinkContest(link); err != nil {
+		// Rollback: a concurrent start won, or the link could not be stored
+		s.contestService.discardContest(ctx, contest.ID)
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Assignment started",
+		zap.String("assignment_id", assignmentID.String()),
+		zap.String("contest_id", contest.ID.String()),
+	)
+	return contest, nil
+}
+
+// GetPendingAssignments lists the user's unfinished assignments across every
+// organization they are a student of
+func (s *OrgService) GetPendingAssignments(ctx context.Context, userID uuid.UUID) ([]domain.PendingAssignment, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.GetPendingAssignments")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	orgs, err := s.orgRepo.WithContext(ctx).FindForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	var pending []domain.PendingAssignment
+	now := time.Now()
+	for _, org := range orgs {
+		if org.Role != domain.OrgRoleStudent {
+			continue
+		}
+		assignments, err := s.orgRepo.WithContext(ctx).FindAssignments(org.ID)
+		if err != nil {
+			return nil, err
+		}
+		progress, err := s.orgRepo.WithContext(ctx).FindProgress(org.ID, []uuid.UUID{userID})
+		if err != nil {
+			return nil, err
+		}
+		own := make(map[uuid.UUID]domain.AssignmentProgress, len(progress))
+		for _, p := range progress {
+			own[p.AssignmentID] = p
+		}
+		for _, a := range assignments {
+			p := own[a.ID]
+			if !p.IsPending() {
+				continue
+			}
+			pending = append(pending, domain.PendingAssignment{
+				Source:       domain.AssignmentFromOrg,
+				SourceID:     org.ID,
+				SourceName:   org.Name,
+				AssignmentID: a.ID,
+				Kind:         a.Kind,
+				Title:        a.Title,
+				DueAt:        a.DueAt,
+				Status:       p.Status,
+				ContestID:    p.ContestID,
+				Solved:       p.Solved,
+				Total:        p.Total,
+				Overdue:      isOverdue(a.DueAt, now),
+			})
+		}
+	}
+	return pending, nil
+}
+
+// GetRoster returns a page of the organization's students with their overall
+// progress and how many assignments each completed
+func (s *OrgService) GetRoster(ctx context.Context, userID, orgID uuid.UUID, limit, offset int) (*domain.OrgRoster, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.GetRoster")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+
+	if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = 50
+	}
+
+	studentIDs, err := s.orgRepo.WithContext(ctx).FindMemberIDs(orgID, domain.OrgRoleStudent)
+	if err != nil {
+		return nil, err
+	}
+	roster := &domain.OrgRoster{Members: []domain.OrgRosterMember{}, Limit: limit, Offset: offset}
+	if len(studentIDs) == 0 {
+		return roster, nil
+	}
+
+	members, total, err := s.progressRepo.WithContext(ctx).FindMembers(studentIDs, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	roster.Total = total
+	if len(members) == 0 {
+		return roster, nil
+	}
+
+	pageIDs := make([]uuid.UUID, len(members))
+	for i, m := range members {
+		pageIDs[i] = m.UserID
+	}
+	assignments, err := s.orgRepo.WithContext(ctx).FindAssignments(orgID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.orgRepo.WithContext(ctx).FindProgress(orgID, pageIDs)
+	if err != nil {
+		return nil, err
+	}
+	joined, err := s.orgRepo.WithContext(ctx).FindMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	roster.Assignments = len(assignments)
+	dueAt := make(map[uuid.UUID]*time.Time, len(assignments))
+	for _, a := range assignments {
+		dueAt[a.ID] = a.DueAt
+	}
+	joinedAt := make(map[uuid.UUID]time.Time, len(joined))
+	for _, m := range joined {
+		joinedAt[m.UserID] = m.JoinedAt
+	}
+	completed := make(map[uuid.UUID]int)
+	overdue := make(map[uuid.UUID]int)
+	now := time.Now()
+	for _, p := range progress {
+		switch {
+		case p.Status == domain.AssignmentCompleted:
+			completed[p.UserID]++
+		case isOverdue(dueAt[p.AssignmentID], now):
+			overdue[p.UserID]++
+		}
+	}
+
+	for _, m := range members {
+		roster.Members = append(roster.Members, domain.OrgRosterMember{
+			MemberProgress:       m,
+			JoinedAt:             joinedAt[m.UserID],
+			AssignmentsCompleted: completed[m.UserID],
+			AssignmentsOverdue:   overdue[m.UserID],
+		})
+	}
+	return roster, nil
+}
+
+// GetAssignmentReport returns every student's progress on one assignment, with
+// whether they are overdue or finished late and how often their contest lost
+// focus or switched tabs
+func (s *OrgService) GetAssignmentReport(ctx context.Context, userID, orgID, assignmentID uuid.UUID) (*domain.AssignmentReport, error) {
+	ctx, span := s.tracer.Start(ctx, "OrgService.GetAssignmentReport")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("assignment.id", assignmentID.String()),
+	)
+
+	if _, err := s.instructor(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	assignment, err := s.orgRepo.WithContext(ctx).FindAssignment(orgID, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgRepo.WithContext(ctx).FindMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+	usernames := make(map[uuid.UUID]string, len(members))
+	var studentIDs []uuid.UUID
+	for _, m := range members {
+		if m.Role == domain.OrgRoleStudent {
+			usernames[m.UserID] = m.Username
+			studentIDs = append(studentIDs, m.UserID)
+		}
+	}
+	progress, err := s.orgRepo.WithContext(ctx).FindProgress(orgID, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var contestIDs []uuid.UUID
+	for _, p := range progress {
+		if p.AssignmentID == assignmentID && p.ContestID != nil {
+			contestIDs = append(contestIDs, *p.ContestID)
+		}
+	}
+	counts, err := s.eventRepo.WithContext(ctx).FindCounts(contestIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.AssignmentReport{Assignment: *assignment, Members: []domain.AssignmentReportRow{}}
+	now := time.Now()
+	for _, p := range progress {
+		if p.AssignmentID != assignmentID {
+			continue
+		}
+		row := domain.AssignmentReportRow{AssignmentProgress: p, Username: usernames[p.UserID]}
+		if p.ContestID != nil {
+			row.ProctorCounts = counts[*p.ContestID]
+		}
+		switch p.Status {
+		case domain.AssignmentNotStarted:
+			report.NotStarted++
+		case domain.AssignmentInProgress:
+			report.InProgress++
+		case domain.AssignmentCompleted:
+			report.Completed++
+			row.Late = assignment.DueAt != nil && p.EndedAt != nil && p.EndedAt.After(*assignment.DueAt)
+		case domain.AssignmentAbandoned:
+			report.Abandoned++
+		}
+		if p.Status != domain.AssignmentCompleted && isOverdue(assignment.DueAt, now) {
+			row.Overdue = true
+			report.Overdue++
+		}
+		report.Members = append(report.Members, row)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		return report.Members[i].Username < report.Members[j].Username
+	})
+	return report, nil
+}
+
+// startProblemSet starts the member's pending contest of a problem set. One is
+// created first when the member has none, such as when pushing it failed.
+func (s *OrgService) startProblemSet(ctx context.Context, assignment *domain.OrgAssignment, userID uuid.UUID, progress *domain.AssignmentProgress) (*domain.Contest, error) {
+	if progress.ContestID != nil {
+		if progress.Status != domain.AssignmentNotStarted {
+			return nil, domain.ErrAssignmentStarted
+		}
+		return s.contestService.ActivateContest(ctx, userID, *progress.ContestID)
+	}
+
+	problems, err := loadProblemSet(ctx, s.problemService, assignment.ProblemIDs)
+	if err != nil {
+		return nil, err
+	}
+	contest, err := s.assignContest(ctx, assignment, problems, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.contestService.ActivateContest(ctx, userID, contest.ID)
+}
+
+// pushProblemSet gives every student of the organization a pending contest of
+// the problem set
+func (s *OrgService) pushProblemSet(ctx context.Context, assignment *domain.OrgAssignment) error {
+	studentIDs, err := s.orgRepo.WithContext(ctx).FindMemberIDs(assignment.OrgID, domain.OrgRoleStudent)
+	if err != nil || len(studentIDs) == 0 {
+		return err
+	}
+	problems, err := loadProblemSet(ctx, s.problemService, assignment.ProblemIDs)
+	if err != nil {
+		return err
+	}
+	for _, studentID := range studentIDs {
+		if _, err := s.assignContest(ctx, assignment, problems, studentID); err != nil {
+			return err
+		}
+	}
+
+	logFor(ctx, s.logger).Info("Problem set pushed",
+		zap.String("assignment_id", assignment.ID.String()),
+		zap.Int("students", len(studentIDs)),
+	)
+	return nil
+}
+
+// pushOpenProblemSets gives a student who just joined a pending contest of each
+// problem set that is not due yet. A failure is logged, not returned: the
+// student still gets the contest when they start the assignment.
+func (s *OrgService) pushOpenProblemSets(ctx context.Context, orgID, userID uuid.UUID) {
+	assignments, err := s.orgRepo.WithContext(ctx).FindAssignments(orgID)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to load assignments to push", zap.Error(err))
+		return
+	}
+	now := time.Now()
+	for i := range assignments {
+		a := &assignments[i]
+		if a.Kind != domain.AssignmentProblemSet || isOverdue(a.DueAt, now) {
+			continue
+		}
+		problems, err := loadProblemSet(ctx, s.problemService, a.ProblemIDs)
+		if err == nil {
+			_, err = s.assignContest(ctx, a, problems, userID)
+		}
+		if err != nil {
+			logFor(ctx, s.logger).Error("Failed to push problem set",
+				zap.String("assignment_id", a.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// assignContest creates the user's pending contest of a problem set and links
+// it to the assignment, deleting it again when the link fails
+func (s *OrgService) assignContest(ctx context.Context, assignment *domain.OrgAssignment, problems []domain.Problem, userID uuid.UUID) (*domain.Contest, error) {
+	contest, err := s.contestService.CreateAssignedContest(ctx, userID, problems, assignment.DurationMinutes)
+	if err != nil {
+		return nil, err
+	}
+	link := &domain.OrgAssignmentContest{AssignmentID: assignment.ID, UserID: userID, ContestID: contest.ID}
+	if err := s.orgRepo.WithContext(ctx).LinkContest(link); err != nil {
+		s.contestService.discardContest(ctx, contest.ID)
+		return nil, err
+	}
+	return contest, nil
+}
+
+// discardContests deletes the pending contests of a removed member or assignment
+func (s *OrgService) discardContests(ctx context.Context, contestIDs []uuid.UUID) {
+	for _, id := range contestIDs {
+		s.contestService.discardContest(ctx, id)
+	}
+}
+
+// instructor returns the user's membership, failing unless they are an instructor
+func (s *OrgService) instructor(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	member, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsInstructor() {
+		return nil, domain.ErrForbidden
+	}
+	return member, nil
+}
+
+// assignmentResponses lists the organization's assignments with the user's
+// progress, adding class completion counts for instructors
+func (s *OrgService) assignmentResponses(ctx context.Context, userID, orgID uuid.UUID, instructor bool) ([]domain.OrgAssignmentResponse, error) {
+	assignments, err := s.orgRepo.WithContext(ctx).FindAssignments(orgID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]domain.OrgAssignmentResponse, len(assignments))
+	if len(assignments) == 0 {
+		return responses, nil
+	}
+
+	userIDs := []uuid.UUID{userID}
+	if instructor {
+		studentIDs, err := s.orgRepo.WithContext(ctx).FindMemberIDs(orgID, domain.OrgRoleStudent)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, studentIDs...)
+	}
+	progress, err := s.orgRepo.WithContext(ctx).FindProgress(orgID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make(map[uuid.UUID]domain.AssignmentProgress)
+	completed := make(map[uuid.UUID]int)
+	for _, p := range progress {
+		if p.UserID == userID {
+			own[p.AssignmentID] = p
+		} else if p.Status == domain.AssignmentCompleted {
+			completed[p.AssignmentID]++
+		}
+	}
+
+	now := time.Now()
+	for i, a := range assignments {
+		p := own[a.ID]
+		responses[i] = domain.OrgAssignmentResponse{
+			OrgAssignment: a,
+			Status:        p.Status,
+			ContestID:     p.ContestID,
+			Solved:        p.Solved,
+			Total:         p.Total,
+			Overdue:       p.Status != domain.AssignmentCompleted && isOverdue(a.DueAt, now),
+		}
+		if instructor {
+			count := completed[a.ID]
+			responses[i].CompletedCount = &count
+		}
+	}
+	return responses, nil
+}
+
+// toResponse builds an organization's details as seen by the member
+func (s *OrgService) toResponse(ctx context.Context, org *domain.Organization, member *domain.OrgMember) (*domain.OrgResponse, error) {
+	members, err := s.orgRepo.WithContext(ctx).FindMembers(org.ID)
+	if err != nil {
+		return nil, err
+	}
+	response := &domain.OrgResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		OwnerID:   org.OwnerID,
+		Role:      member.Role,
+		Members:   members,
+		CreatedAt: org.CreatedAt,
+	}
+	if member.IsInstructor() {
+		response.Invites, err = s.orgRepo.WithContext(ctx).FindOpenInvites(org.ID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// isOverdue reports whether a due date has passed
+func isOverdue(dueAt *time.Time, now time.Time) bool {
+	return dueAt != nil && now.After(*dueAt)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+const (
+	// HashAlgorithmBcrypt selects bcrypt password hashing
+	HashAlgorithmBcrypt = "bcrypt"
+	// HashAlgorithmArgon2id selects Argon2id password hashing
+	HashAlgorithmArgon2id = "argon2id"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+var errMalformedHash = errors.New("malformed password hash")
+
+// PasswordHasher hashes and verifies passwords with the configured algorithm.
+// Hashes produced under older parameters (or another algorithm) still verify,
+// and are reported as needing a rehash so they can be upgraded on login.
+type PasswordHasher struct {
+	config *infrastructure.PasswordConfig
+}
+
+// NewPasswordHasher creates a new password hasher
+func NewPasswordHasher(config *infrastructure.PasswordConfig) (*PasswordHasher, error) {
+	switch config.HashAlgorithm {
+	case HashAlgorithmBcrypt:
+		if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case HashAlgorithmArgon2id:
+		if config.Argon2Memory == 0 || config.Argon2Iterations == 0 || config.Argon2Parallelism == 0 {
+			return nil, errors.New("argon2id memory, iterations and parallelism must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", config.HashAlgorithm)
+	}
+	return &PasswordHasher{config: config}, nil
+}
+
+// Hash hashes a password using the configured algorithm and parameters
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.config.HashAlgorithm == HashAlgorithmArgon2id {
+		return h.hashArgon2id(password)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify checks a password against a stored hash. needsRehash is true when the
+// password matched but the hash was produced with outdated settings.
+func (h *PasswordHasher) Verify(hash, password string) (ok bool, needsRehash bool, err error) {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		return h.verifyArgon2id(hash, password)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+
+	if h.config.HashAlgorithm != HashAlgorithmBcrypt {
+		return true, true, nil
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true, false, err
+	}
+	return true, cost != h.config.BcryptCost, nil
+}
+
+// hashArgon2id produces a PHC-formatted Argon2id hash
+func (h *PasswordHasher) hashArgon2id(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt,
+		h.config.Argon2Iterations, h.config.Argon2Memory, h.config.Argon2Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.config.Argon2Memory, h.config.Argon2Iterations, h.config.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyArgon2id verifies a PHC-formatted Argon2id hash using the parameters encoded in it
+func (h *PasswordHasher) verifyArgon2id(hash, password string) (bool, bool, error) {
+	// Format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false, false, errMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, errMalformedHash
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, false, errMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, errMalformedHash
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, false, errMalformedHash
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(expected)))
+	if subtle.ConstantTimeCompare(actual, expected) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash := h.config.HashAlgorithm != HashAlgorithmArgon2id ||
+		memory != h.config.Argon2Memory ||
+		iterations != h.config.Argon2Iterations ||
+		parallelism != h.config.Argon2Parallelism
+	return true, needsRehash, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// commonPasswords is a small built-in list of passwords that are always rejected
+var commonPasswords = []string{
+	"password", "password1", "password123", "passw0rd", "p@ssw0rd",
+	"12345678", "123456789", "1234567890", "qwerty123", "qwertyuiop",
+	"iloveyou", "letmein1", "welcome1", "welcome123", "admin123",
+	"abc12345", "football", "baseball", "sunshine", "princess",
+	"monkey123", "dragon123", "trustno1", "changeme", "leetcode",
+}
+
+// BreachChecker reports whether a password is known to be compromised
+type BreachChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// PasswordPolicy validates candidate passwords against configurable rules
+type PasswordPolicy struct {
+	config *infrastructure.PasswordConfig
+	banned map[string]struct{}
+	breach BreachChecker
+	logger *zap.Logger
+}
+
+// NewPasswordPolicy creates a new password policy; breach may be nil to skip the breach check
+func NewPasswordPolicy(config *infrastructure.PasswordConfig, breach BreachChecker, logger *zap.Logger) *PasswordPolicy {
+	banned := make(map[string]struct{}, len(commonPasswords)+len(config.BannedPasswords))
+	for _, p := range commonPasswords {
+		banned[p] = struct{}{}
+	}
+	for _, p := range config.BannedPasswords {
+		banned[strings.ToLower(p)] = struct{}{}
+	}
+
+	return &PasswordPolicy{
+		config: config,
+		banned: banned,
+		breach: breach,
+		logger: logger,
+	}
+}
+
+// Validate checks a password against every rule and returns a
+// *domain.PasswordPolicyError listing all violations, or nil if it passes
+func (p *PasswordPolicy) Validate(ctx context.Context, password string) error {
+	var violations []domain.PasswordViolation
+
+	if len([]rune(password)) < p.config.MinLength {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    "min_length",
+			Message: fmt.Sprintf("Password must be at least %d characters long", p.config.MinLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.config.RequireUpper && !hasUpper {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    "uppercase",
+			Message: "Password must contain an uppercase letter",
+		})
+	}
+	if p.config.RequireLower && !hasLower {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    "lowercase",
+			Message: "Password must contain a lowercase letter",
+		})
+	}
+	if p.config.RequireDigit && !hasDigit {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    "digit",
+			Message: "Password must contain a digit",
+		})
+	}
+	if p.config.RequireSymbol && !hasSymbol {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    "symbol",
+			Message: "Password must contain a symbol",
+		})
+	}
+
+	if _, ok := p.banned[strings.ToLower(password)]; ok {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    "common",
+			Message: "Password is too common",
+		})
+	}
+
+	// Only hit the network when the password is otherwise acceptable
+	if len(violations) == 0 && p.config.BreachCheckEnabled && p.breach != nil {
+		breached, err := p.breach.IsBreached(ctx, password)
+		if err != nil {
+			// Fail open: an unavailable breach API should not block signups
+			logFor(ctx, p.logger).Warn("Password breach check failed", zap.Error(err))
+		} else if breached {
+			violations = append(violations, domain.PasswordViolation{
+				Rule:    "breached",
+				Message: "Password has appeared in a known data breach",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &domain.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
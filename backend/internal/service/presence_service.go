@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// PresenceService records heartbeats and reports who is online or competing
+type PresenceService struct {
+	presenceRepo domain.PresenceRepository
+	contestRepo  domain.ContestRepository
+	config       *infrastructure.PresenceConfig
+	tracer       trace.Tracer
+	logger       *zap.Logger
+}
+
+// NewPresenceService creates a new presence service
+func NewPresenceService(
+	presenceRepo domain.PresenceRepository,
+	contestRepo domain.ContestRepository,
+	config *infrastructure.PresenceConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *PresenceService {
+	return &PresenceService{
+		presenceRepo: presenceRepo,
+		contestRepo:  contestRepo,
+		config:       config,
+		tracer:       tracer,
+		logger:       logger,
+	}
+}
+
+// Heartbeat marks the user as online. With a contest ID the user counts as in
+// the contest while it is active; a finished contest is ignored.
+func (s *PresenceService) Heartbeat(ctx context.Context, userID uuid.UUID, contestID *uuid.UUID) (*domain.Presence, error) {
+	ctx, span := s.tracer.Start(ctx, "PresenceService.Heartbeat")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if contestID != nil {
+		span.SetAttributes(attribute.String("contest.id", contestID.String()))
+
+		contest, err := s.contestRepo.WithContext(ctx).FindByID(*contestID)
+		if err != nil {
+			return nil, err
+		}
+		if contest.UserID != userID {
+			return nil, domain.ErrForbidden
+		}
+		if contest.Status != domain.ContestStatusActive || contest.IsExpired() {
+			contestID = nil
+		}
+	}
+
+	now := time.Now()
+	if err := s.presenceRepo.WithContext(ctx).Touch(userID, contestID, now); err != nil {
+		return nil, err
+	}
+
+	presence := (&domain.UserPresence{UserID: userID, ContestID: contestID, LastSeenAt: now}).Presence(now, s.config.TTL)
+	return &presence, nil
+}
+
+// Statuses reports the presence of the given users; users without a recent
+// heartbeat are offline
+func (s *PresenceService) Statuses(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.Presence, error) {
+	ctx, span := s.tracer.Start(ctx, "PresenceService.Statuses")
+	defer span.End()
+
+	rows, err := s.presenceRepo.WithContext(ctx).FindByUserIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	statuses := make(map[uuid.UUID]domain.Presence, len(userIDs))
+	for _, id := range userIDs {
+		statuses[id] = domain.Presence{Status: domain.PresenceOffline}
+	}
+	for i := range rows {
+		statuses[rows[i].UserID] = rows[i].Presence(now, s.config.TTL)
+	}
+	return statuses, nil
+}
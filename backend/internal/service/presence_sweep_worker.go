@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// PresenceSweepWorker periodically deletes heartbeats older than the presence
+// TTL, so users who closed the app without signing off do not linger as rows
+type PresenceSweepWorker struct {
+	presenceRepo domain.PresenceRepository
+	config       *infrastructure.PresenceConfig
+	locks        *infrastructure.JobLocks
+	logger       *zap.Logger
+	wg           sync.WaitGroup
+	cancel       context.CancelFunc
+}
+
+// NewPresenceSweepWorker creates a new presence sweep worker
+func NewPresenceSweepWorker(
+	presenceRepo domain.PresenceRepository,
+	config *infrastructure.PresenceConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *PresenceSweepWorker {
+	return &PresenceSweepWorker{
+		presenceRepo: presenceRepo,
+		config:       config,
+		locks:        locks,
+		logger:       logger,
+	}
+}
+
+// Start launches the sweep loop in the background. A zero interval disables it;
+// stale heartbeats still read as offline.
+func (w *PresenceSweepWorker) Start(ctx context.Context) {
+	if w.config.SweepInterval <= 0 {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.SweepInterval)
+		defer ticker.Stop()
+
+		w.logger.Info("Presence sweep worker started",
+			zap.Duration("interval", w.config.SweepInterval),
+			zap.Duration("ttl", w.config.TTL),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				w.locks.RunEvery(ctx, "presence_sweep", w.config.SweepInterval, func() { w.Sweep(now) })
+			}
+		}
+	}()
+}
+
+// Stop stops the sweep loop and waits for an in-progress sweep to finish, or
+// until ctx is done
+func (w *PresenceSweepWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Presence sweep worker stopped")
+	return nil
+}
+
+// Sweep deletes the heartbeats that expired before now
+func (w *PresenceSweepWorker) Sweep(now time.Time) {
+	removed, err := w.presenceRepo.DeleteSeenBefore(now.Add(-w.config.TTL))
+	if err != nil {
+		w.logger.Error("Failed to sweep stale presence", zap.Error(err))
+		return
+	}
+	if removed > 0 {
+		w.logger.Debug("Stale presence swept", zap.Int64("count", removed))
+	}
+}
@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// defaultListPageSize is how many lists a browser page holds when the client does not ask
+const defaultListPageSize = 20
+
+// ProblemListService handles community problem lists: publishing, browsing,
+// subscribing, building contests from them and moderating them
+type ProblemListService struct {
+	listRepo       domain.ProblemListRepository
+	userRepo       domain.UserRepository
+	problemService *ProblemService
+	contestService *ContestService
+	filter         MessageFilter
+	config         *infrastructure.ListConfig
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewProblemListService creates a new community list service. Titles and
+// descriptions pass through filter before they are stored.
+func NewProblemListService(
+	listRepo domain.ProblemListRepository,
+	userRepo domain.UserRepository,
+	problemService *ProblemService,
+	contestService *ContestService,
+	filter MessageFilter,
+	config *infrastructure.ListConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *ProblemListService {
+	return &ProblemListService{
+		listRepo:       listRepo,
+		userRepo:       userRepo,
+		problemService: problemService,
+		contestService: contestService,
+		filter:         filter,
+		config:         config,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// BrowseLists returns a page of the published lists matching the query, or of
+// the user's own lists, hidden ones included
+func (s *ProblemListService) BrowseLists(ctx context.Context, userID uuid.UUID, query *domain.BrowseListsQuery) (*domain.ProblemListPage, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.BrowseLists")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("lists.sort", string(query.Sort)),
+		attribute.Bool("lists.subscribed", query.Subscribed),
+		attribute.Bool("lists.mine", query.Mine),
+	)
+
+	filter := domain.ProblemListFilter{
+		Query:  strings.TrimSpace(query.Query),
+		Sort:   query.Sort,
+		Status: domain.ProblemListPublished,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultListPageSize
+	}
+	if query.Mine {
+		filter.OwnerID = &userID
+		filter.Status = ""
+	}
+	if query.Subscribed {
+		filter.SubscriberID = &userID
+	}
+
+	lists, total, err := s.listRepo.WithContext(ctx).FindPage(filter, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		if lists[i].OwnerID != userID {
+			lists[i].Reports = 0
+		}
+	}
+	return &domain.ProblemListPage{Lists: lists, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// GetList returns a list with its problems in order and which of them the
+// user solved. Hidden lists are only shown to their owner.
+func (s *ProblemListService) GetList(ctx context.Context, userID, listID uuid.UUID) (*domain.ProblemListResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.GetList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("list.id", listID.String()),
+	)
+
+	list, err := s.visibleList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.summary(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := s.listProblems(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	solved, err := s.solvedSet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.ProblemListResponse{
+		ProblemListSummary: *summary,
+		Problems:           make([]domain.ProblemListProblem, len(problems)),
+	}
+	for i := range problems {
+		_, isSolved := solved[problems[i].ID]
+		response.Problems[i] = domain.ProblemListProblem{
+			ProblemResponse: problems[i].ToResponse(),
+			Position:        i + 1,
+			Solved:          isSolved,
+		}
+		if isSolved {
+			response.Solved++
+		}
+	}
+	return response, nil
+}
+
+// PublishList publishes a new list of the user's
+func (s *ProblemListService) PublishList(ctx context.Context, userID uuid.UUID, req *domain.ProblemListRequest) (*domain.ProblemListResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.PublishList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("list.problems", len(req.Problems)),
+	)
+
+	list := &domain.ProblemList{OwnerID: userID, Status: domain.ProblemListPublished}
+	if err := s.apply(ctx, list, req); err != nil {
+		return nil, err
+	}
+	if err := s.listRepo.WithContext(ctx).Create(list); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Problem list published",
+		zap.String("list_id", list.ID.String()),
+		zap.Int("problem_count", list.ProblemCount),
+	)
+	return s.GetList(ctx, userID, list.ID)
+}
+
+// UpdateList replaces the title, description and problems of one of the
+// user's lists. A hidden list stays hidden.
+func (s *ProblemListService) UpdateList(ctx context.Context, userID, listID uuid.UUID, req *domain.ProblemListRequest) (*domain.ProblemListResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.UpdateList")
+	defer span.End()
+
+	list, err := s.ownList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, list, req); err != nil {
+		return nil, err
+	}
+	if err := s.listRepo.WithContext(ctx).Update(list); err != nil {
+		return nil, err
+	}
+	return s.GetList(ctx, userID, list.ID)
+}
+
+// DeleteList deletes one of the user's lists with its subscriptions
+func (s *ProblemListService) DeleteList(ctx context.Context, userID, listID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.DeleteList")
+	defer span.End()
+
+	list, err := s.ownList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	return s.listRepo.WithContext(ctx).Delete(list.ID)
+}
+
+// Subscribe subscribes the user to a published list of another user's
+func (s *ProblemListService) Subscribe(ctx context.Context, userID, listID uuid.UUID) (*domain.ProblemListSummary, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.Subscribe")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("list.id", listID.String()),
+	)
+
+	list, err := s.visibleList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID == userID {
+		return nil, domain.ErrOwnList
+	}
+	if err := s.listRepo.WithContext(ctx).Subscribe(list.ID, userID); err != nil {
+		return nil, err
+	}
+	return s.summary(ctx, userID, list.ID)
+}
+
+// Unsubscribe removes the user's subscription to a list, hidden or not
+func (s *ProblemListService) Unsubscribe(ctx context.Context, userID, listID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.Unsubscribe")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("list.id", listID.String()),
+	)
+
+	if _, err := s.listRepo.WithContext(ctx).FindByID(listID); err != nil {
+		return err
+	}
+	return s.listRepo.WithContext(ctx).Unsubscribe(listID, userID)
+}
+
+// CreateContest starts a contest of the next problems of a list the user owns
+// or subscribes to, in list order. Problems the user solved are skipped unless
+// they ask for them; the contest is shorter when fewer problems are left.
+func (s *ProblemListService) CreateContest(ctx context.Context, userID, listID uuid.UUID, req *domain.CreateListContestRequest) (*domain.Contest, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.CreateContest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("list.id", listID.String()),
+		attribute.Int("problem.count", req.ProblemCount),
+		attribute.Bool("include_solved", req.IncludeSolved),
+	)
+
+	list, err := s.visibleList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID != userID {
+		subscribed, err := s.listRepo.WithContext(ctx).IsSubscribed(list.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !subscribed {
+			return nil, domain.ErrListNotSubscribed
+		}
+	}
+
+	problems, err := s.listProblems(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	if !req.IncludeSolved {
+		solved, err := s.solvedSet(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		unsolved := problems[:0]
+		for _, p := range problems {
+			if _, ok := solved[p.ID]; !ok {
+				unsolved = append(unsolved, p)
+			}
+		}
+		problems = unsolved
+	}
+	if len(problems) == 0 {
+		return nil, domain.ErrNotEnoughProblems
+	}
+
+	var warning *domain.ContestWarning
+	if len(problems) > req.ProblemCount {
+		problems = problems[:req.ProblemCount]
+	} else if len(problems) < req.ProblemCount {
+		delivered := make(map[domain.Difficulty]int)
+		for _, p := range problems {
+			delivered[p.Difficulty]++
+		}
+		warning = &domain.ContestWarning{
+			Code:      domain.WarningNotEnoughProblems,
+			Message:   fmt.Sprintf("Only %d of %d requested problems are left on this list", len(problems), req.ProblemCount),
+			Requested: map[domain.Difficulty]int{},
+			Delivered: delivered,
+		}
+		span.SetAttributes(attribute.String("selection.warning", warning.Code))
+	}
+
+	contest, err := s.contestService.CreateFromProblems(ctx, userID, problems, req.DurationMinutes, domain.OrderingList)
+	if err != nil {
+		return nil, err
+	}
+	contest.Warning = warning
+
+	logFor(ctx, s.logger).Info("Contest built from problem list",
+		zap.String("list_id", list.ID.String()),
+		zap.String("contest_id", contest.ID.String()),
+		zap.Int("problem_count", len(problems)),
+	)
+	return contest, nil
+}
+
+// ReportList reports a published list of another user's to the moderators.
+// Once enough users reported it, it is hidden until a moderator reviews it.
+func (s *ProblemListService) ReportList(ctx context.Context, userID, listID uuid.UUID, req *domain.ReportProblemListRequest) error {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.ReportList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("list.id", listID.String()),
+	)
+
+	list, err := s.visibleList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	if list.OwnerID == userID {
+		return domain.ErrOwnList
+	}
+
+	reports, err := s.listRepo.WithContext(ctx).AddReport(&domain.ProblemListReport{
+		ListID: list.ID,
+		UserID: userID,
+		Reason: strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int("list.reports", reports))
+
+	if s.config.ReportThreshold <= 0 || reports < s.config.ReportThreshold || list.Status != domain.ProblemListPublished {
+		return nil
+	}
+	if err := s.listRepo.WithContext(ctx).SetStatus(list.ID, domain.ProblemListHidden, "Hidden after reports from other users, pending review"); err != nil {
+		return err
+	}
+	logFor(ctx, s.logger).Warn("Problem list hidden after reports",
+		zap.String("list_id", list.ID.String()),
+		zap.Int("reports", reports),
+	)
+	return nil
+}
+
+// GetModerationQueue returns a page of lists for moderators, most reported
+// first: by default every list users reported, or every list of a status
+func (s *ProblemListService) GetModerationQueue(ctx context.Context, query *domain.ModerationListQuery) (*domain.ProblemListPage, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.GetModerationQueue")
+	defer span.End()
+
+	filter := domain.ProblemListFilter{
+		Sort:     domain.ProblemListSortReports,
+		Status:   query.Status,
+		Reported: query.Status == "",
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultListPageSize
+	}
+
+	lists, total, err := s.listRepo.WithContext(ctx).FindPage(filter, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ProblemListPage{Lists: lists, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// ModerateList hides or restores a list. Restoring it clears its reports.
+func (s *ProblemListService) ModerateList(ctx context.Context, moderatorID, listID uuid.UUID, req *domain.ModerateProblemListRequest) (*domain.ProblemListSummary, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemListService.ModerateList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("moderator.id", moderatorID.String()),
+		attribute.String("list.id", listID.String()),
+		attribute.String("list.status", string(req.Status)),
+	)
+
+	reason := strings.TrimSpace(req.Reason)
+	if req.Status == domain.ProblemListHidden && reason == "" {
+		reason = "Hidden by a moderator"
+	}
+	if err := s.listRepo.WithContext(ctx).SetStatus(listID, req.Status, reason); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Problem list moderated",
+		zap.String("list_id", listID.String()),
+		zap.String("moderator_id", moderatorID.String()),
+		zap.String("status", string(req.Status)),
+	)
+	return s.listRepo.WithContext(ctx).FindSummary(listID, moderatorID)
+}
+
+// apply resolves the requested problems and sets them with the filtered title
+// and description on the list
+func (s *ProblemListService) apply(ctx context.Context, list *domain.ProblemList, req *domain.ProblemListRequest) error {
+	ids, err := resolveProblemSet(ctx, s.problemService, req.Problems)
+	if err != nil {
+		return err
+	}
+	title, err := s.filter.Filter(ctx, strings.TrimSpace(req.Title))
+	if err != nil {
+		return err
+	}
+	description, err := s.filter.Filter(ctx, strings.TrimSpace(req.Description))
+	if err != nil {
+		return err
+	}
+
+	list.Title = title
+	list.Description = description
+	list.ProblemIDs = ids
+	list.ProblemCount = len(ids)
+	return nil
+}
+
+// visibleList returns a list the user can see: a published one, or their own
+func (s *ProblemListService) visibleList(ctx context.Context, userID, listID uuid.UUID) (*domain.ProblemList, error) {
+	list, err := s.listRepo.WithContext(ctx).FindByID(listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.Status != domain.ProblemListPublished && list.OwnerID != userID {
+		return nil, domain.ErrListNotFound
+	}
+	return list, nil
+}
+
+// ownList returns one of the user's lists
+func (s *ProblemListService) ownList(ctx context.Context, userID, listID uuid.UUID) (*domain.ProblemList, error) {
+	list, err := s.visibleList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if list.OwnerID != userID {
+		return nil, domain.ErrForbidden
+	}
+	return list, nil
+}
+
+// summary returns a list as the browser shows it to the user; only its owner
+// sees how often it was reported
+func (s *ProblemListService) summary(ctx context.Context, userID, listID uuid.UUID) (*domain.ProblemListSummary, error) {
+	summary, err := s.listRepo.WithContext(ctx).FindSummary(listID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.OwnerID != userID {
+		summary.Reports = 0
+	}
+	return summary, nil
+}
+
+// listProblems loads the problems of a list in order, leaving out any deleted
+// from the catalog since they were listed
+func (s *ProblemListService) listProblems(ctx context.Context, list *domain.ProblemList) ([]domain.Problem, error) {
+	if len(list.ProblemIDs) == 0 {
+		return []domain.Problem{}, nil
+	}
+	keys := make([]string, len(list.ProblemIDs))
+	for i, id := range list.ProblemIDs {
+		keys[i] = id.String()
+	}
+	problems, _, err := s.problemService.GetProblemsBatch(ctx, keys, uuid.Nil)
+	return problems, err
+}
+
+// solvedSet returns the IDs of the problems the user solved
+func (s *ProblemListService) solvedSet(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	ids, err := s.userRepo.WithContext(ctx).GetSolvedProblemIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+	solved := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		solved[id] = struct{}{}
+	}
+	return solved, nil
+}
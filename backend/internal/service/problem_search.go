@@ -0,0 +1,179 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// Scores of the ways a search term can match; fuzzy matches score their
+// trigram similarity, and matches on a topic count less than on the title
+const (
+	searchScoreExact        = 1.0
+	searchScorePrefix       = 0.9
+	searchScoreWordPrefix   = 0.8
+	searchScoreSubstring    = 0.7
+	searchTopicWeight       = 0.8
+	searchMinScore          = 0.3 // pg_trgm's default similarity threshold
+	searchWordSimilarityMin = 0.5 // A fuzzy word match, e.g. "palindrom" for "Palindromic"
+)
+
+// rankSearch scores every problem against the query, drops those below
+// searchMinScore and returns the rest best first with their highlights
+func rankSearch(problems []domain.Problem, query string, limit int) []domain.ProblemSearchResult {
+	q := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	terms := strings.Fields(q)
+
+	results := make([]domain.ProblemSearchResult, 0, len(problems))
+	for i := range problems {
+		p := &problems[i]
+		score, highlights := matchField(p.Title, q, terms, domain.SearchFieldTitle, 0)
+		for j, topic := range p.Topics {
+			topicScore, topicHighlights := matchField(topic, q, terms, domain.SearchFieldTopic, j)
+			score = max(score, topicScore*searchTopicWeight)
+			highlights = append(highlights, topicHighlights...)
+		}
+		if score < searchMinScore {
+			continue
+		}
+		results = append(results, domain.ProblemSearchResult{
+			Problem:    p.ToResponse(),
+			Score:      float64(int(score*1000+0.5)) / 1000,
+			Highlights: highlights,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// matchField scores one field against the normalized query and its terms and
+// returns the ranges to highlight: every occurrence of a term, or the words
+// that only match fuzzily
+func matchField(value, q string, terms []string, field domain.SearchField, index int) (float64, []domain.SearchHighlight) {
+	lower := strings.ToLower(value)
+	var score float64
+	switch {
+	case lower == q:
+		score = searchScoreExact
+	case strings.HasPrefix(lower, q):
+		score = searchScorePrefix
+	case hasWordPrefix(lower, q):
+		score = searchScoreWordPrefix
+	case strings.Contains(lower, q):
+		score = searchScoreSubstring
+	default:
+		score = trigramSimilarity(q, lower)
+	}
+
+	words := wordSpans(lower)
+	var highlights []domain.SearchHighlight
+	var termScore float64 // Mean best match of each term, so "binary tree" finds "Binary Search Tree"
+	for _, term := range terms {
+		best := 0.0
+		for start := 0; ; {
+			at := strings.Index(lower[start:], term)
+			if at < 0 {
+				break
+			}
+			begin := start + at
+			highlights = append(highlights, highlight(value, field, index, begin, begin+len(term)))
+			best = searchScoreSubstring
+			start = begin + len(term)
+		}
+		if best > 0 {
+			termScore += best
+			continue
+		}
+		var bestWord [2]int
+		for _, w := range words {
+			if sim := trigramSimilarity(term, lower[w[0]:w[1]]); sim > best {
+				best, bestWord = sim, w
+			}
+		}
+		if best >= searchWordSimilarityMin {
+			highlights = append(highlights, highlight(value, field, index, bestWord[0], bestWord[1]))
+			termScore += best
+		}
+	}
+	if len(terms) > 0 {
+		score = max(score, termScore/float64(len(terms))*searchScoreSubstring)
+	}
+	return score, highlights
+}
+
+// highlight converts a byte range of value into rune offsets, which is what
+// clients index strings by
+func highlight(value string, field domain.SearchField, index, start, end int) domain.SearchHighlight {
+	return domain.SearchHighlight{
+		Field:  field,
+		Index:  index,
+		Start:  len([]rune(value[:start])),
+		Length: len([]rune(value[start:end])),
+	}
+}
+
+// hasWordPrefix reports whether a word after the first starts with prefix
+func hasWordPrefix(s, prefix string) bool {
+	spans := wordSpans(s)
+	for i := 1; i < len(spans); i++ {
+		if strings.HasPrefix(s[spans[i][0]:], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// wordSpans returns the byte ranges of the alphanumeric words in s
+func wordSpans(s string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range s {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(s)})
+	}
+	return spans
+}
+
+// trigramSimilarity is pg_trgm's similarity(): the share of trigrams the two
+// strings have in common, with each word padded by two spaces in front and one
+// behind
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range wordSpans(s) {
+		padded := []rune("  " + s[w[0]:w[1]] + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"math"
 	"math/rand"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,33 +15,84 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// The adaptive selection algorithm looks at the user's last adaptiveWindowContests
+// finished contests and makes the mix harder or easier past these solve rates
+const (
+	adaptiveWindowContests = 5
+	adaptiveHarderRate     = 0.8
+	adaptiveEasierRate     = 0.4
+)
+
+// Difficulty ratings tune the default mix of a profile once there are
+// tuningMinRatings of them; the strongest factor (±1) moves tuningMaxShift of
+// a difficulty's problems to the neighbouring difficulty
+const (
+	tuningMinRatings = 2
+	tuningMaxShift   = 0.5
 )
 
 // ProblemService handles problem-related business logic
 type ProblemService struct {
 	problemRepo domain.ProblemRepository
 	userRepo    domain.UserRepository
+	config      *infrastructure.ContestConfig
 	tracer      trace.Tracer
 	logger      *zap.Logger
-	rng         *rand.Rand
-	rngMu       sync.Mutex // Protects rng for concurrent access
+	siteURL     string // Base URL of the public frontend
+
+	// Each selection and shuffle draws from its own generator, built by
+	// newSource from a seed; seeds draws the seeds of callers that give none
+	newSource func(seed int64) rand.Source
+	seeds     *rand.Rand
+	seedsMu   sync.Mutex
+
+	// Problem stats are cached per tenant for statsTTL, since each tenant sees
+	// its own problems; concurrent misses share one computation
+	statsTTL    time.Duration
+	statsGroup  singleflight.Group
+	statsMu     sync.Mutex
+	stats       map[uuid.UUID]*domain.ProblemStats
+	statsExpiry map[uuid.UUID]time.Time
 }
 
-// NewProblemService creates a new problem service
+// NewProblemService creates a new problem service. newSource builds the random
+// source of one problem selection or shuffle from its seed, so the same seed and
+// pool give the same problems; nil uses rand.NewSource. Selections without a
+// seed get one drawn from problemConfig.SelectionSeed, or the clock when unset.
 func NewProblemService(
 	problemRepo domain.ProblemRepository,
 	userRepo domain.UserRepository,
+	config *infrastructure.ContestConfig,
+	problemConfig *infrastructure.ProblemConfig,
+	newSource func(seed int64) rand.Source,
 	tracer trace.Tracer,
 	logger *zap.Logger,
 ) *ProblemService {
+	if newSource == nil {
+		newSource = rand.NewSource
+	}
+	seed := problemConfig.SelectionSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
 	return &ProblemService{
 		problemRepo: problemRepo,
 		userRepo:    userRepo,
+		config:      config,
 		tracer:      tracer,
 		logger:      logger,
-		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		newSource:   newSource,
+		seeds:       rand.New(rand.NewSource(seed)),
+		statsTTL:    problemConfig.StatsCacheTTL,
+		stats:       make(map[uuid.UUID]*domain.ProblemStats),
+		statsExpiry: make(map[uuid.UUID]time.Time),
+		siteURL:     problemConfig.SiteURL,
 	}
 }
 
@@ -46,24 +101,400 @@ func (s *ProblemService) GetAllProblems(ctx context.Context) ([]domain.Problem,
 	ctx, span := s.tracer.Start(ctx, "ProblemService.GetAllProblems")
 	defer span.End()
 
-	return s.problemRepo.FindAll()
+	return s.problemRepo.WithContext(ctx).FindAll()
+}
+
+// FindProblems returns the problems matching the criteria. userID may be uuid.Nil
+// when the criteria do not depend on the user's solved state.
+func (s *ProblemService) FindProblems(ctx context.Context, userID uuid.UUID, criteria domain.ProblemCriteria) ([]domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.FindProblems")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("criteria.difficulties", len(criteria.Difficulties)),
+		attribute.Int("criteria.topics", len(criteria.Topics)),
+		attribute.String("criteria.solved_state", string(criteria.SolvedState)),
+	)
+
+	problems, err := s.problemRepo.WithContext(ctx).FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var unsolved map[uuid.UUID]struct{}
+	if criteria.NeedsUser() {
+		if userID == uuid.Nil {
+			return nil, domain.NewDomainError(domain.ErrUnauthorized, "Sign in to filter by solved state")
+		}
+		remaining, err := s.problemRepo.WithContext(ctx).FindUnsolvedByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		unsolved = make(map[uuid.UUID]struct{}, len(remaining))
+		for _, p := range remaining {
+			unsolved[p.ID] = struct{}{}
+		}
+	}
+
+	matched := make([]domain.Problem, 0, len(problems))
+	for i := range problems {
+		p := &problems[i]
+		if !criteria.Matches(p) {
+			continue
+		}
+		if unsolved != nil {
+			_, isUnsolved := unsolved[p.ID]
+			if isUnsolved != (criteria.SolvedState == domain.SolvedStateUnsolved) {
+				continue
+			}
+		}
+		matched = append(matched, *p)
+	}
+	return matched, nil
+}
+
+// SearchProblems finds catalog problems by title or topic, tolerating typos and
+// partial words, and returns up to limit matches best first with the ranges
+// that matched
+func (s *ProblemService) SearchProblems(ctx context.Context, query string, limit int) (*domain.ProblemSearchResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.SearchProblems")
+	defer span.End()
+
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" {
+		return nil, domain.NewValidationError("Search query is empty", nil)
+	}
+
+	candidates, err := s.problemRepo.WithContext(ctx).FindSearchCandidates(strings.ToLower(query))
+	if err != nil {
+		return nil, err
+	}
+	results := rankSearch(candidates, query, limit)
+
+	span.SetAttributes(
+		attribute.Int("search.candidates", len(candidates)),
+		attribute.Int("search.results", len(results)),
+	)
+	return &domain.ProblemSearchResponse{
+		Query:   query,
+		Results: results,
+		Count:   len(results),
+	}, nil
 }
 
-// GetProblemByID returns a specific problem
-func (s *ProblemService) GetProblemByID(ctx context.Context, id uuid.UUID) (*domain.Problem, error) {
+// GetProblemByID returns a specific problem. Custom problems are only returned
+// to their owner; viewerID is uuid.Nil for anonymous requests.
+func (s *ProblemService) GetProblemByID(ctx context.Context, id, viewerID uuid.UUID) (*domain.Problem, error) {
 	ctx, span := s.tracer.Start(ctx, "ProblemService.GetProblemByID")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("problem.id", id.String()))
-	return s.problemRepo.FindByID(id)
+
+	problem, err := s.problemRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !problem.VisibleTo(viewerID) {
+		return nil, domain.ErrProblemNotFound
+	}
+	return problem, nil
+}
+
+// GetProblemsBatch looks up problems by ID or catalog slug in one query. The
+// problems come back in the order of their first key; keys that match no
+// problem visible to viewerID are returned as notFound.
+func (s *ProblemService) GetProblemsBatch(ctx context.Context, keys []string, viewerID uuid.UUID) ([]domain.Problem, []string, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetProblemsBatch")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("batch.keys", len(keys)))
+
+	var ids []uuid.UUID
+	var slugs []string
+	for _, key := range keys {
+		if id, err := uuid.Parse(key); err == nil {
+			ids = append(ids, id)
+		} else {
+			slugs = append(slugs, key)
+		}
+	}
+
+	found, err := s.problemRepo.WithContext(ctx).FindByIDsOrSlugs(ids, slugs)
+	if err != nil {
+		return nil, nil, err
+	}
+	byKey := make(map[string]*domain.Problem, 2*len(found))
+	for i := range found {
+		p := &found[i]
+		if !p.VisibleTo(viewerID) {
+			continue
+		}
+		byKey[p.ID.String()] = p
+		if !p.IsCustom() {
+			byKey[p.Slug] = p
+		}
+	}
+
+	problems := make([]domain.Problem, 0, len(keys))
+	notFound := []string{}
+	seen := make(map[uuid.UUID]struct{}, len(keys))
+	for _, key := range keys {
+		lookup := key
+		if id, err := uuid.Parse(key); err == nil {
+			lookup = id.String()
+		}
+		p, ok := byKey[lookup]
+		if !ok {
+			notFound = append(notFound, key)
+			continue
+		}
+		if _, dup := seen[p.ID]; dup {
+			continue
+		}
+		seen[p.ID] = struct{}{}
+		problems = append(problems, *p)
+	}
+	return problems, notFound, nil
+}
+
+// problemPageURL is where the public frontend renders a catalog problem
+func (s *ProblemService) problemPageURL(slug string) string {
+	return s.siteURL + "/problems/" + slug
+}
+
+// GetProblemPage returns a catalog problem by slug with the metadata its public page needs
+func (s *ProblemService) GetProblemPage(ctx context.Context, slug string) (*domain.ProblemPage, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetProblemPage")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("problem.slug", slug))
+
+	problem, err := s.problemRepo.WithContext(ctx).FindBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	prerequisites, err := s.problemRepo.WithContext(ctx).FindPrerequisites(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.ProblemPage{
+		Problem: problem.ToResponse(),
+		SEO: domain.PageMetadata{
+			Title:        fmt.Sprintf("%s (%s) - NeetCode 150 practice", problem.Title, problem.Difficulty),
+			Description:  problemDescription(problem),
+			CanonicalURL: s.problemPageURL(problem.Slug),
+			Keywords:     append([]string{problem.Title, string(problem.Difficulty)}, problem.Topics...),
+		},
+		Prerequisites: make([]domain.ProblemResponse, len(prerequisites)),
+	}
+	for i := range prerequisites {
+		page.Prerequisites[i] = prerequisites[i].ToResponse()
+	}
+	return page, nil
+}
+
+// problemDescription is the search snippet of a problem page, e.g. "Two Sum is an
+// Easy Arrays & Hashing problem from the NeetCode 150..."
+func problemDescription(p *domain.Problem) string {
+	article := "a"
+	if p.Difficulty == domain.DifficultyEasy {
+		article = "an"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is %s %s", p.Title, article, p.Difficulty)
+	if len(p.Topics) > 0 {
+		fmt.Fprintf(&b, " %s", strings.Join(p.Topics, " and "))
+	}
+	b.WriteString(" problem from the NeetCode 150.")
+	if companies := p.CompanyNames(); len(companies) > 0 {
+		fmt.Fprintf(&b, " Asked at %s.", strings.Join(companies[:min(len(companies), 3)], ", "))
+	}
+	b.WriteString(" Practice it in a timed mock interview contest.")
+	return b.String()
+}
+
+// GetSitemap lists the site root and every catalog problem page. More important
+// problems get a higher priority so crawlers visit them first.
+func (s *ProblemService) GetSitemap(ctx context.Context) (*domain.Sitemap, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetSitemap")
+	defer span.End()
+
+	problems, err := s.problemRepo.WithContext(ctx).FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sitemap := &domain.Sitemap{
+		Xmlns: domain.SitemapNamespace,
+		URLs:  make([]domain.SitemapURL, 0, len(problems)+1),
+	}
+	sitemap.URLs = append(sitemap.URLs, domain.SitemapURL{Loc: s.siteURL + "/", ChangeFreq: "weekly", Priority: "1.0"})
+	for _, p := range problems {
+		priority := 0.5 + float64(p.Importance)/200 // Importance runs 0-100
+		sitemap.URLs = append(sitemap.URLs, domain.SitemapURL{
+			Loc:        s.problemPageURL(p.Slug),
+			ChangeFreq: "monthly",
+			Priority:   fmt.Sprintf("%.1f", priority),
+		})
+	}
+	return sitemap, nil
 }
 
-// GetProblemStats returns statistics about the problem set
+// findCatalogProblem returns a catalog problem, reporting custom problems as not found
+func (s *ProblemService) findCatalogProblem(ctx context.Context, id uuid.UUID) (*domain.Problem, error) {
+	problem, err := s.problemRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if problem.IsCustom() {
+		return nil, domain.ErrProblemNotFound
+	}
+	return problem, nil
+}
+
+// GetCompanies returns every company with its number of tagged problems
+func (s *ProblemService) GetCompanies(ctx context.Context) ([]domain.CompanyCount, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetCompanies")
+	defer span.End()
+
+	return s.problemRepo.WithContext(ctx).FindCompanies()
+}
+
+// SetProblemCompanies replaces the company tags of a problem and returns the updated problem
+func (s *ProblemService) SetProblemCompanies(ctx context.Context, problemID uuid.UUID, companies []string) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.SetProblemCompanies")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("problem.id", problemID.String()))
+
+	if _, err := s.findCatalogProblem(ctx, problemID); err != nil {
+		return nil, err
+	}
+
+	normalized := domain.NormalizeCompanies(companies)
+	if err := s.problemRepo.WithContext(ctx).SetCompanies(problemID, normalized); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Problem companies updated",
+		zap.String("problem_id", problemID.String()),
+		zap.Strings("companies", normalized),
+	)
+	return s.problemRepo.WithContext(ctx).FindByID(problemID)
+}
+
+// SetProblemImportance tunes the importance score of a problem and returns the updated problem
+func (s *ProblemService) SetProblemImportance(ctx context.Context, problemID uuid.UUID, importance int) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.SetProblemImportance")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("problem.id", problemID.String()),
+		attribute.Int("problem.importance", importance),
+	)
+
+	if _, err := s.findCatalogProblem(ctx, problemID); err != nil {
+		return nil, err
+	}
+	if err := s.problemRepo.WithContext(ctx).SetImportance(problemID, importance); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Problem importance updated",
+		zap.String("problem_id", problemID.String()),
+		zap.Int("importance", importance),
+	)
+	return s.problemRepo.WithContext(ctx).FindByID(problemID)
+}
+
+// SetProblemComplexity sets the canonical complexity that contest answers are graded against
+func (s *ProblemService) SetProblemComplexity(ctx context.Context, problemID uuid.UUID, req *domain.SetProblemComplexityRequest) (*domain.ProblemComplexity, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.SetProblemComplexity")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("problem.id", problemID.String()))
+
+	problem, err := s.findCatalogProblem(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	timeComplexity := strings.TrimSpace(req.TimeComplexity)
+	spaceComplexity := strings.TrimSpace(req.SpaceComplexity)
+	if err := s.problemRepo.WithContext(ctx).SetComplexity(problemID, timeComplexity, spaceComplexity); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Problem complexity updated",
+		zap.String("problem_id", problemID.String()),
+		zap.String("time_complexity", timeComplexity),
+		zap.String("space_complexity", spaceComplexity),
+	)
+	return &domain.ProblemComplexity{
+		ProblemID:       problem.ID,
+		Title:           problem.Title,
+		TimeComplexity:  timeComplexity,
+		SpaceComplexity: spaceComplexity,
+	}, nil
+}
+
+// GetPrerequisites returns the direct prerequisites of a problem
+func (s *ProblemService) GetPrerequisites(ctx context.Context, problemID uuid.UUID) ([]domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetPrerequisites")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("problem.id", problemID.String()))
+
+	// Make sure the problem exists so unknown IDs are reported as not found
+	if _, err := s.findCatalogProblem(ctx, problemID); err != nil {
+		return nil, err
+	}
+	return s.problemRepo.WithContext(ctx).FindPrerequisites(problemID)
+}
+
+// GetProblemStats returns statistics about the problem set.
+// The result is cached and shared between callers, so it must not be modified.
 func (s *ProblemService) GetProblemStats(ctx context.Context) (*domain.ProblemStats, error) {
 	ctx, span := s.tracer.Start(ctx, "ProblemService.GetProblemStats")
 	defer span.End()
 
-	problems, err := s.problemRepo.FindAll()
+	tenantID := cacheTenant(ctx)
+	s.statsMu.Lock()
+	stats, expiry := s.stats[tenantID], s.statsExpiry[tenantID]
+	s.statsMu.Unlock()
+	if stats != nil && time.Now().Before(expiry) {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return stats, nil
+	}
+
+	// The computation outlives a caller that gives up, since other callers may be waiting on it
+	computeCtx := context.WithoutCancel(ctx)
+	result := s.statsGroup.DoChan("stats:"+tenantID.String(), func() (interface{}, error) {
+		stats, err := s.computeProblemStats(computeCtx)
+		if err != nil {
+			return nil, err
+		}
+		s.statsMu.Lock()
+		s.stats[tenantID], s.statsExpiry[tenantID] = stats, time.Now().Add(s.statsTTL)
+		s.statsMu.Unlock()
+		return stats, nil
+	})
+
+	select {
+	case r := <-result:
+		span.SetAttributes(attribute.Bool("cache.hit", false), attribute.Bool("cache.shared", r.Shared))
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*domain.ProblemStats), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// computeProblemStats counts the catalog problems by difficulty and topic
+func (s *ProblemService) computeProblemStats(ctx context.Context) (*domain.ProblemStats, error) {
+	problems, err := s.problemRepo.WithContext(ctx).FindAll()
 	if err != nil {
 		return nil, err
 	}
@@ -84,22 +515,207 @@ func (s *ProblemService) GetProblemStats(ctx context.Context) (*domain.ProblemSt
 	return stats, nil
 }
 
+// GetCalibration returns usage counters for every problem, grouped by difficulty and
+// ordered from lowest to highest completion rate, so admins can spot mislabeled problems
+func (s *ProblemService) GetCalibration(ctx context.Context) ([]domain.ProblemCalibration, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetCalibration")
+	defer span.End()
+
+	problems, err := s.problemRepo.WithContext(ctx).FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	calibration := make([]domain.ProblemCalibration, len(problems))
+	for i, p := range problems {
+		calibration[i] = domain.ProblemCalibration{
+			ID:         p.ID,
+			Title:      p.Title,
+			Difficulty: p.Difficulty,
+			Importance: p.Importance,
+			Popularity: p.Popularity(),
+		}
+	}
+
+	sort.SliceStable(calibration, func(i, j int) bool {
+		if calibration[i].Difficulty != calibration[j].Difficulty {
+			return calibration[i].Difficulty.Weight() < calibration[j].Difficulty.Weight()
+		}
+		return calibration[i].Popularity.CompletionRate < calibration[j].Popularity.CompletionRate
+	})
+
+	return calibration, nil
+}
+
+// HandleContestCreated counts every problem served in a new contest as selected
+func (s *ProblemService) HandleContestCreated(ctx context.Context, event domain.Event) error {
+	e, ok := event.(domain.ContestCreatedEvent)
+	if !ok {
+		return nil
+	}
+	return s.problemRepo.WithContext(ctx).IncrementTimesSelected(e.ProblemIDs)
+}
+
+// HandleProblemCompletionChanged keeps the completion counter in step with contest check-offs
+func (s *ProblemService) HandleProblemCompletionChanged(ctx context.Context, event domain.Event) error {
+	e, ok := event.(domain.ProblemCompletionChangedEvent)
+	if !ok {
+		return nil
+	}
+	delta := -1
+	if e.IsCompleted {
+		delta = 1
+	}
+	return s.problemRepo.WithContext(ctx).AddTimesCompleted(e.ProblemID, delta)
+}
+
 // SelectProblemsForContest selects n problems with gradual difficulty increase
 // The algorithm:
 // 1. Exclude previously solved problems for the user
 // 2. Group remaining problems by difficulty
-// 3. Distribute across difficulties based on n (Easy → Medium → Hard progression)
-// 4. Randomize within each difficulty bucket
+// 3. Distribute across difficulties based on n (Easy → Medium → Hard progression),
+// moving any bucket's shortfall to the nearest difficulties that still have problems
+// 4. Randomize within each difficulty bucket, preferring problems outside the recent-contest cooldown
 // 5. Sort final list by difficulty (ascending)
-func (s *ProblemService) SelectProblemsForContest(ctx context.Context, userID uuid.UUID, count int) ([]domain.Problem, error) {
+// The options can narrow step 1 to unlocked problems (all prerequisites solved) or to
+// company-tagged problems, and step 3 to a subset of difficulties.
+// The returned warning is non-nil when the delivered mix differs from the requested one.
+func (s *ProblemService) SelectProblemsForContest(ctx context.Context, userID uuid.UUID, count int, opts domain.SelectionOptions) ([]domain.Problem, *domain.ContestWarning, error) {
 	ctx, span := s.tracer.Start(ctx, "ProblemService.SelectProblemsForContest")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user.id", userID.String()),
 		attribute.Int("problem.count", count),
+		attribute.Bool("selection.respect_prerequisites", opts.RespectPrerequisites),
+		attribute.StringSlice("selection.companies", opts.Companies),
+		attribute.String("selection.weighting", string(opts.Weighting)),
+		attribute.Bool("selection.include_custom", opts.IncludeCustom),
+		attribute.String("selection.algorithm", string(opts.Algorithm)),
+	)
+
+	// The seed is traced so the draw can be reproduced
+	rng, seed := s.selectionRand(opts.Seed)
+	span.SetAttributes(attribute.Int64("selection.seed", seed))
+
+	difficulties := domain.AllDifficulties
+	problemsByDifficulty, err := s.candidatePool(ctx, userID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Problems from recent contests (including abandoned ones) are only used as a fallback
+	recent := s.recentlyServed(ctx, userID)
+	span.SetAttributes(attribute.Int("cooldown.recent_problems", len(recent)))
+
+	// Calculate distribution based on count, or take the one tuned by the user's
+	// ratings, moved onto the allowed difficulties if restricted
+	distribution := s.calculateDistribution(count)
+	if opts.Tuning != nil {
+		distribution = maps.Clone(opts.Tuning.Tuned)
+	}
+	if opts.Algorithm == domain.SelectionAdaptive {
+		distribution = s.adaptDistribution(ctx, userID, distribution)
+	}
+	if len(opts.Difficulties) > 0 {
+		allowed := make(map[domain.Difficulty]int, len(opts.Difficulties))
+		for _, diff := range opts.Difficulties {
+			allowed[diff] = count
+		}
+		distribution = redistribute(difficulties, distribution, allowed)
+	}
+
+	span.SetAttributes(
+		attribute.Int("distribution.easy", distribution[domain.DifficultyEasy]),
+		attribute.Int("distribution.medium", distribution[domain.DifficultyMedium]),
+		attribute.Int("distribution.hard", distribution[domain.DifficultyHard]),
+	)
+
+	// Rebalance the mix around buckets that ran dry
+	available := make(map[domain.Difficulty]int, len(difficulties))
+	for _, diff := range difficulties {
+		available[diff] = len(problemsByDifficulty[diff])
+	}
+	delivered := redistribute(difficulties, distribution, available)
+
+	// Select problems according to the rebalanced distribution
+	var selectedProblems []domain.Problem
+	for _, diff := range difficulties {
+		// Randomly select from available, outside the cooldown window first
+		selected := selectWithCooldown(rng, problemsByDifficulty[diff], delivered[diff], recent, opts.Weighting)
+		selectedProblems = append(selectedProblems, selected...)
+	}
+
+	if len(selectedProblems) == 0 {
+		// Tell the client what is left so it can ask for a contest that fits
+		return nil, nil, &domain.DomainError{Err: domain.ErrNotEnoughProblems, Details: availabilityOf(problemsByDifficulty)}
+	}
+
+	warning := selectionWarning(count, distribution, delivered)
+	if warning != nil {
+		span.SetAttributes(attribute.String("selection.warning", warning.Code))
+		logFor(ctx, s.logger).Warn("Problem mix adjusted",
+			zap.String("code", warning.Code),
+			zap.Int("requested", count),
+			zap.Int("delivered", len(selectedProblems)),
+		)
+	}
+
+	// Sort by difficulty (for proper progression)
+	sort.Slice(selectedProblems, func(i, j int) bool {
+		return selectedProblems[i].Difficulty.Weight() < selectedProblems[j].Difficulty.Weight()
+	})
+
+	logFor(ctx, s.logger).Info("Problems selected for contest",
+		zap.Int("count", len(selectedProblems)),
+	)
+
+	return selectedProblems, warning, nil
+}
+
+// GetAvailability counts the unsolved problems a random contest with the given
+// options can draw for the user, by difficulty and topic
+func (s *ProblemService) GetAvailability(ctx context.Context, userID uuid.UUID, opts domain.SelectionOptions) (*domain.ProblemAvailability, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.GetAvailability")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("selection.respect_prerequisites", opts.RespectPrerequisites),
+		attribute.StringSlice("selection.companies", opts.Companies),
+		attribute.Bool("selection.include_custom", opts.IncludeCustom),
 	)
 
+	problemsByDifficulty, err := s.candidatePool(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+	availability := availabilityOf(problemsByDifficulty)
+	span.SetAttributes(attribute.Int("availability.total", availability.Total))
+	return availability, nil
+}
+
+// availabilityOf counts a candidate pool by difficulty and topic
+func availabilityOf(problemsByDifficulty map[domain.Difficulty][]domain.Problem) *domain.ProblemAvailability {
+	availability := &domain.ProblemAvailability{
+		ByDifficulty: make(map[domain.Difficulty]int, len(domain.AllDifficulties)),
+		ByTopic:      make(map[string]int),
+	}
+	for _, diff := range domain.AllDifficulties {
+		availability.ByDifficulty[diff] = len(problemsByDifficulty[diff])
+		availability.Total += len(problemsByDifficulty[diff])
+		for _, p := range problemsByDifficulty[diff] {
+			for _, topic := range p.Topics {
+				availability.ByTopic[topic]++
+			}
+		}
+	}
+	return availability
+}
+
+// candidatePool fetches the unsolved problems a random contest may draw from,
+// by difficulty, narrowed to the companies and unlocked problems the options ask for
+func (s *ProblemService) candidatePool(ctx context.Context, userID uuid.UUID, opts domain.SelectionOptions) (map[domain.Difficulty][]domain.Problem, error) {
 	// Use worker pool pattern for parallel fetching of problems by difficulty
 	type difficultyResult struct {
 		difficulty domain.Difficulty
@@ -107,19 +723,13 @@ func (s *ProblemService) SelectProblemsForContest(ctx context.Context, userID uu
 		err        error
 	}
 
-	difficulties := []domain.Difficulty{
-		domain.DifficultyEasy,
-		domain.DifficultyMedium,
-		domain.DifficultyHard,
-	}
-
-	resultChan := make(chan difficultyResult, len(difficulties))
+	resultChan := make(chan difficultyResult, len(domain.AllDifficulties))
 	var wg sync.WaitGroup
 
 	// Worker function to fetch problems by difficulty
 	fetchProblems := func(diff domain.Difficulty) {
 		defer wg.Done()
-		problems, err := s.problemRepo.FindUnsolvedByUserAndDifficulty(userID, diff)
+		problems, err := s.problemRepo.WithContext(ctx).FindUnsolvedByUserAndDifficulty(userID, diff, opts.IncludeCustom)
 		resultChan <- difficultyResult{
 			difficulty: diff,
 			problems:   problems,
@@ -128,8 +738,13 @@ func (s *ProblemService) SelectProblemsForContest(ctx context.Context, userID uu
 	}
 
 	// Launch workers
-	for _, diff := range difficulties {
+	for _, diff := range domain.AllDifficulties {
 		wg.Add(1)
+		// A request transaction holds one connection, which cannot run queries concurrently
+		if infrastructure.InTx(ctx) {
+			fetchProblems(diff)
+			continue
+		}
 		go fetchProblems(diff)
 	}
 
@@ -143,7 +758,7 @@ func (s *ProblemService) SelectProblemsForContest(ctx context.Context, userID uu
 	problemsByDifficulty := make(map[domain.Difficulty][]domain.Problem)
 	for result := range resultChan {
 		if result.err != nil {
-			s.logger.Error("Failed to fetch problems by difficulty",
+			logFor(ctx, s.logger).Error("Failed to fetch problems by difficulty",
 				zap.String("difficulty", string(result.difficulty)),
 				zap.Error(result.err),
 			)
@@ -152,57 +767,149 @@ func (s *ProblemService) SelectProblemsForContest(ctx context.Context, userID uu
 		problemsByDifficulty[result.difficulty] = result.problems
 	}
 
-	// Calculate distribution based on count
-	distribution := s.calculateDistribution(count)
+	// Keep only problems tagged with one of the requested companies
+	if len(opts.Companies) > 0 {
+		taggedIDs, err := s.problemRepo.WithContext(ctx).FindIDsByCompanies(opts.Companies)
+		if err != nil {
+			return nil, err
+		}
+		tagged := make(map[uuid.UUID]struct{}, len(taggedIDs))
+		for _, id := range taggedIDs {
+			tagged[id] = struct{}{}
+		}
+		for diff, problems := range problemsByDifficulty {
+			problemsByDifficulty[diff] = onlyIDs(problems, tagged)
+		}
+	}
 
-	span.SetAttributes(
-		attribute.Int("distribution.easy", distribution[domain.DifficultyEasy]),
-		attribute.Int("distribution.medium", distribution[domain.DifficultyMedium]),
-		attribute.Int("distribution.hard", distribution[domain.DifficultyHard]),
-	)
+	// Keep only problems the user has unlocked by solving their prerequisites
+	if opts.RespectPrerequisites {
+		lockedIDs, err := s.problemRepo.WithContext(ctx).FindLockedIDsByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int("prerequisites.locked_problems", len(lockedIDs)))
+		locked := make(map[uuid.UUID]struct{}, len(lockedIDs))
+		for _, id := range lockedIDs {
+			locked[id] = struct{}{}
+		}
+		for diff, problems := range problemsByDifficulty {
+			problemsByDifficulty[diff] = withoutIDs(problems, locked)
+		}
+	}
 
-	// Select problems according to distribution
-	var selectedProblems []domain.Problem
-	shortfall := 0
+	return problemsByDifficulty, nil
+}
 
-	for _, diff := range []domain.Difficulty{domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard} {
-		needed := distribution[diff] + shortfall
-		available := problemsByDifficulty[diff]
+// SelectWarmupProblem picks a single unsolved easy problem for a contest warmup,
+// skipping the problems already chosen for the contest itself
+func (s *ProblemService) SelectWarmupProblem(ctx context.Context, userID uuid.UUID, exclude []domain.Problem) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.SelectWarmupProblem")
+	defer span.End()
 
-		if len(available) < needed {
-			// Not enough problems at this difficulty, carry over to next
-			shortfall = needed - len(available)
-			selectedProblems = append(selectedProblems, available...)
-		} else {
-			// Randomly select from available
-			selected := s.randomSelect(available, needed)
-			selectedProblems = append(selectedProblems, selected...)
-			shortfall = 0
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	easy, err := s.problemRepo.WithContext(ctx).FindUnsolvedByUserAndDifficulty(userID, domain.DifficultyEasy, false)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[uuid.UUID]struct{}, len(exclude))
+	for _, p := range exclude {
+		taken[p.ID] = struct{}{}
+	}
+	var candidates []domain.Problem
+	for _, p := range easy {
+		if _, ok := taken[p.ID]; !ok {
+			candidates = append(candidates, p)
 		}
 	}
 
-	// Check if we have enough problems
-	if len(selectedProblems) < count {
-		s.logger.Warn("Not enough unsolved problems available",
-			zap.Int("requested", count),
-			zap.Int("available", len(selectedProblems)),
-		)
-		if len(selectedProblems) == 0 {
-			return nil, domain.ErrNotEnoughProblems
+	if len(candidates) == 0 {
+		return nil, domain.NewDomainError(domain.ErrNotEnoughProblems, "No unsolved easy problem left for a warmup. Try without one.")
+	}
+
+	rng, _ := s.selectionRand(0)
+	warmup := selectWithCooldown(rng, candidates, 1, s.recentlyServed(ctx, userID), domain.WeightingUniform)[0]
+	return &warmup, nil
+}
+
+// SelectSolvedWarmupProblem picks a single easy catalog problem the user has already
+// solved, to be served unscored at the start of a contest
+func (s *ProblemService) SelectSolvedWarmupProblem(ctx context.Context, userID uuid.UUID) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "ProblemService.SelectSolvedWarmupProblem")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	candidates, err := s.problemRepo.WithContext(ctx).FindSolvedByUserAndDifficulty(userID, domain.DifficultyEasy)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, domain.NewDomainError(domain.ErrNotEnoughProblems, "No solved easy problem yet for a warm-up. Try without one.")
+	}
+
+	rng, _ := s.selectionRand(0)
+	warmup := selectWithCooldown(rng, candidates, 1, s.recentlyServed(ctx, userID), domain.WeightingUniform)[0]
+	return &warmup, nil
+}
+
+// redistribute caps each bucket at what is available and moves the remainder to the
+// nearest difficulties with problems to spare, trying the harder neighbour first
+func redistribute(order []domain.Difficulty, target, available map[domain.Difficulty]int) map[domain.Difficulty]int {
+	result := make(map[domain.Difficulty]int, len(order))
+	for _, diff := range order {
+		result[diff] = min(target[diff], available[diff])
+	}
+
+	for i, diff := range order {
+		deficit := target[diff] - result[diff]
+		for distance := 1; deficit > 0 && distance < len(order); distance++ {
+			for _, j := range []int{i + distance, i - distance} {
+				if j < 0 || j >= len(order) || deficit == 0 {
+					continue
+				}
+				neighbour := order[j]
+				take := min(available[neighbour]-result[neighbour], deficit)
+				result[neighbour] += take
+				deficit -= take
+			}
 		}
 	}
 
-	// Sort by difficulty (for proper progression)
-	sort.Slice(selectedProblems, func(i, j int) bool {
-		return selectedProblems[i].Difficulty.Weight() < selectedProblems[j].Difficulty.Weight()
-	})
+	return result
+}
 
-	s.logger.Info("Problems selected for contest",
-		zap.String("user_id", userID.String()),
-		zap.Int("count", len(selectedProblems)),
-	)
+// selectionWarning describes how the delivered mix deviates from the requested one, if at all
+func selectionWarning(count int, requested, delivered map[domain.Difficulty]int) *domain.ContestWarning {
+	total := 0
+	adjusted := false
+	for diff, n := range delivered {
+		total += n
+		if requested[diff] != n {
+			adjusted = true
+		}
+	}
 
-	return selectedProblems, nil
+	switch {
+	case total < count:
+		return &domain.ContestWarning{
+			Code:      domain.WarningNotEnoughProblems,
+			Message:   fmt.Sprintf("Only %d of %d requested problems are available", total, count),
+			Requested: requested,
+			Delivered: delivered,
+		}
+	case adjusted:
+		return &domain.ContestWarning{
+			Code:      domain.WarningDifficultyMixAdjusted,
+			Message:   "Some difficulties ran out of problems; the mix was rebalanced",
+			Requested: requested,
+			Delivered: delivered,
+		}
+	default:
+		return nil
+	}
 }
 
 // calculateDistribution determines how many problems of each difficulty to select
@@ -235,21 +942,291 @@ func (s *ProblemService) calculateDistribution(count int) map[domain.Difficulty]
 		distribution[domain.DifficultyHard] = count - distribution[domain.DifficultyEasy] - distribution[domain.DifficultyMedium]
 	}
 
-	// Ensure at least one problem per difficulty if count allows
-	if count >= 3 {
-		for _, diff := range []domain.Difficulty{domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard} {
-			if distribution[diff] == 0 {
-				distribution[diff] = 1
+	ensureEveryDifficulty(distribution, count)
+	return distribution
+}
+
+// ensureEveryDifficulty gives each difficulty at least one problem if count
+// allows, taking the slot from the largest bucket so the total still matches count
+func ensureEveryDifficulty(distribution map[domain.Difficulty]int, count int) {
+	if count < 3 {
+		return
+	}
+	difficulties := []domain.Difficulty{domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard}
+	for _, diff := range difficulties {
+		if distribution[diff] > 0 {
+			continue
+		}
+		largest := difficulties[0]
+		for _, d := range difficulties[1:] {
+			if distribution[d] > distribution[largest] {
+				largest = d
 			}
 		}
+		distribution[largest]--
+		distribution[diff] = 1
 	}
+}
 
-	return distribution
+// TuneDistribution returns the default mix of a contest of count problems,
+// tuned by the user's recent difficulty ratings of contests of its profile.
+// The mean rating sets a factor from -1 (all "much too hard") to 1 (all "much
+// too easy"); a positive factor moves part of the medium problems to hard and
+// of the easy ones to medium, a negative one the reverse.
+func (s *ProblemService) TuneDistribution(count int, ratings []int) *domain.DistributionTuning {
+	base := s.calculateDistribution(count)
+	tuning := &domain.DistributionTuning{
+		Profile: domain.DistributionProfileFor(count),
+		Ratings: len(ratings),
+		Base:    base,
+		Tuned:   base,
+	}
+	if len(ratings) < tuningMinRatings {
+		return tuning
+	}
+
+	sum := 0
+	for _, r := range ratings {
+		sum += r
+	}
+	mean := float64(sum) / float64(len(ratings))
+	factor := (domain.RatingJustRight - mean) / (domain.RatingJustRight - domain.RatingMuchTooEasy)
+	tuning.MeanRating = math.Round(mean*100) / 100
+	tuning.Factor = math.Round(factor*100) / 100
+
+	easy, medium, hard := domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard
+	steps := [][2]domain.Difficulty{{medium, hard}, {easy, medium}}
+	if factor < 0 {
+		steps = [][2]domain.Difficulty{{medium, easy}, {hard, medium}}
+	}
+	tuned := maps.Clone(base)
+	for _, step := range steps {
+		from, to := step[0], step[1]
+		moved := min(int(math.Round(float64(base[from])*math.Abs(factor)*tuningMaxShift)), tuned[from])
+		tuned[from] -= moved
+		tuned[to] += moved
+	}
+	ensureEveryDifficulty(tuned, count)
+	tuning.Tuned = tuned
+	return tuning
+}
+
+// adaptDistribution shifts the progressive mix toward the user's recent solve rate,
+// weighted by confidence: strong users trade an easy for a medium and a medium for
+// a hard, struggling users the reverse. Users without a finished contest keep the
+// progressive mix.
+func (s *ProblemService) adaptDistribution(ctx context.Context, userID uuid.UUID, distribution map[domain.Difficulty]int) map[domain.Difficulty]int {
+	served, solved, err := s.problemRepo.WithContext(ctx).FindRecentSolveCounts(userID, adaptiveWindowContests)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to fetch recent solve rate, using the progressive mix",
+			zap.Error(err),
+		)
+		return distribution
+	}
+	if served == 0 {
+		return distribution
+	}
+
+	rate := s.weightedSolves(ctx, userID, solved) / float64(served)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Float64("selection.recent_solve_rate", rate))
+
+	order := []domain.Difficulty{domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard}
+	adapted := make(map[domain.Difficulty]int, len(order))
+	for _, diff := range order {
+		adapted[diff] = distribution[diff]
+	}
+	switch {
+	case rate >= adaptiveHarderRate:
+		for i := len(order) - 2; i >= 0; i-- {
+			if adapted[order[i]] > 0 {
+				adapted[order[i]]--
+				adapted[order[i+1]]++
+			}
+		}
+	case rate < adaptiveEasierRate:
+		for i := 1; i < len(order); i++ {
+			if adapted[order[i]] > 0 {
+				adapted[order[i]]--
+				adapted[order[i-1]]++
+			}
+		}
+	}
+	return adapted
+}
+
+// weightedSolves weights the recent solves by the confidence the user rated
+// them with, so shaky solves count less toward a harder mix. Failures are
+// logged and leave every solve at full weight.
+func (s *ProblemService) weightedSolves(ctx context.Context, userID uuid.UUID, solved int64) float64 {
+	byConfidence, err := s.problemRepo.WithContext(ctx).FindRecentSolveConfidence(userID, adaptiveWindowContests)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to fetch recent solve confidence, weighting solves equally",
+			zap.Error(err),
+		)
+		return float64(solved)
+	}
+
+	weighted := float64(solved)
+	for confidence, count := range byConfidence {
+		weighted += float64(count) * (domain.ConfidenceWeight(confidence) - 1)
+	}
+	return weighted
+}
+
+// recentlyServed returns the set of problems served in the user's cooldown window.
+// Failures are logged and treated as an empty window so selection still succeeds.
+func (s *ProblemService) recentlyServed(ctx context.Context, userID uuid.UUID) map[uuid.UUID]struct{} {
+	recent := make(map[uuid.UUID]struct{})
+	if s.config.ProblemCooldownContests <= 0 {
+		return recent
+	}
+
+	ids, err := s.problemRepo.WithContext(ctx).FindRecentlyServedIDs(userID, s.config.ProblemCooldownContests)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to fetch recently served problems",
+			zap.Error(err),
+		)
+		return recent
+	}
+
+	for _, id := range ids {
+		recent[id] = struct{}{}
+	}
+	return recent
+}
+
+// selectionRand returns a generator of its own for one selection or shuffle,
+// seeded by seed, or by a newly drawn seed when it is 0, and the seed used
+func (s *ProblemService) selectionRand(seed int64) (*rand.Rand, int64) {
+	for seed == 0 {
+		s.seedsMu.Lock()
+		seed = s.seeds.Int63()
+		s.seedsMu.Unlock()
+	}
+	return rand.New(s.newSource(seed)), seed
+}
+
+// selectWithCooldown randomly selects n problems, drawing from recently served
+// problems only when there are not enough fresh ones
+func selectWithCooldown(rng *rand.Rand, problems []domain.Problem, n int, recent map[uuid.UUID]struct{}, weighting domain.SelectionWeighting) []domain.Problem {
+	pick := randomSelect
+	if weighting == domain.WeightingImportance {
+		pick = weightedSelect
+	}
+
+	if len(recent) == 0 {
+		return pick(rng, problems, n)
+	}
+
+	var fresh, cooling []domain.Problem
+	for _, p := range problems {
+		if _, ok := recent[p.ID]; ok {
+			cooling = append(cooling, p)
+		} else {
+			fresh = append(fresh, p)
+		}
+	}
+
+	if len(fresh) >= n {
+		return pick(rng, fresh, n)
+	}
+	return append(fresh, pick(rng, cooling, n-len(fresh))...)
+}
+
+// onlyIDs returns the problems whose IDs are in the included set
+func onlyIDs(problems []domain.Problem, included map[uuid.UUID]struct{}) []domain.Problem {
+	kept := make([]domain.Problem, 0, len(problems))
+	for _, p := range problems {
+		if _, ok := included[p.ID]; ok {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// withoutIDs returns the problems whose IDs are not in the excluded set
+func withoutIDs(problems []domain.Problem, excluded map[uuid.UUID]struct{}) []domain.Problem {
+	if len(excluded) == 0 {
+		return problems
+	}
+	kept := make([]domain.Problem, 0, len(problems))
+	for _, p := range problems {
+		if _, ok := excluded[p.ID]; !ok {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// OrderProblems arranges selected problems for presentation in a contest.
+// problems must already be sorted by ascending difficulty.
+func (s *ProblemService) OrderProblems(problems []domain.Problem, ordering domain.ContestOrdering) []domain.Problem {
+	ordered := make([]domain.Problem, len(problems))
+	copy(ordered, problems)
+
+	switch ordering {
+	case domain.OrderingDescending:
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	case domain.OrderingShuffled:
+		rng, _ := s.selectionRand(0)
+		rng.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	case domain.OrderingInterleaved:
+		buckets := make(map[domain.Difficulty][]domain.Problem)
+		for _, p := range problems {
+			buckets[p.Difficulty] = append(buckets[p.Difficulty], p)
+		}
+		ordered = ordered[:0]
+		for remaining := true; remaining; {
+			remaining = false
+			for _, diff := range []domain.Difficulty{domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard} {
+				if len(buckets[diff]) > 0 {
+					ordered = append(ordered, buckets[diff][0])
+					buckets[diff] = buckets[diff][1:]
+					remaining = true
+				}
+			}
+		}
+	}
+
+	return ordered
+}
+
+// weightedSelect randomly selects n problems without replacement, each draw
+// proportional to the problem's importance (Efraimidis-Spirakis: keep the n
+// largest keys u^(1/w) for uniform u)
+func weightedSelect(rng *rand.Rand, problems []domain.Problem, n int) []domain.Problem {
+	if n >= len(problems) {
+		return problems
+	}
+
+	type keyed struct {
+		problem domain.Problem
+		key     float64
+	}
+	candidates := make([]keyed, len(problems))
+	for i, p := range problems {
+		candidates[i] = keyed{
+			problem: p,
+			key:     math.Pow(rng.Float64(), 1/float64(p.SelectionWeight())),
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].key > candidates[j].key
+	})
+
+	selected := make([]domain.Problem, n)
+	for i := range selected {
+		selected[i] = candidates[i].problem
+	}
+	return selected
 }
 
 // randomSelect randomly selects n problems from the given slice
-// Uses Fisher-Yates shuffle (thread-safe)
-func (s *ProblemService) randomSelect(problems []domain.Problem, n int) []domain.Problem {
+// Uses Fisher-Yates shuffle
+func randomSelect(rng *rand.Rand, problems []domain.Problem, n int) []domain.Problem {
 	if n >= len(problems) {
 		return problems
 	}
@@ -259,12 +1236,10 @@ func (s *ProblemService) randomSelect(problems []domain.Problem, n int) []domain
 	copy(shuffled, problems)
 
 	// Fisher-Yates shuffle (partial, only need first n elements)
-	s.rngMu.Lock()
 	for i := 0; i < n; i++ {
-		j := i + s.rng.Intn(len(shuffled)-i)
+		j := i + rng.Intn(len(shuffled)-i)
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	}
-	s.rngMu.Unlock()
 
 	return shuffled[:n]
 }
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+func TestCalculateDistribution(t *testing.T) {
+	tests := []struct {
+		count, easy, medium, hard int
+	}{
+		{1, 1, 0, 0},
+		{2, 1, 1, 0},
+		{3, 1, 1, 1},
+		{4, 1, 2, 1},
+		{5, 2, 2, 1},
+		{6, 1, 2, 3},
+		{7, 2, 2, 3},
+		{8, 2, 3, 3},
+		{9, 2, 3, 4},
+		{10, 3, 4, 3},
+		{11, 2, 5, 4},
+		{12, 3, 6, 3},
+		{13, 3, 6, 4},
+		{14, 3, 7, 4},
+		{15, 3, 7, 5},
+		{16, 4, 8, 4},
+		{17, 4, 8, 5},
+		{18, 4, 9, 5},
+		{19, 4, 9, 6},
+		{20, 5, 10, 5},
+	}
+	s := &ProblemService{}
+	for _, tt := range tests {
+		got := s.calculateDistribution(tt.count)
+		easy, medium, hard := got[domain.DifficultyEasy], got[domain.DifficultyMedium], got[domain.DifficultyHard]
+		if total := easy + medium + hard; total != tt.count {
+			t.Errorf("calculateDistribution(%d) hands out %d problems: %v", tt.count, total, got)
+		}
+		if tt.count >= 3 && (easy == 0 || medium == 0 || hard == 0) {
+			t.Errorf("calculateDistribution(%d) leaves out a difficulty: %v", tt.count, got)
+		}
+		if easy != tt.easy || medium != tt.medium || hard != tt.hard {
+			t.Errorf("calculateDistribution(%d) = %d/%d/%d, want %d/%d/%d", tt.count, easy, medium, hard, tt.easy, tt.medium, tt.hard)
+		}
+	}
+}
+
+// poolRepository serves the same unsolved pool to every selection
+type poolRepository struct {
+	domain.ProblemRepository
+	pool []domain.Problem
+}
+
+func (r *poolRepository) WithContext(context.Context) domain.ProblemRepository { return r }
+
+func (r *poolRepository) FindUnsolvedByUserAndDifficulty(_ uuid.UUID, difficulty domain.Difficulty, _ bool) ([]domain.Problem, error) {
+	var problems []domain.Problem
+	for _, p := range r.pool {
+		if p.Difficulty == difficulty {
+			problems = append(problems, p)
+		}
+	}
+	return problems, nil
+}
+
+func TestSelectProblemsForContestSeed(t *testing.T) {
+	var pool []domain.Problem
+	for i := 0; i < 60; i++ {
+		difficulty := domain.AllDifficulties[i%len(domain.AllDifficulties)]
+		pool = append(pool, domain.Problem{ID: uuid.New(), Difficulty: difficulty, OrderIndex: i, Importance: i})
+	}
+	newService := func() *ProblemService {
+		return NewProblemService(&poolRepository{pool: pool}, nil, &infrastructure.ContestConfig{}, &infrastructure.ProblemConfig{},
+			nil, noop.NewTracerProvider().Tracer(""), zap.NewNop())
+	}
+	selectIDs := func(s *ProblemService, opts domain.SelectionOptions) []uuid.UUID {
+		t.Helper()
+		problems, _, err := s.SelectProblemsForContest(context.Background(), uuid.New(), 10, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]uuid.UUID, len(problems))
+		for i, p := range problems {
+			ids[i] = p.ID
+		}
+		return ids
+	}
+
+	for _, weighting := range []domain.SelectionWeighting{domain.WeightingUniform, domain.WeightingImportance} {
+		t.Run(string(weighting), func(t *testing.T) {
+			// A service that already drew other selections draws the same with the seed
+			first, second := newService(), newService()
+			selectIDs(second, domain.SelectionOptions{Weighting: weighting})
+			opts := domain.SelectionOptions{Weighting: weighting, Seed: 42}
+			want := selectIDs(first, opts)
+			if got := selectIDs(second, opts); !slices.Equal(got, want) {
+				t.Fatalf("seed 42 selected %v, then %v", want, got)
+			}
+
+			opts.Seed = 43
+			if got := selectIDs(first, opts); slices.Equal(got, want) {
+				t.Fatalf("seeds 42 and 43 both selected %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// maxContestEvents caps the events stored per contest so a misbehaving client
+// cannot grow the table without bound
+const maxContestEvents = 1000
+
+// ProctoringService records the focus and tab switch events clients report
+// during organization assignment contests and summarizes them for instructors
+type ProctoringService struct {
+	eventRepo   domain.ContestEventRepository
+	orgRepo     domain.OrgRepository
+	contestRepo domain.ContestRepository
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewProctoringService creates a new proctoring service
+func NewProctoringService(
+	eventRepo domain.ContestEventRepository,
+	orgRepo domain.OrgRepository,
+	contestRepo domain.ContestRepository,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *ProctoringService {
+	return &ProctoringService{
+		eventRepo:   eventRepo,
+		orgRepo:     orgRepo,
+		contestRepo: contestRepo,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// RecordEvents stores a batch of events for the user's running assignment
+// contest and returns how many were stored. Timestamps are kept within the
+// contest's lifetime, and events past the per-contest cap are dropped.
+func (s *ProctoringService) RecordEvents(ctx context.Context, userID, contestID uuid.UUID, inputs []domain.ContestEventInput) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "ProctoringService.RecordEvents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.Int("events.count", len(inputs)),
+	)
+
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return 0, err
+	}
+	if contest.UserID != userID {
+		return 0, domain.ErrForbidden
+	}
+	if contest.Status != domain.ContestStatusActive || contest.IsExpired() {
+		return 0, domain.ErrContestNotActive
+	}
+	if _, err := s.orgRepo.WithContext(ctx).FindContestLink(contestID); err != nil {
+		if errors.Is(err, domain.ErrAssignmentNotFound) {
+			return 0, domain.ErrContestNotProctored
+		}
+		return 0, err
+	}
+
+	stored, err := s.eventRepo.WithContext(ctx).Count(contestID)
+	if err != nil {
+		return 0, err
+	}
+	room := maxContestEvents - int(stored)
+	if room <= 0 {
+		return 0, nil
+	}
+	if len(inputs) > room {
+		inputs = inputs[:room]
+	}
+
+	now := time.Now()
+	events := make([]domain.ContestEvent, len(inputs))
+	for i, in := range inputs {
+		occurredAt := in.OccurredAt
+		if occurredAt.Before(contest.CreatedAt) {
+			occurredAt = contest.CreatedAt
+		}
+		if occurredAt.After(now) {
+			occurredAt = now
+		}
+		events[i] = domain.ContestEvent{ContestID: contestID, Type: in.Type, OccurredAt: occurredAt, ReceivedAt: now}
+	}
+	if err := s.eventRepo.WithContext(ctx).Create(events); err != nil {
+		return 0, err
+	}
+
+	logFor(ctx, s.logger).Debug("Contest events recorded",
+		zap.String("contest_id", contestID.String()),
+		zap.Int("count", len(events)),
+	)
+	return len(events), nil
+}
+
+// GetSummary returns the integrity summary of a student's assignment contest
+// for an instructor of the organization it was assigned in
+func (s *ProctoringService) GetSummary(ctx context.Context, userID, orgID, contestID uuid.UUID) (*domain.ProctoringSummary, error) {
+	ctx, span := s.tracer.Start(ctx, "ProctoringService.GetSummary")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("contest.id", contestID.String()),
+	)
+
+	member, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsInstructor() {
+		return nil, domain.ErrForbidden
+	}
+	link, err := s.orgRepo.WithContext(ctx).FindContestLink(contestID)
+	if err != nil && !errors.Is(err, domain.ErrAssignmentNotFound) {
+		return nil, err
+	}
+	// Contests of other organizations are not found through this one
+	if link == nil || link.Assignment.OrgID != orgID {
+		return nil, domain.ErrContestNotFound
+	}
+	contest, err := s.contestRepo.WithContext(ctx).FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.eventRepo.WithContext(ctx).FindByContest(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.ProctoringSummary{
+		ContestID:    contestID,
+		AssignmentID: link.AssignmentID,
+		UserID:       link.UserID,
+		Status:       contest.Status,
+		Truncated:    len(events) >= maxContestEvents,
+		Events:       events,
+	}
+	if summary.Events == nil {
+		summary.Events = []domain.ContestEvent{}
+	}
+
+	// A period away runs from a focus loss or hidden tab to the next return,
+	// or to the end of the contest when the student never came back
+	end := time.Now()
+	if contest.EndedAt != nil {
+		end = *contest.EndedAt
+	} else if contest.Status == domain.ContestStatusActive && end.After(contest.EndTime()) {
+		end = contest.EndTime()
+	}
+	var awaySince *time.Time
+	addAway := func(until time.Time) {
+		seconds := int(until.Sub(*awaySince).Seconds())
+		if seconds > 0 {
+			summary.AwaySeconds += seconds
+			summary.LongestAwaySeconds = max(summary.LongestAwaySeconds, seconds)
+		}
+		awaySince = nil
+	}
+	for i := range events {
+		e := &events[i]
+		switch e.Type {
+		case domain.ProctorFocusLost:
+			summary.FocusLosses++
+		case domain.ProctorTabHidden:
+			summary.TabSwitches++
+		}
+		switch {
+		case e.Type.IsAway() && awaySince == nil:
+			awaySince = &e.OccurredAt
+		case !e.Type.IsAway() && awaySince != nil:
+			addAway(e.OccurredAt)
+		}
+	}
+	if awaySince != nil {
+		addAway(end)
+	}
+	return summary, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// ProgressBackfillWorker rebuilds the user progress summaries on startup and then
+// periodically, so summaries of rows written around the repositories (seeding,
+// manual fixes) converge again
+type ProgressBackfillWorker struct {
+	progressRepo domain.UserProgressRepository
+	config       *infrastructure.ProgressConfig
+	locks        *infrastructure.JobLocks
+	logger       *zap.Logger
+	wg           sync.WaitGroup
+	cancel       context.CancelFunc
+}
+
+// NewProgressBackfillWorker creates a new progress backfill worker
+func NewProgressBackfillWorker(
+	progressRepo domain.UserProgressRepository,
+	config *infrastructure.ProgressConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *ProgressBackfillWorker {
+	return &ProgressBackfillWorker{
+		progressRepo: progressRepo,
+		config:       config,
+		locks:        locks,
+		logger:       logger,
+	}
+}
+
+// Start runs a first backfill and launches the periodic loop in the background.
+// A zero interval only runs the startup backfill.
+func (w *ProgressBackfillWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		w.locks.Run(ctx, "progress_backfill", w.Backfill)
+		if w.config.BackfillInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(w.config.BackfillInterval)
+		defer ticker.Stop()
+
+		w.logger.Info("Progress backfill worker started",
+			zap.Duration("interval", w.config.BackfillInterval),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.locks.RunEvery(ctx, "progress_backfill", w.config.BackfillInterval, w.Backfill)
+			}
+		}
+	}()
+}
+
+// Stop stops the backfill loop and waits for an in-progress backfill to finish,
+// or until ctx is done
+func (w *ProgressBackfillWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Progress backfill worker stopped")
+	return nil
+}
+
+// Backfill recomputes every user's progress summary
+func (w *ProgressBackfillWorker) Backfill() {
+	start := time.Now()
+	rows, err := w.progressRepo.Rebuild()
+	if err != nil {
+		w.logger.Error("Failed to rebuild progress summaries", zap.Error(err))
+		return
+	}
+
+	w.logger.Info("Progress summaries rebuilt",
+		zap.Int64("users", rows),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// publicStatsSnapshot is one computation of every public figure
+type publicStatsSnapshot struct {
+	stats    domain.PublicStats
+	problems []domain.PopularProblem // The MaxPublicProblems most attempted
+}
+
+// PublicStatsService serves anonymized platform statistics to anonymous callers.
+// The figures are computed together and cached for the configured TTL, so a
+// busy landing page costs a few aggregate queries per TTL.
+type PublicStatsService struct {
+	statsRepo domain.PublicStatsRepository
+	tracer    trace.Tracer
+	logger    *zap.Logger
+
+	// Snapshots are kept per tenant, since each only counts its tenant's users
+	ttl       time.Duration
+	group     singleflight.Group
+	mu        sync.Mutex
+	snapshots map[uuid.UUID]*publicStatsSnapshot
+	expiry    map[uuid.UUID]time.Time
+}
+
+// NewPublicStatsService creates a new public stats service
+func NewPublicStatsService(
+	statsRepo domain.PublicStatsRepository,
+	config *infrastructure.PublicStatsConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *PublicStatsService {
+	return &PublicStatsService{
+		statsRepo: statsRepo,
+		tracer:    tracer,
+		logger:    logger,
+		ttl:       config.CacheTTL,
+		snapshots: make(map[uuid.UUID]*publicStatsSnapshot),
+		expiry:    make(map[uuid.UUID]time.Time),
+	}
+}
+
+// CacheTTL is how long a computed snapshot is served, for HTTP caching headers
+func (s *PublicStatsService) CacheTTL() time.Duration {
+	return s.ttl
+}
+
+// GetStats returns the platform overview
+func (s *PublicStatsService) GetStats(ctx context.Context) (*domain.PublicStats, error) {
+	ctx, span := s.tracer.Start(ctx, "PublicStatsService.GetStats")
+	defer span.End()
+
+	snapshot, err := s.getSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := snapshot.stats
+	return &stats, nil
+}
+
+// GetProblemStats returns up to limit of the most attempted catalog problems
+func (s *PublicStatsService) GetProblemStats(ctx context.Context, limit int) (*domain.PublicProblemStats, error) {
+	ctx, span := s.tracer.Start(ctx, "PublicStatsService.GetProblemStats")
+	defer span.End()
+
+	snapshot, err := s.getSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	problems := snapshot.problems[:min(limit, len(snapshot.problems))]
+	return &domain.PublicProblemStats{
+		Problems:   problems,
+		Count:      len(problems),
+		ComputedAt: snapshot.stats.ComputedAt,
+	}, nil
+}
+
+// getSnapshot returns the cached snapshot, computing it once for concurrent
+// callers when it has expired. The snapshot is shared and must not be modified.
+func (s *PublicStatsService) getSnapshot(ctx context.Context) (*publicStatsSnapshot, error) {
+	span := trace.SpanFromContext(ctx)
+
+	tenantID := cacheTenant(ctx)
+	s.mu.Lock()
+	snapshot, expiry := s.snapshots[tenantID], s.expiry[tenantID]
+	s.mu.Unlock()
+	if snapshot != nil && time.Now().Before(expiry) {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return snapshot, nil
+	}
+
+	// The computation outlives a caller that gives up, since other callers may be waiting on it
+	computeCtx := context.WithoutCancel(ctx)
+	result := s.group.DoChan("public_stats:"+tenantID.String(), func() (interface{}, error) {
+		snapshot, err := s.compute(computeCtx)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.snapshots[tenantID], s.expiry[tenantID] = snapshot, time.Now().Add(s.ttl)
+		s.mu.Unlock()
+		return snapshot, nil
+	})
+
+	select {
+	case r := <-result:
+		span.SetAttributes(attribute.Bool("cache.hit", false), attribute.Bool("cache.shared", r.Shared))
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*publicStatsSnapshot), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// compute runs the aggregate queries behind the public figures
+func (s *PublicStatsService) compute(ctx context.Context) (*publicStatsSnapshot, error) {
+	repo := s.statsRepo.WithContext(ctx)
+
+	completed, abandoned, err := repo.CountFinishedContests()
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := repo.CountContestSizes(domain.PublicStatsMinGroup)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := repo.FindMostAttempted(domain.MaxPublicProblems, domain.PublicStatsMinGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := domain.PublicStats{
+		ContestsFinished: completed + abandoned,
+		ContestSizes:     sizes,
+		ComputedAt:       time.Now().UTC(),
+	}
+	if stats.ContestsFinished >= domain.PublicStatsMinGroup {
+		rate := float64(completed) / float64(stats.ContestsFinished)
+		stats.CompletionRate = &rate
+	}
+	if stats.ContestSizes == nil {
+		stats.ContestSizes = []domain.ContestSizeCount{}
+	}
+	if problems == nil {
+		problems = []domain.PopularProblem{}
+	}
+	for i := range problems {
+		problems[i].SolveRate = float64(problems[i].Solvers) / float64(problems[i].Users)
+	}
+
+	logFor(ctx, s.logger).Debug("Public stats computed",
+		zap.Int64("contests_finished", stats.ContestsFinished),
+		zap.Int("problems", len(problems)),
+	)
+	return &publicStatsSnapshot{stats: stats, problems: problems}, nil
+}
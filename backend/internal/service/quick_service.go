@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// maxQuickErrorLength bounds the error stored in the quick command audit trail
+const maxQuickErrorLength = 255
+
+// QuickService runs the quick command DSL of keyboard-driven clients on top of
+// the contest operations and keeps an audit trail of every command
+type QuickService struct {
+	quickRepo      domain.QuickCommandRepository
+	contestService *ContestService
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewQuickService creates a new quick command service
+func NewQuickService(
+	quickRepo domain.QuickCommandRepository,
+	contestService *ContestService,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *QuickService {
+	return &QuickService{
+		quickRepo:      quickRepo,
+		contestService: contestService,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// Execute parses and runs a quick command, recording it in the audit trail
+// whether or not it succeeds
+func (s *QuickService) Execute(ctx context.Context, userID uuid.UUID, input string) (*domain.QuickResult, error) {
+	ctx, span := s.tracer.Start(ctx, "QuickService.Execute")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	entry := &domain.QuickCommandLog{UserID: userID, Input: strings.TrimSpace(input)}
+	result, err := s.execute(ctx, userID, entry)
+	if err != nil {
+		entry.Error = err.Error()
+		if len(entry.Error) > maxQuickErrorLength {
+			entry.Error = entry.Error[:maxQuickErrorLength]
+		}
+	}
+	span.SetAttributes(attribute.String("quick.verb", string(entry.Command.Verb)))
+
+	if auditErr := s.quickRepo.WithContext(ctx).Create(entry); auditErr != nil {
+		logFor(ctx, s.logger).Error("Failed to record quick command", zap.Error(auditErr))
+	}
+	return result, err
+}
+
+// execute runs the command, filling in the parsed command and the contest it
+// acts on as soon as they are known
+func (s *QuickService) execute(ctx context.Context, userID uuid.UUID, entry *domain.QuickCommandLog) (*domain.QuickResult, error) {
+	cmd, err := domain.ParseQuickCommand(entry.Input)
+	if err != nil {
+		return nil, domain.NewValidationError("Invalid quick command", fmt.Sprintf("%v; usage: %s", err, domain.QuickUsage))
+	}
+	entry.Command = cmd
+
+	if cmd.Verb == domain.QuickStart {
+		req := &domain.CreateContestRequest{ProblemCount: cmd.ProblemCount, DurationMinutes: cmd.DurationMinutes}
+		contest, err := s.contestService.CreateContest(ctx, userID, req)
+		if err != nil {
+			return nil, err
+		}
+		entry.ContestID = &contest.ID
+		message := fmt.Sprintf("Started %d problems in %d minutes", len(contest.ScoredProblems()), contest.DurationMinutes)
+		return quickResult(cmd, message, contest), nil
+	}
+
+	contest, err := s.contestService.GetActiveContest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if contest == nil {
+		return nil, domain.ErrNoActiveContest
+	}
+	entry.ContestID = &contest.ID
+
+	var message string
+	switch cmd.Verb {
+	case domain.QuickStatus:
+		response := contest.ToResponse()
+		message = fmt.Sprintf("%d of %d solved, %d:%02d left",
+			contest.CompletedCount(), len(contest.ScoredProblems()), response.TimeRemaining/60, response.TimeRemaining%60)
+		return quickResult(cmd, message, contest), nil
+
+	case domain.QuickGo, domain.QuickDone, domain.QuickUndo:
+		cp, err := problemNumber(contest, cmd.Problem)
+		if err != nil {
+			return nil, err
+		}
+		switch cmd.Verb {
+		case domain.QuickGo:
+			_, err = s.contestService.StartProblem(ctx, userID, contest.ID, cp.ProblemID)
+			message = fmt.Sprintf("Working on %d: %s", cp.Order, cp.Problem.Title)
+		case domain.QuickDone:
+			err = s.contestService.MarkProblemComplete(ctx, userID, contest.ID, cp.ProblemID, true, nil)
+			message = fmt.Sprintf("Solved %d: %s", cp.Order, cp.Problem.Title)
+		default:
+			err = s.contestService.MarkProblemComplete(ctx, userID, contest.ID, cp.ProblemID, false, nil)
+			message = fmt.Sprintf("Reopened %d: %s", cp.Order, cp.Problem.Title)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+	case domain.QuickSkip:
+		next, err := nextOpenProblem(contest)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.contestService.StartProblem(ctx, userID, contest.ID, next.ProblemID); err != nil {
+			return nil, err
+		}
+		message = fmt.Sprintf("Skipped to %d: %s", next.Order, next.Problem.Title)
+
+	case domain.QuickFinish:
+		if err := s.contestService.CompleteContest(ctx, userID, contest.ID); err != nil {
+			return nil, err
+		}
+		message = fmt.Sprintf("Finished with %d of %d solved", contest.CompletedCount(), len(contest.ScoredProblems()))
+
+	case domain.QuickAbandon:
+		if err := s.contestService.AbandonContest(ctx, userID, contest.ID); err != nil {
+			return nil, err
+		}
+		message = "Contest abandoned"
+	}
+
+	// Reload so the result shows the contest as the command left it
+	contest, err = s.contestService.GetContestByID(ctx, contest.ID)
+	if err != nil {
+		return nil, err
+	}
+	logFor(ctx, s.logger).Info("Quick command executed",
+		zap.String("verb", string(cmd.Verb)),
+		zap.String("contest_id", contest.ID.String()),
+	)
+	return quickResult(cmd, message, contest), nil
+}
+
+// GetHistory lists the user's latest quick commands, newest first
+func (s *QuickService) GetHistory(ctx context.Context, userID uuid.UUID, limit int) (*domain.QuickHistory, error) {
+	ctx, span := s.tracer.Start(ctx, "QuickService.GetHistory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if limit == 0 {
+		limit = 20
+	}
+	commands, err := s.quickRepo.WithContext(ctx).FindByUserID(userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.QuickHistory{Commands: commands}, nil
+}
+
+// quickResult builds the result of a command that leaves the contest as given
+func quickResult(cmd domain.QuickCommand, message string, contest *domain.Contest) *domain.QuickResult {
+	response := contest.ToResponse()
+	return &domain.QuickResult{Command: cmd, Message: message, Contest: &response}
+}
+
+// problemNumber finds the contest problem shown with the number n
+func problemNumber(contest *domain.Contest, n int) (*domain.ContestProblem, error) {
+	for i := range contest.ContestProblems {
+		if contest.ContestProblems[i].Order == n {
+			return &contest.ContestProblems[i], nil
+		}
+	}
+	return nil, domain.ErrProblemNotInContest
+}
+
+// nextOpenProblem returns the open problem after the one being timed, or after
+// the first open one when no timer runs, wrapping around to the first problem
+func nextOpenProblem(contest *domain.Contest) (*domain.ContestProblem, error) {
+	var open []*domain.ContestProblem
+	current := -1
+	for i := range contest.ContestProblems {
+		cp := &contest.ContestProblems[i]
+		if cp.IsCompleted || cp.IsWarmup {
+			continue
+		}
+		if cp.TimerStartedAt != nil {
+			current = len(open)
+		}
+		open = append(open, cp)
+	}
+	if current < 0 {
+		current = 0
+	}
+	if len(open) < 2 {
+		return nil, domain.ErrNothingToSkip
+	}
+	return open[(current+1)%len(open)], nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// quotaDay is the period of daily allowances, which reset at midnight UTC
+const quotaDay = 24 * time.Hour
+
+// QuotaService enforces plan quotas and reports remaining allowances
+type QuotaService struct {
+	quotaRepo   domain.QuotaRepository
+	userRepo    domain.UserRepository
+	contestRepo domain.ContestRepository
+	problemRepo domain.ProblemRepository
+	config      *infrastructure.QuotaConfig
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService(
+	quotaRepo domain.QuotaRepository,
+	userRepo domain.UserRepository,
+	contestRepo domain.ContestRepository,
+	problemRepo domain.ProblemRepository,
+	config *infrastructure.QuotaConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *QuotaService {
+	return &QuotaService{
+		quotaRepo:   quotaRepo,
+		userRepo:    userRepo,
+		contestRepo: contestRepo,
+		problemRepo: problemRepo,
+		config:      config,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// GetQuotas reports the user's plan and remaining allowances
+func (s *QuotaService) GetQuotas(ctx context.Context, userID uuid.UUID) (*domain.QuotaStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "QuotaService.GetQuotas")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	override, err := s.quotaRepo.WithContext(ctx).FindOverride(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	contests, err := s.contestUsage(ctx, user, override)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := s.customProblemUsage(ctx, user, override)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.QuotaStatus{
+		UserID: user.ID,
+		Plan:   s.plan(user),
+		Quotas: []domain.QuotaUsage{contests, custom},
+	}
+	if override != nil {
+		status.Reason = override.Reason
+	}
+	return status, nil
+}
+
+// CheckContestQuota fails with ErrQuotaExceeded once the user has created
+// today's allowance of contests
+func (s *QuotaService) CheckContestQuota(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "QuotaService.CheckContestQuota")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, override, err := s.find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	usage, err := s.contestUsage(ctx, user, override)
+	if err != nil {
+		return err
+	}
+	if !usage.Exhausted() {
+		return nil
+	}
+
+	logFor(ctx, s.logger).Info("Contest quota exceeded",
+		zap.String("plan", string(s.plan(user))),
+		zap.Int("limit", *usage.Limit),
+	)
+	return &domain.DomainError{
+		Err:     domain.ErrQuotaExceeded,
+		Message: fmt.Sprintf("Your %s plan allows %d contests per day. The allowance resets at midnight UTC.", s.plan(user), *usage.Limit),
+		Details: usage,
+	}
+}
+
+// CheckCustomProblemQuota fails with ErrTooManyCustomProblems once the user
+// keeps as many custom problems as their plan allows
+func (s *QuotaService) CheckCustomProblemQuota(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "QuotaService.CheckCustomProblemQuota")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, override, err := s.find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	usage, err := s.customProblemUsage(ctx, user, override)
+	if err != nil {
+		return err
+	}
+	if !usage.Exhausted() {
+		return nil
+	}
+
+	return &domain.DomainError{
+		Err:     domain.ErrTooManyCustomProblems,
+		Message: fmt.Sprintf("Your %s plan allows %d custom problems. Delete a custom problem first.", s.plan(user), *usage.Limit),
+		Details: usage,
+	}
+}
+
+// SetOverride sets the user's plan and replaces their quota override (admin only).
+// A request without limits only changes the plan and drops any override.
+func (s *QuotaService) SetOverride(ctx context.Context, adminID, userID uuid.UUID, req *domain.SetQuotaOverrideRequest) (*domain.QuotaStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "QuotaService.SetOverride")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("plan", string(req.Plan)),
+	)
+
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Plan != req.Plan {
+		user.Plan = req.Plan
+		if err := s.userRepo.WithContext(ctx).Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ContestsPerDay == nil && req.CustomProblems == nil {
+		err = s.quotaRepo.WithContext(ctx).DeleteOverride(userID)
+	} else {
+		err = s.quotaRepo.WithContext(ctx).SaveOverride(&domain.QuotaOverride{
+			UserID:         userID,
+			ContestsPerDay: req.ContestsPerDay,
+			CustomProblems: req.CustomProblems,
+			Reason:         req.Reason,
+			UpdatedBy:      adminID,
+			UpdatedAt:      time.Now(),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Quota override set",
+		zap.String("admin_id", adminID.String()),
+		zap.String("target_user_id", userID.String()),
+		zap.String("plan", string(req.Plan)),
+	)
+	return s.GetQuotas(ctx, userID)
+}
+
+// ClearOverride drops the user's quota override so their plan's limits apply again (admin only)
+func (s *QuotaService) ClearOverride(ctx context.Context, adminID, userID uuid.UUID) (*domain.QuotaStatus, error) {
+	ctx, span := s.tracer.Start(ctx, "QuotaService.ClearOverride")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if _, err := s.userRepo.WithContext(ctx).FindByID(userID); err != nil {
+		return nil, err
+	}
+	if err := s.quotaRepo.WithContext(ctx).DeleteOverride(userID); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Quota override cleared",
+		zap.String("admin_id", adminID.String()),
+		zap.String("target_user_id", userID.String()),
+	)
+	return s.GetQuotas(ctx, userID)
+}
+
+// find loads the user and their quota override, if any
+func (s *QuotaService) find(ctx context.Context, userID uuid.UUID) (*domain.User, *domain.QuotaOverride, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	override, err := s.quotaRepo.WithContext(ctx).FindOverride(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, override, nil
+}
+
+// plan returns the plan the user is currently entitled to, which drops back to
+// free once a paid subscription has lapsed past the grace period
+func (s *QuotaService) plan(user *domain.User) domain.Plan {
+	return user.EffectivePlan(time.Now(), s.config.PremiumGracePeriod)
+}
+
+// limits returns the user's plan limits with their override applied
+func (s *QuotaService) limits(user *domain.User, override *domain.QuotaOverride) domain.PlanQuotas {
+	quotas := domain.PlanQuotas{
+		ContestsPerDay: s.config.FreeContestsPerDay,
+		CustomProblems: s.config.FreeCustomProblems,
+	}
+	if s.plan(user) == domain.PlanPremium {
+		quotas = domain.PlanQuotas{
+			ContestsPerDay: s.config.PremiumContestsPerDay,
+			CustomProblems: s.config.PremiumCustomProblems,
+		}
+	}
+	return override.Apply(quotas)
+}
+
+// contestUsage counts the contests the user created today against their daily allowance
+func (s *QuotaService) contestUsage(ctx context.Context, user *domain.User, override *domain.QuotaOverride) (domain.QuotaUsage, error) {
+	today := time.Now().UTC().Truncate(quotaDay)
+	count, err := s.contestRepo.WithContext(ctx).CountCreatedSince(user.ID, today)
+	if err != nil {
+		return domain.QuotaUsage{}, err
+	}
+	resetsAt := today.Add(quotaDay)
+	overridden := override != nil && override.ContestsPerDay != nil
+	return domain.NewQuotaUsage(domain.QuotaContestsPerDay, s.limits(user, override).ContestsPerDay, int(count), &resetsAt, overridden), nil
+}
+
+// customProblemUsage counts the user's custom problems against their allowance
+func (s *QuotaService) customProblemUsage(ctx context.Context, user *domain.User, override *domain.QuotaOverride) (domain.QuotaUsage, error) {
+	count, err := s.problemRepo.WithContext(ctx).CountByOwner(user.ID)
+	if err != nil {
+		return domain.QuotaUsage{}, err
+	}
+	overridden := override != nil && override.CustomProblems != nil
+	return domain.NewQuotaUsage(domain.QuotaCustomProblems, s.limits(user, override).CustomProblems, int(count), nil, overridden), nil
+}
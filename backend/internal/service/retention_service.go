@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// RetentionService deletes data that has outlived its configured retention,
+// in batches so no transaction holds locks for long
+type RetentionService struct {
+	retentionRepo domain.RetentionRepository
+	config        *infrastructure.RetentionConfig
+	tracer        trace.Tracer
+	logger        *zap.Logger
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(
+	retentionRepo domain.RetentionRepository,
+	config *infrastructure.RetentionConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *RetentionService {
+	return &RetentionService{
+		retentionRepo: retentionRepo,
+		config:        config,
+		tracer:        tracer,
+		logger:        logger,
+	}
+}
+
+// cutoff returns the time before which the policy deletes rows, or false when
+// the policy keeps data forever
+func (s *RetentionService) cutoff(policy domain.RetentionPolicy, now time.Time) (time.Time, bool) {
+	switch policy {
+	case domain.RetentionAbandonedContests:
+		return now.AddDate(0, -s.config.AbandonedContestMonths, 0), s.config.AbandonedContestMonths > 0
+	case domain.RetentionQuickCommandLogs:
+		return now.AddDate(0, 0, -s.config.QuickCommandLogDays), s.config.QuickCommandLogDays > 0
+	case domain.RetentionBillingEvents:
+		return now.AddDate(0, 0, -s.config.BillingEventDays), s.config.BillingEventDays > 0
+	}
+	return time.Time{}, false
+}
+
+// Run applies every enabled policy and, unless dryRun is set, deletes what it
+// finds. A cancelled run stops between batches; what was deleted stays deleted.
+func (s *RetentionService) Run(ctx context.Context, dryRun bool) (*domain.RetentionReport, error) {
+	ctx, span := s.tracer.Start(ctx, "RetentionService.Run")
+	defer span.End()
+
+	span.SetAttributes(attribute.Bool("retention.dry_run", dryRun))
+
+	report := &domain.RetentionReport{
+		DryRun:    dryRun,
+		Results:   make([]domain.RetentionResult, 0, len(domain.RetentionPolicies)),
+		StartedAt: time.Now(),
+	}
+	batchSize := max(s.config.BatchSize, 1)
+
+	for _, policy := range domain.RetentionPolicies {
+		result := domain.RetentionResult{Policy: policy}
+		cutoff, enabled := s.cutoff(policy, report.StartedAt)
+		if !enabled {
+			report.Results = append(report.Results, result)
+			continue
+		}
+		result.Enabled = true
+		result.Cutoff = &cutoff
+
+		found, err := s.retentionRepo.WithContext(ctx).Count(policy, cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("count %s: %w", policy, err)
+		}
+		result.Found = found
+
+		for !dryRun && result.Purged < found {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			purged, err := s.retentionRepo.WithContext(ctx).Purge(policy, cutoff, batchSize)
+			if err != nil {
+				return nil, fmt.Errorf("purge %s: %w", policy, err)
+			}
+			result.Purged += purged
+			if purged < int64(batchSize) {
+				break
+			}
+		}
+
+		if found > 0 {
+			logFor(ctx, s.logger).Info("Retention policy applied",
+				zap.String("policy", string(policy)),
+				zap.Time("cutoff", cutoff),
+				zap.Int64("found", result.Found),
+				zap.Int64("purged", result.Purged),
+				zap.Bool("dry_run", dryRun),
+			)
+		}
+		report.Results = append(report.Results, result)
+	}
+	report.FinishedAt = time.Now()
+
+	logFor(ctx, s.logger).Info("Retention run finished",
+		zap.Bool("dry_run", dryRun),
+		zap.Duration("duration", report.FinishedAt.Sub(report.StartedAt)),
+	)
+	return report, nil
+}
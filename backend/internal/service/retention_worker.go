@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// RetentionWorker runs the retention policies on a schedule, on one instance
+// at a time. Overlapping runs would still be safe: each batch only deletes
+// rows that still qualify.
+type RetentionWorker struct {
+	retention *RetentionService
+	config    *infrastructure.RetentionConfig
+	locks     *infrastructure.JobLocks
+	logger    *zap.Logger
+	wg        sync.WaitGroup
+	cancel    context.CancelFunc
+}
+
+// NewRetentionWorker creates a new retention worker
+func NewRetentionWorker(
+	retention *RetentionService,
+	config *infrastructure.RetentionConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *RetentionWorker {
+	return &RetentionWorker{
+		retention: retention,
+		config:    config,
+		locks:     locks,
+		logger:    logger,
+	}
+}
+
+// Start launches the retention loop in the background. A zero interval disables it.
+func (w *RetentionWorker) Start(ctx context.Context) {
+	if w.config.Interval <= 0 {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		w.logger.Info("Retention worker started",
+			zap.Duration("interval", w.config.Interval),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.locks.RunEvery(ctx, "retention", w.config.Interval, func() { w.Run(ctx) })
+			}
+		}
+	}()
+}
+
+// Stop stops the retention loop and waits for an in-progress run to reach the
+// end of its batch, or until ctx is done
+func (w *RetentionWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Retention worker stopped")
+	return nil
+}
+
+// Run applies the retention policies once and logs the summary of each
+func (w *RetentionWorker) Run(ctx context.Context) {
+	report, err := w.retention.Run(ctx, false)
+	if err != nil {
+		w.logger.Error("Retention run failed", zap.Error(err))
+		return
+	}
+	for _, result := range report.Results {
+		if result.Enabled {
+			w.logger.Info("Retention summary",
+				zap.String("policy", string(result.Policy)),
+				zap.Int64("purged", result.Purged),
+			)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// RoadmapService handles the curriculum roadmap
+type RoadmapService struct {
+	roadmapRepo domain.RoadmapRepository
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+// NewRoadmapService creates a new roadmap service
+func NewRoadmapService(
+	roadmapRepo domain.RoadmapRepository,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *RoadmapService {
+	return &RoadmapService{
+		roadmapRepo: roadmapRepo,
+		tracer:      tracer,
+		logger:      logger,
+	}
+}
+
+// GetRoadmap returns the ordered roadmap. When userID is not uuid.Nil each
+// problem and category carries the user's completion.
+func (s *RoadmapService) GetRoadmap(ctx context.Context, userID uuid.UUID) (*domain.RoadmapResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "RoadmapService.GetRoadmap")
+	defer span.End()
+
+	categories, err := s.roadmapRepo.WithContext(ctx).FindCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	var solved map[uuid.UUID]struct{}
+	if userID != uuid.Nil {
+		span.SetAttributes(attribute.String("user.id", userID.String()))
+		ids, err := s.roadmapRepo.WithContext(ctx).FindSolvedProblemIDs(userID)
+		if err != nil {
+			return nil, err
+		}
+		solved = make(map[uuid.UUID]struct{}, len(ids))
+		for _, id := range ids {
+			solved[id] = struct{}{}
+		}
+	}
+
+	roadmap := &domain.RoadmapResponse{
+		Categories: make([]domain.RoadmapCategoryResponse, len(categories)),
+	}
+	completedTotal := 0
+	for i, category := range categories {
+		resp := domain.RoadmapCategoryResponse{
+			ID:       category.ID,
+			Name:     category.Name,
+			Position: category.Position,
+			Problems: make([]domain.RoadmapProblemResponse, len(category.Problems)),
+			Total:    len(category.Problems),
+		}
+		completed := 0
+		for j, entry := range category.Problems {
+			resp.Problems[j] = domain.RoadmapProblemResponse{
+				ProblemResponse: entry.Problem.ToResponse(),
+				Position:        entry.Position,
+			}
+			if solved != nil {
+				_, isSolved := solved[entry.ProblemID]
+				resp.Problems[j].Solved = &isSolved
+				if isSolved {
+					completed++
+				}
+			}
+		}
+		if solved != nil {
+			resp.Completed = &completed
+			completedTotal += completed
+		}
+		roadmap.Categories[i] = resp
+		roadmap.Total += resp.Total
+	}
+	if solved != nil {
+		roadmap.Completed = &completedTotal
+	}
+
+	return roadmap, nil
+}
+
+// SelectNextProblems returns the next count unsolved problems from the user's
+// roadmap position, in roadmap order. The warning is non-nil when fewer remain.
+func (s *RoadmapService) SelectNextProblems(ctx context.Context, userID uuid.UUID, count int) ([]domain.Problem, *domain.ContestWarning, error) {
+	ctx, span := s.tracer.Start(ctx, "RoadmapService.SelectNextProblems")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("problem.count", count),
+	)
+
+	problems, err := s.roadmapRepo.WithContext(ctx).FindNextUnsolved(userID, count)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(problems) == 0 {
+		return nil, nil, domain.ErrNotEnoughProblems
+	}
+
+	var warning *domain.ContestWarning
+	if len(problems) < count {
+		delivered := make(map[domain.Difficulty]int)
+		for _, p := range problems {
+			delivered[p.Difficulty]++
+		}
+		warning = &domain.ContestWarning{
+			Code:      domain.WarningNotEnoughProblems,
+			Message:   fmt.Sprintf("Only %d of %d requested problems are left on your roadmap", len(problems), count),
+			Requested: map[domain.Difficulty]int{},
+			Delivered: delivered,
+		}
+		span.SetAttributes(attribute.String("selection.warning", warning.Code))
+	}
+
+	logFor(ctx, s.logger).Info("Roadmap problems selected for contest",
+		zap.Int("count", len(problems)),
+	)
+
+	return problems, warning, nil
+}
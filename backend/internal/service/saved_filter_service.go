@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+)
+
+// SavedFilterService handles the user's saved problem filters
+type SavedFilterService struct {
+	filterRepo domain.SavedFilterRepository
+	tracer     trace.Tracer
+	logger     *zap.Logger
+}
+
+// NewSavedFilterService creates a new saved filter service
+func NewSavedFilterService(
+	filterRepo domain.SavedFilterRepository,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *SavedFilterService {
+	return &SavedFilterService{
+		filterRepo: filterRepo,
+		tracer:     tracer,
+		logger:     logger,
+	}
+}
+
+// ListFilters returns all saved filters of a user
+func (s *SavedFilterService) ListFilters(ctx context.Context, userID uuid.UUID) ([]domain.SavedFilter, error) {
+	ctx, span := s.tracer.Start(ctx, "SavedFilterService.ListFilters")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	return s.filterRepo.WithContext(ctx).FindByUserID(userID)
+}
+
+// GetFilter returns a saved filter owned by the user
+func (s *SavedFilterService) GetFilter(ctx context.Context, userID, filterID uuid.UUID) (*domain.SavedFilter, error) {
+	ctx, span := s.tracer.Start(ctx, "SavedFilterService.GetFilter")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("filter.id", filterID.String()),
+	)
+
+	filter, err := s.filterRepo.WithContext(ctx).FindByID(filterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if filter.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+	return filter, nil
+}
+
+// CreateFilter saves a new named filter for the user
+func (s *SavedFilterService) CreateFilter(ctx context.Context, userID uuid.UUID, req *domain.SavedFilterRequest) (*domain.SavedFilter, error) {
+	ctx, span := s.tracer.Start(ctx, "SavedFilterService.CreateFilter")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	count, err := s.filterRepo.WithContext(ctx).CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxSavedFiltersPerUser {
+		return nil, domain.ErrTooManyFilters
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.ensureNameAvailable(ctx, userID, name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	filter := &domain.SavedFilter{UserID: userID, Name: name}
+	filter.Apply(req.ProblemCriteria)
+	if err := s.filterRepo.WithContext(ctx).Create(filter); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Saved filter created",
+		zap.String("filter_id", filter.ID.String()),
+	)
+	return filter, nil
+}
+
+// UpdateFilter replaces the name and criteria of a saved filter
+func (s *SavedFilterService) UpdateFilter(ctx context.Context, userID, filterID uuid.UUID, req *domain.SavedFilterRequest) (*domain.SavedFilter, error) {
+	ctx, span := s.tracer.Start(ctx, "SavedFilterService.UpdateFilter")
+	defer span.End()
+
+	filter, err := s.GetFilter(ctx, userID, filterID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.ensureNameAvailable(ctx, userID, name, filter.ID); err != nil {
+		return nil, err
+	}
+
+	filter.Name = name
+	filter.Apply(req.ProblemCriteria)
+	if err := s.filterRepo.WithContext(ctx).Update(filter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// DeleteFilter deletes a saved filter owned by the user
+func (s *SavedFilterService) DeleteFilter(ctx context.Context, userID, filterID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "SavedFilterService.DeleteFilter")
+	defer span.End()
+
+	filter, err := s.GetFilter(ctx, userID, filterID)
+	if err != nil {
+		return err
+	}
+	return s.filterRepo.WithContext(ctx).Delete(filter.ID)
+}
+
+// ensureNameAvailable rejects names already used by another of the user's filters
+func (s *SavedFilterService) ensureNameAvailable(ctx context.Context, userID uuid.UUID, name string, self uuid.UUID) error {
+	existing, err := s.filterRepo.WithContext(ctx).FindByUserAndName(userID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != self {
+		return domain.ErrFilterNameTaken
+	}
+	return nil
+}
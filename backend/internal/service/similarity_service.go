@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// SimilarityService stores the solution snippets users attach to contest
+// problems and compares those of group assignments with the other students'
+// solutions to the same problem, flagging near-identical pairs for instructors
+type SimilarityService struct {
+	similarityRepo domain.SimilarityRepository
+	contestRepo    domain.ContestRepository
+	orgRepo        domain.OrgRepository
+	config         *infrastructure.SimilarityConfig
+	tracer         trace.Tracer
+	logger         *zap.Logger
+}
+
+// NewSimilarityService creates a new similarity service
+func NewSimilarityService(
+	similarityRepo domain.SimilarityRepository,
+	contestRepo domain.ContestRepository,
+	orgRepo domain.OrgRepository,
+	config *infrastructure.SimilarityConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *SimilarityService {
+	return &SimilarityService{
+		similarityRepo: similarityRepo,
+		contestRepo:    contestRepo,
+		orgRepo:        orgRepo,
+		config:         config,
+		tracer:         tracer,
+		logger:         logger,
+	}
+}
+
+// AttachSolution attaches the user's code to a problem of their contest,
+// replacing the snippet attached before
+func (s *SimilarityService) AttachSolution(ctx context.Context, userID, contestID, problemID uuid.UUID, req *domain.AttachSolutionRequest) (*domain.SolutionSnippet, error) {
+	ctx, span := s.tracer.Start(ctx, "SimilarityService.AttachSolution")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.String("problem.id", problemID.String()),
+	)
+
+	if err := s.ownProblem(ctx, userID, contestID, problemID); err != nil {
+		return nil, err
+	}
+
+	tokens := normalizeCode(req.Code)
+	snippet := &domain.SolutionSnippet{
+		ContestID:    contestID,
+		ProblemID:    problemID,
+		UserID:       userID,
+		Language:     req.Language,
+		Code:         req.Code,
+		UpdatedAt:    time.Now(),
+		Revision:     1,
+		Fingerprints: winnow(tokens),
+		TokenCount:   len(tokens),
+	}
+	if err := s.similarityRepo.WithContext(ctx).SaveSnippet(snippet); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Debug("Solution snippet attached",
+		zap.String("contest_id", contestID.String()),
+		zap.String("problem_id", problemID.String()),
+		zap.Int("tokens", snippet.TokenCount),
+	)
+	return snippet, nil
+}
+
+// GetSolution returns the snippet the user attached to a problem of their contest
+func (s *SimilarityService) GetSolution(ctx context.Context, userID, contestID, problemID uuid.UUID) (*domain.SolutionSnippet, error) {
+	ctx, span := s.tracer.Start(ctx, "SimilarityService.GetSolution")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("contest.id", contestID.String()),
+		attribute.String("problem.id", problemID.String()),
+	)
+
+	if err := s.ownProblem(ctx, userID, contestID, problemID); err != nil {
+		return nil, err
+	}
+	return s.similarityRepo.WithContext(ctx).FindSnippet(contestID, problemID)
+}
+
+// ownProblem checks that the contest is the user's and has the problem
+func (s *SimilarityService) ownProblem(ctx context.Context, userID, contestID, problemID uuid.UUID) error {
+	contest, err := s.contestRepo.WithContext(ctx).FindByIDWithProblems(contestID)
+	if err != nil {
+		return err
+	}
+	if contest.UserID != userID {
+		return domain.ErrForbidden
+	}
+	for _, cp := range contest.ContestProblems {
+		if cp.ProblemID == problemID {
+			return nil
+		}
+	}
+	return domain.ErrProblemNotInContest
+}
+
+// CheckChanged compares every snippet of an assignment contest that changed
+// since the last round with the other students' snippets for the problem. Pairs
+// at or above the threshold are flagged; a pending flag whose pair fell below
+// it is dropped, while reviewed flags are kept for the record.
+func (s *SimilarityService) CheckChanged(ctx context.Context) (*domain.SimilarityRunReport, error) {
+	ctx, span := s.tracer.Start(ctx, "SimilarityService.CheckChanged")
+	defer span.End()
+
+	snippets, err := s.similarityRepo.WithContext(ctx).FindUnchecked(max(s.config.BatchSize, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.SimilarityRunReport{}
+	compared := make(map[snippetPair]bool)
+	for i := range snippets {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.check(ctx, &snippets[i], compared, report); err != nil {
+			return nil, err
+		}
+		report.Checked++
+	}
+
+	span.SetAttributes(
+		attribute.Int("similarity.checked", report.Checked),
+		attribute.Int("similarity.flagged", report.Flagged),
+	)
+	if report.Flagged > 0 || report.Cleared > 0 {
+		logFor(ctx, s.logger).Info("Solution similarity checked",
+			zap.Int("checked", report.Checked),
+			zap.Int("compared", report.Compared),
+			zap.Int("flagged", report.Flagged),
+			zap.Int("cleared", report.Cleared),
+		)
+	}
+	return report, nil
+}
+
+// snippetPair identifies two snippets for one problem, the lower contest ID first
+type snippetPair struct {
+	problemID, contestAID, contestBID uuid.UUID
+}
+
+// check compares one changed snippet with its peers, skipping pairs already
+// compared this round when both of their snippets changed, and marks it checked
+func (s *SimilarityService) check(ctx context.Context, snippet *domain.AssignmentSnippet, compared map[snippetPair]bool, report *domain.SimilarityRunReport) error {
+	peers, err := s.similarityRepo.WithContext(ctx).FindPeers(snippet.AssignmentID, snippet.ProblemID, snippet.ContestID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range peers {
+		peer := &peers[i]
+		a, b := &snippet.SolutionSnippet, peer
+		if b.ContestID.String() < a.ContestID.String() {
+			a, b = b, a
+		}
+		pair := snippetPair{snippet.ProblemID, a.ContestID, b.ContestID}
+		if compared[pair] {
+			continue
+		}
+		compared[pair] = true
+
+		similarity := 0.0
+		if a.TokenCount >= s.config.MinTokens && b.TokenCount >= s.config.MinTokens {
+			similarity = fingerprintSimilarity(a.Fingerprints, b.Fingerprints)
+			report.Compared++
+		}
+		if similarity < s.config.Threshold {
+			cleared, err := s.similarityRepo.WithContext(ctx).DeletePendingFlag(snippet.AssignmentID, snippet.ProblemID, a.ContestID, b.ContestID)
+			if err != nil {
+				return err
+			}
+			if cleared {
+				report.Cleared++
+			}
+			continue
+		}
+
+		flag := &domain.SimilarityFlag{
+			AssignmentID: snippet.AssignmentID,
+			ProblemID:    snippet.ProblemID,
+			ContestAID:   a.ContestID,
+			ContestBID:   b.ContestID,
+			UserAID:      a.UserID,
+			UserBID:      b.UserID,
+			Similarity:   similarity,
+			Status:       domain.SimilarityPending,
+			DetectedAt:   now,
+		}
+		if err := s.similarityRepo.WithContext(ctx).UpsertFlag(flag); err != nil {
+			return err
+		}
+		report.Flagged++
+	}
+
+	return s.similarityRepo.WithContext(ctx).MarkChecked(snippet.ContestID, snippet.ProblemID, snippet.Revision)
+}
+
+// ListFlags lists the flagged pairs of an assignment for an instructor of its
+// organization, narrowed to a review status when given
+func (s *SimilarityService) ListFlags(ctx context.Context, userID, orgID, assignmentID uuid.UUID, status domain.SimilarityStatus) ([]domain.SimilarityFlagResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "SimilarityService.ListFlags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("assignment.id", assignmentID.String()),
+	)
+
+	if err := s.instructor(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.orgRepo.WithContext(ctx).FindAssignment(orgID, assignmentID); err != nil {
+		return nil, err
+	}
+	return s.similarityRepo.WithContext(ctx).FindFlags(assignmentID, status)
+}
+
+// GetFlag returns a flagged pair with both solutions for an instructor of the
+// organization it was flagged in
+func (s *SimilarityService) GetFlag(ctx context.Context, userID, orgID, flagID uuid.UUID) (*domain.SimilarityFlagDetail, error) {
+	ctx, span := s.tracer.Start(ctx, "SimilarityService.GetFlag")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("similarity.flag_id", flagID.String()),
+	)
+
+	flag, err := s.orgFlag(ctx, userID, orgID, flagID)
+	if err != nil {
+		return nil, err
+	}
+	detail := &domain.SimilarityFlagDetail{SimilarityFlagResponse: *flag}
+	for _, side := range []struct {
+		contestID uuid.UUID
+		snippet   *domain.SolutionSnippet
+	}{
+		{flag.ContestAID, &detail.SnippetA},
+		{flag.ContestBID, &detail.SnippetB},
+	} {
+		snippet, err := s.similarityRepo.WithContext(ctx).FindSnippet(side.contestID, flag.ProblemID)
+		if err != nil {
+			return nil, err
+		}
+		*side.snippet = *snippet
+	}
+	return detail, nil
+}
+
+// ReviewFlag records an instructor's verdict on a flagged pair; setting it back
+// to pending lets a later round drop the flag if the code changes
+func (s *SimilarityService) ReviewFlag(ctx context.Context, userID, orgID, flagID uuid.UUID, req *domain.ReviewSimilarityRequest) (*domain.SimilarityFlagResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "SimilarityService.ReviewFlag")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("similarity.flag_id", flagID.String()),
+		attribute.String("similarity.status", string(req.Status)),
+	)
+
+	flag, err := s.orgFlag(ctx, userID, orgID, flagID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	flag.Status = req.Status
+	flag.Note = req.Note
+	flag.ReviewedBy = &userID
+	flag.ReviewedAt = &now
+	if err := s.similarityRepo.WithContext(ctx).UpdateReview(&flag.SimilarityFlag); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Similarity flag reviewed",
+		zap.String("flag_id", flagID.String()),
+		zap.String("org_id", orgID.String()),
+		zap.String("status", string(req.Status)),
+	)
+	return flag, nil
+}
+
+// orgFlag finds a flag of one of the organization's assignments for an instructor
+func (s *SimilarityService) orgFlag(ctx context.Context, userID, orgID, flagID uuid.UUID) (*domain.SimilarityFlagResponse, error) {
+	if err := s.instructor(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	flag, err := s.similarityRepo.WithContext(ctx).FindFlag(flagID)
+	if err != nil {
+		return nil, err
+	}
+	// Flags of other organizations are not found through this one
+	if _, err := s.orgRepo.WithContext(ctx).FindAssignment(orgID, flag.AssignmentID); err != nil {
+		if errors.Is(err, domain.ErrAssignmentNotFound) {
+			return nil, domain.ErrSimilarityFlagNotFound
+		}
+		return nil, err
+	}
+	return flag, nil
+}
+
+// instructor checks that the user is an instructor of the organization
+func (s *SimilarityService) instructor(ctx context.Context, orgID, userID uuid.UUID) error {
+	member, err := s.orgRepo.WithContext(ctx).FindMember(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !member.IsInstructor() {
+		return domain.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// SimilarityWorker compares changed solution snippets on a schedule, on one
+// instance at a time so snippets are not compared twice.
+type SimilarityWorker struct {
+	similarity *SimilarityService
+	config     *infrastructure.SimilarityConfig
+	locks      *infrastructure.JobLocks
+	logger     *zap.Logger
+	wg         sync.WaitGroup
+	cancel     context.CancelFunc
+}
+
+// NewSimilarityWorker creates a new similarity worker
+func NewSimilarityWorker(
+	similarity *SimilarityService,
+	config *infrastructure.SimilarityConfig,
+	locks *infrastructure.JobLocks,
+	logger *zap.Logger,
+) *SimilarityWorker {
+	return &SimilarityWorker{
+		similarity: similarity,
+		config:     config,
+		locks:      locks,
+		logger:     logger,
+	}
+}
+
+// Start launches the similarity loop in the background. A zero interval disables it.
+func (w *SimilarityWorker) Start(ctx context.Context) {
+	if w.config.Interval <= 0 {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		w.logger.Info("Similarity worker started",
+			zap.Duration("interval", w.config.Interval),
+			zap.Float64("threshold", w.config.Threshold),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.locks.RunEvery(ctx, "similarity", w.config.Interval, func() {
+					if _, err := w.similarity.CheckChanged(ctx); err != nil {
+						w.logger.Error("Similarity round failed", zap.Error(err))
+					}
+				})
+			}
+		}
+	}()
+}
+
+// Stop stops the similarity loop and waits for an in-progress round to finish
+// the snippet it is comparing, or until ctx is done
+func (w *SimilarityWorker) Stop(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if err := infrastructure.WaitContext(ctx, &w.wg); err != nil {
+		return err
+	}
+	w.logger.Info("Similarity worker stopped")
+	return nil
+}
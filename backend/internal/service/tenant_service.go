@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// tenantSlugPattern is what a slug must look like to be usable as a subdomain
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// reservedTenantSlugs are subdomains of the platform itself; requests to them
+// are for the default tenant and no tenant can take them
+var reservedTenantSlugs = map[string]bool{"www": true, "api": true, "app": true, "admin": true}
+
+// DefaultLeaderboardSize is how many users the leaderboard lists without a limit
+const DefaultLeaderboardSize = 25
+
+// TenantService manages the tenants hosted on the platform and what is kept per
+// tenant: problems overlaying the shared catalog and the leaderboard
+type TenantService struct {
+	tenantRepo  domain.TenantRepository
+	problemRepo domain.ProblemRepository
+	config      *infrastructure.TenancyConfig
+	tracer      trace.Tracer
+	logger      *zap.Logger
+
+	// Resolved tenants by slug, reused for config.CacheTTL since every request
+	// of a tenant resolves it
+	mu    sync.Mutex
+	cache map[string]cachedTenant
+}
+
+// cachedTenant is a resolved tenant and until when it is reused
+type cachedTenant struct {
+	tenant *domain.Tenant
+	expiry time.Time
+}
+
+// NewTenantService creates a new tenant service
+func NewTenantService(
+	tenantRepo domain.TenantRepository,
+	problemRepo domain.ProblemRepository,
+	config *infrastructure.TenancyConfig,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *TenantService {
+	return &TenantService{
+		tenantRepo:  tenantRepo,
+		problemRepo: problemRepo,
+		config:      config,
+		tracer:      tracer,
+		logger:      logger,
+		cache:       make(map[string]cachedTenant),
+	}
+}
+
+// Resolve returns the tenant a request naming slug is for, or nil for the
+// default tenant when slug is empty or one of the platform's own subdomains
+func (s *TenantService) Resolve(ctx context.Context, slug string) (*domain.Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" || reservedTenantSlugs[slug] {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[slug]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.tenant, nil
+	}
+
+	tenant, err := s.tenantRepo.WithContext(ctx).FindBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.cache[slug] = cachedTenant{tenant: tenant, expiry: time.Now().Add(s.config.CacheTTL)}
+	s.mu.Unlock()
+	return tenant, nil
+}
+
+// CreateTenant hosts a new tenant. Its admin gets the admin role by signing up
+// in the tenant with the given email.
+func (s *TenantService) CreateTenant(ctx context.Context, req *domain.CreateTenantRequest) (*domain.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "TenantService.CreateTenant")
+	defer span.End()
+
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	span.SetAttributes(attribute.String("tenant.slug", slug))
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, domain.NewValidationError("Tenant slugs use lowercase letters, digits and inner dashes", nil)
+	}
+	if reservedTenantSlugs[slug] {
+		return nil, domain.ErrTenantSlugTaken
+	}
+
+	tenant := &domain.Tenant{
+		Slug:       slug,
+		Name:       strings.TrimSpace(req.Name),
+		AdminEmail: strings.TrimSpace(req.AdminEmail),
+	}
+	if err := s.tenantRepo.WithContext(ctx).Create(tenant); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Tenant created",
+		zap.String("tenant_id", tenant.ID.String()),
+		zap.String("slug", tenant.Slug),
+	)
+	return tenant, nil
+}
+
+// ListTenants lists every hosted tenant
+func (s *TenantService) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "TenantService.ListTenants")
+	defer span.End()
+
+	return s.tenantRepo.WithContext(ctx).FindAll()
+}
+
+// GetCurrent returns the tenant the request is for. Requests for the default
+// tenant have none.
+func (s *TenantService) GetCurrent(ctx context.Context) (*domain.TenantInfo, error) {
+	ctx, span := s.tracer.Start(ctx, "TenantService.GetCurrent")
+	defer span.End()
+
+	tenant, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TenantInfo{ID: tenant.ID, Slug: tenant.Slug, Name: tenant.Name}, nil
+}
+
+// CreateProblem adds a problem to the tenant's catalog, after the shared one.
+// Only the tenant's users see it.
+func (s *TenantService) CreateProblem(ctx context.Context, req *domain.CustomProblemRequest) (*domain.Problem, error) {
+	ctx, span := s.tracer.Start(ctx, "TenantService.CreateProblem")
+	defer span.End()
+
+	tenant, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("tenant.id", tenant.ID.String()))
+
+	catalog, err := s.problemRepo.WithContext(ctx).FindAll()
+	if err != nil {
+		return nil, err
+	}
+	problem := &domain.Problem{ID: uuid.New()}
+	req.Apply(problem)
+	problem.Slug = domain.CustomProblemSlug(problem.Title, problem.ID)
+	for _, p := range catalog {
+		problem.OrderIndex = max(problem.OrderIndex, p.OrderIndex)
+	}
+	problem.OrderIndex++
+
+	if err := s.problemRepo.WithContext(ctx).Create(problem); err != nil {
+		return nil, err
+	}
+
+	logFor(ctx, s.logger).Info("Tenant problem created",
+		zap.String("tenant_id", tenant.ID.String()),
+		zap.String("problem_id", problem.ID.String()),
+	)
+	return problem, nil
+}
+
+// GetLeaderboard ranks the users of the request's tenant by solved problems
+func (s *TenantService) GetLeaderboard(ctx context.Context, limit int) ([]domain.LeaderboardEntry, error) {
+	ctx, span := s.tracer.Start(ctx, "TenantService.GetLeaderboard")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = DefaultLeaderboardSize
+	}
+	tenantID, _ := infrastructure.TenantFrom(ctx)
+	span.SetAttributes(attribute.String("tenant.id", tenantID.String()), attribute.Int("leaderboard.limit", limit))
+	return s.tenantRepo.WithContext(ctx).FindLeaderboard(limit)
+}
+
+// current returns the tenant the request is for; the default tenant is not one
+func (s *TenantService) current(ctx context.Context) (*domain.Tenant, error) {
+	tenantID, ok := infrastructure.TenantFrom(ctx)
+	if !ok || tenantID == domain.DefaultTenantID {
+		return nil, domain.ErrTenantNotFound
+	}
+	return s.tenantRepo.WithContext(ctx).FindByID(tenantID)
+}
+
+// cacheTenant is the tenant whose entries a cache of per-tenant figures uses
+// for the request, the default tenant when it names none
+func cacheTenant(ctx context.Context) uuid.UUID {
+	tenantID, _ := infrastructure.TenantFrom(ctx)
+	return tenantID
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,7 +12,6 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/contest-maker-150/backend/internal/domain"
 	"github.com/contest-maker-150/backend/internal/infrastructure"
@@ -17,30 +19,90 @@ import (
 
 // UserService handles user-related business logic
 type UserService struct {
-	userRepo  domain.UserRepository
-	subRepo   domain.SubmissionRepository
-	jwtConfig *infrastructure.JWTConfig
-	tracer    trace.Tracer
-	logger    *zap.Logger
+	userRepo       domain.UserRepository
+	subRepo        domain.SubmissionRepository
+	attemptRepo    domain.AttemptRepository
+	contestRepo    domain.ContestRepository
+	progressRepo   domain.UserProgressRepository
+	revocationRepo domain.TokenRevocationRepository
+	tenantRepo     domain.TenantRepository
+	jwtConfig      *infrastructure.JWTConfig
+	passwordPolicy *PasswordPolicy
+	hasher         *PasswordHasher
+	tracer         trace.Tracer
+	logger         *zap.Logger
 }
 
 // NewUserService creates a new user service
 func NewUserService(
 	userRepo domain.UserRepository,
 	subRepo domain.SubmissionRepository,
+	attemptRepo domain.AttemptRepository,
+	contestRepo domain.ContestRepository,
+	progressRepo domain.UserProgressRepository,
+	revocationRepo domain.TokenRevocationRepository,
+	tenantRepo domain.TenantRepository,
 	jwtConfig *infrastructure.JWTConfig,
+	passwordPolicy *PasswordPolicy,
+	hasher *PasswordHasher,
 	tracer trace.Tracer,
 	logger *zap.Logger,
 ) *UserService {
 	return &UserService{
-		userRepo:  userRepo,
-		subRepo:   subRepo,
-		jwtConfig: jwtConfig,
-		tracer:    tracer,
-		logger:    logger,
+		userRepo:       userRepo,
+		subRepo:        subRepo,
+		attemptRepo:    attemptRepo,
+		contestRepo:    contestRepo,
+		progressRepo:   progressRepo,
+		revocationRepo: revocationRepo,
+		tenantRepo:     tenantRepo,
+		jwtConfig:      jwtConfig,
+		passwordPolicy: passwordPolicy,
+		hasher:         hasher,
+		tracer:         tracer,
+		logger:         logger,
 	}
 }
 
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+// TokenClaims are the JWT claims carried by access and refresh tokens.
+// Access tokens include the role and scopes so routes can be authorized
+// without a database lookup.
+type TokenClaims struct {
+	jwt.RegisteredClaims
+	Type   string      `json:"type"`
+	Email  string      `json:"email,omitempty"`
+	Role   domain.Role `json:"role,omitempty"`
+	Scopes []string    `json:"scopes,omitempty"`
+
+	// Version is the user's token version at issue time; bumping the user's
+	// version revokes every token carrying an older one
+	Version int `json:"ver,omitempty"`
+
+	// TenantID is the tenant the user belongs to, empty for the default one;
+	// the token is only accepted in requests for that tenant
+	TenantID string `json:"tid,omitempty"`
+}
+
+// UserID parses the subject claim as a user ID
+func (c *TokenClaims) UserID() (uuid.UUID, error) {
+	return uuid.Parse(c.Subject)
+}
+
+// HasScope reports whether the token grants the given scope
+func (c *TokenClaims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // TokenPair represents access and refresh tokens
 type TokenPair struct {
 	AccessToken  string    `json:"access_token"`
@@ -53,22 +115,25 @@ func (s *UserService) Register(ctx context.Context, req *domain.UserCreateReques
 	ctx, span := s.tracer.Start(ctx, "UserService.Register")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("user.email", req.Email))
-
 	// Check if user already exists
-	existing, err := s.userRepo.FindByEmail(req.Email)
+	existing, err := s.userRepo.WithContext(ctx).FindByEmail(req.Email)
 	if err != nil && err != domain.ErrUserNotFound {
-		s.logger.Error("Failed to check existing user", zap.Error(err))
+		logFor(ctx, s.logger).Error("Failed to check existing user", zap.Error(err))
 		return nil, nil, err
 	}
 	if existing != nil {
 		return nil, nil, domain.ErrUserAlreadyExists
 	}
 
+	// Enforce password policy
+	if err := s.passwordPolicy.Validate(ctx, req.Password); err != nil {
+		return nil, nil, err
+	}
+
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Hash(req.Password)
 	if err != nil {
-		s.logger.Error("Failed to hash password", zap.Error(err))
+		logFor(ctx, s.logger).Error("Failed to hash password", zap.Error(err))
 		return nil, nil, domain.ErrInternalServer
 	}
 
@@ -76,11 +141,22 @@ func (s *UserService) Register(ctx context.Context, req *domain.UserCreateReques
 	user := &domain.User{
 		Email:        req.Email,
 		Username:     req.Username,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
+	}
+
+	// The email a tenant was created for signs up as its admin
+	if tenantID, ok := infrastructure.TenantFrom(ctx); ok && tenantID != domain.DefaultTenantID {
+		tenant, err := s.tenantRepo.WithContext(ctx).FindByID(tenantID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if strings.EqualFold(tenant.AdminEmail, req.Email) {
+			user.Role = domain.RoleAdmin
+		}
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
-		s.logger.Error("Failed to create user", zap.Error(err))
+	if err := s.userRepo.WithContext(ctx).Create(user); err != nil {
+		logFor(ctx, s.logger).Error("Failed to create user", zap.Error(err))
 		return nil, nil, err
 	}
 
@@ -90,9 +166,8 @@ func (s *UserService) Register(ctx context.Context, req *domain.UserCreateReques
 		return nil, nil, err
 	}
 
-	s.logger.Info("User registered successfully",
+	logFor(ctx, s.logger).Info("User registered successfully",
 		zap.String("user_id", user.ID.String()),
-		zap.String("email", user.Email),
 	)
 
 	span.SetAttributes(attribute.String("user.id", user.ID.String()))
@@ -104,10 +179,8 @@ func (s *UserService) Login(ctx context.Context, email, password string) (*domai
 	ctx, span := s.tracer.Start(ctx, "UserService.Login")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("user.email", email))
-
 	// Find user by email
-	user, err := s.userRepo.FindByEmail(email)
+	user, err := s.userRepo.WithContext(ctx).FindByEmail(email)
 	if err != nil {
 		if err == domain.ErrUserNotFound {
 			return nil, nil, domain.ErrInvalidCredentials
@@ -116,56 +189,115 @@ func (s *UserService) Login(ctx context.Context, email, password string) (*domai
 	}
 
 	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+	ok, needsRehash, err := s.hasher.Verify(user.PasswordHash, password)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to verify password hash", zap.Error(err))
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+	if !ok {
 		return nil, nil, domain.ErrInvalidCredentials
 	}
 
+	// Transparently upgrade hashes created with outdated parameters
+	if needsRehash {
+		s.rehashPassword(ctx, user, password)
+	}
+
 	// Generate tokens
 	tokens, err := s.generateTokenPair(user)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	s.logger.Info("User logged in",
+	logFor(ctx, s.logger).Info("User logged in",
 		zap.String("user_id", user.ID.String()),
-		zap.String("email", user.Email),
 	)
 
 	span.SetAttributes(attribute.String("user.id", user.ID.String()))
 	return user, tokens, nil
 }
 
-// RefreshToken generates a new access token from a refresh token
-func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
-	ctx, span := s.tracer.Start(ctx, "UserService.RefreshToken")
+// ChangePassword verifies the current password and replaces it with a new one
+func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, req *domain.ChangePasswordRequest) error {
+	ctx, span := s.tracer.Start(ctx, "UserService.ChangePassword")
 	defer span.End()
 
-	// Parse and validate refresh token
-	claims, err := s.validateToken(refreshToken)
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
 	if err != nil {
-		return nil, domain.ErrInvalidToken
+		return err
 	}
 
-	// Check token type
-	tokenType, ok := claims["type"].(string)
-	if !ok || tokenType != "refresh" {
-		return nil, domain.ErrInvalidToken
+	// Verify current password
+	ok, _, err := s.hasher.Verify(user.PasswordHash, req.CurrentPassword)
+	if err != nil || !ok {
+		return domain.ErrInvalidCredentials
 	}
 
-	// Get user ID from claims
-	userIDStr, ok := claims["sub"].(string)
-	if !ok {
-		return nil, domain.ErrInvalidToken
+	// Enforce password policy
+	if err := s.passwordPolicy.Validate(ctx, req.NewPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hasher.Hash(req.NewPassword)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to hash password", zap.Error(err))
+		return domain.ErrInternalServer
+	}
+
+	user.PasswordHash = hashedPassword
+	if err := s.userRepo.WithContext(ctx).Update(user); err != nil {
+		logFor(ctx, s.logger).Error("Failed to update password", zap.Error(err))
+		return err
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	logFor(ctx, s.logger).Info("Password changed")
+	return nil
+}
+
+// rehashPassword re-hashes a verified password with the current parameters.
+// Failures are logged but never block the login.
+func (s *UserService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to rehash password", zap.Error(err))
+		return
+	}
+
+	user.PasswordHash = hashedPassword
+	if err := s.userRepo.WithContext(ctx).Update(user); err != nil {
+		logFor(ctx, s.logger).Error("Failed to store rehashed password", zap.Error(err))
+		return
+	}
+
+	logFor(ctx, s.logger).Info("Password rehashed with current parameters",
+		zap.String("user_id", user.ID.String()),
+	)
+}
+
+// RefreshToken generates a new access token from a refresh token
+func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	ctx, span := s.tracer.Start(ctx, "UserService.RefreshToken")
+	defer span.End()
+
+	// Parse and validate refresh token
+	claims, err := s.validateToken(ctx, refreshToken, tokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := claims.UserID()
 	if err != nil {
 		return nil, domain.ErrInvalidToken
 	}
 
 	// Find user
-	user, err := s.userRepo.FindByID(userID)
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
 	if err != nil {
+		if err == domain.ErrUserNotFound {
+			return nil, domain.ErrInvalidToken
+		}
 		return nil, err
 	}
 
@@ -173,95 +305,212 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*T
 	return s.generateTokenPair(user)
 }
 
+// Logout revokes the access token the request was made with and, when given,
+// the refresh token of the same session
+func (s *UserService) Logout(ctx context.Context, claims *TokenClaims, refreshToken string) error {
+	ctx, span := s.tracer.Start(ctx, "UserService.Logout")
+	defer span.End()
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return domain.ErrInvalidToken
+	}
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	now := time.Now()
+	var tokens []domain.RevokedToken
+	if claims.ID != "" { // Tokens issued before jti claims existed can only be revoked all at once
+		tokens = append(tokens, revokedToken(claims, userID, now))
+	}
+
+	if refreshToken != "" {
+		refreshClaims, err := s.validateToken(ctx, refreshToken, tokenTypeRefresh)
+		if err != nil {
+			return err
+		}
+		if refreshClaims.Subject != claims.Subject {
+			return domain.ErrForbidden
+		}
+		if refreshClaims.ID != "" {
+			tokens = append(tokens, revokedToken(refreshClaims, userID, now))
+		}
+	}
+
+	if err := s.revocationRepo.WithContext(ctx).Revoke(tokens); err != nil {
+		logFor(ctx, s.logger).Error("Failed to revoke tokens", zap.Error(err))
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("User logged out")
+	return nil
+}
+
+// RevokeAllTokens invalidates every access and refresh token issued to the user,
+// signing them out on all devices
+func (s *UserService) RevokeAllTokens(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "UserService.RevokeAllTokens")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if err := s.revocationRepo.WithContext(ctx).RevokeAllForUser(userID); err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			logFor(ctx, s.logger).Error("Failed to revoke user tokens", zap.String("target_user_id", userID.String()), zap.Error(err))
+		}
+		return err
+	}
+
+	logFor(ctx, s.logger).Info("Revoked all user tokens", zap.String("target_user_id", userID.String()))
+	return nil
+}
+
+// revokedToken builds the revocation entry of a validated token
+func revokedToken(claims *TokenClaims, userID uuid.UUID, revokedAt time.Time) domain.RevokedToken {
+	return domain.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		RevokedAt: revokedAt,
+	}
+}
+
 // GetUserByID retrieves a user by their ID
 func (s *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	ctx, span := s.tracer.Start(ctx, "UserService.GetUserByID")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("user.id", id.String()))
-	return s.userRepo.FindByID(id)
+	return s.userRepo.WithContext(ctx).FindByID(id)
 }
 
-// GetUserProgress retrieves the user's progress statistics
+// GetUserProgress retrieves the user's progress statistics from the progress summary
 func (s *UserService) GetUserProgress(ctx context.Context, userID uuid.UUID) (*domain.UserProgress, error) {
 	ctx, span := s.tracer.Start(ctx, "UserService.GetUserProgress")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("user.id", userID.String()))
 
-	// Count solved problems by difficulty (concurrent queries using goroutines)
-	type countResult struct {
-		difficulty domain.Difficulty
-		count      int64
-		err        error
+	summary, err := s.progressRepo.WithContext(ctx).FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		// No summary yet: either a new user or the startup backfill has not reached
+		// them, so count solved problems directly. Contest stats follow with the summary.
+		counts, err := s.subRepo.WithContext(ctx).CountSolvedByDifficulty(userID)
+		if err != nil {
+			return nil, err
+		}
+		summary = &domain.UserProgressSummary{
+			UserID:       userID,
+			EasySolved:   counts[domain.DifficultyEasy],
+			MediumSolved: counts[domain.DifficultyMedium],
+			HardSolved:   counts[domain.DifficultyHard],
+		}
 	}
 
-	difficulties := []domain.Difficulty{
-		domain.DifficultyEasy,
-		domain.DifficultyMedium,
-		domain.DifficultyHard,
+	topics, err := s.subRepo.WithContext(ctx).CountByTopic(userID)
+	if err != nil {
+		return nil, err
+	}
+	confidence, err := s.subRepo.WithContext(ctx).CountByConfidence(userID)
+	if err != nil {
+		return nil, err
 	}
+	attempts, err := s.attemptRepo.WithContext(ctx).Stats(userID)
+	if err != nil {
+		return nil, err
+	}
+	solveTimes, err := s.contestRepo.WithContext(ctx).FindSolveTimes(userID)
+	if err != nil {
+		return nil, err
+	}
+	progress := summary.ToProgress()
+	progress.TopicProgress = topics
+	progress.Confidence = domain.NewConfidenceStats(confidence)
+	progress.Attempts = attempts
+	progress.SolveTimes = solveTimes
+	return progress, nil
+}
+
+// GetAttemptHistory lists the user's attempts at a problem, oldest first
+func (s *UserService) GetAttemptHistory(ctx context.Context, userID, problemID uuid.UUID) (*domain.AttemptHistory, error) {
+	ctx, span := s.tracer.Start(ctx, "UserService.GetAttemptHistory")
+	defer span.End()
 
-	resultChan := make(chan countResult, len(difficulties))
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("problem.id", problemID.String()),
+	)
 
-	// Fan-out: Launch concurrent queries
-	for _, diff := range difficulties {
-		go func(d domain.Difficulty) {
-			count, err := s.subRepo.CountByUserAndDifficulty(userID, d)
-			resultChan <- countResult{difficulty: d, count: count, err: err}
-		}(diff)
+	attempts, err := s.attemptRepo.WithContext(ctx).FindByUserAndProblem(userID, problemID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fan-in: Collect results
-	progress := &domain.UserProgress{
-		TopicProgress: make(map[string]domain.TopicStats),
+	span.SetAttributes(attribute.Int("attempts.count", len(attempts)))
+	return domain.NewAttemptHistory(problemID, attempts), nil
+}
+
+// GetReviewQueue lists the user's solved problems in spaced-repetition order:
+// each comes up for review after an interval that grows with the confidence
+// the user rated their latest solve with
+func (s *UserService) GetReviewQueue(ctx context.Context, userID uuid.UUID, query domain.ReviewQueueQuery) (*domain.ReviewQueue, error) {
+	ctx, span := s.tracer.Start(ctx, "UserService.GetReviewQueue")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	submissions, err := s.subRepo.WithContext(ctx).FindByUserID(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	for range difficulties {
-		result := <-resultChan
-		if result.err != nil {
-			s.logger.Error("Failed to count submissions by difficulty",
-				zap.String("difficulty", string(result.difficulty)),
-				zap.Error(result.err),
-			)
+	now := time.Now()
+	queue := &domain.ReviewQueue{Reviews: make([]domain.ReviewItem, 0, len(submissions))}
+	for i := range submissions {
+		sub := &submissions[i]
+		dueAt := sub.ReviewDueAt()
+		due := !dueAt.After(now)
+		if due {
+			queue.Due++
+		} else if query.DueOnly {
 			continue
 		}
-
-		switch result.difficulty {
-		case domain.DifficultyEasy:
-			progress.EasySolved = int(result.count)
-		case domain.DifficultyMedium:
-			progress.MediumSolved = int(result.count)
-		case domain.DifficultyHard:
-			progress.HardSolved = int(result.count)
-		}
+		queue.Reviews = append(queue.Reviews, domain.ReviewItem{
+			Problem:      sub.Problem.ToResponse(),
+			Confidence:   sub.Confidence,
+			LastSolvedAt: sub.LatestSolve(),
+			DueAt:        dueAt,
+			Due:          due,
+		})
 	}
 
-	progress.TotalSolved = progress.EasySolved + progress.MediumSolved + progress.HardSolved
+	sort.SliceStable(queue.Reviews, func(i, j int) bool {
+		return queue.Reviews[i].DueAt.Before(queue.Reviews[j].DueAt)
+	})
+	if query.Limit > 0 && len(queue.Reviews) > query.Limit {
+		queue.Reviews = queue.Reviews[:query.Limit]
+	}
 
-	return progress, nil
+	span.SetAttributes(attribute.Int("reviews.due", queue.Due))
+	return queue, nil
 }
 
-// ValidateAccessToken validates an access token and returns the user ID
-func (s *UserService) ValidateAccessToken(tokenString string) (uuid.UUID, error) {
-	claims, err := s.validateToken(tokenString)
+// ValidateAccessToken validates an access token and returns its claims
+func (s *UserService) ValidateAccessToken(ctx context.Context, tokenString string) (*TokenClaims, error) {
+	claims, err := s.validateToken(ctx, tokenString, tokenTypeAccess)
 	if err != nil {
-		return uuid.Nil, domain.ErrInvalidToken
-	}
-
-	// Check token type
-	tokenType, ok := claims["type"].(string)
-	if !ok || tokenType != "access" {
-		return uuid.Nil, domain.ErrInvalidToken
+		return nil, err
 	}
 
-	// Get user ID from claims
-	userIDStr, ok := claims["sub"].(string)
-	if !ok {
-		return uuid.Nil, domain.ErrInvalidToken
+	// Tokens issued before roles existed carry no role
+	if claims.Role == "" {
+		claims.Role = domain.RoleUser
+		claims.Scopes = domain.RoleUser.Scopes()
 	}
 
-	return uuid.Parse(userIDStr)
+	return claims, nil
 }
 
 // generateTokenPair creates access and refresh tokens for a user
@@ -270,14 +519,20 @@ func (s *UserService) generateTokenPair(user *domain.User) (*TokenPair, error) {
 	accessExpiry := now.Add(s.jwtConfig.AccessTokenExpiry)
 	refreshExpiry := now.Add(s.jwtConfig.RefreshTokenExpiry)
 
+	role := user.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
+
 	// Generate access token
-	accessClaims := jwt.MapClaims{
-		"sub":   user.ID.String(),
-		"email": user.Email,
-		"type":  "access",
-		"iat":   now.Unix(),
-		"exp":   accessExpiry.Unix(),
-		"iss":   s.jwtConfig.Issuer,
+	accessClaims := TokenClaims{
+		RegisteredClaims: s.registeredClaims(user, now, accessExpiry),
+		Type:             tokenTypeAccess,
+		Email:            user.Email,
+		Role:             role,
+		Scopes:           role.Scopes(),
+		Version:          user.TokenVersion,
+		TenantID:         tenantClaim(user.TenantID),
 	}
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
 	accessTokenString, err := accessToken.SignedString([]byte(s.jwtConfig.SecretKey))
@@ -286,12 +541,11 @@ func (s *UserService) generateTokenPair(user *domain.User) (*TokenPair, error) {
 	}
 
 	// Generate refresh token
-	refreshClaims := jwt.MapClaims{
-		"sub":  user.ID.String(),
-		"type": "refresh",
-		"iat":  now.Unix(),
-		"exp":  refreshExpiry.Unix(),
-		"iss":  s.jwtConfig.Issuer,
+	refreshClaims := TokenClaims{
+		RegisteredClaims: s.registeredClaims(user, now, refreshExpiry),
+		Type:             tokenTypeRefresh,
+		Version:          user.TokenVersion,
+		TenantID:         tenantClaim(user.TenantID),
 	}
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
 	refreshTokenString, err := refreshToken.SignedString([]byte(s.jwtConfig.SecretKey))
@@ -306,23 +560,91 @@ func (s *UserService) generateTokenPair(user *domain.User) (*TokenPair, error) {
 	}, nil
 }
 
-// validateToken validates a JWT token and returns its claims
-func (s *UserService) validateToken(tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, domain.ErrInvalidToken
-		}
+// registeredClaims builds the standard JWT claims shared by access and refresh tokens.
+// Every token gets its own jti so it can be revoked individually.
+func (s *UserService) registeredClaims(user *domain.User, issuedAt, expiresAt time.Time) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   user.ID.String(),
+		Issuer:    s.jwtConfig.Issuer,
+		Audience:  jwt.ClaimStrings{s.jwtConfig.Audience},
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		NotBefore: jwt.NewNumericDate(issuedAt),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+}
+
+// validateToken validates a JWT token of the expected type and returns its claims.
+// Signature, issuer, audience, exp, nbf and iat are all checked, with the configured
+// clock skew tolerated on the time-based claims, and revoked tokens are rejected.
+func (s *UserService) validateToken(ctx context.Context, tokenString, expectedType string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.jwtConfig.SecretKey), nil
-	})
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(s.jwtConfig.Issuer),
+		jwt.WithAudience(s.jwtConfig.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(s.jwtConfig.ClockSkew),
+	)
 
-	if err != nil || !token.Valid {
+	if err != nil {
+		return nil, classifyTokenError(err)
+	}
+	if !token.Valid {
 		return nil, domain.ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
+	if claims.Type != expectedType {
+		return nil, domain.ErrInvalidToken
+	}
+
+	// A token issued in one tenant is not accepted in another
+	if tenantID, ok := infrastructure.TenantFrom(ctx); ok && claims.TenantID != tenantClaim(tenantID) {
 		return nil, domain.ErrInvalidToken
 	}
 
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, domain.ErrTokenMalformed
+	}
+
+	revoked, err := s.revocationRepo.WithContext(ctx).IsRevoked(claims.ID, userID, claims.Version)
+	if err != nil {
+		logFor(ctx, s.logger).Error("Failed to check token revocation", zap.Error(err))
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrTokenRevoked
+	}
+
 	return claims, nil
 }
+
+// tenantClaim is the tid claim of tokens for the tenant's users
+func tenantClaim(tenantID uuid.UUID) string {
+	if tenantID == domain.DefaultTenantID {
+		return ""
+	}
+	return tenantID.String()
+}
+
+// classifyTokenError maps JWT library errors to distinct domain token errors
+func classifyTokenError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return domain.ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return domain.ErrTokenNotYetValid
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return domain.ErrTokenInvalidAudience
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return domain.ErrTokenInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenMalformed), errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return domain.ErrTokenMalformed
+	default:
+		return domain.ErrInvalidToken
+	}
+}
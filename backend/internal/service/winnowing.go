@@ -0,0 +1,150 @@
+package service
+
+import (
+	"hash/fnv"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// winnowK is the number of tokens hashed together; matches shorter than
+	// this are ignored as noise
+	winnowK = 5
+	// winnowWindow is how many consecutive hashes one fingerprint is picked
+	// from; any match of winnowK+winnowWindow-1 tokens shares a fingerprint
+	winnowWindow = 4
+)
+
+// codeKeywords are the words kept as they are when code is normalized. Every
+// other identifier becomes the same token, so renaming variables does not
+// hide copied code.
+var codeKeywords = map[string]bool{
+	"if": true, "else": true, "elif": true, "for": true, "while": true, "do": true,
+	"switch": true, "case": true, "default": true, "break": true, "continue": true,
+	"return": true, "yield": true, "goto": true, "pass": true,
+	"def": true, "func": true, "function": true, "lambda": true, "class": true,
+	"struct": true, "new": true, "var": true, "let": true, "const": true,
+	"int": true, "long": true, "float": true, "double": true, "char": true,
+	"bool": true, "boolean": true, "string": true, "void": true, "auto": true,
+	"in": true, "not": true, "and": true, "or": true, "is": true,
+	"try": true, "except": true, "catch": true, "finally": true, "throw": true, "raise": true,
+	"true": true, "false": true, "null": true, "nil": true, "none": true,
+	"range": true, "len": true, "append": true, "map": true, "vector": true,
+}
+
+// normalizeCode splits source code into tokens, dropping comments and
+// whitespace and replacing identifiers, numbers and string literals with
+// placeholders. It understands the comment and string syntax shared by the
+// common contest languages well enough for comparison; it is not a parser.
+func normalizeCode(code string) []string {
+	var tokens []string
+	src := []rune(code)
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '#' || (c == '/' && i+1 < len(src) && src[i+1] == '/'):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			i += 2
+			for i < len(src) && !(src[i] == '*' && i+1 < len(src) && src[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case c == '"' || c == '\'' || c == '`':
+			i++
+			for i < len(src) && src[i] != c && src[i] != '\n' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+			tokens = append(tokens, "s")
+		case unicode.IsDigit(c):
+			for i < len(src) && (unicode.IsDigit(src[i]) || unicode.IsLetter(src[i]) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, "0")
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(src[i]) || unicode.IsDigit(src[i]) || src[i] == '_') {
+				i++
+			}
+			if word := strings.ToLower(string(src[start:i])); codeKeywords[word] {
+				tokens = append(tokens, word)
+			} else {
+				tokens = append(tokens, "v")
+			}
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+// winnow returns the sorted, distinct fingerprints of a token sequence: the
+// hashes of its k-grams, keeping the smallest of every window so that any
+// long enough shared passage is caught with far fewer hashes than k-grams
+func winnow(tokens []string) []uint64 {
+	if len(tokens) < winnowK {
+		return []uint64{}
+	}
+	hashes := make([]uint64, len(tokens)-winnowK+1)
+	for i := range hashes {
+		h := fnv.New64a()
+		for _, t := range tokens[i : i+winnowK] {
+			h.Write([]byte(t))
+			h.Write([]byte{0})
+		}
+		hashes[i] = h.Sum64()
+	}
+
+	picked := make([]uint64, 0, len(hashes)/2+1)
+	last := -1
+	windows := max(len(hashes)-winnowWindow+1, 1)
+	for start := 0; start < windows; start++ {
+		end := min(start+winnowWindow, len(hashes))
+		// The rightmost minimum, so a window sliding past it picks it only once
+		minAt := start
+		for j := start; j < end; j++ {
+			if hashes[j] <= hashes[minAt] {
+				minAt = j
+			}
+		}
+		if minAt != last {
+			picked = append(picked, hashes[minAt])
+			last = minAt
+		}
+	}
+
+	slices.Sort(picked)
+	return slices.Compact(picked)
+}
+
+// fingerprintSimilarity is the Jaccard similarity of two sorted fingerprint
+// sets: the share of all their fingerprints the two have in common
+func fingerprintSimilarity(a, b []uint64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			shared++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,132 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/handler"
+	"github.com/contest-maker-150/backend/internal/middleware"
+)
+
+// Password is the password the auth helpers sign users up with; it passes the
+// password policy
+const Password = "Xq9!vLm2#pRt"
+
+// Client is one signed-in (or anonymous) API user
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+	Auth    handler.AuthResponse // Set by SignUp and Login; its access token is sent with every request
+	Header  http.Header          // Sent with every request, e.g. the tenant header
+}
+
+// APIError is a response with an unexpected status
+type APIError struct {
+	Method, Path string
+	Status       int
+	Body         middleware.ErrorResponse
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: status %d %s: %s", e.Method, e.Path, e.Status, e.Body.Error.Code, e.Body.Error.Message)
+}
+
+// NewClient creates an anonymous client of the API at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTP: &http.Client{Timeout: 30 * time.Second}, Header: make(http.Header)}
+}
+
+// Do sends a request and decodes the response into out when it has the wanted
+// status; any other status is returned as an *APIError
+func (c *Client) Do(method, path string, body interface{}, want int, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.Header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Auth.Tokens != nil {
+		req.Header.Set("Authorization", "Bearer "+c.Auth.Tokens.AccessToken)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != want {
+		apiErr := &APIError{Method: method, Path: path, Status: resp.StatusCode}
+		json.NewDecoder(resp.Body).Decode(&apiErr.Body)
+		return apiErr
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	return nil
+}
+
+// ExpectError sends a request that must fail with the given status and error code
+func (c *Client) ExpectError(method, path string, body interface{}, status int, code string) error {
+	err := c.Do(method, path, body, 0, nil)
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return fmt.Errorf("%s %s: expected %d %s, got %v", method, path, status, code, err)
+	}
+	if apiErr.Status != status || apiErr.Body.Error.Code != code {
+		return fmt.Errorf("%s %s: expected %d %s, got %d %s", method, path, status, code, apiErr.Status, apiErr.Body.Error.Code)
+	}
+	return nil
+}
+
+// SignUp registers a user with Password and signs the client in as them
+func (c *Client) SignUp(email, username string) error {
+	req := domain.UserCreateRequest{Email: email, Username: username, Password: Password}
+	if err := c.Do(http.MethodPost, "/api/auth/signup", req, http.StatusCreated, &c.Auth); err != nil {
+		return fmt.Errorf("sign up %s: %w", username, err)
+	}
+	return nil
+}
+
+// Login signs the client in as an existing user with Password
+func (c *Client) Login(email string) error {
+	req := handler.LoginRequest{Email: email, Password: Password}
+	if err := c.Do(http.MethodPost, "/api/auth/login", req, http.StatusOK, &c.Auth); err != nil {
+		return fmt.Errorf("log in %s: %w", email, err)
+	}
+	return nil
+}
+
+// Logout revokes the client's tokens and signs it out
+func (c *Client) Logout() error {
+	if c.Auth.Tokens == nil {
+		return nil
+	}
+	req := domain.LogoutRequest{RefreshToken: c.Auth.Tokens.RefreshToken}
+	if err := c.Do(http.MethodPost, "/api/auth/logout", req, http.StatusOK, nil); err != nil {
+		return err
+	}
+	c.Auth = handler.AuthResponse{}
+	return nil
+}
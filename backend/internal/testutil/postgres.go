@@ -0,0 +1,98 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+const (
+	postgresImage    = "postgres:16-alpine"
+	postgresUser     = "contest"
+	postgresPassword = "contest"
+	postgresDB       = "contest_maker_test"
+
+	// postgresStartTimeout bounds pulling the image and the server coming up
+	postgresStartTimeout = 2 * time.Minute
+)
+
+// Postgres is a throwaway Postgres server in a container started through
+// testcontainers; it needs a Docker daemon and is removed when it stops
+type Postgres struct {
+	Host string
+	Port int
+
+	container *tcpostgres.PostgresContainer
+}
+
+// StartPostgres starts a Postgres container on a free local port and waits
+// until it accepts connections
+func StartPostgres(ctx context.Context) (pg *Postgres, err error) {
+	// testcontainers panics when it finds no Docker daemon
+	defer func() {
+		if r := recover(); r != nil {
+			pg, err = nil, fmt.Errorf("start %s: %v", postgresImage, r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, postgresStartTimeout)
+	defer cancel()
+
+	container, err := tcpostgres.Run(ctx, postgresImage,
+		tcpostgres.WithUsername(postgresUser),
+		tcpostgres.WithPassword(postgresPassword),
+		tcpostgres.WithDatabase(postgresDB),
+		// The image initializes the database on a server it then restarts, so
+		// wait for the second "ready" line as well as the port
+		tcpostgres.BasicWaitStrategies(),
+	)
+	pg = &Postgres{container: container}
+	if err != nil {
+		pg.Stop()
+		return nil, fmt.Errorf("start %s: %w", postgresImage, err)
+	}
+
+	if pg.Host, err = container.Host(ctx); err != nil {
+		pg.Stop()
+		return nil, fmt.Errorf("postgres host: %w", err)
+	}
+	port, err := container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		pg.Stop()
+		return nil, fmt.Errorf("postgres port: %w", err)
+	}
+	if pg.Port, err = strconv.Atoi(port.Port()); err != nil {
+		pg.Stop()
+		return nil, fmt.Errorf("postgres port %q: %w", port, err)
+	}
+	return pg, nil
+}
+
+// Config points a database configuration at the container
+func (p *Postgres) Config(base infrastructure.DatabaseConfig) infrastructure.DatabaseConfig {
+	base.Driver = infrastructure.DriverPostgres
+	base.Host = p.Host
+	base.Port = p.Port
+	base.User = postgresUser
+	base.Password = postgresPassword
+	base.DBName = postgresDB
+	base.SSLMode = "disable"
+	return base
+}
+
+// Stop terminates the container, which removes it
+func (p *Postgres) Stop() error {
+	if p == nil || p.container == nil {
+		return nil
+	}
+	if err := testcontainers.TerminateContainer(p.container); err != nil {
+		return fmt.Errorf("stop postgres: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Package testutil runs the assembled API in-process for integration runs: a
+// fresh database with the catalog fixtures seeded, the background workers
+// started, and HTTP clients with auth helpers pointed at it. The database is an
+// in-memory SQLite one by default, or a throwaway Postgres container.
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/contest-maker-150/backend/internal/app"
+	"github.com/contest-maker-150/backend/internal/domain"
+	"github.com/contest-maker-150/backend/internal/infrastructure"
+)
+
+// ServerOptions configures NewServer
+type ServerOptions struct {
+	Postgres  bool                         // Run on a throwaway Postgres container instead of in-memory SQLite
+	Configure func(*infrastructure.Config) // Adjusts the configuration before the API is assembled
+	Logger    *zap.Logger                  // Server logs; discarded when nil
+}
+
+// Server is the API served on a local port
+type Server struct {
+	URL      string
+	Database *infrastructure.Database
+
+	api      *app.App
+	http     *httptest.Server
+	postgres *Postgres
+	cancel   context.CancelFunc
+}
+
+// NewServer prepares a fresh database, assembles the API like cmd/api does and
+// serves it. Telemetry, the breach check and load shedding are disabled so a
+// run needs no network beyond the database.
+func NewServer(ctx context.Context, opts ServerOptions) (*Server, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	config := infrastructure.LoadConfig()
+	config.Database.Driver = infrastructure.DriverSQLite
+	config.Database.SQLitePath = ":memory:"
+	config.Telemetry.Enabled = false
+	config.Password.BreachCheckEnabled = false
+	config.LoadShed.Enabled = false
+
+	s := &Server{}
+	if opts.Postgres {
+		pg, err := StartPostgres(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.postgres = pg
+		config.Database = pg.Config(config.Database)
+	}
+	if opts.Configure != nil {
+		opts.Configure(config)
+	}
+
+	if err := s.start(ctx, config, logger); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Server) start(ctx context.Context, config *infrastructure.Config, logger *zap.Logger) error {
+	database, err := infrastructure.NewDatabase(&config.Database, logger)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	s.Database = database
+
+	telemetry, err := infrastructure.NewTelemetry(ctx, &config.Telemetry, &config.Resilience, logger)
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	metrics, err := telemetry.CreateMetrics()
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+
+	// Migrates the schema and seeds the problem catalog
+	if err := app.PrepareDatabase(database, logger); err != nil {
+		return fmt.Errorf("prepare database: %w", err)
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	api, err := app.New(config, database, telemetry, metrics, logger, zap.NewAtomicLevel())
+	if err != nil {
+		return fmt.Errorf("assemble API: %w", err)
+	}
+	s.api = api
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	api.Start(workerCtx)
+
+	s.http = httptest.NewServer(api.Router)
+	s.URL = s.http.URL
+	return nil
+}
+
+// Client returns an anonymous client of the server
+func (s *Server) Client() *Client {
+	return NewClient(s.URL)
+}
+
+// SignUp registers a user named name and returns a client signed in as them
+func (s *Server) SignUp(name string) (*Client, error) {
+	c := s.Client()
+	if err := c.SignUp(name+"@example.com", name); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Promote grants a user a role. Roles other than the default can only be
+// granted in the database; the user signs in again to receive it in a token.
+func (s *Server) Promote(email string, role domain.Role) error {
+	return s.Database.DB.Model(&domain.User{}).Where("email = ?", email).Update("role", role).Error
+}
+
+// Close stops the HTTP server, then the workers, the database and the container
+func (s *Server) Close() error {
+	var errs []error
+	if s.http != nil {
+		s.http.Close()
+	}
+	if s.api != nil {
+		errs = append(errs, s.api.Stop(context.Background()))
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if s.Database != nil {
+		errs = append(errs, s.Database.Close())
+	}
+	errs = append(errs, s.postgres.Stop())
+	return errors.Join(errs...)
+}
@@ -0,0 +1,393 @@
+// Package contestmaker is a typed client for the Contest Maker 150 API.
+//
+// Types and operations are generated from the OpenAPI specification by
+// backend/cmd/clientgen; this file holds the hand-written transport. Requests
+// are retried with jittered exponential backoff when the server sheds load or
+// is briefly unavailable, and an expired access token is refreshed once with
+// the refresh token before the request is repeated.
+//
+//	client := contestmaker.New("https://contest-maker.example.com")
+//	if _, err := client.PostAuthLogin(ctx, &contestmaker.LoginRequest{Email: email, Password: password}); err != nil {
+//		return err
+//	}
+//	contest, err := client.GetContestsActive(ctx)
+package contestmaker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first; 1 disables retries
+	BaseDelay   time.Duration // Backoff before the second attempt, doubled for each further one
+	MaxDelay    time.Duration // Upper bound on a single wait; a longer Retry-After is returned as an error
+}
+
+// DefaultRetryPolicy retries up to three times within a few seconds
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retry      RetryPolicy
+	clientName string
+	onTokens   func(*TokenPair)
+
+	mu        sync.Mutex
+	tokens    *TokenPair
+	refreshMu sync.Mutex // Lets one caller refresh an expired token while the others wait
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
+// WithTokens starts the client with tokens from an earlier session
+func WithTokens(tokens TokenPair) Option {
+	return func(c *Client) { c.tokens = &tokens }
+}
+
+// WithTokenHandler registers a function called whenever the tokens change, so
+// they can be persisted. It receives nil after logging out.
+func WithTokenHandler(fn func(*TokenPair)) Option {
+	return func(c *Client) { c.onTokens = fn }
+}
+
+// WithClientName identifies the integration to the server, which reports
+// callers of deprecated operations by this name
+func WithClientName(name string) Option {
+	return func(c *Client) { c.clientName = name }
+}
+
+// New creates a client for the API at baseURL, e.g. https://contest-maker.example.com
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c
+}
+
+// Tokens returns the current tokens, or nil when the client is not signed in
+func (c *Client) Tokens() *TokenPair {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		return nil
+	}
+	tokens := *c.tokens
+	return &tokens
+}
+
+// SetTokens replaces the tokens; nil signs the client out locally
+func (c *Client) SetTokens(tokens *TokenPair) {
+	c.mu.Lock()
+	if tokens != nil {
+		copied := *tokens
+		tokens = &copied
+	}
+	c.tokens = tokens
+	onTokens := c.onTokens
+	c.mu.Unlock()
+
+	if onTokens != nil {
+		onTokens(tokens)
+	}
+}
+
+func (c *Client) accessToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		return ""
+	}
+	return c.tokens.AccessToken
+}
+
+// ErrNotSignedIn is returned without a request for operations that need
+// tokens when the client has none
+var ErrNotSignedIn = errors.New("contest maker API: not signed in")
+
+// Error is a failed request, carrying the API error envelope
+type Error struct {
+	StatusCode int
+	Code       string // Machine-readable code, e.g. CONTEST_NOT_FOUND
+	Message    string
+	Details    any
+	RequestID  string
+	Retry      string        // "safe", "idempotent" or "never"; empty for responses without the envelope
+	RetryAfter time.Duration // From the Retry-After header, or the envelope's retry_after_seconds
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("contest maker API: HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("contest maker API: %s: %s (HTTP %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// IsCode reports whether err is an API error with the given code
+func IsCode(err error, code string) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// request describes one API call; generated operations fill it in
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	auth   bool // Requires signing in; the token is sent whenever the client has one
+}
+
+// do sends the request, retrying and refreshing the access token as needed,
+// and decodes a successful response into out
+func (c *Client) do(ctx context.Context, req request, out any) error {
+	var payload []byte
+	if req.body != nil {
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return fmt.Errorf("encode request body: %w", err)
+		}
+	}
+
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		token := c.accessToken()
+		if req.auth && token == "" {
+			return ErrNotSignedIn
+		}
+
+		resp, err := c.send(ctx, req, payload, token)
+		if err != nil {
+			if ctx.Err() != nil || !idempotent(req.method) || attempt >= c.retry.MaxAttempts {
+				return err
+			}
+			if err := sleep(ctx, c.backoff(attempt)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if resp.StatusCode < http.StatusMultipleChoices {
+			err := decode(resp, out)
+			if err == nil {
+				c.captureTokens(req.path, out)
+			}
+			return err
+		}
+
+		apiErr := readError(resp)
+		if apiErr.StatusCode == http.StatusUnauthorized && apiErr.Code == "TOKEN_EXPIRED" && token != "" && !refreshed {
+			refreshed = true
+			if c.refresh(ctx, token) != nil {
+				return apiErr
+			}
+			attempt-- // A refresh does not use up a retry
+			continue
+		}
+
+		if !retryable(req.method, apiErr) || attempt >= c.retry.MaxAttempts {
+			return apiErr
+		}
+		delay := c.backoff(attempt)
+		if apiErr.RetryAfter > 0 {
+			if apiErr.RetryAfter > c.retry.MaxDelay {
+				return apiErr // e.g. maintenance; waiting here would only stall the caller
+			}
+			delay = apiErr.RetryAfter
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request, payload []byte, token string) (*http.Response, error) {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.clientName != "" {
+		httpReq.Header.Set("X-Client-Name", c.clientName)
+	}
+	return c.httpClient.Do(httpReq)
+}
+
+// refresh exchanges the refresh token for new tokens unless another caller
+// already replaced the expired access token
+func (c *Client) refresh(ctx context.Context, expired string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	tokens := c.Tokens()
+	if tokens == nil || tokens.RefreshToken == "" {
+		return errors.New("no refresh token")
+	}
+	if tokens.AccessToken != expired {
+		return nil
+	}
+	_, err := c.PostAuthRefresh(ctx, &RefreshRequest{RefreshToken: tokens.RefreshToken})
+	return err
+}
+
+// captureTokens keeps the tokens issued by signup, login and refresh, and
+// forgets them after logging out
+func (c *Client) captureTokens(path string, out any) {
+	switch v := out.(type) {
+	case *AuthResponse:
+		c.SetTokens(&v.Tokens)
+	case *PostAuthRefreshResponse:
+		c.SetTokens(&v.Tokens)
+	}
+	switch path {
+	case "/api/auth/logout", "/api/auth/logout-all":
+		c.SetTokens(nil)
+	}
+}
+
+// backoff returns a random wait of up to BaseDelay·2^(attempt-1), capped at MaxDelay
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := c.retry.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > c.retry.MaxDelay {
+		ceiling = c.retry.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+// idempotent methods can be retried after a network error without risking a
+// duplicate write
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether a failed request is worth another attempt, going by
+// the retry class of the error envelope. Responses without one, e.g. from a
+// proxy, go by status: 429 and 503 are answered before the handler runs, so any
+// method can be retried.
+func retryable(method string, apiErr *Error) bool {
+	switch apiErr.Retry {
+	case "safe":
+		return true
+	case "idempotent":
+		return idempotent(method)
+	case "never":
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(method)
+	}
+	return false
+}
+
+func decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	if out == nil {
+		_, err := io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", resp.Request.URL.Path, err)
+	}
+	return nil
+}
+
+func readError(resp *http.Response) *Error {
+	defer resp.Body.Close()
+	apiErr := &Error{StatusCode: resp.StatusCode, RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+
+	var envelope ErrorResponse
+	if json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&envelope) == nil {
+		apiErr.Code = envelope.Error.Code
+		apiErr.Message = envelope.Error.Message
+		apiErr.Details = envelope.Error.Details
+		apiErr.RequestID = envelope.Error.RequestID
+		apiErr.Retry = envelope.Error.Retry
+		if apiErr.RetryAfter == 0 && envelope.Error.RetryAfterSeconds > 0 {
+			apiErr.RetryAfter = time.Duration(envelope.Error.RetryAfterSeconds) * time.Second
+		}
+	}
+	return apiErr
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
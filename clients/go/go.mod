@@ -0,0 +1,3 @@
+module github.com/contest-maker-150/clients/go
+
+go 1.25.0
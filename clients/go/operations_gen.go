@@ -0,0 +1,2049 @@
+// Code generated by backend/cmd/clientgen from backend/api/openapi.json. DO NOT EDIT.
+
+package contestmaker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetAdminAnalyticsCohorts calls GET /api/admin/analytics/cohorts: Weekly signup cohorts with retention and contest activity
+func (c *Client) GetAdminAnalyticsCohorts(ctx context.Context) (*CohortsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/analytics/cohorts", auth: true}
+	var out CohortsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminBackups calls GET /api/admin/backups: List the stored backups, newest first
+func (c *Client) GetAdminBackups(ctx context.Context) (*BackupListResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/backups", auth: true}
+	var out BackupListResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminBackups calls POST /api/admin/backups: Back up users, custom problems, contests, submissions and attempts to object storage
+func (c *Client) PostAdminBackups(ctx context.Context) (*BackupManifest, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/backups", auth: true}
+	var out BackupManifest
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminBackupsIDVerify calls POST /api/admin/backups/{id}/verify: Check the checksums and row counts of a stored backup
+func (c *Client) PostAdminBackupsIDVerify(ctx context.Context, id string) (*BackupManifest, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/backups/" + url.PathEscape(id) + "/verify", auth: true}
+	var out BackupManifest
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminDigestsSend calls POST /api/admin/digests/send: Send the recommendation digests that are due now
+func (c *Client) PostAdminDigestsSend(ctx context.Context) (*DigestRunReport, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/digests/send", auth: true}
+	var out DigestRunReport
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminDigestsStatsParams holds the optional query parameters of GetAdminDigestsStats; zero values are omitted
+type GetAdminDigestsStatsParams struct {
+	// Digests sent in this many days (1-365, default 28)
+	Days int
+}
+
+func (p *GetAdminDigestsStatsParams) values() url.Values {
+	q := url.Values{}
+	if p.Days != 0 {
+		q.Set("days", strconv.FormatInt(int64(p.Days), 10))
+	}
+	return q
+}
+
+// GetAdminDigestsStats calls GET /api/admin/digests/stats: Click-through and solve rates of recent recommendation digests
+func (c *Client) GetAdminDigestsStats(ctx context.Context, params *GetAdminDigestsStatsParams) (*DigestStats, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/digests/stats", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out DigestStats
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminExperiments calls GET /api/admin/experiments: Completion rates per experiment variant
+func (c *Client) GetAdminExperiments(ctx context.Context) (*ExperimentsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/experiments", auth: true}
+	var out ExperimentsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminFeatureFlags calls GET /api/admin/feature-flags: List feature flags and their rollout
+func (c *Client) GetAdminFeatureFlags(ctx context.Context) (*FeatureFlagListResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/feature-flags", auth: true}
+	var out FeatureFlagListResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAdminFeatureFlagsKey calls PUT /api/admin/feature-flags/{key}: Turn a feature flag on or off for a share of users
+func (c *Client) PutAdminFeatureFlagsKey(ctx context.Context, key string, body *UpdateFeatureFlagRequest) (*FeatureFlag, error) {
+	req := request{method: http.MethodPut, path: "/api/admin/feature-flags/" + url.PathEscape(key), auth: true}
+	req.body = body
+	var out FeatureFlag
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminIntegrity calls POST /api/admin/integrity: Detect and repair inconsistent data and recompute progress and usage counters
+func (c *Client) PostAdminIntegrity(ctx context.Context, body *RunIntegrityRequest) (*IntegrityReport, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/integrity", auth: true}
+	req.body = body
+	var out IntegrityReport
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminListsParams holds the optional query parameters of GetAdminLists; zero values are omitted
+type GetAdminListsParams struct {
+	// published or hidden; every reported list when omitted
+	Status string
+	// Page size (1-100, default 20)
+	Limit int
+	// Lists to skip
+	Offset int
+}
+
+func (p *GetAdminListsParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != "" {
+		q.Set("status", p.Status)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	if p.Offset != 0 {
+		q.Set("offset", strconv.FormatInt(int64(p.Offset), 10))
+	}
+	return q
+}
+
+// GetAdminLists calls GET /api/admin/lists: Moderation queue of community lists, most reported first
+func (c *Client) GetAdminLists(ctx context.Context, params *GetAdminListsParams) (*ProblemListPage, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/lists", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ProblemListPage
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchAdminListsID calls PATCH /api/admin/lists/{id}: Hide or restore a community list; restoring clears its reports
+func (c *Client) PatchAdminListsID(ctx context.Context, id string, body *ModerateProblemListRequest) (*ProblemListSummary, error) {
+	req := request{method: http.MethodPatch, path: "/api/admin/lists/" + url.PathEscape(id), auth: true}
+	req.body = body
+	var out ProblemListSummary
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminLogLevel calls GET /api/admin/log-level: Log level in effect
+func (c *Client) GetAdminLogLevel(ctx context.Context) (*LogLevelStatus, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/log-level", auth: true}
+	var out LogLevelStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAdminLogLevel calls PUT /api/admin/log-level: Change the log level of every instance at runtime
+func (c *Client) PutAdminLogLevel(ctx context.Context, body *SetLogLevelRequest) (*LogLevelStatus, error) {
+	req := request{method: http.MethodPut, path: "/api/admin/log-level", auth: true}
+	req.body = body
+	var out LogLevelStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAdminMaintenance calls PUT /api/admin/maintenance: Start, schedule or end maintenance
+func (c *Client) PutAdminMaintenance(ctx context.Context, body *SetMaintenanceRequest) (*MaintenanceStatus, error) {
+	req := request{method: http.MethodPut, path: "/api/admin/maintenance", auth: true}
+	req.body = body
+	var out MaintenanceStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminProblemsCalibration calls GET /api/admin/problems/calibration: Per-problem usage counters
+func (c *Client) GetAdminProblemsCalibration(ctx context.Context) (*GetAdminProblemsCalibrationResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/problems/calibration", auth: true}
+	var out GetAdminProblemsCalibrationResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAdminProblemsIDCompanies calls PUT /api/admin/problems/{id}/companies: Replace problem company tags
+func (c *Client) PutAdminProblemsIDCompanies(ctx context.Context, id string, body *SetProblemCompaniesRequest) (*ProblemResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/admin/problems/" + url.PathEscape(id) + "/companies", auth: true}
+	req.body = body
+	var out ProblemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAdminProblemsIDComplexity calls PUT /api/admin/problems/{id}/complexity: Set the canonical complexity of a problem
+func (c *Client) PutAdminProblemsIDComplexity(ctx context.Context, id string, body *SetProblemComplexityRequest) (*ProblemComplexity, error) {
+	req := request{method: http.MethodPut, path: "/api/admin/problems/" + url.PathEscape(id) + "/complexity", auth: true}
+	req.body = body
+	var out ProblemComplexity
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchAdminProblemsIDImportance calls PATCH /api/admin/problems/{id}/importance: Tune problem importance score
+func (c *Client) PatchAdminProblemsIDImportance(ctx context.Context, id string, body *SetProblemImportanceRequest) (*ProblemResponse, error) {
+	req := request{method: http.MethodPatch, path: "/api/admin/problems/" + url.PathEscape(id) + "/importance", auth: true}
+	req.body = body
+	var out ProblemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminRetention calls POST /api/admin/retention: Delete data older than its configured retention and report what each policy found
+func (c *Client) PostAdminRetention(ctx context.Context, body *RunRetentionRequest) (*RetentionReport, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/retention", auth: true}
+	req.body = body
+	var out RetentionReport
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminSimilarityCheck calls POST /api/admin/similarity/check: Compare the solution snippets changed since the last similarity round
+func (c *Client) PostAdminSimilarityCheck(ctx context.Context) (*SimilarityRunReport, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/similarity/check", auth: true}
+	var out SimilarityRunReport
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminTenants calls GET /api/admin/tenants: List the hosted tenants
+func (c *Client) GetAdminTenants(ctx context.Context) (*GetAdminTenantsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/tenants", auth: true}
+	var out GetAdminTenantsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminTenants calls POST /api/admin/tenants: Host a new tenant with isolated users, organizations and leaderboard
+func (c *Client) PostAdminTenants(ctx context.Context, body *CreateTenantRequest) (*Tenant, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/tenants", auth: true}
+	req.body = body
+	var out Tenant
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAdminUsersIDQuotas calls DELETE /api/admin/users/{id}/quotas: Drop a user's quota override
+func (c *Client) DeleteAdminUsersIDQuotas(ctx context.Context, id string) (*QuotaStatus, error) {
+	req := request{method: http.MethodDelete, path: "/api/admin/users/" + url.PathEscape(id) + "/quotas", auth: true}
+	var out QuotaStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminUsersIDQuotas calls GET /api/admin/users/{id}/quotas: Plan and remaining allowances of a user
+func (c *Client) GetAdminUsersIDQuotas(ctx context.Context, id string) (*QuotaStatus, error) {
+	req := request{method: http.MethodGet, path: "/api/admin/users/" + url.PathEscape(id) + "/quotas", auth: true}
+	var out QuotaStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutAdminUsersIDQuotas calls PUT /api/admin/users/{id}/quotas: Set a user's plan and override their quotas
+func (c *Client) PutAdminUsersIDQuotas(ctx context.Context, id string, body *SetQuotaOverrideRequest) (*QuotaStatus, error) {
+	req := request{method: http.MethodPut, path: "/api/admin/users/" + url.PathEscape(id) + "/quotas", auth: true}
+	req.body = body
+	var out QuotaStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAdminUsersIDRevokeTokens calls POST /api/admin/users/{id}/revoke-tokens: Sign a user out on all devices
+func (c *Client) PostAdminUsersIDRevokeTokens(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/admin/users/" + url.PathEscape(id) + "/revoke-tokens", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAssignments calls GET /api/assignments: The caller's unfinished assignments from their organizations and mentors, soonest due first
+func (c *Client) GetAssignments(ctx context.Context) (*GetAssignmentsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/assignments", auth: true}
+	var out GetAssignmentsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAuthLogin calls POST /api/auth/login: Login user
+func (c *Client) PostAuthLogin(ctx context.Context, body *LoginRequest) (*AuthResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/auth/login", auth: false}
+	req.body = body
+	var out AuthResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAuthLogout calls POST /api/auth/logout: Revoke the current access token and optionally its refresh token
+func (c *Client) PostAuthLogout(ctx context.Context, body *LogoutRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/auth/logout", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAuthLogoutAll calls POST /api/auth/logout-all: Revoke every token of the current user
+func (c *Client) PostAuthLogoutAll(ctx context.Context) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/auth/logout-all", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAuthRefresh calls POST /api/auth/refresh: Refresh access token
+func (c *Client) PostAuthRefresh(ctx context.Context, body *RefreshRequest) (*PostAuthRefreshResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/auth/refresh", auth: false}
+	req.body = body
+	var out PostAuthRefreshResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostAuthSignup calls POST /api/auth/signup: Register new user
+func (c *Client) PostAuthSignup(ctx context.Context, body *UserCreateRequest) (*AuthResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/auth/signup", auth: false}
+	req.body = body
+	var out AuthResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostBillingCheckout calls POST /api/billing/checkout: Start a premium subscription checkout
+func (c *Client) PostBillingCheckout(ctx context.Context) (*CheckoutSessionResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/billing/checkout", auth: true}
+	var out CheckoutSessionResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostBillingWebhook calls POST /api/billing/webhook: Receive a signed Stripe webhook event
+func (c *Client) PostBillingWebhook(ctx context.Context, body *PostBillingWebhookRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/billing/webhook", auth: false}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetChallengesCode calls GET /api/challenges/{code}: Get challenge invite
+func (c *Client) GetChallengesCode(ctx context.Context, code string) (*ChallengeResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/challenges/" + url.PathEscape(code), auth: true}
+	var out ChallengeResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostChallengesCodeAccept calls POST /api/challenges/{code}/accept: Accept challenge and start its contest
+func (c *Client) PostChallengesCodeAccept(ctx context.Context, code string) (*ContestResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/challenges/" + url.PathEscape(code) + "/accept", auth: true}
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetChallengesCodeChatParams holds the optional query parameters of GetChallengesCodeChat; zero values are omitted
+type GetChallengesCodeChatParams struct {
+	// Only messages created after this time (RFC 3339); without it the latest messages
+	Since string
+	// Maximum number of messages (1-100, default 50)
+	Limit int
+}
+
+func (p *GetChallengesCodeChatParams) values() url.Values {
+	q := url.Values{}
+	if p.Since != "" {
+		q.Set("since", p.Since)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetChallengesCodeChat calls GET /api/challenges/{code}/chat: Read the challenge chat
+func (c *Client) GetChallengesCodeChat(ctx context.Context, code string, params *GetChallengesCodeChatParams) (*ChatMessages, error) {
+	req := request{method: http.MethodGet, path: "/api/challenges/" + url.PathEscape(code) + "/chat", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ChatMessages
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostChallengesCodeChat calls POST /api/challenges/{code}/chat: Post to the challenge chat
+func (c *Client) PostChallengesCodeChat(ctx context.Context, code string, body *PostChatMessageRequest) (*ChatMessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/challenges/" + url.PathEscape(code) + "/chat", auth: true}
+	req.body = body
+	var out ChatMessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetChallengesCodeComparison calls GET /api/challenges/{code}/comparison: Compare challenge results
+func (c *Client) GetChallengesCodeComparison(ctx context.Context, code string) (*ChallengeComparison, error) {
+	req := request{method: http.MethodGet, path: "/api/challenges/" + url.PathEscape(code) + "/comparison", auth: true}
+	var out ChallengeComparison
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteChallengesCodeSpectators calls DELETE /api/challenges/{code}/spectators: Close the spectator invite
+func (c *Client) DeleteChallengesCodeSpectators(ctx context.Context, code string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/challenges/" + url.PathEscape(code) + "/spectators", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostChallengesCodeSpectators calls POST /api/challenges/{code}/spectators: Invite read-only spectators of the live standings
+func (c *Client) PostChallengesCodeSpectators(ctx context.Context, code string, body *SpectatorInviteRequest) (*SpectatorInvite, error) {
+	req := request{method: http.MethodPost, path: "/api/challenges/" + url.PathEscape(code) + "/spectators", auth: true}
+	req.body = body
+	var out SpectatorInvite
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetChallengesCodeStandings calls GET /api/challenges/{code}/standings: Live challenge standings with participant presence
+func (c *Client) GetChallengesCodeStandings(ctx context.Context, code string) (*ChallengeStandings, error) {
+	req := request{method: http.MethodGet, path: "/api/challenges/" + url.PathEscape(code) + "/standings", auth: true}
+	var out ChallengeStandings
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCompanies calls GET /api/companies: List companies with tagged problem counts
+func (c *Client) GetCompanies(ctx context.Context) (*GetCompaniesResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/companies", auth: false}
+	var out GetCompaniesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsParams holds the optional query parameters of GetContests; zero values are omitted
+type GetContestsParams struct {
+	// Only contests whose retro notes contain this text
+	Q string
+	// Only contests carrying this tag
+	Tag string
+	// Only contests in this status (active, completed, abandoned, pending)
+	Status string
+	// Only contests created on or after this UTC day (YYYY-MM-DD)
+	From string
+	// Only contests created on or before this UTC day (YYYY-MM-DD)
+	To string
+	// Only contests lasting at least this many minutes
+	MinDuration int
+	// Only contests lasting at most this many minutes
+	MaxDuration int
+	// Only contests with at least this many solved problems
+	MinScore int
+	// Only contests with at most this many solved problems
+	MaxScore int
+	// Only contests containing this problem, by ID or slug
+	Problem string
+}
+
+func (p *GetContestsParams) values() url.Values {
+	q := url.Values{}
+	if p.Q != "" {
+		q.Set("q", p.Q)
+	}
+	if p.Tag != "" {
+		q.Set("tag", p.Tag)
+	}
+	if p.Status != "" {
+		q.Set("status", p.Status)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	if p.MinDuration != 0 {
+		q.Set("min_duration", strconv.FormatInt(int64(p.MinDuration), 10))
+	}
+	if p.MaxDuration != 0 {
+		q.Set("max_duration", strconv.FormatInt(int64(p.MaxDuration), 10))
+	}
+	if p.MinScore != 0 {
+		q.Set("min_score", strconv.FormatInt(int64(p.MinScore), 10))
+	}
+	if p.MaxScore != 0 {
+		q.Set("max_score", strconv.FormatInt(int64(p.MaxScore), 10))
+	}
+	if p.Problem != "" {
+		q.Set("problem", p.Problem)
+	}
+	return q
+}
+
+// GetContests calls GET /api/contests: List and filter user's contests
+func (c *Client) GetContests(ctx context.Context, params *GetContestsParams) (*GetContestsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/contests", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out GetContestsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContests calls POST /api/contests: Create new contest
+func (c *Client) PostContests(ctx context.Context, body *CreateContestRequest) (*ContestResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests", auth: true}
+	req.body = body
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsActive calls GET /api/contests/active: Get active contest
+func (c *Client) GetContestsActive(ctx context.Context) (*GetContestsActiveResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/active", auth: true}
+	var out GetContestsActiveResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsAvailabilityParams holds the optional query parameters of GetContestsAvailability; zero values are omitted
+type GetContestsAvailabilityParams struct {
+	// Only problems whose prerequisites you solved
+	RespectPrerequisites bool
+	// Only problems tagged with this company (repeatable)
+	Company []string
+	// Count your own custom problems too
+	IncludeCustom bool
+}
+
+func (p *GetContestsAvailabilityParams) values() url.Values {
+	q := url.Values{}
+	if p.RespectPrerequisites {
+		q.Set("respect_prerequisites", "true")
+	}
+	for _, v := range p.Company {
+		q.Add("company", v)
+	}
+	if p.IncludeCustom {
+		q.Set("include_custom", "true")
+	}
+	return q
+}
+
+// GetContestsAvailability calls GET /api/contests/availability: Count the unsolved problems left for random contests, by difficulty and topic
+func (c *Client) GetContestsAvailability(ctx context.Context, params *GetContestsAvailabilityParams) (*ProblemAvailability, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/availability", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ProblemAvailability
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsImport calls POST /api/contests/import: Start a contest from a shared contest template
+func (c *Client) PostContestsImport(ctx context.Context, body *ImportContestTemplateRequest) (*ContestTemplateImport, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/import", auth: true}
+	req.body = body
+	var out ContestTemplateImport
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsTagsParams holds the optional query parameters of GetContestsTags; zero values are omitted
+type GetContestsTagsParams struct {
+	// Only tags starting with this text
+	Prefix string
+	// Maximum number of suggestions (1-50, default 10)
+	Limit int
+}
+
+func (p *GetContestsTagsParams) values() url.Values {
+	q := url.Values{}
+	if p.Prefix != "" {
+		q.Set("prefix", p.Prefix)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetContestsTags calls GET /api/contests/tags: Autocomplete contest tags
+func (c *Client) GetContestsTags(ctx context.Context, params *GetContestsTagsParams) (*GetContestsTagsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/tags", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out GetContestsTagsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsID calls GET /api/contests/{id}: Get contest by ID
+func (c *Client) GetContestsID(ctx context.Context, id string) (*ContestResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/" + url.PathEscape(id), auth: true}
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDAbandon calls POST /api/contests/{id}/abandon: Abandon contest
+func (c *Client) PostContestsIDAbandon(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/abandon", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDChallenge calls POST /api/contests/{id}/challenge: Challenge a friend to the same contest
+func (c *Client) PostContestsIDChallenge(ctx context.Context, id string, body *CreateChallengeRequest) (*ChallengeResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/challenge", auth: true}
+	req.body = body
+	var out ChallengeResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDComplete calls POST /api/contests/{id}/complete: Complete contest
+func (c *Client) PostContestsIDComplete(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/complete", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDEvents calls POST /api/contests/{id}/events: Report focus and tab switch events of a running organization assignment contest
+func (c *Client) PostContestsIDEvents(ctx context.Context, id string, body *RecordContestEventsRequest) (*PostContestsIDEventsResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/events", auth: true}
+	req.body = body
+	var out PostContestsIDEventsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchContestsIDProblemsProblemID calls PATCH /api/contests/{id}/problems/{problemId}: Mark problem complete
+func (c *Client) PatchContestsIDProblemsProblemID(ctx context.Context, id string, problemID string, body *MarkProblemCompleteRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPatch, path: "/api/contests/" + url.PathEscape(id) + "/problems/" + url.PathEscape(problemID), auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDProblemsProblemIDAttempts calls POST /api/contests/{id}/problems/{problemId}/attempts: Record a failed or solved attempt at a contest problem
+func (c *Client) PostContestsIDProblemsProblemIDAttempts(ctx context.Context, id string, problemID string, body *RecordAttemptRequest) (*AttemptHistory, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/problems/" + url.PathEscape(problemID) + "/attempts", auth: true}
+	req.body = body
+	var out AttemptHistory
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutContestsIDProblemsProblemIDComplexity calls PUT /api/contests/{id}/problems/{problemId}/complexity: State the complexity of a completed problem's solution
+func (c *Client) PutContestsIDProblemsProblemIDComplexity(ctx context.Context, id string, problemID string, body *StateComplexityRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/contests/" + url.PathEscape(id) + "/problems/" + url.PathEscape(problemID) + "/complexity", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsIDProblemsProblemIDSolution calls GET /api/contests/{id}/problems/{problemId}/solution: Get the solution snippet attached to a contest problem
+func (c *Client) GetContestsIDProblemsProblemIDSolution(ctx context.Context, id string, problemID string) (*SolutionSnippet, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/" + url.PathEscape(id) + "/problems/" + url.PathEscape(problemID) + "/solution", auth: true}
+	var out SolutionSnippet
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutContestsIDProblemsProblemIDSolution calls PUT /api/contests/{id}/problems/{problemId}/solution: Attach a solution snippet to a contest problem, replacing the previous one
+func (c *Client) PutContestsIDProblemsProblemIDSolution(ctx context.Context, id string, problemID string, body *AttachSolutionRequest) (*SolutionSnippet, error) {
+	req := request{method: http.MethodPut, path: "/api/contests/" + url.PathEscape(id) + "/problems/" + url.PathEscape(problemID) + "/solution", auth: true}
+	req.body = body
+	var out SolutionSnippet
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDProblemsProblemIDStart calls POST /api/contests/{id}/problems/{problemId}/start: Start the timer of a contest problem
+func (c *Client) PostContestsIDProblemsProblemIDStart(ctx context.Context, id string, problemID string) (*ContestResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/problems/" + url.PathEscape(problemID) + "/start", auth: true}
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutContestsIDRating calls PUT /api/contests/{id}/rating: Rate how hard a finished contest was, tuning the mix of later ones
+func (c *Client) PutContestsIDRating(ctx context.Context, id string, body *RateContestRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/contests/" + url.PathEscape(id) + "/rating", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchContestsIDRetro calls PATCH /api/contests/{id}/retro: Save contest retro notes
+func (c *Client) PatchContestsIDRetro(ctx context.Context, id string, body *UpdateRetroRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPatch, path: "/api/contests/" + url.PathEscape(id) + "/retro", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostContestsIDStart calls POST /api/contests/{id}/start: End warmup, or start an assigned pending contest, and start the contest timer
+func (c *Client) PostContestsIDStart(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/contests/" + url.PathEscape(id) + "/start", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsIDState calls GET /api/contests/{id}/state: Get the contest's session state: the problem on screen and scratch notes
+func (c *Client) GetContestsIDState(ctx context.Context, id string) (*ContestSession, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/" + url.PathEscape(id) + "/state", auth: true}
+	var out ContestSession
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutContestsIDState calls PUT /api/contests/{id}/state: Save the contest's session state, failing when another device saved since it was read
+func (c *Client) PutContestsIDState(ctx context.Context, id string, body *UpdateContestSessionRequest) (*ContestSession, error) {
+	req := request{method: http.MethodPut, path: "/api/contests/" + url.PathEscape(id) + "/state", auth: true}
+	req.body = body
+	var out ContestSession
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutContestsIDTags calls PUT /api/contests/{id}/tags: Replace contest tags
+func (c *Client) PutContestsIDTags(ctx context.Context, id string, body *SetContestTagsRequest) (*PutContestsIDTagsResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/contests/" + url.PathEscape(id) + "/tags", auth: true}
+	req.body = body
+	var out PutContestsIDTagsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetContestsIDTemplate calls GET /api/contests/{id}/template: Export a contest as a signed, shareable template
+func (c *Client) GetContestsIDTemplate(ctx context.Context, id string) (*SignedContestTemplate, error) {
+	req := request{method: http.MethodGet, path: "/api/contests/" + url.PathEscape(id) + "/template", auth: true}
+	var out SignedContestTemplate
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchContestsIDWarmup calls PATCH /api/contests/{id}/warmup: Mark warmup problem complete
+func (c *Client) PatchContestsIDWarmup(ctx context.Context, id string, body *MarkProblemCompleteRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPatch, path: "/api/contests/" + url.PathEscape(id) + "/warmup", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetListsParams holds the optional query parameters of GetLists; zero values are omitted
+type GetListsParams struct {
+	// Only lists whose title or description contain this text
+	Q string
+	// popular (most subscribers, default) or newest
+	Sort string
+	// Only lists you subscribe to
+	Subscribed bool
+	// Only your own lists, hidden ones included
+	Mine bool
+	// Page size (1-100, default 20)
+	Limit int
+	// Lists to skip
+	Offset int
+}
+
+func (p *GetListsParams) values() url.Values {
+	q := url.Values{}
+	if p.Q != "" {
+		q.Set("q", p.Q)
+	}
+	if p.Sort != "" {
+		q.Set("sort", p.Sort)
+	}
+	if p.Subscribed {
+		q.Set("subscribed", "true")
+	}
+	if p.Mine {
+		q.Set("mine", "true")
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	if p.Offset != 0 {
+		q.Set("offset", strconv.FormatInt(int64(p.Offset), 10))
+	}
+	return q
+}
+
+// GetLists calls GET /api/lists: Browse published community problem lists
+func (c *Client) GetLists(ctx context.Context, params *GetListsParams) (*ProblemListPage, error) {
+	req := request{method: http.MethodGet, path: "/api/lists", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ProblemListPage
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostLists calls POST /api/lists: Publish a curated, ordered list of catalog problems
+func (c *Client) PostLists(ctx context.Context, body *ProblemListRequest) (*ProblemListResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/lists", auth: true}
+	req.body = body
+	var out ProblemListResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteListsID calls DELETE /api/lists/{id}: Delete one of your lists
+func (c *Client) DeleteListsID(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/lists/" + url.PathEscape(id), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetListsID calls GET /api/lists/{id}: Get a community list with its problems and which you solved
+func (c *Client) GetListsID(ctx context.Context, id string) (*ProblemListResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/lists/" + url.PathEscape(id), auth: true}
+	var out ProblemListResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutListsID calls PUT /api/lists/{id}: Replace one of your lists
+func (c *Client) PutListsID(ctx context.Context, id string, body *ProblemListRequest) (*ProblemListResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/lists/" + url.PathEscape(id), auth: true}
+	req.body = body
+	var out ProblemListResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostListsIDContests calls POST /api/lists/{id}/contests: Start a contest from the next problems of a list you own or subscribe to
+func (c *Client) PostListsIDContests(ctx context.Context, id string, body *CreateListContestRequest) (*ContestResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/lists/" + url.PathEscape(id) + "/contests", auth: true}
+	req.body = body
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostListsIDReports calls POST /api/lists/{id}/reports: Report a community list to the moderators
+func (c *Client) PostListsIDReports(ctx context.Context, id string, body *ReportProblemListRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/lists/" + url.PathEscape(id) + "/reports", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteListsIDSubscription calls DELETE /api/lists/{id}/subscription: Unsubscribe from a community list
+func (c *Client) DeleteListsIDSubscription(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/lists/" + url.PathEscape(id) + "/subscription", auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutListsIDSubscription calls PUT /api/lists/{id}/subscription: Subscribe to a community list
+func (c *Client) PutListsIDSubscription(ctx context.Context, id string) (*ProblemListSummary, error) {
+	req := request{method: http.MethodPut, path: "/api/lists/" + url.PathEscape(id) + "/subscription", auth: true}
+	var out ProblemListSummary
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMaintenance calls GET /api/maintenance: Ongoing or upcoming maintenance
+func (c *Client) GetMaintenance(ctx context.Context) (*MaintenanceStatus, error) {
+	req := request{method: http.MethodGet, path: "/api/maintenance", auth: false}
+	var out MaintenanceStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorships calls GET /api/mentorships: The caller's mentorships as mentor and as mentee
+func (c *Client) GetMentorships(ctx context.Context) (*MentorshipList, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships", auth: true}
+	var out MentorshipList
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostMentorships calls POST /api/mentorships: Invite a user, by email, to be mentored by the caller
+func (c *Client) PostMentorships(ctx context.Context, body *CreateMentorshipRequest) (*Mentorship, error) {
+	req := request{method: http.MethodPost, path: "/api/mentorships", auth: true}
+	req.body = body
+	var out Mentorship
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteMentorshipsID calls DELETE /api/mentorships/{id}: Revoke a mentorship or withdraw an invite (either side)
+func (c *Client) DeleteMentorshipsID(ctx context.Context, id string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/mentorships/" + url.PathEscape(id), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostMentorshipsIDAccept calls POST /api/mentorships/{id}/accept: Consent to a mentorship invite (mentee)
+func (c *Client) PostMentorshipsIDAccept(ctx context.Context, id string) (*Mentorship, error) {
+	req := request{method: http.MethodPost, path: "/api/mentorships/" + url.PathEscape(id) + "/accept", auth: true}
+	var out Mentorship
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorshipsIDAssignments calls GET /api/mentorships/{id}/assignments: Assignments with the mentee's progress (either side)
+func (c *Client) GetMentorshipsIDAssignments(ctx context.Context, id string) (*GetMentorshipsIDAssignmentsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships/" + url.PathEscape(id) + "/assignments", auth: true}
+	var out GetMentorshipsIDAssignmentsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostMentorshipsIDAssignments calls POST /api/mentorships/{id}/assignments: Assign a contest or problem set with a due date (mentor); problem sets are pushed to the mentee as a pending contest
+func (c *Client) PostMentorshipsIDAssignments(ctx context.Context, id string, body *CreateMentorAssignmentRequest) (*MentorAssignmentResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/mentorships/" + url.PathEscape(id) + "/assignments", auth: true}
+	req.body = body
+	var out MentorAssignmentResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteMentorshipsIDAssignmentsAssignmentID calls DELETE /api/mentorships/{id}/assignments/{assignmentId}: Delete an assignment (mentor)
+func (c *Client) DeleteMentorshipsIDAssignmentsAssignmentID(ctx context.Context, id string, assignmentID string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/mentorships/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostMentorshipsIDAssignmentsAssignmentIDStart calls POST /api/mentorships/{id}/assignments/{assignmentId}/start: Start the contest of an assignment (mentee)
+func (c *Client) PostMentorshipsIDAssignmentsAssignmentIDStart(ctx context.Context, id string, assignmentID string) (*ContestResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/mentorships/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID) + "/start", auth: true}
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorshipsIDAuditParams holds the optional query parameters of GetMentorshipsIDAudit; zero values are omitted
+type GetMentorshipsIDAuditParams struct {
+	// Maximum number of entries (1-200, default 50)
+	Limit int
+}
+
+func (p *GetMentorshipsIDAuditParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetMentorshipsIDAudit calls GET /api/mentorships/{id}/audit: Audit trail of consent changes and mentor reads (either side)
+func (c *Client) GetMentorshipsIDAudit(ctx context.Context, id string, params *GetMentorshipsIDAuditParams) (*MentorshipAudit, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships/" + url.PathEscape(id) + "/audit", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out MentorshipAudit
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorshipsIDContests calls GET /api/mentorships/{id}/contests: The mentee's contests (mentor)
+func (c *Client) GetMentorshipsIDContests(ctx context.Context, id string) (*GetMentorshipsIDContestsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships/" + url.PathEscape(id) + "/contests", auth: true}
+	var out GetMentorshipsIDContestsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorshipsIDContestsContestID calls GET /api/mentorships/{id}/contests/{contestId}: One of the mentee's contests (mentor)
+func (c *Client) GetMentorshipsIDContestsContestID(ctx context.Context, id string, contestID string) (*ContestResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships/" + url.PathEscape(id) + "/contests/" + url.PathEscape(contestID), auth: true}
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostMentorshipsIDDecline calls POST /api/mentorships/{id}/decline: Decline a mentorship invite (mentee)
+func (c *Client) PostMentorshipsIDDecline(ctx context.Context, id string) (*Mentorship, error) {
+	req := request{method: http.MethodPost, path: "/api/mentorships/" + url.PathEscape(id) + "/decline", auth: true}
+	var out Mentorship
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorshipsIDNotes calls GET /api/mentorships/{id}/notes: The mentee's contest retro notes (mentor)
+func (c *Client) GetMentorshipsIDNotes(ctx context.Context, id string) (*GetMentorshipsIDNotesResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships/" + url.PathEscape(id) + "/notes", auth: true}
+	var out GetMentorshipsIDNotesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMentorshipsIDTiming calls GET /api/mentorships/{id}/timing: The mentee's solve times and contest statistics (mentor)
+func (c *Client) GetMentorshipsIDTiming(ctx context.Context, id string) (*MenteeTiming, error) {
+	req := request{method: http.MethodGet, path: "/api/mentorships/" + url.PathEscape(id) + "/timing", auth: true}
+	var out MenteeTiming
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOpenapiJSON calls GET /api/openapi.json: OpenAPI specification
+func (c *Client) GetOpenapiJSON(ctx context.Context) (map[string]any, error) {
+	req := request{method: http.MethodGet, path: "/api/openapi.json", auth: false}
+	var out map[string]any
+	if err := c.do(ctx, req, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// GetOrgs calls GET /api/orgs: List the caller's organizations
+func (c *Client) GetOrgs(ctx context.Context) (*GetOrgsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs", auth: true}
+	var out GetOrgsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostOrgs calls POST /api/orgs: Create an organization (class) with the caller as instructor
+func (c *Client) PostOrgs(ctx context.Context, body *CreateOrgRequest) (*OrgResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/orgs", auth: true}
+	req.body = body
+	var out OrgResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostOrgsJoin calls POST /api/orgs/join: Join an organization with an invite code
+func (c *Client) PostOrgsJoin(ctx context.Context, body *JoinOrgRequest) (*OrgResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/orgs/join", auth: true}
+	req.body = body
+	var out OrgResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsID calls GET /api/orgs/{id}: Get an organization with its members
+func (c *Client) GetOrgsID(ctx context.Context, id string) (*OrgResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id), auth: true}
+	var out OrgResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsIDAssignments calls GET /api/orgs/{id}/assignments: List assignments with the caller's progress
+func (c *Client) GetOrgsIDAssignments(ctx context.Context, id string) (*GetOrgsIDAssignmentsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id) + "/assignments", auth: true}
+	var out GetOrgsIDAssignmentsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostOrgsIDAssignments calls POST /api/orgs/{id}/assignments: Assign a contest, study plan or problem set to the class (instructors); problem sets are pushed to students as pending contests
+func (c *Client) PostOrgsIDAssignments(ctx context.Context, id string, body *CreateAssignmentRequest) (*OrgAssignmentResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/orgs/" + url.PathEscape(id) + "/assignments", auth: true}
+	req.body = body
+	var out OrgAssignmentResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteOrgsIDAssignmentsAssignmentID calls DELETE /api/orgs/{id}/assignments/{assignmentId}: Delete an assignment (instructors)
+func (c *Client) DeleteOrgsIDAssignmentsAssignmentID(ctx context.Context, id string, assignmentID string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/orgs/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsIDAssignmentsAssignmentIDReportParams holds the optional query parameters of GetOrgsIDAssignmentsAssignmentIDReport; zero values are omitted
+type GetOrgsIDAssignmentsAssignmentIDReportParams struct {
+	// Set to "csv" to download the report as CSV
+	Format string
+}
+
+func (p *GetOrgsIDAssignmentsAssignmentIDReportParams) values() url.Values {
+	q := url.Values{}
+	if p.Format != "" {
+		q.Set("format", p.Format)
+	}
+	return q
+}
+
+// GetOrgsIDAssignmentsAssignmentIDReport calls GET /api/orgs/{id}/assignments/{assignmentId}/report: Per-student completion report of an assignment (instructors)
+func (c *Client) GetOrgsIDAssignmentsAssignmentIDReport(ctx context.Context, id string, assignmentID string, params *GetOrgsIDAssignmentsAssignmentIDReportParams) (*AssignmentReport, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID) + "/report", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out AssignmentReport
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsIDAssignmentsAssignmentIDSimilarityParams holds the optional query parameters of GetOrgsIDAssignmentsAssignmentIDSimilarity; zero values are omitted
+type GetOrgsIDAssignmentsAssignmentIDSimilarityParams struct {
+	// Only flags with this review status: pending, confirmed or dismissed
+	Status string
+}
+
+func (p *GetOrgsIDAssignmentsAssignmentIDSimilarityParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != "" {
+		q.Set("status", p.Status)
+	}
+	return q
+}
+
+// GetOrgsIDAssignmentsAssignmentIDSimilarity calls GET /api/orgs/{id}/assignments/{assignmentId}/similarity: Pairs of students' solutions to an assignment flagged as highly similar (instructors)
+func (c *Client) GetOrgsIDAssignmentsAssignmentIDSimilarity(ctx context.Context, id string, assignmentID string, params *GetOrgsIDAssignmentsAssignmentIDSimilarityParams) (*GetOrgsIDAssignmentsAssignmentIDSimilarityResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID) + "/similarity", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out GetOrgsIDAssignmentsAssignmentIDSimilarityResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostOrgsIDAssignmentsAssignmentIDStart calls POST /api/orgs/{id}/assignments/{assignmentId}/start: Start the contest of a contest assignment, or the pending contest of a problem set
+func (c *Client) PostOrgsIDAssignmentsAssignmentIDStart(ctx context.Context, id string, assignmentID string) (*ContestResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/orgs/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID) + "/start", auth: true}
+	var out ContestResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsIDContestsContestIDProctoring calls GET /api/orgs/{id}/contests/{contestId}/proctoring: Focus and tab switch summary of a student's assignment contest (instructors)
+func (c *Client) GetOrgsIDContestsContestIDProctoring(ctx context.Context, id string, contestID string) (*ProctoringSummary, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id) + "/contests/" + url.PathEscape(contestID) + "/proctoring", auth: true}
+	var out ProctoringSummary
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostOrgsIDInvites calls POST /api/orgs/{id}/invites: Create an invite code (instructors)
+func (c *Client) PostOrgsIDInvites(ctx context.Context, id string, body *CreateOrgInviteRequest) (*OrgInvite, error) {
+	req := request{method: http.MethodPost, path: "/api/orgs/" + url.PathEscape(id) + "/invites", auth: true}
+	req.body = body
+	var out OrgInvite
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteOrgsIDInvitesCode calls DELETE /api/orgs/{id}/invites/{code}: Revoke an invite code (instructors)
+func (c *Client) DeleteOrgsIDInvitesCode(ctx context.Context, id string, code string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/orgs/" + url.PathEscape(id) + "/invites/" + url.PathEscape(code), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteOrgsIDMembersUserID calls DELETE /api/orgs/{id}/members/{userId}: Remove a member (instructors), or leave the organization
+func (c *Client) DeleteOrgsIDMembersUserID(ctx context.Context, id string, userID string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/orgs/" + url.PathEscape(id) + "/members/" + url.PathEscape(userID), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsIDRosterParams holds the optional query parameters of GetOrgsIDRoster; zero values are omitted
+type GetOrgsIDRosterParams struct {
+	// Maximum number of students (1-200, default 50)
+	Limit int
+	// Number of students to skip
+	Offset int
+}
+
+func (p *GetOrgsIDRosterParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	if p.Offset != 0 {
+		q.Set("offset", strconv.FormatInt(int64(p.Offset), 10))
+	}
+	return q
+}
+
+// GetOrgsIDRoster calls GET /api/orgs/{id}/roster: Roster progress dashboard of the students (instructors)
+func (c *Client) GetOrgsIDRoster(ctx context.Context, id string, params *GetOrgsIDRosterParams) (*OrgRoster, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id) + "/roster", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out OrgRoster
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrgsIDSimilarityFlagID calls GET /api/orgs/{id}/similarity/{flagId}: A flagged solution pair with both solutions (instructors)
+func (c *Client) GetOrgsIDSimilarityFlagID(ctx context.Context, id string, flagID string) (*SimilarityFlagDetail, error) {
+	req := request{method: http.MethodGet, path: "/api/orgs/" + url.PathEscape(id) + "/similarity/" + url.PathEscape(flagID), auth: true}
+	var out SimilarityFlagDetail
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchOrgsIDSimilarityFlagID calls PATCH /api/orgs/{id}/similarity/{flagId}: Confirm or dismiss a flagged solution pair (instructors)
+func (c *Client) PatchOrgsIDSimilarityFlagID(ctx context.Context, id string, flagID string, body *ReviewSimilarityRequest) (*SimilarityFlagResponse, error) {
+	req := request{method: http.MethodPatch, path: "/api/orgs/" + url.PathEscape(id) + "/similarity/" + url.PathEscape(flagID), auth: true}
+	req.body = body
+	var out SimilarityFlagResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProblemsParams holds the optional query parameters of GetProblems; zero values are omitted
+type GetProblemsParams struct {
+	// Set to "popularity" to include usage counters
+	Include string
+	// Apply one of the user's saved filters (requires auth)
+	FilterID string
+	// Only problems of this difficulty (repeatable)
+	Difficulty []string
+	// Only problems with this topic (repeatable)
+	Topic []string
+	// Only problems tagged with this company (repeatable)
+	Company []string
+	// "any", "solved" or "unsolved" (solved states require auth)
+	Solved string
+}
+
+func (p *GetProblemsParams) values() url.Values {
+	q := url.Values{}
+	if p.Include != "" {
+		q.Set("include", p.Include)
+	}
+	if p.FilterID != "" {
+		q.Set("filter_id", p.FilterID)
+	}
+	for _, v := range p.Difficulty {
+		q.Add("difficulty", v)
+	}
+	for _, v := range p.Topic {
+		q.Add("topic", v)
+	}
+	for _, v := range p.Company {
+		q.Add("company", v)
+	}
+	if p.Solved != "" {
+		q.Set("solved", p.Solved)
+	}
+	return q
+}
+
+// GetProblems calls GET /api/problems: List all problems
+func (c *Client) GetProblems(ctx context.Context, params *GetProblemsParams) (*GetProblemsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/problems", auth: false}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out GetProblemsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostProblemsBatchParams holds the optional query parameters of PostProblemsBatch; zero values are omitted
+type PostProblemsBatchParams struct {
+	// Set to "popularity" to include usage counters
+	Include string
+}
+
+func (p *PostProblemsBatchParams) values() url.Values {
+	q := url.Values{}
+	if p.Include != "" {
+		q.Set("include", p.Include)
+	}
+	return q
+}
+
+// PostProblemsBatch calls POST /api/problems/batch: Look up several problems by ID or slug
+func (c *Client) PostProblemsBatch(ctx context.Context, body *ProblemBatchRequest, params *PostProblemsBatchParams) (*ProblemBatchResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/problems/batch", auth: false}
+	req.body = body
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ProblemBatchResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProblemsSearchParams holds the optional query parameters of GetProblemsSearch; zero values are omitted
+type GetProblemsSearchParams struct {
+	// Search text, e.g. "palindrom" or "binary tree"
+	Q string
+	// Maximum number of results (1-50, default 20)
+	Limit int
+}
+
+func (p *GetProblemsSearchParams) values() url.Values {
+	q := url.Values{}
+	if p.Q != "" {
+		q.Set("q", p.Q)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetProblemsSearch calls GET /api/problems/search: Search problems by title or topic with fuzzy matching
+func (c *Client) GetProblemsSearch(ctx context.Context, params *GetProblemsSearchParams) (*ProblemSearchResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/problems/search", auth: false}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ProblemSearchResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProblemsSlugSlug calls GET /api/problems/slug/{slug}: Public page of a problem with SEO metadata
+func (c *Client) GetProblemsSlugSlug(ctx context.Context, slug string) (*ProblemPage, error) {
+	req := request{method: http.MethodGet, path: "/api/problems/slug/" + url.PathEscape(slug), auth: false}
+	var out ProblemPage
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProblemsStats calls GET /api/problems/stats: Get problem statistics
+func (c *Client) GetProblemsStats(ctx context.Context) (*ProblemStats, error) {
+	req := request{method: http.MethodGet, path: "/api/problems/stats", auth: false}
+	var out ProblemStats
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProblemsIDParams holds the optional query parameters of GetProblemsID; zero values are omitted
+type GetProblemsIDParams struct {
+	// Set to "popularity" to include usage counters
+	Include string
+}
+
+func (p *GetProblemsIDParams) values() url.Values {
+	q := url.Values{}
+	if p.Include != "" {
+		q.Set("include", p.Include)
+	}
+	return q
+}
+
+// GetProblemsID calls GET /api/problems/{id}: Get single problem
+func (c *Client) GetProblemsID(ctx context.Context, id string, params *GetProblemsIDParams) (*ProblemResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/problems/" + url.PathEscape(id), auth: false}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ProblemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProblemsIDPrerequisites calls GET /api/problems/{id}/prerequisites: List problem prerequisites
+func (c *Client) GetProblemsIDPrerequisites(ctx context.Context, id string) (*ProblemPrerequisitesResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/problems/" + url.PathEscape(id) + "/prerequisites", auth: false}
+	var out ProblemPrerequisitesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPublicStats calls GET /api/public/stats: Anonymized platform statistics
+func (c *Client) GetPublicStats(ctx context.Context) (*PublicStats, error) {
+	req := request{method: http.MethodGet, path: "/api/public/stats", auth: false}
+	var out PublicStats
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPublicStatsProblemsParams holds the optional query parameters of GetPublicStatsProblems; zero values are omitted
+type GetPublicStatsProblemsParams struct {
+	// Maximum number of problems (1-50, default 10)
+	Limit int
+}
+
+func (p *GetPublicStatsProblemsParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetPublicStatsProblems calls GET /api/public/stats/problems: Most attempted problems
+func (c *Client) GetPublicStatsProblems(ctx context.Context, params *GetPublicStatsProblemsParams) (*PublicProblemStats, error) {
+	req := request{method: http.MethodGet, path: "/api/public/stats/problems", auth: false}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out PublicProblemStats
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostQuick calls POST /api/quick: Run a quick command such as "start 5x90", "done 3" or "skip"
+func (c *Client) PostQuick(ctx context.Context, body *QuickCommandRequest) (*QuickResult, error) {
+	req := request{method: http.MethodPost, path: "/api/quick", auth: true}
+	req.body = body
+	var out QuickResult
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetQuickHistoryParams holds the optional query parameters of GetQuickHistory; zero values are omitted
+type GetQuickHistoryParams struct {
+	// Maximum number of commands (1-100, default 20)
+	Limit int
+}
+
+func (p *GetQuickHistoryParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetQuickHistory calls GET /api/quick/history: List the latest quick commands and their outcome
+func (c *Client) GetQuickHistory(ctx context.Context, params *GetQuickHistoryParams) (*QuickHistory, error) {
+	req := request{method: http.MethodGet, path: "/api/quick/history", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out QuickHistory
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRoadmap calls GET /api/roadmap: Get the roadmap with completion overlay
+func (c *Client) GetRoadmap(ctx context.Context) (*RoadmapResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/roadmap", auth: false}
+	var out RoadmapResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSpectateSpectatorCode calls GET /api/spectate/{spectatorCode}: Watch a challenge's standings as a spectator
+func (c *Client) GetSpectateSpectatorCode(ctx context.Context, spectatorCode string) (*SpectatorView, error) {
+	req := request{method: http.MethodGet, path: "/api/spectate/" + url.PathEscape(spectatorCode), auth: true}
+	var out SpectatorView
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenant calls GET /api/tenant: The tenant the request is for, selected by subdomain or the X-Tenant header
+func (c *Client) GetTenant(ctx context.Context) (*TenantInfo, error) {
+	req := request{method: http.MethodGet, path: "/api/tenant", auth: false}
+	var out TenantInfo
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTenantLeaderboardParams holds the optional query parameters of GetTenantLeaderboard; zero values are omitted
+type GetTenantLeaderboardParams struct {
+	// Maximum number of users (1-100, default 25)
+	Limit int
+}
+
+func (p *GetTenantLeaderboardParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetTenantLeaderboard calls GET /api/tenant/leaderboard: Users of your tenant ranked by solved problems
+func (c *Client) GetTenantLeaderboard(ctx context.Context, params *GetTenantLeaderboardParams) (*GetTenantLeaderboardResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/tenant/leaderboard", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out GetTenantLeaderboardResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostTenantProblems calls POST /api/tenant/problems: Add a problem to your tenant's catalog (tenant admins)
+func (c *Client) PostTenantProblems(ctx context.Context, body *CustomProblemRequest) (*ProblemResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/tenant/problems", auth: true}
+	req.body = body
+	var out ProblemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMe calls GET /api/users/me: Get current user
+func (c *Client) GetUsersMe(ctx context.Context) (*UserResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me", auth: true}
+	var out UserResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeAttemptsProblemID calls GET /api/users/me/attempts/{problemId}: Your attempt history at a problem
+func (c *Client) GetUsersMeAttemptsProblemID(ctx context.Context, problemID string) (*AttemptHistory, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/attempts/" + url.PathEscape(problemID), auth: true}
+	var out AttemptHistory
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeContestsByTopic calls GET /api/users/me/contests/by-topic: How often each topic came up in your finished contests and your completion rate
+func (c *Client) GetUsersMeContestsByTopic(ctx context.Context) (*GetUsersMeContestsByTopicResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/contests/by-topic", auth: true}
+	var out GetUsersMeContestsByTopicResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeDigest calls GET /api/users/me/digest: Weekly recommendation digest opt-in and the latest digest
+func (c *Client) GetUsersMeDigest(ctx context.Context) (*DigestStatus, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/digest", auth: true}
+	var out DigestStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutUsersMeDigest calls PUT /api/users/me/digest: Opt in to or out of the weekly recommendation digest
+func (c *Client) PutUsersMeDigest(ctx context.Context, body *UpdateDigestRequest) (*DigestStatus, error) {
+	req := request{method: http.MethodPut, path: "/api/users/me/digest", auth: true}
+	req.body = body
+	var out DigestStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeFeatures calls GET /api/users/me/features: Feature flags that are on for the current user
+func (c *Client) GetUsersMeFeatures(ctx context.Context) (*FeaturesResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/features", auth: true}
+	var out FeaturesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeFeedParams holds the optional query parameters of GetUsersMeFeed; zero values are omitted
+type GetUsersMeFeedParams struct {
+	// next_cursor of the previous page
+	Cursor string
+	// Maximum number of items (1-100, default 20)
+	Limit int
+}
+
+func (p *GetUsersMeFeedParams) values() url.Values {
+	q := url.Values{}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetUsersMeFeed calls GET /api/users/me/feed: Activity feed of the user and their friends, newest first
+func (c *Client) GetUsersMeFeed(ctx context.Context, params *GetUsersMeFeedParams) (*FeedResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/feed", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out FeedResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeFilters calls GET /api/users/me/filters: List saved problem filters
+func (c *Client) GetUsersMeFilters(ctx context.Context) (*GetUsersMeFiltersResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/filters", auth: true}
+	var out GetUsersMeFiltersResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostUsersMeFilters calls POST /api/users/me/filters: Save a problem filter
+func (c *Client) PostUsersMeFilters(ctx context.Context, body *SavedFilterRequest) (*SavedFilter, error) {
+	req := request{method: http.MethodPost, path: "/api/users/me/filters", auth: true}
+	req.body = body
+	var out SavedFilter
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUsersMeFiltersFilterID calls DELETE /api/users/me/filters/{filterId}: Delete a saved problem filter
+func (c *Client) DeleteUsersMeFiltersFilterID(ctx context.Context, filterID string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/users/me/filters/" + url.PathEscape(filterID), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutUsersMeFiltersFilterID calls PUT /api/users/me/filters/{filterId}: Replace a saved problem filter
+func (c *Client) PutUsersMeFiltersFilterID(ctx context.Context, filterID string, body *SavedFilterRequest) (*SavedFilter, error) {
+	req := request{method: http.MethodPut, path: "/api/users/me/filters/" + url.PathEscape(filterID), auth: true}
+	req.body = body
+	var out SavedFilter
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostUsersMeHeartbeat calls POST /api/users/me/heartbeat: Mark yourself online, or in your contest
+func (c *Client) PostUsersMeHeartbeat(ctx context.Context, body *HeartbeatRequest) (*Presence, error) {
+	req := request{method: http.MethodPost, path: "/api/users/me/heartbeat", auth: true}
+	req.body = body
+	var out Presence
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutUsersMePassword calls PUT /api/users/me/password: Change password
+func (c *Client) PutUsersMePassword(ctx context.Context, body *ChangePasswordRequest) (*MessageResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/users/me/password", auth: true}
+	req.body = body
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeProblems calls GET /api/users/me/problems: List private custom problems
+func (c *Client) GetUsersMeProblems(ctx context.Context) (*GetUsersMeProblemsResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/problems", auth: true}
+	var out GetUsersMeProblemsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostUsersMeProblems calls POST /api/users/me/problems: Add a private custom problem
+func (c *Client) PostUsersMeProblems(ctx context.Context, body *CustomProblemRequest) (*ProblemResponse, error) {
+	req := request{method: http.MethodPost, path: "/api/users/me/problems", auth: true}
+	req.body = body
+	var out ProblemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUsersMeProblemsProblemID calls DELETE /api/users/me/problems/{problemId}: Delete a private custom problem
+func (c *Client) DeleteUsersMeProblemsProblemID(ctx context.Context, problemID string) (*MessageResponse, error) {
+	req := request{method: http.MethodDelete, path: "/api/users/me/problems/" + url.PathEscape(problemID), auth: true}
+	var out MessageResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutUsersMeProblemsProblemID calls PUT /api/users/me/problems/{problemId}: Replace a private custom problem
+func (c *Client) PutUsersMeProblemsProblemID(ctx context.Context, problemID string, body *CustomProblemRequest) (*ProblemResponse, error) {
+	req := request{method: http.MethodPut, path: "/api/users/me/problems/" + url.PathEscape(problemID), auth: true}
+	req.body = body
+	var out ProblemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeProgress calls GET /api/users/me/progress: Get user progress stats
+func (c *Client) GetUsersMeProgress(ctx context.Context) (*UserProgress, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/progress", auth: true}
+	var out UserProgress
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeQuotas calls GET /api/users/me/quotas: Plan and remaining allowances of the current user
+func (c *Client) GetUsersMeQuotas(ctx context.Context) (*QuotaStatus, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/quotas", auth: true}
+	var out QuotaStatus
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeReviewsParams holds the optional query parameters of GetUsersMeReviews; zero values are omitted
+type GetUsersMeReviewsParams struct {
+	// Only problems due for review now
+	DueOnly bool
+	// Maximum number of problems (1-200)
+	Limit int
+}
+
+func (p *GetUsersMeReviewsParams) values() url.Values {
+	q := url.Values{}
+	if p.DueOnly {
+		q.Set("due_only", "true")
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetUsersMeReviews calls GET /api/users/me/reviews: Solved problems in spaced-repetition order
+func (c *Client) GetUsersMeReviews(ctx context.Context, params *GetUsersMeReviewsParams) (*ReviewQueue, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/reviews", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out ReviewQueue
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsersMeSearchParams holds the optional query parameters of GetUsersMeSearch; zero values are omitted
+type GetUsersMeSearchParams struct {
+	// Words that must all appear, e.g. "sliding window"
+	Q string
+	// Maximum number of results (1-50, default 20)
+	Limit int
+}
+
+func (p *GetUsersMeSearchParams) values() url.Values {
+	q := url.Values{}
+	if p.Q != "" {
+		q.Set("q", p.Q)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return q
+}
+
+// GetUsersMeSearch calls GET /api/users/me/search: Search the user's contest retros and solution snippets
+func (c *Client) GetUsersMeSearch(ctx context.Context, params *GetUsersMeSearchParams) (*NoteSearchResponse, error) {
+	req := request{method: http.MethodGet, path: "/api/users/me/search", auth: true}
+	if params != nil {
+		req.query = params.values()
+	}
+	var out NoteSearchResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
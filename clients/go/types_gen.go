@@ -0,0 +1,1676 @@
+// Code generated by backend/cmd/clientgen from backend/api/openapi.json. DO NOT EDIT.
+
+package contestmaker
+
+import (
+	"time"
+)
+
+// APIError is the APIError schema of the API
+type APIError struct {
+	Code              string `json:"code"`
+	Details           any    `json:"details"`
+	Message           string `json:"message"`
+	RequestID         string `json:"request_id"`
+	Retry             string `json:"retry"`
+	RetryAfterSeconds int    `json:"retry_after_seconds"`
+}
+
+// AssignmentReport is the AssignmentReport schema of the API
+type AssignmentReport struct {
+	Abandoned  int                   `json:"abandoned"`
+	Assignment OrgAssignment         `json:"assignment"`
+	Completed  int                   `json:"completed"`
+	InProgress int                   `json:"in_progress"`
+	Members    []AssignmentReportRow `json:"members"`
+	NotStarted int                   `json:"not_started"`
+	Overdue    int                   `json:"overdue"`
+}
+
+// AssignmentReportRow is the AssignmentReportRow schema of the API
+type AssignmentReportRow struct {
+	AssignmentID string     `json:"assignment_id"`
+	ContestID    *string    `json:"contest_id"`
+	EndedAt      *time.Time `json:"ended_at"`
+	FocusLosses  int        `json:"focus_losses"`
+	Late         bool       `json:"late"`
+	Overdue      bool       `json:"overdue"`
+	Solved       int        `json:"solved"`
+	StartedAt    *time.Time `json:"started_at"`
+	Status       string     `json:"status"`
+	TabSwitches  int        `json:"tab_switches"`
+	Total        int        `json:"total"`
+	UserID       string     `json:"user_id"`
+	Username     string     `json:"username"`
+}
+
+// AttachSolutionRequest is the AttachSolutionRequest schema of the API
+type AttachSolutionRequest struct {
+	Code     string `json:"code"`
+	Language string `json:"language,omitempty"`
+}
+
+// Attempt is the Attempt schema of the API
+type Attempt struct {
+	AttemptedAt     time.Time `json:"attempted_at"`
+	ContestID       *string   `json:"contest_id"`
+	DurationSeconds int       `json:"duration_seconds"`
+	ID              string    `json:"id"`
+	Outcome         string    `json:"outcome"`
+	ProblemID       string    `json:"problem_id"`
+	UserID          string    `json:"user_id"`
+}
+
+// AttemptHistory is the AttemptHistory schema of the API
+type AttemptHistory struct {
+	Attempts             []Attempt `json:"attempts"`
+	ProblemID            string    `json:"problem_id"`
+	Solved               bool      `json:"solved"`
+	SolvedOnFirstAttempt bool      `json:"solved_on_first_attempt"`
+}
+
+// AttemptStats is the AttemptStats schema of the API
+type AttemptStats struct {
+	FailedAttempts       int     `json:"failed_attempts"`
+	FirstAttemptRate     float64 `json:"first_attempt_rate"`
+	SolvedOnFirstAttempt int     `json:"solved_on_first_attempt"`
+	SolvedProblems       int     `json:"solved_problems"`
+	TotalAttempts        int     `json:"total_attempts"`
+}
+
+// AuthResponse is the AuthResponse schema of the API
+type AuthResponse struct {
+	Tokens TokenPair    `json:"tokens"`
+	User   UserResponse `json:"user"`
+}
+
+// BackupFile is the BackupFile schema of the API
+type BackupFile struct {
+	Bytes  int64  `json:"bytes"`
+	Name   string `json:"name"`
+	Rows   int64  `json:"rows"`
+	Sha256 string `json:"sha256"`
+	Table  string `json:"table"`
+}
+
+// BackupListResponse is the BackupListResponse schema of the API
+type BackupListResponse struct {
+	Backups []BackupManifest `json:"backups"`
+}
+
+// BackupManifest is the BackupManifest schema of the API
+type BackupManifest struct {
+	CatalogSlugs    map[string]string `json:"catalog_slugs"`
+	CreatedAt       time.Time         `json:"created_at"`
+	Driver          string            `json:"driver"`
+	DurationSeconds float64           `json:"duration_seconds"`
+	Files           []BackupFile      `json:"files"`
+	Format          string            `json:"format"`
+	ID              string            `json:"id"`
+	Version         int               `json:"version"`
+}
+
+// ChallengeComparison is the ChallengeComparison schema of the API
+type ChallengeComparison struct {
+	Challenger ChallengeResult              `json:"challenger"`
+	Code       string                       `json:"code"`
+	Opponent   ChallengeResult              `json:"opponent"`
+	Problems   []ChallengeProblemComparison `json:"problems"`
+	WinnerID   *string                      `json:"winner_id"`
+}
+
+// ChallengeProblemComparison is the ChallengeProblemComparison schema of the API
+type ChallengeProblemComparison struct {
+	ChallengerSolved bool            `json:"challenger_solved"`
+	OpponentSolved   bool            `json:"opponent_solved"`
+	Problem          ProblemResponse `json:"problem"`
+}
+
+// ChallengeResponse is the ChallengeResponse schema of the API
+type ChallengeResponse struct {
+	Accepted           bool       `json:"accepted"`
+	AcceptedAt         *time.Time `json:"accepted_at"`
+	ChallengerID       string     `json:"challenger_id"`
+	ChallengerUsername string     `json:"challenger_username"`
+	Code               string     `json:"code"`
+	ContestID          string     `json:"contest_id"`
+	DurationMinutes    int        `json:"duration_minutes"`
+	ExpiresAt          time.Time  `json:"expires_at"`
+	ProblemCount       int        `json:"problem_count"`
+	SilentMode         bool       `json:"silent_mode"`
+}
+
+// ChallengeResult is the ChallengeResult schema of the API
+type ChallengeResult struct {
+	ContestID      string `json:"contest_id"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	Solved         int    `json:"solved"`
+	Status         string `json:"status"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// ChallengeStanding is the ChallengeStanding schema of the API
+type ChallengeStanding struct {
+	ContestID string   `json:"contest_id"`
+	Presence  Presence `json:"presence"`
+	Solved    int      `json:"solved"`
+	Status    string   `json:"status"`
+	Total     int      `json:"total"`
+	UserID    string   `json:"user_id"`
+	Username  string   `json:"username"`
+}
+
+// ChallengeStandings is the ChallengeStandings schema of the API
+type ChallengeStandings struct {
+	Code         string              `json:"code"`
+	Participants []ChallengeStanding `json:"participants"`
+}
+
+// ChangePasswordRequest is the ChangePasswordRequest schema of the API
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ChatMessageResponse is the ChatMessageResponse schema of the API
+type ChatMessageResponse struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+}
+
+// ChatMessages is the ChatMessages schema of the API
+type ChatMessages struct {
+	Messages []ChatMessageResponse `json:"messages"`
+	Silenced bool                  `json:"silenced"`
+}
+
+// CheckoutSessionResponse is the CheckoutSessionResponse schema of the API
+type CheckoutSessionResponse struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Cohort is the Cohort schema of the API
+type Cohort struct {
+	Size  int               `json:"size"`
+	Week  time.Time         `json:"week"`
+	Weeks []CohortWeekStats `json:"weeks"`
+}
+
+// CohortWeekStats is the CohortWeekStats schema of the API
+type CohortWeekStats struct {
+	ActiveUsers       int     `json:"active_users"`
+	Complete          bool    `json:"complete"`
+	ContestsCompleted int     `json:"contests_completed"`
+	ContestsStarted   int     `json:"contests_started"`
+	Retention         float64 `json:"retention"`
+	Week              int     `json:"week"`
+}
+
+// CohortsResponse is the CohortsResponse schema of the API
+type CohortsResponse struct {
+	Cohorts    []Cohort   `json:"cohorts"`
+	ComputedAt *time.Time `json:"computed_at"`
+}
+
+// CompanyCount is the CompanyCount schema of the API
+type CompanyCount struct {
+	Count int64  `json:"count"`
+	Name  string `json:"name"`
+}
+
+// ComplexityResult is the ComplexityResult schema of the API
+type ComplexityResult struct {
+	ExpectedSpaceComplexity string `json:"expected_space_complexity"`
+	ExpectedTimeComplexity  string `json:"expected_time_complexity"`
+	SpaceComplexity         string `json:"space_complexity"`
+	SpaceCorrect            *bool  `json:"space_correct"`
+	TimeComplexity          string `json:"time_complexity"`
+	TimeCorrect             *bool  `json:"time_correct"`
+}
+
+// ComplexityScore is the ComplexityScore schema of the API
+type ComplexityScore struct {
+	Answered int `json:"answered"`
+	Correct  int `json:"correct"`
+	Graded   int `json:"graded"`
+}
+
+// ConfidenceStats is the ConfidenceStats schema of the API
+type ConfidenceStats struct {
+	Average      float64        `json:"average"`
+	Distribution map[string]int `json:"distribution"`
+	Rated        int            `json:"rated"`
+	Unrated      int            `json:"unrated"`
+}
+
+// ContestEvent is the ContestEvent schema of the API
+type ContestEvent struct {
+	ContestID  string    `json:"contest_id"`
+	ID         string    `json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	ReceivedAt time.Time `json:"received_at"`
+	Type       string    `json:"type"`
+}
+
+// ContestEventInput is the ContestEventInput schema of the API
+type ContestEventInput struct {
+	OccurredAt time.Time `json:"occurred_at"`
+	Type       string    `json:"type"`
+}
+
+// ContestProblemResponse is the ContestProblemResponse schema of the API
+type ContestProblemResponse struct {
+	Complexity       ComplexityResult `json:"complexity"`
+	IsCompleted      bool             `json:"is_completed"`
+	IsWarmup         bool             `json:"is_warmup"`
+	Order            int              `json:"order"`
+	Problem          ProblemResponse  `json:"problem"`
+	TimeSpentSeconds int              `json:"time_spent_seconds"`
+	TimerRunning     bool             `json:"timer_running"`
+}
+
+// ContestResponse is the ContestResponse schema of the API
+type ContestResponse struct {
+	ComplexityScore      ComplexityScore          `json:"complexity_score"`
+	DifficultyRating     *int                     `json:"difficulty_rating"`
+	DurationMinutes      int                      `json:"duration_minutes"`
+	EndedAt              *time.Time               `json:"ended_at"`
+	ID                   string                   `json:"id"`
+	Ordering             string                   `json:"ordering"`
+	Problems             []ContestProblemResponse `json:"problems"`
+	Retro                string                   `json:"retro"`
+	RetroUpdatedAt       *time.Time               `json:"retro_updated_at"`
+	StartedAt            time.Time                `json:"started_at"`
+	Status               string                   `json:"status"`
+	Tags                 []string                 `json:"tags"`
+	TimeRemainingSeconds int                      `json:"time_remaining_seconds"`
+	Tuning               DistributionTuning       `json:"tuning"`
+	Warmup               ContestWarmupResponse    `json:"warmup"`
+	Warning              ContestWarning           `json:"warning"`
+}
+
+// ContestSession is the ContestSession schema of the API
+type ContestSession struct {
+	ContestID        string            `json:"contest_id"`
+	CurrentProblemID *string           `json:"current_problem_id"`
+	Device           string            `json:"device"`
+	Notes            map[string]string `json:"notes"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	Version          int               `json:"version"`
+}
+
+// ContestSizeCount is the ContestSizeCount schema of the API
+type ContestSizeCount struct {
+	Contests     int64 `json:"contests"`
+	ProblemCount int   `json:"problem_count"`
+}
+
+// ContestStatistics is the ContestStatistics schema of the API
+type ContestStatistics struct {
+	AbandonedContests int `json:"abandoned_contests"`
+	CompletedContests int `json:"completed_contests"`
+	TotalContests     int `json:"total_contests"`
+}
+
+// ContestTemplate is the ContestTemplate schema of the API
+type ContestTemplate struct {
+	DurationMinutes int               `json:"duration_minutes"`
+	ExportedAt      time.Time         `json:"exported_at,omitempty"`
+	Ordering        string            `json:"ordering"`
+	Problems        []TemplateProblem `json:"problems"`
+	Version         int               `json:"version"`
+}
+
+// ContestTemplateImport is the ContestTemplateImport schema of the API
+type ContestTemplateImport struct {
+	Conflicts []TemplateConflict `json:"conflicts"`
+	Contest   ContestResponse    `json:"contest"`
+	Problems  int                `json:"problems"`
+}
+
+// ContestWarmupResponse is the ContestWarmupResponse schema of the API
+type ContestWarmupResponse struct {
+	EndsAt               time.Time       `json:"ends_at"`
+	IsCompleted          bool            `json:"is_completed"`
+	Problem              ProblemResponse `json:"problem"`
+	TimeRemainingSeconds int             `json:"time_remaining_seconds"`
+}
+
+// ContestWarning is the ContestWarning schema of the API
+type ContestWarning struct {
+	Code      string         `json:"code"`
+	Delivered map[string]int `json:"delivered"`
+	Message   string         `json:"message"`
+	Requested map[string]int `json:"requested"`
+}
+
+// CreateAssignmentRequest is the CreateAssignmentRequest schema of the API
+type CreateAssignmentRequest struct {
+	CategoryID      *string              `json:"category_id"`
+	Contest         CreateContestRequest `json:"contest"`
+	DueAt           *time.Time           `json:"due_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	Kind            string               `json:"kind"`
+	Problems        []string             `json:"problems"`
+	Title           string               `json:"title"`
+}
+
+// CreateChallengeRequest is the CreateChallengeRequest schema of the API
+type CreateChallengeRequest struct {
+	SilentMode bool `json:"silent_mode,omitempty"`
+}
+
+// CreateContestRequest is the CreateContestRequest schema of the API
+type CreateContestRequest struct {
+	Companies            []string `json:"companies,omitempty"`
+	Difficulties         []string `json:"difficulties,omitempty"`
+	DurationMinutes      int      `json:"duration_minutes"`
+	IncludeCustom        bool     `json:"include_custom,omitempty"`
+	Ordering             string   `json:"ordering,omitempty"`
+	ProblemCount         int      `json:"problem_count"`
+	RespectPrerequisites bool     `json:"respect_prerequisites,omitempty"`
+	SolvedWarmup         bool     `json:"solved_warmup,omitempty"`
+	Source               string   `json:"source,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	WarmupMinutes        int      `json:"warmup_minutes,omitempty"`
+	Weighting            string   `json:"weighting,omitempty"`
+}
+
+// CreateListContestRequest is the CreateListContestRequest schema of the API
+type CreateListContestRequest struct {
+	DurationMinutes int  `json:"duration_minutes"`
+	IncludeSolved   bool `json:"include_solved,omitempty"`
+	ProblemCount    int  `json:"problem_count"`
+}
+
+// CreateMentorAssignmentRequest is the CreateMentorAssignmentRequest schema of the API
+type CreateMentorAssignmentRequest struct {
+	Contest         CreateContestRequest `json:"contest"`
+	DueAt           time.Time            `json:"due_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	Kind            string               `json:"kind"`
+	Problems        []string             `json:"problems"`
+	Title           string               `json:"title"`
+}
+
+// CreateMentorshipRequest is the CreateMentorshipRequest schema of the API
+type CreateMentorshipRequest struct {
+	MenteeEmail string `json:"mentee_email"`
+}
+
+// CreateOrgInviteRequest is the CreateOrgInviteRequest schema of the API
+type CreateOrgInviteRequest struct {
+	Role string `json:"role,omitempty"`
+}
+
+// CreateOrgRequest is the CreateOrgRequest schema of the API
+type CreateOrgRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateTenantRequest is the CreateTenantRequest schema of the API
+type CreateTenantRequest struct {
+	AdminEmail string `json:"admin_email"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+}
+
+// CustomProblemRequest is the CustomProblemRequest schema of the API
+type CustomProblemRequest struct {
+	Difficulty string   `json:"difficulty"`
+	Title      string   `json:"title"`
+	Topics     []string `json:"topics,omitempty"`
+	URL        string   `json:"url"`
+}
+
+// DigestItemResponse is the DigestItemResponse schema of the API
+type DigestItemResponse struct {
+	ClickURL    string            `json:"click_url"`
+	Clicked     bool              `json:"clicked"`
+	Companies   []string          `json:"companies"`
+	Custom      bool              `json:"custom"`
+	Difficulty  string            `json:"difficulty"`
+	ID          string            `json:"id"`
+	Importance  int               `json:"importance"`
+	LeetcodeURL string            `json:"leetcode_url"`
+	NeetcodeURL string            `json:"neetcode_url"`
+	Popularity  ProblemPopularity `json:"popularity"`
+	Position    int               `json:"position"`
+	Slug        string            `json:"slug"`
+	Title       string            `json:"title"`
+	Topics      []string          `json:"topics"`
+}
+
+// DigestResponse is the DigestResponse schema of the API
+type DigestResponse struct {
+	Channel string               `json:"channel"`
+	ID      string               `json:"id"`
+	Items   []DigestItemResponse `json:"items"`
+	SentAt  time.Time            `json:"sent_at"`
+}
+
+// DigestRunReport is the DigestRunReport schema of the API
+type DigestRunReport struct {
+	Due     int `json:"due"`
+	Emailed int `json:"emailed"`
+	Failed  int `json:"failed"`
+	Sent    int `json:"sent"`
+	Skipped int `json:"skipped"`
+}
+
+// DigestStats is the DigestStats schema of the API
+type DigestStats struct {
+	ClickRate        float64   `json:"click_rate"`
+	ClickedDigests   int64     `json:"clicked_digests"`
+	Clicks           int64     `json:"clicks"`
+	ClicksByPosition []int64   `json:"clicks_by_position"`
+	DigestClickRate  float64   `json:"digest_click_rate"`
+	Digests          int64     `json:"digests"`
+	Emailed          int64     `json:"emailed"`
+	Items            int64     `json:"items"`
+	Since            time.Time `json:"since"`
+	SolveRate        float64   `json:"solve_rate"`
+	SolvedItems      int64     `json:"solved_items"`
+	Subscribers      int64     `json:"subscribers"`
+}
+
+// DigestStatus is the DigestStatus schema of the API
+type DigestStatus struct {
+	Enabled    bool           `json:"enabled"`
+	LastSentAt *time.Time     `json:"last_sent_at"`
+	Latest     DigestResponse `json:"latest"`
+	OptedInAt  *time.Time     `json:"opted_in_at"`
+}
+
+// DistributionTuning is the DistributionTuning schema of the API
+type DistributionTuning struct {
+	Base       map[string]int `json:"base"`
+	Factor     float64        `json:"factor"`
+	MeanRating float64        `json:"mean_rating"`
+	Profile    string         `json:"profile"`
+	Ratings    int            `json:"ratings"`
+	Tuned      map[string]int `json:"tuned"`
+}
+
+// ErrorResponse is the ErrorResponse schema of the API
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// ExperimentOutcome is the ExperimentOutcome schema of the API
+type ExperimentOutcome struct {
+	Flag     string           `json:"flag"`
+	Key      string           `json:"key"`
+	Variants []VariantOutcome `json:"variants"`
+}
+
+// ExperimentsResponse is the ExperimentsResponse schema of the API
+type ExperimentsResponse struct {
+	Experiments []ExperimentOutcome `json:"experiments"`
+}
+
+// FeatureFlag is the FeatureFlag schema of the API
+type FeatureFlag struct {
+	Description    string    `json:"description"`
+	Enabled        bool      `json:"enabled"`
+	Key            string    `json:"key"`
+	RolloutPercent int       `json:"rollout_percent"`
+	Source         string    `json:"source"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// FeatureFlagListResponse is the FeatureFlagListResponse schema of the API
+type FeatureFlagListResponse struct {
+	Flags []FeatureFlag `json:"flags"`
+}
+
+// FeaturesResponse is the FeaturesResponse schema of the API
+type FeaturesResponse struct {
+	Features map[string]bool `json:"features"`
+}
+
+// FeedEntry is the FeedEntry schema of the API
+type FeedEntry struct {
+	ActorID       string    `json:"actor_id"`
+	ActorUsername string    `json:"actor_username"`
+	ContestID     *string   `json:"contest_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	Detail        string    `json:"detail"`
+	ID            string    `json:"id"`
+	Kind          string    `json:"kind"`
+	Own           bool      `json:"own"`
+	ProblemID     *string   `json:"problem_id"`
+	ProblemTitle  string    `json:"problem_title"`
+}
+
+// FeedResponse is the FeedResponse schema of the API
+type FeedResponse struct {
+	Items      []FeedEntry `json:"items"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+// GetAdminProblemsCalibrationResponse is the response body of GetAdminProblemsCalibration
+type GetAdminProblemsCalibrationResponse struct {
+	Problems []ProblemCalibration `json:"problems"`
+}
+
+// GetAdminTenantsResponse is the response body of GetAdminTenants
+type GetAdminTenantsResponse struct {
+	Tenants []Tenant `json:"tenants"`
+}
+
+// GetAssignmentsResponse is the response body of GetAssignments
+type GetAssignmentsResponse struct {
+	Assignments []PendingAssignment `json:"assignments"`
+}
+
+// GetCompaniesResponse is the response body of GetCompanies
+type GetCompaniesResponse struct {
+	Companies []CompanyCount `json:"companies"`
+}
+
+// GetContestsActiveResponse is the response body of GetContestsActive
+type GetContestsActiveResponse struct {
+	Contest ContestResponse `json:"contest"`
+}
+
+// GetContestsResponse is the response body of GetContests
+type GetContestsResponse struct {
+	Contests []ContestResponse `json:"contests"`
+}
+
+// GetContestsTagsResponse is the response body of GetContestsTags
+type GetContestsTagsResponse struct {
+	Tags []TagCount `json:"tags"`
+}
+
+// GetMentorshipsIDAssignmentsResponse is the response body of GetMentorshipsIDAssignments
+type GetMentorshipsIDAssignmentsResponse struct {
+	Assignments []MentorAssignmentResponse `json:"assignments"`
+}
+
+// GetMentorshipsIDContestsResponse is the response body of GetMentorshipsIDContests
+type GetMentorshipsIDContestsResponse struct {
+	Contests []ContestResponse `json:"contests"`
+}
+
+// GetMentorshipsIDNotesResponse is the response body of GetMentorshipsIDNotes
+type GetMentorshipsIDNotesResponse struct {
+	Notes []MenteeNote `json:"notes"`
+}
+
+// GetOrgsIDAssignmentsAssignmentIDSimilarityResponse is the response body of GetOrgsIDAssignmentsAssignmentIDSimilarity
+type GetOrgsIDAssignmentsAssignmentIDSimilarityResponse struct {
+	Flags []SimilarityFlagResponse `json:"flags"`
+}
+
+// GetOrgsIDAssignmentsResponse is the response body of GetOrgsIDAssignments
+type GetOrgsIDAssignmentsResponse struct {
+	Assignments []OrgAssignmentResponse `json:"assignments"`
+}
+
+// GetOrgsResponse is the response body of GetOrgs
+type GetOrgsResponse struct {
+	Orgs []OrgSummary `json:"orgs"`
+}
+
+// GetProblemsResponse is the response body of GetProblems
+type GetProblemsResponse struct {
+	Count    int               `json:"count"`
+	Problems []ProblemResponse `json:"problems"`
+}
+
+// GetTenantLeaderboardResponse is the response body of GetTenantLeaderboard
+type GetTenantLeaderboardResponse struct {
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// GetUsersMeContestsByTopicResponse is the response body of GetUsersMeContestsByTopic
+type GetUsersMeContestsByTopicResponse struct {
+	Topics []TopicContestStats `json:"topics"`
+}
+
+// GetUsersMeFiltersResponse is the response body of GetUsersMeFilters
+type GetUsersMeFiltersResponse struct {
+	Count   int           `json:"count"`
+	Filters []SavedFilter `json:"filters"`
+}
+
+// GetUsersMeProblemsResponse is the response body of GetUsersMeProblems
+type GetUsersMeProblemsResponse struct {
+	Count    int               `json:"count"`
+	Problems []ProblemResponse `json:"problems"`
+}
+
+// HeartbeatRequest is the HeartbeatRequest schema of the API
+type HeartbeatRequest struct {
+	ContestID *string `json:"contest_id,omitempty"`
+}
+
+// ImportContestTemplateRequest is the ImportContestTemplateRequest schema of the API
+type ImportContestTemplateRequest struct {
+	DryRun      bool            `json:"dry_run,omitempty"`
+	Signature   string          `json:"signature"`
+	SkipMissing bool            `json:"skip_missing,omitempty"`
+	Template    ContestTemplate `json:"template"`
+}
+
+// IntegrityFinding is the IntegrityFinding schema of the API
+type IntegrityFinding struct {
+	Found    int64  `json:"found"`
+	Issue    string `json:"issue"`
+	Repaired int64  `json:"repaired"`
+}
+
+// IntegrityReport is the IntegrityReport schema of the API
+type IntegrityReport struct {
+	DryRun     bool               `json:"dry_run"`
+	Findings   []IntegrityFinding `json:"findings"`
+	FinishedAt time.Time          `json:"finished_at"`
+	StartedAt  time.Time          `json:"started_at"`
+}
+
+// JoinOrgRequest is the JoinOrgRequest schema of the API
+type JoinOrgRequest struct {
+	Code string `json:"code"`
+}
+
+// LeaderboardEntry is the LeaderboardEntry schema of the API
+type LeaderboardEntry struct {
+	LastSolvedAt time.Time `json:"last_solved_at"`
+	Rank         int       `json:"rank"`
+	Solved       int64     `json:"solved"`
+	UserID       string    `json:"user_id"`
+	Username     string    `json:"username"`
+}
+
+// LogLevelStatus is the LogLevelStatus schema of the API
+type LogLevelStatus struct {
+	Default   string     `json:"default"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Level     string     `json:"level"`
+}
+
+// LoginRequest is the LoginRequest schema of the API
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LogoutRequest is the LogoutRequest schema of the API
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// MaintenanceStatus is the MaintenanceStatus schema of the API
+type MaintenanceStatus struct {
+	Active            bool       `json:"active"`
+	EndsAt            *time.Time `json:"ends_at"`
+	Message           string     `json:"message"`
+	RetryAfterSeconds int        `json:"retry_after_seconds"`
+	StartsAt          *time.Time `json:"starts_at"`
+	Upcoming          bool       `json:"upcoming"`
+}
+
+// MarkProblemCompleteRequest is the MarkProblemCompleteRequest schema of the API
+type MarkProblemCompleteRequest struct {
+	Confidence  *int `json:"confidence,omitempty"`
+	IsCompleted bool `json:"is_completed,omitempty"`
+}
+
+// MenteeNote is the MenteeNote schema of the API
+type MenteeNote struct {
+	ContestID string     `json:"contest_id"`
+	Retro     string     `json:"retro"`
+	StartedAt time.Time  `json:"started_at"`
+	Status    string     `json:"status"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// MenteeTiming is the MenteeTiming schema of the API
+type MenteeTiming struct {
+	ContestStats ContestStatistics      `json:"contest_stats"`
+	LastActiveAt *time.Time             `json:"last_active_at"`
+	SolveTimes   map[string]SolveTiming `json:"solve_times"`
+}
+
+// MentorAssignmentResponse is the MentorAssignmentResponse schema of the API
+type MentorAssignmentResponse struct {
+	Contest         CreateContestRequest `json:"contest"`
+	ContestID       *string              `json:"contest_id"`
+	CreatedAt       time.Time            `json:"created_at"`
+	DueAt           time.Time            `json:"due_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	EndedAt         *time.Time           `json:"ended_at"`
+	ID              string               `json:"id"`
+	Kind            string               `json:"kind"`
+	Late            bool                 `json:"late"`
+	MentorshipID    string               `json:"mentorship_id"`
+	Overdue         bool                 `json:"overdue"`
+	ProblemIds      []string             `json:"problem_ids"`
+	Solved          int                  `json:"solved"`
+	StartedAt       *time.Time           `json:"started_at"`
+	Status          string               `json:"status"`
+	Title           string               `json:"title"`
+	Total           int                  `json:"total"`
+}
+
+// Mentorship is the Mentorship schema of the API
+type Mentorship struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	ID          string     `json:"id"`
+	InvitedAt   time.Time  `json:"invited_at"`
+	MenteeID    string     `json:"mentee_id"`
+	MentorID    string     `json:"mentor_id"`
+	RespondedAt *time.Time `json:"responded_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	RevokedBy   *string    `json:"revoked_by"`
+	Status      string     `json:"status"`
+}
+
+// MentorshipAudit is the MentorshipAudit schema of the API
+type MentorshipAudit struct {
+	Entries []MentorshipAuditEntry `json:"entries"`
+}
+
+// MentorshipAuditEntry is the MentorshipAuditEntry schema of the API
+type MentorshipAuditEntry struct {
+	Action       string    `json:"action"`
+	ActorID      string    `json:"actor_id"`
+	AssignmentID *string   `json:"assignment_id"`
+	ContestID    *string   `json:"contest_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	ID           string    `json:"id"`
+	MentorshipID string    `json:"mentorship_id"`
+}
+
+// MentorshipList is the MentorshipList schema of the API
+type MentorshipList struct {
+	Mentoring []MentorshipResponse `json:"mentoring"`
+	Mentors   []MentorshipResponse `json:"mentors"`
+}
+
+// MentorshipResponse is the MentorshipResponse schema of the API
+type MentorshipResponse struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	ID             string     `json:"id"`
+	InvitedAt      time.Time  `json:"invited_at"`
+	MenteeID       string     `json:"mentee_id"`
+	MenteeUsername string     `json:"mentee_username"`
+	MentorID       string     `json:"mentor_id"`
+	MentorUsername string     `json:"mentor_username"`
+	RespondedAt    *time.Time `json:"responded_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	RevokedBy      *string    `json:"revoked_by"`
+	Status         string     `json:"status"`
+}
+
+// MessageResponse confirms an operation that has no other result
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// ModerateProblemListRequest is the ModerateProblemListRequest schema of the API
+type ModerateProblemListRequest struct {
+	Reason string `json:"reason,omitempty"`
+	Status string `json:"status"`
+}
+
+// NoteHighlight is the NoteHighlight schema of the API
+type NoteHighlight struct {
+	Length int `json:"length"`
+	Start  int `json:"start"`
+}
+
+// NoteSearchResponse is the NoteSearchResponse schema of the API
+type NoteSearchResponse struct {
+	Count   int                `json:"count"`
+	Query   string             `json:"query"`
+	Results []NoteSearchResult `json:"results"`
+}
+
+// NoteSearchResult is the NoteSearchResult schema of the API
+type NoteSearchResult struct {
+	ContestID    string          `json:"contest_id"`
+	Excerpt      string          `json:"excerpt"`
+	Highlights   []NoteHighlight `json:"highlights"`
+	Kind         string          `json:"kind"`
+	ProblemID    *string         `json:"problem_id"`
+	ProblemTitle string          `json:"problem_title"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// OrgAssignment is the OrgAssignment schema of the API
+type OrgAssignment struct {
+	CategoryID      *string              `json:"category_id"`
+	Contest         CreateContestRequest `json:"contest"`
+	CreatedAt       time.Time            `json:"created_at"`
+	CreatedBy       string               `json:"created_by"`
+	DueAt           *time.Time           `json:"due_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	ID              string               `json:"id"`
+	Kind            string               `json:"kind"`
+	OrgID           string               `json:"org_id"`
+	ProblemIds      []string             `json:"problem_ids"`
+	Title           string               `json:"title"`
+}
+
+// OrgAssignmentResponse is the OrgAssignmentResponse schema of the API
+type OrgAssignmentResponse struct {
+	CategoryID      *string              `json:"category_id"`
+	CompletedCount  *int                 `json:"completed_count"`
+	Contest         CreateContestRequest `json:"contest"`
+	ContestID       *string              `json:"contest_id"`
+	CreatedAt       time.Time            `json:"created_at"`
+	CreatedBy       string               `json:"created_by"`
+	DueAt           *time.Time           `json:"due_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	ID              string               `json:"id"`
+	Kind            string               `json:"kind"`
+	OrgID           string               `json:"org_id"`
+	Overdue         bool                 `json:"overdue"`
+	ProblemIds      []string             `json:"problem_ids"`
+	Solved          int                  `json:"solved"`
+	Status          string               `json:"status"`
+	Title           string               `json:"title"`
+	Total           int                  `json:"total"`
+}
+
+// OrgInvite is the OrgInvite schema of the API
+type OrgInvite struct {
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	OrgID     string    `json:"org_id"`
+	Role      string    `json:"role"`
+}
+
+// OrgMemberResponse is the OrgMemberResponse schema of the API
+type OrgMemberResponse struct {
+	JoinedAt time.Time `json:"joined_at"`
+	Role     string    `json:"role"`
+	UserID   string    `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+// OrgResponse is the OrgResponse schema of the API
+type OrgResponse struct {
+	CreatedAt time.Time           `json:"created_at"`
+	ID        string              `json:"id"`
+	Invites   []OrgInvite         `json:"invites"`
+	Members   []OrgMemberResponse `json:"members"`
+	Name      string              `json:"name"`
+	OwnerID   string              `json:"owner_id"`
+	Role      string              `json:"role"`
+}
+
+// OrgRoster is the OrgRoster schema of the API
+type OrgRoster struct {
+	Assignments int               `json:"assignments"`
+	Limit       int               `json:"limit"`
+	Members     []OrgRosterMember `json:"members"`
+	Offset      int               `json:"offset"`
+	Total       int64             `json:"total"`
+}
+
+// OrgRosterMember is the OrgRosterMember schema of the API
+type OrgRosterMember struct {
+	AssignmentsCompleted int        `json:"assignments_completed"`
+	AssignmentsOverdue   int        `json:"assignments_overdue"`
+	CompletedContests    int        `json:"completed_contests"`
+	CompletionRate       float64    `json:"completion_rate"`
+	EasySolved           int        `json:"easy_solved"`
+	HardSolved           int        `json:"hard_solved"`
+	JoinedAt             time.Time  `json:"joined_at"`
+	LastActiveAt         *time.Time `json:"last_active_at"`
+	MediumSolved         int        `json:"medium_solved"`
+	TotalContests        int        `json:"total_contests"`
+	TotalSolved          int        `json:"total_solved"`
+	UserID               string     `json:"user_id"`
+	Username             string     `json:"username"`
+}
+
+// OrgSummary is the OrgSummary schema of the API
+type OrgSummary struct {
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	MemberCount int       `json:"member_count"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+}
+
+// PageMetadata is the PageMetadata schema of the API
+type PageMetadata struct {
+	CanonicalURL string   `json:"canonical_url"`
+	Description  string   `json:"description"`
+	Keywords     []string `json:"keywords"`
+	Title        string   `json:"title"`
+}
+
+// PendingAssignment is the PendingAssignment schema of the API
+type PendingAssignment struct {
+	AssignmentID string     `json:"assignment_id"`
+	ContestID    *string    `json:"contest_id"`
+	DueAt        *time.Time `json:"due_at"`
+	Kind         string     `json:"kind"`
+	Overdue      bool       `json:"overdue"`
+	Solved       int        `json:"solved"`
+	Source       string     `json:"source"`
+	SourceID     string     `json:"source_id"`
+	SourceName   string     `json:"source_name"`
+	Status       string     `json:"status"`
+	Title        string     `json:"title"`
+	Total        int        `json:"total"`
+}
+
+// PopularProblem is the PopularProblem schema of the API
+type PopularProblem struct {
+	Attempts   int64   `json:"attempts"`
+	Difficulty string  `json:"difficulty"`
+	ID         string  `json:"id"`
+	Slug       string  `json:"slug"`
+	SolveRate  float64 `json:"solve_rate"`
+	Title      string  `json:"title"`
+	Users      int64   `json:"users"`
+}
+
+// PostAuthRefreshResponse is the response body of PostAuthRefresh
+type PostAuthRefreshResponse struct {
+	Tokens TokenPair `json:"tokens"`
+}
+
+// PostBillingWebhookRequest is the request body of PostBillingWebhook
+type PostBillingWebhookRequest struct {
+	Created int                           `json:"created,omitempty"`
+	Data    PostBillingWebhookRequestData `json:"data,omitempty"`
+	ID      string                        `json:"id,omitempty"`
+	Type    string                        `json:"type,omitempty"`
+}
+
+// PostBillingWebhookRequestData is the PostBillingWebhookRequestData schema of the API
+type PostBillingWebhookRequestData struct {
+	Object map[string]any `json:"object,omitempty"`
+}
+
+// PostChatMessageRequest is the PostChatMessageRequest schema of the API
+type PostChatMessageRequest struct {
+	Body string `json:"body"`
+}
+
+// PostContestsIDEventsResponse is the response body of PostContestsIDEvents
+type PostContestsIDEventsResponse struct {
+	Recorded int `json:"recorded"`
+}
+
+// Presence is the Presence schema of the API
+type Presence struct {
+	ContestID  *string    `json:"contest_id"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	Status     string     `json:"status"`
+}
+
+// ProblemAvailability is the ProblemAvailability schema of the API
+type ProblemAvailability struct {
+	ByDifficulty map[string]int `json:"by_difficulty"`
+	ByTopic      map[string]int `json:"by_topic"`
+	Total        int            `json:"total"`
+}
+
+// ProblemBatchRequest is the ProblemBatchRequest schema of the API
+type ProblemBatchRequest struct {
+	Keys []string `json:"keys"`
+}
+
+// ProblemBatchResponse is the ProblemBatchResponse schema of the API
+type ProblemBatchResponse struct {
+	Count    int               `json:"count"`
+	NotFound []string          `json:"not_found"`
+	Problems []ProblemResponse `json:"problems"`
+}
+
+// ProblemCalibration is the ProblemCalibration schema of the API
+type ProblemCalibration struct {
+	Difficulty string            `json:"difficulty"`
+	ID         string            `json:"id"`
+	Importance int               `json:"importance"`
+	Popularity ProblemPopularity `json:"popularity"`
+	Title      string            `json:"title"`
+}
+
+// ProblemComplexity is the ProblemComplexity schema of the API
+type ProblemComplexity struct {
+	ProblemID       string `json:"problem_id"`
+	SpaceComplexity string `json:"space_complexity"`
+	TimeComplexity  string `json:"time_complexity"`
+	Title           string `json:"title"`
+}
+
+// ProblemListPage is the ProblemListPage schema of the API
+type ProblemListPage struct {
+	Limit  int                  `json:"limit"`
+	Lists  []ProblemListSummary `json:"lists"`
+	Offset int                  `json:"offset"`
+	Total  int64                `json:"total"`
+}
+
+// ProblemListProblem is the ProblemListProblem schema of the API
+type ProblemListProblem struct {
+	Companies   []string          `json:"companies"`
+	Custom      bool              `json:"custom"`
+	Difficulty  string            `json:"difficulty"`
+	ID          string            `json:"id"`
+	Importance  int               `json:"importance"`
+	LeetcodeURL string            `json:"leetcode_url"`
+	NeetcodeURL string            `json:"neetcode_url"`
+	Popularity  ProblemPopularity `json:"popularity"`
+	Position    int               `json:"position"`
+	Slug        string            `json:"slug"`
+	Solved      bool              `json:"solved"`
+	Title       string            `json:"title"`
+	Topics      []string          `json:"topics"`
+}
+
+// ProblemListRequest is the ProblemListRequest schema of the API
+type ProblemListRequest struct {
+	Description string   `json:"description,omitempty"`
+	Problems    []string `json:"problems"`
+	Title       string   `json:"title"`
+}
+
+// ProblemListResponse is the ProblemListResponse schema of the API
+type ProblemListResponse struct {
+	CreatedAt     time.Time            `json:"created_at"`
+	Description   string               `json:"description"`
+	HiddenReason  string               `json:"hidden_reason"`
+	ID            string               `json:"id"`
+	OwnerID       string               `json:"owner_id"`
+	OwnerUsername string               `json:"owner_username"`
+	ProblemCount  int                  `json:"problem_count"`
+	Problems      []ProblemListProblem `json:"problems"`
+	Reports       int                  `json:"reports"`
+	Solved        int                  `json:"solved"`
+	Status        string               `json:"status"`
+	Subscribed    bool                 `json:"subscribed"`
+	Subscribers   int                  `json:"subscribers"`
+	Title         string               `json:"title"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// ProblemListSummary is the ProblemListSummary schema of the API
+type ProblemListSummary struct {
+	CreatedAt     time.Time `json:"created_at"`
+	Description   string    `json:"description"`
+	HiddenReason  string    `json:"hidden_reason"`
+	ID            string    `json:"id"`
+	OwnerID       string    `json:"owner_id"`
+	OwnerUsername string    `json:"owner_username"`
+	ProblemCount  int       `json:"problem_count"`
+	Reports       int       `json:"reports"`
+	Status        string    `json:"status"`
+	Subscribed    bool      `json:"subscribed"`
+	Subscribers   int       `json:"subscribers"`
+	Title         string    `json:"title"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ProblemPage is the ProblemPage schema of the API
+type ProblemPage struct {
+	Prerequisites []ProblemResponse `json:"prerequisites"`
+	Problem       ProblemResponse   `json:"problem"`
+	Seo           PageMetadata      `json:"seo"`
+}
+
+// ProblemPopularity is the ProblemPopularity schema of the API
+type ProblemPopularity struct {
+	CompletionRate float64 `json:"completion_rate"`
+	TimesCompleted int64   `json:"times_completed"`
+	TimesSelected  int64   `json:"times_selected"`
+}
+
+// ProblemPrerequisitesResponse is the ProblemPrerequisitesResponse schema of the API
+type ProblemPrerequisitesResponse struct {
+	Count         int               `json:"count"`
+	Prerequisites []ProblemResponse `json:"prerequisites"`
+	ProblemID     string            `json:"problem_id"`
+}
+
+// ProblemResponse is the ProblemResponse schema of the API
+type ProblemResponse struct {
+	Companies   []string          `json:"companies"`
+	Custom      bool              `json:"custom"`
+	Difficulty  string            `json:"difficulty"`
+	ID          string            `json:"id"`
+	Importance  int               `json:"importance"`
+	LeetcodeURL string            `json:"leetcode_url"`
+	NeetcodeURL string            `json:"neetcode_url"`
+	Popularity  ProblemPopularity `json:"popularity"`
+	Slug        string            `json:"slug"`
+	Title       string            `json:"title"`
+	Topics      []string          `json:"topics"`
+}
+
+// ProblemSearchResponse is the ProblemSearchResponse schema of the API
+type ProblemSearchResponse struct {
+	Count   int                   `json:"count"`
+	Query   string                `json:"query"`
+	Results []ProblemSearchResult `json:"results"`
+}
+
+// ProblemSearchResult is the ProblemSearchResult schema of the API
+type ProblemSearchResult struct {
+	Highlights []SearchHighlight `json:"highlights"`
+	Problem    ProblemResponse   `json:"problem"`
+	Score      float64           `json:"score"`
+}
+
+// ProblemStats is the ProblemStats schema of the API
+type ProblemStats struct {
+	ByDifficulty map[string]int `json:"by_difficulty"`
+	ByTopic      map[string]int `json:"by_topic"`
+	Total        int            `json:"total"`
+}
+
+// ProctoringSummary is the ProctoringSummary schema of the API
+type ProctoringSummary struct {
+	AssignmentID       string         `json:"assignment_id"`
+	AwaySeconds        int            `json:"away_seconds"`
+	ContestID          string         `json:"contest_id"`
+	Events             []ContestEvent `json:"events"`
+	FocusLosses        int            `json:"focus_losses"`
+	LongestAwaySeconds int            `json:"longest_away_seconds"`
+	Status             string         `json:"status"`
+	TabSwitches        int            `json:"tab_switches"`
+	Truncated          bool           `json:"truncated"`
+	UserID             string         `json:"user_id"`
+}
+
+// PublicProblemStats is the PublicProblemStats schema of the API
+type PublicProblemStats struct {
+	ComputedAt time.Time        `json:"computed_at"`
+	Count      int              `json:"count"`
+	Problems   []PopularProblem `json:"problems"`
+}
+
+// PublicStats is the PublicStats schema of the API
+type PublicStats struct {
+	CompletionRate   *float64           `json:"completion_rate"`
+	ComputedAt       time.Time          `json:"computed_at"`
+	ContestSizes     []ContestSizeCount `json:"contest_sizes"`
+	ContestsFinished int64              `json:"contests_finished"`
+}
+
+// PutContestsIDTagsResponse is the response body of PutContestsIDTags
+type PutContestsIDTagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// QuickCommand is the QuickCommand schema of the API
+type QuickCommand struct {
+	DurationMinutes int    `json:"duration_minutes"`
+	Problem         int    `json:"problem"`
+	ProblemCount    int    `json:"problem_count"`
+	Verb            string `json:"verb"`
+}
+
+// QuickCommandLog is the QuickCommandLog schema of the API
+type QuickCommandLog struct {
+	Command   QuickCommand `json:"command"`
+	ContestID *string      `json:"contest_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Error     string       `json:"error"`
+	ID        string       `json:"id"`
+	Input     string       `json:"input"`
+}
+
+// QuickCommandRequest is the QuickCommandRequest schema of the API
+type QuickCommandRequest struct {
+	Command string `json:"command"`
+}
+
+// QuickHistory is the QuickHistory schema of the API
+type QuickHistory struct {
+	Commands []QuickCommandLog `json:"commands"`
+}
+
+// QuickResult is the QuickResult schema of the API
+type QuickResult struct {
+	Command QuickCommand    `json:"command"`
+	Contest ContestResponse `json:"contest"`
+	Message string          `json:"message"`
+}
+
+// QuotaStatus is the QuotaStatus schema of the API
+type QuotaStatus struct {
+	Plan   string       `json:"plan"`
+	Quotas []QuotaUsage `json:"quotas"`
+	Reason string       `json:"reason"`
+	UserID string       `json:"user_id"`
+}
+
+// QuotaUsage is the QuotaUsage schema of the API
+type QuotaUsage struct {
+	Kind       string     `json:"kind"`
+	Limit      *int       `json:"limit"`
+	Overridden bool       `json:"overridden"`
+	Remaining  *int       `json:"remaining"`
+	ResetsAt   *time.Time `json:"resets_at"`
+	Used       int        `json:"used"`
+}
+
+// RateContestRequest is the RateContestRequest schema of the API
+type RateContestRequest struct {
+	Rating int `json:"rating"`
+}
+
+// RecordAttemptRequest is the RecordAttemptRequest schema of the API
+type RecordAttemptRequest struct {
+	Outcome string `json:"outcome"`
+}
+
+// RecordContestEventsRequest is the RecordContestEventsRequest schema of the API
+type RecordContestEventsRequest struct {
+	Events []ContestEventInput `json:"events"`
+}
+
+// RefreshRequest is the RefreshRequest schema of the API
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ReportProblemListRequest is the ReportProblemListRequest schema of the API
+type ReportProblemListRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RetentionReport is the RetentionReport schema of the API
+type RetentionReport struct {
+	DryRun     bool              `json:"dry_run"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Results    []RetentionResult `json:"results"`
+	StartedAt  time.Time         `json:"started_at"`
+}
+
+// RetentionResult is the RetentionResult schema of the API
+type RetentionResult struct {
+	Cutoff  *time.Time `json:"cutoff"`
+	Enabled bool       `json:"enabled"`
+	Found   int64      `json:"found"`
+	Policy  string     `json:"policy"`
+	Purged  int64      `json:"purged"`
+}
+
+// ReviewItem is the ReviewItem schema of the API
+type ReviewItem struct {
+	Confidence   *int            `json:"confidence"`
+	Due          bool            `json:"due"`
+	DueAt        time.Time       `json:"due_at"`
+	LastSolvedAt time.Time       `json:"last_solved_at"`
+	Problem      ProblemResponse `json:"problem"`
+}
+
+// ReviewQueue is the ReviewQueue schema of the API
+type ReviewQueue struct {
+	Due     int          `json:"due"`
+	Reviews []ReviewItem `json:"reviews"`
+}
+
+// ReviewSimilarityRequest is the ReviewSimilarityRequest schema of the API
+type ReviewSimilarityRequest struct {
+	Note   string `json:"note,omitempty"`
+	Status string `json:"status"`
+}
+
+// RoadmapCategoryResponse is the RoadmapCategoryResponse schema of the API
+type RoadmapCategoryResponse struct {
+	Completed *int                     `json:"completed"`
+	ID        string                   `json:"id"`
+	Name      string                   `json:"name"`
+	Position  int                      `json:"position"`
+	Problems  []RoadmapProblemResponse `json:"problems"`
+	Total     int                      `json:"total"`
+}
+
+// RoadmapProblemResponse is the RoadmapProblemResponse schema of the API
+type RoadmapProblemResponse struct {
+	Companies   []string          `json:"companies"`
+	Custom      bool              `json:"custom"`
+	Difficulty  string            `json:"difficulty"`
+	ID          string            `json:"id"`
+	Importance  int               `json:"importance"`
+	LeetcodeURL string            `json:"leetcode_url"`
+	NeetcodeURL string            `json:"neetcode_url"`
+	Popularity  ProblemPopularity `json:"popularity"`
+	Position    int               `json:"position"`
+	Slug        string            `json:"slug"`
+	Solved      *bool             `json:"solved"`
+	Title       string            `json:"title"`
+	Topics      []string          `json:"topics"`
+}
+
+// RoadmapResponse is the RoadmapResponse schema of the API
+type RoadmapResponse struct {
+	Categories []RoadmapCategoryResponse `json:"categories"`
+	Completed  *int                      `json:"completed"`
+	Total      int                       `json:"total"`
+}
+
+// RunIntegrityRequest is the RunIntegrityRequest schema of the API
+type RunIntegrityRequest struct {
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// RunRetentionRequest is the RunRetentionRequest schema of the API
+type RunRetentionRequest struct {
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// SavedFilter is the SavedFilter schema of the API
+type SavedFilter struct {
+	Companies    []string  `json:"companies"`
+	CreatedAt    time.Time `json:"created_at"`
+	Difficulties []string  `json:"difficulties"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	SolvedState  string    `json:"solved_state"`
+	Topics       []string  `json:"topics"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	UserID       string    `json:"user_id"`
+}
+
+// SavedFilterRequest is the SavedFilterRequest schema of the API
+type SavedFilterRequest struct {
+	Companies    []string `json:"companies,omitempty"`
+	Difficulties []string `json:"difficulties,omitempty"`
+	Name         string   `json:"name"`
+	SolvedState  string   `json:"solved_state,omitempty"`
+	Topics       []string `json:"topics,omitempty"`
+}
+
+// SearchHighlight is the SearchHighlight schema of the API
+type SearchHighlight struct {
+	Field  string `json:"field"`
+	Index  int    `json:"index"`
+	Length int    `json:"length"`
+	Start  int    `json:"start"`
+}
+
+// SetContestTagsRequest is the SetContestTagsRequest schema of the API
+type SetContestTagsRequest struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+// SetLogLevelRequest is the SetLogLevelRequest schema of the API
+type SetLogLevelRequest struct {
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+	Level           string `json:"level"`
+}
+
+// SetMaintenanceRequest is the SetMaintenanceRequest schema of the API
+type SetMaintenanceRequest struct {
+	Enabled  *bool      `json:"enabled"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+	Message  string     `json:"message,omitempty"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+// SetProblemCompaniesRequest is the SetProblemCompaniesRequest schema of the API
+type SetProblemCompaniesRequest struct {
+	Companies []string `json:"companies,omitempty"`
+}
+
+// SetProblemComplexityRequest is the SetProblemComplexityRequest schema of the API
+type SetProblemComplexityRequest struct {
+	SpaceComplexity string `json:"space_complexity,omitempty"`
+	TimeComplexity  string `json:"time_complexity,omitempty"`
+}
+
+// SetProblemImportanceRequest is the SetProblemImportanceRequest schema of the API
+type SetProblemImportanceRequest struct {
+	Importance int `json:"importance"`
+}
+
+// SetQuotaOverrideRequest is the SetQuotaOverrideRequest schema of the API
+type SetQuotaOverrideRequest struct {
+	ContestsPerDay *int   `json:"contests_per_day,omitempty"`
+	CustomProblems *int   `json:"custom_problems,omitempty"`
+	Plan           string `json:"plan"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// SignedContestTemplate is the SignedContestTemplate schema of the API
+type SignedContestTemplate struct {
+	Signature string          `json:"signature"`
+	Template  ContestTemplate `json:"template"`
+}
+
+// SimilarityFlagDetail is the SimilarityFlagDetail schema of the API
+type SimilarityFlagDetail struct {
+	AssignmentID string          `json:"assignment_id"`
+	ContestAID   string          `json:"contest_a_id"`
+	ContestBID   string          `json:"contest_b_id"`
+	DetectedAt   time.Time       `json:"detected_at"`
+	ID           string          `json:"id"`
+	Note         string          `json:"note"`
+	ProblemID    string          `json:"problem_id"`
+	ProblemTitle string          `json:"problem_title"`
+	ReviewedAt   *time.Time      `json:"reviewed_at"`
+	ReviewedBy   *string         `json:"reviewed_by"`
+	Similarity   float64         `json:"similarity"`
+	SnippetA     SolutionSnippet `json:"snippet_a"`
+	SnippetB     SolutionSnippet `json:"snippet_b"`
+	Status       string          `json:"status"`
+	UserAID      string          `json:"user_a_id"`
+	UserBID      string          `json:"user_b_id"`
+	UsernameA    string          `json:"username_a"`
+	UsernameB    string          `json:"username_b"`
+}
+
+// SimilarityFlagResponse is the SimilarityFlagResponse schema of the API
+type SimilarityFlagResponse struct {
+	AssignmentID string     `json:"assignment_id"`
+	ContestAID   string     `json:"contest_a_id"`
+	ContestBID   string     `json:"contest_b_id"`
+	DetectedAt   time.Time  `json:"detected_at"`
+	ID           string     `json:"id"`
+	Note         string     `json:"note"`
+	ProblemID    string     `json:"problem_id"`
+	ProblemTitle string     `json:"problem_title"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	ReviewedBy   *string    `json:"reviewed_by"`
+	Similarity   float64    `json:"similarity"`
+	Status       string     `json:"status"`
+	UserAID      string     `json:"user_a_id"`
+	UserBID      string     `json:"user_b_id"`
+	UsernameA    string     `json:"username_a"`
+	UsernameB    string     `json:"username_b"`
+}
+
+// SimilarityRunReport is the SimilarityRunReport schema of the API
+type SimilarityRunReport struct {
+	Checked  int `json:"checked"`
+	Cleared  int `json:"cleared"`
+	Compared int `json:"compared"`
+	Flagged  int `json:"flagged"`
+}
+
+// SolutionSnippet is the SolutionSnippet schema of the API
+type SolutionSnippet struct {
+	Code      string    `json:"code"`
+	ContestID string    `json:"contest_id"`
+	Language  string    `json:"language"`
+	ProblemID string    `json:"problem_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    string    `json:"user_id"`
+}
+
+// SolveTiming is the SolveTiming schema of the API
+type SolveTiming struct {
+	AverageSeconds int `json:"average_seconds"`
+	TimedSolves    int `json:"timed_solves"`
+}
+
+// SpectatorInvite is the SpectatorInvite schema of the API
+type SpectatorInvite struct {
+	Anonymize     bool   `json:"anonymize"`
+	SpectatorCode string `json:"spectator_code"`
+}
+
+// SpectatorInviteRequest is the SpectatorInviteRequest schema of the API
+type SpectatorInviteRequest struct {
+	Anonymize bool `json:"anonymize,omitempty"`
+}
+
+// SpectatorStanding is the SpectatorStanding schema of the API
+type SpectatorStanding struct {
+	Name     string `json:"name"`
+	Presence string `json:"presence"`
+	Solved   int    `json:"solved"`
+	Status   string `json:"status"`
+	Total    int    `json:"total"`
+}
+
+// SpectatorView is the SpectatorView schema of the API
+type SpectatorView struct {
+	Anonymized   bool                `json:"anonymized"`
+	Participants []SpectatorStanding `json:"participants"`
+}
+
+// StateComplexityRequest is the StateComplexityRequest schema of the API
+type StateComplexityRequest struct {
+	SpaceComplexity string `json:"space_complexity,omitempty"`
+	TimeComplexity  string `json:"time_complexity,omitempty"`
+}
+
+// TagCount is the TagCount schema of the API
+type TagCount struct {
+	Count int64  `json:"count"`
+	Tag   string `json:"tag"`
+}
+
+// TemplateConflict is the TemplateConflict schema of the API
+type TemplateConflict struct {
+	Difficulty string `json:"difficulty"`
+	Expected   string `json:"expected"`
+	Reason     string `json:"reason"`
+	Slug       string `json:"slug"`
+}
+
+// TemplateProblem is the TemplateProblem schema of the API
+type TemplateProblem struct {
+	Difficulty string `json:"difficulty,omitempty"`
+	Slug       string `json:"slug"`
+	Title      string `json:"title,omitempty"`
+}
+
+// Tenant is the Tenant schema of the API
+type Tenant struct {
+	AdminEmail string    `json:"admin_email"`
+	CreatedAt  time.Time `json:"created_at"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Slug       string    `json:"slug"`
+}
+
+// TenantInfo is the TenantInfo schema of the API
+type TenantInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// TokenPair is the TokenPair schema of the API
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+// TopicContestStats is the TopicContestStats schema of the API
+type TopicContestStats struct {
+	Appearances    int     `json:"appearances"`
+	Completed      int     `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"`
+	Contests       int     `json:"contests"`
+	Topic          string  `json:"topic"`
+}
+
+// TopicStats is the TopicStats schema of the API
+type TopicStats struct {
+	Solved int `json:"solved"`
+	Total  int `json:"total"`
+}
+
+// UpdateContestSessionRequest is the UpdateContestSessionRequest schema of the API
+type UpdateContestSessionRequest struct {
+	CurrentProblemID *string           `json:"current_problem_id,omitempty"`
+	Device           string            `json:"device,omitempty"`
+	Notes            map[string]string `json:"notes,omitempty"`
+	Version          int               `json:"version,omitempty"`
+}
+
+// UpdateDigestRequest is the UpdateDigestRequest schema of the API
+type UpdateDigestRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// UpdateFeatureFlagRequest is the UpdateFeatureFlagRequest schema of the API
+type UpdateFeatureFlagRequest struct {
+	Enabled        *bool `json:"enabled"`
+	RolloutPercent *int  `json:"rollout_percent,omitempty"`
+}
+
+// UpdateRetroRequest is the UpdateRetroRequest schema of the API
+type UpdateRetroRequest struct {
+	Retro string `json:"retro,omitempty"`
+}
+
+// UserCreateRequest is the UserCreateRequest schema of the API
+type UserCreateRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// UserProgress is the UserProgress schema of the API
+type UserProgress struct {
+	Attempts      AttemptStats           `json:"attempts"`
+	Confidence    ConfidenceStats        `json:"confidence"`
+	ContestStats  ContestStatistics      `json:"contest_stats"`
+	EasySolved    int                    `json:"easy_solved"`
+	HardSolved    int                    `json:"hard_solved"`
+	LastActiveAt  *time.Time             `json:"last_active_at"`
+	MediumSolved  int                    `json:"medium_solved"`
+	SolveTimes    map[string]SolveTiming `json:"solve_times"`
+	TopicProgress map[string]TopicStats  `json:"topic_progress"`
+	TotalSolved   int                    `json:"total_solved"`
+}
+
+// UserResponse is the UserResponse schema of the API
+type UserResponse struct {
+	CreatedAt          time.Time  `json:"created_at"`
+	Email              string     `json:"email"`
+	ID                 string     `json:"id"`
+	Plan               string     `json:"plan"`
+	PlanRenewsAt       *time.Time `json:"plan_renews_at"`
+	Role               string     `json:"role"`
+	SubscriptionStatus string     `json:"subscription_status"`
+	Username           string     `json:"username"`
+}
+
+// VariantOutcome is the VariantOutcome schema of the API
+type VariantOutcome struct {
+	Abandoned      int64   `json:"abandoned"`
+	Completed      int64   `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"`
+	Contests       int64   `json:"contests"`
+	ProblemsServed int64   `json:"problems_served"`
+	ProblemsSolved int64   `json:"problems_solved"`
+	SolveRate      float64 `json:"solve_rate"`
+	Variant        string  `json:"variant"`
+}
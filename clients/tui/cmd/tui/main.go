@@ -0,0 +1,47 @@
+// Command tui is a terminal client for practising contests without a browser.
+//
+// It signs in with the Go client, resumes the active contest or creates a new
+// one, shows the countdown and marks problems complete. Tokens are kept in the
+// user's config directory so later runs skip the login screen.
+//
+//	go run ./cmd/tui -url http://localhost:8080
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	contestmaker "github.com/contest-maker-150/clients/go"
+)
+
+func main() {
+	baseURL := flag.String("url", envOr("CONTEST_MAKER_URL", "http://localhost:8080"), "base URL of the API")
+	tokenFile := flag.String("tokens", defaultTokenFile(), "file the session tokens are stored in; empty disables persistence")
+	flag.Parse()
+
+	store := tokenStore{path: *tokenFile}
+	opts := []contestmaker.Option{
+		contestmaker.WithClientName("contest-maker-tui"),
+		contestmaker.WithTokenHandler(store.save),
+	}
+	if tokens, err := store.load(); err != nil {
+		fmt.Fprintf(os.Stderr, "ignoring saved tokens: %v\n", err)
+	} else if tokens != nil {
+		opts = append(opts, contestmaker.WithTokens(*tokens))
+	}
+	client := contestmaker.New(*baseURL, opts...)
+
+	if _, err := tea.NewProgram(newModel(client), tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
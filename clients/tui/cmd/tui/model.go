@@ -0,0 +1,493 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	contestmaker "github.com/contest-maker-150/clients/go"
+)
+
+// requestTimeout bounds one API call including the client's retries
+const requestTimeout = 20 * time.Second
+
+type screen int
+
+const (
+	screenLoading screen = iota // Waiting for the active contest
+	screenLogin
+	screenNewContest
+	screenContest
+)
+
+// form is a list of single-line text fields edited in place
+type form struct {
+	labels  []string
+	values  []string
+	secret  []bool // Rendered as asterisks
+	focused int
+}
+
+func (f *form) update(key tea.KeyMsg) {
+	switch key.Type {
+	case tea.KeyTab, tea.KeyDown:
+		f.focused = (f.focused + 1) % len(f.values)
+	case tea.KeyShiftTab, tea.KeyUp:
+		f.focused = (f.focused + len(f.values) - 1) % len(f.values)
+	case tea.KeyBackspace:
+		if v := f.values[f.focused]; v != "" {
+			runes := []rune(v)
+			f.values[f.focused] = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		f.values[f.focused] += string(key.Runes)
+	}
+}
+
+func (f *form) view(b *strings.Builder) {
+	for i, label := range f.labels {
+		cursor := "  "
+		if i == f.focused {
+			cursor = "> "
+		}
+		value := f.values[i]
+		if f.secret[i] {
+			value = strings.Repeat("*", len([]rune(value)))
+		}
+		fmt.Fprintf(b, "%s%-10s %s\n", cursor, label+":", value)
+	}
+}
+
+// Messages delivered by API commands and the countdown
+type (
+	signedInMsg  struct{}
+	signedOutMsg struct{}
+	contestMsg   struct{ contest *contestmaker.ContestResponse } // nil when there is no active contest
+	statusMsg    string
+	errMsg       struct{ err error }
+	tickMsg      time.Time
+)
+
+type model struct {
+	client *contestmaker.Client
+	screen screen
+	busy   bool
+
+	login      form
+	newContest form
+
+	contest  *contestmaker.ContestResponse
+	deadline time.Time // When the contest (or its warm-up) ends, from the last fetch
+	cursor   int
+	now      time.Time
+
+	status string
+	err    error
+}
+
+func newModel(client *contestmaker.Client) model {
+	m := model{
+		client: client,
+		login: form{
+			labels: []string{"Email", "Password"},
+			values: []string{"", ""},
+			secret: []bool{false, true},
+		},
+		newContest: form{
+			labels: []string{"Problems", "Minutes"},
+			values: []string{"3", "60"},
+			secret: []bool{false, false},
+		},
+		now: time.Now(),
+	}
+	if client.Tokens() == nil {
+		m.screen = screenLogin
+	}
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	cmds := []tea.Cmd{tick()}
+	if m.screen == screenLoading {
+		cmds = append(cmds, m.fetchActive())
+	}
+	return tea.Batch(cmds...)
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// call runs fn against the API with a timeout and turns its error into an errMsg
+func (m model) call(fn func(ctx context.Context) (tea.Msg, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		msg, err := fn(ctx)
+		if err != nil {
+			return errMsg{err}
+		}
+		return msg
+	}
+}
+
+func (m model) fetchActive() tea.Cmd {
+	return m.call(func(ctx context.Context) (tea.Msg, error) {
+		resp, err := m.client.GetContestsActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Contest.ID == "" {
+			return contestMsg{}, nil
+		}
+		return contestMsg{&resp.Contest}, nil
+	})
+}
+
+func (m model) fetchContest(id string) tea.Cmd {
+	return m.call(func(ctx context.Context) (tea.Msg, error) {
+		contest, err := m.client.GetContestsID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return contestMsg{contest}, nil
+	})
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tickMsg:
+		m.now = time.Time(msg)
+		cmds := []tea.Cmd{tick()}
+		// Refetch once the countdown runs out so the server's view (warm-up
+		// over, contest expired) replaces the local clock
+		if m.screen == screenContest && !m.busy && !m.deadline.IsZero() && !m.now.Before(m.deadline) {
+			m.busy = true
+			m.deadline = time.Time{}
+			cmds = append(cmds, m.fetchContest(m.contest.ID))
+		}
+		return m, tea.Batch(cmds...)
+
+	case signedInMsg:
+		m.busy = false
+		m.login.values[1] = ""
+		m.screen = screenLoading
+		return m, m.fetchActive()
+
+	case signedOutMsg:
+		m.busy = false
+		m.contest, m.status, m.err = nil, "", nil
+		m.screen = screenLogin
+		return m, nil
+
+	case contestMsg:
+		m.busy = false
+		m.err = nil
+		m.setContest(msg.contest)
+		return m, nil
+
+	case statusMsg:
+		m.busy = false
+		m.status = string(msg)
+		if m.contest != nil {
+			return m, m.fetchContest(m.contest.ID)
+		}
+		return m, nil
+
+	case errMsg:
+		m.busy = false
+		m.err = msg.err
+		var apiErr *contestmaker.Error
+		if errors.Is(msg.err, contestmaker.ErrNotSignedIn) ||
+			(errors.As(msg.err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized) {
+			m.client.SetTokens(nil)
+			m.screen = screenLogin
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		if m.busy {
+			return m, nil
+		}
+		switch m.screen {
+		case screenLogin:
+			return m.updateLogin(msg)
+		case screenNewContest:
+			return m.updateNewContest(msg)
+		case screenContest:
+			return m.updateContest(msg)
+		}
+		if msg.String() == "q" {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// setContest shows the contest, or the new contest form when it is nil or over
+func (m *model) setContest(contest *contestmaker.ContestResponse) {
+	if contest == nil || contest.Status != "active" {
+		if contest != nil {
+			m.status = fmt.Sprintf("Contest %s", contest.Status)
+		}
+		m.contest = nil
+		m.deadline = time.Time{}
+		m.screen = screenNewContest
+		return
+	}
+
+	m.contest = contest
+	m.screen = screenContest
+	remaining := contest.TimeRemainingSeconds
+	if contest.Warmup.TimeRemainingSeconds > 0 {
+		remaining = contest.Warmup.TimeRemainingSeconds
+	}
+	m.deadline = time.Now().Add(time.Duration(remaining) * time.Second)
+	if m.cursor >= len(contest.Problems) {
+		m.cursor = max(len(contest.Problems)-1, 0)
+	}
+}
+
+func (m model) updateLogin(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.Type {
+	case tea.KeyEsc:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		email, password := strings.TrimSpace(m.login.values[0]), m.login.values[1]
+		if email == "" || password == "" {
+			m.err = errors.New("email and password are required")
+			return m, nil
+		}
+		m.busy, m.err = true, nil
+		return m, m.call(func(ctx context.Context) (tea.Msg, error) {
+			if _, err := m.client.PostAuthLogin(ctx, &contestmaker.LoginRequest{Email: email, Password: password}); err != nil {
+				return nil, err
+			}
+			return signedInMsg{}, nil
+		})
+	}
+	m.login.update(key)
+	return m, nil
+}
+
+func (m model) updateNewContest(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.Type {
+	case tea.KeyEsc:
+		return m, tea.Quit
+	case tea.KeyCtrlL:
+		return m.logout()
+	case tea.KeyEnter:
+		count, err1 := strconv.Atoi(strings.TrimSpace(m.newContest.values[0]))
+		minutes, err2 := strconv.Atoi(strings.TrimSpace(m.newContest.values[1]))
+		if err1 != nil || err2 != nil {
+			m.err = errors.New("problems and minutes must be numbers")
+			return m, nil
+		}
+		m.busy, m.err, m.status, m.cursor = true, nil, "", 0
+		req := &contestmaker.CreateContestRequest{ProblemCount: count, DurationMinutes: minutes}
+		return m, m.call(func(ctx context.Context) (tea.Msg, error) {
+			contest, err := m.client.PostContests(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return contestMsg{contest}, nil
+		})
+	}
+	m.newContest.update(key)
+	return m, nil
+}
+
+func (m model) updateContest(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	id := m.contest.ID
+	switch key.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.contest.Problems)-1 {
+			m.cursor++
+		}
+	case "r":
+		m.busy = true
+		return m, m.fetchContest(id)
+	case " ", "enter":
+		if len(m.contest.Problems) == 0 {
+			return m, nil
+		}
+		cp := m.contest.Problems[m.cursor]
+		return m.action(func(ctx context.Context) error {
+			_, err := m.client.PatchContestsIDProblemsProblemID(ctx, id, cp.Problem.ID,
+				&contestmaker.MarkProblemCompleteRequest{IsCompleted: !cp.IsCompleted})
+			return err
+		}, "")
+	case "t":
+		if len(m.contest.Problems) == 0 {
+			return m, nil
+		}
+		problemID := m.contest.Problems[m.cursor].Problem.ID
+		return m.action(func(ctx context.Context) error {
+			_, err := m.client.PostContestsIDProblemsProblemIDStart(ctx, id, problemID)
+			return err
+		}, "Timer started")
+	case "w":
+		return m.action(func(ctx context.Context) error {
+			_, err := m.client.PatchContestsIDWarmup(ctx, id, &contestmaker.MarkProblemCompleteRequest{IsCompleted: true})
+			return err
+		}, "Warm-up done")
+	case "s":
+		return m.action(func(ctx context.Context) error {
+			_, err := m.client.PostContestsIDStart(ctx, id)
+			return err
+		}, "Contest started")
+	case "c":
+		return m.action(func(ctx context.Context) error {
+			_, err := m.client.PostContestsIDComplete(ctx, id)
+			return err
+		}, "Contest completed")
+	case "A":
+		return m.action(func(ctx context.Context) error {
+			_, err := m.client.PostContestsIDAbandon(ctx, id)
+			return err
+		}, "Contest abandoned")
+	case "ctrl+l":
+		return m.logout()
+	}
+	return m, nil
+}
+
+// action runs a contest mutation and then refetches the contest
+func (m model) action(fn func(ctx context.Context) error, status string) (tea.Model, tea.Cmd) {
+	m.busy, m.err = true, nil
+	return m, m.call(func(ctx context.Context) (tea.Msg, error) {
+		if err := fn(ctx); err != nil {
+			return nil, err
+		}
+		return statusMsg(status), nil
+	})
+}
+
+func (m model) logout() (tea.Model, tea.Cmd) {
+	tokens := m.client.Tokens()
+	if tokens == nil {
+		m.screen = screenLogin
+		return m, nil
+	}
+	m.busy = true
+	return m, m.call(func(ctx context.Context) (tea.Msg, error) {
+		_, err := m.client.PostAuthLogout(ctx, &contestmaker.LogoutRequest{RefreshToken: tokens.RefreshToken})
+		if err != nil {
+			return nil, err
+		}
+		return signedOutMsg{}, nil
+	})
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	b.WriteString("Contest Maker 150\n\n")
+
+	switch m.screen {
+	case screenLoading:
+		b.WriteString("Loading...\n")
+	case screenLogin:
+		b.WriteString("Sign in\n\n")
+		m.login.view(&b)
+		b.WriteString("\ntab: next field  enter: sign in  esc: quit\n")
+	case screenNewContest:
+		b.WriteString("New contest\n\n")
+		m.newContest.view(&b)
+		b.WriteString("\ntab: next field  enter: create  ctrl+l: log out  esc: quit\n")
+	case screenContest:
+		m.viewContest(&b)
+	}
+
+	if m.busy {
+		b.WriteString("\nWorking...\n")
+	}
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "\nError: %s\n", errorText(m.err))
+	}
+	return b.String()
+}
+
+func (m model) viewContest(b *strings.Builder) {
+	c := m.contest
+	remaining := max(m.deadline.Sub(m.now), 0)
+	inWarmup := c.Warmup.TimeRemainingSeconds > 0
+
+	if inWarmup {
+		fmt.Fprintf(b, "Warm-up  %s until the contest starts\n\n", clock(remaining))
+		done := " "
+		if c.Warmup.IsCompleted {
+			done = "x"
+		}
+		fmt.Fprintf(b, "  [%s] %s (%s)\n\n", done, c.Warmup.Problem.Title, c.Warmup.Problem.Difficulty)
+	} else {
+		fmt.Fprintf(b, "%d min contest  %s remaining\n\n", c.DurationMinutes, clock(remaining))
+	}
+
+	solved := 0
+	for i, cp := range c.Problems {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		done := " "
+		if cp.IsCompleted {
+			done = "x"
+			solved++
+		}
+		timer := ""
+		if cp.TimerRunning || cp.TimeSpentSeconds > 0 {
+			timer = "  " + clock(time.Duration(cp.TimeSpentSeconds)*time.Second)
+			if cp.TimerRunning {
+				timer += " (running)"
+			}
+		}
+		fmt.Fprintf(b, "%s[%s] %d. %-40s %-6s%s\n", cursor, done, cp.Order, cp.Problem.Title, cp.Problem.Difficulty, timer)
+	}
+	fmt.Fprintf(b, "\n%d/%d solved\n", solved, len(c.Problems))
+	if len(c.Problems) > 0 {
+		fmt.Fprintf(b, "%s\n", c.Problems[m.cursor].Problem.LeetcodeURL)
+	}
+
+	b.WriteString("\nspace: toggle solved  t: start timer  r: refresh  c: complete  A: abandon  q: quit\n")
+	if inWarmup {
+		b.WriteString("w: warm-up solved  s: start contest now\n")
+	}
+}
+
+// clock formats a duration as h:mm:ss or mm:ss
+func clock(d time.Duration) string {
+	secs := int(d.Round(time.Second).Seconds())
+	if secs >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs%3600/60, secs%60)
+	}
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// errorText prefers the server's message over the client's wrapped error
+func errorText(err error) string {
+	var apiErr *contestmaker.Error
+	if errors.As(err, &apiErr) && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	if errors.Is(err, contestmaker.ErrNotSignedIn) {
+		return "signed out"
+	}
+	return err.Error()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	contestmaker "github.com/contest-maker-150/clients/go"
+)
+
+// tokenStore persists the session tokens between runs. The file is only
+// readable by the user because the refresh token grants a new session.
+type tokenStore struct {
+	path string
+}
+
+func defaultTokenFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "contest-maker", "tokens.json")
+}
+
+// load returns the saved tokens, or nil when there are none
+func (s tokenStore) load() (*contestmaker.TokenPair, error) {
+	if s.path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tokens contestmaker.TokenPair
+	if err := json.Unmarshal(raw, &tokens); err != nil {
+		return nil, err
+	}
+	if tokens.RefreshToken == "" {
+		return nil, nil
+	}
+	return &tokens, nil
+}
+
+// save is the client's token handler; nil removes the file after logging out.
+// Failures are ignored because the session still works until the program exits.
+func (s tokenStore) save(tokens *contestmaker.TokenPair) {
+	if s.path == "" {
+		return
+	}
+	if tokens == nil {
+		_ = os.Remove(s.path)
+		return
+	}
+	raw, err := json.Marshal(tokens)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(s.path, raw, 0o600)
+}
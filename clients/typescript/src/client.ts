@@ -0,0 +1,1051 @@
+// Code generated by backend/cmd/clientgen from backend/api/openapi.json. DO NOT EDIT.
+
+import { BaseClient, type RequestOptions } from './runtime.js';
+import type {
+    AssignmentReport,
+    AttachSolutionRequest,
+    AttemptHistory,
+    AuthResponse,
+    BackupListResponse,
+    BackupManifest,
+    ChallengeComparison,
+    ChallengeResponse,
+    ChallengeStandings,
+    ChangePasswordRequest,
+    ChatMessageResponse,
+    ChatMessages,
+    CheckoutSessionResponse,
+    CohortsResponse,
+    ContestResponse,
+    ContestSession,
+    ContestTemplateImport,
+    CreateAssignmentRequest,
+    CreateChallengeRequest,
+    CreateContestRequest,
+    CreateListContestRequest,
+    CreateMentorAssignmentRequest,
+    CreateMentorshipRequest,
+    CreateOrgInviteRequest,
+    CreateOrgRequest,
+    CreateTenantRequest,
+    CustomProblemRequest,
+    DigestRunReport,
+    DigestStats,
+    DigestStatus,
+    ExperimentsResponse,
+    FeatureFlag,
+    FeatureFlagListResponse,
+    FeaturesResponse,
+    FeedResponse,
+    GetAdminProblemsCalibrationResponse,
+    GetAdminTenantsResponse,
+    GetAssignmentsResponse,
+    GetCompaniesResponse,
+    GetContestsActiveResponse,
+    GetContestsResponse,
+    GetContestsTagsResponse,
+    GetMentorshipsIDAssignmentsResponse,
+    GetMentorshipsIDContestsResponse,
+    GetMentorshipsIDNotesResponse,
+    GetOrgsIDAssignmentsAssignmentIDSimilarityResponse,
+    GetOrgsIDAssignmentsResponse,
+    GetOrgsResponse,
+    GetProblemsResponse,
+    GetTenantLeaderboardResponse,
+    GetUsersMeContestsByTopicResponse,
+    GetUsersMeFiltersResponse,
+    GetUsersMeProblemsResponse,
+    HeartbeatRequest,
+    ImportContestTemplateRequest,
+    IntegrityReport,
+    JoinOrgRequest,
+    LogLevelStatus,
+    LoginRequest,
+    LogoutRequest,
+    MaintenanceStatus,
+    MarkProblemCompleteRequest,
+    MenteeTiming,
+    MentorAssignmentResponse,
+    Mentorship,
+    MentorshipAudit,
+    MentorshipList,
+    MessageResponse,
+    ModerateProblemListRequest,
+    NoteSearchResponse,
+    OrgAssignmentResponse,
+    OrgInvite,
+    OrgResponse,
+    OrgRoster,
+    PostAuthRefreshResponse,
+    PostBillingWebhookRequest,
+    PostChatMessageRequest,
+    PostContestsIDEventsResponse,
+    Presence,
+    ProblemAvailability,
+    ProblemBatchRequest,
+    ProblemBatchResponse,
+    ProblemComplexity,
+    ProblemListPage,
+    ProblemListRequest,
+    ProblemListResponse,
+    ProblemListSummary,
+    ProblemPage,
+    ProblemPrerequisitesResponse,
+    ProblemResponse,
+    ProblemSearchResponse,
+    ProblemStats,
+    ProctoringSummary,
+    PublicProblemStats,
+    PublicStats,
+    PutContestsIDTagsResponse,
+    QuickCommandRequest,
+    QuickHistory,
+    QuickResult,
+    QuotaStatus,
+    RateContestRequest,
+    RecordAttemptRequest,
+    RecordContestEventsRequest,
+    RefreshRequest,
+    ReportProblemListRequest,
+    RetentionReport,
+    ReviewQueue,
+    ReviewSimilarityRequest,
+    RoadmapResponse,
+    RunIntegrityRequest,
+    RunRetentionRequest,
+    SavedFilter,
+    SavedFilterRequest,
+    SetContestTagsRequest,
+    SetLogLevelRequest,
+    SetMaintenanceRequest,
+    SetProblemCompaniesRequest,
+    SetProblemComplexityRequest,
+    SetProblemImportanceRequest,
+    SetQuotaOverrideRequest,
+    SignedContestTemplate,
+    SimilarityFlagDetail,
+    SimilarityFlagResponse,
+    SimilarityRunReport,
+    SolutionSnippet,
+    SpectatorInvite,
+    SpectatorInviteRequest,
+    SpectatorView,
+    StateComplexityRequest,
+    Tenant,
+    TenantInfo,
+    UpdateContestSessionRequest,
+    UpdateDigestRequest,
+    UpdateFeatureFlagRequest,
+    UpdateRetroRequest,
+    UserCreateRequest,
+    UserProgress,
+    UserResponse,
+} from './types.js';
+
+export interface GetAdminDigestsStatsParams {
+    /** Digests sent in this many days (1-365, default 28) */
+    days?: number;
+}
+
+export interface GetAdminListsParams {
+    /** published or hidden; every reported list when omitted */
+    status?: string;
+    /** Page size (1-100, default 20) */
+    limit?: number;
+    /** Lists to skip */
+    offset?: number;
+}
+
+export interface GetChallengesCodeChatParams {
+    /** Only messages created after this time (RFC 3339); without it the latest messages */
+    since?: string;
+    /** Maximum number of messages (1-100, default 50) */
+    limit?: number;
+}
+
+export interface GetContestsParams {
+    /** Only contests whose retro notes contain this text */
+    q?: string;
+    /** Only contests carrying this tag */
+    tag?: string;
+    /** Only contests in this status (active, completed, abandoned, pending) */
+    status?: string;
+    /** Only contests created on or after this UTC day (YYYY-MM-DD) */
+    from?: string;
+    /** Only contests created on or before this UTC day (YYYY-MM-DD) */
+    to?: string;
+    /** Only contests lasting at least this many minutes */
+    min_duration?: number;
+    /** Only contests lasting at most this many minutes */
+    max_duration?: number;
+    /** Only contests with at least this many solved problems */
+    min_score?: number;
+    /** Only contests with at most this many solved problems */
+    max_score?: number;
+    /** Only contests containing this problem, by ID or slug */
+    problem?: string;
+}
+
+export interface GetContestsAvailabilityParams {
+    /** Only problems whose prerequisites you solved */
+    respect_prerequisites?: boolean;
+    /** Only problems tagged with this company (repeatable) */
+    company?: string[];
+    /** Count your own custom problems too */
+    include_custom?: boolean;
+}
+
+export interface GetContestsTagsParams {
+    /** Only tags starting with this text */
+    prefix?: string;
+    /** Maximum number of suggestions (1-50, default 10) */
+    limit?: number;
+}
+
+export interface GetListsParams {
+    /** Only lists whose title or description contain this text */
+    q?: string;
+    /** popular (most subscribers, default) or newest */
+    sort?: string;
+    /** Only lists you subscribe to */
+    subscribed?: boolean;
+    /** Only your own lists, hidden ones included */
+    mine?: boolean;
+    /** Page size (1-100, default 20) */
+    limit?: number;
+    /** Lists to skip */
+    offset?: number;
+}
+
+export interface GetMentorshipsIDAuditParams {
+    /** Maximum number of entries (1-200, default 50) */
+    limit?: number;
+}
+
+export interface GetOrgsIDAssignmentsAssignmentIDReportParams {
+    /** Set to "csv" to download the report as CSV */
+    format?: string;
+}
+
+export interface GetOrgsIDAssignmentsAssignmentIDSimilarityParams {
+    /** Only flags with this review status: pending, confirmed or dismissed */
+    status?: string;
+}
+
+export interface GetOrgsIDRosterParams {
+    /** Maximum number of students (1-200, default 50) */
+    limit?: number;
+    /** Number of students to skip */
+    offset?: number;
+}
+
+export interface GetProblemsParams {
+    /** Set to "popularity" to include usage counters */
+    include?: string;
+    /** Apply one of the user's saved filters (requires auth) */
+    filter_id?: string;
+    /** Only problems of this difficulty (repeatable) */
+    difficulty?: string[];
+    /** Only problems with this topic (repeatable) */
+    topic?: string[];
+    /** Only problems tagged with this company (repeatable) */
+    company?: string[];
+    /** "any", "solved" or "unsolved" (solved states require auth) */
+    solved?: string;
+}
+
+export interface PostProblemsBatchParams {
+    /** Set to "popularity" to include usage counters */
+    include?: string;
+}
+
+export interface GetProblemsSearchParams {
+    /** Search text, e.g. "palindrom" or "binary tree" */
+    q?: string;
+    /** Maximum number of results (1-50, default 20) */
+    limit?: number;
+}
+
+export interface GetProblemsIDParams {
+    /** Set to "popularity" to include usage counters */
+    include?: string;
+}
+
+export interface GetPublicStatsProblemsParams {
+    /** Maximum number of problems (1-50, default 10) */
+    limit?: number;
+}
+
+export interface GetQuickHistoryParams {
+    /** Maximum number of commands (1-100, default 20) */
+    limit?: number;
+}
+
+export interface GetTenantLeaderboardParams {
+    /** Maximum number of users (1-100, default 25) */
+    limit?: number;
+}
+
+export interface GetUsersMeFeedParams {
+    /** next_cursor of the previous page */
+    cursor?: string;
+    /** Maximum number of items (1-100, default 20) */
+    limit?: number;
+}
+
+export interface GetUsersMeReviewsParams {
+    /** Only problems due for review now */
+    due_only?: boolean;
+    /** Maximum number of problems (1-200) */
+    limit?: number;
+}
+
+export interface GetUsersMeSearchParams {
+    /** Words that must all appear, e.g. "sliding window" */
+    q?: string;
+    /** Maximum number of results (1-50, default 20) */
+    limit?: number;
+}
+
+/** Typed client for the Contest Maker 150 API */
+export class ContestMakerClient extends BaseClient {
+    /** GET /api/admin/analytics/cohorts: Weekly signup cohorts with retention and contest activity */
+    getAdminAnalyticsCohorts(options: RequestOptions = {}): Promise<CohortsResponse> {
+        return this.request('GET', '/api/admin/analytics/cohorts', { auth: true, ...options });
+    }
+
+    /** GET /api/admin/backups: List the stored backups, newest first */
+    getAdminBackups(options: RequestOptions = {}): Promise<BackupListResponse> {
+        return this.request('GET', '/api/admin/backups', { auth: true, ...options });
+    }
+
+    /** POST /api/admin/backups: Back up users, custom problems, contests, submissions and attempts to object storage */
+    postAdminBackups(options: RequestOptions = {}): Promise<BackupManifest> {
+        return this.request('POST', '/api/admin/backups', { auth: true, ...options });
+    }
+
+    /** POST /api/admin/backups/{id}/verify: Check the checksums and row counts of a stored backup */
+    postAdminBackupsIdVerify(id: string, options: RequestOptions = {}): Promise<BackupManifest> {
+        return this.request('POST', `/api/admin/backups/${encodeURIComponent(id)}/verify`, { auth: true, ...options });
+    }
+
+    /** POST /api/admin/digests/send: Send the recommendation digests that are due now */
+    postAdminDigestsSend(options: RequestOptions = {}): Promise<DigestRunReport> {
+        return this.request('POST', '/api/admin/digests/send', { auth: true, ...options });
+    }
+
+    /** GET /api/admin/digests/stats: Click-through and solve rates of recent recommendation digests */
+    getAdminDigestsStats(params: GetAdminDigestsStatsParams = {}, options: RequestOptions = {}): Promise<DigestStats> {
+        return this.request('GET', '/api/admin/digests/stats', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/admin/experiments: Completion rates per experiment variant */
+    getAdminExperiments(options: RequestOptions = {}): Promise<ExperimentsResponse> {
+        return this.request('GET', '/api/admin/experiments', { auth: true, ...options });
+    }
+
+    /** GET /api/admin/feature-flags: List feature flags and their rollout */
+    getAdminFeatureFlags(options: RequestOptions = {}): Promise<FeatureFlagListResponse> {
+        return this.request('GET', '/api/admin/feature-flags', { auth: true, ...options });
+    }
+
+    /** PUT /api/admin/feature-flags/{key}: Turn a feature flag on or off for a share of users */
+    putAdminFeatureFlagsKey(key: string, body: UpdateFeatureFlagRequest, options: RequestOptions = {}): Promise<FeatureFlag> {
+        return this.request('PUT', `/api/admin/feature-flags/${encodeURIComponent(key)}`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/admin/integrity: Detect and repair inconsistent data and recompute progress and usage counters */
+    postAdminIntegrity(body: RunIntegrityRequest, options: RequestOptions = {}): Promise<IntegrityReport> {
+        return this.request('POST', '/api/admin/integrity', { auth: true, body, ...options });
+    }
+
+    /** GET /api/admin/lists: Moderation queue of community lists, most reported first */
+    getAdminLists(params: GetAdminListsParams = {}, options: RequestOptions = {}): Promise<ProblemListPage> {
+        return this.request('GET', '/api/admin/lists', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** PATCH /api/admin/lists/{id}: Hide or restore a community list; restoring clears its reports */
+    patchAdminListsId(id: string, body: ModerateProblemListRequest, options: RequestOptions = {}): Promise<ProblemListSummary> {
+        return this.request('PATCH', `/api/admin/lists/${encodeURIComponent(id)}`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/admin/log-level: Log level in effect */
+    getAdminLogLevel(options: RequestOptions = {}): Promise<LogLevelStatus> {
+        return this.request('GET', '/api/admin/log-level', { auth: true, ...options });
+    }
+
+    /** PUT /api/admin/log-level: Change the log level of every instance at runtime */
+    putAdminLogLevel(body: SetLogLevelRequest, options: RequestOptions = {}): Promise<LogLevelStatus> {
+        return this.request('PUT', '/api/admin/log-level', { auth: true, body, ...options });
+    }
+
+    /** PUT /api/admin/maintenance: Start, schedule or end maintenance */
+    putAdminMaintenance(body: SetMaintenanceRequest, options: RequestOptions = {}): Promise<MaintenanceStatus> {
+        return this.request('PUT', '/api/admin/maintenance', { auth: true, body, ...options });
+    }
+
+    /** GET /api/admin/problems/calibration: Per-problem usage counters */
+    getAdminProblemsCalibration(options: RequestOptions = {}): Promise<GetAdminProblemsCalibrationResponse> {
+        return this.request('GET', '/api/admin/problems/calibration', { auth: true, ...options });
+    }
+
+    /** PUT /api/admin/problems/{id}/companies: Replace problem company tags */
+    putAdminProblemsIdCompanies(id: string, body: SetProblemCompaniesRequest, options: RequestOptions = {}): Promise<ProblemResponse> {
+        return this.request('PUT', `/api/admin/problems/${encodeURIComponent(id)}/companies`, { auth: true, body, ...options });
+    }
+
+    /** PUT /api/admin/problems/{id}/complexity: Set the canonical complexity of a problem */
+    putAdminProblemsIdComplexity(id: string, body: SetProblemComplexityRequest, options: RequestOptions = {}): Promise<ProblemComplexity> {
+        return this.request('PUT', `/api/admin/problems/${encodeURIComponent(id)}/complexity`, { auth: true, body, ...options });
+    }
+
+    /** PATCH /api/admin/problems/{id}/importance: Tune problem importance score */
+    patchAdminProblemsIdImportance(id: string, body: SetProblemImportanceRequest, options: RequestOptions = {}): Promise<ProblemResponse> {
+        return this.request('PATCH', `/api/admin/problems/${encodeURIComponent(id)}/importance`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/admin/retention: Delete data older than its configured retention and report what each policy found */
+    postAdminRetention(body: RunRetentionRequest, options: RequestOptions = {}): Promise<RetentionReport> {
+        return this.request('POST', '/api/admin/retention', { auth: true, body, ...options });
+    }
+
+    /** POST /api/admin/similarity/check: Compare the solution snippets changed since the last similarity round */
+    postAdminSimilarityCheck(options: RequestOptions = {}): Promise<SimilarityRunReport> {
+        return this.request('POST', '/api/admin/similarity/check', { auth: true, ...options });
+    }
+
+    /** GET /api/admin/tenants: List the hosted tenants */
+    getAdminTenants(options: RequestOptions = {}): Promise<GetAdminTenantsResponse> {
+        return this.request('GET', '/api/admin/tenants', { auth: true, ...options });
+    }
+
+    /** POST /api/admin/tenants: Host a new tenant with isolated users, organizations and leaderboard */
+    postAdminTenants(body: CreateTenantRequest, options: RequestOptions = {}): Promise<Tenant> {
+        return this.request('POST', '/api/admin/tenants', { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/admin/users/{id}/quotas: Drop a user's quota override */
+    deleteAdminUsersIdQuotas(id: string, options: RequestOptions = {}): Promise<QuotaStatus> {
+        return this.request('DELETE', `/api/admin/users/${encodeURIComponent(id)}/quotas`, { auth: true, ...options });
+    }
+
+    /** GET /api/admin/users/{id}/quotas: Plan and remaining allowances of a user */
+    getAdminUsersIdQuotas(id: string, options: RequestOptions = {}): Promise<QuotaStatus> {
+        return this.request('GET', `/api/admin/users/${encodeURIComponent(id)}/quotas`, { auth: true, ...options });
+    }
+
+    /** PUT /api/admin/users/{id}/quotas: Set a user's plan and override their quotas */
+    putAdminUsersIdQuotas(id: string, body: SetQuotaOverrideRequest, options: RequestOptions = {}): Promise<QuotaStatus> {
+        return this.request('PUT', `/api/admin/users/${encodeURIComponent(id)}/quotas`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/admin/users/{id}/revoke-tokens: Sign a user out on all devices */
+    postAdminUsersIdRevokeTokens(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', `/api/admin/users/${encodeURIComponent(id)}/revoke-tokens`, { auth: true, ...options });
+    }
+
+    /** GET /api/assignments: The caller's unfinished assignments from their organizations and mentors, soonest due first */
+    getAssignments(options: RequestOptions = {}): Promise<GetAssignmentsResponse> {
+        return this.request('GET', '/api/assignments', { auth: true, ...options });
+    }
+
+    /** POST /api/auth/login: Login user */
+    postAuthLogin(body: LoginRequest, options: RequestOptions = {}): Promise<AuthResponse> {
+        return this.request('POST', '/api/auth/login', { auth: false, body, ...options });
+    }
+
+    /** POST /api/auth/logout: Revoke the current access token and optionally its refresh token */
+    postAuthLogout(body: LogoutRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', '/api/auth/logout', { auth: true, body, ...options });
+    }
+
+    /** POST /api/auth/logout-all: Revoke every token of the current user */
+    postAuthLogoutAll(options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', '/api/auth/logout-all', { auth: true, ...options });
+    }
+
+    /** POST /api/auth/refresh: Refresh access token */
+    postAuthRefresh(body: RefreshRequest, options: RequestOptions = {}): Promise<PostAuthRefreshResponse> {
+        return this.request('POST', '/api/auth/refresh', { auth: false, body, ...options });
+    }
+
+    /** POST /api/auth/signup: Register new user */
+    postAuthSignup(body: UserCreateRequest, options: RequestOptions = {}): Promise<AuthResponse> {
+        return this.request('POST', '/api/auth/signup', { auth: false, body, ...options });
+    }
+
+    /** POST /api/billing/checkout: Start a premium subscription checkout */
+    postBillingCheckout(options: RequestOptions = {}): Promise<CheckoutSessionResponse> {
+        return this.request('POST', '/api/billing/checkout', { auth: true, ...options });
+    }
+
+    /** POST /api/billing/webhook: Receive a signed Stripe webhook event */
+    postBillingWebhook(body: PostBillingWebhookRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', '/api/billing/webhook', { auth: false, body, ...options });
+    }
+
+    /** GET /api/challenges/{code}: Get challenge invite */
+    getChallengesCode(code: string, options: RequestOptions = {}): Promise<ChallengeResponse> {
+        return this.request('GET', `/api/challenges/${encodeURIComponent(code)}`, { auth: true, ...options });
+    }
+
+    /** POST /api/challenges/{code}/accept: Accept challenge and start its contest */
+    postChallengesCodeAccept(code: string, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('POST', `/api/challenges/${encodeURIComponent(code)}/accept`, { auth: true, ...options });
+    }
+
+    /** GET /api/challenges/{code}/chat: Read the challenge chat */
+    getChallengesCodeChat(code: string, params: GetChallengesCodeChatParams = {}, options: RequestOptions = {}): Promise<ChatMessages> {
+        return this.request('GET', `/api/challenges/${encodeURIComponent(code)}/chat`, { auth: true, query: { ...params }, ...options });
+    }
+
+    /** POST /api/challenges/{code}/chat: Post to the challenge chat */
+    postChallengesCodeChat(code: string, body: PostChatMessageRequest, options: RequestOptions = {}): Promise<ChatMessageResponse> {
+        return this.request('POST', `/api/challenges/${encodeURIComponent(code)}/chat`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/challenges/{code}/comparison: Compare challenge results */
+    getChallengesCodeComparison(code: string, options: RequestOptions = {}): Promise<ChallengeComparison> {
+        return this.request('GET', `/api/challenges/${encodeURIComponent(code)}/comparison`, { auth: true, ...options });
+    }
+
+    /** DELETE /api/challenges/{code}/spectators: Close the spectator invite */
+    deleteChallengesCodeSpectators(code: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/challenges/${encodeURIComponent(code)}/spectators`, { auth: true, ...options });
+    }
+
+    /** POST /api/challenges/{code}/spectators: Invite read-only spectators of the live standings */
+    postChallengesCodeSpectators(code: string, body: SpectatorInviteRequest, options: RequestOptions = {}): Promise<SpectatorInvite> {
+        return this.request('POST', `/api/challenges/${encodeURIComponent(code)}/spectators`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/challenges/{code}/standings: Live challenge standings with participant presence */
+    getChallengesCodeStandings(code: string, options: RequestOptions = {}): Promise<ChallengeStandings> {
+        return this.request('GET', `/api/challenges/${encodeURIComponent(code)}/standings`, { auth: true, ...options });
+    }
+
+    /** GET /api/companies: List companies with tagged problem counts */
+    getCompanies(options: RequestOptions = {}): Promise<GetCompaniesResponse> {
+        return this.request('GET', '/api/companies', { auth: false, ...options });
+    }
+
+    /** GET /api/contests: List and filter user's contests */
+    getContests(params: GetContestsParams = {}, options: RequestOptions = {}): Promise<GetContestsResponse> {
+        return this.request('GET', '/api/contests', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** POST /api/contests: Create new contest */
+    postContests(body: CreateContestRequest, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('POST', '/api/contests', { auth: true, body, ...options });
+    }
+
+    /** GET /api/contests/active: Get active contest */
+    getContestsActive(options: RequestOptions = {}): Promise<GetContestsActiveResponse> {
+        return this.request('GET', '/api/contests/active', { auth: true, ...options });
+    }
+
+    /** GET /api/contests/availability: Count the unsolved problems left for random contests, by difficulty and topic */
+    getContestsAvailability(params: GetContestsAvailabilityParams = {}, options: RequestOptions = {}): Promise<ProblemAvailability> {
+        return this.request('GET', '/api/contests/availability', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** POST /api/contests/import: Start a contest from a shared contest template */
+    postContestsImport(body: ImportContestTemplateRequest, options: RequestOptions = {}): Promise<ContestTemplateImport> {
+        return this.request('POST', '/api/contests/import', { auth: true, body, ...options });
+    }
+
+    /** GET /api/contests/tags: Autocomplete contest tags */
+    getContestsTags(params: GetContestsTagsParams = {}, options: RequestOptions = {}): Promise<GetContestsTagsResponse> {
+        return this.request('GET', '/api/contests/tags', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/contests/{id}: Get contest by ID */
+    getContestsId(id: string, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('GET', `/api/contests/${encodeURIComponent(id)}`, { auth: true, ...options });
+    }
+
+    /** POST /api/contests/{id}/abandon: Abandon contest */
+    postContestsIdAbandon(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/abandon`, { auth: true, ...options });
+    }
+
+    /** POST /api/contests/{id}/challenge: Challenge a friend to the same contest */
+    postContestsIdChallenge(id: string, body: CreateChallengeRequest, options: RequestOptions = {}): Promise<ChallengeResponse> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/challenge`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/contests/{id}/complete: Complete contest */
+    postContestsIdComplete(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/complete`, { auth: true, ...options });
+    }
+
+    /** POST /api/contests/{id}/events: Report focus and tab switch events of a running organization assignment contest */
+    postContestsIdEvents(id: string, body: RecordContestEventsRequest, options: RequestOptions = {}): Promise<PostContestsIDEventsResponse> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/events`, { auth: true, body, ...options });
+    }
+
+    /** PATCH /api/contests/{id}/problems/{problemId}: Mark problem complete */
+    patchContestsIdProblemsProblemId(id: string, problemId: string, body: MarkProblemCompleteRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('PATCH', `/api/contests/${encodeURIComponent(id)}/problems/${encodeURIComponent(problemId)}`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/contests/{id}/problems/{problemId}/attempts: Record a failed or solved attempt at a contest problem */
+    postContestsIdProblemsProblemIdAttempts(id: string, problemId: string, body: RecordAttemptRequest, options: RequestOptions = {}): Promise<AttemptHistory> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/problems/${encodeURIComponent(problemId)}/attempts`, { auth: true, body, ...options });
+    }
+
+    /** PUT /api/contests/{id}/problems/{problemId}/complexity: State the complexity of a completed problem's solution */
+    putContestsIdProblemsProblemIdComplexity(id: string, problemId: string, body: StateComplexityRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('PUT', `/api/contests/${encodeURIComponent(id)}/problems/${encodeURIComponent(problemId)}/complexity`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/contests/{id}/problems/{problemId}/solution: Get the solution snippet attached to a contest problem */
+    getContestsIdProblemsProblemIdSolution(id: string, problemId: string, options: RequestOptions = {}): Promise<SolutionSnippet> {
+        return this.request('GET', `/api/contests/${encodeURIComponent(id)}/problems/${encodeURIComponent(problemId)}/solution`, { auth: true, ...options });
+    }
+
+    /** PUT /api/contests/{id}/problems/{problemId}/solution: Attach a solution snippet to a contest problem, replacing the previous one */
+    putContestsIdProblemsProblemIdSolution(id: string, problemId: string, body: AttachSolutionRequest, options: RequestOptions = {}): Promise<SolutionSnippet> {
+        return this.request('PUT', `/api/contests/${encodeURIComponent(id)}/problems/${encodeURIComponent(problemId)}/solution`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/contests/{id}/problems/{problemId}/start: Start the timer of a contest problem */
+    postContestsIdProblemsProblemIdStart(id: string, problemId: string, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/problems/${encodeURIComponent(problemId)}/start`, { auth: true, ...options });
+    }
+
+    /** PUT /api/contests/{id}/rating: Rate how hard a finished contest was, tuning the mix of later ones */
+    putContestsIdRating(id: string, body: RateContestRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('PUT', `/api/contests/${encodeURIComponent(id)}/rating`, { auth: true, body, ...options });
+    }
+
+    /** PATCH /api/contests/{id}/retro: Save contest retro notes */
+    patchContestsIdRetro(id: string, body: UpdateRetroRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('PATCH', `/api/contests/${encodeURIComponent(id)}/retro`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/contests/{id}/start: End warmup, or start an assigned pending contest, and start the contest timer */
+    postContestsIdStart(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', `/api/contests/${encodeURIComponent(id)}/start`, { auth: true, ...options });
+    }
+
+    /** GET /api/contests/{id}/state: Get the contest's session state: the problem on screen and scratch notes */
+    getContestsIdState(id: string, options: RequestOptions = {}): Promise<ContestSession> {
+        return this.request('GET', `/api/contests/${encodeURIComponent(id)}/state`, { auth: true, ...options });
+    }
+
+    /** PUT /api/contests/{id}/state: Save the contest's session state, failing when another device saved since it was read */
+    putContestsIdState(id: string, body: UpdateContestSessionRequest, options: RequestOptions = {}): Promise<ContestSession> {
+        return this.request('PUT', `/api/contests/${encodeURIComponent(id)}/state`, { auth: true, body, ...options });
+    }
+
+    /** PUT /api/contests/{id}/tags: Replace contest tags */
+    putContestsIdTags(id: string, body: SetContestTagsRequest, options: RequestOptions = {}): Promise<PutContestsIDTagsResponse> {
+        return this.request('PUT', `/api/contests/${encodeURIComponent(id)}/tags`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/contests/{id}/template: Export a contest as a signed, shareable template */
+    getContestsIdTemplate(id: string, options: RequestOptions = {}): Promise<SignedContestTemplate> {
+        return this.request('GET', `/api/contests/${encodeURIComponent(id)}/template`, { auth: true, ...options });
+    }
+
+    /** PATCH /api/contests/{id}/warmup: Mark warmup problem complete */
+    patchContestsIdWarmup(id: string, body: MarkProblemCompleteRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('PATCH', `/api/contests/${encodeURIComponent(id)}/warmup`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/lists: Browse published community problem lists */
+    getLists(params: GetListsParams = {}, options: RequestOptions = {}): Promise<ProblemListPage> {
+        return this.request('GET', '/api/lists', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** POST /api/lists: Publish a curated, ordered list of catalog problems */
+    postLists(body: ProblemListRequest, options: RequestOptions = {}): Promise<ProblemListResponse> {
+        return this.request('POST', '/api/lists', { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/lists/{id}: Delete one of your lists */
+    deleteListsId(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/lists/${encodeURIComponent(id)}`, { auth: true, ...options });
+    }
+
+    /** GET /api/lists/{id}: Get a community list with its problems and which you solved */
+    getListsId(id: string, options: RequestOptions = {}): Promise<ProblemListResponse> {
+        return this.request('GET', `/api/lists/${encodeURIComponent(id)}`, { auth: true, ...options });
+    }
+
+    /** PUT /api/lists/{id}: Replace one of your lists */
+    putListsId(id: string, body: ProblemListRequest, options: RequestOptions = {}): Promise<ProblemListResponse> {
+        return this.request('PUT', `/api/lists/${encodeURIComponent(id)}`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/lists/{id}/contests: Start a contest from the next problems of a list you own or subscribe to */
+    postListsIdContests(id: string, body: CreateListContestRequest, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('POST', `/api/lists/${encodeURIComponent(id)}/contests`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/lists/{id}/reports: Report a community list to the moderators */
+    postListsIdReports(id: string, body: ReportProblemListRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('POST', `/api/lists/${encodeURIComponent(id)}/reports`, { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/lists/{id}/subscription: Unsubscribe from a community list */
+    deleteListsIdSubscription(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/lists/${encodeURIComponent(id)}/subscription`, { auth: true, ...options });
+    }
+
+    /** PUT /api/lists/{id}/subscription: Subscribe to a community list */
+    putListsIdSubscription(id: string, options: RequestOptions = {}): Promise<ProblemListSummary> {
+        return this.request('PUT', `/api/lists/${encodeURIComponent(id)}/subscription`, { auth: true, ...options });
+    }
+
+    /** GET /api/maintenance: Ongoing or upcoming maintenance */
+    getMaintenance(options: RequestOptions = {}): Promise<MaintenanceStatus> {
+        return this.request('GET', '/api/maintenance', { auth: false, ...options });
+    }
+
+    /** GET /api/mentorships: The caller's mentorships as mentor and as mentee */
+    getMentorships(options: RequestOptions = {}): Promise<MentorshipList> {
+        return this.request('GET', '/api/mentorships', { auth: true, ...options });
+    }
+
+    /** POST /api/mentorships: Invite a user, by email, to be mentored by the caller */
+    postMentorships(body: CreateMentorshipRequest, options: RequestOptions = {}): Promise<Mentorship> {
+        return this.request('POST', '/api/mentorships', { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/mentorships/{id}: Revoke a mentorship or withdraw an invite (either side) */
+    deleteMentorshipsId(id: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/mentorships/${encodeURIComponent(id)}`, { auth: true, ...options });
+    }
+
+    /** POST /api/mentorships/{id}/accept: Consent to a mentorship invite (mentee) */
+    postMentorshipsIdAccept(id: string, options: RequestOptions = {}): Promise<Mentorship> {
+        return this.request('POST', `/api/mentorships/${encodeURIComponent(id)}/accept`, { auth: true, ...options });
+    }
+
+    /** GET /api/mentorships/{id}/assignments: Assignments with the mentee's progress (either side) */
+    getMentorshipsIdAssignments(id: string, options: RequestOptions = {}): Promise<GetMentorshipsIDAssignmentsResponse> {
+        return this.request('GET', `/api/mentorships/${encodeURIComponent(id)}/assignments`, { auth: true, ...options });
+    }
+
+    /** POST /api/mentorships/{id}/assignments: Assign a contest or problem set with a due date (mentor); problem sets are pushed to the mentee as a pending contest */
+    postMentorshipsIdAssignments(id: string, body: CreateMentorAssignmentRequest, options: RequestOptions = {}): Promise<MentorAssignmentResponse> {
+        return this.request('POST', `/api/mentorships/${encodeURIComponent(id)}/assignments`, { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/mentorships/{id}/assignments/{assignmentId}: Delete an assignment (mentor) */
+    deleteMentorshipsIdAssignmentsAssignmentId(id: string, assignmentId: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/mentorships/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentId)}`, { auth: true, ...options });
+    }
+
+    /** POST /api/mentorships/{id}/assignments/{assignmentId}/start: Start the contest of an assignment (mentee) */
+    postMentorshipsIdAssignmentsAssignmentIdStart(id: string, assignmentId: string, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('POST', `/api/mentorships/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentId)}/start`, { auth: true, ...options });
+    }
+
+    /** GET /api/mentorships/{id}/audit: Audit trail of consent changes and mentor reads (either side) */
+    getMentorshipsIdAudit(id: string, params: GetMentorshipsIDAuditParams = {}, options: RequestOptions = {}): Promise<MentorshipAudit> {
+        return this.request('GET', `/api/mentorships/${encodeURIComponent(id)}/audit`, { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/mentorships/{id}/contests: The mentee's contests (mentor) */
+    getMentorshipsIdContests(id: string, options: RequestOptions = {}): Promise<GetMentorshipsIDContestsResponse> {
+        return this.request('GET', `/api/mentorships/${encodeURIComponent(id)}/contests`, { auth: true, ...options });
+    }
+
+    /** GET /api/mentorships/{id}/contests/{contestId}: One of the mentee's contests (mentor) */
+    getMentorshipsIdContestsContestId(id: string, contestId: string, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('GET', `/api/mentorships/${encodeURIComponent(id)}/contests/${encodeURIComponent(contestId)}`, { auth: true, ...options });
+    }
+
+    /** POST /api/mentorships/{id}/decline: Decline a mentorship invite (mentee) */
+    postMentorshipsIdDecline(id: string, options: RequestOptions = {}): Promise<Mentorship> {
+        return this.request('POST', `/api/mentorships/${encodeURIComponent(id)}/decline`, { auth: true, ...options });
+    }
+
+    /** GET /api/mentorships/{id}/notes: The mentee's contest retro notes (mentor) */
+    getMentorshipsIdNotes(id: string, options: RequestOptions = {}): Promise<GetMentorshipsIDNotesResponse> {
+        return this.request('GET', `/api/mentorships/${encodeURIComponent(id)}/notes`, { auth: true, ...options });
+    }
+
+    /** GET /api/mentorships/{id}/timing: The mentee's solve times and contest statistics (mentor) */
+    getMentorshipsIdTiming(id: string, options: RequestOptions = {}): Promise<MenteeTiming> {
+        return this.request('GET', `/api/mentorships/${encodeURIComponent(id)}/timing`, { auth: true, ...options });
+    }
+
+    /** GET /api/openapi.json: OpenAPI specification */
+    getOpenapiJson(options: RequestOptions = {}): Promise<Record<string, unknown>> {
+        return this.request('GET', '/api/openapi.json', { auth: false, ...options });
+    }
+
+    /** GET /api/orgs: List the caller's organizations */
+    getOrgs(options: RequestOptions = {}): Promise<GetOrgsResponse> {
+        return this.request('GET', '/api/orgs', { auth: true, ...options });
+    }
+
+    /** POST /api/orgs: Create an organization (class) with the caller as instructor */
+    postOrgs(body: CreateOrgRequest, options: RequestOptions = {}): Promise<OrgResponse> {
+        return this.request('POST', '/api/orgs', { auth: true, body, ...options });
+    }
+
+    /** POST /api/orgs/join: Join an organization with an invite code */
+    postOrgsJoin(body: JoinOrgRequest, options: RequestOptions = {}): Promise<OrgResponse> {
+        return this.request('POST', '/api/orgs/join', { auth: true, body, ...options });
+    }
+
+    /** GET /api/orgs/{id}: Get an organization with its members */
+    getOrgsId(id: string, options: RequestOptions = {}): Promise<OrgResponse> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}`, { auth: true, ...options });
+    }
+
+    /** GET /api/orgs/{id}/assignments: List assignments with the caller's progress */
+    getOrgsIdAssignments(id: string, options: RequestOptions = {}): Promise<GetOrgsIDAssignmentsResponse> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}/assignments`, { auth: true, ...options });
+    }
+
+    /** POST /api/orgs/{id}/assignments: Assign a contest, study plan or problem set to the class (instructors); problem sets are pushed to students as pending contests */
+    postOrgsIdAssignments(id: string, body: CreateAssignmentRequest, options: RequestOptions = {}): Promise<OrgAssignmentResponse> {
+        return this.request('POST', `/api/orgs/${encodeURIComponent(id)}/assignments`, { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/orgs/{id}/assignments/{assignmentId}: Delete an assignment (instructors) */
+    deleteOrgsIdAssignmentsAssignmentId(id: string, assignmentId: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/orgs/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentId)}`, { auth: true, ...options });
+    }
+
+    /** GET /api/orgs/{id}/assignments/{assignmentId}/report: Per-student completion report of an assignment (instructors) */
+    getOrgsIdAssignmentsAssignmentIdReport(id: string, assignmentId: string, params: GetOrgsIDAssignmentsAssignmentIDReportParams = {}, options: RequestOptions = {}): Promise<AssignmentReport> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentId)}/report`, { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/orgs/{id}/assignments/{assignmentId}/similarity: Pairs of students' solutions to an assignment flagged as highly similar (instructors) */
+    getOrgsIdAssignmentsAssignmentIdSimilarity(id: string, assignmentId: string, params: GetOrgsIDAssignmentsAssignmentIDSimilarityParams = {}, options: RequestOptions = {}): Promise<GetOrgsIDAssignmentsAssignmentIDSimilarityResponse> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentId)}/similarity`, { auth: true, query: { ...params }, ...options });
+    }
+
+    /** POST /api/orgs/{id}/assignments/{assignmentId}/start: Start the contest of a contest assignment, or the pending contest of a problem set */
+    postOrgsIdAssignmentsAssignmentIdStart(id: string, assignmentId: string, options: RequestOptions = {}): Promise<ContestResponse> {
+        return this.request('POST', `/api/orgs/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentId)}/start`, { auth: true, ...options });
+    }
+
+    /** GET /api/orgs/{id}/contests/{contestId}/proctoring: Focus and tab switch summary of a student's assignment contest (instructors) */
+    getOrgsIdContestsContestIdProctoring(id: string, contestId: string, options: RequestOptions = {}): Promise<ProctoringSummary> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}/contests/${encodeURIComponent(contestId)}/proctoring`, { auth: true, ...options });
+    }
+
+    /** POST /api/orgs/{id}/invites: Create an invite code (instructors) */
+    postOrgsIdInvites(id: string, body: CreateOrgInviteRequest, options: RequestOptions = {}): Promise<OrgInvite> {
+        return this.request('POST', `/api/orgs/${encodeURIComponent(id)}/invites`, { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/orgs/{id}/invites/{code}: Revoke an invite code (instructors) */
+    deleteOrgsIdInvitesCode(id: string, code: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/orgs/${encodeURIComponent(id)}/invites/${encodeURIComponent(code)}`, { auth: true, ...options });
+    }
+
+    /** DELETE /api/orgs/{id}/members/{userId}: Remove a member (instructors), or leave the organization */
+    deleteOrgsIdMembersUserId(id: string, userId: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/orgs/${encodeURIComponent(id)}/members/${encodeURIComponent(userId)}`, { auth: true, ...options });
+    }
+
+    /** GET /api/orgs/{id}/roster: Roster progress dashboard of the students (instructors) */
+    getOrgsIdRoster(id: string, params: GetOrgsIDRosterParams = {}, options: RequestOptions = {}): Promise<OrgRoster> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}/roster`, { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/orgs/{id}/similarity/{flagId}: A flagged solution pair with both solutions (instructors) */
+    getOrgsIdSimilarityFlagId(id: string, flagId: string, options: RequestOptions = {}): Promise<SimilarityFlagDetail> {
+        return this.request('GET', `/api/orgs/${encodeURIComponent(id)}/similarity/${encodeURIComponent(flagId)}`, { auth: true, ...options });
+    }
+
+    /** PATCH /api/orgs/{id}/similarity/{flagId}: Confirm or dismiss a flagged solution pair (instructors) */
+    patchOrgsIdSimilarityFlagId(id: string, flagId: string, body: ReviewSimilarityRequest, options: RequestOptions = {}): Promise<SimilarityFlagResponse> {
+        return this.request('PATCH', `/api/orgs/${encodeURIComponent(id)}/similarity/${encodeURIComponent(flagId)}`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/problems: List all problems */
+    getProblems(params: GetProblemsParams = {}, options: RequestOptions = {}): Promise<GetProblemsResponse> {
+        return this.request('GET', '/api/problems', { auth: false, query: { ...params }, ...options });
+    }
+
+    /** POST /api/problems/batch: Look up several problems by ID or slug */
+    postProblemsBatch(body: ProblemBatchRequest, params: PostProblemsBatchParams = {}, options: RequestOptions = {}): Promise<ProblemBatchResponse> {
+        return this.request('POST', '/api/problems/batch', { auth: false, body, query: { ...params }, ...options });
+    }
+
+    /** GET /api/problems/search: Search problems by title or topic with fuzzy matching */
+    getProblemsSearch(params: GetProblemsSearchParams = {}, options: RequestOptions = {}): Promise<ProblemSearchResponse> {
+        return this.request('GET', '/api/problems/search', { auth: false, query: { ...params }, ...options });
+    }
+
+    /** GET /api/problems/slug/{slug}: Public page of a problem with SEO metadata */
+    getProblemsSlugSlug(slug: string, options: RequestOptions = {}): Promise<ProblemPage> {
+        return this.request('GET', `/api/problems/slug/${encodeURIComponent(slug)}`, { auth: false, ...options });
+    }
+
+    /** GET /api/problems/stats: Get problem statistics */
+    getProblemsStats(options: RequestOptions = {}): Promise<ProblemStats> {
+        return this.request('GET', '/api/problems/stats', { auth: false, ...options });
+    }
+
+    /** GET /api/problems/{id}: Get single problem */
+    getProblemsId(id: string, params: GetProblemsIDParams = {}, options: RequestOptions = {}): Promise<ProblemResponse> {
+        return this.request('GET', `/api/problems/${encodeURIComponent(id)}`, { auth: false, query: { ...params }, ...options });
+    }
+
+    /** GET /api/problems/{id}/prerequisites: List problem prerequisites */
+    getProblemsIdPrerequisites(id: string, options: RequestOptions = {}): Promise<ProblemPrerequisitesResponse> {
+        return this.request('GET', `/api/problems/${encodeURIComponent(id)}/prerequisites`, { auth: false, ...options });
+    }
+
+    /** GET /api/public/stats: Anonymized platform statistics */
+    getPublicStats(options: RequestOptions = {}): Promise<PublicStats> {
+        return this.request('GET', '/api/public/stats', { auth: false, ...options });
+    }
+
+    /** GET /api/public/stats/problems: Most attempted problems */
+    getPublicStatsProblems(params: GetPublicStatsProblemsParams = {}, options: RequestOptions = {}): Promise<PublicProblemStats> {
+        return this.request('GET', '/api/public/stats/problems', { auth: false, query: { ...params }, ...options });
+    }
+
+    /** POST /api/quick: Run a quick command such as "start 5x90", "done 3" or "skip" */
+    postQuick(body: QuickCommandRequest, options: RequestOptions = {}): Promise<QuickResult> {
+        return this.request('POST', '/api/quick', { auth: true, body, ...options });
+    }
+
+    /** GET /api/quick/history: List the latest quick commands and their outcome */
+    getQuickHistory(params: GetQuickHistoryParams = {}, options: RequestOptions = {}): Promise<QuickHistory> {
+        return this.request('GET', '/api/quick/history', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/roadmap: Get the roadmap with completion overlay */
+    getRoadmap(options: RequestOptions = {}): Promise<RoadmapResponse> {
+        return this.request('GET', '/api/roadmap', { auth: false, ...options });
+    }
+
+    /** GET /api/spectate/{spectatorCode}: Watch a challenge's standings as a spectator */
+    getSpectateSpectatorCode(spectatorCode: string, options: RequestOptions = {}): Promise<SpectatorView> {
+        return this.request('GET', `/api/spectate/${encodeURIComponent(spectatorCode)}`, { auth: true, ...options });
+    }
+
+    /** GET /api/tenant: The tenant the request is for, selected by subdomain or the X-Tenant header */
+    getTenant(options: RequestOptions = {}): Promise<TenantInfo> {
+        return this.request('GET', '/api/tenant', { auth: false, ...options });
+    }
+
+    /** GET /api/tenant/leaderboard: Users of your tenant ranked by solved problems */
+    getTenantLeaderboard(params: GetTenantLeaderboardParams = {}, options: RequestOptions = {}): Promise<GetTenantLeaderboardResponse> {
+        return this.request('GET', '/api/tenant/leaderboard', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** POST /api/tenant/problems: Add a problem to your tenant's catalog (tenant admins) */
+    postTenantProblems(body: CustomProblemRequest, options: RequestOptions = {}): Promise<ProblemResponse> {
+        return this.request('POST', '/api/tenant/problems', { auth: true, body, ...options });
+    }
+
+    /** GET /api/users/me: Get current user */
+    getUsersMe(options: RequestOptions = {}): Promise<UserResponse> {
+        return this.request('GET', '/api/users/me', { auth: true, ...options });
+    }
+
+    /** GET /api/users/me/attempts/{problemId}: Your attempt history at a problem */
+    getUsersMeAttemptsProblemId(problemId: string, options: RequestOptions = {}): Promise<AttemptHistory> {
+        return this.request('GET', `/api/users/me/attempts/${encodeURIComponent(problemId)}`, { auth: true, ...options });
+    }
+
+    /** GET /api/users/me/contests/by-topic: How often each topic came up in your finished contests and your completion rate */
+    getUsersMeContestsByTopic(options: RequestOptions = {}): Promise<GetUsersMeContestsByTopicResponse> {
+        return this.request('GET', '/api/users/me/contests/by-topic', { auth: true, ...options });
+    }
+
+    /** GET /api/users/me/digest: Weekly recommendation digest opt-in and the latest digest */
+    getUsersMeDigest(options: RequestOptions = {}): Promise<DigestStatus> {
+        return this.request('GET', '/api/users/me/digest', { auth: true, ...options });
+    }
+
+    /** PUT /api/users/me/digest: Opt in to or out of the weekly recommendation digest */
+    putUsersMeDigest(body: UpdateDigestRequest, options: RequestOptions = {}): Promise<DigestStatus> {
+        return this.request('PUT', '/api/users/me/digest', { auth: true, body, ...options });
+    }
+
+    /** GET /api/users/me/features: Feature flags that are on for the current user */
+    getUsersMeFeatures(options: RequestOptions = {}): Promise<FeaturesResponse> {
+        return this.request('GET', '/api/users/me/features', { auth: true, ...options });
+    }
+
+    /** GET /api/users/me/feed: Activity feed of the user and their friends, newest first */
+    getUsersMeFeed(params: GetUsersMeFeedParams = {}, options: RequestOptions = {}): Promise<FeedResponse> {
+        return this.request('GET', '/api/users/me/feed', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/users/me/filters: List saved problem filters */
+    getUsersMeFilters(options: RequestOptions = {}): Promise<GetUsersMeFiltersResponse> {
+        return this.request('GET', '/api/users/me/filters', { auth: true, ...options });
+    }
+
+    /** POST /api/users/me/filters: Save a problem filter */
+    postUsersMeFilters(body: SavedFilterRequest, options: RequestOptions = {}): Promise<SavedFilter> {
+        return this.request('POST', '/api/users/me/filters', { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/users/me/filters/{filterId}: Delete a saved problem filter */
+    deleteUsersMeFiltersFilterId(filterId: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/users/me/filters/${encodeURIComponent(filterId)}`, { auth: true, ...options });
+    }
+
+    /** PUT /api/users/me/filters/{filterId}: Replace a saved problem filter */
+    putUsersMeFiltersFilterId(filterId: string, body: SavedFilterRequest, options: RequestOptions = {}): Promise<SavedFilter> {
+        return this.request('PUT', `/api/users/me/filters/${encodeURIComponent(filterId)}`, { auth: true, body, ...options });
+    }
+
+    /** POST /api/users/me/heartbeat: Mark yourself online, or in your contest */
+    postUsersMeHeartbeat(body: HeartbeatRequest, options: RequestOptions = {}): Promise<Presence> {
+        return this.request('POST', '/api/users/me/heartbeat', { auth: true, body, ...options });
+    }
+
+    /** PUT /api/users/me/password: Change password */
+    putUsersMePassword(body: ChangePasswordRequest, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('PUT', '/api/users/me/password', { auth: true, body, ...options });
+    }
+
+    /** GET /api/users/me/problems: List private custom problems */
+    getUsersMeProblems(options: RequestOptions = {}): Promise<GetUsersMeProblemsResponse> {
+        return this.request('GET', '/api/users/me/problems', { auth: true, ...options });
+    }
+
+    /** POST /api/users/me/problems: Add a private custom problem */
+    postUsersMeProblems(body: CustomProblemRequest, options: RequestOptions = {}): Promise<ProblemResponse> {
+        return this.request('POST', '/api/users/me/problems', { auth: true, body, ...options });
+    }
+
+    /** DELETE /api/users/me/problems/{problemId}: Delete a private custom problem */
+    deleteUsersMeProblemsProblemId(problemId: string, options: RequestOptions = {}): Promise<MessageResponse> {
+        return this.request('DELETE', `/api/users/me/problems/${encodeURIComponent(problemId)}`, { auth: true, ...options });
+    }
+
+    /** PUT /api/users/me/problems/{problemId}: Replace a private custom problem */
+    putUsersMeProblemsProblemId(problemId: string, body: CustomProblemRequest, options: RequestOptions = {}): Promise<ProblemResponse> {
+        return this.request('PUT', `/api/users/me/problems/${encodeURIComponent(problemId)}`, { auth: true, body, ...options });
+    }
+
+    /** GET /api/users/me/progress: Get user progress stats */
+    getUsersMeProgress(options: RequestOptions = {}): Promise<UserProgress> {
+        return this.request('GET', '/api/users/me/progress', { auth: true, ...options });
+    }
+
+    /** GET /api/users/me/quotas: Plan and remaining allowances of the current user */
+    getUsersMeQuotas(options: RequestOptions = {}): Promise<QuotaStatus> {
+        return this.request('GET', '/api/users/me/quotas', { auth: true, ...options });
+    }
+
+    /** GET /api/users/me/reviews: Solved problems in spaced-repetition order */
+    getUsersMeReviews(params: GetUsersMeReviewsParams = {}, options: RequestOptions = {}): Promise<ReviewQueue> {
+        return this.request('GET', '/api/users/me/reviews', { auth: true, query: { ...params }, ...options });
+    }
+
+    /** GET /api/users/me/search: Search the user's contest retros and solution snippets */
+    getUsersMeSearch(params: GetUsersMeSearchParams = {}, options: RequestOptions = {}): Promise<NoteSearchResponse> {
+        return this.request('GET', '/api/users/me/search', { auth: true, query: { ...params }, ...options });
+    }
+}
@@ -0,0 +1,3 @@
+export * from './client.js';
+export * from './runtime.js';
+export type * from './types.js';
@@ -0,0 +1,269 @@
+// Hand-written transport for the generated ContestMakerClient: retries with
+// jittered exponential backoff and a single token refresh on TOKEN_EXPIRED.
+
+import type { AuthResponse, ErrorResponse, PostAuthRefreshResponse, TokenPair } from './types.js';
+
+export interface RetryPolicy {
+    /** Total attempts including the first; 1 disables retries */
+    maxAttempts: number;
+    /** Backoff before the second attempt, doubled for each further one */
+    baseDelayMs: number;
+    /** Upper bound on a single wait; a longer Retry-After is thrown as an ApiError */
+    maxDelayMs: number;
+}
+
+export const defaultRetryPolicy: RetryPolicy = { maxAttempts: 4, baseDelayMs: 200, maxDelayMs: 5000 };
+
+export interface ClientOptions {
+    /** API origin, e.g. https://contest-maker.example.com */
+    baseUrl: string;
+    retry?: RetryPolicy;
+    /** Tokens from an earlier session */
+    tokens?: TokenPair | null;
+    /** Called whenever the tokens change so they can be persisted; null after logging out */
+    onTokens?: (tokens: TokenPair | null) => void;
+    /** Sent as X-Client-Name; the server reports callers of deprecated operations by it */
+    clientName?: string;
+    fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+    signal?: AbortSignal;
+}
+
+interface RequestSpec extends RequestOptions {
+    /** Requires signing in; the token is sent whenever the client has one */
+    auth: boolean;
+    body?: unknown;
+    query?: Record<string, string | number | boolean | string[] | undefined>;
+}
+
+/** A failed request, carrying the API error envelope */
+export class ApiError extends Error {
+    constructor(
+        readonly status: number,
+        readonly code: string,
+        message: string,
+        readonly details: unknown,
+        readonly requestId: string,
+        /** From the Retry-After header, or the envelope's retry_after_seconds */
+        readonly retryAfterMs: number,
+        /** 'safe', 'idempotent' or 'never'; empty for responses without the envelope */
+        readonly retry: string,
+    ) {
+        super(code ? `${code}: ${message} (HTTP ${status})` : `HTTP ${status}`);
+        this.name = 'ApiError';
+    }
+}
+
+/** Thrown without a request for operations that need tokens when the client has none */
+export class NotSignedInError extends Error {
+    constructor() {
+        super('not signed in');
+        this.name = 'NotSignedInError';
+    }
+}
+
+const idempotentMethods = new Set(['GET', 'HEAD', 'OPTIONS', 'PUT', 'DELETE']);
+
+export class BaseClient {
+    private readonly baseUrl: string;
+    private readonly retry: RetryPolicy;
+    private readonly fetchFn: typeof fetch;
+    private tokens: TokenPair | null;
+    private refreshing: Promise<void> | null = null;
+
+    constructor(private readonly options: ClientOptions) {
+        this.baseUrl = options.baseUrl.replace(/\/+$/, '');
+        this.retry = options.retry ?? defaultRetryPolicy;
+        this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis);
+        this.tokens = options.tokens ?? null;
+    }
+
+    /** The current tokens, or null when the client is not signed in */
+    getTokens(): TokenPair | null {
+        return this.tokens;
+    }
+
+    /** Replaces the tokens; null signs the client out locally */
+    setTokens(tokens: TokenPair | null): void {
+        this.tokens = tokens;
+        this.options.onTokens?.(tokens);
+    }
+
+    protected async request<T>(method: string, path: string, spec: RequestSpec): Promise<T> {
+        const payload = spec.body === undefined ? undefined : JSON.stringify(spec.body);
+        let refreshed = false;
+
+        for (let attempt = 1; ; attempt++) {
+            const token = this.tokens?.access_token;
+            if (spec.auth && !token) {
+                throw new NotSignedInError();
+            }
+            let response: Response;
+            try {
+                response = await this.send(method, path, spec, payload, token);
+            } catch (err) {
+                if (spec.signal?.aborted || !idempotentMethods.has(method) || attempt >= this.retry.maxAttempts) {
+                    throw err;
+                }
+                await sleep(this.backoff(attempt), spec.signal);
+                continue;
+            }
+
+            if (response.ok) {
+                const result = (await response.json()) as T;
+                this.captureTokens(path, result);
+                return result;
+            }
+
+            const error = await readError(response);
+            if (error.status === 401 && error.code === 'TOKEN_EXPIRED' && token && !refreshed) {
+                refreshed = true;
+                try {
+                    await this.refresh(token, spec.signal);
+                } catch {
+                    throw error;
+                }
+                attempt--; // A refresh does not use up a retry
+                continue;
+            }
+
+            if (!retryable(method, error) || attempt >= this.retry.maxAttempts) {
+                throw error;
+            }
+            let delay = this.backoff(attempt);
+            if (error.retryAfterMs > 0) {
+                if (error.retryAfterMs > this.retry.maxDelayMs) {
+                    throw error; // e.g. maintenance; waiting here would only stall the caller
+                }
+                delay = error.retryAfterMs;
+            }
+            await sleep(delay, spec.signal);
+        }
+    }
+
+    private send(method: string, path: string, spec: RequestSpec, payload: string | undefined, token: string | undefined): Promise<Response> {
+        const url = new URL(this.baseUrl + path);
+        for (const [key, value] of Object.entries(spec.query ?? {})) {
+            if (value === undefined || value === '') continue;
+            for (const v of Array.isArray(value) ? value : [value]) {
+                url.searchParams.append(key, String(v));
+            }
+        }
+
+        const headers: Record<string, string> = { Accept: 'application/json' };
+        if (payload !== undefined) headers['Content-Type'] = 'application/json';
+        if (token) headers.Authorization = `Bearer ${token}`;
+        if (this.options.clientName) headers['X-Client-Name'] = this.options.clientName;
+
+        return this.fetchFn(url, { method, headers, body: payload, signal: spec.signal });
+    }
+
+    /** Exchanges the refresh token once, however many requests hit the expired token */
+    private refresh(expired: string | undefined, signal?: AbortSignal): Promise<void> {
+        if (this.tokens && this.tokens.access_token !== expired) {
+            return Promise.resolve(); // Another request already refreshed
+        }
+        if (this.refreshing) {
+            return this.refreshing;
+        }
+        const refreshToken = this.tokens?.refresh_token;
+        if (!refreshToken) {
+            return Promise.reject(new Error('no refresh token'));
+        }
+        const refreshing = this.request<PostAuthRefreshResponse>('POST', '/api/auth/refresh', {
+            auth: false,
+            body: { refresh_token: refreshToken },
+            signal,
+        })
+            .then(() => undefined)
+            .finally(() => {
+                this.refreshing = null;
+            });
+        this.refreshing = refreshing;
+        return refreshing;
+    }
+
+    /** Keeps the tokens issued by signup, login and refresh, and forgets them after logging out */
+    private captureTokens(path: string, result: unknown): void {
+        switch (path) {
+            case '/api/auth/signup':
+            case '/api/auth/login':
+                this.setTokens((result as AuthResponse).tokens);
+                break;
+            case '/api/auth/refresh':
+                this.setTokens((result as PostAuthRefreshResponse).tokens);
+                break;
+            case '/api/auth/logout':
+            case '/api/auth/logout-all':
+                this.setTokens(null);
+                break;
+        }
+    }
+
+    /** A random wait of up to baseDelayMs·2^(attempt-1), capped at maxDelayMs */
+    private backoff(attempt: number): number {
+        const ceiling = Math.min(this.retry.maxDelayMs, this.retry.baseDelayMs * 2 ** (attempt - 1));
+        return Math.random() * ceiling;
+    }
+}
+
+/**
+ * Goes by the retry class of the error envelope. Responses without one, e.g. from a proxy,
+ * go by status: 429 and 503 are answered before the handler runs, so any method can be retried.
+ */
+function retryable(method: string, error: ApiError): boolean {
+    if (error.retry === 'safe') return true;
+    if (error.retry === 'idempotent') return idempotentMethods.has(method);
+    if (error.retry === 'never') return false;
+    if (error.status === 429 || error.status === 503) return true;
+    if (error.status === 502 || error.status === 504) return idempotentMethods.has(method);
+    return false;
+}
+
+async function readError(response: Response): Promise<ApiError> {
+    let envelope: ErrorResponse | undefined;
+    try {
+        envelope = (await response.json()) as ErrorResponse;
+    } catch {
+        // Not the API error envelope, e.g. from a proxy
+    }
+    const error = envelope?.error;
+    return new ApiError(
+        response.status,
+        error?.code ?? '',
+        error?.message ?? response.statusText,
+        error?.details ?? null,
+        error?.request_id ?? '',
+        retryAfter(response.headers.get('Retry-After')) || (error?.retry_after_seconds ?? 0) * 1000,
+        error?.retry ?? '',
+    );
+}
+
+/** Parses a Retry-After header given in seconds or as an HTTP date */
+function retryAfter(value: string | null): number {
+    if (!value) return 0;
+    const seconds = Number(value);
+    if (Number.isFinite(seconds)) return Math.max(0, seconds * 1000);
+    const at = Date.parse(value);
+    return Number.isNaN(at) ? 0 : Math.max(0, at - Date.now());
+}
+
+function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+    return new Promise((resolve, reject) => {
+        if (signal?.aborted) {
+            reject(signal.reason);
+            return;
+        }
+        const timer = setTimeout(() => {
+            signal?.removeEventListener('abort', onAbort);
+            resolve();
+        }, ms);
+        const onAbort = () => {
+            clearTimeout(timer);
+            reject(signal?.reason);
+        };
+        signal?.addEventListener('abort', onAbort, { once: true });
+    });
+}
@@ -0,0 +1,1468 @@
+// Code generated by backend/cmd/clientgen from backend/api/openapi.json. DO NOT EDIT.
+
+export interface APIError {
+    code: string;
+    details: unknown;
+    message: string;
+    request_id: string;
+    retry: string;
+    retry_after_seconds: number;
+}
+
+export interface AssignmentReport {
+    abandoned: number;
+    assignment: OrgAssignment;
+    completed: number;
+    in_progress: number;
+    members: AssignmentReportRow[];
+    not_started: number;
+    overdue: number;
+}
+
+export interface AssignmentReportRow {
+    assignment_id: string;
+    contest_id: string | null;
+    ended_at: string | null;
+    focus_losses: number;
+    late: boolean;
+    overdue: boolean;
+    solved: number;
+    started_at: string | null;
+    status: string;
+    tab_switches: number;
+    total: number;
+    user_id: string;
+    username: string;
+}
+
+export interface AttachSolutionRequest {
+    code: string;
+    language?: string;
+}
+
+export interface Attempt {
+    attempted_at: string;
+    contest_id: string | null;
+    duration_seconds: number;
+    id: string;
+    outcome: string;
+    problem_id: string;
+    user_id: string;
+}
+
+export interface AttemptHistory {
+    attempts: Attempt[];
+    problem_id: string;
+    solved: boolean;
+    solved_on_first_attempt: boolean;
+}
+
+export interface AttemptStats {
+    failed_attempts: number;
+    first_attempt_rate: number;
+    solved_on_first_attempt: number;
+    solved_problems: number;
+    total_attempts: number;
+}
+
+export interface AuthResponse {
+    tokens: TokenPair;
+    user: UserResponse;
+}
+
+export interface BackupFile {
+    bytes: number;
+    name: string;
+    rows: number;
+    sha256: string;
+    table: string;
+}
+
+export interface BackupListResponse {
+    backups: BackupManifest[];
+}
+
+export interface BackupManifest {
+    catalog_slugs: Record<string, string>;
+    created_at: string;
+    driver: string;
+    duration_seconds: number;
+    files: BackupFile[];
+    format: string;
+    id: string;
+    version: number;
+}
+
+export interface ChallengeComparison {
+    challenger: ChallengeResult;
+    code: string;
+    opponent: ChallengeResult;
+    problems: ChallengeProblemComparison[];
+    winner_id: string | null;
+}
+
+export interface ChallengeProblemComparison {
+    challenger_solved: boolean;
+    opponent_solved: boolean;
+    problem: ProblemResponse;
+}
+
+export interface ChallengeResponse {
+    accepted: boolean;
+    accepted_at: string | null;
+    challenger_id: string;
+    challenger_username: string;
+    code: string;
+    contest_id: string;
+    duration_minutes: number;
+    expires_at: string;
+    problem_count: number;
+    silent_mode: boolean;
+}
+
+export interface ChallengeResult {
+    contest_id: string;
+    elapsed_seconds: number;
+    solved: number;
+    status: string;
+    user_id: string;
+    username: string;
+}
+
+export interface ChallengeStanding {
+    contest_id: string;
+    presence: Presence;
+    solved: number;
+    status: string;
+    total: number;
+    user_id: string;
+    username: string;
+}
+
+export interface ChallengeStandings {
+    code: string;
+    participants: ChallengeStanding[];
+}
+
+export interface ChangePasswordRequest {
+    current_password: string;
+    new_password: string;
+}
+
+export interface ChatMessageResponse {
+    body: string;
+    created_at: string;
+    id: string;
+    user_id: string;
+    username: string;
+}
+
+export interface ChatMessages {
+    messages: ChatMessageResponse[];
+    silenced: boolean;
+}
+
+export interface CheckoutSessionResponse {
+    id: string;
+    url: string;
+}
+
+export interface Cohort {
+    size: number;
+    week: string;
+    weeks: CohortWeekStats[];
+}
+
+export interface CohortWeekStats {
+    active_users: number;
+    complete: boolean;
+    contests_completed: number;
+    contests_started: number;
+    retention: number;
+    week: number;
+}
+
+export interface CohortsResponse {
+    cohorts: Cohort[];
+    computed_at: string | null;
+}
+
+export interface CompanyCount {
+    count: number;
+    name: string;
+}
+
+export interface ComplexityResult {
+    expected_space_complexity: string;
+    expected_time_complexity: string;
+    space_complexity: string;
+    space_correct: boolean | null;
+    time_complexity: string;
+    time_correct: boolean | null;
+}
+
+export interface ComplexityScore {
+    answered: number;
+    correct: number;
+    graded: number;
+}
+
+export interface ConfidenceStats {
+    average: number;
+    distribution: Record<string, number>;
+    rated: number;
+    unrated: number;
+}
+
+export interface ContestEvent {
+    contest_id: string;
+    id: string;
+    occurred_at: string;
+    received_at: string;
+    type: string;
+}
+
+export interface ContestEventInput {
+    occurred_at: string;
+    type: string;
+}
+
+export interface ContestProblemResponse {
+    complexity: ComplexityResult;
+    is_completed: boolean;
+    is_warmup: boolean;
+    order: number;
+    problem: ProblemResponse;
+    time_spent_seconds: number;
+    timer_running: boolean;
+}
+
+export interface ContestResponse {
+    complexity_score: ComplexityScore;
+    difficulty_rating: number | null;
+    duration_minutes: number;
+    ended_at: string | null;
+    id: string;
+    ordering: string;
+    problems: ContestProblemResponse[];
+    retro: string;
+    retro_updated_at: string | null;
+    started_at: string;
+    status: string;
+    tags: string[];
+    time_remaining_seconds: number;
+    tuning: DistributionTuning;
+    warmup: ContestWarmupResponse;
+    warning: ContestWarning;
+}
+
+export interface ContestSession {
+    contest_id: string;
+    current_problem_id: string | null;
+    device: string;
+    notes: Record<string, string>;
+    updated_at: string;
+    version: number;
+}
+
+export interface ContestSizeCount {
+    contests: number;
+    problem_count: number;
+}
+
+export interface ContestStatistics {
+    abandoned_contests: number;
+    completed_contests: number;
+    total_contests: number;
+}
+
+export interface ContestTemplate {
+    duration_minutes: number;
+    exported_at?: string;
+    ordering: string;
+    problems: TemplateProblem[];
+    version: number;
+}
+
+export interface ContestTemplateImport {
+    conflicts: TemplateConflict[];
+    contest: ContestResponse;
+    problems: number;
+}
+
+export interface ContestWarmupResponse {
+    ends_at: string;
+    is_completed: boolean;
+    problem: ProblemResponse;
+    time_remaining_seconds: number;
+}
+
+export interface ContestWarning {
+    code: string;
+    delivered: Record<string, number>;
+    message: string;
+    requested: Record<string, number>;
+}
+
+export interface CreateAssignmentRequest {
+    category_id: string | null;
+    contest: CreateContestRequest;
+    due_at: string | null;
+    duration_minutes: number;
+    kind: string;
+    problems: string[];
+    title: string;
+}
+
+export interface CreateChallengeRequest {
+    silent_mode?: boolean;
+}
+
+export interface CreateContestRequest {
+    companies?: string[];
+    difficulties?: string[];
+    duration_minutes: number;
+    include_custom?: boolean;
+    ordering?: string;
+    problem_count: number;
+    respect_prerequisites?: boolean;
+    solved_warmup?: boolean;
+    source?: string;
+    tags?: string[];
+    warmup_minutes?: number;
+    weighting?: string;
+}
+
+export interface CreateListContestRequest {
+    duration_minutes: number;
+    include_solved?: boolean;
+    problem_count: number;
+}
+
+export interface CreateMentorAssignmentRequest {
+    contest: CreateContestRequest;
+    due_at: string;
+    duration_minutes: number;
+    kind: string;
+    problems: string[];
+    title: string;
+}
+
+export interface CreateMentorshipRequest {
+    mentee_email: string;
+}
+
+export interface CreateOrgInviteRequest {
+    role?: string;
+}
+
+export interface CreateOrgRequest {
+    name: string;
+}
+
+export interface CreateTenantRequest {
+    admin_email: string;
+    name: string;
+    slug: string;
+}
+
+export interface CustomProblemRequest {
+    difficulty: string;
+    title: string;
+    topics?: string[];
+    url: string;
+}
+
+export interface DigestItemResponse {
+    click_url: string;
+    clicked: boolean;
+    companies: string[];
+    custom: boolean;
+    difficulty: string;
+    id: string;
+    importance: number;
+    leetcode_url: string;
+    neetcode_url: string;
+    popularity: ProblemPopularity;
+    position: number;
+    slug: string;
+    title: string;
+    topics: string[];
+}
+
+export interface DigestResponse {
+    channel: string;
+    id: string;
+    items: DigestItemResponse[];
+    sent_at: string;
+}
+
+export interface DigestRunReport {
+    due: number;
+    emailed: number;
+    failed: number;
+    sent: number;
+    skipped: number;
+}
+
+export interface DigestStats {
+    click_rate: number;
+    clicked_digests: number;
+    clicks: number;
+    clicks_by_position: number[];
+    digest_click_rate: number;
+    digests: number;
+    emailed: number;
+    items: number;
+    since: string;
+    solve_rate: number;
+    solved_items: number;
+    subscribers: number;
+}
+
+export interface DigestStatus {
+    enabled: boolean;
+    last_sent_at: string | null;
+    latest: DigestResponse;
+    opted_in_at: string | null;
+}
+
+export interface DistributionTuning {
+    base: Record<string, number>;
+    factor: number;
+    mean_rating: number;
+    profile: string;
+    ratings: number;
+    tuned: Record<string, number>;
+}
+
+export interface ErrorResponse {
+    error: APIError;
+}
+
+export interface ExperimentOutcome {
+    flag: string;
+    key: string;
+    variants: VariantOutcome[];
+}
+
+export interface ExperimentsResponse {
+    experiments: ExperimentOutcome[];
+}
+
+export interface FeatureFlag {
+    description: string;
+    enabled: boolean;
+    key: string;
+    rollout_percent: number;
+    source: string;
+    updated_at: string;
+}
+
+export interface FeatureFlagListResponse {
+    flags: FeatureFlag[];
+}
+
+export interface FeaturesResponse {
+    features: Record<string, boolean>;
+}
+
+export interface FeedEntry {
+    actor_id: string;
+    actor_username: string;
+    contest_id: string | null;
+    created_at: string;
+    detail: string;
+    id: string;
+    kind: string;
+    own: boolean;
+    problem_id: string | null;
+    problem_title: string;
+}
+
+export interface FeedResponse {
+    items: FeedEntry[];
+    next_cursor: string;
+}
+
+export interface GetAdminProblemsCalibrationResponse {
+    problems: ProblemCalibration[];
+}
+
+export interface GetAdminTenantsResponse {
+    tenants: Tenant[];
+}
+
+export interface GetAssignmentsResponse {
+    assignments: PendingAssignment[];
+}
+
+export interface GetCompaniesResponse {
+    companies: CompanyCount[];
+}
+
+export interface GetContestsActiveResponse {
+    contest: ContestResponse;
+}
+
+export interface GetContestsResponse {
+    contests: ContestResponse[];
+}
+
+export interface GetContestsTagsResponse {
+    tags: TagCount[];
+}
+
+export interface GetMentorshipsIDAssignmentsResponse {
+    assignments: MentorAssignmentResponse[];
+}
+
+export interface GetMentorshipsIDContestsResponse {
+    contests: ContestResponse[];
+}
+
+export interface GetMentorshipsIDNotesResponse {
+    notes: MenteeNote[];
+}
+
+export interface GetOrgsIDAssignmentsAssignmentIDSimilarityResponse {
+    flags: SimilarityFlagResponse[];
+}
+
+export interface GetOrgsIDAssignmentsResponse {
+    assignments: OrgAssignmentResponse[];
+}
+
+export interface GetOrgsResponse {
+    orgs: OrgSummary[];
+}
+
+export interface GetProblemsResponse {
+    count: number;
+    problems: ProblemResponse[];
+}
+
+export interface GetTenantLeaderboardResponse {
+    entries: LeaderboardEntry[];
+}
+
+export interface GetUsersMeContestsByTopicResponse {
+    topics: TopicContestStats[];
+}
+
+export interface GetUsersMeFiltersResponse {
+    count: number;
+    filters: SavedFilter[];
+}
+
+export interface GetUsersMeProblemsResponse {
+    count: number;
+    problems: ProblemResponse[];
+}
+
+export interface HeartbeatRequest {
+    contest_id?: string | null;
+}
+
+export interface ImportContestTemplateRequest {
+    dry_run?: boolean;
+    signature: string;
+    skip_missing?: boolean;
+    template: ContestTemplate;
+}
+
+export interface IntegrityFinding {
+    found: number;
+    issue: string;
+    repaired: number;
+}
+
+export interface IntegrityReport {
+    dry_run: boolean;
+    findings: IntegrityFinding[];
+    finished_at: string;
+    started_at: string;
+}
+
+export interface JoinOrgRequest {
+    code: string;
+}
+
+export interface LeaderboardEntry {
+    last_solved_at: string;
+    rank: number;
+    solved: number;
+    user_id: string;
+    username: string;
+}
+
+export interface LogLevelStatus {
+    default: string;
+    expires_at: string | null;
+    level: string;
+}
+
+export interface LoginRequest {
+    email: string;
+    password: string;
+}
+
+export interface LogoutRequest {
+    refresh_token?: string;
+}
+
+export interface MaintenanceStatus {
+    active: boolean;
+    ends_at: string | null;
+    message: string;
+    retry_after_seconds: number;
+    starts_at: string | null;
+    upcoming: boolean;
+}
+
+export interface MarkProblemCompleteRequest {
+    confidence?: number | null;
+    is_completed?: boolean;
+}
+
+export interface MenteeNote {
+    contest_id: string;
+    retro: string;
+    started_at: string;
+    status: string;
+    updated_at: string | null;
+}
+
+export interface MenteeTiming {
+    contest_stats: ContestStatistics;
+    last_active_at: string | null;
+    solve_times: Record<string, SolveTiming>;
+}
+
+export interface MentorAssignmentResponse {
+    contest: CreateContestRequest;
+    contest_id: string | null;
+    created_at: string;
+    due_at: string;
+    duration_minutes: number;
+    ended_at: string | null;
+    id: string;
+    kind: string;
+    late: boolean;
+    mentorship_id: string;
+    overdue: boolean;
+    problem_ids: string[];
+    solved: number;
+    started_at: string | null;
+    status: string;
+    title: string;
+    total: number;
+}
+
+export interface Mentorship {
+    created_at: string;
+    id: string;
+    invited_at: string;
+    mentee_id: string;
+    mentor_id: string;
+    responded_at: string | null;
+    revoked_at: string | null;
+    revoked_by: string | null;
+    status: string;
+}
+
+export interface MentorshipAudit {
+    entries: MentorshipAuditEntry[];
+}
+
+export interface MentorshipAuditEntry {
+    action: string;
+    actor_id: string;
+    assignment_id: string | null;
+    contest_id: string | null;
+    created_at: string;
+    id: string;
+    mentorship_id: string;
+}
+
+export interface MentorshipList {
+    mentoring: MentorshipResponse[];
+    mentors: MentorshipResponse[];
+}
+
+export interface MentorshipResponse {
+    created_at: string;
+    id: string;
+    invited_at: string;
+    mentee_id: string;
+    mentee_username: string;
+    mentor_id: string;
+    mentor_username: string;
+    responded_at: string | null;
+    revoked_at: string | null;
+    revoked_by: string | null;
+    status: string;
+}
+
+export interface MessageResponse {
+    message: string;
+}
+
+export interface ModerateProblemListRequest {
+    reason?: string;
+    status: string;
+}
+
+export interface NoteHighlight {
+    length: number;
+    start: number;
+}
+
+export interface NoteSearchResponse {
+    count: number;
+    query: string;
+    results: NoteSearchResult[];
+}
+
+export interface NoteSearchResult {
+    contest_id: string;
+    excerpt: string;
+    highlights: NoteHighlight[];
+    kind: string;
+    problem_id: string | null;
+    problem_title: string;
+    updated_at: string;
+}
+
+export interface OrgAssignment {
+    category_id: string | null;
+    contest: CreateContestRequest;
+    created_at: string;
+    created_by: string;
+    due_at: string | null;
+    duration_minutes: number;
+    id: string;
+    kind: string;
+    org_id: string;
+    problem_ids: string[];
+    title: string;
+}
+
+export interface OrgAssignmentResponse {
+    category_id: string | null;
+    completed_count: number | null;
+    contest: CreateContestRequest;
+    contest_id: string | null;
+    created_at: string;
+    created_by: string;
+    due_at: string | null;
+    duration_minutes: number;
+    id: string;
+    kind: string;
+    org_id: string;
+    overdue: boolean;
+    problem_ids: string[];
+    solved: number;
+    status: string;
+    title: string;
+    total: number;
+}
+
+export interface OrgInvite {
+    code: string;
+    created_at: string;
+    created_by: string;
+    expires_at: string;
+    org_id: string;
+    role: string;
+}
+
+export interface OrgMemberResponse {
+    joined_at: string;
+    role: string;
+    user_id: string;
+    username: string;
+}
+
+export interface OrgResponse {
+    created_at: string;
+    id: string;
+    invites: OrgInvite[];
+    members: OrgMemberResponse[];
+    name: string;
+    owner_id: string;
+    role: string;
+}
+
+export interface OrgRoster {
+    assignments: number;
+    limit: number;
+    members: OrgRosterMember[];
+    offset: number;
+    total: number;
+}
+
+export interface OrgRosterMember {
+    assignments_completed: number;
+    assignments_overdue: number;
+    completed_contests: number;
+    completion_rate: number;
+    easy_solved: number;
+    hard_solved: number;
+    joined_at: string;
+    last_active_at: string | null;
+    medium_solved: number;
+    total_contests: number;
+    total_solved: number;
+    user_id: string;
+    username: string;
+}
+
+export interface OrgSummary {
+    created_at: string;
+    id: string;
+    member_count: number;
+    name: string;
+    role: string;
+}
+
+export interface PageMetadata {
+    canonical_url: string;
+    description: string;
+    keywords: string[];
+    title: string;
+}
+
+export interface PendingAssignment {
+    assignment_id: string;
+    contest_id: string | null;
+    due_at: string | null;
+    kind: string;
+    overdue: boolean;
+    solved: number;
+    source: string;
+    source_id: string;
+    source_name: string;
+    status: string;
+    title: string;
+    total: number;
+}
+
+export interface PopularProblem {
+    attempts: number;
+    difficulty: string;
+    id: string;
+    slug: string;
+    solve_rate: number;
+    title: string;
+    users: number;
+}
+
+export interface PostAuthRefreshResponse {
+    tokens: TokenPair;
+}
+
+export interface PostBillingWebhookRequest {
+    created?: number;
+    data?: PostBillingWebhookRequestData;
+    id?: string;
+    type?: string;
+}
+
+export interface PostBillingWebhookRequestData {
+    object?: Record<string, unknown>;
+}
+
+export interface PostChatMessageRequest {
+    body: string;
+}
+
+export interface PostContestsIDEventsResponse {
+    recorded: number;
+}
+
+export interface Presence {
+    contest_id: string | null;
+    last_seen_at: string | null;
+    status: string;
+}
+
+export interface ProblemAvailability {
+    by_difficulty: Record<string, number>;
+    by_topic: Record<string, number>;
+    total: number;
+}
+
+export interface ProblemBatchRequest {
+    keys: string[];
+}
+
+export interface ProblemBatchResponse {
+    count: number;
+    not_found: string[];
+    problems: ProblemResponse[];
+}
+
+export interface ProblemCalibration {
+    difficulty: string;
+    id: string;
+    importance: number;
+    popularity: ProblemPopularity;
+    title: string;
+}
+
+export interface ProblemComplexity {
+    problem_id: string;
+    space_complexity: string;
+    time_complexity: string;
+    title: string;
+}
+
+export interface ProblemListPage {
+    limit: number;
+    lists: ProblemListSummary[];
+    offset: number;
+    total: number;
+}
+
+export interface ProblemListProblem {
+    companies: string[];
+    custom: boolean;
+    difficulty: string;
+    id: string;
+    importance: number;
+    leetcode_url: string;
+    neetcode_url: string;
+    popularity: ProblemPopularity;
+    position: number;
+    slug: string;
+    solved: boolean;
+    title: string;
+    topics: string[];
+}
+
+export interface ProblemListRequest {
+    description?: string;
+    problems: string[];
+    title: string;
+}
+
+export interface ProblemListResponse {
+    created_at: string;
+    description: string;
+    hidden_reason: string;
+    id: string;
+    owner_id: string;
+    owner_username: string;
+    problem_count: number;
+    problems: ProblemListProblem[];
+    reports: number;
+    solved: number;
+    status: string;
+    subscribed: boolean;
+    subscribers: number;
+    title: string;
+    updated_at: string;
+}
+
+export interface ProblemListSummary {
+    created_at: string;
+    description: string;
+    hidden_reason: string;
+    id: string;
+    owner_id: string;
+    owner_username: string;
+    problem_count: number;
+    reports: number;
+    status: string;
+    subscribed: boolean;
+    subscribers: number;
+    title: string;
+    updated_at: string;
+}
+
+export interface ProblemPage {
+    prerequisites: ProblemResponse[];
+    problem: ProblemResponse;
+    seo: PageMetadata;
+}
+
+export interface ProblemPopularity {
+    completion_rate: number;
+    times_completed: number;
+    times_selected: number;
+}
+
+export interface ProblemPrerequisitesResponse {
+    count: number;
+    prerequisites: ProblemResponse[];
+    problem_id: string;
+}
+
+export interface ProblemResponse {
+    companies: string[];
+    custom: boolean;
+    difficulty: string;
+    id: string;
+    importance: number;
+    leetcode_url: string;
+    neetcode_url: string;
+    popularity: ProblemPopularity;
+    slug: string;
+    title: string;
+    topics: string[];
+}
+
+export interface ProblemSearchResponse {
+    count: number;
+    query: string;
+    results: ProblemSearchResult[];
+}
+
+export interface ProblemSearchResult {
+    highlights: SearchHighlight[];
+    problem: ProblemResponse;
+    score: number;
+}
+
+export interface ProblemStats {
+    by_difficulty: Record<string, number>;
+    by_topic: Record<string, number>;
+    total: number;
+}
+
+export interface ProctoringSummary {
+    assignment_id: string;
+    away_seconds: number;
+    contest_id: string;
+    events: ContestEvent[];
+    focus_losses: number;
+    longest_away_seconds: number;
+    status: string;
+    tab_switches: number;
+    truncated: boolean;
+    user_id: string;
+}
+
+export interface PublicProblemStats {
+    computed_at: string;
+    count: number;
+    problems: PopularProblem[];
+}
+
+export interface PublicStats {
+    completion_rate: number | null;
+    computed_at: string;
+    contest_sizes: ContestSizeCount[];
+    contests_finished: number;
+}
+
+export interface PutContestsIDTagsResponse {
+    tags: string[];
+}
+
+export interface QuickCommand {
+    duration_minutes: number;
+    problem: number;
+    problem_count: number;
+    verb: string;
+}
+
+export interface QuickCommandLog {
+    command: QuickCommand;
+    contest_id: string | null;
+    created_at: string;
+    error: string;
+    id: string;
+    input: string;
+}
+
+export interface QuickCommandRequest {
+    command: string;
+}
+
+export interface QuickHistory {
+    commands: QuickCommandLog[];
+}
+
+export interface QuickResult {
+    command: QuickCommand;
+    contest: ContestResponse;
+    message: string;
+}
+
+export interface QuotaStatus {
+    plan: string;
+    quotas: QuotaUsage[];
+    reason: string;
+    user_id: string;
+}
+
+export interface QuotaUsage {
+    kind: string;
+    limit: number | null;
+    overridden: boolean;
+    remaining: number | null;
+    resets_at: string | null;
+    used: number;
+}
+
+export interface RateContestRequest {
+    rating: number;
+}
+
+export interface RecordAttemptRequest {
+    outcome: string;
+}
+
+export interface RecordContestEventsRequest {
+    events: ContestEventInput[];
+}
+
+export interface RefreshRequest {
+    refresh_token: string;
+}
+
+export interface ReportProblemListRequest {
+    reason: string;
+}
+
+export interface RetentionReport {
+    dry_run: boolean;
+    finished_at: string;
+    results: RetentionResult[];
+    started_at: string;
+}
+
+export interface RetentionResult {
+    cutoff: string | null;
+    enabled: boolean;
+    found: number;
+    policy: string;
+    purged: number;
+}
+
+export interface ReviewItem {
+    confidence: number | null;
+    due: boolean;
+    due_at: string;
+    last_solved_at: string;
+    problem: ProblemResponse;
+}
+
+export interface ReviewQueue {
+    due: number;
+    reviews: ReviewItem[];
+}
+
+export interface ReviewSimilarityRequest {
+    note?: string;
+    status: string;
+}
+
+export interface RoadmapCategoryResponse {
+    completed: number | null;
+    id: string;
+    name: string;
+    position: number;
+    problems: RoadmapProblemResponse[];
+    total: number;
+}
+
+export interface RoadmapProblemResponse {
+    companies: string[];
+    custom: boolean;
+    difficulty: string;
+    id: string;
+    importance: number;
+    leetcode_url: string;
+    neetcode_url: string;
+    popularity: ProblemPopularity;
+    position: number;
+    slug: string;
+    solved: boolean | null;
+    title: string;
+    topics: string[];
+}
+
+export interface RoadmapResponse {
+    categories: RoadmapCategoryResponse[];
+    completed: number | null;
+    total: number;
+}
+
+export interface RunIntegrityRequest {
+    dry_run?: boolean;
+}
+
+export interface RunRetentionRequest {
+    dry_run?: boolean;
+}
+
+export interface SavedFilter {
+    companies: string[];
+    created_at: string;
+    difficulties: string[];
+    id: string;
+    name: string;
+    solved_state: string;
+    topics: string[];
+    updated_at: string;
+    user_id: string;
+}
+
+export interface SavedFilterRequest {
+    companies?: string[];
+    difficulties?: string[];
+    name: string;
+    solved_state?: string;
+    topics?: string[];
+}
+
+export interface SearchHighlight {
+    field: string;
+    index: number;
+    length: number;
+    start: number;
+}
+
+export interface SetContestTagsRequest {
+    tags?: string[];
+}
+
+export interface SetLogLevelRequest {
+    duration_minutes?: number;
+    level: string;
+}
+
+export interface SetMaintenanceRequest {
+    enabled: boolean | null;
+    ends_at?: string | null;
+    message?: string;
+    starts_at?: string | null;
+}
+
+export interface SetProblemCompaniesRequest {
+    companies?: string[];
+}
+
+export interface SetProblemComplexityRequest {
+    space_complexity?: string;
+    time_complexity?: string;
+}
+
+export interface SetProblemImportanceRequest {
+    importance: number;
+}
+
+export interface SetQuotaOverrideRequest {
+    contests_per_day?: number | null;
+    custom_problems?: number | null;
+    plan: string;
+    reason?: string;
+}
+
+export interface SignedContestTemplate {
+    signature: string;
+    template: ContestTemplate;
+}
+
+export interface SimilarityFlagDetail {
+    assignment_id: string;
+    contest_a_id: string;
+    contest_b_id: string;
+    detected_at: string;
+    id: string;
+    note: string;
+    problem_id: string;
+    problem_title: string;
+    reviewed_at: string | null;
+    reviewed_by: string | null;
+    similarity: number;
+    snippet_a: SolutionSnippet;
+    snippet_b: SolutionSnippet;
+    status: string;
+    user_a_id: string;
+    user_b_id: string;
+    username_a: string;
+    username_b: string;
+}
+
+export interface SimilarityFlagResponse {
+    assignment_id: string;
+    contest_a_id: string;
+    contest_b_id: string;
+    detected_at: string;
+    id: string;
+    note: string;
+    problem_id: string;
+    problem_title: string;
+    reviewed_at: string | null;
+    reviewed_by: string | null;
+    similarity: number;
+    status: string;
+    user_a_id: string;
+    user_b_id: string;
+    username_a: string;
+    username_b: string;
+}
+
+export interface SimilarityRunReport {
+    checked: number;
+    cleared: number;
+    compared: number;
+    flagged: number;
+}
+
+export interface SolutionSnippet {
+    code: string;
+    contest_id: string;
+    language: string;
+    problem_id: string;
+    updated_at: string;
+    user_id: string;
+}
+
+export interface SolveTiming {
+    average_seconds: number;
+    timed_solves: number;
+}
+
+export interface SpectatorInvite {
+    anonymize: boolean;
+    spectator_code: string;
+}
+
+export interface SpectatorInviteRequest {
+    anonymize?: boolean;
+}
+
+export interface SpectatorStanding {
+    name: string;
+    presence: string;
+    solved: number;
+    status: string;
+    total: number;
+}
+
+export interface SpectatorView {
+    anonymized: boolean;
+    participants: SpectatorStanding[];
+}
+
+export interface StateComplexityRequest {
+    space_complexity?: string;
+    time_complexity?: string;
+}
+
+export interface TagCount {
+    count: number;
+    tag: string;
+}
+
+export interface TemplateConflict {
+    difficulty: string;
+    expected: string;
+    reason: string;
+    slug: string;
+}
+
+export interface TemplateProblem {
+    difficulty?: string;
+    slug: string;
+    title?: string;
+}
+
+export interface Tenant {
+    admin_email: string;
+    created_at: string;
+    id: string;
+    name: string;
+    slug: string;
+}
+
+export interface TenantInfo {
+    id: string;
+    name: string;
+    slug: string;
+}
+
+export interface TokenPair {
+    access_token: string;
+    expires_at: string;
+    refresh_token: string;
+}
+
+export interface TopicContestStats {
+    appearances: number;
+    completed: number;
+    completion_rate: number;
+    contests: number;
+    topic: string;
+}
+
+export interface TopicStats {
+    solved: number;
+    total: number;
+}
+
+export interface UpdateContestSessionRequest {
+    current_problem_id?: string | null;
+    device?: string;
+    notes?: Record<string, string>;
+    version?: number;
+}
+
+export interface UpdateDigestRequest {
+    enabled: boolean | null;
+}
+
+export interface UpdateFeatureFlagRequest {
+    enabled: boolean | null;
+    rollout_percent?: number | null;
+}
+
+export interface UpdateRetroRequest {
+    retro?: string;
+}
+
+export interface UserCreateRequest {
+    email: string;
+    password: string;
+    username: string;
+}
+
+export interface UserProgress {
+    attempts: AttemptStats;
+    confidence: ConfidenceStats;
+    contest_stats: ContestStatistics;
+    easy_solved: number;
+    hard_solved: number;
+    last_active_at: string | null;
+    medium_solved: number;
+    solve_times: Record<string, SolveTiming>;
+    topic_progress: Record<string, TopicStats>;
+    total_solved: number;
+}
+
+export interface UserResponse {
+    created_at: string;
+    email: string;
+    id: string;
+    plan: string;
+    plan_renews_at: string | null;
+    role: string;
+    subscription_status: string;
+    username: string;
+}
+
+export interface VariantOutcome {
+    abandoned: number;
+    completed: number;
+    completion_rate: number;
+    contests: number;
+    problems_served: number;
+    problems_solved: number;
+    solve_rate: number;
+    variant: string;
+}
@@ -1,5 +1,7 @@
 import { Link, useLocation, useNavigate } from 'react-router-dom';
+import { useQuery } from '@tanstack/react-query';
 import { useAuthStore } from '@/stores/authStore';
+import { maintenanceApi } from '@/services/api';
 import {
     Home,
     Trophy,
@@ -8,7 +10,8 @@ import {
     LogOut,
     User,
     Menu,
-    X
+    X,
+    AlertTriangle
 } from 'lucide-react';
 import { useState } from 'react';
 import clsx from 'clsx';
@@ -23,8 +26,14 @@ export default function Layout({ children }: LayoutProps) {
     const { user, logout } = useAuthStore();
     const [isMobileMenuOpen, setIsMobileMenuOpen] = useState(false);
 
-    const handleLogout = () => {
-        logout();
+    const { data: maintenance } = useQuery({
+        queryKey: ['maintenance'],
+        queryFn: maintenanceApi.getStatus,
+        refetchInterval: 60000, // Pick up newly scheduled maintenance
+    });
+
+    const handleLogout = async () => {
+        await logout();
         navigate('/');
     };
 
@@ -132,6 +141,22 @@ export default function Layout({ children }: LayoutProps) {
                 )}
             </header>
 
+            {/* Maintenance Banner */}
+            {(maintenance?.active || maintenance?.upcoming) && (
+                <div className="bg-[var(--color-warning)]/15 border-b border-[var(--color-warning)]/40">
+                    <div className="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-3 flex items-center gap-3 text-sm">
+                        <AlertTriangle className="w-4 h-4 text-[var(--color-warning)] shrink-0" />
+                        <span>
+                            {maintenance.active
+                                ? 'Maintenance in progress: changes are paused.'
+                                : `Maintenance starts ${new Date(maintenance.starts_at!).toLocaleString()}.`}
+                            {maintenance.ends_at && ` Expected back by ${new Date(maintenance.ends_at).toLocaleString()}.`}
+                            {maintenance.message && ` ${maintenance.message}`}
+                        </span>
+                    </div>
+                </div>
+            )}
+
             {/* Main Content */}
             <main className="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-8">
                 {children}
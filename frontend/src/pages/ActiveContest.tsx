@@ -1,9 +1,9 @@
-import { useEffect } from 'react';
+import { useEffect, useState } from 'react';
 import { useNavigate, useParams } from 'react-router-dom';
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
-import { contestApi } from '@/services/api';
+import { contestApi, userApi } from '@/services/api';
 import { useTimer } from '@/hooks/useTimer';
-import type { Contest, ContestProblem } from '@/types';
+import type { AttemptHistory, ComplexityResult, Contest, ContestProblem } from '@/types';
 import {
     Clock,
     ExternalLink,
@@ -12,10 +12,15 @@ import {
     AlertTriangle,
     Trophy,
     XCircle,
-    Loader2
+    Loader2,
+    RotateCcw,
+    Timer
 } from 'lucide-react';
 import clsx from 'clsx';
 
+// Well inside the server's presence TTL, so one dropped heartbeat does not show you offline
+const HEARTBEAT_INTERVAL_MS = 20000;
+
 export default function ActiveContest() {
     const navigate = useNavigate();
     const { id } = useParams<{ id: string }>();
@@ -45,12 +50,55 @@ export default function ActiveContest() {
         },
     });
 
+    // Problem whose confidence and complexity prompt is open after completing it
+    const [promptProblemId, setPromptProblemId] = useState<string | null>(null);
+
+    // Failed attempts per problem logged in this session
+    const [failedAttempts, setFailedAttempts] = useState<Record<string, number>>({});
+
+    // Log a failed attempt mutation
+    const failedAttemptMutation = useMutation({
+        mutationFn: (problemId: string) => contestApi.recordAttempt(contest!.id, problemId, 'failed'),
+        onSuccess: (history: AttemptHistory) => {
+            setFailedAttempts((counts) => ({
+                ...counts,
+                [history.problem_id]: history.attempts.filter((a) => a.outcome === 'failed').length,
+            }));
+        },
+    });
+
+    // Start timing a problem when its LeetCode page is opened; the server stops the previous one
+    const startProblemMutation = useMutation({
+        mutationFn: (problemId: string) => contestApi.startProblem(contest!.id, problemId),
+        onSuccess: () => {
+            queryClient.invalidateQueries({ queryKey: id ? ['contest', id] : ['active-contest'] });
+        },
+    });
+
     // Mark problem complete mutation
     const markCompleteMutation = useMutation({
         mutationFn: ({ problemId, isCompleted }: { problemId: string; isCompleted: boolean }) =>
             contestApi.markProblemComplete(contest!.id, problemId, isCompleted),
+        onSuccess: (_, { problemId, isCompleted }) => {
+            setPromptProblemId(isCompleted ? problemId : null);
+            queryClient.invalidateQueries({ queryKey: id ? ['contest', id] : ['active-contest'] });
+        },
+    });
+
+    // Rate the solve and state its complexity, both optional
+    const complexityMutation = useMutation({
+        mutationFn: async ({ problemId, confidence, time, space }: { problemId: string; confidence?: number; time: string; space: string }) => {
+            if (confidence) {
+                await contestApi.markProblemComplete(contest!.id, problemId, true, confidence);
+            }
+            if (time || space) {
+                await contestApi.stateComplexity(contest!.id, problemId, { time_complexity: time, space_complexity: space });
+            }
+        },
         onSuccess: () => {
+            setPromptProblemId(null);
             queryClient.invalidateQueries({ queryKey: id ? ['contest', id] : ['active-contest'] });
+            queryClient.invalidateQueries({ queryKey: ['user-progress'] });
         },
     });
 
@@ -73,6 +121,18 @@ export default function ActiveContest() {
         },
     });
 
+    // Heartbeat while the contest is open so challenge opponents see you competing
+    const activeContestId = contest?.status === 'active' ? contest.id : undefined;
+    useEffect(() => {
+        if (!activeContestId) {
+            return;
+        }
+        const beat = () => userApi.heartbeat(activeContestId).catch(() => undefined);
+        beat();
+        const interval = setInterval(beat, HEARTBEAT_INTERVAL_MS);
+        return () => clearInterval(interval);
+    }, [activeContestId]);
+
     // Redirect if no active contest
     useEffect(() => {
         if (!isLoading && !contest && !id) {
@@ -92,8 +152,9 @@ export default function ActiveContest() {
         return null;
     }
 
-    const completedCount = contest.problems.filter(p => p.is_completed).length;
-    const totalCount = contest.problems.length;
+    const scored = contest.problems.filter(p => !p.is_warmup);
+    const completedCount = scored.filter(p => p.is_completed).length;
+    const totalCount = scored.length;
     const isActive = contest.status === 'active' && !isExpired;
 
     // Timer color based on remaining time
@@ -188,11 +249,10 @@ export default function ActiveContest() {
             <div className="space-y-4 mb-8">
                 {contest.problems
                     .sort((a, b) => a.order - b.order)
-                    .map((contestProblem, index) => (
+                    .map((contestProblem) => (
                         <ProblemCard
                             key={contestProblem.problem.id}
                             contestProblem={contestProblem}
-                            index={index}
                             isActive={isActive}
                             onToggle={(isCompleted) =>
                                 markCompleteMutation.mutate({
@@ -201,6 +261,19 @@ export default function ActiveContest() {
                                 })
                             }
                             isLoading={markCompleteMutation.isPending}
+                            showPrompt={promptProblemId === contestProblem.problem.id}
+                            onStateComplexity={(confidence, time, space) =>
+                                complexityMutation.mutate({
+                                    problemId: contestProblem.problem.id,
+                                    confidence,
+                                    time,
+                                    space,
+                                })
+                            }
+                            onDismissPrompt={() => setPromptProblemId(null)}
+                            failedAttempts={failedAttempts[contestProblem.problem.id] ?? 0}
+                            onFailedAttempt={() => failedAttemptMutation.mutate(contestProblem.problem.id)}
+                            onStart={() => startProblemMutation.mutate(contestProblem.problem.id)}
                         />
                     ))}
             </div>
@@ -256,6 +329,11 @@ export default function ActiveContest() {
                     <div className="text-[var(--color-text-muted)]">
                         You completed {completedCount} out of {totalCount} problems
                     </div>
+                    {contest.complexity_score && contest.complexity_score.graded > 0 && (
+                        <div className="text-sm text-[var(--color-text-muted)] mt-1">
+                            Complexity analysis: {contest.complexity_score.correct} of {contest.complexity_score.graded} correct
+                        </div>
+                    )}
                 </div>
             )}
         </div>
@@ -265,14 +343,20 @@ export default function ActiveContest() {
 // Problem Card Component
 interface ProblemCardProps {
     contestProblem: ContestProblem;
-    index: number;
     isActive: boolean;
     onToggle: (isCompleted: boolean) => void;
     isLoading: boolean;
+    showPrompt: boolean;
+    onStateComplexity: (confidence: number | undefined, time: string, space: string) => void;
+    onDismissPrompt: () => void;
+    failedAttempts: number;
+    onFailedAttempt: () => void;
+    onStart: () => void;
 }
 
-function ProblemCard({ contestProblem, index, isActive, onToggle, isLoading }: ProblemCardProps) {
-    const { problem, is_completed } = contestProblem;
+function ProblemCard({ contestProblem, isActive, onToggle, isLoading, showPrompt, onStateComplexity, onDismissPrompt, failedAttempts, onFailedAttempt, onStart }: ProblemCardProps) {
+    const { problem, is_completed, is_warmup, complexity, time_spent_seconds, timer_running } = contestProblem;
+    const timeable = isActive && !is_completed && !is_warmup;
 
     const difficultyClass = {
         Easy: 'badge-easy',
@@ -283,64 +367,176 @@ function ProblemCard({ contestProblem, index, isActive, onToggle, isLoading }: P
     return (
         <div
             className={clsx(
-                'card flex items-center gap-4 transition-all',
+                'card transition-all',
                 is_completed && 'bg-[var(--color-success)]/5 border-[var(--color-success)]/20'
             )}
         >
-            {/* Completion Toggle */}
-            <button
-                onClick={() => isActive && onToggle(!is_completed)}
-                disabled={!isActive || isLoading}
-                className={clsx(
-                    'w-8 h-8 rounded-full flex items-center justify-center transition-all',
-                    is_completed
-                        ? 'bg-[var(--color-success)] text-white'
-                        : 'bg-[var(--color-surface-hover)] text-[var(--color-text-muted)] hover:text-white',
-                    !isActive && 'cursor-not-allowed opacity-50'
-                )}
-            >
-                {is_completed ? (
-                    <CheckCircle2 className="w-5 h-5" />
-                ) : (
-                    <Circle className="w-5 h-5" />
-                )}
-            </button>
+            <div className="flex items-center gap-4">
+                {/* Completion Toggle */}
+                <button
+                    onClick={() => isActive && onToggle(!is_completed)}
+                    disabled={!isActive || isLoading}
+                    className={clsx(
+                        'w-8 h-8 rounded-full flex items-center justify-center transition-all',
+                        is_completed
+                            ? 'bg-[var(--color-success)] text-white'
+                            : 'bg-[var(--color-surface-hover)] text-[var(--color-text-muted)] hover:text-white',
+                        !isActive && 'cursor-not-allowed opacity-50'
+                    )}
+                >
+                    {is_completed ? (
+                        <CheckCircle2 className="w-5 h-5" />
+                    ) : (
+                        <Circle className="w-5 h-5" />
+                    )}
+                </button>
 
-            {/* Problem Number */}
-            <div className="w-8 h-8 rounded-lg bg-[var(--color-surface-hover)] flex items-center justify-center font-semibold text-sm">
-                {index + 1}
-            </div>
+                {/* Problem Number */}
+                <div className="w-8 h-8 rounded-lg bg-[var(--color-surface-hover)] flex items-center justify-center font-semibold text-sm">
+                    {is_warmup ? 'W' : contestProblem.order}
+                </div>
 
-            {/* Problem Info */}
-            <div className="flex-1 min-w-0">
-                <h3 className={clsx(
-                    'font-medium truncate',
-                    is_completed && 'line-through text-[var(--color-text-muted)]'
-                )}>
-                    {problem.title}
-                </h3>
-                <div className="flex items-center gap-2 mt-1">
-                    <span className={clsx('badge', difficultyClass)}>
-                        {problem.difficulty}
-                    </span>
-                    <span className="text-xs text-[var(--color-text-muted)]">
-                        {problem.topics[0]}
-                    </span>
+                {/* Problem Info */}
+                <div className="flex-1 min-w-0">
+                    <h3 className={clsx(
+                        'font-medium truncate',
+                        is_completed && 'line-through text-[var(--color-text-muted)]'
+                    )}>
+                        {problem.title}
+                    </h3>
+                    <div className="flex items-center gap-2 mt-1">
+                        <span className={clsx('badge', difficultyClass)}>
+                            {problem.difficulty}
+                        </span>
+                        {is_warmup && (
+                            <span className="text-xs text-[var(--color-text-muted)]">
+                                Warm-up · not scored
+                            </span>
+                        )}
+                        <span className="text-xs text-[var(--color-text-muted)]">
+                            {problem.topics[0]}
+                        </span>
+                    </div>
+                </div>
+
+                {/* External Links */}
+                <div className="flex items-center gap-2">
+                    {(time_spent_seconds > 0 || timer_running) && (
+                        <span
+                            className={clsx(
+                                'flex items-center gap-1 text-xs',
+                                timer_running ? 'text-[var(--color-primary)]' : 'text-[var(--color-text-muted)]'
+                            )}
+                            title={timer_running ? 'Timing this problem' : 'Time spent'}
+                        >
+                            <Timer className="w-3 h-3" />
+                            {Math.floor(time_spent_seconds / 60)}m
+                        </span>
+                    )}
+                    {failedAttempts > 0 && (
+                        <span className="text-xs text-[var(--color-text-muted)]">
+                            {failedAttempts} failed
+                        </span>
+                    )}
+                    {timeable && (
+                        <button
+                            onClick={onFailedAttempt}
+                            className="btn btn-ghost p-2"
+                            title="Log a failed attempt"
+                        >
+                            <RotateCcw className="w-4 h-4" />
+                        </button>
+                    )}
+                    <a
+                        href={problem.leetcode_url}
+                        target="_blank"
+                        rel="noopener noreferrer"
+                        onClick={() => timeable && !timer_running && onStart()}
+                        className="btn btn-ghost p-2"
+                        title="Open in LeetCode"
+                    >
+                        <ExternalLink className="w-4 h-4" />
+                    </a>
                 </div>
             </div>
 
-            {/* External Links */}
-            <div className="flex items-center gap-2">
-                <a
-                    href={problem.leetcode_url}
-                    target="_blank"
-                    rel="noopener noreferrer"
-                    className="btn btn-ghost p-2"
-                    title="Open in LeetCode"
+            {showPrompt && is_completed && (
+                <ComplexityPrompt onSave={onStateComplexity} onSkip={onDismissPrompt} />
+            )}
+            {!showPrompt && complexity && <ComplexityLine complexity={complexity} />}
+        </div>
+    );
+}
+
+// Optional prompt for confidence and the complexity of the solution, shown right after solving
+function ComplexityPrompt({ onSave, onSkip }: { onSave: (confidence: number | undefined, time: string, space: string) => void; onSkip: () => void }) {
+    const [confidence, setConfidence] = useState<number>();
+    const [time, setTime] = useState('');
+    const [space, setSpace] = useState('');
+
+    return (
+        <form
+            className="mt-4 flex flex-wrap items-center gap-2"
+            onSubmit={(e) => {
+                e.preventDefault();
+                onSave(confidence, time, space);
+            }}
+        >
+            <span className="text-sm text-[var(--color-text-muted)]">How confident are you?</span>
+            {[1, 2, 3, 4, 5].map((rating) => (
+                <button
+                    key={rating}
+                    type="button"
+                    className={clsx('btn w-9 p-2', confidence === rating ? 'btn-primary' : 'btn-ghost')}
+                    onClick={() => setConfidence(rating)}
                 >
-                    <ExternalLink className="w-4 h-4" />
-                </a>
-            </div>
+                    {rating}
+                </button>
+            ))}
+            <span className="text-sm text-[var(--color-text-muted)]">Complexity?</span>
+            <input
+                className="input w-28"
+                placeholder="Time, O(n)"
+                maxLength={32}
+                value={time}
+                onChange={(e) => setTime(e.target.value)}
+            />
+            <input
+                className="input w-28"
+                placeholder="Space, O(1)"
+                maxLength={32}
+                value={space}
+                onChange={(e) => setSpace(e.target.value)}
+            />
+            <button type="submit" className="btn btn-primary" disabled={!confidence && !time && !space}>
+                Save
+            </button>
+            <button type="button" className="btn btn-ghost" onClick={onSkip}>
+                Skip
+            </button>
+        </form>
+    );
+}
+
+// Stated complexity, with the expected answer once the contest is over
+function ComplexityLine({ complexity }: { complexity: ComplexityResult }) {
+    const verdict = (correct: boolean | null) =>
+        correct === null ? '' : correct ? ' ✓' : ' ✗';
+
+    return (
+        <div className="mt-3 text-xs text-[var(--color-text-muted)] flex flex-wrap gap-4">
+            {complexity.time_complexity && (
+                <span>
+                    Time {complexity.time_complexity}{verdict(complexity.time_correct)}
+                    {complexity.time_correct === false && ` (expected ${complexity.expected_time_complexity})`}
+                </span>
+            )}
+            {complexity.space_complexity && (
+                <span>
+                    Space {complexity.space_complexity}{verdict(complexity.space_correct)}
+                    {complexity.space_correct === false && ` (expected ${complexity.expected_space_complexity})`}
+                </span>
+            )}
         </div>
     );
 }
@@ -17,11 +17,11 @@ import clsx from 'clsx';
 export default function ContestHistory() {
     const { data, isLoading } = useQuery<{ contests: Contest[] }>({
         queryKey: ['contests'],
-        queryFn: contestApi.getAll,
+        queryFn: () => contestApi.getAll(),
     });
 
     const contests = data?.contests ?? [];
-    const pastContests = contests.filter(c => c.status !== 'active');
+    const pastContests = contests.filter(c => c.status === 'completed' || c.status === 'abandoned');
 
     if (isLoading) {
         return (
@@ -83,7 +83,7 @@ export default function ContestHistory() {
                         <div>
                             <div className="text-2xl font-bold">
                                 {pastContests.reduce((acc, c) =>
-                                    acc + c.problems.filter(p => p.is_completed).length, 0
+                                    acc + c.problems.filter(p => p.is_completed && !p.is_warmup).length, 0
                                 )}
                             </div>
                             <div className="text-sm text-[var(--color-text-muted)]">
@@ -126,8 +126,9 @@ export default function ContestHistory() {
 
 // Contest Card Component
 function ContestCard({ contest }: { contest: Contest }) {
-    const completedCount = contest.problems.filter(p => p.is_completed).length;
-    const totalCount = contest.problems.length;
+    const scored = contest.problems.filter(p => !p.is_warmup);
+    const completedCount = scored.filter(p => p.is_completed).length;
+    const totalCount = scored.length;
     const completionRate = Math.round((completedCount / totalCount) * 100);
 
     const isCompleted = contest.status === 'completed';
@@ -12,9 +12,16 @@ import {
     Info
 } from 'lucide-react';
 import clsx from 'clsx';
+import type { ContestOrdering } from '@/types';
 
 const PROBLEM_COUNTS = [3, 5, 7, 10];
 const DURATIONS = [30, 60, 90, 120]; // in minutes
+const ORDERINGS: { value: ContestOrdering; label: string }[] = [
+    { value: 'ascending', label: 'Easy → Hard' },
+    { value: 'descending', label: 'Hard → Easy' },
+    { value: 'interleaved', label: 'Interleaved' },
+    { value: 'shuffled', label: 'Shuffled' },
+];
 
 export default function CreateContest() {
     const navigate = useNavigate();
@@ -22,6 +29,12 @@ export default function CreateContest() {
 
     const [problemCount, setProblemCount] = useState(5);
     const [duration, setDuration] = useState(60);
+    const [ordering, setOrdering] = useState<ContestOrdering>('ascending');
+    const [respectPrerequisites, setRespectPrerequisites] = useState(false);
+    const [fromRoadmap, setFromRoadmap] = useState(false);
+    const [favorImportant, setFavorImportant] = useState(false);
+    const [includeCustom, setIncludeCustom] = useState(false);
+    const [solvedWarmup, setSolvedWarmup] = useState(false);
     const [showAdvanced, setShowAdvanced] = useState(false);
     const [error, setError] = useState('');
 
@@ -29,14 +42,20 @@ export default function CreateContest() {
         mutationFn: () => contestApi.create({
             problem_count: problemCount,
             duration_minutes: duration,
+            ordering,
+            respect_prerequisites: respectPrerequisites,
+            source: fromRoadmap ? 'roadmap' : 'random',
+            weighting: favorImportant ? 'importance' : 'uniform',
+            include_custom: includeCustom,
+            solved_warmup: solvedWarmup,
         }),
         onSuccess: () => {
             queryClient.invalidateQueries({ queryKey: ['active-contest'] });
             navigate('/contest/active');
         },
         onError: (err: any) => {
-            if (err.response?.data?.error) {
-                setError(err.response.data.error);
+            if (err.response?.data?.error?.message) {
+                setError(err.response.data.error.message);
             } else {
                 setError('Failed to create contest. Please try again.');
             }
@@ -189,7 +208,7 @@ export default function CreateContest() {
                             />
                             <span className="text-[var(--color-text-muted)]">problems</span>
                         </div>
-                        <div className="flex items-center gap-2">
+                        <div className="flex items-center gap-2 mb-4">
                             <input
                                 type="number"
                                 min="5"
@@ -200,6 +219,68 @@ export default function CreateContest() {
                             />
                             <span className="text-[var(--color-text-muted)]">minutes</span>
                         </div>
+                        <div className="flex items-center gap-2">
+                            <select
+                                value={ordering}
+                                onChange={(e) => setOrdering(e.target.value as ContestOrdering)}
+                                className="input w-48"
+                            >
+                                {ORDERINGS.map((o) => (
+                                    <option key={o.value} value={o.value}>{o.label}</option>
+                                ))}
+                            </select>
+                            <span className="text-[var(--color-text-muted)]">problem order</span>
+                        </div>
+                        <label className="flex items-center gap-2 mt-4 cursor-pointer">
+                            <input
+                                type="checkbox"
+                                checked={respectPrerequisites}
+                                onChange={(e) => setRespectPrerequisites(e.target.checked)}
+                            />
+                            <span className="text-[var(--color-text-muted)]">
+                                Only include problems whose prerequisites I've solved
+                            </span>
+                        </label>
+                        <label className="flex items-center gap-2 mt-2 cursor-pointer">
+                            <input
+                                type="checkbox"
+                                checked={fromRoadmap}
+                                onChange={(e) => setFromRoadmap(e.target.checked)}
+                            />
+                            <span className="text-[var(--color-text-muted)]">
+                                Next unsolved problems from my roadmap
+                            </span>
+                        </label>
+                        <label className="flex items-center gap-2 mt-2 cursor-pointer">
+                            <input
+                                type="checkbox"
+                                checked={favorImportant}
+                                onChange={(e) => setFavorImportant(e.target.checked)}
+                            />
+                            <span className="text-[var(--color-text-muted)]">
+                                Favor frequently asked problems
+                            </span>
+                        </label>
+                        <label className="flex items-center gap-2 mt-2 cursor-pointer">
+                            <input
+                                type="checkbox"
+                                checked={includeCustom}
+                                onChange={(e) => setIncludeCustom(e.target.checked)}
+                            />
+                            <span className="text-[var(--color-text-muted)]">
+                                Include my custom problems
+                            </span>
+                        </label>
+                        <label className="flex items-center gap-2 mt-2 cursor-pointer">
+                            <input
+                                type="checkbox"
+                                checked={solvedWarmup}
+                                onChange={(e) => setSolvedWarmup(e.target.checked)}
+                            />
+                            <span className="text-[var(--color-text-muted)]">
+                                Start with an easy problem I've already solved as an unscored warm-up
+                            </span>
+                        </label>
                     </div>
                 )}
 
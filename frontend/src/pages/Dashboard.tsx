@@ -11,7 +11,7 @@ import {
     TrendingUp,
     Zap
 } from 'lucide-react';
-import type { UserProgress, Contest } from '@/types';
+import type { UserProgress, Contest, ReviewQueue } from '@/types';
 
 export default function Dashboard() {
     const user = useAuthStore((state) => state.user);
@@ -26,7 +26,13 @@ export default function Dashboard() {
         queryFn: contestApi.getActive,
     });
 
+    const { data: reviewQueue } = useQuery<ReviewQueue>({
+        queryKey: ['review-queue'],
+        queryFn: () => userApi.getReviews({ due_only: true, limit: 5 }),
+    });
+
     const activeContest = activeContestData?.contest;
+    const confidence = progress?.confidence;
 
     // Calculate solved percentages
     const totalProblems = 150;
@@ -210,6 +216,66 @@ export default function Dashboard() {
                         );
                     })}
                 </div>
+                {progress && progress.attempts.solved_problems > 0 && (
+                    <p className="text-sm text-[var(--color-text-muted)] mt-6">
+                        Solved on the first attempt: {progress.attempts.solved_on_first_attempt} of{' '}
+                        {progress.attempts.solved_problems} ({Math.round(progress.attempts.first_attempt_rate * 100)}%)
+                    </p>
+                )}
+            </div>
+
+            {/* Confidence and Reviews */}
+            <div className="grid grid-cols-1 md:grid-cols-2 gap-6">
+                <div className="card">
+                    <h2 className="text-lg font-semibold mb-1">Confidence</h2>
+                    <p className="text-sm text-[var(--color-text-muted)] mb-6">
+                        {confidence && confidence.rated > 0
+                            ? `Average ${confidence.average.toFixed(1)} across ${confidence.rated} rated solves`
+                            : 'Rate your solves to see how sure you are'}
+                    </p>
+                    <div className="flex items-end gap-3 h-24">
+                        {[1, 2, 3, 4, 5].map((rating) => {
+                            const count = confidence?.distribution[rating] ?? 0;
+                            const height = confidence && confidence.rated > 0 ? (count / confidence.rated) * 100 : 0;
+                            return (
+                                <div key={rating} className="flex-1 flex flex-col items-center gap-2 h-full justify-end">
+                                    <div
+                                        className="w-full rounded-t bg-[var(--color-primary)] transition-all duration-500"
+                                        style={{ height: `${height}%` }}
+                                        title={`${count} solves`}
+                                    />
+                                    <span className="text-xs text-[var(--color-text-muted)]">{rating}</span>
+                                </div>
+                            );
+                        })}
+                    </div>
+                </div>
+
+                <div className="card">
+                    <h2 className="text-lg font-semibold mb-1">Due for Review</h2>
+                    <p className="text-sm text-[var(--color-text-muted)] mb-4">
+                        {reviewQueue?.due
+                            ? `${reviewQueue.due} solved problems are due`
+                            : 'Nothing to review right now'}
+                    </p>
+                    <ul className="space-y-2">
+                        {reviewQueue?.reviews.map((review) => (
+                            <li key={review.problem.id} className="flex items-center justify-between text-sm">
+                                <a
+                                    href={review.problem.leetcode_url}
+                                    target="_blank"
+                                    rel="noopener noreferrer"
+                                    className="truncate hover:text-[var(--color-primary)]"
+                                >
+                                    {review.problem.title}
+                                </a>
+                                <span className="text-xs text-[var(--color-text-muted)]">
+                                    {review.confidence ? `Confidence ${review.confidence}` : 'Unrated'}
+                                </span>
+                            </li>
+                        ))}
+                    </ul>
+                </div>
             </div>
 
             {/* Quick Actions */}
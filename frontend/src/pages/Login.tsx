@@ -21,8 +21,8 @@ export default function Login() {
             await login(email, password);
             navigate('/dashboard');
         } catch (err) {
-            if (err instanceof AxiosError && err.response?.data?.error) {
-                setError(err.response.data.error);
+            if (err instanceof AxiosError && err.response?.data?.error?.message) {
+                setError(err.response.data.error.message);
             } else {
                 setError('An unexpected error occurred. Please try again.');
             }
@@ -34,8 +34,8 @@ export default function Signup() {
             await signup(email, username, password);
             navigate('/dashboard');
         } catch (err) {
-            if (err instanceof AxiosError && err.response?.data?.error) {
-                setError(err.response.data.error);
+            if (err instanceof AxiosError && err.response?.data?.error?.message) {
+                setError(err.response.data.error.message);
             } else {
                 setError('An unexpected error occurred. Please try again.');
             }
@@ -1,5 +1,5 @@
 import axios, { AxiosError, InternalAxiosRequestConfig } from 'axios';
-import type { ApiError } from '@/types';
+import type { ApiError, AttemptOutcome, CreateContestRequest, CustomProblemRequest, FeaturesResponse, MaintenanceStatus, ProblemListQuery, SavedFilterRequest } from '@/types';
 
 const API_BASE_URL = import.meta.env.VITE_API_URL || '/api';
 
@@ -133,6 +133,16 @@ export const authApi = {
         const response = await api.post('/auth/refresh', { refresh_token: refreshToken });
         return response.data;
     },
+
+    logout: async (refreshToken?: string) => {
+        const response = await api.post('/auth/logout', refreshToken ? { refresh_token: refreshToken } : {});
+        return response.data;
+    },
+
+    logoutAll: async () => {
+        const response = await api.post('/auth/logout-all');
+        return response.data;
+    },
 };
 
 export const userApi = {
@@ -145,11 +155,93 @@ export const userApi = {
         const response = await api.get('/users/me/progress');
         return response.data;
     },
+
+    heartbeat: async (contestId?: string) => {
+        const response = await api.post('/users/me/heartbeat', { contest_id: contestId });
+        return response.data;
+    },
+
+    getAttempts: async (problemId: string) => {
+        const response = await api.get(`/users/me/attempts/${problemId}`);
+        return response.data;
+    },
+
+    getReviews: async (params: { due_only?: boolean; limit?: number } = {}) => {
+        const response = await api.get('/users/me/reviews', { params });
+        return response.data;
+    },
+
+    getFilters: async () => {
+        const response = await api.get('/users/me/filters');
+        return response.data;
+    },
+
+    createFilter: async (data: SavedFilterRequest) => {
+        const response = await api.post('/users/me/filters', data);
+        return response.data;
+    },
+
+    updateFilter: async (filterId: string, data: SavedFilterRequest) => {
+        const response = await api.put(`/users/me/filters/${filterId}`, data);
+        return response.data;
+    },
+
+    deleteFilter: async (filterId: string) => {
+        const response = await api.delete(`/users/me/filters/${filterId}`);
+        return response.data;
+    },
+
+    getCustomProblems: async () => {
+        const response = await api.get('/users/me/problems');
+        return response.data;
+    },
+
+    createCustomProblem: async (data: CustomProblemRequest) => {
+        const response = await api.post('/users/me/problems', data);
+        return response.data;
+    },
+
+    updateCustomProblem: async (problemId: string, data: CustomProblemRequest) => {
+        const response = await api.put(`/users/me/problems/${problemId}`, data);
+        return response.data;
+    },
+
+    deleteCustomProblem: async (problemId: string) => {
+        const response = await api.delete(`/users/me/problems/${problemId}`);
+        return response.data;
+    },
+
+    getFeatures: async (): Promise<FeaturesResponse> => {
+        const response = await api.get('/users/me/features');
+        return response.data;
+    },
 };
 
-export const problemApi = {
+export const companyApi = {
     getAll: async () => {
-        const response = await api.get('/problems');
+        const response = await api.get('/companies');
+        return response.data;
+    },
+};
+
+export const maintenanceApi = {
+    getStatus: async (): Promise<MaintenanceStatus> => {
+        const response = await api.get('/maintenance');
+        return response.data;
+    },
+};
+
+export const roadmapApi = {
+    get: async () => {
+        const response = await api.get('/roadmap');
+        return response.data;
+    },
+};
+
+export const problemApi = {
+    getAll: async (query: ProblemListQuery = {}) => {
+        // Repeat array params (difficulty=Easy&difficulty=Medium) as the API expects
+        const response = await api.get('/problems', { params: query, paramsSerializer: { indexes: null } });
         return response.data;
     },
 
@@ -162,16 +254,21 @@ export const problemApi = {
         const response = await api.get(`/problems/${id}`);
         return response.data;
     },
+
+    getPrerequisites: async (id: string) => {
+        const response = await api.get(`/problems/${id}/prerequisites`);
+        return response.data;
+    },
 };
 
 export const contestApi = {
-    create: async (data: { problem_count: number; duration_minutes: number }) => {
+    create: async (data: CreateContestRequest) => {
         const response = await api.post('/contests', data);
         return response.data;
     },
 
-    getAll: async () => {
-        const response = await api.get('/contests');
+    getAll: async (filter: { q?: string; tag?: string } = {}) => {
+        const response = await api.get('/contests', { params: filter });
         return response.data;
     },
 
@@ -185,13 +282,56 @@ export const contestApi = {
         return response.data;
     },
 
-    markProblemComplete: async (contestId: string, problemId: string, isCompleted: boolean) => {
+    markProblemComplete: async (contestId: string, problemId: string, isCompleted: boolean, confidence?: number) => {
         const response = await api.patch(`/contests/${contestId}/problems/${problemId}`, {
             is_completed: isCompleted,
+            confidence,
+        });
+        return response.data;
+    },
+
+    stateComplexity: async (contestId: string, problemId: string, complexity: { time_complexity: string; space_complexity: string }) => {
+        const response = await api.put(`/contests/${contestId}/problems/${problemId}/complexity`, complexity);
+        return response.data;
+    },
+
+    recordAttempt: async (contestId: string, problemId: string, outcome: AttemptOutcome) => {
+        const response = await api.post(`/contests/${contestId}/problems/${problemId}/attempts`, { outcome });
+        return response.data;
+    },
+
+    startProblem: async (contestId: string, problemId: string) => {
+        const response = await api.post(`/contests/${contestId}/problems/${problemId}/start`);
+        return response.data;
+    },
+
+    markWarmupComplete: async (contestId: string, isCompleted: boolean) => {
+        const response = await api.patch(`/contests/${contestId}/warmup`, {
+            is_completed: isCompleted,
         });
         return response.data;
     },
 
+    start: async (id: string) => {
+        const response = await api.post(`/contests/${id}/start`);
+        return response.data;
+    },
+
+    setTags: async (id: string, tags: string[]) => {
+        const response = await api.put(`/contests/${id}/tags`, { tags });
+        return response.data;
+    },
+
+    suggestTags: async (prefix: string) => {
+        const response = await api.get('/contests/tags', { params: { prefix } });
+        return response.data;
+    },
+
+    updateRetro: async (id: string, retro: string) => {
+        const response = await api.patch(`/contests/${id}/retro`, { retro });
+        return response.data;
+    },
+
     complete: async (id: string) => {
         const response = await api.post(`/contests/${id}/complete`);
         return response.data;
@@ -201,4 +341,56 @@ export const contestApi = {
         const response = await api.post(`/contests/${id}/abandon`);
         return response.data;
     },
+
+    challenge: async (id: string, silentMode = false) => {
+        const response = await api.post(`/contests/${id}/challenge`, { silent_mode: silentMode });
+        return response.data;
+    },
+};
+
+export const challengeApi = {
+    get: async (code: string) => {
+        const response = await api.get(`/challenges/${code}`);
+        return response.data;
+    },
+
+    accept: async (code: string) => {
+        const response = await api.post(`/challenges/${code}/accept`);
+        return response.data;
+    },
+
+    getComparison: async (code: string) => {
+        const response = await api.get(`/challenges/${code}/comparison`);
+        return response.data;
+    },
+
+    getStandings: async (code: string) => {
+        const response = await api.get(`/challenges/${code}/standings`);
+        return response.data;
+    },
+
+    openSpectators: async (code: string, anonymize: boolean) => {
+        const response = await api.post(`/challenges/${code}/spectators`, { anonymize });
+        return response.data;
+    },
+
+    closeSpectators: async (code: string) => {
+        const response = await api.delete(`/challenges/${code}/spectators`);
+        return response.data;
+    },
+
+    getChat: async (code: string, params: { since?: string; limit?: number } = {}) => {
+        const response = await api.get(`/challenges/${code}/chat`, { params });
+        return response.data;
+    },
+
+    postChat: async (code: string, body: string) => {
+        const response = await api.post(`/challenges/${code}/chat`, { body });
+        return response.data;
+    },
+
+    spectate: async (spectatorCode: string) => {
+        const response = await api.get(`/spectate/${spectatorCode}`);
+        return response.data;
+    },
 };
@@ -1,7 +1,7 @@
 import { create } from 'zustand';
 import { persist } from 'zustand/middleware';
 import type { User } from '@/types';
-import { setStoredTokens, clearStoredTokens, authApi } from '@/services/api';
+import { getStoredTokens, setStoredTokens, clearStoredTokens, authApi } from '@/services/api';
 
 interface AuthState {
     user: User | null;
@@ -10,7 +10,7 @@ interface AuthState {
 
     login: (email: string, password: string) => Promise<void>;
     signup: (email: string, username: string, password: string) => Promise<void>;
-    logout: () => void;
+    logout: () => Promise<void>;
     setUser: (user: User) => void;
 }
 
@@ -59,9 +59,19 @@ export const useAuthStore = create<AuthState>()(
                 }
             },
 
-            logout: () => {
-                clearStoredTokens();
-                set({ user: null, isAuthenticated: false });
+            logout: async () => {
+                // Revoke the session server-side; signing out locally must not depend on it
+                const tokens = getStoredTokens();
+                try {
+                    if (tokens) {
+                        await authApi.logout(tokens.refreshToken);
+                    }
+                } catch {
+                    // Tokens that are already invalid need no revocation
+                } finally {
+                    clearStoredTokens();
+                    set({ user: null, isAuthenticated: false });
+                }
             },
 
             setUser: (user: User) => {
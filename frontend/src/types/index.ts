@@ -3,7 +3,11 @@ export interface User {
     id: string;
     email: string;
     username: string;
+    role: 'user' | 'admin';
+    plan: 'free' | 'premium';
     created_at: string;
+    subscription_status?: string;
+    plan_renews_at?: string;
 }
 
 export interface UserProgress {
@@ -13,6 +17,66 @@ export interface UserProgress {
     hard_solved: number;
     topic_progress: Record<string, TopicStats>;
     contest_stats: ContestStats;
+    confidence: ConfidenceStats;
+    attempts: AttemptStats;
+    solve_times: Partial<Record<Difficulty, SolveTiming>>;
+    last_active_at: string | null;
+}
+
+// Timed contest solves of one difficulty
+export interface SolveTiming {
+    timed_solves: number;
+    average_seconds: number;
+}
+
+// Attempts across all problems
+export interface AttemptStats {
+    total_attempts: number;
+    failed_attempts: number;
+    solved_problems: number;
+    solved_on_first_attempt: number;
+    first_attempt_rate: number;
+}
+
+export type AttemptOutcome = 'failed' | 'solved';
+
+export interface Attempt {
+    id: string;
+    problem_id: string;
+    contest_id: string | null;
+    outcome: AttemptOutcome;
+    duration_seconds: number;
+    attempted_at: string;
+}
+
+// A user's attempts at one problem, oldest first
+export interface AttemptHistory {
+    problem_id: string;
+    attempts: Attempt[];
+    solved: boolean;
+    solved_on_first_attempt: boolean;
+}
+
+// Confidence ratings (1-5) of solved problems
+export interface ConfidenceStats {
+    rated: number;
+    unrated: number;
+    average: number;
+    distribution: Record<string, number>;
+}
+
+// Solved problem in the spaced-repetition queue
+export interface ReviewItem {
+    problem: Problem;
+    confidence: number | null;
+    last_solved_at: string;
+    due_at: string;
+    due: boolean;
+}
+
+export interface ReviewQueue {
+    reviews: ReviewItem[];
+    due: number;
 }
 
 export interface TopicStats {
@@ -60,6 +124,80 @@ export interface Problem {
     topics: string[];
     leetcode_url: string;
     neetcode_url: string;
+    companies?: string[];
+    importance: number;
+    custom?: boolean;
+}
+
+export interface CustomProblemRequest {
+    title: string;
+    url: string;
+    difficulty: Difficulty;
+    topics?: string[];
+}
+
+export interface CompanyCount {
+    name: string;
+    count: number;
+}
+
+export type SolvedState = 'any' | 'solved' | 'unsolved';
+
+export interface ProblemCriteria {
+    difficulties?: Difficulty[];
+    topics?: string[];
+    companies?: string[];
+    solved_state?: SolvedState;
+}
+
+export interface SavedFilter {
+    id: string;
+    user_id: string;
+    name: string;
+    difficulties: Difficulty[];
+    topics: string[];
+    companies: string[];
+    solved_state: SolvedState;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface SavedFilterRequest extends ProblemCriteria {
+    name: string;
+}
+
+export interface ProblemListQuery {
+    filter_id?: string;
+    difficulty?: Difficulty[];
+    topic?: string[];
+    company?: string[];
+    solved?: SolvedState;
+}
+
+export interface ProblemPrerequisites {
+    problem_id: string;
+    prerequisites: Problem[];
+    count: number;
+}
+
+export interface RoadmapProblem extends Problem {
+    position: number;
+    solved?: boolean;
+}
+
+export interface RoadmapCategory {
+    id: string;
+    name: string;
+    position: number;
+    problems: RoadmapProblem[];
+    total: number;
+    completed?: number;
+}
+
+export interface Roadmap {
+    categories: RoadmapCategory[];
+    total: number;
+    completed?: number;
 }
 
 export interface ProblemStats {
@@ -69,7 +207,10 @@ export interface ProblemStats {
 }
 
 // Contest types
-export type ContestStatus = 'active' | 'completed' | 'abandoned';
+export type ContestStatus = 'pending' | 'active' | 'completed' | 'abandoned';
+export type ContestOrdering = 'ascending' | 'descending' | 'shuffled' | 'interleaved' | 'roadmap' | 'assigned';
+export type ContestSource = 'random' | 'roadmap';
+export type SelectionWeighting = 'uniform' | 'importance';
 
 export interface Contest {
     id: string;
@@ -77,25 +218,174 @@ export interface Contest {
     started_at: string;
     ended_at: string | null;
     status: ContestStatus;
+    ordering: ContestOrdering;
     problems: ContestProblem[];
     time_remaining_seconds: number;
+    warning?: ContestWarning;
+    warmup?: ContestWarmup;
+    tags: string[];
+    retro: string;
+    retro_updated_at: string | null;
+    complexity_score?: ComplexityScore;
+}
+
+export interface Challenge {
+    code: string;
+    contest_id: string;
+    challenger_id: string;
+    challenger_username: string;
+    problem_count: number;
+    duration_minutes: number;
+    accepted: boolean;
+    silent_mode: boolean;
+    accepted_at?: string;
+    expires_at: string;
+}
+
+export interface ChallengeResult {
+    user_id: string;
+    username: string;
+    contest_id: string;
+    status: ContestStatus;
+    solved: number;
+    elapsed_seconds: number;
+}
+
+export interface ChallengeComparison {
+    code: string;
+    challenger: ChallengeResult;
+    opponent: ChallengeResult;
+    problems: {
+        problem: Problem;
+        challenger_solved: boolean;
+        opponent_solved: boolean;
+    }[];
+    winner_id: string | null;
+}
+
+export type PresenceStatus = 'offline' | 'online' | 'in_contest';
+
+export interface ChatMessage {
+    id: string;
+    user_id: string;
+    username: string;
+    body: string;
+    created_at: string;
+}
+
+// A page of a challenge chat; poll with `since` set to the latest created_at
+export interface ChatMessages {
+    messages: ChatMessage[];
+    silenced: boolean;
+}
+
+export interface SpectatorInvite {
+    spectator_code: string;
+    anonymize: boolean;
+}
+
+// Read-only challenge standings shown to spectators
+export interface SpectatorView {
+    anonymized: boolean;
+    participants: {
+        name: string;
+        status: ContestStatus;
+        solved: number;
+        total: number;
+        presence: PresenceStatus;
+    }[];
+}
+
+export interface Presence {
+    status: PresenceStatus;
+    contest_id?: string;
+    last_seen_at?: string;
+}
+
+// Live standings of a running challenge
+export interface ChallengeStandings {
+    code: string;
+    participants: {
+        user_id: string;
+        username: string;
+        contest_id: string;
+        status: ContestStatus;
+        solved: number;
+        total: number;
+        presence: Presence;
+    }[];
+}
+
+export interface TagCount {
+    tag: string;
+    count: number;
+}
+
+export interface ContestWarmup {
+    problem: Problem;
+    is_completed: boolean;
+    ends_at: string;
+    time_remaining_seconds: number;
+}
+
+export interface ContestWarning {
+    code: 'DIFFICULTY_MIX_ADJUSTED' | 'NOT_ENOUGH_PROBLEMS';
+    message: string;
+    requested: Partial<Record<Difficulty, number>>;
+    delivered: Partial<Record<Difficulty, number>>;
 }
 
 export interface ContestProblem {
     order: number;
     is_completed: boolean;
+    is_warmup: boolean;
     problem: Problem;
+    complexity?: ComplexityResult;
+    time_spent_seconds: number;
+    timer_running: boolean;
+}
+
+// Complexity stated after solving; graded once the contest is over
+export interface ComplexityResult {
+    time_complexity: string;
+    space_complexity: string;
+    expected_time_complexity?: string;
+    expected_space_complexity?: string;
+    time_correct: boolean | null;
+    space_correct: boolean | null;
+}
+
+export interface ComplexityScore {
+    answered: number;
+    graded: number;
+    correct: number;
 }
 
 export interface CreateContestRequest {
     problem_count: number;
     duration_minutes: number;
+    ordering?: ContestOrdering;
+    warmup_minutes?: number;
+    solved_warmup?: boolean;
+    tags?: string[];
+    respect_prerequisites?: boolean;
+    source?: ContestSource;
+    companies?: string[];
+    difficulties?: Difficulty[];
+    weighting?: SelectionWeighting;
+    include_custom?: boolean;
 }
 
 // API response types
 export interface ApiError {
-    error: string;
-    details?: string;
+    error: {
+        code: string;
+        message: string;
+        details?: unknown;
+        request_id?: string;
+        retry?: 'safe' | 'idempotent' | 'never';
+        retry_after_seconds?: number;
+    };
 }
 
 export interface ProblemsResponse {
@@ -106,3 +396,18 @@ export interface ProblemsResponse {
 export interface ContestsResponse {
     contests: Contest[];
 }
+
+// Feature flags that are on for the current user, keyed by flag
+export interface FeaturesResponse {
+    features: Record<string, boolean>;
+}
+
+// Ongoing or upcoming maintenance; writes fail with 503 while it is active
+export interface MaintenanceStatus {
+    active: boolean;
+    upcoming: boolean;
+    message: string;
+    starts_at: string | null;
+    ends_at: string | null;
+    retry_after_seconds: number;
+}